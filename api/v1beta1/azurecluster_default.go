@@ -39,12 +39,30 @@ const (
 	DefaultAzureBastionSubnetName = "AzureBastionSubnet"
 	// DefaultAzureBastionSubnetRole is the default Subnet role for AzureBastion.
 	DefaultAzureBastionSubnetRole = SubnetBastion
+	// DefaultVirtualNetworkGatewaySubnetCIDR is the default Subnet CIDR for virtual network gateways.
+	DefaultVirtualNetworkGatewaySubnetCIDR = "10.255.255.192/27"
+	// VirtualNetworkGatewaySubnetName is the name Azure requires for the subnet of virtual network gateways.
+	VirtualNetworkGatewaySubnetName = "GatewaySubnet"
+	// DefaultVPNGatewaySKU is the default SKU for VPN gateways.
+	DefaultVPNGatewaySKU = "VpnGw1AZ"
+	// DefaultExpressRouteGatewaySKU is the default SKU for ExpressRoute gateways.
+	DefaultExpressRouteGatewaySKU = "ErGw1AZ"
+	// DefaultRouteServerSubnetCIDR is the default Subnet CIDR for Route Servers.
+	DefaultRouteServerSubnetCIDR = "10.255.255.160/27"
+	// RouteServerSubnetName is the name Azure requires for the subnet of Route Servers.
+	RouteServerSubnetName = "RouteServerSubnet"
 	// DefaultInternalLBIPAddress is the default internal load balancer ip address.
 	DefaultInternalLBIPAddress = "10.0.0.100"
 	// DefaultOutboundRuleIdleTimeoutInMinutes is the default for IdleTimeoutInMinutes for the load balancer.
 	DefaultOutboundRuleIdleTimeoutInMinutes = 4
 	// DefaultAzureCloud is the public cloud that will be used by most users.
 	DefaultAzureCloud = "AzurePublicCloud"
+	// DefaultNetworkWatcherResourceGroup is the resource group in which Azure creates Network Watchers.
+	DefaultNetworkWatcherResourceGroup = "NetworkWatcherRG"
+	// DefaultTrafficAnalyticsIntervalInMinutes is the default traffic analytics processing interval.
+	DefaultTrafficAnalyticsIntervalInMinutes = 60
+	// DefaultAPIServerDNSTTL is the default time to live in seconds of the API server DNS record.
+	DefaultAPIServerDNSTTL = 300
 )
 
 func (c *AzureCluster) setDefaults() {
@@ -61,6 +79,45 @@ func (c *AzureCluster) setNetworkSpecDefaults() {
 	c.setAPIServerLBDefaults()
 	c.SetNodeOutboundLBDefaults()
 	c.SetControlPlaneOutboundLBDefaults()
+	c.setFlowLogsDefaults()
+	c.setAPIServerDNSDefaults()
+	c.setVirtualNetworkGatewayDefaults()
+	c.setRouteServerDefaults()
+	c.setAPIServerPrivateLinkServiceDefaults()
+}
+
+func (c *AzureCluster) setAPIServerDNSDefaults() {
+	apiServerDNS := c.Spec.NetworkSpec.APIServerDNS
+	if apiServerDNS == nil {
+		return
+	}
+	if apiServerDNS.TTL == nil {
+		apiServerDNS.TTL = ptr.To[int32](DefaultAPIServerDNSTTL)
+	}
+	if c.Spec.ControlPlaneEndpoint.Host == "" {
+		c.Spec.ControlPlaneEndpoint.Host = apiServerDNS.Hostname
+	}
+}
+
+func (c *AzureCluster) setFlowLogsDefaults() {
+	flowLogs := c.Spec.NetworkSpec.FlowLogs
+	if flowLogs == nil {
+		return
+	}
+	if flowLogs.NetworkWatcherName == "" {
+		flowLogs.NetworkWatcherName = generateNetworkWatcherName(c.Spec.Location)
+	}
+	if flowLogs.NetworkWatcherResourceGroup == "" {
+		flowLogs.NetworkWatcherResourceGroup = DefaultNetworkWatcherResourceGroup
+	}
+	if flowLogs.TrafficAnalytics != nil {
+		if flowLogs.TrafficAnalytics.WorkspaceRegion == "" {
+			flowLogs.TrafficAnalytics.WorkspaceRegion = c.Spec.Location
+		}
+		if flowLogs.TrafficAnalytics.IntervalInMinutes == nil {
+			flowLogs.TrafficAnalytics.IntervalInMinutes = ptr.To[int32](DefaultTrafficAnalyticsIntervalInMinutes)
+		}
+	}
 }
 
 func (c *AzureCluster) setResourceGroupDefault() {
@@ -216,21 +273,21 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 
 	if lb.Type == Public {
 		if lb.Name == "" {
-			lb.Name = generatePublicLBName(c.ObjectMeta.Name)
+			lb.Name = c.Spec.ResourceNaming.Apply(generatePublicLBName(c.ObjectMeta.Name))
 		}
 		if len(lb.FrontendIPs) == 0 {
 			lb.FrontendIPs = []FrontendIP{
 				{
 					Name: generateFrontendIPConfigName(lb.Name),
 					PublicIP: &PublicIPSpec{
-						Name: generatePublicIPName(c.ObjectMeta.Name),
+						Name: c.Spec.ResourceNaming.Apply(generatePublicIPName(c.ObjectMeta.Name)),
 					},
 				},
 			}
 		}
 	} else if lb.Type == Internal {
 		if lb.Name == "" {
-			lb.Name = generateInternalLBName(c.ObjectMeta.Name)
+			lb.Name = c.Spec.ResourceNaming.Apply(generateInternalLBName(c.ObjectMeta.Name))
 		}
 		if len(lb.FrontendIPs) == 0 {
 			lb.FrontendIPs = []FrontendIP{
@@ -275,7 +332,7 @@ func (c *AzureCluster) SetNodeOutboundLBDefaults() {
 	lb.LoadBalancerClassSpec.setNodeOutboundLBDefaults()
 
 	if lb.Name == "" {
-		lb.Name = c.ObjectMeta.Name
+		lb.Name = c.Spec.ResourceNaming.Apply(c.ObjectMeta.Name)
 	}
 
 	if lb.FrontendIPsCount == nil {
@@ -296,7 +353,7 @@ func (c *AzureCluster) SetControlPlaneOutboundLBDefaults() {
 
 	lb.LoadBalancerClassSpec.setControlPlaneOutboundLBDefaults()
 	if lb.Name == "" {
-		lb.Name = generateControlPlaneOutboundLBName(c.ObjectMeta.Name)
+		lb.Name = c.Spec.ResourceNaming.Apply(generateControlPlaneOutboundLBName(c.ObjectMeta.Name))
 	}
 	if lb.FrontendIPsCount == nil {
 		lb.FrontendIPsCount = ptr.To[int32](1)
@@ -347,7 +404,7 @@ func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePu
 			{
 				Name: generateFrontendIPConfigName(lb.Name),
 				PublicIP: &PublicIPSpec{
-					Name: generatePublicIPName(c.ObjectMeta.Name),
+					Name: c.Spec.ResourceNaming.Apply(generatePublicIPName(c.ObjectMeta.Name)),
 				},
 			},
 		}
@@ -357,7 +414,7 @@ func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePu
 			lb.FrontendIPs[i] = FrontendIP{
 				Name: withIndex(generateFrontendIPConfigName(lb.Name), i+1),
 				PublicIP: &PublicIPSpec{
-					Name: withIndex(generatePublicIPName(c.ObjectMeta.Name), i+1),
+					Name: c.Spec.ResourceNaming.Apply(withIndex(generatePublicIPName(c.ObjectMeta.Name), i+1)),
 				},
 			}
 		}
@@ -453,11 +510,105 @@ func generateNodeSubnetName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "node-subnet")
 }
 
+func (c *AzureCluster) setVirtualNetworkGatewayDefaults() {
+	gateway := c.Spec.NetworkSpec.VirtualNetworkGateway
+	if gateway == nil {
+		return
+	}
+	if gateway.Name == "" {
+		gateway.Name = generateVirtualNetworkGatewayName(c.ObjectMeta.Name)
+	}
+	if gateway.SKU == "" {
+		if gateway.Type == VirtualNetworkGatewayTypeExpressRoute {
+			gateway.SKU = DefaultExpressRouteGatewaySKU
+		} else {
+			gateway.SKU = DefaultVPNGatewaySKU
+		}
+	}
+	if gateway.VPNType == "" && gateway.Type == VirtualNetworkGatewayTypeVPN {
+		gateway.VPNType = VPNTypeRouteBased
+	}
+	if gateway.Subnet.Name == "" {
+		gateway.Subnet.Name = VirtualNetworkGatewaySubnetName
+	}
+	if len(gateway.Subnet.CIDRBlocks) == 0 {
+		gateway.Subnet.CIDRBlocks = []string{DefaultVirtualNetworkGatewaySubnetCIDR}
+	}
+	if gateway.Subnet.Role == "" {
+		gateway.Subnet.Role = SubnetGateway
+	}
+	if gateway.PublicIP.Name == "" {
+		gateway.PublicIP.Name = generateVirtualNetworkGatewayPublicIPName(c.ObjectMeta.Name)
+	}
+}
+
+func (c *AzureCluster) setRouteServerDefaults() {
+	routeServer := c.Spec.NetworkSpec.RouteServer
+	if routeServer == nil {
+		return
+	}
+	if routeServer.Name == "" {
+		routeServer.Name = generateRouteServerName(c.ObjectMeta.Name)
+	}
+	if routeServer.Subnet.Name == "" {
+		routeServer.Subnet.Name = RouteServerSubnetName
+	}
+	if len(routeServer.Subnet.CIDRBlocks) == 0 {
+		routeServer.Subnet.CIDRBlocks = []string{DefaultRouteServerSubnetCIDR}
+	}
+	if routeServer.Subnet.Role == "" {
+		routeServer.Subnet.Role = SubnetRouteServer
+	}
+	if routeServer.PublicIP.Name == "" {
+		routeServer.PublicIP.Name = generateRouteServerPublicIPName(c.ObjectMeta.Name)
+	}
+}
+
+func (c *AzureCluster) setAPIServerPrivateLinkServiceDefaults() {
+	pls := c.Spec.NetworkSpec.APIServerPrivateLinkService
+	if pls == nil {
+		return
+	}
+	if pls.Name == "" {
+		pls.Name = generateAPIServerPrivateLinkServiceName(c.ObjectMeta.Name)
+	}
+	if pls.SubnetName == "" {
+		if subnet, err := c.Spec.NetworkSpec.GetControlPlaneSubnet(); err == nil {
+			pls.SubnetName = subnet.Name
+		}
+	}
+}
+
 // generateAzureBastionName generates an azure bastion name.
 func generateAzureBastionName(clusterName string) string {
 	return fmt.Sprintf("%s-azure-bastion", clusterName)
 }
 
+// generateVirtualNetworkGatewayName generates a virtual network gateway name.
+func generateVirtualNetworkGatewayName(clusterName string) string {
+	return fmt.Sprintf("%s-vnet-gateway", clusterName)
+}
+
+// generateVirtualNetworkGatewayPublicIPName generates a virtual network gateway public ip name.
+func generateVirtualNetworkGatewayPublicIPName(clusterName string) string {
+	return fmt.Sprintf("%s-vnet-gateway-pip", clusterName)
+}
+
+// generateRouteServerName generates a Route Server name.
+func generateRouteServerName(clusterName string) string {
+	return fmt.Sprintf("%s-routeserver", clusterName)
+}
+
+// generateRouteServerPublicIPName generates a Route Server public ip name.
+func generateRouteServerPublicIPName(clusterName string) string {
+	return fmt.Sprintf("%s-routeserver-pip", clusterName)
+}
+
+// generateAPIServerPrivateLinkServiceName generates an API server private link service name.
+func generateAPIServerPrivateLinkServiceName(clusterName string) string {
+	return fmt.Sprintf("%s-apiserver-pls", clusterName)
+}
+
 // generateAzureBastionPublicIPName generates an azure bastion public ip name.
 func generateAzureBastionPublicIPName(clusterName string) string {
 	return fmt.Sprintf("%s-azure-bastion-pip", clusterName)
@@ -552,3 +703,8 @@ func generateBackendAddressPoolName(lbName string) string {
 func generateOutboundBackendAddressPoolName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "outboundBackendPool")
 }
+
+// generateNetworkWatcherName generates the name of the Network Watcher Azure creates for a location.
+func generateNetworkWatcherName(location string) string {
+	return fmt.Sprintf("NetworkWatcher_%s", location)
+}
@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestResourceGroupDefault(t *testing.T) {
@@ -1251,6 +1252,52 @@ func TestAPIServerLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no lb with resource naming",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						ResourceNaming: &ResourceNamingSpec{Prefix: "corp-", Suffix: "-01"},
+					},
+					NetworkSpec: NetworkSpec{},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						ResourceNaming: &ResourceNamingSpec{Prefix: "corp-", Suffix: "-01"},
+					},
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							Name: "corp-cluster-test-public-lb-01",
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "corp-cluster-test-public-lb-01-frontEnd",
+									PublicIP: &PublicIPSpec{
+										Name:    "corp-pip-cluster-test-apiserver-01",
+										DNSName: "",
+									},
+								},
+							},
+							BackendPool: BackendPool{
+								Name: "corp-cluster-test-public-lb-01-backendPool",
+							},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Public,
+								IdleTimeoutInMinutes: ptr.To[int32](DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "internal lb",
 			cluster: &AzureCluster{
@@ -2520,3 +2567,470 @@ func TestBastionDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestFlowLogsDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no flow logs": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"flow logs with default network watcher": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus2",
+					},
+					NetworkSpec: NetworkSpec{
+						FlowLogs: &FlowLogsSpec{
+							StorageAccountID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+							TrafficAnalytics: &TrafficAnalyticsSpec{
+								WorkspaceResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+								WorkspaceID:         "00000000-0000-0000-0000-000000000000",
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus2",
+					},
+					NetworkSpec: NetworkSpec{
+						FlowLogs: &FlowLogsSpec{
+							StorageAccountID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+							NetworkWatcherName:          "NetworkWatcher_westus2",
+							NetworkWatcherResourceGroup: DefaultNetworkWatcherResourceGroup,
+							TrafficAnalytics: &TrafficAnalyticsSpec{
+								WorkspaceResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+								WorkspaceID:         "00000000-0000-0000-0000-000000000000",
+								WorkspaceRegion:     "westus2",
+								IntervalInMinutes:   ptr.To[int32](DefaultTrafficAnalyticsIntervalInMinutes),
+							},
+						},
+					},
+				},
+			},
+		},
+		"flow logs with custom network watcher": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus2",
+					},
+					NetworkSpec: NetworkSpec{
+						FlowLogs: &FlowLogsSpec{
+							StorageAccountID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+							NetworkWatcherName:          "my-network-watcher",
+							NetworkWatcherResourceGroup: "my-network-watcher-rg",
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus2",
+					},
+					NetworkSpec: NetworkSpec{
+						FlowLogs: &FlowLogsSpec{
+							StorageAccountID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+							NetworkWatcherName:          "my-network-watcher",
+							NetworkWatcherResourceGroup: "my-network-watcher-rg",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setFlowLogsDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestAPIServerDNSDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no API server DNS": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"API server DNS sets TTL and control plane endpoint host": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerDNS: &APIServerDNSSpec{
+							Hostname:  "api.foo.example.com",
+							DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{
+						Host: "api.foo.example.com",
+					},
+					NetworkSpec: NetworkSpec{
+						APIServerDNS: &APIServerDNSSpec{
+							Hostname:  "api.foo.example.com",
+							DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+							TTL:       ptr.To[int32](DefaultAPIServerDNSTTL),
+						},
+					},
+				},
+			},
+		},
+		"API server DNS keeps TTL and control plane endpoint host": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{
+						Host: "api.foo.example.com",
+						Port: 443,
+					},
+					NetworkSpec: NetworkSpec{
+						APIServerDNS: &APIServerDNSSpec{
+							Hostname:  "api.foo.example.com",
+							DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+							TTL:       ptr.To[int32](60),
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{
+						Host: "api.foo.example.com",
+						Port: 443,
+					},
+					NetworkSpec: NetworkSpec{
+						APIServerDNS: &APIServerDNSSpec{
+							Hostname:  "api.foo.example.com",
+							DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+							TTL:       ptr.To[int32](60),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setAPIServerDNSDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkGatewayDefaults(t *testing.T) {
+	cases := map[string]struct {
+		gateway *VirtualNetworkGatewaySpec
+		output  *VirtualNetworkGatewaySpec
+	}{
+		"no virtual network gateway": {
+			gateway: nil,
+			output:  nil,
+		},
+		"vpn gateway": {
+			gateway: &VirtualNetworkGatewaySpec{
+				Type: VirtualNetworkGatewayTypeVPN,
+			},
+			output: &VirtualNetworkGatewaySpec{
+				Name:    "foo-vnet-gateway",
+				Type:    VirtualNetworkGatewayTypeVPN,
+				SKU:     DefaultVPNGatewaySKU,
+				VPNType: VPNTypeRouteBased,
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       VirtualNetworkGatewaySubnetName,
+						CIDRBlocks: []string{DefaultVirtualNetworkGatewaySubnetCIDR},
+						Role:       SubnetGateway,
+					},
+				},
+				PublicIP: PublicIPSpec{
+					Name: "foo-vnet-gateway-pip",
+				},
+			},
+		},
+		"expressroute gateway": {
+			gateway: &VirtualNetworkGatewaySpec{
+				Type: VirtualNetworkGatewayTypeExpressRoute,
+			},
+			output: &VirtualNetworkGatewaySpec{
+				Name: "foo-vnet-gateway",
+				Type: VirtualNetworkGatewayTypeExpressRoute,
+				SKU:  DefaultExpressRouteGatewaySKU,
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       VirtualNetworkGatewaySubnetName,
+						CIDRBlocks: []string{DefaultVirtualNetworkGatewaySubnetCIDR},
+						Role:       SubnetGateway,
+					},
+				},
+				PublicIP: PublicIPSpec{
+					Name: "foo-vnet-gateway-pip",
+				},
+			},
+		},
+		"user-specified values are kept": {
+			gateway: &VirtualNetworkGatewaySpec{
+				Name:    "my-gateway",
+				Type:    VirtualNetworkGatewayTypeVPN,
+				SKU:     "VpnGw2AZ",
+				VPNType: VPNTypePolicyBased,
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						CIDRBlocks: []string{"10.0.255.0/27"},
+					},
+				},
+				PublicIP: PublicIPSpec{
+					Name: "my-gateway-pip",
+				},
+			},
+			output: &VirtualNetworkGatewaySpec{
+				Name:    "my-gateway",
+				Type:    VirtualNetworkGatewayTypeVPN,
+				SKU:     "VpnGw2AZ",
+				VPNType: VPNTypePolicyBased,
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       VirtualNetworkGatewaySubnetName,
+						CIDRBlocks: []string{"10.0.255.0/27"},
+						Role:       SubnetGateway,
+					},
+				},
+				PublicIP: PublicIPSpec{
+					Name: "my-gateway-pip",
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VirtualNetworkGateway: c.gateway,
+					},
+				},
+			}
+			cluster.setVirtualNetworkGatewayDefaults()
+			if !reflect.DeepEqual(cluster.Spec.NetworkSpec.VirtualNetworkGateway, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(cluster.Spec.NetworkSpec.VirtualNetworkGateway, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestRouteServerDefaults(t *testing.T) {
+	cases := map[string]struct {
+		routeServer *RouteServerSpec
+		output      *RouteServerSpec
+	}{
+		"no route server": {
+			routeServer: nil,
+			output:      nil,
+		},
+		"route server": {
+			routeServer: &RouteServerSpec{
+				BGPPeers: []BGPPeer{{Name: "nva", PeerIP: "10.0.0.4", PeerASN: 65001}},
+			},
+			output: &RouteServerSpec{
+				Name: "foo-routeserver",
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       RouteServerSubnetName,
+						CIDRBlocks: []string{DefaultRouteServerSubnetCIDR},
+						Role:       SubnetRouteServer,
+					},
+				},
+				PublicIP: PublicIPSpec{
+					Name: "foo-routeserver-pip",
+				},
+				BGPPeers: []BGPPeer{{Name: "nva", PeerIP: "10.0.0.4", PeerASN: 65001}},
+			},
+		},
+		"user-specified values are kept": {
+			routeServer: &RouteServerSpec{
+				Name: "my-route-server",
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						CIDRBlocks: []string{"10.0.254.0/26"},
+					},
+				},
+				PublicIP: PublicIPSpec{
+					Name: "my-route-server-pip",
+				},
+			},
+			output: &RouteServerSpec{
+				Name: "my-route-server",
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       RouteServerSubnetName,
+						CIDRBlocks: []string{"10.0.254.0/26"},
+						Role:       SubnetRouteServer,
+					},
+				},
+				PublicIP: PublicIPSpec{
+					Name: "my-route-server-pip",
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						RouteServer: c.routeServer,
+					},
+				},
+			}
+			cluster.setRouteServerDefaults()
+			if !reflect.DeepEqual(cluster.Spec.NetworkSpec.RouteServer, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(cluster.Spec.NetworkSpec.RouteServer, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestAPIServerPrivateLinkServiceDefaults(t *testing.T) {
+	cases := map[string]struct {
+		pls    *PrivateLinkServiceSpec
+		output *PrivateLinkServiceSpec
+	}{
+		"no private link service": {
+			pls:    nil,
+			output: nil,
+		},
+		"private link service": {
+			pls: &PrivateLinkServiceSpec{
+				AllowedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+			output: &PrivateLinkServiceSpec{
+				Name:                 "foo-apiserver-pls",
+				SubnetName:           "foo-controlplane-subnet",
+				AllowedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+		},
+		"user-specified values are kept": {
+			pls: &PrivateLinkServiceSpec{
+				Name:       "my-pls",
+				SubnetName: "foo-node-subnet",
+			},
+			output: &PrivateLinkServiceSpec{
+				Name:       "my-pls",
+				SubnetName: "foo-node-subnet",
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{SubnetClassSpec: SubnetClassSpec{Name: "foo-controlplane-subnet", Role: SubnetControlPlane}},
+							{SubnetClassSpec: SubnetClassSpec{Name: "foo-node-subnet", Role: SubnetNode}},
+						},
+						APIServerPrivateLinkService: c.pls,
+					},
+				},
+			}
+			cluster.setAPIServerPrivateLinkServiceDefaults()
+			if !reflect.DeepEqual(cluster.Spec.NetworkSpec.APIServerPrivateLinkService, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(cluster.Spec.NetworkSpec.APIServerPrivateLinkService, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
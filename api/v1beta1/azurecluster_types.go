@@ -41,6 +41,13 @@ type AzureClusterSpec struct {
 	// +optional
 	ResourceGroup string `json:"resourceGroup,omitempty"`
 
+	// NodeResourceGroup is the name of the resource group of the compute resources of the cluster: virtual machines,
+	// scale sets and their network interfaces, disks and public IPs. The load balancers and public IPs of the cluster
+	// remain in ResourceGroup, and the virtual network in the resource group of the vnet. Defaults to ResourceGroup.
+	// CAPZ creates the resource group and deletes it along with the cluster unless it already exists. Immutable.
+	// +optional
+	NodeResourceGroup string `json:"nodeResourceGroup,omitempty"`
+
 	// BastionSpec encapsulates all things related to the Bastions in the cluster.
 	// +optional
 	BastionSpec BastionSpec `json:"bastionSpec,omitempty"`
@@ -49,6 +56,13 @@ type AzureClusterSpec struct {
 	// this when creating an AzureCluster as CAPZ will set this for you. However, if it is set, CAPZ will not change it.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+
+	// ExternallyManagedControlPlaneEndpoint indicates that the control plane endpoint is served by infrastructure
+	// managed outside of CAPZ, such as a customer-managed load balancer or proxy. When true, ControlPlaneEndpoint must
+	// be set, and CAPZ neither creates the API server load balancer and its public IP nor adds control plane machines
+	// to it. Immutable.
+	// +optional
+	ExternallyManagedControlPlaneEndpoint bool `json:"externallyManagedControlPlaneEndpoint,omitempty"`
 }
 
 // AzureClusterStatus defines the observed state of AzureCluster.
@@ -74,6 +88,20 @@ type AzureClusterStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// Resources reports whether the resource groups and virtual network of the cluster are managed by CAPZ.
+	// +optional
+	Resources []ResourceOwnership `json:"resources,omitempty"`
+
+	// ResourceIDs are the Azure Resource Manager IDs of the virtual network, subnets, network security groups, route
+	// tables, load balancers and public IPs of the cluster, recorded once they are all reconciled.
+	// +optional
+	ResourceIDs []AzureResourceID `json:"resourceIDs,omitempty"`
+
+	// APIServerPrivateLinkServiceAlias is the alias of the Private Link Service of the API server, which private
+	// endpoints in other virtual networks or tenants use to connect to it.
+	// +optional
+	APIServerPrivateLinkServiceAlias string `json:"apiServerPrivateLinkServiceAlias,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -21,14 +21,18 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"slices"
+	"strings"
 
 	valid "github.com/asaskevich/govalidator"
+	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -44,6 +48,8 @@ const (
 	// described in https://learn.microsoft.com/azure/azure-resource-manager/management/resource-name-rules.
 	subnetRegex       = `^[-\w\._]+$`
 	loadBalancerRegex = `^[-\w\._]+$`
+	// application security group names start with an alphanumeric character and end with an alphanumeric character or an underscore.
+	applicationSecurityGroupRegex = `^[a-zA-Z0-9]([-\w\.]{0,78}[\w])?$`
 	// MaxLoadBalancerOutboundIPs is the maximum number of outbound IPs in a Standard LoadBalancer frontend configuration.
 	MaxLoadBalancerOutboundIPs = 16
 	// MinLBIdleTimeoutInMinutes is the minimum number of minutes for the LB idle timeout.
@@ -56,17 +62,28 @@ const (
 	maxRulePriority = 4096
 	// Must start with 'Microsoft.', then an alpha character, then can include alnum.
 	serviceEndpointServiceRegexPattern = `^Microsoft\.[a-zA-Z]{1,42}[a-zA-Z0-9]{0,42}$`
+	// delegationServiceNameRegexPattern matches resource types such as Microsoft.NetApp/volumes or Microsoft.Web/serverFarms.
+	delegationServiceNameRegexPattern = `^[a-zA-Z0-9]+(\.[a-zA-Z0-9]+)+(/[a-zA-Z0-9]+)+$`
 	// Must start with an alpha character and then can include alnum OR be only *.
 	serviceEndpointLocationRegexPattern = `^([a-z]{1,42}\d{0,5}|[*])$`
 	// described in https://learn.microsoft.com/azure/azure-resource-manager/management/resource-name-rules.
 	privateEndpointRegex = `^[-\w\._]+$`
 	// resource ID Pattern.
 	resourceIDPattern = `(?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)`
+	// availability zones are numbered, e.g. 1, 2 or 3.
+	availabilityZoneRegex = `^[1-9][0-9]*$`
+	// DNS zone resource ID Pattern, capturing the zone name.
+	dnsZoneIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/dnszones/([^/]+)$`
+	// resource name prefixes and suffixes may only contain characters that are valid in all the resource names they apply to.
+	resourceNameAffixPattern = `^[-\w\.]+$`
 )
 
 var (
 	serviceEndpointServiceRegex  = regexp.MustCompile(serviceEndpointServiceRegexPattern)
 	serviceEndpointLocationRegex = regexp.MustCompile(serviceEndpointLocationRegexPattern)
+	delegationServiceNameRegex   = regexp.MustCompile(delegationServiceNameRegexPattern)
+	dnsZoneIDRegex               = regexp.MustCompile(dnsZoneIDPattern)
+	resourceNameAffixRegex       = regexp.MustCompile(resourceNameAffixPattern)
 )
 
 // validateCluster validates a cluster.
@@ -104,6 +121,44 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "extendedLocation"), "can be set only if the EdgeZone feature flag is enabled"))
 	}
 
+	if c.Spec.NetworkSpec.APIServerDNS != nil {
+		allErrs = append(allErrs, validateAPIServerDNS(*c.Spec.NetworkSpec.APIServerDNS, c.Spec.ControlPlaneEndpoint.Host,
+			field.NewPath("spec").Child("networkSpec").Child("apiServerDNS"))...)
+	}
+
+	if c.Spec.NetworkSpec.VirtualNetworkGateway != nil {
+		allErrs = append(allErrs, validateVirtualNetworkGateway(*c.Spec.NetworkSpec.VirtualNetworkGateway,
+			field.NewPath("spec").Child("networkSpec").Child("virtualNetworkGateway"))...)
+	}
+
+	if c.Spec.NetworkSpec.RouteServer != nil {
+		allErrs = append(allErrs, validateRouteServer(*c.Spec.NetworkSpec.RouteServer,
+			field.NewPath("spec").Child("networkSpec").Child("routeServer"))...)
+	}
+
+	if c.Spec.NetworkSpec.APIServerPrivateLinkService != nil {
+		allErrs = append(allErrs, validateAPIServerPrivateLinkService(*c.Spec.NetworkSpec.APIServerPrivateLinkService, c.Spec.NetworkSpec,
+			field.NewPath("spec").Child("networkSpec").Child("apiServerPrivateLinkService"))...)
+	}
+
+	allErrs = append(allErrs, validateExternallyManagedControlPlaneEndpoint(c.Spec, field.NewPath("spec"))...)
+
+	if c.Spec.NodeResourceGroup != "" {
+		if err := validateResourceGroup(c.Spec.NodeResourceGroup, field.NewPath("spec", "nodeResourceGroup")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	allErrs = append(allErrs, validateResourceNaming(c.Spec.ResourceNaming, field.NewPath("spec", "resourceNaming"))...)
+
+	var oldAdditionalTags Tags
+	if old != nil {
+		oldAdditionalTags = old.Spec.AdditionalTags
+	}
+	allErrs = append(allErrs, validateRequiredTags(c.Spec.AdditionalTags, oldAdditionalTags, old != nil, field.NewPath("spec", "additionalTags"))...)
+
+	allErrs = append(allErrs, validatePublicIPResourceGroups(c.Spec)...)
+
 	if err := validateBastionSpec(c.Spec.BastionSpec, field.NewPath("spec").Child("azureBastion").Child("bastionSpec")); err != nil {
 		allErrs = append(allErrs, err)
 	}
@@ -115,6 +170,34 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	return allErrs
 }
 
+// validateExternallyManagedControlPlaneEndpoint validates that an externally managed control plane endpoint is set,
+// and that it isn't combined with an API server DNS record, which would point at the API server load balancer.
+func validateExternallyManagedControlPlaneEndpoint(spec AzureClusterSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !spec.ExternallyManagedControlPlaneEndpoint {
+		return allErrs
+	}
+
+	if spec.ControlPlaneEndpoint.Host == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("controlPlaneEndpoint", "host"),
+			"host is required when the control plane endpoint is externally managed"))
+	}
+	if spec.ControlPlaneEndpoint.Port == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("controlPlaneEndpoint", "port"),
+			"port is required when the control plane endpoint is externally managed"))
+	}
+	if spec.NetworkSpec.APIServerDNS != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("networkSpec", "apiServerDNS"),
+			"apiServerDNS cannot be set when the control plane endpoint is externally managed"))
+	}
+	if spec.NetworkSpec.APIServerPrivateLinkService != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("networkSpec", "apiServerPrivateLinkService"),
+			"apiServerPrivateLinkService cannot be set when the control plane endpoint is externally managed"))
+	}
+
+	return allErrs
+}
+
 // validateClusterName validates ClusterName.
 func (c *AzureCluster) validateClusterName() field.ErrorList {
 	var allErrs field.ErrorList
@@ -133,6 +216,122 @@ func (c *AzureCluster) validateClusterName() field.ErrorList {
 	return allErrs
 }
 
+// virtualNetworkGatewaySKUs are the SKUs supported by each type of virtual network gateway.
+var virtualNetworkGatewaySKUs = map[VirtualNetworkGatewayType][]string{
+	VirtualNetworkGatewayTypeVPN: {
+		"Basic", "VpnGw1", "VpnGw2", "VpnGw3", "VpnGw4", "VpnGw5",
+		"VpnGw1AZ", "VpnGw2AZ", "VpnGw3AZ", "VpnGw4AZ", "VpnGw5AZ",
+	},
+	VirtualNetworkGatewayTypeExpressRoute: {
+		"Standard", "HighPerformance", "UltraPerformance", "ErGw1AZ", "ErGw2AZ", "ErGw3AZ",
+	},
+}
+
+// validateVirtualNetworkGateway validates a VirtualNetworkGatewaySpec.
+func validateVirtualNetworkGateway(gateway VirtualNetworkGatewaySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if skus, ok := virtualNetworkGatewaySKUs[gateway.Type]; ok && !slices.Contains(skus, gateway.SKU) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sku"), gateway.SKU, skus))
+	}
+	if gateway.Type == VirtualNetworkGatewayTypeExpressRoute && gateway.VPNType != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("vpnType"), "vpnType may only be set for VPN gateways"))
+	}
+
+	if gateway.Subnet.Name != VirtualNetworkGatewaySubnetName {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "name"), gateway.Subnet.Name,
+			fmt.Sprintf("the subnet of a virtual network gateway must be named %s", VirtualNetworkGatewaySubnetName)))
+	}
+	for i, cidr := range gateway.Subnet.CIDRBlocks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr, "invalid CIDR format"))
+			continue
+		}
+		// Azure requires gateway subnets to have at least 8 addresses, and recommends /27 or larger.
+		if ones, bits := ipNet.Mask.Size(); bits == 32 && ones > 29 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr,
+				"the subnet of a virtual network gateway must be /29 or larger"))
+		}
+	}
+
+	return allErrs
+}
+
+// routeServerReservedASNs are the autonomous system numbers Azure reserves, which BGP peers of a Route Server may not use.
+var routeServerReservedASNs = []int64{8075, 8076, 12076, 65515, 65517, 65518, 65519, 65520}
+
+// validateRouteServer validates a RouteServerSpec.
+func validateRouteServer(routeServer RouteServerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if routeServer.Subnet.Name != RouteServerSubnetName {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "name"), routeServer.Subnet.Name,
+			fmt.Sprintf("the subnet of a Route Server must be named %s", RouteServerSubnetName)))
+	}
+	for i, cidr := range routeServer.Subnet.CIDRBlocks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr, "invalid CIDR format"))
+			continue
+		}
+		if ones, bits := ipNet.Mask.Size(); bits == 32 && ones > 27 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr,
+				"the subnet of a Route Server must be /27 or larger"))
+		}
+	}
+
+	peerNames := make(map[string]bool, len(routeServer.BGPPeers))
+	for i, peer := range routeServer.BGPPeers {
+		peerPath := fldPath.Child("bgpPeers").Index(i)
+		if peerNames[peer.Name] {
+			allErrs = append(allErrs, field.Duplicate(peerPath.Child("name"), peer.Name))
+		}
+		peerNames[peer.Name] = true
+		if ip := net.ParseIP(peer.PeerIP); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(peerPath.Child("peerIP"), peer.PeerIP, "peerIP must be a valid IPv4 address"))
+		}
+		if slices.Contains(routeServerReservedASNs, peer.PeerASN) {
+			allErrs = append(allErrs, field.Invalid(peerPath.Child("peerASN"), peer.PeerASN, "peerASN is reserved by Azure"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAPIServerPrivateLinkService validates the PrivateLinkServiceSpec of the API server load balancer.
+func validateAPIServerPrivateLinkService(pls PrivateLinkServiceSpec, networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Azure only supports Private Link Services in front of internal Standard load balancers.
+	if networkSpec.APIServerLB.Type != Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"a Private Link Service can only be created for an internal API server load balancer"))
+	}
+
+	if !slices.ContainsFunc(networkSpec.Subnets, func(subnet SubnetSpec) bool {
+		return subnet.Name == pls.SubnetName && (subnet.Role == SubnetControlPlane || subnet.Role == SubnetNode || subnet.Role == SubnetCluster)
+	}) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnetName"), pls.SubnetName,
+			"subnetName must be the name of a control plane, node or cluster subnet of the cluster"))
+	}
+
+	for i, subscription := range pls.AllowedSubscriptions {
+		if _, err := uuid.Parse(subscription); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedSubscriptions").Index(i), subscription,
+				"must be a valid subscription ID"))
+		}
+	}
+	for i, subscription := range pls.AutoApprovedSubscriptions {
+		if _, err := uuid.Parse(subscription); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("autoApprovedSubscriptions").Index(i), subscription,
+				"must be a valid subscription ID"))
+		}
+	}
+
+	return allErrs
+}
+
 // validateBastionSpec validates a BastionSpec.
 func validateBastionSpec(bastionSpec BastionSpec, fldPath *field.Path) *field.Error {
 	if bastionSpec.AzureBastion != nil && bastionSpec.AzureBastion.Sku != StandardBastionHostSku && bastionSpec.AzureBastion.EnableTunneling {
@@ -197,12 +396,48 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 
 	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec.PrivateDNSZoneName, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSZoneName"))...)
 
+	allErrs = append(allErrs, validateAPIServerAccessList(networkSpec.APIServerAccessList, controlPlaneSubnet, fldPath.Child("apiServerAccessList"))...)
+
+	allErrs = append(allErrs, validateApplicationSecurityGroups(networkSpec.ApplicationSecurityGroups, fldPath.Child("applicationSecurityGroups"))...)
+
+	if networkSpec.FlowLogs != nil {
+		allErrs = append(allErrs, validateFlowLogs(*networkSpec.FlowLogs, fldPath.Child("flowLogs"))...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs
 }
 
+// validateFlowLogs validates the flow logs configuration.
+func validateFlowLogs(flowLogs FlowLogsSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if success, _ := regexp.MatchString(resourceIDPattern, flowLogs.StorageAccountID); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("storageAccountID"), flowLogs.StorageAccountID,
+			fmt.Sprintf("storage account ID doesn't match regex %s", resourceIDPattern)))
+	}
+
+	if flowLogs.NetworkWatcherResourceGroup != "" {
+		if err := validateResourceGroup(flowLogs.NetworkWatcherResourceGroup, fldPath.Child("networkWatcherResourceGroup")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if ta := flowLogs.TrafficAnalytics; ta != nil {
+		if success, _ := regexp.MatchString(resourceIDPattern, ta.WorkspaceResourceID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("trafficAnalytics", "workspaceResourceID"), ta.WorkspaceResourceID,
+				fmt.Sprintf("workspace resource ID doesn't match regex %s", resourceIDPattern)))
+		}
+		if ta.WorkspaceID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("trafficAnalytics", "workspaceID"), "workspaceID is required when traffic analytics is enabled"))
+		}
+	}
+
+	return allErrs
+}
+
 // validateResourceGroup validates a ResourceGroup.
 func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Error {
 	if success, _ := regexp.MatchString(resourceGroupRegex, resourceGroup); !success {
@@ -223,6 +458,8 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 	}
 	clusterSubnet := false
 	numberofClusterSubnets := 0
+	// routes are tracked per route table, as subnets may share a route table.
+	routeTableRoutes := make(map[string]map[string]Route)
 	for i, subnet := range subnets {
 		if err := validateSubnetName(subnet.Name, fldPath.Index(i).Child("name")); err != nil {
 			allErrs = append(allErrs, err)
@@ -231,6 +468,14 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 			allErrs = append(allErrs, field.Duplicate(fldPath, subnet.Name))
 		}
 		subnetNames[subnet.Name] = true
+		if subnet.Role == SubnetGateway {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("role"), subnet.Role,
+				"the gateway role is reserved for the subnet of the virtual network gateway"))
+		}
+		if subnet.Role == SubnetRouteServer {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("role"), subnet.Role,
+				"the routeserver role is reserved for the subnet of the Route Server"))
+		}
 		if subnet.Role == SubnetCluster {
 			clusterSubnet = true
 			numberofClusterSubnets++
@@ -250,17 +495,68 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 				allErrs = append(allErrs, err...)
 			}
 		}
+		if subnet.SecurityGroup.DefaultSSHRule != nil {
+			allErrs = append(allErrs, validateDefaultSSHRule(*subnet.SecurityGroup.DefaultSSHRule, subnet.Role, fldPath.Index(i).Child("securityGroup").Child("defaultSSHRule"))...)
+		}
+		if subnet.SecurityGroup.ResourceGroup != "" {
+			if err := validateResourceGroup(subnet.SecurityGroup.ResourceGroup, fldPath.Index(i).Child("securityGroup").Child("resourceGroup")); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if len(subnet.SecurityGroup.SecurityRules) > 0 {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("securityGroup").Child("securityRules"),
+					"securityRules cannot be set on a security group which is not managed by CAPZ"))
+			}
+		}
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
 
 		if len(subnet.ServiceEndpoints) > 0 {
 			allErrs = append(allErrs, validateServiceEndpoints(subnet.ServiceEndpoints, fldPath.Index(i).Child("serviceEndpoints"))...)
 		}
 
+		if len(subnet.Delegations) > 0 {
+			allErrs = append(allErrs, validateDelegations(subnet.Delegations, fldPath.Index(i).Child("delegations"))...)
+		}
+
+		if len(subnet.RouteTable.Routes) > 0 {
+			routesPath := fldPath.Index(i).Child("routeTable").Child("routes")
+			allErrs = append(allErrs, validateRoutes(subnet.RouteTable.Routes, routesPath)...)
+			if _, ok := routeTableRoutes[subnet.RouteTable.Name]; !ok {
+				routeTableRoutes[subnet.RouteTable.Name] = make(map[string]Route)
+			}
+			for j, route := range subnet.RouteTable.Routes {
+				if existing, ok := routeTableRoutes[subnet.RouteTable.Name][route.Name]; ok && existing != route {
+					allErrs = append(allErrs, field.Invalid(routesPath.Index(j), route,
+						fmt.Sprintf("route %s is defined differently on another subnet using route table %s", route.Name, subnet.RouteTable.Name)))
+				}
+				routeTableRoutes[subnet.RouteTable.Name][route.Name] = route
+			}
+		}
+
 		if len(subnet.PrivateEndpoints) > 0 {
 			allErrs = append(allErrs, validatePrivateEndpoints(subnet.PrivateEndpoints, subnet.CIDRBlocks, fldPath.Index(i).Child("privateEndpoints"))...)
 		}
+
+		if len(subnet.ApplicationSecurityGroups) > 0 {
+			allErrs = append(allErrs, ValidateApplicationSecurityGroupReferences(subnet.ApplicationSecurityGroups, fldPath.Index(i).Child("applicationSecurityGroups"))...)
+		}
+
+		if subnet.PodSubnet != nil {
+			podSubnetPath := fldPath.Index(i).Child("podSubnet")
+			if _, ok := subnetNames[subnet.PodSubnet.Name]; ok {
+				allErrs = append(allErrs, field.Duplicate(podSubnetPath.Child("name"), subnet.PodSubnet.Name))
+			}
+			subnetNames[subnet.PodSubnet.Name] = true
+			allErrs = append(allErrs, validatePodSubnet(*subnet.PodSubnet, subnet.Role, vnet, podSubnetPath)...)
+		}
+
+		if err := validatePublicIPPrefixID(subnet.NatGateway.NatGatewayIP.PublicIPPrefixID, fldPath.Index(i).Child("natGateway").Child("ip").Child("publicIPPrefixID")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		allErrs = append(allErrs, validatePublicIPZones(subnet.NatGateway.NatGatewayIP.Zones, fldPath.Index(i).Child("natGateway").Child("ip").Child("zones"))...)
 	}
 
+	allErrs = append(allErrs, validateSubnetsOverlap(subnets, fldPath)...)
+
 	// The clusterSubnet is applicable to both the control-plane and node pools.
 	// Validation of requiredSubnetRoles is skipped since clusterSubnet is set to true.
 	if clusterSubnet {
@@ -297,14 +593,14 @@ func validateSubnetCIDR(subnetCidrBlocks []string, vnetCidrBlocks []string, fldP
 	}
 
 	for _, subnetCidr := range subnetCidrBlocks {
-		subnetCidrIP, _, err := net.ParseCIDR(subnetCidr)
+		_, subnetNw, err := net.ParseCIDR(subnetCidr)
 		if err != nil {
 			allErrs = append(allErrs, field.Invalid(fldPath, subnetCidr, "invalid CIDR format"))
 		}
 
 		var found bool
 		for _, vnetNw := range vnetNws {
-			if vnetNw.Contains(subnetCidrIP) {
+			if subnetNw != nil && cidrContains(vnetNw, subnetNw) {
 				found = true
 				break
 			}
@@ -318,13 +614,124 @@ func validateSubnetCIDR(subnetCidrBlocks []string, vnetCidrBlocks []string, fldP
 	return allErrs
 }
 
+// validatePodSubnet validates the dedicated pod subnet of a subnet.
+func validatePodSubnet(podSubnet PodSubnetSpec, role SubnetRole, vnet VnetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if role != SubnetNode && role != SubnetCluster {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "podSubnet may only be set on subnets with role node or all"))
+	}
+	if err := validateSubnetName(podSubnet.Name, fldPath.Child("name")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	allErrs = append(allErrs, validateSubnetCIDR(podSubnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Child("cidrBlocks"))...)
+	return allErrs
+}
+
+// validateSubnetsOverlap validates that the CIDR blocks of the subnets and of their dedicated pod subnets don't overlap
+// each other.
+func validateSubnetsOverlap(subnets Subnets, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	type subnetCIDRs struct {
+		name       string
+		cidrBlocks []string
+		path       *field.Path
+	}
+	var all []subnetCIDRs
+	for i, subnet := range subnets {
+		all = append(all, subnetCIDRs{name: subnet.Name, cidrBlocks: subnet.CIDRBlocks, path: fldPath.Index(i).Child("cidrBlocks")})
+		if subnet.PodSubnet != nil {
+			all = append(all, subnetCIDRs{name: subnet.PodSubnet.Name, cidrBlocks: subnet.PodSubnet.CIDRBlocks,
+				path: fldPath.Index(i).Child("podSubnet").Child("cidrBlocks")})
+		}
+	}
+
+	type subnetNw struct {
+		name string
+		nw   *net.IPNet
+	}
+	var seen []subnetNw
+	for _, subnet := range all {
+		for _, cidr := range subnet.cidrBlocks {
+			_, nw, err := net.ParseCIDR(cidr)
+			if err != nil {
+				// Invalid CIDR blocks are reported by validateSubnetCIDR.
+				continue
+			}
+			for _, other := range seen {
+				if other.name != subnet.name && cidrsOverlap(nw, other.nw) {
+					allErrs = append(allErrs, field.Invalid(subnet.path, cidr,
+						fmt.Sprintf("subnet CIDR overlaps with %s of subnet %s", other.nw, other.name)))
+				}
+			}
+			seen = append(seen, subnetNw{name: subnet.name, nw: nw})
+		}
+	}
+	return allErrs
+}
+
+// validateClusterNetworkCIDRs validates that the CIDR blocks of the subnets don't overlap the pod and service CIDR
+// blocks of the Cluster.
+func validateClusterNetworkCIDRs(subnets Subnets, clusterNetwork *clusterv1.ClusterNetwork, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if clusterNetwork == nil {
+		return allErrs
+	}
+
+	ranges := map[string]*clusterv1.NetworkRanges{
+		"pod":     clusterNetwork.Pods,
+		"service": clusterNetwork.Services,
+	}
+	for i, subnet := range subnets {
+		for _, cidr := range subnet.CIDRBlocks {
+			_, nw, err := net.ParseCIDR(cidr)
+			if err != nil {
+				continue
+			}
+			for _, kind := range []string{"pod", "service"} {
+				if ranges[kind] == nil {
+					continue
+				}
+				for _, clusterCIDR := range ranges[kind].CIDRBlocks {
+					if _, clusterNw, err := net.ParseCIDR(clusterCIDR); err == nil && cidrsOverlap(nw, clusterNw) {
+						allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("cidrBlocks"), cidr,
+							fmt.Sprintf("subnet CIDR overlaps with the cluster %s CIDR %s", kind, clusterCIDR)))
+					}
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// cidrsOverlap returns true if the two networks share at least one address.
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// cidrContains returns true if the inner network is entirely contained in the outer network.
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && innerOnes >= outerOnes && outer.Contains(inner.IP)
+}
+
 // validateVnetCIDR validates the CIDR blocks of a Vnet.
 func validateVnetCIDR(vnetCIDRBlocks []string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
+	var vnetNws []*net.IPNet
 	for _, vnetCidr := range vnetCIDRBlocks {
-		if _, _, err := net.ParseCIDR(vnetCidr); err != nil {
+		_, vnetNw, err := net.ParseCIDR(vnetCidr)
+		if err != nil {
 			allErrs = append(allErrs, field.Invalid(fldPath, vnetCidr, "invalid CIDR format"))
+			continue
 		}
+		// address spaces of a virtual network can't overlap.
+		for _, other := range vnetNws {
+			if cidrsOverlap(vnetNw, other) {
+				allErrs = append(allErrs, field.Invalid(fldPath, vnetCidr, fmt.Sprintf("vnet address space overlaps with %s", other)))
+			}
+		}
+		vnetNws = append(vnetNws, vnetNw)
 	}
 	return allErrs
 }
@@ -353,6 +760,18 @@ func validateLoadBalancerName(name string, fldPath *field.Path) *field.Error {
 	return nil
 }
 
+// validatePublicIPPrefixID validates the resource ID of a public IP prefix.
+func validatePublicIPPrefixID(publicIPPrefixID *string, fldPath *field.Path) *field.Error {
+	if publicIPPrefixID == nil {
+		return nil
+	}
+	if success, _ := regexp.MatchString(resourceIDPattern, *publicIPPrefixID); !success {
+		return field.Invalid(fldPath, *publicIPPrefixID,
+			fmt.Sprintf("public IP prefix ID doesn't match regex %s", resourceIDPattern))
+	}
+	return nil
+}
+
 // validateInternalLBIPAddress validates a InternalLBIPAddress.
 func validateInternalLBIPAddress(address string, cidrs []string, fldPath *field.Path) *field.Error {
 	ip := net.ParseIP(address)
@@ -380,6 +799,112 @@ func validateSecurityRule(rule SecurityRule, fldPath *field.Path) (allErrs field
 		allErrs = append(allErrs, field.Invalid(fldPath, rule.Source, "security rule cannot have both source and sources"))
 	}
 
+	if len(rule.SourceApplicationSecurityGroups) > 0 {
+		if rule.Source != nil || rule.Sources != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("sourceApplicationSecurityGroups"), "security rule cannot have both source address prefixes and source application security groups"))
+		}
+		allErrs = append(allErrs, ValidateApplicationSecurityGroupReferences(rule.SourceApplicationSecurityGroups, fldPath.Child("sourceApplicationSecurityGroups"))...)
+	}
+
+	if len(rule.DestinationApplicationSecurityGroups) > 0 {
+		if rule.Destination != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("destinationApplicationSecurityGroups"), "security rule cannot have both a destination address prefix and destination application security groups"))
+		}
+		allErrs = append(allErrs, ValidateApplicationSecurityGroupReferences(rule.DestinationApplicationSecurityGroups, fldPath.Child("destinationApplicationSecurityGroups"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateApplicationSecurityGroupReferences validates a list of references to application security groups, which
+// are either names or resource IDs.
+func ValidateApplicationSecurityGroupReferences(refs ApplicationSecurityGroupReferences, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := make(map[string]bool, len(refs))
+	for i, ref := range refs {
+		if seen[strings.ToLower(ref)] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), ref))
+		}
+		seen[strings.ToLower(ref)] = true
+		if strings.HasPrefix(ref, "/") {
+			if success, _ := regexp.MatchString(resourceIDPattern, ref); !success {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), ref,
+					fmt.Sprintf("application security group ID doesn't match regex %s", resourceIDPattern)))
+			}
+			continue
+		}
+		if err := validateApplicationSecurityGroupName(ref, fldPath.Index(i)); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+// validateApplicationSecurityGroupName validates the name of an application security group.
+func validateApplicationSecurityGroupName(name string, fldPath *field.Path) *field.Error {
+	if success, _ := regexp.MatchString(applicationSecurityGroupRegex, name); !success {
+		return field.Invalid(fldPath, name,
+			fmt.Sprintf("name of application security group doesn't match regex %s", applicationSecurityGroupRegex))
+	}
+	return nil
+}
+
+// validateApplicationSecurityGroups validates the application security groups managed by CAPZ.
+func validateApplicationSecurityGroups(asgs []ApplicationSecurityGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, asg := range asgs {
+		if err := validateApplicationSecurityGroupName(asg.Name, fldPath.Index(i).Child("name")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+// validateDefaultSSHRule validates the configuration of the default SSH security rule of a subnet.
+func validateDefaultSSHRule(rule DefaultSSHRule, role SubnetRole, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if role != SubnetControlPlane && role != SubnetCluster {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("defaultSSHRule may only be set on subnets with role %s or %s", SubnetControlPlane, SubnetCluster)))
+	}
+
+	if rule.Disabled && len(rule.SourceCIDRs) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sourceCIDRs"), "sourceCIDRs may not be set when the default SSH rule is disabled"))
+	}
+
+	for i, cidr := range rule.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sourceCIDRs").Index(i), cidr, "must be a valid CIDR"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAPIServerAccessList validates the address prefixes allowed to reach the API server, and that they can be
+// applied to the security rules of the control plane subnet.
+func validateAPIServerAccessList(accessList []string, controlPlaneSubnet SubnetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(accessList) == 0 {
+		return allErrs
+	}
+
+	for i, cidr := range accessList {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cidr, "must be a valid CIDR"))
+		}
+	}
+
+	securityGroup := controlPlaneSubnet.SecurityGroup
+	if securityGroup.ResourceGroup != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "apiServerAccessList may not be set when the security group of the control plane subnet is not managed"))
+	} else if securityGroup.SecurityRules != nil && !slices.ContainsFunc(securityGroup.SecurityRules, func(rule SecurityRule) bool {
+		return rule.Name == APIServerSecurityRuleName
+	}) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("apiServerAccessList requires a %s security rule in the control plane subnet", APIServerSecurityRuleName)))
+	}
+
 	return allErrs
 }
 
@@ -399,6 +924,8 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer name should not be modified after AzureCluster creation."))
 	}
 
+	allErrs = append(allErrs, validateLoadBalancerResourceGroup(lb, old, fldPath)...)
+
 	// There should only be one IP config.
 	if len(lb.FrontendIPs) != 1 || ptr.Deref[int32](lb.FrontendIPsCount, 1) != 1 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPConfigs"), lb.FrontendIPs,
@@ -427,6 +954,26 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP"),
 					"Public Load Balancers cannot have a Private IP"))
 			}
+			if publicIP := lb.FrontendIPs[0].PublicIP; publicIP != nil {
+				publicIPPath := fldPath.Child("frontendIPConfigs").Index(0).Child("publicIP")
+				if err := validatePublicIPPrefixID(publicIP.PublicIPPrefixID, publicIPPath.Child("publicIPPrefixID")); err != nil {
+					allErrs = append(allErrs, err)
+				}
+				allErrs = append(allErrs, validatePublicIPZones(publicIP.Zones, publicIPPath.Child("zones"))...)
+				if len(old.FrontendIPs) != 0 && old.FrontendIPs[0].PublicIP != nil &&
+					!reflect.DeepEqual(old.FrontendIPs[0].PublicIP.Zones, publicIP.Zones) {
+					allErrs = append(allErrs, field.Forbidden(publicIPPath.Child("zones"), "API Server public IP zones should not be modified after AzureCluster creation."))
+				}
+				if publicIP.ResourceGroup != "" {
+					if err := validateResourceGroup(publicIP.ResourceGroup, publicIPPath.Child("resourceGroup")); err != nil {
+						allErrs = append(allErrs, err)
+					}
+					if publicIP.DNSName == "" {
+						allErrs = append(allErrs, field.Required(publicIPPath.Child("dnsName"),
+							"dnsName is required when using an existing public IP from another resource group"))
+					}
+				}
+			}
 		}
 	}
 
@@ -459,6 +1006,12 @@ func validateNodeOutboundLB(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserv
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "Node outbound load balancer Name should not be modified after AzureCluster creation."))
 	}
 
+	oldLB := LoadBalancerSpec{}
+	if old != nil {
+		oldLB = *old
+	}
+	allErrs = append(allErrs, validateLoadBalancerResourceGroup(*lb, oldLB, fldPath)...)
+
 	if old != nil && old.FrontendIPsCount == lb.FrontendIPsCount {
 		if len(old.FrontendIPs) != len(lb.FrontendIPs) {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPs"), "Node outbound load balancer FrontendIPs cannot be modified after AzureCluster creation."))
@@ -494,6 +1047,11 @@ func validateControlPlaneOutboundLB(lb *LoadBalancerSpec, apiserverLB LoadBalanc
 
 	allErrs = append(allErrs, validateClassSpecForControlPlaneOutboundLB(lbClassSpec, apiServerLBClassSpec, fldPath)...)
 
+	if lb != nil && lb.ResourceGroup != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("resourceGroup"),
+			"resourceGroup is only supported for the API server and node outbound load balancers"))
+	}
+
 	if apiServerLBClassSpec.Type == Internal && lb != nil {
 		if lb.FrontendIPsCount != nil && *lb.FrontendIPsCount > MaxLoadBalancerOutboundIPs {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPsCount"), *lb.FrontendIPsCount,
@@ -504,6 +1062,85 @@ func validateControlPlaneOutboundLB(lb *LoadBalancerSpec, apiserverLB LoadBalanc
 	return allErrs
 }
 
+// clusterPublicIP is a public IP of an AzureCluster with its field path.
+type clusterPublicIP struct {
+	path     *field.Path
+	publicIP *PublicIPSpec
+}
+
+// clusterPublicIPs returns the public IPs of an AzureCluster spec other than the ones of the API server load balancer.
+func clusterPublicIPs(spec AzureClusterSpec) []clusterPublicIP {
+	var publicIPs []clusterPublicIP
+	networkPath := field.NewPath("spec").Child("networkSpec")
+	addFrontendIPs := func(lb *LoadBalancerSpec, lbPath *field.Path) {
+		if lb == nil {
+			return
+		}
+		for i := range lb.FrontendIPs {
+			if lb.FrontendIPs[i].PublicIP != nil {
+				publicIPs = append(publicIPs, clusterPublicIP{lbPath.Child("frontendIPs").Index(i).Child("publicIP"), lb.FrontendIPs[i].PublicIP})
+			}
+		}
+	}
+	addFrontendIPs(spec.NetworkSpec.NodeOutboundLB, networkPath.Child("nodeOutboundLB"))
+	addFrontendIPs(spec.NetworkSpec.ControlPlaneOutboundLB, networkPath.Child("controlPlaneOutboundLB"))
+	for i := range spec.NetworkSpec.Subnets {
+		publicIPs = append(publicIPs, clusterPublicIP{networkPath.Child("subnets").Index(i).Child("natGateway").Child("ip"), &spec.NetworkSpec.Subnets[i].NatGateway.NatGatewayIP})
+	}
+	if spec.NetworkSpec.VirtualNetworkGateway != nil {
+		publicIPs = append(publicIPs, clusterPublicIP{networkPath.Child("virtualNetworkGateway").Child("publicIP"), &spec.NetworkSpec.VirtualNetworkGateway.PublicIP})
+	}
+	if spec.NetworkSpec.RouteServer != nil {
+		publicIPs = append(publicIPs, clusterPublicIP{networkPath.Child("routeServer").Child("publicIP"), &spec.NetworkSpec.RouteServer.PublicIP})
+	}
+	if spec.BastionSpec.AzureBastion != nil {
+		publicIPs = append(publicIPs, clusterPublicIP{field.NewPath("spec").Child("bastionSpec").Child("azureBastion").Child("publicIP"), &spec.BastionSpec.AzureBastion.PublicIP})
+	}
+	return publicIPs
+}
+
+// validatePublicIPResourceGroups forbids a resource group on the public IPs which CAPZ creates in the resource group of
+// the cluster, i.e. all of them but the API server one.
+func validatePublicIPResourceGroups(spec AzureClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, ip := range clusterPublicIPs(spec) {
+		if ip.publicIP.ResourceGroup != "" {
+			allErrs = append(allErrs, field.Forbidden(ip.path.Child("resourceGroup"),
+				"resourceGroup is only supported for the API server load balancer public IP"))
+		}
+	}
+	return allErrs
+}
+
+// validatePublicIPZones validates the availability zones of a public IP.
+func validatePublicIPZones(zones []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, zone := range zones {
+		if success, _ := regexp.MatchString(availabilityZoneRegex, zone); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), zone,
+				fmt.Sprintf("zone doesn't match regex %s", availabilityZoneRegex)))
+		}
+	}
+	return allErrs
+}
+
+// validateLoadBalancerResourceGroup validates the resource group of an existing load balancer.
+func validateLoadBalancerResourceGroup(lb LoadBalancerSpec, old LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if lb.ResourceGroup != "" {
+		if err := validateResourceGroup(lb.ResourceGroup, fldPath.Child("resourceGroup")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	// An existing load balancer can't be swapped with a managed one, and vice versa.
+	if old.Name != "" && old.ResourceGroup != lb.ResourceGroup {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("resourceGroup"), "load balancer resource group should not be modified after AzureCluster creation."))
+	}
+
+	return allErrs
+}
+
 // validatePrivateDNSZoneName validates the PrivateDNSZoneName.
 func validatePrivateDNSZoneName(privateDNSZoneName string, apiserverLBType LBType, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -523,6 +1160,73 @@ func validatePrivateDNSZoneName(privateDNSZoneName string, apiserverLBType LBTyp
 	return allErrs
 }
 
+// validateAPIServerDNS validates the API server DNS configuration.
+func validateAPIServerDNS(apiServerDNS APIServerDNSSpec, controlPlaneEndpointHost string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	matches := dnsZoneIDRegex.FindStringSubmatch(apiServerDNS.DNSZoneID)
+	if matches == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsZoneID"), apiServerDNS.DNSZoneID,
+			fmt.Sprintf("DNS zone ID doesn't match regex %s", dnsZoneIDPattern)))
+	}
+
+	if !valid.IsDNSName(apiServerDNS.Hostname) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostname"), apiServerDNS.Hostname,
+			"hostname must be a valid DNS name"))
+	} else if matches != nil && !strings.HasSuffix(strings.ToLower(apiServerDNS.Hostname), "."+strings.ToLower(matches[1])) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostname"), apiServerDNS.Hostname,
+			fmt.Sprintf("hostname must be a subdomain of the DNS zone %s", matches[1])))
+	}
+
+	if controlPlaneEndpointHost != "" && controlPlaneEndpointHost != apiServerDNS.Hostname {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostname"), apiServerDNS.Hostname,
+			fmt.Sprintf("hostname must match the control plane endpoint host %s", controlPlaneEndpointHost)))
+	}
+
+	return allErrs
+}
+
+// validateResourceNaming validates the resource naming templates of a cluster.
+func validateResourceNaming(naming *ResourceNamingSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if naming == nil {
+		return allErrs
+	}
+	for _, affix := range []struct {
+		name  string
+		value string
+	}{
+		{"prefix", naming.Prefix},
+		{"suffix", naming.Suffix},
+	} {
+		if affix.value != "" && !resourceNameAffixRegex.MatchString(affix.value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(affix.name), affix.value,
+				"can only contain alphanumerics, underscores, periods and hyphens"))
+		}
+	}
+	for _, template := range []struct {
+		name         string
+		value        string
+		placeholders []string
+	}{
+		{"networkInterface", naming.NetworkInterface, []string{ResourceNamingMachinePlaceholder}},
+		{"osDisk", naming.OSDisk, []string{ResourceNamingMachinePlaceholder}},
+		{"dataDisk", naming.DataDisk, []string{ResourceNamingMachinePlaceholder, ResourceNamingNameSuffixPlaceholder}},
+		{"publicIP", naming.PublicIP, []string{ResourceNamingMachinePlaceholder}},
+	} {
+		if template.value == "" {
+			continue
+		}
+		for _, placeholder := range template.placeholders {
+			if !strings.Contains(template.value, placeholder) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(template.name), template.value,
+					fmt.Sprintf("must contain the %s placeholder", placeholder)))
+			}
+		}
+	}
+	return allErrs
+}
+
 // validateCloudProviderConfigOverrides validates CloudProviderConfigOverrides.
 func validateCloudProviderConfigOverrides(oldConfig, newConfig *CloudProviderConfigOverrides, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -563,7 +1267,7 @@ func validateClassSpecForAPIServerLB(lb LoadBalancerClassSpec, old *LoadBalancer
 
 	if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
 		allErrs = append(allErrs, field.Invalid(apiServerLBPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
-			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
+			fmt.Sprintf("API Server load balancer idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLBIdleTimeoutInMinutes)))
 	}
 
 	return allErrs
@@ -596,7 +1300,7 @@ func validateClassSpecForNodeOutboundLB(lb *LoadBalancerClassSpec, old *LoadBala
 
 	if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
-			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
+			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLBIdleTimeoutInMinutes)))
 	}
 
 	return allErrs
@@ -618,7 +1322,7 @@ func validateClassSpecForControlPlaneOutboundLB(lb *LoadBalancerClassSpec, apise
 
 		if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
-				fmt.Sprintf("Control plane outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
+				fmt.Sprintf("Control plane outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLBIdleTimeoutInMinutes)))
 		}
 	}
 
@@ -661,6 +1365,65 @@ func validateServiceEndpoints(serviceEndpoints []ServiceEndpointSpec, fldPath *f
 	return allErrs
 }
 
+// validateDelegations validates the delegations of a subnet.
+func validateDelegations(delegations Delegations, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	delegationNames := make(map[string]bool, len(delegations))
+	for i, delegation := range delegations {
+		if delegation.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "name is required for all delegations"))
+		} else {
+			if _, ok := delegationNames[delegation.Name]; ok {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), delegation.Name))
+			}
+			delegationNames[delegation.Name] = true
+		}
+
+		if delegation.ServiceName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("serviceName"), "serviceName is required for all delegations"))
+		} else if success := delegationServiceNameRegex.MatchString(delegation.ServiceName); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("serviceName"), delegation.ServiceName,
+				fmt.Sprintf("service name of delegation doesn't match regex %s", delegationServiceNameRegexPattern)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateRoutes validates the user-defined routes of a route table.
+func validateRoutes(routes []Route, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	routeNames := make(map[string]bool, len(routes))
+	for i, route := range routes {
+		if route.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "name is required for all routes"))
+		} else {
+			if _, ok := routeNames[route.Name]; ok {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), route.Name))
+			}
+			routeNames[route.Name] = true
+		}
+
+		if _, _, err := net.ParseCIDR(route.AddressPrefix); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("addressPrefix"), route.AddressPrefix, "invalid CIDR format"))
+		}
+
+		if route.NextHopType == RouteNextHopTypeVirtualAppliance {
+			if route.NextHopIPAddress == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("nextHopIPAddress"), "nextHopIPAddress is required when nextHopType is VirtualAppliance"))
+			} else if net.ParseIP(route.NextHopIPAddress) == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("nextHopIPAddress"), route.NextHopIPAddress, "invalid IP address"))
+			}
+		} else if route.NextHopIPAddress != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("nextHopIPAddress"), "nextHopIPAddress is only allowed when nextHopType is VirtualAppliance"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateServiceEndpointServiceName(serviceName string, fldPath *field.Path) *field.Error {
 	if success := serviceEndpointServiceRegex.MatchString(serviceName); !success {
 		return field.Invalid(fldPath, serviceName, fmt.Sprintf("service name of endpoint service doesn't match regex %s", serviceEndpointServiceRegexPattern))
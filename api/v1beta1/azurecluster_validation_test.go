@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestClusterNameValidation(t *testing.T) {
@@ -453,6 +454,22 @@ func TestValidateVnetCIDR(t *testing.T) {
 				Detail:   "invalid CIDR format",
 			},
 		},
+		{
+			name:           "valid multiple vnet address spaces",
+			vnetCidrBlocks: []string{"10.0.0.0/16", "10.1.0.0/16", "172.16.0.0/12"},
+			wantErr:        false,
+		},
+		{
+			name:           "invalid overlapping vnet address spaces",
+			vnetCidrBlocks: []string{"10.0.0.0/8", "10.1.0.0/16"},
+			wantErr:        true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "vnet.cidrBlocks",
+				BadValue: "10.1.0.0/16",
+				Detail:   "vnet address space overlaps with 10.0.0.0/8",
+			},
+		},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -601,6 +618,93 @@ func TestSubnetsInvalidSubnetName(t *testing.T) {
 	})
 }
 
+func TestSubnetsInvalidSecurityGroupResourceGroup(t *testing.T) {
+	type test struct {
+		name    string
+		subnets Subnets
+	}
+
+	testCase := test{
+		name:    "subnets - invalid security group resource group",
+		subnets: createValidSubnets(),
+	}
+
+	testCase.subnets[0].SecurityGroup.ResourceGroup = "inv@lid-rg"
+
+	t.Run(testCase.name, func(t *testing.T) {
+		g := NewWithT(t)
+		errs := validateSubnets(testCase.subnets, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets[0].securityGroup.resourceGroup"))
+		g.Expect(errs[0].BadValue).To(BeEquivalentTo("inv@lid-rg"))
+	})
+}
+
+func TestSubnetsSecurityRulesOnExistingSecurityGroup(t *testing.T) {
+	g := NewWithT(t)
+
+	subnets := createValidSubnets()
+	subnets[0].SecurityGroup.Name = "central-cp-nsg"
+	subnets[0].SecurityGroup.ResourceGroup = "network-team-rg"
+	subnets[0].SecurityGroup.SecurityRules = SecurityRules{
+		{
+			Name:             "allow_apiserver",
+			Description:      "Allow K8s API Server",
+			Priority:         101,
+			Protocol:         SecurityGroupProtocolTCP,
+			Direction:        SecurityRuleDirectionInbound,
+			Source:           ptr.To("*"),
+			SourcePorts:      ptr.To("*"),
+			Destination:      ptr.To("*"),
+			DestinationPorts: ptr.To("6443"),
+			Action:           SecurityRuleActionAllow,
+		},
+	}
+
+	errs := validateSubnets(subnets, createValidVnet(),
+		field.NewPath("spec").Child("networkSpec").Child("subnets"))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+	g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets[0].securityGroup.securityRules"))
+}
+
+func TestSubnetsConflictingRoutes(t *testing.T) {
+	type test struct {
+		name    string
+		subnets Subnets
+	}
+
+	testCase := test{
+		name:    "subnets - conflicting routes on a shared route table",
+		subnets: createValidSubnets(),
+	}
+
+	testCase.subnets[0].RouteTable = RouteTable{
+		Name: "shared-rt",
+		Routes: []Route{
+			{Name: "to-onprem", AddressPrefix: "192.168.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway},
+		},
+	}
+	testCase.subnets[1].RouteTable = RouteTable{
+		Name: "shared-rt",
+		Routes: []Route{
+			{Name: "to-onprem", AddressPrefix: "172.16.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway},
+		},
+	}
+
+	t.Run(testCase.name, func(t *testing.T) {
+		g := NewWithT(t)
+		errs := validateSubnets(testCase.subnets, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets[1].routeTable.routes[0]"))
+		g.Expect(errs[0].Detail).To(Equal("route to-onprem is defined differently on another subnet using route table shared-rt"))
+	})
+}
+
 func TestSubnetsInvalidLackRequiredSubnet(t *testing.T) {
 	type test struct {
 		name    string
@@ -728,6 +832,18 @@ func TestValidateSubnetCIDR(t *testing.T) {
 				Detail:   "subnet CIDR not in vnet address space: [10.0.0.0/8]",
 			},
 		},
+		{
+			name:             "subnet cidr larger than vnet range",
+			vnetCidrBlocks:   []string{"10.0.0.0/16"},
+			subnetCidrBlocks: []string{"10.0.0.0/8"},
+			wantErr:          true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets.cidrBlocks",
+				BadValue: "10.0.0.0/8",
+				Detail:   "subnet CIDR not in vnet address space: [10.0.0.0/16]",
+			},
+		},
 		{
 			name:             "subnet cidr in at least one vnet's range in case of multiple vnet cidr blocks",
 			vnetCidrBlocks:   []string{"10.0.0.0/8", "11.0.0.0/8"},
@@ -749,6 +865,98 @@ func TestValidateSubnetCIDR(t *testing.T) {
 	}
 }
 
+func TestValidateSubnetsOverlap(t *testing.T) {
+	tests := []struct {
+		name        string
+		subnets     Subnets
+		expectedErr *field.Error
+	}{
+		{
+			name: "subnets don't overlap",
+			subnets: Subnets{
+				{SubnetClassSpec: SubnetClassSpec{Name: "cp", CIDRBlocks: []string{"10.0.0.0/16"}}},
+				{SubnetClassSpec: SubnetClassSpec{Name: "node", CIDRBlocks: []string{"10.1.0.0/16"}}},
+			},
+		},
+		{
+			name: "subnets overlap",
+			subnets: Subnets{
+				{SubnetClassSpec: SubnetClassSpec{Name: "cp", CIDRBlocks: []string{"10.0.0.0/16"}}},
+				{SubnetClassSpec: SubnetClassSpec{Name: "node", CIDRBlocks: []string{"10.0.128.0/24"}}},
+			},
+			expectedErr: field.Invalid(field.NewPath("subnets").Index(1).Child("cidrBlocks"), "10.0.128.0/24",
+				"subnet CIDR overlaps with 10.0.0.0/16 of subnet cp"),
+		},
+		{
+			name: "pod subnet overlaps a subnet",
+			subnets: Subnets{
+				{SubnetClassSpec: SubnetClassSpec{Name: "cp", CIDRBlocks: []string{"10.0.0.0/16"}}},
+				{SubnetClassSpec: SubnetClassSpec{Name: "node", CIDRBlocks: []string{"10.1.0.0/16"},
+					PodSubnet: &PodSubnetSpec{Name: "pods", CIDRBlocks: []string{"10.0.64.0/18"}}}},
+			},
+			expectedErr: field.Invalid(field.NewPath("subnets").Index(1).Child("podSubnet").Child("cidrBlocks"), "10.0.64.0/18",
+				"subnet CIDR overlaps with 10.0.0.0/16 of subnet cp"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateSubnetsOverlap(tc.subnets, field.NewPath("subnets"))
+			if tc.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(tc.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateClusterNetworkCIDRs(t *testing.T) {
+	subnets := Subnets{
+		{SubnetClassSpec: SubnetClassSpec{Name: "cp", CIDRBlocks: []string{"10.0.0.0/16"}}},
+		{SubnetClassSpec: SubnetClassSpec{Name: "node", CIDRBlocks: []string{"10.1.0.0/16"}}},
+	}
+	tests := []struct {
+		name           string
+		clusterNetwork *clusterv1.ClusterNetwork
+		expectedErrs   field.ErrorList
+	}{
+		{
+			name: "no cluster network",
+		},
+		{
+			name: "pod and service CIDRs don't overlap the subnets",
+			clusterNetwork: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.96.0.0/12"}},
+			},
+		},
+		{
+			name: "pod and service CIDRs overlap the subnets",
+			clusterNetwork: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.1.0.0/24"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("subnets").Index(0).Child("cidrBlocks"), "10.0.0.0/16", "subnet CIDR overlaps with the cluster service CIDR 10.0.0.0/8"),
+				field.Invalid(field.NewPath("subnets").Index(1).Child("cidrBlocks"), "10.1.0.0/16", "subnet CIDR overlaps with the cluster pod CIDR 10.1.0.0/24"),
+				field.Invalid(field.NewPath("subnets").Index(1).Child("cidrBlocks"), "10.1.0.0/16", "subnet CIDR overlaps with the cluster service CIDR 10.0.0.0/8"),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateClusterNetworkCIDRs(subnets, tc.clusterNetwork, field.NewPath("subnets"))
+			if tc.expectedErrs != nil {
+				g.Expect(errs).To(Equal(tc.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateSecurityRule(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -809,6 +1017,39 @@ func TestValidateSecurityRule(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "security rule - valid application security groups",
+			validRule: SecurityRule{
+				Name:                                 "allow_web",
+				Description:                          "Allow web traffic",
+				Priority:                             4000,
+				SourceApplicationSecurityGroups:      ApplicationSecurityGroupReferences{"frontend"},
+				DestinationApplicationSecurityGroups: ApplicationSecurityGroupReferences{"/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Network/applicationSecurityGroups/web"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - source and source application security groups",
+			validRule: SecurityRule{
+				Name:                            "allow_web",
+				Description:                     "Allow web traffic",
+				Priority:                        4000,
+				Source:                          ptr.To("*"),
+				SourceApplicationSecurityGroups: ApplicationSecurityGroupReferences{"frontend"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - destination and destination application security groups",
+			validRule: SecurityRule{
+				Name:                                 "allow_web",
+				Description:                          "Allow web traffic",
+				Priority:                             4000,
+				Destination:                          ptr.To("*"),
+				DestinationApplicationSecurityGroups: ApplicationSecurityGroupReferences{"web"},
+			},
+			wantErr: true,
+		},
 	}
 	for _, testCase := range tests {
 		testCase := testCase
@@ -830,6 +1071,217 @@ func TestValidateSecurityRule(t *testing.T) {
 	}
 }
 
+func TestValidateDefaultSSHRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    DefaultSSHRule
+		role    SubnetRole
+		wantErr bool
+	}{
+		{
+			name:    "restricted sources on control plane subnet",
+			rule:    DefaultSSHRule{SourceCIDRs: []string{"10.0.0.0/8"}},
+			role:    SubnetControlPlane,
+			wantErr: false,
+		},
+		{
+			name:    "disabled on cluster subnet",
+			rule:    DefaultSSHRule{Disabled: true},
+			role:    SubnetCluster,
+			wantErr: false,
+		},
+		{
+			name:    "node subnet",
+			rule:    DefaultSSHRule{Disabled: true},
+			role:    SubnetNode,
+			wantErr: true,
+		},
+		{
+			name:    "disabled with sources",
+			rule:    DefaultSSHRule{Disabled: true, SourceCIDRs: []string{"10.0.0.0/8"}},
+			role:    SubnetControlPlane,
+			wantErr: true,
+		},
+		{
+			name:    "invalid source CIDR",
+			rule:    DefaultSSHRule{SourceCIDRs: []string{"10.0.0.0"}},
+			role:    SubnetControlPlane,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateDefaultSSHRule(tc.rule, tc.role, field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("securityGroup", "defaultSSHRule"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerAccessList(t *testing.T) {
+	tests := []struct {
+		name          string
+		accessList    []string
+		securityGroup SecurityGroup
+		wantErr       bool
+	}{
+		{
+			name:       "valid CIDRs",
+			accessList: []string{"10.0.0.0/8", "203.0.113.0/24"},
+			wantErr:    false,
+		},
+		{
+			name:       "invalid CIDR",
+			accessList: []string{"203.0.113.1"},
+			wantErr:    true,
+		},
+		{
+			name:       "existing API server rule",
+			accessList: []string{"10.0.0.0/8"},
+			securityGroup: SecurityGroup{
+				SecurityGroupClass: SecurityGroupClass{
+					SecurityRules: SecurityRules{{Name: APIServerSecurityRuleName}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "custom security rules without API server rule",
+			accessList: []string{"10.0.0.0/8"},
+			securityGroup: SecurityGroup{
+				SecurityGroupClass: SecurityGroupClass{
+					SecurityRules: SecurityRules{{Name: "allow_https"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:          "unmanaged security group",
+			accessList:    []string{"10.0.0.0/8"},
+			securityGroup: SecurityGroup{ResourceGroup: "my-rg"},
+			wantErr:       true,
+		},
+		{
+			name:          "no access list with unmanaged security group",
+			securityGroup: SecurityGroup{ResourceGroup: "my-rg"},
+			wantErr:       false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			subnet := SubnetSpec{SecurityGroup: tc.securityGroup}
+			errs := validateAPIServerAccessList(tc.accessList, subnet, field.NewPath("spec", "networkSpec", "apiServerAccessList"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePodSubnet(t *testing.T) {
+	vnet := VnetSpec{VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}}}
+	tests := []struct {
+		name      string
+		podSubnet PodSubnetSpec
+		role      SubnetRole
+		wantErr   bool
+	}{
+		{
+			name:      "pod subnet on node subnet",
+			podSubnet: PodSubnetSpec{Name: "pod-subnet", CIDRBlocks: []string{"10.2.0.0/16"}},
+			role:      SubnetNode,
+			wantErr:   false,
+		},
+		{
+			name:      "pod subnet of an existing vnet without CIDR blocks",
+			podSubnet: PodSubnetSpec{Name: "pod-subnet"},
+			role:      SubnetCluster,
+			wantErr:   false,
+		},
+		{
+			name:      "pod subnet on control plane subnet",
+			podSubnet: PodSubnetSpec{Name: "pod-subnet", CIDRBlocks: []string{"10.2.0.0/16"}},
+			role:      SubnetControlPlane,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid pod subnet name",
+			podSubnet: PodSubnetSpec{Name: "pod subnet!", CIDRBlocks: []string{"10.2.0.0/16"}},
+			role:      SubnetNode,
+			wantErr:   true,
+		},
+		{
+			name:      "pod subnet CIDR outside of the vnet",
+			podSubnet: PodSubnetSpec{Name: "pod-subnet", CIDRBlocks: []string{"192.168.0.0/16"}},
+			role:      SubnetNode,
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validatePodSubnet(tc.podSubnet, tc.role, vnet, field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("podSubnet"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateApplicationSecurityGroupReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		refs    ApplicationSecurityGroupReferences
+		wantErr bool
+	}{
+		{
+			name:    "no references",
+			refs:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "names and resource IDs",
+			refs:    ApplicationSecurityGroupReferences{"web", "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Network/applicationSecurityGroups/db"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid name",
+			refs:    ApplicationSecurityGroupReferences{"-web"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid resource ID",
+			refs:    ApplicationSecurityGroupReferences{"/applicationSecurityGroups/web"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate references",
+			refs:    ApplicationSecurityGroupReferences{"web", "web"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := ValidateApplicationSecurityGroupReferences(tc.refs, field.NewPath("spec", "applicationSecurityGroups"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateAPIServerLB(t *testing.T) {
 	testcases := []struct {
 		name        string
@@ -1027,12 +1479,315 @@ func TestValidateAPIServerLB(t *testing.T) {
 			cpCIDRS: []string{"10.0.0.0/24", "10.1.0.0/24"},
 			wantErr: false,
 		},
-	}
-
-	for _, test := range testcases {
-		test := test
-		t.Run(test.name, func(t *testing.T) {
-			t.Parallel()
+		{
+			name: "public LB with valid public IP prefix ID",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name:             "my-pip",
+							PublicIPPrefixID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			wantErr: false,
+		},
+		{
+			name: "public LB with invalid public IP prefix ID",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name:             "my-pip",
+							PublicIPPrefixID: ptr.To("my-prefix"),
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs[0].publicIP.publicIPPrefixID",
+				BadValue: "my-prefix",
+				Detail:   "public IP prefix ID doesn't match regex (?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)",
+			},
+		},
+		{
+			name: "public LB with idle timeout above the maximum",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name: "my-pip",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type:                 Public,
+					SKU:                  SKUStandard,
+					IdleTimeoutInMinutes: ptr.To[int32](31),
+				},
+				Name: "my-public-lb",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.idleTimeoutInMinutes",
+				BadValue: 31,
+				Detail:   "API Server load balancer idle timeout should be between 4 and 30 minutes",
+			},
+		},
+		{
+			name: "public LB with idle timeout set to the maximum",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name: "my-pip",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type:                 Public,
+					SKU:                  SKUStandard,
+					IdleTimeoutInMinutes: ptr.To[int32](30),
+				},
+				Name: "my-public-lb",
+			},
+			wantErr: false,
+		},
+		{
+			name: "public LB with existing public IP from another resource group",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name:          "my-static-ip",
+							DNSName:       "my-cluster.eastus.cloudapp.azure.com",
+							ResourceGroup: "my-static-ip-rg",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			wantErr: false,
+		},
+		{
+			name: "public LB with existing public IP from another resource group and no DNS name",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name:          "my-static-ip",
+							ResourceGroup: "my-static-ip-rg",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "apiServerLB.frontendIPConfigs[0].publicIP.dnsName",
+				Detail: "dnsName is required when using an existing public IP from another resource group",
+			},
+		},
+		{
+			name: "public LB with zonal public IP",
+			lb: LoadBalancerSpec{
+				Name: "my-public-lb",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name:  "my-ip",
+							Zones: []string{"1"},
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "public LB with invalid public IP zone",
+			lb: LoadBalancerSpec{
+				Name: "my-public-lb",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name:  "my-ip",
+							Zones: []string{"1", "eastus-2"},
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs[0].publicIP.zones[1]",
+				BadValue: "eastus-2",
+				Detail:   "zone doesn't match regex ^[1-9][0-9]*$",
+			},
+		},
+		{
+			name: "public IP zones are immutable",
+			lb: LoadBalancerSpec{
+				Name: "my-public-lb",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name:  "my-ip",
+							Zones: []string{"1"},
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			old: LoadBalancerSpec{
+				Name: "my-public-lb",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name:  "my-ip",
+							Zones: []string{"1", "2", "3"},
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.frontendIPConfigs[0].publicIP.zones",
+				Detail: "API Server public IP zones should not be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "existing internal LB from another resource group",
+			lb: LoadBalancerSpec{
+				Name:          "my-existing-lb",
+				ResourceGroup: "my-lb-rg",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "10.0.0.10",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+			},
+			cpCIDRS: []string{"10.0.0.0/24"},
+			wantErr: false,
+		},
+		{
+			name: "existing LB with invalid resource group",
+			lb: LoadBalancerSpec{
+				Name:          "my-existing-lb",
+				ResourceGroup: "inv@lid-rg",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.resourceGroup",
+				BadValue: "inv@lid-rg",
+				Detail:   "resourceGroup doesn't match regex ^[-\\w\\._\\(\\)]+$",
+			},
+		},
+		{
+			name: "LB resource group is immutable",
+			lb: LoadBalancerSpec{
+				Name:          "my-lb",
+				ResourceGroup: "my-lb-rg",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			old: LoadBalancerSpec{
+				Name: "my-lb",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.resourceGroup",
+				Detail: "load balancer resource group should not be modified after AzureCluster creation.",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
 			g := NewWithT(t)
 			err := validateAPIServerLB(test.lb, test.old, test.cpCIDRS, field.NewPath("apiServerLB"))
 			if test.wantErr {
@@ -1316,6 +2071,21 @@ func TestValidateControlPlaneNodeOutboundLB(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "cp outbound lb cannot be an existing load balancer",
+			lb:   &LoadBalancerSpec{Name: "foo", ResourceGroup: "my-lb-rg"},
+			apiServerLB: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "controlPlaneOutboundLB.resourceGroup",
+				Detail: "resourceGroup is only supported for the API server and node outbound load balancers",
+			},
+		},
 		{
 			name: "cp outbound lb can be nil for private clusters",
 			lb:   nil,
@@ -1681,6 +2451,909 @@ func TestValidateServiceEndpoints(t *testing.T) {
 	}
 }
 
+func TestValidateDelegations(t *testing.T) {
+	tests := []struct {
+		name        string
+		delegations Delegations
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid delegations",
+			delegations: []DelegationSpec{
+				{Name: "netapp", ServiceName: "Microsoft.NetApp/volumes"},
+				{Name: "webapp", ServiceName: "Microsoft.Web/serverFarms"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid delegation service name",
+			delegations: []DelegationSpec{
+				{Name: "netapp", ServiceName: "NetApp"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].delegations[0].serviceName",
+				BadValue: "NetApp",
+				Detail:   "service name of delegation doesn't match regex ^[a-zA-Z0-9]+(\\.[a-zA-Z0-9]+)+(/[a-zA-Z0-9]+)+$",
+			},
+		},
+		{
+			name: "delegation without service name",
+			delegations: []DelegationSpec{
+				{Name: "netapp"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "subnets[0].delegations[0].serviceName",
+				Detail: "serviceName is required for all delegations",
+			},
+		},
+		{
+			name: "duplicate delegation names",
+			delegations: []DelegationSpec{
+				{Name: "netapp", ServiceName: "Microsoft.NetApp/volumes"},
+				{Name: "netapp", ServiceName: "Microsoft.Web/serverFarms"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].delegations[1].name",
+				BadValue: "netapp",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateDelegations(testCase.delegations, field.NewPath("subnets[0].delegations"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateFlowLogs(t *testing.T) {
+	tests := []struct {
+		name        string
+		flowLogs    FlowLogsSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid flow logs",
+			flowLogs: FlowLogsSpec{
+				StorageAccountID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+				RetentionDays:               7,
+				NetworkWatcherResourceGroup: "NetworkWatcherRG",
+				TrafficAnalytics: &TrafficAnalyticsSpec{
+					WorkspaceResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+					WorkspaceID:         "00000000-0000-0000-0000-000000000000",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid storage account ID",
+			flowLogs: FlowLogsSpec{
+				StorageAccountID: "flowlogs",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.flowLogs.storageAccountID",
+				BadValue: "flowlogs",
+				Detail:   "storage account ID doesn't match regex (?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)",
+			},
+		},
+		{
+			name: "invalid network watcher resource group",
+			flowLogs: FlowLogsSpec{
+				StorageAccountID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+				NetworkWatcherResourceGroup: "inv@lid-rg",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.flowLogs.networkWatcherResourceGroup",
+				BadValue: "inv@lid-rg",
+				Detail:   "resourceGroup doesn't match regex ^[-\\w\\._\\(\\)]+$",
+			},
+		},
+		{
+			name: "traffic analytics without workspace ID",
+			flowLogs: FlowLogsSpec{
+				StorageAccountID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+				TrafficAnalytics: &TrafficAnalyticsSpec{
+					WorkspaceResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "networkSpec.flowLogs.trafficAnalytics.workspaceID",
+				Detail: "workspaceID is required when traffic analytics is enabled",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateFlowLogs(testCase.flowLogs, field.NewPath("networkSpec", "flowLogs"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateVirtualNetworkGateway(t *testing.T) {
+	validSubnet := SubnetSpec{
+		SubnetClassSpec: SubnetClassSpec{
+			Name:       VirtualNetworkGatewaySubnetName,
+			CIDRBlocks: []string{DefaultVirtualNetworkGatewaySubnetCIDR},
+			Role:       SubnetGateway,
+		},
+	}
+	tests := []struct {
+		name        string
+		gateway     VirtualNetworkGatewaySpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid vpn gateway",
+			gateway: VirtualNetworkGatewaySpec{
+				Type:    VirtualNetworkGatewayTypeVPN,
+				SKU:     DefaultVPNGatewaySKU,
+				VPNType: VPNTypeRouteBased,
+				Subnet:  validSubnet,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid expressroute gateway",
+			gateway: VirtualNetworkGatewaySpec{
+				Type:   VirtualNetworkGatewayTypeExpressRoute,
+				SKU:    DefaultExpressRouteGatewaySKU,
+				Subnet: validSubnet,
+			},
+			wantErr: false,
+		},
+		{
+			name: "expressroute sku on a vpn gateway",
+			gateway: VirtualNetworkGatewaySpec{
+				Type:    VirtualNetworkGatewayTypeVPN,
+				SKU:     DefaultExpressRouteGatewaySKU,
+				VPNType: VPNTypeRouteBased,
+				Subnet:  validSubnet,
+			},
+			wantErr: true,
+			expectedErr: *field.NotSupported(field.NewPath("networkSpec", "virtualNetworkGateway", "sku"),
+				DefaultExpressRouteGatewaySKU, virtualNetworkGatewaySKUs[VirtualNetworkGatewayTypeVPN]),
+		},
+		{
+			name: "vpn type on an expressroute gateway",
+			gateway: VirtualNetworkGatewaySpec{
+				Type:    VirtualNetworkGatewayTypeExpressRoute,
+				SKU:     DefaultExpressRouteGatewaySKU,
+				VPNType: VPNTypeRouteBased,
+				Subnet:  validSubnet,
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "networkSpec.virtualNetworkGateway.vpnType",
+				Detail: "vpnType may only be set for VPN gateways",
+			},
+		},
+		{
+			name: "subnet not named GatewaySubnet",
+			gateway: VirtualNetworkGatewaySpec{
+				Type:    VirtualNetworkGatewayTypeVPN,
+				SKU:     DefaultVPNGatewaySKU,
+				VPNType: VPNTypeRouteBased,
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       "my-gateway-subnet",
+						CIDRBlocks: []string{DefaultVirtualNetworkGatewaySubnetCIDR},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.virtualNetworkGateway.subnet.name",
+				BadValue: "my-gateway-subnet",
+				Detail:   "the subnet of a virtual network gateway must be named GatewaySubnet",
+			},
+		},
+		{
+			name: "subnet smaller than /29",
+			gateway: VirtualNetworkGatewaySpec{
+				Type:    VirtualNetworkGatewayTypeVPN,
+				SKU:     DefaultVPNGatewaySKU,
+				VPNType: VPNTypeRouteBased,
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       VirtualNetworkGatewaySubnetName,
+						CIDRBlocks: []string{"10.255.255.248/30"},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.virtualNetworkGateway.subnet.cidrBlocks[0]",
+				BadValue: "10.255.255.248/30",
+				Detail:   "the subnet of a virtual network gateway must be /29 or larger",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateVirtualNetworkGateway(testCase.gateway, field.NewPath("networkSpec", "virtualNetworkGateway"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateRouteServer(t *testing.T) {
+	validSubnet := SubnetSpec{
+		SubnetClassSpec: SubnetClassSpec{
+			Name:       RouteServerSubnetName,
+			CIDRBlocks: []string{DefaultRouteServerSubnetCIDR},
+			Role:       SubnetRouteServer,
+		},
+	}
+	tests := []struct {
+		name        string
+		routeServer RouteServerSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid route server",
+			routeServer: RouteServerSpec{
+				Subnet: validSubnet,
+				BGPPeers: []BGPPeer{
+					{Name: "nva-1", PeerIP: "10.0.0.4", PeerASN: 65001},
+					{Name: "nva-2", PeerIP: "10.0.0.5", PeerASN: 65001},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "subnet not named RouteServerSubnet",
+			routeServer: RouteServerSpec{
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       "my-route-server-subnet",
+						CIDRBlocks: []string{DefaultRouteServerSubnetCIDR},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.routeServer.subnet.name",
+				BadValue: "my-route-server-subnet",
+				Detail:   "the subnet of a Route Server must be named RouteServerSubnet",
+			},
+		},
+		{
+			name: "subnet smaller than /27",
+			routeServer: RouteServerSpec{
+				Subnet: SubnetSpec{
+					SubnetClassSpec: SubnetClassSpec{
+						Name:       RouteServerSubnetName,
+						CIDRBlocks: []string{"10.255.255.160/28"},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.routeServer.subnet.cidrBlocks[0]",
+				BadValue: "10.255.255.160/28",
+				Detail:   "the subnet of a Route Server must be /27 or larger",
+			},
+		},
+		{
+			name: "duplicate peer names",
+			routeServer: RouteServerSpec{
+				Subnet: validSubnet,
+				BGPPeers: []BGPPeer{
+					{Name: "nva", PeerIP: "10.0.0.4", PeerASN: 65001},
+					{Name: "nva", PeerIP: "10.0.0.5", PeerASN: 65001},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "networkSpec.routeServer.bgpPeers[1].name",
+				BadValue: "nva",
+			},
+		},
+		{
+			name: "invalid peer IP",
+			routeServer: RouteServerSpec{
+				Subnet: validSubnet,
+				BGPPeers: []BGPPeer{
+					{Name: "nva", PeerIP: "fd00::4", PeerASN: 65001},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.routeServer.bgpPeers[0].peerIP",
+				BadValue: "fd00::4",
+				Detail:   "peerIP must be a valid IPv4 address",
+			},
+		},
+		{
+			name: "reserved peer ASN",
+			routeServer: RouteServerSpec{
+				Subnet: validSubnet,
+				BGPPeers: []BGPPeer{
+					{Name: "nva", PeerIP: "10.0.0.4", PeerASN: 65515},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.routeServer.bgpPeers[0].peerASN",
+				BadValue: int64(65515),
+				Detail:   "peerASN is reserved by Azure",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateRouteServer(testCase.routeServer, field.NewPath("networkSpec", "routeServer"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerPrivateLinkService(t *testing.T) {
+	networkSpec := NetworkSpec{
+		APIServerLB: LoadBalancerSpec{
+			LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Internal},
+		},
+		Subnets: Subnets{
+			{SubnetClassSpec: SubnetClassSpec{Name: "control-plane-subnet", Role: SubnetControlPlane}},
+			{SubnetClassSpec: SubnetClassSpec{Name: "node-subnet", Role: SubnetNode}},
+			{SubnetClassSpec: SubnetClassSpec{Name: DefaultAzureBastionSubnetName, Role: SubnetBastion}},
+		},
+	}
+	publicNetworkSpec := *networkSpec.DeepCopy()
+	publicNetworkSpec.APIServerLB.Type = Public
+	tests := []struct {
+		name        string
+		pls         PrivateLinkServiceSpec
+		networkSpec NetworkSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid private link service",
+			pls: PrivateLinkServiceSpec{
+				Name:                      "my-cluster-apiserver-pls",
+				SubnetName:                "node-subnet",
+				AllowedSubscriptions:      []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"},
+				AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+			networkSpec: networkSpec,
+			wantErr:     false,
+		},
+		{
+			name: "public API server load balancer",
+			pls: PrivateLinkServiceSpec{
+				Name:       "my-cluster-apiserver-pls",
+				SubnetName: "control-plane-subnet",
+			},
+			networkSpec: publicNetworkSpec,
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "networkSpec.apiServerPrivateLinkService",
+				Detail: "a Private Link Service can only be created for an internal API server load balancer",
+			},
+		},
+		{
+			name: "unknown subnet",
+			pls: PrivateLinkServiceSpec{
+				Name:       "my-cluster-apiserver-pls",
+				SubnetName: "other-subnet",
+			},
+			networkSpec: networkSpec,
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerPrivateLinkService.subnetName",
+				BadValue: "other-subnet",
+				Detail:   "subnetName must be the name of a control plane, node or cluster subnet of the cluster",
+			},
+		},
+		{
+			name: "bastion subnet",
+			pls: PrivateLinkServiceSpec{
+				Name:       "my-cluster-apiserver-pls",
+				SubnetName: DefaultAzureBastionSubnetName,
+			},
+			networkSpec: networkSpec,
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerPrivateLinkService.subnetName",
+				BadValue: DefaultAzureBastionSubnetName,
+				Detail:   "subnetName must be the name of a control plane, node or cluster subnet of the cluster",
+			},
+		},
+		{
+			name: "invalid allowed subscription",
+			pls: PrivateLinkServiceSpec{
+				Name:                 "my-cluster-apiserver-pls",
+				SubnetName:           "control-plane-subnet",
+				AllowedSubscriptions: []string{"my-subscription"},
+			},
+			networkSpec: networkSpec,
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerPrivateLinkService.allowedSubscriptions[0]",
+				BadValue: "my-subscription",
+				Detail:   "must be a valid subscription ID",
+			},
+		},
+		{
+			name: "invalid auto-approved subscription",
+			pls: PrivateLinkServiceSpec{
+				Name:                      "my-cluster-apiserver-pls",
+				SubnetName:                "control-plane-subnet",
+				AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000001", "*"},
+			},
+			networkSpec: networkSpec,
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerPrivateLinkService.autoApprovedSubscriptions[1]",
+				BadValue: "*",
+				Detail:   "must be a valid subscription ID",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateAPIServerPrivateLinkService(testCase.pls, testCase.networkSpec, field.NewPath("networkSpec", "apiServerPrivateLinkService"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerDNS(t *testing.T) {
+	tests := []struct {
+		name                     string
+		apiServerDNS             APIServerDNSSpec
+		controlPlaneEndpointHost string
+		wantErr                  bool
+		expectedErr              field.Error
+	}{
+		{
+			name: "valid API server DNS",
+			apiServerDNS: APIServerDNSSpec{
+				Hostname:  "api.my-cluster.example.com",
+				DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+			},
+			controlPlaneEndpointHost: "api.my-cluster.example.com",
+			wantErr:                  false,
+		},
+		{
+			name: "invalid DNS zone ID",
+			apiServerDNS: APIServerDNSSpec{
+				Hostname:  "api.my-cluster.example.com",
+				DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.com",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerDNS.dnsZoneID",
+				BadValue: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.com",
+				Detail:   "DNS zone ID doesn't match regex " + dnsZoneIDPattern,
+			},
+		},
+		{
+			name: "invalid hostname",
+			apiServerDNS: APIServerDNSSpec{
+				Hostname:  "api_server!.example.com",
+				DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerDNS.hostname",
+				BadValue: "api_server!.example.com",
+				Detail:   "hostname must be a valid DNS name",
+			},
+		},
+		{
+			name: "hostname outside of the DNS zone",
+			apiServerDNS: APIServerDNSSpec{
+				Hostname:  "api.my-cluster.example.org",
+				DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerDNS.hostname",
+				BadValue: "api.my-cluster.example.org",
+				Detail:   "hostname must be a subdomain of the DNS zone example.com",
+			},
+		},
+		{
+			name: "hostname different from the control plane endpoint host",
+			apiServerDNS: APIServerDNSSpec{
+				Hostname:  "api.my-cluster.example.com",
+				DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+			},
+			controlPlaneEndpointHost: "my-cluster.eastus.cloudapp.azure.com",
+			wantErr:                  true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerDNS.hostname",
+				BadValue: "api.my-cluster.example.com",
+				Detail:   "hostname must match the control plane endpoint host my-cluster.eastus.cloudapp.azure.com",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateAPIServerDNS(testCase.apiServerDNS, testCase.controlPlaneEndpointHost, field.NewPath("networkSpec", "apiServerDNS"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateExternallyManagedControlPlaneEndpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        AzureClusterSpec
+		expectedErr []field.Error
+	}{
+		{
+			name: "control plane endpoint not externally managed",
+			spec: AzureClusterSpec{},
+		},
+		{
+			name: "externally managed control plane endpoint",
+			spec: AzureClusterSpec{
+				ControlPlaneEndpoint:                  clusterv1.APIEndpoint{Host: "api.example.com", Port: 443},
+				ExternallyManagedControlPlaneEndpoint: true,
+			},
+		},
+		{
+			name: "externally managed control plane endpoint without host and port",
+			spec: AzureClusterSpec{
+				ExternallyManagedControlPlaneEndpoint: true,
+			},
+			expectedErr: []field.Error{
+				{
+					Type:   "FieldValueRequired",
+					Field:  "spec.controlPlaneEndpoint.host",
+					Detail: "host is required when the control plane endpoint is externally managed",
+				},
+				{
+					Type:   "FieldValueRequired",
+					Field:  "spec.controlPlaneEndpoint.port",
+					Detail: "port is required when the control plane endpoint is externally managed",
+				},
+			},
+		},
+		{
+			name: "externally managed control plane endpoint with API server DNS",
+			spec: AzureClusterSpec{
+				ControlPlaneEndpoint:                  clusterv1.APIEndpoint{Host: "api.example.com", Port: 443},
+				ExternallyManagedControlPlaneEndpoint: true,
+				NetworkSpec: NetworkSpec{
+					APIServerDNS: &APIServerDNSSpec{
+						Hostname:  "api.example.com",
+						DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+					},
+				},
+			},
+			expectedErr: []field.Error{
+				{
+					Type:   "FieldValueForbidden",
+					Field:  "spec.networkSpec.apiServerDNS",
+					Detail: "apiServerDNS cannot be set when the control plane endpoint is externally managed",
+				},
+			},
+		},
+		{
+			name: "externally managed control plane endpoint with API server Private Link Service",
+			spec: AzureClusterSpec{
+				ControlPlaneEndpoint:                  clusterv1.APIEndpoint{Host: "api.example.com", Port: 443},
+				ExternallyManagedControlPlaneEndpoint: true,
+				NetworkSpec: NetworkSpec{
+					APIServerPrivateLinkService: &PrivateLinkServiceSpec{Name: "my-cluster-apiserver-pls"},
+				},
+			},
+			expectedErr: []field.Error{
+				{
+					Type:   "FieldValueForbidden",
+					Field:  "spec.networkSpec.apiServerPrivateLinkService",
+					Detail: "apiServerPrivateLinkService cannot be set when the control plane endpoint is externally managed",
+				},
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateExternallyManagedControlPlaneEndpoint(testCase.spec, field.NewPath("spec"))
+			g.Expect(errs).To(HaveLen(len(testCase.expectedErr)))
+			for _, expectedErr := range testCase.expectedErr {
+				g.Expect(errs).To(ContainElement(MatchError(expectedErr.Error())))
+			}
+		})
+	}
+}
+
+func TestValidatePublicIPResourceGroups(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        AzureClusterSpec
+		expectedErr []field.Error
+	}{
+		{
+			name: "API server public IP in another resource group",
+			spec: AzureClusterSpec{
+				NetworkSpec: NetworkSpec{
+					APIServerLB: LoadBalancerSpec{
+						FrontendIPs: []FrontendIP{{PublicIP: &PublicIPSpec{Name: "pip-apiserver", ResourceGroup: "ip-rg"}}},
+					},
+				},
+			},
+		},
+		{
+			name: "NAT gateway, bastion and node outbound public IPs in another resource group",
+			spec: AzureClusterSpec{
+				NetworkSpec: NetworkSpec{
+					NodeOutboundLB: &LoadBalancerSpec{
+						FrontendIPs: []FrontendIP{{PublicIP: &PublicIPSpec{Name: "pip-node-outbound", ResourceGroup: "ip-rg"}}},
+					},
+					Subnets: Subnets{
+						{SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane}},
+						{
+							SubnetClassSpec: SubnetClassSpec{Role: SubnetNode},
+							NatGateway:      NatGateway{NatGatewayIP: PublicIPSpec{Name: "pip-natgw", ResourceGroup: "ip-rg"}},
+						},
+					},
+				},
+				BastionSpec: BastionSpec{
+					AzureBastion: &AzureBastion{PublicIP: PublicIPSpec{Name: "pip-bastion", ResourceGroup: "ip-rg"}},
+				},
+			},
+			expectedErr: []field.Error{
+				{
+					Type:   "FieldValueForbidden",
+					Field:  "spec.networkSpec.nodeOutboundLB.frontendIPs[0].publicIP.resourceGroup",
+					Detail: "resourceGroup is only supported for the API server load balancer public IP",
+				},
+				{
+					Type:   "FieldValueForbidden",
+					Field:  "spec.networkSpec.subnets[1].natGateway.ip.resourceGroup",
+					Detail: "resourceGroup is only supported for the API server load balancer public IP",
+				},
+				{
+					Type:   "FieldValueForbidden",
+					Field:  "spec.bastionSpec.azureBastion.publicIP.resourceGroup",
+					Detail: "resourceGroup is only supported for the API server load balancer public IP",
+				},
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validatePublicIPResourceGroups(testCase.spec)
+			g.Expect(errs).To(HaveLen(len(testCase.expectedErr)))
+			for _, expectedErr := range testCase.expectedErr {
+				g.Expect(errs).To(ContainElement(MatchError(expectedErr.Error())))
+			}
+		})
+	}
+}
+
+func TestValidateResourceNaming(t *testing.T) {
+	tests := []struct {
+		name        string
+		naming      *ResourceNamingSpec
+		expectedErr []field.Error
+	}{
+		{
+			name: "no resource naming",
+		},
+		{
+			name: "valid resource naming",
+			naming: &ResourceNamingSpec{
+				Prefix:           "corp-",
+				Suffix:           "-01",
+				NetworkInterface: "nic-{machine}-{index}",
+				OSDisk:           "disk-{machine}-os",
+				DataDisk:         "disk-{machine}-{nameSuffix}",
+				PublicIP:         "pip-{machine}",
+			},
+		},
+		{
+			name:   "invalid prefix",
+			naming: &ResourceNamingSpec{Prefix: "corp/"},
+			expectedErr: []field.Error{
+				{
+					Type:     "FieldValueInvalid",
+					Field:    "spec.resourceNaming.prefix",
+					BadValue: "corp/",
+					Detail:   "can only contain alphanumerics, underscores, periods and hyphens",
+				},
+			},
+		},
+		{
+			name:   "template without machine placeholder",
+			naming: &ResourceNamingSpec{OSDisk: "os-disk"},
+			expectedErr: []field.Error{
+				{
+					Type:     "FieldValueInvalid",
+					Field:    "spec.resourceNaming.osDisk",
+					BadValue: "os-disk",
+					Detail:   "must contain the {machine} placeholder",
+				},
+			},
+		},
+		{
+			name:   "data disk template without name suffix placeholder",
+			naming: &ResourceNamingSpec{DataDisk: "{machine}-data"},
+			expectedErr: []field.Error{
+				{
+					Type:     "FieldValueInvalid",
+					Field:    "spec.resourceNaming.dataDisk",
+					BadValue: "{machine}-data",
+					Detail:   "must contain the {nameSuffix} placeholder",
+				},
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateResourceNaming(testCase.naming, field.NewPath("spec", "resourceNaming"))
+			g.Expect(errs).To(HaveLen(len(testCase.expectedErr)))
+			for _, expectedErr := range testCase.expectedErr {
+				g.Expect(errs).To(ContainElement(MatchError(expectedErr.Error())))
+			}
+		})
+	}
+}
+
+func TestValidateRoutes(t *testing.T) {
+	tests := []struct {
+		name        string
+		routes      []Route
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid routes",
+			routes: []Route{
+				{Name: "to-firewall", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.1.0.4"},
+				{Name: "to-onprem", AddressPrefix: "192.168.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid address prefix",
+			routes: []Route{
+				{Name: "to-onprem", AddressPrefix: "192.168.0.0", NextHopType: RouteNextHopTypeVirtualNetworkGateway},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].routeTable.routes[0].addressPrefix",
+				BadValue: "192.168.0.0",
+				Detail:   "invalid CIDR format",
+			},
+		},
+		{
+			name: "virtual appliance without next hop IP address",
+			routes: []Route{
+				{Name: "to-firewall", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				Detail: "nextHopIPAddress is required when nextHopType is VirtualAppliance",
+			},
+		},
+		{
+			name: "virtual appliance with invalid next hop IP address",
+			routes: []Route{
+				{Name: "to-firewall", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.1.0"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				BadValue: "10.1.0",
+				Detail:   "invalid IP address",
+			},
+		},
+		{
+			name: "next hop IP address with another next hop type",
+			routes: []Route{
+				{Name: "to-internet", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet, NextHopIPAddress: "10.1.0.4"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				Detail: "nextHopIPAddress is only allowed when nextHopType is VirtualAppliance",
+			},
+		},
+		{
+			name: "duplicate route names",
+			routes: []Route{
+				{Name: "to-onprem", AddressPrefix: "192.168.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway},
+				{Name: "to-onprem", AddressPrefix: "172.16.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].routeTable.routes[1].name",
+				BadValue: "to-onprem",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateRoutes(testCase.routes, field.NewPath("subnets[0].routeTable.routes"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestServiceEndpointsLackRequiredFieldService(t *testing.T) {
 	type test struct {
 		name             string
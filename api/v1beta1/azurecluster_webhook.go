@@ -17,13 +17,19 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"slices"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -32,9 +38,80 @@ import (
 func (c *AzureCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(c).
+		WithValidator(&azureClusterWebhook{Client: mgr.GetClient()}).
 		Complete()
 }
 
+// azureClusterWebhook implements a validating webhook for AzureClusters. On top of the validation implemented by
+// AzureCluster, it validates the AzureCluster against the network configuration of its Cluster.
+type azureClusterWebhook struct {
+	Client client.Client
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (w *azureClusterWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	c, ok := obj.(*AzureCluster)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureCluster resource")
+	}
+	warnings, err := c.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, w.validateClusterNetwork(ctx, c)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (w *azureClusterWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	c, ok := newObj.(*AzureCluster)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureCluster resource")
+	}
+	warnings, err := c.ValidateUpdate(oldObj)
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, w.validateClusterNetwork(ctx, c)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (w *azureClusterWebhook) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	c, ok := obj.(*AzureCluster)
+	if !ok {
+		return nil, apierrors.NewBadRequest("expected an AzureCluster resource")
+	}
+	return c.ValidateDelete()
+}
+
+// validateClusterNetwork validates that the subnets of the AzureCluster don't overlap the pod and service CIDR blocks
+// of its Cluster. The validation is skipped when the Cluster can't be found yet, which is usually the case when
+// both are created together, and happens on the next update of the AzureCluster instead.
+func (w *azureClusterWebhook) validateClusterNetwork(ctx context.Context, c *AzureCluster) error {
+	clusterName := c.Labels[clusterv1.ClusterNameLabel]
+	for _, ref := range c.OwnerReferences {
+		if gv, err := schema.ParseGroupVersion(ref.APIVersion); err == nil && ref.Kind == "Cluster" && gv.Group == clusterv1.GroupVersion.Group {
+			clusterName = ref.Name
+		}
+	}
+	if clusterName == "" {
+		return nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := w.Client.Get(ctx, client.ObjectKey{Namespace: c.Namespace, Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return apierrors.NewInternalError(err)
+	}
+
+	allErrs := validateClusterNetworkCIDRs(c.Spec.NetworkSpec.Subnets, cluster.Spec.ClusterNetwork, field.NewPath("spec", "networkSpec", "subnets"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind(AzureClusterKind).GroupKind(), c.Name, allErrs)
+}
+
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azurecluster,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azureclusters,versions=v1beta1,name=validation.azurecluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azurecluster,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azureclusters,versions=v1beta1,name=default.azurecluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
@@ -63,6 +140,20 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "nodeResourceGroup"),
+		old.Spec.NodeResourceGroup,
+		c.Spec.NodeResourceGroup); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "resourceNaming"),
+		old.Spec.ResourceNaming,
+		c.Spec.ResourceNaming); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "subscriptionID"),
 		old.Spec.SubscriptionID,
@@ -91,6 +182,13 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings
 		)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "externallyManagedControlPlaneEndpoint"),
+		old.Spec.ExternallyManagedControlPlaneEndpoint,
+		c.Spec.ExternallyManagedControlPlaneEndpoint); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if !reflect.DeepEqual(c.Spec.AzureEnvironment, old.Spec.AzureEnvironment) {
 		// The equality failure could be because of default mismatch between v1alpha3 and v1beta1. This happens because
 		// the new object `r` will have run through the default webhooks but the old object `old` would not have so.
@@ -108,6 +206,13 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings
 		}
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "networkSpec", "apiServerDNS"),
+		old.Spec.NetworkSpec.APIServerDNS,
+		c.Spec.NetworkSpec.APIServerDNS); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "networkSpec", "privateDNSZoneName"),
 		old.Spec.NetworkSpec.PrivateDNSZoneName,
@@ -123,6 +228,61 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings
 		)
 	}
 
+	// Allow adding a virtual network gateway and resizing it, but avoid removing or otherwise changing it.
+	if oldGateway := old.Spec.NetworkSpec.VirtualNetworkGateway; oldGateway != nil {
+		if newGateway := c.Spec.NetworkSpec.VirtualNetworkGateway; newGateway == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "virtualNetworkGateway"),
+					newGateway, "virtual network gateway cannot be removed from a cluster"),
+			)
+		} else {
+			oldWithoutSKU, newWithoutSKU := *oldGateway, *newGateway
+			oldWithoutSKU.SKU, newWithoutSKU.SKU = "", ""
+			if !reflect.DeepEqual(oldWithoutSKU, newWithoutSKU) {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "networkSpec", "virtualNetworkGateway"),
+						newGateway, "only the sku of a virtual network gateway can be changed"),
+				)
+			}
+		}
+	}
+
+	// Allow adding a Route Server and changing its BGP peers, but avoid removing or otherwise changing it.
+	if oldRouteServer := old.Spec.NetworkSpec.RouteServer; oldRouteServer != nil {
+		if newRouteServer := c.Spec.NetworkSpec.RouteServer; newRouteServer == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "routeServer"),
+					newRouteServer, "Route Server cannot be removed from a cluster"),
+			)
+		} else {
+			oldWithoutPeers, newWithoutPeers := *oldRouteServer, *newRouteServer
+			oldWithoutPeers.BGPPeers, newWithoutPeers.BGPPeers = nil, nil
+			oldWithoutPeers.BranchToBranchTraffic, newWithoutPeers.BranchToBranchTraffic = false, false
+			if !reflect.DeepEqual(oldWithoutPeers, newWithoutPeers) {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "networkSpec", "routeServer"),
+						newRouteServer, "only the BGP peers and branch-to-branch traffic of a Route Server can be changed"),
+				)
+			}
+		}
+	}
+
+	// Allow adding a Private Link Service and changing the subscriptions it accepts connections from, but avoid
+	// removing or otherwise changing it.
+	if oldPLS := old.Spec.NetworkSpec.APIServerPrivateLinkService; oldPLS != nil {
+		if newPLS := c.Spec.NetworkSpec.APIServerPrivateLinkService; newPLS == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "apiServerPrivateLinkService"),
+					newPLS, "API server Private Link Service cannot be removed from a cluster"),
+			)
+		} else if oldPLS.Name != newPLS.Name || oldPLS.SubnetName != newPLS.SubnetName {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "apiServerPrivateLinkService"),
+					newPLS, "only the subscriptions of an API server Private Link Service can be changed"),
+			)
+		}
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "networkSpec", "controlPlaneOutboundLB"),
 		old.Spec.NetworkSpec.ControlPlaneOutboundLB,
@@ -130,6 +290,8 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, c.validateVnetUpdate(old)...)
+
 	allErrs = append(allErrs, c.validateSubnetUpdate(old)...)
 
 	if len(allErrs) == 0 {
@@ -139,6 +301,27 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings
 	return nil, apierrors.NewInvalid(GroupVersion.WithKind(AzureClusterKind).GroupKind(), c.Name, allErrs)
 }
 
+// validateVnetUpdate validates that address spaces are only added to, and never removed from, an owned Vnet.
+// Address spaces of a non-owned Vnet are loaded from Azure directly and can change freely.
+func (c *AzureCluster) validateVnetUpdate(old *AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !old.Spec.NetworkSpec.Vnet.Tags.HasOwned(old.Name) {
+		return allErrs
+	}
+
+	for _, oldCIDR := range old.Spec.NetworkSpec.Vnet.CIDRBlocks {
+		if !slices.Contains(c.Spec.NetworkSpec.Vnet.CIDRBlocks, oldCIDR) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "vnet", "cidrBlocks"),
+					c.Spec.NetworkSpec.Vnet.CIDRBlocks, fmt.Sprintf("address space %s cannot be removed from the vnet, only new address spaces can be added", oldCIDR)),
+			)
+		}
+	}
+
+	return allErrs
+}
+
 // validateSubnetUpdate validates a ClusterSpec.NetworkSpec.Subnets for immutability.
 func (c *AzureCluster) validateSubnetUpdate(old *AzureCluster) field.ErrorList {
 	var allErrs field.ErrorList
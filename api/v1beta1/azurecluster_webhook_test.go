@@ -17,10 +17,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAzureCluster_ValidateCreate(t *testing.T) {
@@ -233,6 +237,40 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "azurecluster node resource group is immutable",
+			oldCluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					ResourceGroup:     "demoResourceGroup",
+					NodeResourceGroup: "demoNodeResourceGroup",
+				},
+			},
+			cluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					ResourceGroup:     "demoResourceGroup",
+					NodeResourceGroup: "demoNodeResourceGroup-2",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azurecluster resource naming is immutable",
+			oldCluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						ResourceNaming: &ResourceNamingSpec{Prefix: "corp-"},
+					},
+				},
+			},
+			cluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						ResourceNaming: &ResourceNamingSpec{Prefix: "corp2-"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "azurecluster subscription ID is immutable",
 			oldCluster: &AzureCluster{
@@ -339,6 +377,179 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "address spaces can be added to an owned vnet",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = Tags{ClusterTagKey(cluster.Name): string(ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/16"}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = Tags{ClusterTagKey(cluster.Name): string(ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/16", "10.1.0.0/16"}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "address spaces cannot be removed from an owned vnet",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = Tags{ClusterTagKey(cluster.Name): string(ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/16", "10.1.0.0/16"}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = Tags{ClusterTagKey(cluster.Name): string(ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.1.0.0/16"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "address spaces of a non-owned vnet can change",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/16", "10.1.0.0/16"}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.1.0.0/16"}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name:       "virtual network gateway can be added",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway = createValidVirtualNetworkGateway()
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "virtual network gateway sku can change",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway = createValidVirtualNetworkGateway()
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway = createValidVirtualNetworkGateway()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway.SKU = "VpnGw2AZ"
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "virtual network gateway type is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway = createValidVirtualNetworkGateway()
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway = createValidVirtualNetworkGateway()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway.Type = VirtualNetworkGatewayTypeExpressRoute
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway.SKU = DefaultExpressRouteGatewaySKU
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway.VPNType = ""
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "route server peers can change",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.RouteServer = createValidRouteServer()
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.RouteServer = createValidRouteServer()
+				cluster.Spec.NetworkSpec.RouteServer.BGPPeers = append(cluster.Spec.NetworkSpec.RouteServer.BGPPeers,
+					BGPPeer{Name: "nva-2", PeerIP: "10.0.0.5", PeerASN: 65001})
+				cluster.Spec.NetworkSpec.RouteServer.BranchToBranchTraffic = true
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "route server name is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.RouteServer = createValidRouteServer()
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.RouteServer = createValidRouteServer()
+				cluster.Spec.NetworkSpec.RouteServer.Name = "another-route-server"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "route server cannot be removed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.RouteServer = createValidRouteServer()
+				return cluster
+			}(),
+			cluster: createValidCluster(),
+			wantErr: true,
+		},
+		{
+			name:       "API server private link service can be added",
+			oldCluster: createValidPrivateLinkServiceCluster(nil),
+			cluster:    createValidPrivateLinkServiceCluster(createValidPrivateLinkService()),
+			wantErr:    false,
+		},
+		{
+			name:       "API server private link service subscriptions can change",
+			oldCluster: createValidPrivateLinkServiceCluster(createValidPrivateLinkService()),
+			cluster: func() *AzureCluster {
+				pls := createValidPrivateLinkService()
+				pls.AllowedSubscriptions = append(pls.AllowedSubscriptions, "00000000-0000-0000-0000-000000000002")
+				pls.AutoApprovedSubscriptions = []string{"00000000-0000-0000-0000-000000000002"}
+				return createValidPrivateLinkServiceCluster(pls)
+			}(),
+			wantErr: false,
+		},
+		{
+			name:       "API server private link service subnet is immutable",
+			oldCluster: createValidPrivateLinkServiceCluster(createValidPrivateLinkService()),
+			cluster: func() *AzureCluster {
+				pls := createValidPrivateLinkService()
+				pls.SubnetName = "node-subnet"
+				return createValidPrivateLinkServiceCluster(pls)
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "API server private link service cannot be removed",
+			oldCluster: createValidPrivateLinkServiceCluster(createValidPrivateLinkService()),
+			cluster:    createValidPrivateLinkServiceCluster(nil),
+			wantErr:    true,
+		},
+		{
+			name: "virtual network gateway cannot be removed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway = createValidVirtualNetworkGateway()
+				return cluster
+			}(),
+			cluster: createValidCluster(),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -354,3 +565,147 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureClusterWebhook_ValidateClusterNetwork(t *testing.T) {
+	tests := []struct {
+		name         string
+		azureCluster *AzureCluster
+		wantErr      bool
+	}{
+		{
+			name: "subnets overlap the pod CIDR of the owner cluster",
+			azureCluster: func() *AzureCluster {
+				azureCluster := createValidClusterWithSubnetCIDRs()
+				azureCluster.OwnerReferences = []metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Name: "my-cluster"}}
+				return azureCluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "subnets overlap the pod CIDR of the labeled cluster",
+			azureCluster: func() *AzureCluster {
+				azureCluster := createValidClusterWithSubnetCIDRs()
+				azureCluster.Labels = map[string]string{clusterv1.ClusterNameLabel: "my-cluster"}
+				return azureCluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "cluster not found",
+			azureCluster: func() *AzureCluster {
+				azureCluster := createValidClusterWithSubnetCIDRs()
+				azureCluster.Labels = map[string]string{clusterv1.ClusterNameLabel: "other-cluster"}
+				return azureCluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name:         "no cluster reference",
+			azureCluster: createValidClusterWithSubnetCIDRs(),
+			wantErr:      false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: &clusterv1.ClusterNetwork{
+						Pods: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+					},
+				},
+			}
+			scheme := runtime.NewScheme()
+			_ = AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+			w := &azureClusterWebhook{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()}
+
+			_, err := w.ValidateCreate(context.Background(), tc.azureCluster)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("subnet CIDR overlaps with the cluster pod CIDR 10.0.0.0/8"))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func createValidClusterWithSubnetCIDRs() *AzureCluster {
+	azureCluster := createValidCluster()
+	azureCluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/16"}
+	azureCluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks = []string{"10.0.0.0/24"}
+	azureCluster.Spec.NetworkSpec.Subnets[1].CIDRBlocks = []string{"10.0.1.0/24"}
+	return azureCluster
+}
+
+func createValidVirtualNetworkGateway() *VirtualNetworkGatewaySpec {
+	return &VirtualNetworkGatewaySpec{
+		Name:    "test-cluster-vnet-gateway",
+		Type:    VirtualNetworkGatewayTypeVPN,
+		SKU:     DefaultVPNGatewaySKU,
+		VPNType: VPNTypeRouteBased,
+		Subnet: SubnetSpec{
+			SubnetClassSpec: SubnetClassSpec{
+				Name:       VirtualNetworkGatewaySubnetName,
+				CIDRBlocks: []string{DefaultVirtualNetworkGatewaySubnetCIDR},
+				Role:       SubnetGateway,
+			},
+		},
+		PublicIP: PublicIPSpec{
+			Name: "test-cluster-vnet-gateway-pip",
+		},
+	}
+}
+
+func createValidPrivateLinkService() *PrivateLinkServiceSpec {
+	return &PrivateLinkServiceSpec{
+		Name:                 "test-cluster-apiserver-pls",
+		SubnetName:           "control-plane-subnet",
+		AllowedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+	}
+}
+
+func createValidPrivateLinkServiceCluster(pls *PrivateLinkServiceSpec) *AzureCluster {
+	cluster := createValidCluster()
+	cluster.Spec.NetworkSpec.APIServerLB = LoadBalancerSpec{
+		Name: "my-lb",
+		FrontendIPs: []FrontendIP{
+			{
+				Name: "ip-config",
+				FrontendIPClass: FrontendIPClass{
+					PrivateIPAddress: DefaultInternalLBIPAddress,
+				},
+			},
+		},
+		LoadBalancerClassSpec: LoadBalancerClassSpec{
+			SKU:  SKUStandard,
+			Type: Internal,
+		},
+	}
+	cluster.Spec.NetworkSpec.Vnet = createValidVnet()
+	cluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks = []string{DefaultControlPlaneSubnetCIDR}
+	cluster.Spec.NetworkSpec.APIServerPrivateLinkService = pls
+	return cluster
+}
+
+func createValidRouteServer() *RouteServerSpec {
+	return &RouteServerSpec{
+		Name: "test-cluster-routeserver",
+		Subnet: SubnetSpec{
+			SubnetClassSpec: SubnetClassSpec{
+				Name:       RouteServerSubnetName,
+				CIDRBlocks: []string{DefaultRouteServerSubnetCIDR},
+				Role:       SubnetRouteServer,
+			},
+		},
+		PublicIP: PublicIPSpec{
+			Name: "test-cluster-routeserver-pip",
+		},
+		BGPPeers: []BGPPeer{
+			{Name: "nva-1", PeerIP: "10.0.0.4", PeerASN: 65001},
+		},
+	}
+}
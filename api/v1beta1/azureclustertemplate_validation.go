@@ -62,6 +62,11 @@ func (c *AzureClusterTemplate) validateClusterTemplateSpec() field.ErrorList {
 
 	allErrs = append(allErrs, c.validatePrivateDNSZoneName()...)
 
+	allErrs = append(allErrs, validateResourceNaming(
+		c.Spec.Template.Spec.ResourceNaming,
+		field.NewPath("spec").Child("template").Child("spec").Child("resourceNaming"),
+	)...)
+
 	return allErrs
 }
 
@@ -80,6 +85,19 @@ func (c *AzureClusterTemplate) validateNetworkSpec() field.ErrorList {
 		allErrs = append(allErrs, c.validateNodeOutboundLB()...)
 	}
 
+	var controlPlaneSubnet SubnetSpec
+	for _, subnet := range networkSpec.Subnets {
+		if subnet.Role == SubnetControlPlane {
+			controlPlaneSubnet.SecurityGroup.SecurityGroupClass = subnet.SecurityGroup
+			break
+		}
+	}
+	allErrs = append(allErrs, validateAPIServerAccessList(
+		networkSpec.APIServerAccessList,
+		controlPlaneSubnet,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("apiServerAccessList"),
+	)...)
+
 	return allErrs
 }
 
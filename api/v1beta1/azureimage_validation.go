@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -47,6 +49,53 @@ func ValidateImage(image *Image, fldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// ValidateFIPSImage validates that the image of a FIPS-enabled virtual machine is a FIPS variant.
+// As image metadata doesn't tell whether an image is FIPS-enabled, an image is considered to be a FIPS
+// variant when its marketplace offer or SKU, its gallery image name or its ID contains "fips".
+func ValidateFIPSImage(fips bool, image *Image, osType string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !fips {
+		return allErrs
+	}
+
+	if image == nil {
+		if osType == WindowsOS {
+			allErrs = append(allErrs, field.Required(fldPath, "an image must be provided as FIPS-enabled default images are only available for Linux"))
+		}
+		return allErrs
+	}
+
+	if !isFIPSImage(image) {
+		allErrs = append(allErrs, field.Invalid(fldPath, image, "image must be a FIPS variant when fips is true"))
+	}
+
+	return allErrs
+}
+
+// isFIPSImage returns true if the image is named as a FIPS variant.
+func isFIPSImage(image *Image) bool {
+	var names []string
+	if image.Marketplace != nil {
+		names = append(names, image.Marketplace.Offer, image.Marketplace.SKU)
+	}
+	if image.ComputeGallery != nil {
+		names = append(names, image.ComputeGallery.Name)
+	}
+	if image.SharedGallery != nil {
+		names = append(names, image.SharedGallery.Name)
+	}
+	if image.ID != nil {
+		names = append(names, *image.ID)
+	}
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(name), "fips") {
+			return true
+		}
+	}
+	return false
+}
+
 func validateSingleDetailsOnly(image *Image, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	imageDetailsFound := false
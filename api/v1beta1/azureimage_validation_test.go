@@ -288,3 +288,79 @@ func TestValidateSingleDetailsOnly(t *testing.T) {
 		g.Expect(ValidateImage(tc.image, field.NewPath("image"))).To(Equal(tc.expectedErrors))
 	}
 }
+
+func TestValidateFIPSImage(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fips    bool
+		image   *Image
+		osType  string
+		wantErr bool
+	}{
+		{
+			name:   "fips disabled with a non-FIPS image",
+			image:  &Image{ID: ptr.To("/subscriptions/123/resourceGroups/rg/providers/Microsoft.Compute/images/ubuntu")},
+			osType: LinuxOS,
+		},
+		{
+			name:   "fips enabled with a default Linux image",
+			fips:   true,
+			osType: LinuxOS,
+		},
+		{
+			name:    "fips enabled with a default Windows image",
+			fips:    true,
+			osType:  WindowsOS,
+			wantErr: true,
+		},
+		{
+			name: "fips enabled with a FIPS marketplace image",
+			fips: true,
+			image: &Image{
+				Marketplace: &AzureMarketplaceImage{
+					ImagePlan: ImagePlan{Publisher: "cncf-upstream", Offer: "capi", SKU: "ubuntu-2204-fips-gen1"},
+					Version:   "latest",
+				},
+			},
+			osType: LinuxOS,
+		},
+		{
+			name: "fips enabled with a FIPS compute gallery image",
+			fips: true,
+			image: &Image{
+				ComputeGallery: &AzureComputeGalleryImage{Gallery: "gallery", Name: "Ubuntu-FIPS", Version: "1.0.0"},
+			},
+			osType: WindowsOS,
+		},
+		{
+			name: "fips enabled with a non-FIPS marketplace image",
+			fips: true,
+			image: &Image{
+				Marketplace: &AzureMarketplaceImage{
+					ImagePlan: ImagePlan{Publisher: "cncf-upstream", Offer: "capi", SKU: "ubuntu-2204-gen1"},
+					Version:   "latest",
+				},
+			},
+			osType:  LinuxOS,
+			wantErr: true,
+		},
+		{
+			name:    "fips enabled with a non-FIPS image ID",
+			fips:    true,
+			image:   &Image{ID: ptr.To("/subscriptions/123/resourceGroups/rg/providers/Microsoft.Compute/images/ubuntu")},
+			osType:  LinuxOS,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := ValidateFIPSImage(tc.fips, tc.image, tc.osType, field.NewPath("image"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -18,22 +18,33 @@ package v1beta1
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	utilSSH "sigs.k8s.io/cluster-api-provider-azure/util/ssh"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// ContributorRoleID is the ID of the built-in "Contributor" role.
-const ContributorRoleID = "b24988ac-6180-42a0-ab88-20f7382dd24c"
+const (
+	// ContributorRoleID is the ID of the built-in "Contributor" role.
+	ContributorRoleID = "b24988ac-6180-42a0-ab88-20f7382dd24c"
+	// SSHPublicKeySecretKey is the key of the public key in the secret holding a cluster's generated SSH key pair.
+	// The private key is stored under corev1.SSHAuthPrivateKey.
+	SSHPublicKeySecretKey = "ssh-publickey"
+)
 
 // SetDefaultSSHPublicKey sets the default SSHPublicKey for an AzureMachine.
 func (s *AzureMachineSpec) SetDefaultSSHPublicKey() error {
@@ -48,6 +59,75 @@ func (s *AzureMachineSpec) SetDefaultSSHPublicKey() error {
 	return nil
 }
 
+// SSHKeySecretName returns the name of the secret holding the SSH key pair generated for the machines of a cluster.
+func SSHKeySecretName(clusterName string) string {
+	return clusterName + "-ssh-key"
+}
+
+// SetDefaultSSHPublicKeyFromSecret sets the SSHPublicKey of an AzureMachine without one to the public key stored
+// in the cluster's SSH key secret, generating the key pair and creating the secret if it doesn't exist yet.
+// The secret is not created for dry-run requests.
+func (m *AzureMachine) SetDefaultSSHPublicKeyFromSecret(ctx context.Context, cli client.Client, dryRun bool) error {
+	clusterName, ok := m.Labels[clusterv1.ClusterNameLabel]
+	if m.Spec.SSHPublicKey != "" || !ok {
+		return nil
+	}
+
+	key := client.ObjectKey{Namespace: m.Namespace, Name: SSHKeySecretName(clusterName)}
+	secret := &corev1.Secret{}
+	err := cli.Get(ctx, key, secret)
+	switch {
+	case err == nil:
+		m.Spec.SSHPublicKey = base64.StdEncoding.EncodeToString(secret.Data[SSHPublicKeySecretKey])
+		return nil
+	case !apierrors.IsNotFound(err):
+		return errors.Wrapf(err, "failed to get SSH key secret %s", key)
+	case dryRun:
+		return nil
+	}
+
+	privateKey, publicKey, err := utilSSH.GenerateSSHKey()
+	if err != nil {
+		return err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:       clusterName,
+				clusterctlv1.ClusterctlMoveLabel: "",
+			},
+		},
+		Type: corev1.SecretTypeSSHAuth,
+		Data: map[string][]byte{
+			corev1.SSHAuthPrivateKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}),
+			SSHPublicKeySecretKey:    ssh.MarshalAuthorizedKey(publicKey),
+		},
+	}
+	cluster := &clusterv1.Cluster{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: m.Namespace, Name: clusterName}, cluster); err == nil && cluster.UID != "" {
+		secret.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+			Name:       cluster.Name,
+			UID:        cluster.UID,
+		}}
+	}
+	if err := cli.Create(ctx, secret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create SSH key secret %s", key)
+		}
+		// Another machine of the cluster created the secret first, so use its key instead.
+		if err := cli.Get(ctx, key, secret); err != nil {
+			return errors.Wrapf(err, "failed to get SSH key secret %s", key)
+		}
+	}
+
+	m.Spec.SSHPublicKey = base64.StdEncoding.EncodeToString(secret.Data[SSHPublicKeySecretKey])
+	return nil
+}
+
 // SetDefaultCachingType sets the default cache type for an AzureMachine.
 func (s *AzureMachineSpec) SetDefaultCachingType() {
 	if s.OSDisk.CachingType == "" {
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -27,11 +28,14 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAzureMachineSpec_SetDefaultSSHPublicKey(t *testing.T) {
@@ -54,6 +58,103 @@ func TestAzureMachineSpec_SetDefaultSSHPublicKey(t *testing.T) {
 	g.Expect(publicKeyNotExistTest.machine.Spec.SSHPublicKey).To(Not(BeEmpty()))
 }
 
+func TestAzureMachine_SetDefaultSSHPublicKeyFromSecret(t *testing.T) {
+	existingSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster-ssh-key",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			SSHPublicKeySecretKey: []byte("existing-public-key"),
+		},
+	}
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "default",
+			UID:       "test-cluster-uid",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		sshPublicKey  string
+		clusterLabel  bool
+		dryRun        bool
+		objects       []client.Object
+		expectedKey   string
+		expectCreated bool
+	}{
+		{
+			name:         "public key is already set",
+			sshPublicKey: "user-public-key",
+			clusterLabel: true,
+			expectedKey:  "user-public-key",
+		},
+		{
+			name:         "machine without a cluster",
+			clusterLabel: false,
+			expectedKey:  "",
+		},
+		{
+			name:         "secret exists",
+			clusterLabel: true,
+			objects:      []client.Object{existingSecret},
+			expectedKey:  base64.StdEncoding.EncodeToString([]byte("existing-public-key")),
+		},
+		{
+			name:          "secret is created",
+			clusterLabel:  true,
+			objects:       []client.Object{cluster},
+			expectCreated: true,
+		},
+		{
+			name:         "secret is not created on dry run",
+			clusterLabel: true,
+			dryRun:       true,
+			expectedKey:  "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+
+			machine := &AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: "default"},
+				Spec:       AzureMachineSpec{SSHPublicKey: tc.sshPublicKey},
+			}
+			if tc.clusterLabel {
+				machine.Labels = map[string]string{clusterv1.ClusterNameLabel: "test-cluster"}
+			}
+
+			err := machine.SetDefaultSSHPublicKeyFromSecret(context.Background(), fakeClient, tc.dryRun)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			getErr := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-cluster-ssh-key"}, secret)
+			if !tc.expectCreated {
+				g.Expect(machine.Spec.SSHPublicKey).To(Equal(tc.expectedKey))
+				if len(tc.objects) == 0 {
+					g.Expect(apierrors.IsNotFound(getErr)).To(BeTrue())
+				}
+				return
+			}
+
+			g.Expect(getErr).NotTo(HaveOccurred())
+			g.Expect(secret.Type).To(Equal(corev1.SecretTypeSSHAuth))
+			g.Expect(secret.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "test-cluster"))
+			g.Expect(secret.OwnerReferences).To(HaveLen(1))
+			g.Expect(secret.OwnerReferences[0].UID).To(Equal(cluster.UID))
+			g.Expect(secret.Data[corev1.SSHAuthPrivateKey]).NotTo(BeEmpty())
+			g.Expect(machine.Spec.SSHPublicKey).To(Equal(base64.StdEncoding.EncodeToString(secret.Data[SSHPublicKeySecretKey])))
+		})
+	}
+}
+
 func TestAzureMachineSpec_SetIdentityDefaults(t *testing.T) {
 	g := NewWithT(t)
 
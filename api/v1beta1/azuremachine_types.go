@@ -38,6 +38,12 @@ type AzureMachineSpec struct {
 
 	VMSize string `json:"vmSize"`
 
+	// ResizePolicy describes how changes to VMSize are handled. Immutable, the default, rejects changes to VMSize, so
+	// resizing a machine requires replacing it. InPlace allows changing VMSize, in which case the VM is deallocated,
+	// resized and started again, keeping its disks but taking it down for a few minutes.
+	// +optional
+	ResizePolicy VMResizePolicy `json:"resizePolicy,omitempty"`
+
 	// FailureDomain is the failure domain unique identifier this Machine should be attached to,
 	// as defined in Cluster API. This relates to an Azure Availability Zone
 	// +optional
@@ -50,6 +56,12 @@ type AzureMachineSpec struct {
 	// +optional
 	Image *Image `json:"image,omitempty"`
 
+	// FIPS specifies whether the virtual machine runs a FIPS 140-2 validated image. When true and image is omitted,
+	// the image defaults to the FIPS-enabled variant of the Azure Marketplace "capi" offer; when an image is provided,
+	// it must be a FIPS variant. FIPS-enabled default images are only available for Linux.
+	// +optional
+	FIPS bool `json:"fips,omitempty"`
+
 	// Identity is the type of identity used for the virtual machine.
 	// The type 'SystemAssigned' is an implicitly created identity.
 	// The generated identity will be assigned a Subscription contributor role.
@@ -100,6 +112,18 @@ type AzureMachineSpec struct {
 	// +optional
 	AllocatePublicIP bool `json:"allocatePublicIP,omitempty"`
 
+	// EnablePublicIPDNSLabel assigns a DNS name label, generated from the machine name, to the public IP allocated for
+	// the machine so it can be reached at "<label>.<location>.cloudapp.azure.com" instead of by its IP address.
+	// It may only be set to true when AllocatePublicIP is true.
+	// +optional
+	EnablePublicIPDNSLabel bool `json:"enablePublicIPDNSLabel,omitempty"`
+
+	// PublicIPPrefixID is the resource ID of an existing public IP prefix the machine's public IP is allocated from,
+	// so that all node public IPs fall within a single, known CIDR range.
+	// It may only be set when AllocatePublicIP is true.
+	// +optional
+	PublicIPPrefixID *string `json:"publicIPPrefixID,omitempty"`
+
 	// EnableIPForwarding enables IP Forwarding in Azure which is required for some CNI's to send traffic from a pods on one machine
 	// to another. This is required for IpV6 with Calico in combination with User Defined Routes (set by the Azure Cloud Controller
 	// manager). Default is false for disabled.
@@ -142,6 +166,42 @@ type AzureMachineSpec struct {
 	// +optional
 	VMExtensions []VMExtension `json:"vmExtensions,omitempty"`
 
+	// InstallGPUDriver installs the Azure GPU driver VM extension matching the GPU vendor of the VM size, i.e. NVIDIA
+	// or AMD, when the VM size has GPUs. The AMD GPU driver extension is only available for Windows.
+	// +optional
+	InstallGPUDriver *bool `json:"installGPUDriver,omitempty"`
+
+	// JITAccess registers the virtual machine with a Microsoft Defender for Cloud just-in-time (JIT) VM access
+	// policy, so that access to its ports is requested for a limited time instead of being allowed by standing
+	// security rules. Microsoft Defender for Servers must be enabled on the subscription.
+	// +optional
+	JITAccess *JITAccess `json:"jitAccess,omitempty"`
+
+	// WindowsConfiguration specifies settings for Windows virtual machines, and may only be set when the OS disk
+	// OS type is Windows.
+	// +optional
+	WindowsConfiguration *WindowsConfiguration `json:"windowsConfiguration,omitempty"`
+
+	// BootstrapDataStorage specifies a storage account the bootstrap data of the virtual machine is uploaded to
+	// when it exceeds the 64 KiB custom data limit of Azure virtual machines, for instance with large cloud-init or
+	// ignition configurations. The virtual machine then receives a small custom data fetching its bootstrap data
+	// from the storage account at boot. The bootstrap data is passed as custom data when this isn't set.
+	// +optional
+	BootstrapDataStorage *BootstrapDataStorage `json:"bootstrapDataStorage,omitempty"`
+
+	// BootstrapDataKeyVault specifies a key vault the bootstrap data of the virtual machine is stored in as secrets,
+	// which the virtual machine reads at boot with its managed identity, so that bootstrap tokens and certificates
+	// never appear in its custom data. The identity of the virtual machine must be allowed to read the secrets of the
+	// key vault, and only Linux virtual machines with cloud-init bootstrap data are supported. It may not be set
+	// together with BootstrapDataStorage.
+	// +optional
+	BootstrapDataKeyVault *BootstrapDataKeyVault `json:"bootstrapDataKeyVault,omitempty"`
+
+	// ApplicationSecurityGroups is the list of application security groups the network interfaces of the virtual
+	// machine join, in addition to the ones of its subnets.
+	// +optional
+	ApplicationSecurityGroups ApplicationSecurityGroupReferences `json:"applicationSecurityGroups,omitempty"`
+
 	// NetworkInterfaces specifies a list of network interface configurations.
 	// If left unspecified, the VM will get a single network interface with a
 	// single IPConfig in the subnet specified in the cluster's node subnet field.
@@ -168,6 +228,33 @@ type SpotVMOptions struct {
 	// EvictionPolicy defines the behavior of the virtual machine when it is evicted. It can be either Delete or Deallocate.
 	// +optional
 	EvictionPolicy *SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
+
+	// PlacementCheck checks the likelihood that Spot VMs of the VM size are allocated and not evicted quickly in the
+	// location, based on the Spot Placement Score and the eviction rate reported by Azure, before creating them.
+	// +optional
+	PlacementCheck *SpotPlacementCheck `json:"placementCheck,omitempty"`
+}
+
+// SpotPlacementCheck configures the preflight check of Spot VM allocation and eviction.
+type SpotPlacementCheck struct {
+	// Policy is what to do when the check fails. Warn reports the failure in the SpotCapacityAvailable condition and
+	// creates the Spot VMs anyway, Fail doesn't create them until the check passes.
+	// +kubebuilder:default=Warn
+	// +optional
+	Policy SpotPlacementCheckPolicy `json:"policy,omitempty"`
+
+	// MinimumScore is the lowest acceptable Spot Placement Score of the VM size in the location.
+	// +kubebuilder:default=Medium
+	// +optional
+	MinimumScore SpotPlacementScore `json:"minimumScore,omitempty"`
+
+	// MaximumEvictionRate is the highest acceptable eviction rate of the VM size in the location, in percent. Azure
+	// reports eviction rates as ranges, e.g. 5-10, and the check fails when the upper bound of the range exceeds it.
+	// The eviction rate isn't checked if omitted.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaximumEvictionRate *int32 `json:"maximumEvictionRate,omitempty"`
 }
 
 // SystemAssignedIdentityRole defines the role and scope to assign to the system assigned identity.
@@ -19,8 +19,14 @@ package v1beta1
 import (
 	"encoding/base64"
 	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/blang/semver"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/ssh"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -36,6 +42,10 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateFIPSImage(spec.FIPS, spec.Image, spec.OSDisk.OSType, field.NewPath("image")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if errs := ValidateOSDisk(spec.OSDisk, field.NewPath("osDisk")); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
@@ -64,6 +74,10 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateApplicationSecurityGroupReferences(spec.ApplicationSecurityGroups, field.NewPath("applicationSecurityGroups")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if errs := ValidateSystemAssignedIdentityRole(spec.Identity, spec.RoleAssignmentName, spec.SystemAssignedIdentityRole, field.NewPath("systemAssignedIdentityRole")); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
@@ -76,6 +90,38 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateGPUDriver(spec.InstallGPUDriver, spec.DisableExtensionOperations, field.NewPath("installGPUDriver")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateJITAccess(spec.JITAccess, field.NewPath("jitAccess")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateWindowsConfiguration(spec.WindowsConfiguration, spec.OSDisk.OSType, spec.DisableExtensionOperations, field.NewPath("windowsConfiguration")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateBootstrapDataStorage(spec.BootstrapDataStorage, spec.Identity, field.NewPath("bootstrapDataStorage")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateBootstrapDataKeyVault(spec.BootstrapDataKeyVault, spec.BootstrapDataStorage, spec.Identity, spec.UserAssignedIdentities, spec.OSDisk.OSType, field.NewPath("bootstrapDataKeyVault")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidatePublicIPDNSLabel(spec.AllocatePublicIP, spec.EnablePublicIPDNSLabel, field.NewPath("enablePublicIPDNSLabel")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if spec.PublicIPPrefixID != nil && !spec.AllocatePublicIP {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("publicIPPrefixID"), "publicIPPrefixID may only be set when allocatePublicIP is true"))
+	}
+
+	if err := validatePublicIPPrefixID(spec.PublicIPPrefixID, field.NewPath("publicIPPrefixID")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	return allErrs
 }
 
@@ -421,6 +467,12 @@ func ValidateDiagnostics(diagnostics *Diagnostics, fieldPath *field.Path) field.
 		}
 	}
 
+	if diagnostics != nil && diagnostics.CaptureOnFailure &&
+		(diagnostics.Boot == nil || diagnostics.Boot.StorageAccountType != UserManagedDiagnosticsStorage) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("captureOnFailure"), diagnostics.CaptureOnFailure,
+			fmt.Sprintf("captureOnFailure requires boot diagnostics with storageAccountType '%s'", UserManagedDiagnosticsStorage)))
+	}
+
 	return allErrs
 }
 
@@ -496,3 +548,252 @@ func ValidateVMExtensions(disableExtensionOperations *bool, vmExtensions []VMExt
 
 	return allErrs
 }
+
+// ValidateGPUDriver validates that the GPU driver extension isn't requested when extension operations are disabled.
+func ValidateGPUDriver(installGPUDriver, disableExtensionOperations *bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ptr.Deref(installGPUDriver, false) && ptr.Deref(disableExtensionOperations, false) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "installGPUDriver can't be true when DisableExtensionOperations is true"))
+	}
+
+	return allErrs
+}
+
+// maxJITAccessDuration is the maximum duration of the access granted by a just-in-time VM access request.
+const maxJITAccessDuration = 24 * time.Hour
+
+// ValidateJITAccess validates the JITAccess spec.
+func ValidateJITAccess(jitAccess *JITAccess, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if jitAccess == nil {
+		return allErrs
+	}
+
+	ports := make(map[string]bool, len(jitAccess.Ports))
+	for i, port := range jitAccess.Ports {
+		portPath := fldPath.Child("ports").Index(i)
+		key := fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+		if ports[key] {
+			allErrs = append(allErrs, field.Duplicate(portPath, key))
+		}
+		ports[key] = true
+
+		for j, cidr := range port.AllowedSourceCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				allErrs = append(allErrs, field.Invalid(portPath.Child("allowedSourceCIDRs").Index(j), cidr, "must be a valid CIDR"))
+			}
+		}
+
+		if port.MaxRequestAccessDuration != nil {
+			duration := port.MaxRequestAccessDuration.Duration
+			if duration < time.Minute || duration > maxJITAccessDuration || duration%time.Minute != 0 {
+				allErrs = append(allErrs, field.Invalid(portPath.Child("maxRequestAccessDuration"), duration.String(),
+					fmt.Sprintf("must be a whole number of minutes between 1m and %s", maxJITAccessDuration)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+var (
+	storageAccountNameRegex = regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+	blobContainerNameRegex  = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{1,61}[a-z0-9])?$`)
+	keyVaultNameRegex       = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{1,22}[a-zA-Z0-9]$`)
+)
+
+// ValidateBootstrapDataStorage validates the BootstrapDataStorage spec.
+func ValidateBootstrapDataStorage(storage *BootstrapDataStorage, identity VMIdentity, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if storage == nil {
+		return allErrs
+	}
+
+	if !storageAccountNameRegex.MatchString(storage.StorageAccountName) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("storageAccountName"), storage.StorageAccountName,
+			"must be 3 to 24 lowercase letters and numbers"))
+	}
+
+	if storage.ContainerName != "" && (!blobContainerNameRegex.MatchString(storage.ContainerName) || strings.Contains(storage.ContainerName, "--")) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("containerName"), storage.ContainerName,
+			"must be 3 to 63 lowercase letters, numbers and single hyphens, starting and ending with a letter or number"))
+	}
+
+	if storage.Access == BootstrapDataAccessManagedIdentity && (identity == "" || identity == VMIdentityNone) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("access"), storage.Access,
+			"requires the virtual machine to have a system-assigned or user-assigned identity"))
+	}
+
+	return allErrs
+}
+
+// ValidateBootstrapDataKeyVault validates the BootstrapDataKeyVault spec.
+func ValidateBootstrapDataKeyVault(keyVault *BootstrapDataKeyVault, storage *BootstrapDataStorage, identity VMIdentity, userAssignedIdentities []UserAssignedIdentity, osType string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if keyVault == nil {
+		return allErrs
+	}
+
+	if storage != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "may not be set together with bootstrapDataStorage"))
+	}
+
+	if !keyVaultNameRegex.MatchString(keyVault.VaultName) || strings.Contains(keyVault.VaultName, "--") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("vaultName"), keyVault.VaultName,
+			"must be 3 to 24 letters, numbers and single hyphens, starting with a letter and ending with a letter or number"))
+	}
+
+	switch {
+	case identity == "" || identity == VMIdentityNone:
+		allErrs = append(allErrs, field.Invalid(fldPath, keyVault.VaultName,
+			"requires the virtual machine to have a system-assigned or user-assigned identity"))
+	case identity == VMIdentityUserAssigned && len(userAssignedIdentities) > 1 && keyVault.IdentityClientID == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("identityClientID"),
+			"must be set when the virtual machine has several user-assigned identities"))
+	}
+
+	if osType == WindowsOS {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "is not supported for Windows virtual machines"))
+	}
+
+	return allErrs
+}
+
+// ValidateWindowsConfiguration validates the WindowsConfiguration spec.
+func ValidateWindowsConfiguration(windowsConfiguration *WindowsConfiguration, osType string, disableExtensionOperations *bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if windowsConfiguration == nil {
+		return allErrs
+	}
+
+	if osType != WindowsOS {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "windowsConfiguration may only be set when osDisk.osType is Windows"))
+	}
+
+	if enableAutomaticUpdates := windowsConfiguration.EnableAutomaticUpdates; enableAutomaticUpdates != nil {
+		switch windowsConfiguration.PatchMode {
+		case WindowsPatchModeAutomaticByOS, WindowsPatchModeAutomaticByPlatform:
+			if !*enableAutomaticUpdates {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("enableAutomaticUpdates"), *enableAutomaticUpdates, fmt.Sprintf("enableAutomaticUpdates must be true when patchMode is %s", windowsConfiguration.PatchMode)))
+			}
+		case WindowsPatchModeManual:
+			if *enableAutomaticUpdates {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("enableAutomaticUpdates"), *enableAutomaticUpdates, fmt.Sprintf("enableAutomaticUpdates must be false when patchMode is %s", windowsConfiguration.PatchMode)))
+			}
+		}
+	}
+
+	if domainJoin := windowsConfiguration.DomainJoin; domainJoin != nil {
+		domainJoinPath := fldPath.Child("domainJoin")
+		if domainJoin.DomainName == "" {
+			allErrs = append(allErrs, field.Required(domainJoinPath.Child("domainName"), "domainName is required"))
+		}
+		if domainJoin.User == "" {
+			allErrs = append(allErrs, field.Required(domainJoinPath.Child("user"), "user is required"))
+		}
+		if domainJoin.PasswordSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(domainJoinPath.Child("passwordSecretRef", "name"), "passwordSecretRef name is required"))
+		}
+		if ptr.Deref(disableExtensionOperations, false) {
+			allErrs = append(allErrs, field.Forbidden(domainJoinPath, "domainJoin uses a VM extension and may not be set when disableExtensionOperations is true"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidatePublicIPDNSLabel validates that a DNS name label is only requested for an allocated public IP.
+func ValidatePublicIPDNSLabel(allocatePublicIP, enablePublicIPDNSLabel bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if enablePublicIPDNSLabel && !allocatePublicIP {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "enablePublicIPDNSLabel may only be set when allocatePublicIP is true"))
+	}
+
+	return allErrs
+}
+
+// ValidateSpotVMOptions validates that spot VMs are not used for control plane machines, which must not be evicted.
+func ValidateSpotVMOptions(spotVMOptions *SpotVMOptions, isControlPlane bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spotVMOptions != nil && isControlPlane {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "spot VMs may be evicted at any time and are not supported for control plane machines"))
+	}
+
+	return allErrs
+}
+
+const (
+	// referenceImagePublisher is the Azure Marketplace publisher of the reference images of Cluster API for Azure.
+	referenceImagePublisher = "cncf-upstream"
+	// referenceImageOffer is the Azure Marketplace offer of the Linux reference images.
+	referenceImageOffer = "capi"
+	// referenceWindowsImageOffer is the Azure Marketplace offer of the Windows reference images.
+	referenceWindowsImageOffer = "capi-windows"
+)
+
+var (
+	// referenceImageSKURegex matches the SKUs of older reference images, which contain their Kubernetes version, e.g.
+	// "k8s-1dot21dot2-ubuntu-2004".
+	referenceImageSKURegex = regexp.MustCompile(`^k8s-(\d+)dot(\d+)dot(\d+)-`)
+	// referenceImageVersionRegex matches the versions of newer reference images, which start with their Kubernetes
+	// version, e.g. "128.3.20231101" for Kubernetes v1.28.3.
+	referenceImageVersionRegex = regexp.MustCompile(`^(\d)(\d+)\.(\d+)\.\d+$`)
+)
+
+// ValidateImageKubernetesVersion validates that a reference image from the Azure Marketplace is built for the
+// Kubernetes version of the machine, so that machines which can't join the cluster are rejected upfront. Other images
+// don't identify their Kubernetes version, and aren't validated.
+func ValidateImageKubernetesVersion(image *Image, k8sVersion string, fldPath *field.Path) field.ErrorList {
+	if image == nil || image.Marketplace == nil || k8sVersion == "" {
+		return nil
+	}
+	imageVersion, ok := referenceImageKubernetesVersion(image.Marketplace)
+	if !ok {
+		return nil
+	}
+	// The Kubernetes version of the machine is validated by Cluster API.
+	v, err := semver.ParseTolerant(k8sVersion)
+	if err != nil {
+		return nil
+	}
+	if v.Major != imageVersion.Major || v.Minor != imageVersion.Minor || v.Patch != imageVersion.Patch {
+		return field.ErrorList{field.Invalid(fldPath.Child("marketplace"), image.Marketplace,
+			fmt.Sprintf("reference image is built for Kubernetes v%s, not the Kubernetes version %s of the machine", imageVersion, k8sVersion))}
+	}
+	return nil
+}
+
+// referenceImageKubernetesVersion returns the Kubernetes version of a reference image of Cluster API for Azure,
+// and whether its SKU or version identifies one.
+func referenceImageKubernetesVersion(image *AzureMarketplaceImage) (semver.Version, bool) {
+	if image.Publisher != referenceImagePublisher || (image.Offer != referenceImageOffer && image.Offer != referenceWindowsImageOffer) {
+		return semver.Version{}, false
+	}
+	if m := referenceImageSKURegex.FindStringSubmatch(image.SKU); m != nil {
+		return versionFromMatch(m)
+	}
+	if m := referenceImageVersionRegex.FindStringSubmatch(image.Version); m != nil {
+		return versionFromMatch(m)
+	}
+	return semver.Version{}, false
+}
+
+// versionFromMatch returns the version made of the major, minor and patch numbers of a regex match.
+func versionFromMatch(match []string) (semver.Version, bool) {
+	var parts [3]uint64
+	for i := range parts {
+		n, err := strconv.ParseUint(match[i+1], 10, 64)
+		if err != nil {
+			return semver.Version{}, false
+		}
+		parts[i] = n
+	}
+	return semver.Version{Major: parts[0], Minor: parts[1], Patch: parts[2]}, true
+}
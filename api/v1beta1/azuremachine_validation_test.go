@@ -22,11 +22,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 )
@@ -961,6 +964,461 @@ func TestAzureMachine_ValidateNetwork(t *testing.T) {
 	}
 }
 
+func TestAzureMachine_ValidatePublicIPDNSLabel(t *testing.T) {
+	tests := []struct {
+		name                   string
+		allocatePublicIP       bool
+		enablePublicIPDNSLabel bool
+		wantErr                bool
+	}{
+		{
+			name:                   "no public IP and no DNS label",
+			allocatePublicIP:       false,
+			enablePublicIPDNSLabel: false,
+			wantErr:                false,
+		},
+		{
+			name:                   "public IP with DNS label",
+			allocatePublicIP:       true,
+			enablePublicIPDNSLabel: true,
+			wantErr:                false,
+		},
+		{
+			name:                   "DNS label without public IP",
+			allocatePublicIP:       false,
+			enablePublicIPDNSLabel: true,
+			wantErr:                true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidatePublicIPDNSLabel(tc.allocatePublicIP, tc.enablePublicIPDNSLabel, field.NewPath("enablePublicIPDNSLabel"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateJITAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		jitAccess *JITAccess
+		wantErr   bool
+	}{
+		{
+			name:      "no just-in-time VM access",
+			jitAccess: nil,
+			wantErr:   false,
+		},
+		{
+			name: "valid ports",
+			jitAccess: &JITAccess{Ports: []JITAccessPort{
+				{Port: 22, Protocol: JITAccessProtocolTCP, AllowedSourceCIDRs: []string{"10.0.0.0/8"}, MaxRequestAccessDuration: &metav1.Duration{Duration: 90 * time.Minute}},
+				{Port: 3389, Protocol: JITAccessProtocolTCP},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "duplicate ports",
+			jitAccess: &JITAccess{Ports: []JITAccessPort{
+				{Port: 22, Protocol: JITAccessProtocolTCP},
+				{Port: 22, Protocol: JITAccessProtocolTCP},
+			}},
+			wantErr: true,
+		},
+		{
+			name:      "invalid source CIDR",
+			jitAccess: &JITAccess{Ports: []JITAccessPort{{Port: 22, AllowedSourceCIDRs: []string{"10.0.0.1"}}}},
+			wantErr:   true,
+		},
+		{
+			name:      "duration longer than a day",
+			jitAccess: &JITAccess{Ports: []JITAccessPort{{Port: 22, MaxRequestAccessDuration: &metav1.Duration{Duration: 25 * time.Hour}}}},
+			wantErr:   true,
+		},
+		{
+			name:      "duration not in whole minutes",
+			jitAccess: &JITAccess{Ports: []JITAccessPort{{Port: 22, MaxRequestAccessDuration: &metav1.Duration{Duration: 90 * time.Second}}}},
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateJITAccess(tc.jitAccess, field.NewPath("jitAccess"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateGPUDriver(t *testing.T) {
+	tests := []struct {
+		name                       string
+		installGPUDriver           *bool
+		disableExtensionOperations *bool
+		wantErr                    bool
+	}{
+		{
+			name:             "GPU driver not requested",
+			installGPUDriver: nil,
+			wantErr:          false,
+		},
+		{
+			name:             "GPU driver requested",
+			installGPUDriver: ptr.To(true),
+			wantErr:          false,
+		},
+		{
+			name:                       "GPU driver not requested with extension operations disabled",
+			installGPUDriver:           ptr.To(false),
+			disableExtensionOperations: ptr.To(true),
+			wantErr:                    false,
+		},
+		{
+			name:                       "GPU driver requested with extension operations disabled",
+			installGPUDriver:           ptr.To(true),
+			disableExtensionOperations: ptr.To(true),
+			wantErr:                    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateGPUDriver(tc.installGPUDriver, tc.disableExtensionOperations, field.NewPath("installGPUDriver"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateWindowsConfiguration(t *testing.T) {
+	domainJoin := &WindowsDomainJoin{
+		DomainName:        "contoso.com",
+		User:              "contoso.com\\joiner",
+		PasswordSecretRef: corev1.LocalObjectReference{Name: "domain-join"},
+	}
+	tests := []struct {
+		name                       string
+		windowsConfiguration       *WindowsConfiguration
+		osType                     string
+		disableExtensionOperations *bool
+		wantErr                    bool
+	}{
+		{
+			name:                 "no windows configuration",
+			windowsConfiguration: nil,
+			osType:               LinuxOS,
+			wantErr:              false,
+		},
+		{
+			name:                 "windows machine joining a domain",
+			windowsConfiguration: &WindowsConfiguration{DomainJoin: domainJoin},
+			osType:               WindowsOS,
+			wantErr:              false,
+		},
+		{
+			name:                 "linux machine with windows configuration",
+			windowsConfiguration: &WindowsConfiguration{DomainJoin: domainJoin},
+			osType:               LinuxOS,
+			wantErr:              true,
+		},
+		{
+			name:                 "domain join without password secret",
+			windowsConfiguration: &WindowsConfiguration{DomainJoin: &WindowsDomainJoin{DomainName: "contoso.com", User: "contoso.com\\joiner"}},
+			osType:               WindowsOS,
+			wantErr:              true,
+		},
+		{
+			name:                 "domain join without domain name",
+			windowsConfiguration: &WindowsConfiguration{DomainJoin: &WindowsDomainJoin{User: "contoso.com\\joiner", PasswordSecretRef: corev1.LocalObjectReference{Name: "domain-join"}}},
+			osType:               WindowsOS,
+			wantErr:              true,
+		},
+		{
+			name:                 "platform patching",
+			windowsConfiguration: &WindowsConfiguration{PatchMode: WindowsPatchModeAutomaticByPlatform},
+			osType:               WindowsOS,
+			wantErr:              false,
+		},
+		{
+			name:                 "platform patching without automatic updates",
+			windowsConfiguration: &WindowsConfiguration{PatchMode: WindowsPatchModeAutomaticByPlatform, EnableAutomaticUpdates: ptr.To(false)},
+			osType:               WindowsOS,
+			wantErr:              true,
+		},
+		{
+			name:                 "manual patching with automatic updates",
+			windowsConfiguration: &WindowsConfiguration{PatchMode: WindowsPatchModeManual, EnableAutomaticUpdates: ptr.To(true)},
+			osType:               WindowsOS,
+			wantErr:              true,
+		},
+		{
+			name:                       "domain join with extension operations disabled",
+			windowsConfiguration:       &WindowsConfiguration{DomainJoin: domainJoin},
+			osType:                     WindowsOS,
+			disableExtensionOperations: ptr.To(true),
+			wantErr:                    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateWindowsConfiguration(tc.windowsConfiguration, tc.osType, tc.disableExtensionOperations, field.NewPath("windowsConfiguration"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateBootstrapDataStorage(t *testing.T) {
+	tests := []struct {
+		name     string
+		storage  *BootstrapDataStorage
+		identity VMIdentity
+		wantErr  bool
+	}{
+		{
+			name:     "no bootstrap data storage",
+			storage:  nil,
+			identity: VMIdentityNone,
+			wantErr:  false,
+		},
+		{
+			name:     "SAS access",
+			storage:  &BootstrapDataStorage{StorageAccountName: "mystorageaccount", ContainerName: "bootstrap-data", Access: BootstrapDataAccessSAS},
+			identity: VMIdentityNone,
+			wantErr:  false,
+		},
+		{
+			name:     "managed identity access",
+			storage:  &BootstrapDataStorage{StorageAccountName: "mystorageaccount", Access: BootstrapDataAccessManagedIdentity},
+			identity: VMIdentitySystemAssigned,
+			wantErr:  false,
+		},
+		{
+			name:     "managed identity access without identity",
+			storage:  &BootstrapDataStorage{StorageAccountName: "mystorageaccount", Access: BootstrapDataAccessManagedIdentity},
+			identity: VMIdentityNone,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid storage account name",
+			storage:  &BootstrapDataStorage{StorageAccountName: "My-Storage-Account"},
+			identity: VMIdentityNone,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid container name",
+			storage:  &BootstrapDataStorage{StorageAccountName: "mystorageaccount", ContainerName: "bootstrap--data"},
+			identity: VMIdentityNone,
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateBootstrapDataStorage(tc.storage, tc.identity, field.NewPath("bootstrapDataStorage"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateBootstrapDataKeyVault(t *testing.T) {
+	tests := []struct {
+		name                   string
+		keyVault               *BootstrapDataKeyVault
+		storage                *BootstrapDataStorage
+		identity               VMIdentity
+		userAssignedIdentities []UserAssignedIdentity
+		osType                 string
+		wantErr                bool
+	}{
+		{
+			name:     "no bootstrap data key vault",
+			keyVault: nil,
+			identity: VMIdentityNone,
+			osType:   LinuxOS,
+			wantErr:  false,
+		},
+		{
+			name:     "system-assigned identity",
+			keyVault: &BootstrapDataKeyVault{VaultName: "my-vault"},
+			identity: VMIdentitySystemAssigned,
+			osType:   LinuxOS,
+			wantErr:  false,
+		},
+		{
+			name:                   "single user-assigned identity",
+			keyVault:               &BootstrapDataKeyVault{VaultName: "my-vault"},
+			identity:               VMIdentityUserAssigned,
+			userAssignedIdentities: []UserAssignedIdentity{{ProviderID: "azure:///foo"}},
+			osType:                 LinuxOS,
+			wantErr:                false,
+		},
+		{
+			name:                   "several user-assigned identities without identity client ID",
+			keyVault:               &BootstrapDataKeyVault{VaultName: "my-vault"},
+			identity:               VMIdentityUserAssigned,
+			userAssignedIdentities: []UserAssignedIdentity{{ProviderID: "azure:///foo"}, {ProviderID: "azure:///bar"}},
+			osType:                 LinuxOS,
+			wantErr:                true,
+		},
+		{
+			name:                   "several user-assigned identities with identity client ID",
+			keyVault:               &BootstrapDataKeyVault{VaultName: "my-vault", IdentityClientID: "my-client-id"},
+			identity:               VMIdentityUserAssigned,
+			userAssignedIdentities: []UserAssignedIdentity{{ProviderID: "azure:///foo"}, {ProviderID: "azure:///bar"}},
+			osType:                 LinuxOS,
+			wantErr:                false,
+		},
+		{
+			name:     "no identity",
+			keyVault: &BootstrapDataKeyVault{VaultName: "my-vault"},
+			identity: VMIdentityNone,
+			osType:   LinuxOS,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid vault name",
+			keyVault: &BootstrapDataKeyVault{VaultName: "my--vault"},
+			identity: VMIdentitySystemAssigned,
+			osType:   LinuxOS,
+			wantErr:  true,
+		},
+		{
+			name:     "set together with bootstrap data storage",
+			keyVault: &BootstrapDataKeyVault{VaultName: "my-vault"},
+			storage:  &BootstrapDataStorage{StorageAccountName: "mystorageaccount"},
+			identity: VMIdentitySystemAssigned,
+			osType:   LinuxOS,
+			wantErr:  true,
+		},
+		{
+			name:     "windows virtual machine",
+			keyVault: &BootstrapDataKeyVault{VaultName: "my-vault"},
+			identity: VMIdentitySystemAssigned,
+			osType:   WindowsOS,
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateBootstrapDataKeyVault(tc.keyVault, tc.storage, tc.identity, tc.userAssignedIdentities, tc.osType, field.NewPath("bootstrapDataKeyVault"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateSpotVMOptions(t *testing.T) {
+	tests := []struct {
+		name           string
+		spotVMOptions  *SpotVMOptions
+		isControlPlane bool
+		wantErr        bool
+	}{
+		{
+			name:           "spot worker machine",
+			spotVMOptions:  &SpotVMOptions{},
+			isControlPlane: false,
+			wantErr:        false,
+		},
+		{
+			name:           "regular control plane machine",
+			spotVMOptions:  nil,
+			isControlPlane: true,
+			wantErr:        false,
+		},
+		{
+			name:           "spot control plane machine",
+			spotVMOptions:  &SpotVMOptions{},
+			isControlPlane: true,
+			wantErr:        true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateSpotVMOptions(tc.spotVMOptions, tc.isControlPlane, field.NewPath("spotVMOptions"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidatePublicIPPrefixID(t *testing.T) {
+	tests := []struct {
+		name             string
+		allocatePublicIP bool
+		publicIPPrefixID *string
+		wantErr          bool
+	}{
+		{
+			name:             "no public IP prefix",
+			allocatePublicIP: true,
+			publicIPPrefixID: nil,
+			wantErr:          false,
+		},
+		{
+			name:             "valid public IP prefix",
+			allocatePublicIP: true,
+			publicIPPrefixID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+			wantErr:          false,
+		},
+		{
+			name:             "invalid public IP prefix",
+			allocatePublicIP: true,
+			publicIPPrefixID: ptr.To("my-prefix"),
+			wantErr:          true,
+		},
+		{
+			name:             "public IP prefix without public IP",
+			allocatePublicIP: false,
+			publicIPPrefixID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+			wantErr:          true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := createMachineWithSSHPublicKey(validSSHPublicKey).Spec
+			spec.AllocatePublicIP = tc.allocatePublicIP
+			spec.PublicIPPrefixID = tc.publicIPPrefixID
+			err := ValidateAzureMachineSpec(spec)
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_ValidateConfidentialCompute(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -1142,3 +1600,86 @@ func TestAzureMachine_ValidateConfidentialCompute(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureMachine_ValidateImageKubernetesVersion(t *testing.T) {
+	marketplaceImage := func(publisher, offer, sku, version string) *Image {
+		return &Image{
+			Marketplace: &AzureMarketplaceImage{
+				ImagePlan: ImagePlan{Publisher: publisher, Offer: offer, SKU: sku},
+				Version:   version,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		image      *Image
+		k8sVersion string
+		wantErr    bool
+	}{
+		{
+			name:       "default image",
+			k8sVersion: "v1.28.3",
+			wantErr:    false,
+		},
+		{
+			name:       "unknown Kubernetes version",
+			image:      marketplaceImage("cncf-upstream", "capi", "ubuntu-2204-gen1", "127.7.20231101"),
+			k8sVersion: "",
+			wantErr:    false,
+		},
+		{
+			name:       "reference image version matching the Kubernetes version",
+			image:      marketplaceImage("cncf-upstream", "capi", "ubuntu-2204-gen1", "128.3.20231101"),
+			k8sVersion: "v1.28.3",
+			wantErr:    false,
+		},
+		{
+			name:       "reference image version not matching the Kubernetes version",
+			image:      marketplaceImage("cncf-upstream", "capi", "ubuntu-2204-gen1", "128.2.20231101"),
+			k8sVersion: "v1.28.3",
+			wantErr:    true,
+		},
+		{
+			name:       "Windows reference image version not matching the Kubernetes version",
+			image:      marketplaceImage("cncf-upstream", "capi-windows", "windows-2022-containerd-gen1", "127.7.20231101"),
+			k8sVersion: "1.28.3",
+			wantErr:    true,
+		},
+		{
+			name:       "reference image SKU matching the Kubernetes version",
+			image:      marketplaceImage("cncf-upstream", "capi", "k8s-1dot21dot2-ubuntu-2004", "latest"),
+			k8sVersion: "v1.21.2",
+			wantErr:    false,
+		},
+		{
+			name:       "reference image SKU not matching the Kubernetes version",
+			image:      marketplaceImage("cncf-upstream", "capi", "k8s-1dot21dot2-ubuntu-2004", "latest"),
+			k8sVersion: "v1.22.1",
+			wantErr:    true,
+		},
+		{
+			name:       "latest reference image",
+			image:      marketplaceImage("cncf-upstream", "capi", "ubuntu-2204-gen1", "latest"),
+			k8sVersion: "v1.28.3",
+			wantErr:    false,
+		},
+		{
+			name:       "other Marketplace image",
+			image:      marketplaceImage("my-publisher", "my-offer", "my-sku", "127.7.20231101"),
+			k8sVersion: "v1.28.3",
+			wantErr:    false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateImageKubernetesVersion(test.image, test.k8sVersion, field.NewPath("image"))
+			if test.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
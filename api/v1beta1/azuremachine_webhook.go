@@ -22,8 +22,11 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -40,7 +43,7 @@ func SetupAzureMachineWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachine,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremachines,versions=v1beta1,name=validation.azuremachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
-// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachine,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremachines,versions=v1beta1,name=default.azuremachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachine,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremachines,versions=v1beta1,name=default.azuremachine.infrastructure.cluster.x-k8s.io,sideEffects=NoneOnDryRun,admissionReviewVersions=v1;v1beta1
 
 // azureMachineWebhook implements a validating and defaulting webhook for AzureMachines.
 type azureMachineWebhook struct {
@@ -66,6 +69,19 @@ func (mw *azureMachineWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 		allErrs = append(allErrs, errs...)
 	}
 
+	_, isControlPlane := m.Labels[clusterv1.MachineControlPlaneLabel]
+	if errs := ValidateSpotVMOptions(spec.SpotVMOptions, isControlPlane, field.NewPath("spec", "spotVMOptions")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	k8sVersion, err := mw.machineKubernetesVersion(ctx, m)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if errs := ValidateImageKubernetesVersion(spec.Image, k8sVersion, field.NewPath("spec", "image")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -73,6 +89,31 @@ func (mw *azureMachineWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 	return nil, apierrors.NewInvalid(GroupVersion.WithKind(AzureMachineKind).GroupKind(), m.Name, allErrs)
 }
 
+// machineKubernetesVersion returns the Kubernetes version of the Machine owning an AzureMachine or, as AzureMachines
+// are usually created before their Machine, of the MachineSet the AzureMachine belongs to. It returns an empty
+// version when neither can be found.
+func (mw *azureMachineWebhook) machineKubernetesVersion(ctx context.Context, m *AzureMachine) (string, error) {
+	for _, ref := range m.OwnerReferences {
+		if gv, err := schema.ParseGroupVersion(ref.APIVersion); err == nil && ref.Kind == "Machine" && gv.Group == clusterv1.GroupVersion.Group {
+			machine := &clusterv1.Machine{}
+			if err := mw.Client.Get(ctx, client.ObjectKey{Namespace: m.Namespace, Name: ref.Name}, machine); err != nil {
+				return "", client.IgnoreNotFound(err)
+			}
+			return ptr.Deref(machine.Spec.Version, ""), nil
+		}
+	}
+
+	if machineSetName, ok := m.Labels[clusterv1.MachineSetNameLabel]; ok {
+		machineSet := &clusterv1.MachineSet{}
+		if err := mw.Client.Get(ctx, client.ObjectKey{Namespace: m.Namespace, Name: machineSetName}, machineSet); err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		return ptr.Deref(machineSet.Spec.Template.Spec.Version, ""), nil
+	}
+
+	return "", nil
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
@@ -85,6 +126,15 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		return nil, apierrors.NewBadRequest("expected an AzureMachine resource")
 	}
 
+	if m.Spec.ResizePolicy != VMResizePolicyInPlace {
+		if err := webhookutils.ValidateImmutable(
+			field.NewPath("spec", "vmSize"),
+			old.Spec.VMSize,
+			m.Spec.VMSize); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "image"),
 		old.Spec.Image,
@@ -92,6 +142,13 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "fips"),
+		old.Spec.FIPS,
+		m.Spec.FIPS); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "identity"),
 		old.Spec.Identity,
@@ -148,6 +205,20 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "enablePublicIPDNSLabel"),
+		old.Spec.EnablePublicIPDNSLabel,
+		m.Spec.EnablePublicIPDNSLabel); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "publicIPPrefixID"),
+		old.Spec.PublicIPPrefixID,
+		m.Spec.PublicIPPrefixID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "enableIPForwarding"),
 		old.Spec.EnableIPForwarding,
@@ -220,6 +291,48 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "jitAccess"),
+		old.Spec.JITAccess,
+		m.Spec.JITAccess); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "windowsConfiguration"),
+		old.Spec.WindowsConfiguration,
+		m.Spec.WindowsConfiguration); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "bootstrapDataStorage"),
+		old.Spec.BootstrapDataStorage,
+		m.Spec.BootstrapDataStorage); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "bootstrapDataKeyVault"),
+		old.Spec.BootstrapDataKeyVault,
+		m.Spec.BootstrapDataKeyVault); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "installGPUDriver"),
+		old.Spec.InstallGPUDriver,
+		m.Spec.InstallGPUDriver); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "applicationSecurityGroups"),
+		old.Spec.ApplicationSecurityGroups,
+		m.Spec.ApplicationSecurityGroups); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -237,5 +350,12 @@ func (mw *azureMachineWebhook) Default(ctx context.Context, obj runtime.Object)
 	if !ok {
 		return apierrors.NewBadRequest("expected an AzureMachine resource")
 	}
+	dryRun := false
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		dryRun = ptr.Deref(req.DryRun, false)
+	}
+	if err := m.SetDefaultSSHPublicKeyFromSecret(ctx, mw.Client, dryRun); err != nil {
+		return err
+	}
 	return m.SetDefaults(mw.Client)
 }
@@ -24,11 +24,14 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var (
@@ -135,6 +138,24 @@ func TestAzureMachine_ValidateCreate(t *testing.T) {
 			machine: createMachineWithDiagnostics(UserManagedDiagnosticsStorage, nil),
 			wantErr: true,
 		},
+		{
+			name: "azuremachine capturing diagnostics on failure with user managed diagnostics profile",
+			machine: func() *AzureMachine {
+				m := createMachineWithDiagnostics(UserManagedDiagnosticsStorage, &UserManagedBootDiagnostics{StorageAccountURI: "https://fakeurl"})
+				m.Spec.Diagnostics.CaptureOnFailure = true
+				return m
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azuremachine capturing diagnostics on failure with managed diagnostics profile",
+			machine: func() *AzureMachine {
+				m := createMachineWithDiagnostics(ManagedDiagnosticsStorage, nil)
+				m.Spec.Diagnostics.CaptureOnFailure = true
+				return m
+			}(),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachine with invalid network configuration",
 			machine: createMachineWithNetworkConfig("subnet", nil, []NetworkInterface{{SubnetName: "subnet1"}}),
@@ -255,6 +276,81 @@ func TestAzureMachine_ValidateCreate(t *testing.T) {
 	}
 }
 
+func TestAzureMachine_ValidateCreateImageKubernetesVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		machine *AzureMachine
+		wantErr bool
+	}{
+		{
+			name: "reference image matching the version of the owner Machine",
+			machine: func() *AzureMachine {
+				m := createMachineWithMarketPlaceImage("cncf-upstream", "capi", "ubuntu-2204-gen1", "128.3.20231101")
+				m.OwnerReferences = []metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: "my-machine"}}
+				return m
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "reference image not matching the version of the owner Machine",
+			machine: func() *AzureMachine {
+				m := createMachineWithMarketPlaceImage("cncf-upstream", "capi", "ubuntu-2204-gen1", "127.7.20231101")
+				m.OwnerReferences = []metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: "my-machine"}}
+				return m
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "reference image not matching the version of the MachineSet",
+			machine: func() *AzureMachine {
+				m := createMachineWithMarketPlaceImage("cncf-upstream", "capi", "ubuntu-2204-gen1", "127.7.20231101")
+				m.Labels = map[string]string{clusterv1.MachineSetNameLabel: "my-machineset"}
+				return m
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "MachineSet not found",
+			machine: func() *AzureMachine {
+				m := createMachineWithMarketPlaceImage("cncf-upstream", "capi", "ubuntu-2204-gen1", "127.7.20231101")
+				m.Labels = map[string]string{clusterv1.MachineSetNameLabel: "other-machineset"}
+				return m
+			}(),
+			wantErr: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-machine"},
+				Spec:       clusterv1.MachineSpec{Version: ptr.To("v1.28.3")},
+			}
+			machineSet := &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-machineset"},
+				Spec: clusterv1.MachineSetSpec{
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{Version: ptr.To("v1.28.3")},
+					},
+				},
+			}
+			scheme := runtime.NewScheme()
+			_ = AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+			mw := &azureMachineWebhook{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine, machineSet).Build()}
+
+			_, err := mw.ValidateCreate(context.Background(), tc.machine)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("reference image is built for Kubernetes v1.27.7, not the Kubernetes version v1.28.3 of the machine"))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_ValidateUpdate(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -262,6 +358,35 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 		newMachine *AzureMachine
 		wantErr    bool
 	}{
+		{
+			name: "invalidTest: azuremachine.spec.vmSize is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					VMSize: "Standard_D2s_v3",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					VMSize: "Standard_D4s_v3",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.vmSize can change with the InPlace resize policy",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					VMSize: "Standard_D2s_v3",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					VMSize:       "Standard_D4s_v3",
+					ResizePolicy: VMResizePolicyInPlace,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.image is immutable",
 			oldMachine: &AzureMachine{
@@ -570,6 +695,48 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalidTest: azuremachine.spec.EnablePublicIPDNSLabel is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					EnablePublicIPDNSLabel: true,
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					EnablePublicIPDNSLabel: false,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.EnablePublicIPDNSLabel is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					EnablePublicIPDNSLabel: true,
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					EnablePublicIPDNSLabel: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.PublicIPPrefixID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					PublicIPPrefixID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					PublicIPPrefixID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/other-prefix"),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.EnableIPForwarding is immutable",
 			oldMachine: &AzureMachine{
@@ -914,6 +1081,62 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalidTest: azuremachine.spec.fips is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					FIPS: false,
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					FIPS: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.applicationSecurityGroups is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ApplicationSecurityGroups: ApplicationSecurityGroupReferences{"web"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ApplicationSecurityGroups: ApplicationSecurityGroupReferences{"web", "db"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.bootstrapDataStorage is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapDataStorage: &BootstrapDataStorage{StorageAccountName: "mystorageaccount"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapDataStorage: &BootstrapDataStorage{StorageAccountName: "otherstorageaccount"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.bootstrapDataKeyVault is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapDataKeyVault: &BootstrapDataKeyVault{VaultName: "myvault"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapDataKeyVault: &BootstrapDataKeyVault{VaultName: "othervault"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -944,12 +1167,21 @@ func (m mockDefaultClient) Get(ctx context.Context, key client.ObjectKey, obj cl
 			Kind: AzureClusterKind,
 			Name: "test-cluster",
 		}
+	case *corev1.Secret:
+		return apierrors.NewNotFound(corev1.Resource("secrets"), key.Name)
 	default:
 		return errors.New("invalid object type")
 	}
 	return nil
 }
 
+func (m mockDefaultClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*corev1.Secret); !ok {
+		return errors.New("invalid object type")
+	}
+	return nil
+}
+
 func TestAzureMachine_Default(t *testing.T) {
 	g := NewWithT(t)
 
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemediationType describes the Azure-side action taken to remediate an unhealthy machine.
+type RemediationType string
+
+const (
+	// RemediationTypeRestart restarts the virtual machine on its current host.
+	RemediationTypeRestart RemediationType = "Restart"
+	// RemediationTypeRedeploy redeploys the virtual machine to a new Azure host.
+	RemediationTypeRedeploy RemediationType = "Redeploy"
+)
+
+// RemediationPhase describes the state of an AzureMachineRemediation.
+type RemediationPhase string
+
+const (
+	// RemediationPhaseRunning means the Azure-side remediation was requested and CAPZ waits for the machine to
+	// become healthy again.
+	RemediationPhaseRunning RemediationPhase = "Running"
+	// RemediationPhaseDeleting means the Azure-side remediation failed and the machine is handed back to its owner to
+	// be replaced.
+	RemediationPhaseDeleting RemediationPhase = "Deleting"
+)
+
+// RemediationStrategy describes how an unhealthy machine is remediated before it is replaced.
+type RemediationStrategy struct {
+	// Type is the Azure-side action taken to remediate the machine.
+	// +kubebuilder:validation:Enum=Restart;Redeploy
+	// +kubebuilder:default=Redeploy
+	// +optional
+	Type RemediationType `json:"type,omitempty"`
+
+	// RetryLimit is the number of times the Azure-side action is attempted before the machine is replaced.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	RetryLimit int `json:"retryLimit,omitempty"`
+
+	// Timeout is how long to wait for the machine to become healthy after each attempt.
+	// +kubebuilder:default="10m"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// AzureMachineRemediationSpec defines the desired state of AzureMachineRemediation.
+type AzureMachineRemediationSpec struct {
+	// Strategy describes how the machine is remediated.
+	// +optional
+	Strategy RemediationStrategy `json:"strategy,omitempty"`
+}
+
+// AzureMachineRemediationStatus defines the observed state of AzureMachineRemediation.
+type AzureMachineRemediationStatus struct {
+	// Phase is the state of the remediation.
+	// +optional
+	Phase RemediationPhase `json:"phase,omitempty"`
+
+	// RetryCount is the number of times the Azure-side action was requested.
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+
+	// LastRemediated is when the Azure-side action was last requested.
+	// +optional
+	LastRemediated *metav1.Time `json:"lastRemediated,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Remediation phase"
+// +kubebuilder:printcolumn:name="Retries",type="integer",JSONPath=".status.retryCount",description="Number of remediation attempts"
+// +kubebuilder:printcolumn:name="Last Remediated",type="date",JSONPath=".status.lastRemediated",description="Time of the last remediation attempt"
+// +kubebuilder:resource:path=azuremachineremediations,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+
+// AzureMachineRemediation is the Schema for the azuremachineremediations API. It is created by a MachineHealthCheck
+// for an unhealthy Machine and has the same name as the Machine.
+type AzureMachineRemediation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureMachineRemediationSpec   `json:"spec,omitempty"`
+	Status AzureMachineRemediationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureMachineRemediationList contains a list of AzureMachineRemediations.
+type AzureMachineRemediationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureMachineRemediation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AzureMachineRemediation{}, &AzureMachineRemediationList{})
+}
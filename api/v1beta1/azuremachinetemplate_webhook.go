@@ -72,21 +72,21 @@ func (r *AzureMachineTemplate) ValidateCreate(ctx context.Context, obj runtime.O
 		)
 	}
 
-	if (r.Spec.Template.Spec.NetworkInterfaces != nil) && len(r.Spec.Template.Spec.NetworkInterfaces) > 0 && r.Spec.Template.Spec.SubnetName != "" {
-		allErrs = append(allErrs, field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces"), r.Spec.Template.Spec.NetworkInterfaces, "cannot set both NetworkInterfaces and machine SubnetName"))
+	if (spec.NetworkInterfaces != nil) && len(spec.NetworkInterfaces) > 0 && spec.SubnetName != "" {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces"), spec.NetworkInterfaces, "cannot set both NetworkInterfaces and machine SubnetName"))
 	}
 
-	if (r.Spec.Template.Spec.NetworkInterfaces != nil) && len(r.Spec.Template.Spec.NetworkInterfaces) > 0 && r.Spec.Template.Spec.AcceleratedNetworking != nil {
-		allErrs = append(allErrs, field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "acceleratedNetworking"), r.Spec.Template.Spec.NetworkInterfaces, "cannot set both NetworkInterfaces and machine AcceleratedNetworking"))
+	if (spec.NetworkInterfaces != nil) && len(spec.NetworkInterfaces) > 0 && spec.AcceleratedNetworking != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "acceleratedNetworking"), spec.NetworkInterfaces, "cannot set both NetworkInterfaces and machine AcceleratedNetworking"))
 	}
 
-	for i, networkInterface := range r.Spec.Template.Spec.NetworkInterfaces {
+	for i, networkInterface := range spec.NetworkInterfaces {
 		if networkInterface.PrivateIPConfigs < 1 {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces", "privateIPConfigs"), r.Spec.Template.Spec.NetworkInterfaces[i].PrivateIPConfigs, "networkInterface privateIPConfigs must be set to a minimum value of 1"))
+			allErrs = append(allErrs, field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces", "privateIPConfigs"), spec.NetworkInterfaces[i].PrivateIPConfigs, "networkInterface privateIPConfigs must be set to a minimum value of 1"))
 		}
 	}
 
-	if ptr.Deref(r.Spec.Template.Spec.DisableExtensionOperations, false) && len(r.Spec.Template.Spec.VMExtensions) > 0 {
+	if ptr.Deref(spec.DisableExtensionOperations, false) && len(spec.VMExtensions) > 0 {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "vmExtensions"), "VMExtensions must be empty when DisableExtensionOperations is true"))
 	}
 
@@ -108,15 +108,18 @@ func (r *AzureMachineTemplate) ValidateUpdate(ctx context.Context, oldRaw runtim
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a admission.Request inside context: %v", err))
 	}
 
-	if !topology.ShouldSkipImmutabilityChecks(req, t) &&
+	if topology.ShouldSkipImmutabilityChecks(req, t) &&
 		!reflect.DeepEqual(t.Spec.Template.Spec, old.Spec.Template.Spec) {
+		// The changed spec isn't rejected as immutable, so it must be valid on its own.
+		allErrs = append(allErrs, ValidateAzureMachineSpec(t.Spec.Template.Spec)...)
+	} else if !reflect.DeepEqual(t.Spec.Template.Spec, old.Spec.Template.Spec) {
 		// The equality failure could be because of default mismatch between v1alpha3 and v1beta1. This happens because
 		// the new object `r` will have run through the default webhooks but the old object `old` would not have so.
 		// This means if the old object was in v1alpha3, it would not get the new defaults set in v1beta1 resulting
 		// in object inequality. To workaround this, we set the v1beta1 defaults here so that the old object also gets
 		// the new defaults.
 
-		// We need to set ssh key explicitly, otherwise Default() will create a new one.
+		// We need to set ssh key explicitly, as templates created by earlier versions got a generated one by Default().
 		if old.Spec.Template.Spec.SSHPublicKey == "" {
 			old.Spec.Template.Spec.SSHPublicKey = t.Spec.Template.Spec.SSHPublicKey
 		}
@@ -149,11 +152,12 @@ func (r *AzureMachineTemplate) ValidateDelete(ctx context.Context, obj runtime.O
 // Default implements webhookutil.defaulter so a webhook will be registered for the type.
 func (r *AzureMachineTemplate) Default(ctx context.Context, obj runtime.Object) error {
 	t := obj.(*AzureMachineTemplate)
-	if err := t.Spec.Template.Spec.SetDefaultSSHPublicKey(); err != nil {
-		ctrl.Log.WithName("SetDefault").Error(err, "SetDefaultSSHPublicKey failed")
-	}
+	// The SSH public key is not defaulted here so that the AzureMachines created from the template get the public key
+	// of the cluster's SSH key secret.
 	t.Spec.Template.Spec.SetDefaultCachingType()
 	t.Spec.Template.Spec.SetDataDisksDefaults()
+	t.Spec.Template.Spec.SetSpotEvictionPolicyDefaults()
+	t.Spec.Template.Spec.SetDiagnosticsDefaults()
 	t.Spec.Template.Spec.SetNetworkInterfacesDefaults()
 	return nil
 }
@@ -18,13 +18,19 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -250,7 +256,8 @@ func TestAzureMachineTemplate_ValidateCreate(t *testing.T) {
 			t.Parallel()
 			g := NewWithT(t)
 			ctx := context.Background()
-			_, err := test.machineTemplate.ValidateCreate(ctx, test.machineTemplate)
+			// The webhook is registered for an empty AzureMachineTemplate, so the object under validation must be the argument.
+			_, err := (&AzureMachineTemplate{}).ValidateCreate(ctx, test.machineTemplate)
 			if test.wantErr {
 				g.Expect(err).To(HaveOccurred())
 			} else {
@@ -382,6 +389,7 @@ func TestAzureMachineTemplate_ValidateUpdate(t *testing.T) {
 							},
 							DataDisks:    []DataDisk{},
 							SSHPublicKey: "fake ssh key",
+							Diagnostics:  &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: ManagedDiagnosticsStorage}},
 							NetworkInterfaces: []NetworkInterface{{
 								PrivateIPConfigs: 1,
 							}},
@@ -472,6 +480,7 @@ func TestAzureMachineTemplate_ValidateUpdate(t *testing.T) {
 							},
 							DataDisks:             []DataDisk{},
 							SSHPublicKey:          "fake ssh key",
+							Diagnostics:           &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: ManagedDiagnosticsStorage}},
 							SubnetName:            "",
 							AcceleratedNetworking: nil,
 							NetworkInterfaces: []NetworkInterface{
@@ -522,6 +531,7 @@ func TestAzureMachineTemplate_ValidateUpdate(t *testing.T) {
 							},
 							DataDisks:             []DataDisk{},
 							SSHPublicKey:          "fake ssh key",
+							Diagnostics:           &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: ManagedDiagnosticsStorage}},
 							SubnetName:            "",
 							AcceleratedNetworking: nil,
 							NetworkInterfaces: []NetworkInterface{
@@ -622,6 +632,84 @@ func TestAzureMachineTemplate_ValidateUpdate(t *testing.T) {
 	}
 }
 
+func TestAzureMachineTemplate_ValidateUpdateTopologyDryRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   *AzureMarketplaceImage
+		wantErr bool
+	}{
+		{
+			name:    "valid template spec change",
+			image:   &AzureMarketplaceImage{ImagePlan: ImagePlan{Publisher: "PUB1234", Offer: "OFFER1234", SKU: "SKU5678"}, Version: "1.0.0"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid template spec change",
+			image:   &AzureMarketplaceImage{ImagePlan: ImagePlan{Offer: "OFFER1234", SKU: "SKU5678"}, Version: "1.0.0"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{DryRun: ptr.To(true)}})
+			oldTemplate := createAzureMachineTemplateFromMachine(createMachineWithMarketPlaceImage("PUB1234", "OFFER1234", "SKU1234", "1.0.0"))
+			template := oldTemplate.DeepCopy()
+			template.Annotations = map[string]string{clusterv1.TopologyDryRunAnnotation: ""}
+			template.Spec.Template.Spec.Image.Marketplace = tc.image
+			_, err := (&AzureMachineTemplate{}).ValidateUpdate(ctx, oldTemplate, template)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureMachineTemplate_Default(t *testing.T) {
+	g := NewWithT(t)
+	template := createAzureMachineTemplateFromMachine(createMachineWithMarketPlaceImage("PUB1234", "OFFER1234", "SKU1234", "1.0.0"))
+	template.Spec.Template.Spec.SpotVMOptions = &SpotVMOptions{}
+	template.Spec.Template.Spec.SSHPublicKey = ""
+
+	g.Expect((&AzureMachineTemplate{}).Default(context.Background(), template)).To(Succeed())
+	g.Expect(template.Spec.Template.Spec.SSHPublicKey).To(BeEmpty())
+	g.Expect(template.Spec.Template.Spec.SpotVMOptions.EvictionPolicy).To(Equal(ptr.To(SpotEvictionPolicyDeallocate)))
+	g.Expect(template.Spec.Template.Spec.Diagnostics).To(Equal(&Diagnostics{Boot: &BootDiagnostics{StorageAccountType: ManagedDiagnosticsStorage}}))
+}
+
+func TestAzureMachineTemplate_DefaultSSHPublicKeyFromSecret(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	template := createAzureMachineTemplateFromMachine(createMachineWithSSHPublicKey(""))
+	g.Expect((&AzureMachineTemplate{}).Default(context.Background(), template)).To(Succeed())
+
+	// The machines created from the template get the public key of the cluster's SSH key secret.
+	var keys []string
+	for _, name := range []string{"machine-0", "machine-1"} {
+		machine := &AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+			},
+			Spec: *template.Spec.Template.Spec.DeepCopy(),
+		}
+		g.Expect(machine.SetDefaultSSHPublicKeyFromSecret(context.Background(), fakeClient, false)).To(Succeed())
+		keys = append(keys, machine.Spec.SSHPublicKey)
+	}
+
+	secret := &corev1.Secret{}
+	g.Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: SSHKeySecretName("test-cluster")}, secret)).To(Succeed())
+	expectedKey := base64.StdEncoding.EncodeToString(secret.Data[SSHPublicKeySecretKey])
+	g.Expect(keys).To(HaveEach(expectedKey))
+}
+
 func createAzureMachineTemplateFromMachine(machine *AzureMachine) *AzureMachineTemplate {
 	return &AzureMachineTemplate{
 		Spec: AzureMachineTemplateSpec{
@@ -119,6 +119,10 @@ func (mw *azureManagedControlPlaneWebhook) ValidateCreate(ctx context.Context, o
 		)
 	}
 
+	if errs := validateRequiredTags(m.Spec.AdditionalTags, nil, false, field.NewPath("spec", "additionalTags")); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind(AzureManagedControlPlaneKind).GroupKind(), m.Name, errs)
+	}
+
 	return nil, m.Validate(mw.Client)
 }
 
@@ -232,6 +236,10 @@ func (mw *azureManagedControlPlaneWebhook) ValidateUpdate(ctx context.Context, o
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := validateRequiredTags(m.Spec.AdditionalTags, old.Spec.AdditionalTags, true, field.NewPath("spec", "additionalTags")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, m.Validate(mw.Client)
 	}
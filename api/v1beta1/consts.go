@@ -26,6 +26,46 @@ const (
 	NamespaceNotAllowedByIdentity = "NamespaceNotAllowedByIdentity"
 )
 
+// DeletionProtectionAnnotation prevents the deletion of the Azure resources of an AzureCluster or AzureMachine
+// when set to "true". The object's finalizer is only removed once the annotation has been removed.
+const DeletionProtectionAnnotation = "azure.infrastructure.cluster.x-k8s.io/deletion-protection"
+
+// DeletionProtectedReason is used when the deletion of an object's Azure resources is blocked by the
+// DeletionProtectionAnnotation.
+const DeletionProtectedReason = "DeletionProtected"
+
+// LockedResourcePolicyAnnotation sets what CAPZ does when an Azure resource of an AzureCluster, AzureMachine or
+// AzureMachinePool can't be deleted because of an Azure management lock. By default, the deletion stops and the
+// object reports the lock. When set to LockedResourcePolicyOrphan, locked resources are left in Azure and the deletion
+// proceeds.
+const LockedResourcePolicyAnnotation = "azure.infrastructure.cluster.x-k8s.io/locked-resource-policy"
+
+// LockedResourcePolicyOrphan is the value of the LockedResourcePolicyAnnotation which leaves locked resources in Azure.
+const LockedResourcePolicyOrphan = "Orphan"
+
+// ResourceLockedReason is used when an Azure resource can't be deleted or updated because of an Azure management lock.
+const ResourceLockedReason = "ResourceLocked"
+
+// DryRunAnnotation makes the controllers of an AzureCluster, AzureMachine or AzureMachinePool report the Azure
+// resources they would create, update or delete when set to "true", without performing these operations.
+const DryRunAnnotation = "azure.infrastructure.cluster.x-k8s.io/dry-run"
+
+// DryRunReason is used for the Events reporting the operations skipped because of the DryRunAnnotation.
+const DryRunReason = "DryRun"
+
+// AdoptResourcesAnnotation makes CAPZ adopt the resource groups and virtual network of an AzureCluster which
+// already existed in Azure when set to "true". Adopted resources are tagged as owned by the cluster and deleted
+// along with it.
+const AdoptResourcesAnnotation = "azure.infrastructure.cluster.x-k8s.io/adopt-resources"
+
+// RestartAnnotation makes the controller of an AzureMachine restart its virtual machine when set to "true". The
+// annotation is removed once the restart has been requested.
+const RestartAnnotation = "azure.infrastructure.cluster.x-k8s.io/restart"
+
+// RedeployAnnotation makes the controller of an AzureMachine redeploy its virtual machine to a new Azure host when set
+// to "true". The annotation is removed once the redeployment has been requested.
+const RedeployAnnotation = "azure.infrastructure.cluster.x-k8s.io/redeploy"
+
 // AzureMachine Conditions and Reasons.
 const (
 	// VMRunningCondition reports on current status of the Azure VM.
@@ -46,6 +86,16 @@ const (
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+	// VMResizedCondition reports on the progress of the in-place resize of the Azure VM.
+	VMResizedCondition clusterv1.ConditionType = "VMResized"
+	// VMDeallocatingReason used when the vm is being deallocated to be resized.
+	VMDeallocatingReason = "VMDeallocating"
+	// VMResizingReason used when the size of the vm is being changed.
+	VMResizingReason = "VMResizing"
+	// VMStartingReason used when the vm is being started after being resized.
+	VMStartingReason = "VMStarting"
+	// VMResizeFailedReason used for failures during the in-place resize of the vm.
+	VMResizeFailedReason = "VMResizeFailed"
 	// BootstrapSucceededCondition reports the result of the execution of the bootstrap data on the machine.
 	BootstrapSucceededCondition clusterv1.ConditionType = "BootstrapSucceeded"
 	// BootstrapInProgressReason is used to indicate the bootstrap data has not finished executing.
@@ -98,6 +148,10 @@ const (
 	VNetReadyCondition clusterv1.ConditionType = "VNetReady"
 	// VnetPeeringReadyCondition means the virtual network peerings exist and are ready to be used.
 	VnetPeeringReadyCondition clusterv1.ConditionType = "VnetPeeringReady"
+	// ApplicationSecurityGroupsReadyCondition means the application security groups exist and are ready to be used.
+	ApplicationSecurityGroupsReadyCondition clusterv1.ConditionType = "ApplicationSecurityGroupsReady"
+	// FlowLogsReadyCondition means the flow log of the virtual network exists and is ready to be used.
+	FlowLogsReadyCondition clusterv1.ConditionType = "FlowLogsReady"
 	// SecurityGroupsReadyCondition means the security groups exist and are ready to be used.
 	SecurityGroupsReadyCondition clusterv1.ConditionType = "SecurityGroupsReady"
 	// RouteTablesReadyCondition means the route tables exist and are ready to be used.
@@ -118,6 +172,12 @@ const (
 	PrivateDNSRecordReadyCondition clusterv1.ConditionType = "PrivateDNSRecordReady"
 	// BastionHostReadyCondition means the bastion host exists and is ready to be used.
 	BastionHostReadyCondition clusterv1.ConditionType = "BastionHostReady"
+	// VirtualNetworkGatewayReadyCondition means the virtual network gateway exists and is ready to be used.
+	VirtualNetworkGatewayReadyCondition clusterv1.ConditionType = "VirtualNetworkGatewayReady"
+	// PrivateLinkServiceReadyCondition means the Private Link Service of the API server exists and is ready to be used.
+	PrivateLinkServiceReadyCondition clusterv1.ConditionType = "PrivateLinkServiceReady"
+	// RouteServerReadyCondition means the Route Server and its BGP peerings exist and are ready to be used.
+	RouteServerReadyCondition clusterv1.ConditionType = "RouteServerReady"
 	// InboundNATRulesReadyCondition means the inbound NAT rules exist and are ready to be used.
 	InboundNATRulesReadyCondition clusterv1.ConditionType = "InboundNATRulesReady"
 	// AvailabilitySetReadyCondition means the availability set exists and is ready to be used.
@@ -126,15 +186,34 @@ const (
 	RoleAssignmentReadyCondition clusterv1.ConditionType = "RoleAssignmentReady"
 	// DisksReadyCondition means the disks exist and are ready to be used.
 	DisksReadyCondition clusterv1.ConditionType = "DisksReady"
+	// JITAccessPolicyReadyCondition means the just-in-time VM access policy exists and is ready to be used.
+	JITAccessPolicyReadyCondition clusterv1.ConditionType = "JITAccessPolicyReady"
 	// NetworkInterfaceReadyCondition means the network interfaces exist and are ready to be used.
 	NetworkInterfaceReadyCondition clusterv1.ConditionType = "NetworkInterfacesReady"
 	// PrivateEndpointsReadyCondition means the private endpoints exist and are ready to be used.
 	PrivateEndpointsReadyCondition clusterv1.ConditionType = "PrivateEndpointsReady"
+	// DNSRecordsReadyCondition means the DNS records exist and are ready to be used.
+	DNSRecordsReadyCondition clusterv1.ConditionType = "DNSRecordsReady"
 	// FleetReadyCondition means the Fleet exists and is ready to be used.
 	FleetReadyCondition clusterv1.ConditionType = "FleetReady"
 	// AKSExtensionsReadyCondition means the AKS Extensions exist and are ready to be used.
 	AKSExtensionsReadyCondition clusterv1.ConditionType = "AKSExtensionsReady"
+	// IdentityPermissionsReadyCondition means the cluster identity holds the permissions required to create the cluster resources.
+	IdentityPermissionsReadyCondition clusterv1.ConditionType = "IdentityPermissionsReady"
+	// FeaturesRegisteredCondition means the subscription preview features required by the resource spec are registered.
+	FeaturesRegisteredCondition clusterv1.ConditionType = "FeaturesRegistered"
+	// SpotCapacityAvailableCondition means the Spot VMs of the resource spec are likely to be allocated and not
+	// evicted quickly, according to the Spot Placement Score and the eviction rate of the VM size.
+	SpotCapacityAvailableCondition clusterv1.ConditionType = "SpotCapacityAvailable"
 
+	// MissingPermissionsReason means the cluster identity is missing permissions required to create the cluster resources.
+	MissingPermissionsReason = "MissingPermissions"
+	// FeatureNotRegisteredReason means a subscription preview feature required by the resource spec is not registered.
+	FeatureNotRegisteredReason = "FeatureNotRegistered"
+	// SpotPlacementScoreLowReason means the Spot Placement Score of the VM size is below the minimum score.
+	SpotPlacementScoreLowReason = "SpotPlacementScoreLow"
+	// SpotEvictionRateHighReason means the eviction rate of the VM size is above the maximum eviction rate.
+	SpotEvictionRateHighReason = "SpotEvictionRateHigh"
 	// CreatingReason means the resource is being created.
 	CreatingReason = "Creating"
 	// FailedReason means the resource failed to be created.
@@ -179,6 +258,8 @@ const (
 	AzureMachineKind = "AzureMachine"
 	// AzureMachineTemplateKind indicates the kind of an AzureMachineTemplate.
 	AzureMachineTemplateKind = "AzureMachineTemplate"
+	// AzureMachineRemediationKind indicates the kind of an AzureMachineRemediation.
+	AzureMachineRemediationKind = "AzureMachineRemediation"
 	// AzureMachinePoolKind indicates the kind of an AzureMachinePool.
 	AzureMachinePoolKind = "AzureMachinePool"
 	// AzureManagedMachinePoolKind indicates the kind of an AzureManagedMachinePool.
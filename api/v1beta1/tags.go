@@ -19,11 +19,45 @@ package v1beta1
 import (
 	"fmt"
 	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Tags defines a map of tags.
 type Tags map[string]string
 
+// RequiredTags are the tags which the additional tags of every AzureCluster and AzureManagedControlPlane must contain,
+// so that they are applied to all the Azure resources of the cluster. A required tag with an empty value may be set
+// to any value. It is set from the manager's --required-tags flag.
+var RequiredTags Tags
+
+// validateRequiredTags validates that tags contain the RequiredTags. On updates, the tags are only validated when they
+// change, so that clusters created before a tag was required can still be updated.
+func validateRequiredTags(tags, oldTags Tags, isUpdate bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(RequiredTags) == 0 || (isUpdate && tags.Equals(oldTags)) {
+		return allErrs
+	}
+
+	keys := make([]string, 0, len(RequiredTags))
+	for key := range RequiredTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := tags[key]
+		switch {
+		case !ok:
+			allErrs = append(allErrs, field.Required(fldPath.Key(key), fmt.Sprintf("tag %q is required", key)))
+		case RequiredTags[key] != "" && value != RequiredTags[key]:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Key(key), value, []string{RequiredTags[key]}))
+		}
+	}
+	return allErrs
+}
+
 // Equals returns true if the tags are equal.
 func (t Tags) Equals(other Tags) bool {
 	return reflect.DeepEqual(t, other)
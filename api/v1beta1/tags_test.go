@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func TestTags_Merge(t *testing.T) {
@@ -87,3 +88,58 @@ func TestTags_Merge(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRequiredTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     Tags
+		oldTags  Tags
+		isUpdate bool
+		wantErrs int
+	}{
+		{
+			name:     "all required tags",
+			tags:     Tags{"costcenter": "123", "environment": "prod"},
+			wantErrs: 0,
+		},
+		{
+			name:     "missing tag",
+			tags:     Tags{"environment": "prod"},
+			wantErrs: 1,
+		},
+		{
+			name:     "wrong value",
+			tags:     Tags{"costcenter": "123", "environment": "dev"},
+			wantErrs: 1,
+		},
+		{
+			name:     "no tags",
+			tags:     nil,
+			wantErrs: 2,
+		},
+		{
+			name:     "unchanged tags on update",
+			tags:     Tags{"environment": "dev"},
+			oldTags:  Tags{"environment": "dev"},
+			isUpdate: true,
+			wantErrs: 0,
+		},
+		{
+			name:     "changed tags on update",
+			tags:     Tags{"environment": "dev", "team": "a"},
+			oldTags:  Tags{"environment": "dev"},
+			isUpdate: true,
+			wantErrs: 2,
+		},
+	}
+	requiredTags := RequiredTags
+	RequiredTags = Tags{"costcenter": "", "environment": "prod"}
+	defer func() { RequiredTags = requiredTags }()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateRequiredTags(tc.tags, tc.oldTags, tc.isUpdate, field.NewPath("spec", "additionalTags"))
+			g.Expect(errs).To(HaveLen(tc.wantErrs))
+		})
+	}
+}
@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/net"
 )
 
@@ -29,6 +31,10 @@ const (
 	Node string = "node"
 	// Bastion subnet label.
 	Bastion string = "bastion"
+	// Gateway subnet label.
+	Gateway string = "gateway"
+	// RouteServer subnet label.
+	RouteServer string = "routeserver"
 	// Cluster subnet label.
 	Cluster string = "cluster"
 )
@@ -55,6 +61,31 @@ const (
 	SecurityTypesTrustedLaunch SecurityTypes = "TrustedLaunch"
 )
 
+// ResourceOwnership reports whether an Azure resource of a cluster is managed by CAPZ.
+type ResourceOwnership struct {
+	// Kind is the kind of the Azure resource, e.g. "ResourceGroup" or "VirtualNetwork".
+	Kind string `json:"kind"`
+
+	// Name is the name of the Azure resource.
+	Name string `json:"name"`
+
+	// Managed is true if CAPZ manages the lifecycle of the resource and deletes it along with the cluster.
+	// Unmanaged resources existed before the cluster was created and are left in place when it is deleted.
+	Managed bool `json:"managed"`
+}
+
+// AzureResourceID is the Azure Resource Manager ID of an Azure resource of a cluster.
+type AzureResourceID struct {
+	// Kind is the kind of the Azure resource, e.g. "VirtualNetwork" or "LoadBalancer".
+	Kind string `json:"kind"`
+
+	// Name is the name of the Azure resource.
+	Name string `json:"name"`
+
+	// ID is the Azure Resource Manager ID of the resource.
+	ID string `json:"id"`
+}
+
 // Futures is a slice of Future.
 type Futures []Future
 
@@ -86,6 +117,10 @@ type Future struct {
 
 	// Data is the base64 url encoded json Azure AutoRest Future.
 	Data string `json:"data"`
+
+	// StartTime is when the long-running operation was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
 }
 
 // NetworkSpec specifies what the Azure networking resources should look like.
@@ -111,9 +146,110 @@ type NetworkSpec struct {
 	// +optional
 	ControlPlaneOutboundLB *LoadBalancerSpec `json:"controlPlaneOutboundLB,omitempty"`
 
+	// FlowLogs is the configuration for the flow log of the virtual network managed by CAPZ.
+	// +optional
+	FlowLogs *FlowLogsSpec `json:"flowLogs,omitempty"`
+
+	// APIServerDNS is the configuration for a custom hostname for the API server.
+	// When set, CAPZ manages an A record for the hostname in an existing Azure DNS zone and uses it as the control plane endpoint host.
+	// +optional
+	APIServerDNS *APIServerDNSSpec `json:"apiServerDNS,omitempty"`
+
+	// ApplicationSecurityGroups is the list of application security groups CAPZ creates in the cluster resource group,
+	// which network interfaces can join and security rules can reference by name.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ApplicationSecurityGroups []ApplicationSecurityGroup `json:"applicationSecurityGroups,omitempty"`
+
+	// VirtualNetworkGateway is the configuration for a VPN or ExpressRoute gateway connecting the virtual network to
+	// on-premises networks, which is created together with the cluster.
+	// +optional
+	VirtualNetworkGateway *VirtualNetworkGatewaySpec `json:"virtualNetworkGateway,omitempty"`
+
+	// RouteServer is the configuration for an Azure Route Server in the virtual network, exchanging routes over BGP
+	// with network virtual appliances or CNIs that advertise pod routes.
+	// +optional
+	RouteServer *RouteServerSpec `json:"routeServer,omitempty"`
+
+	// APIServerPrivateLinkService is the configuration for an Azure Private Link Service exposing an internal API server
+	// load balancer, which lets clients in other virtual networks or tenants reach the API server of a private cluster
+	// through private endpoints, without peering.
+	// +optional
+	APIServerPrivateLinkService *PrivateLinkServiceSpec `json:"apiServerPrivateLinkService,omitempty"`
+
 	NetworkClassSpec `json:",inline"`
 }
 
+// ApplicationSecurityGroup defines an application security group managed by CAPZ.
+type ApplicationSecurityGroup struct {
+	// Name is the name of the application security group.
+	Name string `json:"name"`
+}
+
+// ApplicationSecurityGroupReferences is a list of references to application security groups. Each reference is either
+// the name of an application security group in the cluster resource group, whether it is managed by CAPZ or not, or
+// the resource ID of an existing application security group in another resource group.
+type ApplicationSecurityGroupReferences []string
+
+// APIServerDNSSpec configures a custom hostname for the API server in an existing Azure DNS zone.
+type APIServerDNSSpec struct {
+	// Hostname is the fully qualified domain name of the API server, e.g. "api.mycluster.example.com".
+	// It must be a subdomain of the DNS zone referenced by DNSZoneID.
+	Hostname string `json:"hostname"`
+
+	// DNSZoneID is the resource ID of the existing public Azure DNS zone the record is created in.
+	DNSZoneID string `json:"dnsZoneID"`
+
+	// TTL is the time to live of the DNS record in seconds. Defaults to 300.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int32 `json:"ttl,omitempty"`
+}
+
+// FlowLogsSpec configures a Network Watcher flow log for the virtual network.
+type FlowLogsSpec struct {
+	// StorageAccountID is the resource ID of the storage account the flow logs are written to.
+	// The storage account must be in the same region as the cluster.
+	StorageAccountID string `json:"storageAccountID"`
+
+	// RetentionDays is the number of days flow log records are retained. When not set or 0, records are retained forever.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RetentionDays int32 `json:"retentionDays,omitempty"`
+
+	// NetworkWatcherName is the name of the existing Network Watcher the flow logs are created in.
+	// Defaults to the Network Watcher Azure creates for the cluster location, "NetworkWatcher_<location>".
+	// +optional
+	NetworkWatcherName string `json:"networkWatcherName,omitempty"`
+
+	// NetworkWatcherResourceGroup is the resource group of the Network Watcher. Defaults to "NetworkWatcherRG".
+	// +optional
+	NetworkWatcherResourceGroup string `json:"networkWatcherResourceGroup,omitempty"`
+
+	// TrafficAnalytics enables traffic analytics on the flow logs.
+	// +optional
+	TrafficAnalytics *TrafficAnalyticsSpec `json:"trafficAnalytics,omitempty"`
+}
+
+// TrafficAnalyticsSpec configures traffic analytics for flow logs.
+type TrafficAnalyticsSpec struct {
+	// WorkspaceResourceID is the resource ID of the Log Analytics workspace.
+	WorkspaceResourceID string `json:"workspaceResourceID"`
+
+	// WorkspaceID is the workspace ID (GUID) of the Log Analytics workspace.
+	WorkspaceID string `json:"workspaceID"`
+
+	// WorkspaceRegion is the location of the Log Analytics workspace. Defaults to the cluster location.
+	// +optional
+	WorkspaceRegion string `json:"workspaceRegion,omitempty"`
+
+	// IntervalInMinutes is how frequently traffic analytics processes the flow logs.
+	// +kubebuilder:validation:Enum=10;60
+	// +optional
+	IntervalInMinutes *int32 `json:"intervalInMinutes,omitempty"`
+}
+
 // VnetSpec configures an Azure virtual network.
 type VnetSpec struct {
 	// ResourceGroup is the name of the resource group of the existing virtual network
@@ -204,6 +340,11 @@ type Subnets []SubnetSpec
 // +listMapKey=service
 type ServiceEndpoints []ServiceEndpointSpec
 
+// Delegations is a slice of DelegationSpec.
+// +listType=map
+// +listMapKey=name
+type Delegations []DelegationSpec
+
 // PrivateEndpoints is a slice of PrivateEndpointSpec.
 // +listType=map
 // +listMapKey=name
@@ -217,6 +358,12 @@ type SecurityGroup struct {
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
 
+	// ResourceGroup is the resource group of an existing security group to attach to the subnet.
+	// When set, the security group is neither created, modified nor deleted by CAPZ, so security rules cannot be set on it.
+	// The subnet is attached to it when CAPZ manages the virtual network, otherwise CAPZ only verifies that it is.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
 	SecurityGroupClass `json:",inline"`
 }
 
@@ -227,6 +374,49 @@ type RouteTable struct {
 	// +optional
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
+
+	// Routes is a list of user-defined routes to add to the route table.
+	// Routes that are not part of this list, such as the ones added by the cloud provider, are left untouched.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Routes []Route `json:"routes,omitempty"`
+
+	// AdditionalTags is an optional set of tags to add to the route table, in addition to the additionalTags of the
+	// cluster. A tag set here takes precedence over a cluster tag with the same key.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
+}
+
+// RouteNextHopType defines the type of Azure hop the packet should be sent to.
+// +kubebuilder:validation:Enum=VirtualNetworkGateway;VnetLocal;Internet;VirtualAppliance;None
+type RouteNextHopType string
+
+const (
+	// RouteNextHopTypeVirtualNetworkGateway sends the traffic to the virtual network gateway.
+	RouteNextHopTypeVirtualNetworkGateway = RouteNextHopType("VirtualNetworkGateway")
+	// RouteNextHopTypeVnetLocal routes the traffic within the virtual network.
+	RouteNextHopTypeVnetLocal = RouteNextHopType("VnetLocal")
+	// RouteNextHopTypeInternet sends the traffic to the Internet.
+	RouteNextHopTypeInternet = RouteNextHopType("Internet")
+	// RouteNextHopTypeVirtualAppliance sends the traffic to a network virtual appliance.
+	RouteNextHopTypeVirtualAppliance = RouteNextHopType("VirtualAppliance")
+	// RouteNextHopTypeNone drops the traffic.
+	RouteNextHopTypeNone = RouteNextHopType("None")
+)
+
+// Route defines a user-defined route of an Azure route table.
+type Route struct {
+	// Name is the name of the route.
+	Name string `json:"name"`
+	// AddressPrefix is the destination CIDR to which the route applies.
+	AddressPrefix string `json:"addressPrefix"`
+	// NextHopType is the type of Azure hop the packet should be sent to.
+	NextHopType RouteNextHopType `json:"nextHopType"`
+	// NextHopIPAddress is the IP address packets should be forwarded to.
+	// It is only allowed, and required, when NextHopType is VirtualAppliance.
+	// +optional
+	NextHopIPAddress string `json:"nextHopIPAddress,omitempty"`
 }
 
 // NatGateway defines an Azure NAT gateway.
@@ -283,6 +473,9 @@ const (
 	SecurityRuleActionDeny SecurityRuleAccess = "Deny"
 )
 
+// APIServerSecurityRuleName is the name of the default security rule allowing the API server in the control plane subnet.
+const APIServerSecurityRuleName = "allow_apiserver"
+
 // SecurityRule defines an Azure security rule for security groups.
 type SecurityRule struct {
 	// Name is a unique name within the network security group.
@@ -312,6 +505,14 @@ type SecurityRule struct {
 	// Destination is the destination address prefix. CIDR or destination IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used.
 	// +optional
 	Destination *string `json:"destination,omitempty"`
+	// SourceApplicationSecurityGroups specifies the application security groups of the network interfaces network
+	// traffic originates from, instead of source address prefixes.
+	// +optional
+	SourceApplicationSecurityGroups ApplicationSecurityGroupReferences `json:"sourceApplicationSecurityGroups,omitempty"`
+	// DestinationApplicationSecurityGroups specifies the application security groups of the network interfaces network
+	// traffic is destined to, instead of a destination address prefix.
+	// +optional
+	DestinationApplicationSecurityGroups ApplicationSecurityGroupReferences `json:"destinationApplicationSecurityGroups,omitempty"`
 	// Action specifies whether network traffic is allowed or denied. Can either be "Allow" or "Deny". Defaults to "Allow".
 	// +kubebuilder:default=Allow
 	// +kubebuilder:validation:Enum=Allow;Deny
@@ -340,6 +541,12 @@ type LoadBalancerSpec struct {
 	// BackendPool describes the backend pool of the load balancer.
 	// +optional
 	BackendPool BackendPool `json:"backendPool,omitempty"`
+	// ResourceGroup is the resource group of an existing load balancer.
+	// When set, the load balancer and its frontend public IPs are neither created, modified nor deleted by CAPZ,
+	// and CAPZ only manages the membership of network interfaces in its backend pool.
+	// It is only supported for the API server and node outbound load balancers.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
 
 	LoadBalancerClassSpec `json:",inline"`
 }
@@ -379,6 +586,24 @@ type PublicIPSpec struct {
 	DNSName string `json:"dnsName,omitempty"`
 	// +optional
 	IPTags []IPTag `json:"ipTags,omitempty"`
+	// PublicIPPrefixID is the resource ID of an existing public IP prefix the public IP is allocated from.
+	// +optional
+	PublicIPPrefixID *string `json:"publicIPPrefixID,omitempty"`
+	// ResourceGroup is the resource group of an existing public IP to use for the API server load balancer.
+	// When set, the public IP is neither created, modified nor deleted by CAPZ, so it survives cluster deletion
+	// and DNSName must be set to the FQDN of the existing public IP.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// Zones is the list of availability zones the public IP is deployed to, e.g. ["1", "2", "3"].
+	// A single zone pins the public IP to that zone, while several zones make it zone-redundant.
+	// Defaults to the failure domains of the cluster location. It cannot be changed after creation.
+	// +optional
+	// +listType=set
+	Zones []string `json:"zones,omitempty"`
+	// AdditionalTags is an optional set of tags to add to the public IP, in addition to the additionalTags of the
+	// cluster. A tag set here takes precedence over a cluster tag with the same key.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
 }
 
 // IPTag contains the IpTag associated with the object.
@@ -550,6 +775,17 @@ const (
 	VMIdentityUserAssigned VMIdentity = "UserAssigned"
 )
 
+// VMResizePolicy defines how changes to the size of a VM are handled.
+// +kubebuilder:validation:Enum=Immutable;InPlace
+type VMResizePolicy string
+
+const (
+	// VMResizePolicyImmutable is the default resize policy and rejects changes to the size of a VM.
+	VMResizePolicyImmutable VMResizePolicy = "Immutable"
+	// VMResizePolicyInPlace resizes a VM by deallocating it, changing its size and starting it again.
+	VMResizePolicyInPlace VMResizePolicy = "InPlace"
+)
+
 // SpotEvictionPolicy defines the eviction policy for spot VMs, if configured.
 // +kubebuilder:validation:Enum=Deallocate;Delete
 type SpotEvictionPolicy string
@@ -561,6 +797,31 @@ const (
 	SpotEvictionPolicyDelete SpotEvictionPolicy = "Delete"
 )
 
+// SpotPlacementCheckPolicy defines what to do when the preflight check of Spot VM allocation and eviction fails.
+// +kubebuilder:validation:Enum=Warn;Fail
+type SpotPlacementCheckPolicy string
+
+const (
+	// SpotPlacementCheckPolicyWarn reports the failed check and creates the Spot VMs anyway.
+	SpotPlacementCheckPolicyWarn SpotPlacementCheckPolicy = "Warn"
+	// SpotPlacementCheckPolicyFail doesn't create the Spot VMs until the check passes.
+	SpotPlacementCheckPolicyFail SpotPlacementCheckPolicy = "Fail"
+)
+
+// SpotPlacementScore is the likelihood that a Spot VM allocation succeeds, as reported by the Azure Spot Placement
+// Score API.
+// +kubebuilder:validation:Enum=Low;Medium;High
+type SpotPlacementScore string
+
+const (
+	// SpotPlacementScoreLow means a Spot VM allocation is unlikely to succeed.
+	SpotPlacementScoreLow SpotPlacementScore = "Low"
+	// SpotPlacementScoreMedium means a Spot VM allocation may succeed.
+	SpotPlacementScoreMedium SpotPlacementScore = "Medium"
+	// SpotPlacementScoreHigh means a Spot VM allocation is likely to succeed.
+	SpotPlacementScoreHigh SpotPlacementScore = "High"
+)
+
 // UserAssignedIdentity defines the user-assigned identities provided
 // by the user to be assigned to Azure resources.
 type UserAssignedIdentity struct {
@@ -648,6 +909,177 @@ type VMExtension struct {
 	ProtectedSettings Tags `json:"protectedSettings,omitempty"`
 }
 
+// JITAccess specifies the just-in-time (JIT) VM access policy of a virtual machine.
+type JITAccess struct {
+	// Ports are the ports of the virtual machine access can be requested to.
+	// +kubebuilder:validation:MinItems=1
+	Ports []JITAccessPort `json:"ports"`
+}
+
+// JITAccessProtocol is the protocol of a port of a just-in-time VM access policy.
+// +kubebuilder:validation:Enum=TCP;UDP;*
+type JITAccessProtocol string
+
+const (
+	// JITAccessProtocolTCP is the TCP protocol.
+	JITAccessProtocolTCP JITAccessProtocol = "TCP"
+	// JITAccessProtocolUDP is the UDP protocol.
+	JITAccessProtocolUDP JITAccessProtocol = "UDP"
+	// JITAccessProtocolAll is any protocol.
+	JITAccessProtocolAll JITAccessProtocol = "*"
+)
+
+// JITAccessPort specifies a port of a virtual machine access can be requested to.
+type JITAccessPort struct {
+	// Port is the port number, e.g. 22 for SSH.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Protocol is the protocol of the port. Defaults to TCP.
+	// +kubebuilder:default=TCP
+	// +optional
+	Protocol JITAccessProtocol `json:"protocol,omitempty"`
+	// AllowedSourceCIDRs are the address prefixes, in CIDR notation, access may be requested from. Access may be
+	// requested from any source when empty.
+	// +optional
+	AllowedSourceCIDRs []string `json:"allowedSourceCIDRs,omitempty"`
+	// MaxRequestAccessDuration is the maximum duration of the access granted by a request, in whole minutes up to
+	// 24 hours. Defaults to 3 hours.
+	// +optional
+	MaxRequestAccessDuration *metav1.Duration `json:"maxRequestAccessDuration,omitempty"`
+}
+
+// WindowsConfiguration specifies the settings of Windows virtual machines.
+type WindowsConfiguration struct {
+	// DomainJoin joins the virtual machine to an Active Directory domain, which is a prerequisite for running
+	// Windows containers with a Group Managed Service Account (GMSA).
+	// +optional
+	DomainJoin *WindowsDomainJoin `json:"domainJoin,omitempty"`
+
+	// EnableAutomaticUpdates specifies whether Windows Update automatically installs updates on the virtual machine.
+	// Defaults to true when PatchMode is AutomaticByOS or AutomaticByPlatform, and to false otherwise, as updates
+	// may restart nodes at any time.
+	// +optional
+	EnableAutomaticUpdates *bool `json:"enableAutomaticUpdates,omitempty"`
+
+	// PatchMode specifies how the virtual machine is patched. Manual, the default, leaves patching to the user.
+	// AutomaticByOS lets Windows Update patch the virtual machine. AutomaticByPlatform lets Azure orchestrate the
+	// patching of the virtual machine, following availability-first principles.
+	// +optional
+	PatchMode WindowsPatchMode `json:"patchMode,omitempty"`
+
+	// TimeZone is the Windows time zone of the virtual machine, e.g. "Pacific Standard Time", as listed by
+	// `tzutil /l`. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// AdditionalUnattendContent specifies XML content added to the Unattend.xml file used by Windows Setup.
+	// +optional
+	// +listType=map
+	// +listMapKey=settingName
+	// +kubebuilder:validation:MaxItems=2
+	AdditionalUnattendContent []AdditionalUnattendContent `json:"additionalUnattendContent,omitempty"`
+}
+
+// WindowsPatchMode specifies how a Windows virtual machine is patched.
+// +kubebuilder:validation:Enum=Manual;AutomaticByOS;AutomaticByPlatform
+type WindowsPatchMode string
+
+const (
+	// WindowsPatchModeManual leaves the patching of the virtual machine to the user.
+	WindowsPatchModeManual WindowsPatchMode = "Manual"
+	// WindowsPatchModeAutomaticByOS lets Windows Update patch the virtual machine.
+	WindowsPatchModeAutomaticByOS WindowsPatchMode = "AutomaticByOS"
+	// WindowsPatchModeAutomaticByPlatform lets Azure orchestrate the patching of the virtual machine.
+	WindowsPatchModeAutomaticByPlatform WindowsPatchMode = "AutomaticByPlatform"
+)
+
+// UnattendSettingName is the name of an Unattend.xml setting of the Microsoft-Windows-Shell-Setup component.
+// +kubebuilder:validation:Enum=AutoLogon;FirstLogonCommands
+type UnattendSettingName string
+
+const (
+	// UnattendSettingNameAutoLogon is the AutoLogon setting.
+	UnattendSettingNameAutoLogon UnattendSettingName = "AutoLogon"
+	// UnattendSettingNameFirstLogonCommands is the FirstLogonCommands setting.
+	UnattendSettingNameFirstLogonCommands UnattendSettingName = "FirstLogonCommands"
+)
+
+// AdditionalUnattendContent specifies XML content added to a setting of the Microsoft-Windows-Shell-Setup component
+// in the oobeSystem pass of the Unattend.xml file.
+type AdditionalUnattendContent struct {
+	// SettingName is the name of the setting the content applies to.
+	SettingName UnattendSettingName `json:"settingName"`
+	// Content is the XML content of the setting, including its root element. It must be less than 4KB.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4095
+	Content string `json:"content"`
+}
+
+// WindowsDomainJoin specifies how a Windows virtual machine joins an Active Directory domain. The virtual machine
+// joins the domain using the JsonADDomainExtension VM extension, and restarts once joined.
+type WindowsDomainJoin struct {
+	// DomainName is the fully qualified name of the Active Directory domain to join, e.g. "contoso.com".
+	// +kubebuilder:validation:MinLength=1
+	DomainName string `json:"domainName"`
+	// OUPath is the distinguished name of the organizational unit the computer account is created in, e.g.
+	// "OU=k8s,DC=contoso,DC=com". The default organizational unit of the domain is used if not set.
+	// +optional
+	OUPath string `json:"ouPath,omitempty"`
+	// User is the name of a domain account allowed to join computers to the domain, e.g. "contoso.com\joiner".
+	// +kubebuilder:validation:MinLength=1
+	User string `json:"user"`
+	// PasswordSecretRef references a Secret, in the namespace of the machine, holding the password of User in its
+	// "password" key.
+	PasswordSecretRef corev1.LocalObjectReference `json:"passwordSecretRef"`
+}
+
+// BootstrapDataAccess is how a virtual machine is allowed to read its bootstrap data from a storage account.
+// +kubebuilder:validation:Enum=SAS;ManagedIdentity
+type BootstrapDataAccess string
+
+const (
+	// BootstrapDataAccessSAS lets the virtual machine read its bootstrap data with a read-only shared access
+	// signature (SAS), signed with a key of the storage account.
+	BootstrapDataAccessSAS BootstrapDataAccess = "SAS"
+	// BootstrapDataAccessManagedIdentity lets the virtual machine read its bootstrap data with its managed identity,
+	// which must be allowed to read the blobs of the storage account.
+	BootstrapDataAccessManagedIdentity BootstrapDataAccess = "ManagedIdentity"
+)
+
+// BootstrapDataStorage specifies a storage account the bootstrap data of a virtual machine is delivered through when
+// it exceeds the custom data size limit of Azure virtual machines.
+type BootstrapDataStorage struct {
+	// StorageAccountName is the name of an existing storage account in the subscription of the cluster.
+	StorageAccountName string `json:"storageAccountName"`
+	// ResourceGroup is the resource group of the storage account. Defaults to the resource group of the cluster.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// ContainerName is the name of the blob container the bootstrap data is uploaded to. It is created if it
+	// doesn't exist. Defaults to "bootstrap-data".
+	// +kubebuilder:default=bootstrap-data
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+	// Access is how the virtual machine is allowed to read its bootstrap data. SAS, the default, embeds a read-only
+	// shared access signature valid for 24 hours in the custom data of the virtual machine. ManagedIdentity requires
+	// the identity of the virtual machine to have the Storage Blob Data Reader role on the storage account, and is
+	// only supported by the ignition bootstrap format.
+	// +kubebuilder:default=SAS
+	// +optional
+	Access BootstrapDataAccess `json:"access,omitempty"`
+}
+
+// BootstrapDataKeyVault specifies a key vault the bootstrap data of a virtual machine is stored in.
+type BootstrapDataKeyVault struct {
+	// VaultName is the name of an existing key vault in the subscription of the cluster.
+	VaultName string `json:"vaultName"`
+	// IdentityClientID is the client ID of the user-assigned identity of the virtual machine used to read its
+	// bootstrap data. It is required when the virtual machine has several user-assigned identities, and the
+	// system-assigned identity or the only user-assigned identity of the virtual machine is used otherwise.
+	// +optional
+	IdentityClientID string `json:"identityClientID,omitempty"`
+}
+
 // ManagedDiskParameters defines the parameters of a managed disk.
 type ManagedDiskParameters struct {
 	// +optional
@@ -741,6 +1173,12 @@ const (
 	// SubnetBastion defines a Bastion subnet role.
 	SubnetBastion = SubnetRole(Bastion)
 
+	// SubnetGateway defines a virtual network gateway subnet role.
+	SubnetGateway = SubnetRole(Gateway)
+
+	// SubnetRouteServer defines a Route Server subnet role.
+	SubnetRouteServer = SubnetRole(RouteServer)
+
 	// SubnetCluster defines a role that can be used for both Kubernetes control plane node and Kubernetes workload node.
 	SubnetCluster = SubnetRole(Cluster)
 )
@@ -774,6 +1212,15 @@ type ServiceEndpointSpec struct {
 	Locations []string `json:"locations"`
 }
 
+// DelegationSpec configures the delegation of a subnet to an Azure service.
+type DelegationSpec struct {
+	// Name is the name of the delegation, unique within the subnet.
+	Name string `json:"name"`
+
+	// ServiceName is the name of the service the subnet is delegated to (e.g. Microsoft.NetApp/volumes).
+	ServiceName string `json:"serviceName"`
+}
+
 // PrivateLinkServiceConnection defines the specification for a private link service connection associated with a private endpoint.
 type PrivateLinkServiceConnection struct {
 	// Name specifies the name of the private link service.
@@ -878,6 +1325,14 @@ func (s SubnetSpec) IsNatGatewayEnabled() bool {
 	return s.NatGateway.Name != ""
 }
 
+// PodSubnetName returns the name of the dedicated pod subnet of the subnet, or an empty string if it has none.
+func (s SubnetSpec) PodSubnetName() string {
+	if s.PodSubnet == nil {
+		return ""
+	}
+	return s.PodSubnet.Name
+}
+
 // IsIPv6Enabled returns whether or not IPv6 is enabled on the subnet.
 func (s SubnetSpec) IsIPv6Enabled() bool {
 	for _, cidr := range s.CIDRBlocks {
@@ -937,6 +1392,34 @@ type CloudProviderConfigOverrides struct {
 	RateLimits []RateLimitSpec `json:"rateLimits,omitempty"`
 	// +optional
 	BackOffs BackOffConfig `json:"backOffs,omitempty"`
+	// ExcludeMasterFromStandardLB excludes the control plane nodes from the backend pools of the Standard load balancers
+	// managed by the cloud provider. The cloud provider defaults it to true.
+	// +optional
+	ExcludeMasterFromStandardLB *bool `json:"excludeMasterFromStandardLB,omitempty"`
+	// External tailors the cloud provider config to the out-of-tree cloud-provider-azure and the Azure Disk and File
+	// CSI drivers, and sets the fields only they understand.
+	// +optional
+	External *ExternalCloudProviderConfig `json:"external,omitempty"`
+}
+
+// ExternalCloudProviderConfig represents the cloud provider config fields only understood by the out-of-tree
+// cloud-provider-azure.
+// See: https://cloud-provider-azure.sigs.k8s.io/install/configs
+type ExternalCloudProviderConfig struct {
+	// LoadBalancerBackendPoolConfigurationType is how the nodes are added to the backend pools of the load balancers:
+	// by their NIC IP configuration, by their IP address, or by the IP addresses of the pods.
+	// +kubebuilder:validation:Enum=nodeIPConfiguration;nodeIP;podIP
+	// +optional
+	LoadBalancerBackendPoolConfigurationType string `json:"loadBalancerBackendPoolConfigurationType,omitempty"`
+	// ClusterServiceLoadBalancerHealthProbeMode is whether the health probes of the load balancer rules target the
+	// node port of each service, or a port shared by all the services.
+	// +kubebuilder:validation:Enum=servicenodeport;shared
+	// +optional
+	ClusterServiceLoadBalancerHealthProbeMode string `json:"clusterServiceLoadBalancerHealthProbeMode,omitempty"`
+	// PutVMSSVMBatchSize is the number of scale set VMs updated in parallel by the cloud provider.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PutVMSSVMBatchSize int `json:"putVMSSVMBatchSize,omitempty"`
 }
 
 // BackOffConfig indicates the back-off config options.
@@ -1049,6 +1532,101 @@ type AzureBastion struct {
 	EnableTunneling bool `json:"enableTunneling,omitempty"`
 }
 
+// VirtualNetworkGatewayType is the type of a virtual network gateway.
+type VirtualNetworkGatewayType string
+
+const (
+	// VirtualNetworkGatewayTypeVPN is a gateway sending encrypted traffic over the internet.
+	VirtualNetworkGatewayTypeVPN VirtualNetworkGatewayType = "Vpn"
+	// VirtualNetworkGatewayTypeExpressRoute is a gateway sending traffic over an ExpressRoute circuit.
+	VirtualNetworkGatewayTypeExpressRoute VirtualNetworkGatewayType = "ExpressRoute"
+)
+
+// VPNType is the routing type of a VPN gateway.
+type VPNType string
+
+const (
+	// VPNTypeRouteBased routes traffic through the tunnels according to routes.
+	VPNTypeRouteBased VPNType = "RouteBased"
+	// VPNTypePolicyBased routes traffic through the tunnels according to the IPsec policies of the connections.
+	VPNTypePolicyBased VPNType = "PolicyBased"
+)
+
+// VirtualNetworkGatewaySpec specifies a virtual network gateway of the cluster virtual network.
+type VirtualNetworkGatewaySpec struct {
+	// Name is the name of the virtual network gateway. Defaults to <cluster name>-vnet-gateway.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Type is the type of the gateway, either Vpn or ExpressRoute.
+	// +kubebuilder:validation:Enum=Vpn;ExpressRoute
+	Type VirtualNetworkGatewayType `json:"type"`
+
+	// SKU is the SKU of the gateway, e.g. VpnGw2AZ for a VPN gateway or ErGw2AZ for an ExpressRoute gateway.
+	// Defaults to VpnGw1AZ for VPN gateways and to ErGw1AZ for ExpressRoute gateways.
+	// +optional
+	SKU string `json:"sku,omitempty"`
+
+	// VPNType is the routing type of a VPN gateway, either RouteBased or PolicyBased. Defaults to RouteBased for VPN
+	// gateways, and may not be set for ExpressRoute gateways.
+	// +kubebuilder:validation:Enum=RouteBased;PolicyBased
+	// +optional
+	VPNType VPNType `json:"vpnType,omitempty"`
+
+	// Subnet is the subnet of the gateway, which Azure requires to be named GatewaySubnet.
+	// Defaults to the GatewaySubnet subnet with the 10.255.255.192/27 CIDR block.
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+
+	// PublicIP is the public IP address of the gateway. Its name defaults to <cluster name>-vnet-gateway-pip.
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+}
+
+// RouteServerSpec specifies an Azure Route Server of the cluster virtual network.
+type RouteServerSpec struct {
+	// Name is the name of the Route Server. Defaults to <cluster name>-routeserver.
+	// A pre-existing Route Server with this name that is not owned by the cluster is referenced rather than created,
+	// in which case CAPZ only manages its BGP peers.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Subnet is the subnet of the Route Server, which Azure requires to be named RouteServerSubnet.
+	// Defaults to the RouteServerSubnet subnet with the 10.255.255.160/27 CIDR block.
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+
+	// PublicIP is the public IP address Azure uses to manage the Route Server. Its name defaults to
+	// <cluster name>-routeserver-pip.
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+
+	// BranchToBranchTraffic enables the exchange of routes between the Route Server and the virtual network gateway.
+	// +optional
+	BranchToBranchTraffic bool `json:"branchToBranchTraffic,omitempty"`
+
+	// BGPPeers are the BGP speakers, such as network virtual appliances or CNI route reflectors, that the Route Server
+	// exchanges routes with.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	BGPPeers []BGPPeer `json:"bgpPeers,omitempty"`
+}
+
+// BGPPeer specifies a BGP speaker peering with an Azure Route Server.
+type BGPPeer struct {
+	// Name is the name of the BGP connection of the Route Server to the peer.
+	Name string `json:"name"`
+
+	// PeerIP is the IP address of the peer, which must be reachable from the Route Server subnet.
+	PeerIP string `json:"peerIP"`
+
+	// PeerASN is the autonomous system number of the peer.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	PeerASN int64 `json:"peerASN"`
+}
+
 // FleetsMember defines the fleets member configuration.
 // See also [AKS doc].
 //
@@ -1082,6 +1660,13 @@ type Diagnostics struct {
 	// If not specified then Boot diagnostics (Managed) will be enabled.
 	// +optional
 	Boot *BootDiagnostics `json:"boot,omitempty"`
+
+	// CaptureOnFailure records an Event referencing the serial log and the screenshot of the virtual machine of a
+	// failed AzureMachine, for example one remediated by a MachineHealthCheck, before the virtual machine is deleted.
+	// It requires boot diagnostics stored in a user-managed storage account, which keeps them after the virtual
+	// machine is deleted. It is ignored by AzureMachinePools.
+	// +optional
+	CaptureOnFailure bool `json:"captureOnFailure,omitempty"`
 }
 
 // BootDiagnostics configures the boot diagnostics settings for the virtual machine.
@@ -1215,3 +1800,75 @@ const (
 	// AKSAssignedIdentityUserAssigned ...
 	AKSAssignedIdentityUserAssigned AKSAssignedIdentity = "UserAssigned"
 )
+
+const (
+	// ResourceNamingMachinePlaceholder is replaced with the name of the machine in a resource naming template.
+	ResourceNamingMachinePlaceholder = "{machine}"
+	// ResourceNamingIndexPlaceholder is replaced with the index of the network interface in a resource naming template.
+	ResourceNamingIndexPlaceholder = "{index}"
+	// ResourceNamingNameSuffixPlaceholder is replaced with the name suffix of the data disk in a resource naming template.
+	ResourceNamingNameSuffixPlaceholder = "{nameSuffix}"
+)
+
+// ResourceNamingSpec configures the names generated for the Azure resources of the cluster.
+type ResourceNamingSpec struct {
+	// Prefix is prepended to the generated names of network interfaces, disks, public IPs and load balancers.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix is appended to the generated names of network interfaces, disks, public IPs and load balancers.
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// NetworkInterface is the template used to name the network interfaces of a machine. It must contain the
+	// "{machine}" placeholder and may contain the "{index}" placeholder, which is replaced with the index of the
+	// network interface. When a machine has several network interfaces and the template does not contain
+	// "{index}", "-{index}" is appended to it. Defaults to "{machine}-nic".
+	// +optional
+	NetworkInterface string `json:"networkInterface,omitempty"`
+
+	// OSDisk is the template used to name the OS disk of a machine. It must contain the "{machine}" placeholder.
+	// Defaults to "{machine}_OSDisk".
+	// +optional
+	OSDisk string `json:"osDisk,omitempty"`
+
+	// DataDisk is the template used to name the data disks of a machine. It must contain the "{machine}" and
+	// "{nameSuffix}" placeholders. Defaults to "{machine}_{nameSuffix}".
+	// +optional
+	DataDisk string `json:"dataDisk,omitempty"`
+
+	// PublicIP is the template used to name the public IP of a machine. It must contain the "{machine}"
+	// placeholder. Defaults to "pip-{machine}".
+	// +optional
+	PublicIP string `json:"publicIP,omitempty"`
+}
+
+// Apply returns name with the configured prefix and suffix.
+func (r *ResourceNamingSpec) Apply(name string) string {
+	if r == nil {
+		return name
+	}
+	return r.Prefix + name + r.Suffix
+}
+
+// PrivateLinkServiceSpec specifies an Azure Private Link Service in front of the API server load balancer.
+type PrivateLinkServiceSpec struct {
+	// Name is the name of the Private Link Service. Defaults to <cluster name>-apiserver-pls.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// SubnetName is the name of the subnet the Private Link Service allocates the source IP addresses of the
+	// connections it forwards to the load balancer from. Defaults to the control plane subnet.
+	// +optional
+	SubnetName string `json:"subnetName,omitempty"`
+
+	// AllowedSubscriptions are the IDs of the subscriptions in which private endpoints can connect to the Private Link
+	// Service. Only the subscription of the cluster is allowed when empty.
+	// +optional
+	AllowedSubscriptions []string `json:"allowedSubscriptions,omitempty"`
+
+	// AutoApprovedSubscriptions are the IDs of the subscriptions whose private endpoint connections are approved
+	// automatically. Connections from other subscriptions must be approved manually.
+	// +optional
+	AutoApprovedSubscriptions []string `json:"autoApprovedSubscriptions,omitempty"`
+}
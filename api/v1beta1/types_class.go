@@ -71,12 +71,23 @@ type AzureClusterClassSpec struct {
 	// +optional
 	CloudProviderConfigOverrides *CloudProviderConfigOverrides `json:"cloudProviderConfigOverrides,omitempty"`
 
+	// DisableCloudProviderConfig disables the generation of the cloud provider config secrets of the machines, for
+	// clusters whose cloud provider config is managed by other means, such as their bootstrap provider.
+	// +optional
+	DisableCloudProviderConfig bool `json:"disableCloudProviderConfig,omitempty"`
+
 	// FailureDomains is a list of failure domains in the cluster's region, used to restrict
 	// eligibility to host the control plane. A FailureDomain maps to an availability zone,
 	// which is a separated group of datacenters within a region.
 	// See: https://learn.microsoft.com/azure/reliability/availability-zones-overview
 	// +optional
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
+
+	// ResourceNaming configures the names generated for network interfaces, disks, public IPs and load balancers,
+	// for example to comply with naming standards. Names of existing resources are not changed.
+	// Immutable.
+	// +optional
+	ResourceNaming *ResourceNamingSpec `json:"resourceNaming,omitempty"`
 }
 
 // AzureManagedControlPlaneClassSpec defines the AzureManagedControlPlane properties that may be shared across several azure managed control planes.
@@ -454,17 +465,29 @@ type NetworkClassSpec struct {
 	// PrivateDNSZoneName defines the zone name for the Azure Private DNS.
 	// +optional
 	PrivateDNSZoneName string `json:"privateDNSZoneName,omitempty"`
+
+	// APIServerAccessList restricts the sources allowed to reach the API server to the given address prefixes in CIDR
+	// notation. It is applied to the allow_apiserver security rule of the control plane subnet, which otherwise allows
+	// any source. The outbound IP addresses of the cluster must be included for its nodes to reach a public API server.
+	// +optional
+	APIServerAccessList []string `json:"apiServerAccessList,omitempty"`
 }
 
 // VnetClassSpec defines the VnetSpec properties that may be shared across several Azure clusters.
 type VnetClassSpec struct {
 	// CIDRBlocks defines the virtual network's address space, specified as one or more address prefixes in CIDR notation.
+	// Address prefixes can be added to a virtual network managed by CAPZ but not removed.
 	// +optional
 	CIDRBlocks []string `json:"cidrBlocks,omitempty"`
 
 	// Tags is a collection of tags describing the resource.
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
+
+	// AdditionalTags is an optional set of tags to add to the virtual network, in addition to the additionalTags of the
+	// cluster. A tag set here takes precedence over a cluster tag with the same key.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
 }
 
 // SubnetClassSpec defines the SubnetSpec properties that may be shared across several Azure clusters.
@@ -473,7 +496,7 @@ type SubnetClassSpec struct {
 	Name string `json:"name"`
 
 	// Role defines the subnet role (eg. Node, ControlPlane)
-	// +kubebuilder:validation:Enum=node;control-plane;bastion;all
+	// +kubebuilder:validation:Enum=node;control-plane;bastion;gateway;routeserver;all
 	Role SubnetRole `json:"role"`
 
 	// CIDRBlocks defines the subnet's address space, specified as one or more address prefixes in CIDR notation.
@@ -484,9 +507,38 @@ type SubnetClassSpec struct {
 	// +optional
 	ServiceEndpoints ServiceEndpoints `json:"serviceEndpoints,omitempty"`
 
+	// Delegations is a slice of services the subnet is delegated to, such as Microsoft.NetApp/volumes.
+	// A delegated subnet can only host resources of the services it is delegated to.
+	// +optional
+	Delegations Delegations `json:"delegations,omitempty"`
+
 	// PrivateEndpoints defines a list of private endpoints that should be attached to this subnet.
 	// +optional
 	PrivateEndpoints PrivateEndpoints `json:"privateEndpoints,omitempty"`
+
+	// PodSubnet defines a dedicated subnet from which the secondary private IP addresses of the network interfaces
+	// in this subnet are allocated, so that Azure CNI assigns pod IPs from a separate address space than node IPs.
+	// The secondary private IP addresses are allocated on a second network interface in the pod subnet.
+	// It may only be set on node subnets and subnets with role all.
+	// +optional
+	PodSubnet *PodSubnetSpec `json:"podSubnet,omitempty"`
+
+	// ApplicationSecurityGroups is the list of application security groups the network interfaces of the machines
+	// in this subnet join.
+	// +optional
+	ApplicationSecurityGroups ApplicationSecurityGroupReferences `json:"applicationSecurityGroups,omitempty"`
+}
+
+// PodSubnetSpec defines a dedicated subnet for the pod IP addresses of the machines of a subnet. It shares the
+// security group, route table and NAT gateway of its subnet.
+type PodSubnetSpec struct {
+	// Name defines a name for the pod subnet resource.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// CIDRBlocks defines the pod subnet's address space, specified as one or more address prefixes in CIDR notation.
+	// +optional
+	CIDRBlocks []string `json:"cidrBlocks,omitempty"`
 }
 
 // LoadBalancerClassSpec defines the LoadBalancerSpec properties that may be shared across several Azure clusters.
@@ -498,6 +550,10 @@ type LoadBalancerClassSpec struct {
 	// IdleTimeoutInMinutes specifies the timeout for the TCP idle connection.
 	// +optional
 	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+	// AdditionalTags is an optional set of tags to add to the load balancer, in addition to the additionalTags of the
+	// cluster. A tag set here takes precedence over a cluster tag with the same key.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
 }
 
 // FleetsMemberClassSpec defines the FleetsMemberSpec properties that may be shared across several Azure clusters.
@@ -517,8 +573,28 @@ type FleetsMemberClassSpec struct {
 type SecurityGroupClass struct {
 	// +optional
 	SecurityRules SecurityRules `json:"securityRules,omitempty"`
+	// DefaultSSHRule configures the default security rule allowing inbound SSH, which is added along with the rule
+	// allowing the API server to the security group of the control plane subnet when it has no security rules.
+	// It may only be set on control plane subnets.
+	// +optional
+	DefaultSSHRule *DefaultSSHRule `json:"defaultSSHRule,omitempty"`
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
+	// AdditionalTags is an optional set of tags to add to the security group, in addition to the additionalTags of the
+	// cluster. A tag set here takes precedence over a cluster tag with the same key.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
+}
+
+// DefaultSSHRule configures the default security rule allowing inbound SSH to the control plane subnet.
+type DefaultSSHRule struct {
+	// Disabled omits the default SSH security rule, so that SSH is not allowed to the control plane subnet.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// SourceCIDRs restricts the sources allowed by the default SSH security rule to the given address prefixes in
+	// CIDR notation. SSH is allowed from any source when empty.
+	// +optional
+	SourceCIDRs []string `json:"sourceCIDRs,omitempty"`
 }
 
 // FrontendIPClass defines the FrontendIP properties that may be shared across several Azure clusters.
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this AzureClusterIdentity to the Hub version (v1beta1).
+func (src *AzureClusterIdentity) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.AzureClusterIdentity)
+
+	restored := &infrav1.AzureClusterIdentity{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = infrav1.AzureClusterIdentitySpec{
+		Type:              src.Spec.Type,
+		ClientID:          src.Spec.ClientID,
+		ClientSecret:      src.Spec.ClientSecret,
+		TenantID:          src.Spec.TenantID,
+		AllowedNamespaces: src.Spec.AllowedNamespaces,
+	}
+	dst.Status = infrav1.AzureClusterIdentityStatus(src.Status)
+
+	// The deprecated ResourceID has no effect, but is kept as long as the spec is unchanged.
+	if ok {
+		var converted AzureClusterIdentity
+		if err := converted.ConvertFrom(restored); err != nil {
+			return err
+		}
+		if apiequality.Semantic.DeepEqual(converted.Spec, src.Spec) {
+			dst.Spec.ResourceID = restored.Spec.ResourceID
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *AzureClusterIdentity) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.AzureClusterIdentity)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = AzureClusterIdentitySpec{
+		Type:              src.Spec.Type,
+		ClientID:          src.Spec.ClientID,
+		ClientSecret:      src.Spec.ClientSecret,
+		TenantID:          src.Spec.TenantID,
+		AllowedNamespaces: src.Spec.AllowedNamespaces,
+	}
+	dst.Status = AzureClusterIdentityStatus(src.Status)
+
+	// Preserve Hub data on down-conversion.
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this AzureClusterIdentityList to the Hub version (v1beta1).
+func (src *AzureClusterIdentityList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.AzureClusterIdentityList)
+	dst.ListMeta = src.ListMeta
+	dst.Items = make([]infrav1.AzureClusterIdentity, len(src.Items))
+	for i := range src.Items {
+		if err := src.Items[i].ConvertTo(&dst.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *AzureClusterIdentityList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.AzureClusterIdentityList)
+	dst.ListMeta = src.ListMeta
+	dst.Items = make([]AzureClusterIdentity, len(src.Items))
+	for i := range src.Items {
+		if err := dst.Items[i].ConvertFrom(&src.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
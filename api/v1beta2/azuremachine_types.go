@@ -0,0 +1,319 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors"
+)
+
+// AzureMachineSpec defines the desired state of AzureMachine.
+type AzureMachineSpec struct {
+	// ProviderID is the unique identifier as specified by the cloud provider.
+	// +optional
+	ProviderID *string `json:"providerID,omitempty"`
+
+	VMSize string `json:"vmSize"`
+
+	// ResizePolicy describes how changes to VMSize are handled. Immutable, the default, rejects changes to VMSize, so
+	// resizing a machine requires replacing it. InPlace allows changing VMSize, in which case the VM is deallocated,
+	// resized and started again, keeping its disks but taking it down for a few minutes.
+	// +optional
+	ResizePolicy infrav1.VMResizePolicy `json:"resizePolicy,omitempty"`
+
+	// FailureDomain is the failure domain unique identifier this Machine should be attached to,
+	// as defined in Cluster API. This relates to an Azure Availability Zone
+	// +optional
+	FailureDomain *string `json:"failureDomain,omitempty"`
+
+	// Image is used to provide details of an image to use during VM creation.
+	// If image details are omitted the image will default the Azure Marketplace "capi" offer,
+	// which is based on Ubuntu.
+	// +kubebuilder:validation:nullable
+	// +optional
+	Image *Image `json:"image,omitempty"`
+
+	// FIPS specifies whether the virtual machine runs a FIPS 140-2 validated image. When true and image is omitted,
+	// the image defaults to the FIPS-enabled variant of the Azure Marketplace "capi" offer; when an image is provided,
+	// it must be a FIPS variant. FIPS-enabled default images are only available for Linux.
+	// +optional
+	FIPS bool `json:"fips,omitempty"`
+
+	// Identity is the type of identity used for the virtual machine.
+	// The type 'SystemAssigned' is an implicitly created identity.
+	// The generated identity will be assigned a Subscription contributor role.
+	// The type 'UserAssigned' is a standalone Azure resource provided by the user
+	// and assigned to the VM
+	// +kubebuilder:default=None
+	// +optional
+	Identity infrav1.VMIdentity `json:"identity,omitempty"`
+
+	// UserAssignedIdentities is a list of standalone Azure identities provided by the user
+	// The lifecycle of a user-assigned identity is managed separately from the lifecycle of
+	// the AzureMachine.
+	// See https://learn.microsoft.com/azure/active-directory/managed-identities-azure-resources/how-to-manage-ua-identity-cli
+	// +optional
+	UserAssignedIdentities []infrav1.UserAssignedIdentity `json:"userAssignedIdentities,omitempty"`
+
+	// SystemAssignedIdentityRole defines the role and scope to assign to the system-assigned identity.
+	// +optional
+	SystemAssignedIdentityRole *infrav1.SystemAssignedIdentityRole `json:"systemAssignedIdentityRole,omitempty"`
+
+	// OSDisk specifies the parameters for the operating system disk of the machine
+	OSDisk infrav1.OSDisk `json:"osDisk"`
+
+	// DataDisk specifies the parameters that are used to add one or more data disks to the machine
+	// +optional
+	DataDisks []infrav1.DataDisk `json:"dataDisks,omitempty"`
+
+	// SSHPublicKey is the SSH public key string, base64-encoded to add to a Virtual Machine. Linux only.
+	// Refer to documentation on how to set up SSH access on Windows instances.
+	// +optional
+	SSHPublicKey string `json:"sshPublicKey"`
+
+	// AdditionalTags is an optional set of tags to add to an instance, in addition to the ones added by default by the
+	// Azure provider. If both the AzureCluster and the AzureMachine specify the same tag name with different values, the
+	// AzureMachine's value takes precedence.
+	// +optional
+	AdditionalTags infrav1.Tags `json:"additionalTags,omitempty"`
+
+	// AdditionalCapabilities specifies additional capabilities enabled or disabled on the virtual machine.
+	// +optional
+	AdditionalCapabilities *infrav1.AdditionalCapabilities `json:"additionalCapabilities,omitempty"`
+
+	// AllocatePublicIP allows the ability to create dynamic public ips for machines where this value is true.
+	// +optional
+	AllocatePublicIP bool `json:"allocatePublicIP,omitempty"`
+
+	// EnablePublicIPDNSLabel assigns a DNS name label, generated from the machine name, to the public IP allocated for
+	// the machine so it can be reached at "<label>.<location>.cloudapp.azure.com" instead of by its IP address.
+	// It may only be set to true when AllocatePublicIP is true.
+	// +optional
+	EnablePublicIPDNSLabel bool `json:"enablePublicIPDNSLabel,omitempty"`
+
+	// PublicIPPrefixID is the resource ID of an existing public IP prefix the machine's public IP is allocated from,
+	// so that all node public IPs fall within a single, known CIDR range.
+	// It may only be set when AllocatePublicIP is true.
+	// +optional
+	PublicIPPrefixID *string `json:"publicIPPrefixID,omitempty"`
+
+	// EnableIPForwarding enables IP Forwarding in Azure which is required for some CNI's to send traffic from a pods on one machine
+	// to another. This is required for IpV6 with Calico in combination with User Defined Routes (set by the Azure Cloud Controller
+	// manager). Default is false for disabled.
+	// +optional
+	EnableIPForwarding bool `json:"enableIPForwarding,omitempty"`
+
+	// Diagnostics specifies the diagnostics settings for a virtual machine.
+	// If not specified then Boot diagnostics (Managed) will be enabled.
+	// +optional
+	Diagnostics *infrav1.Diagnostics `json:"diagnostics,omitempty"`
+
+	// SpotVMOptions allows the ability to specify the Machine should use a Spot VM
+	// +optional
+	SpotVMOptions *infrav1.SpotVMOptions `json:"spotVMOptions,omitempty"`
+
+	// SecurityProfile specifies the Security profile settings for a virtual machine.
+	// +optional
+	SecurityProfile *infrav1.SecurityProfile `json:"securityProfile,omitempty"`
+
+	// DNSServers adds a list of DNS Server IP addresses to the VM NICs.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+
+	// DisableExtensionOperations specifies whether extension operations should be disabled on the virtual machine.
+	// Use this setting only if VMExtensions are not supported by your image, as it disables CAPZ bootstrapping extension used for detecting Kubernetes bootstrap failure.
+	// This may only be set to True when no extensions are configured on the virtual machine.
+	// +optional
+	DisableExtensionOperations *bool `json:"disableExtensionOperations,omitempty"`
+
+	// VMExtensions specifies a list of extensions to be added to the virtual machine.
+	// +optional
+	VMExtensions []infrav1.VMExtension `json:"vmExtensions,omitempty"`
+
+	// InstallGPUDriver installs the Azure GPU driver VM extension matching the GPU vendor of the VM size, i.e. NVIDIA
+	// or AMD, when the VM size has GPUs. The AMD GPU driver extension is only available for Windows.
+	// +optional
+	InstallGPUDriver *bool `json:"installGPUDriver,omitempty"`
+
+	// JITAccess registers the virtual machine with a Microsoft Defender for Cloud just-in-time (JIT) VM access
+	// policy, so that access to its ports is requested for a limited time instead of being allowed by standing
+	// security rules. Microsoft Defender for Servers must be enabled on the subscription.
+	// +optional
+	JITAccess *infrav1.JITAccess `json:"jitAccess,omitempty"`
+
+	// WindowsConfiguration specifies settings for Windows virtual machines, and may only be set when the OS disk
+	// OS type is Windows.
+	// +optional
+	WindowsConfiguration *infrav1.WindowsConfiguration `json:"windowsConfiguration,omitempty"`
+
+	// BootstrapDataStorage specifies a storage account the bootstrap data of the virtual machine is uploaded to
+	// when it exceeds the 64 KiB custom data limit of Azure virtual machines, for instance with large cloud-init or
+	// ignition configurations. The virtual machine then receives a small custom data fetching its bootstrap data
+	// from the storage account at boot. The bootstrap data is passed as custom data when this isn't set.
+	// +optional
+	BootstrapDataStorage *infrav1.BootstrapDataStorage `json:"bootstrapDataStorage,omitempty"`
+
+	// BootstrapDataKeyVault specifies a key vault the bootstrap data of the virtual machine is stored in as secrets,
+	// which the virtual machine reads at boot with its managed identity, so that bootstrap tokens and certificates
+	// never appear in its custom data. The identity of the virtual machine must be allowed to read the secrets of the
+	// key vault, and only Linux virtual machines with cloud-init bootstrap data are supported. It may not be set
+	// together with BootstrapDataStorage.
+	// +optional
+	BootstrapDataKeyVault *infrav1.BootstrapDataKeyVault `json:"bootstrapDataKeyVault,omitempty"`
+
+	// ApplicationSecurityGroups is the list of application security groups the network interfaces of the virtual
+	// machine join, in addition to the ones of its subnets.
+	// +optional
+	ApplicationSecurityGroups infrav1.ApplicationSecurityGroupReferences `json:"applicationSecurityGroups,omitempty"`
+
+	// NetworkInterfaces specifies a list of network interface configurations.
+	// If left unspecified, the VM will get a single network interface with a
+	// single IPConfig in the subnet specified in the cluster's node subnet field.
+	// The primary interface will be the first networkInterface specified (index 0) in the list.
+	// +optional
+	NetworkInterfaces []infrav1.NetworkInterface `json:"networkInterfaces,omitempty"`
+
+	// CapacityReservationGroupID specifies the capacity reservation group resource id that should be
+	// used for allocating the virtual machine.
+	// The field size should be greater than 0 and the field input must start with '/'.
+	// The input for capacityReservationGroupID must be similar to '/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Compute/capacityReservationGroups/{capacityReservationGroupName}'.
+	// The keys which are used should be among 'subscriptions', 'providers' and 'resourcegroups' followed by valid ID or names respectively.
+	// It is optional but may not be changed once set.
+	// +optional
+	CapacityReservationGroupID *string `json:"capacityReservationGroupID,omitempty"`
+}
+
+// AzureMachineStatus defines the observed state of AzureMachine.
+type AzureMachineStatus struct {
+	// Ready is true when the provider resource is ready.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// Addresses contains the Azure instance associated addresses.
+	// +optional
+	Addresses []corev1.NodeAddress `json:"addresses,omitempty"`
+
+	// VMState is the provisioning state of the Azure virtual machine.
+	// +optional
+	VMState *infrav1.ProvisioningState `json:"vmState,omitempty"`
+
+	// ErrorReason will be set in the event that there is a terminal problem
+	// reconciling the Machine and will contain a succinct value suitable
+	// for machine interpretation.
+	//
+	// This field should not be set for transitive errors that a controller
+	// faces that are expected to be fixed automatically over
+	// time (like service outages), but instead indicate that something is
+	// fundamentally wrong with the Machine's spec or the configuration of
+	// the controller, and that manual intervention is required. Examples
+	// of terminal errors would be invalid combinations of settings in the
+	// spec, values that are unsupported by the controller, or the
+	// responsible controller itself being critically misconfigured.
+	//
+	// Any transient errors that occur during the reconciliation of Machines
+	// can be added as events to the Machine object and/or logged in the
+	// controller's output.
+	// +optional
+	FailureReason *errors.MachineStatusError `json:"failureReason,omitempty"`
+
+	// ErrorMessage will be set in the event that there is a terminal problem
+	// reconciling the Machine and will contain a more verbose string suitable
+	// for logging and human consumption.
+	//
+	// This field should not be set for transitive errors that a controller
+	// faces that are expected to be fixed automatically over
+	// time (like service outages), but instead indicate that something is
+	// fundamentally wrong with the Machine's spec or the configuration of
+	// the controller, and that manual intervention is required. Examples
+	// of terminal errors would be invalid combinations of settings in the
+	// spec, values that are unsupported by the controller, or the
+	// responsible controller itself being critically misconfigured.
+	//
+	// Any transient errors that occur during the reconciliation of Machines
+	// can be added as events to the Machine object and/or logged in the
+	// controller's output.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// Conditions defines current service state of the AzureMachine.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// LongRunningOperationStates saves the states for Azure long-running operations so they can be continued on the
+	// next reconciliation loop.
+	// +optional
+	LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Cluster",type="string",priority=1,JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this AzureMachine belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Severity",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].severity"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason"
+// +kubebuilder:printcolumn:name="Message",type="string",priority=1,JSONPath=".status.conditions[?(@.type=='Ready')].message"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.vmState",description="Azure VM provisioning state"
+// +kubebuilder:printcolumn:name="Machine",type="string",priority=1,JSONPath=".metadata.ownerReferences[?(@.kind==\"Machine\")].name",description="Machine object to which this AzureMachine belongs"
+// +kubebuilder:printcolumn:name="VM ID",type="string",priority=1,JSONPath=".spec.providerID",description="Azure VM ID"
+// +kubebuilder:printcolumn:name="VM Size",type="string",priority=1,JSONPath=".spec.vmSize",description="Azure VM Size"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of this AzureMachine"
+// +kubebuilder:resource:path=azuremachines,scope=Namespaced,categories=cluster-api
+// +kubebuilder:subresource:status
+
+// AzureMachine is the Schema for the azuremachines API.
+type AzureMachine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureMachineSpec   `json:"spec,omitempty"`
+	Status AzureMachineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureMachineList contains a list of AzureMachine.
+type AzureMachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureMachine `json:"items"`
+}
+
+// GetConditions returns the list of conditions for an AzureMachine API object.
+func (m *AzureMachine) GetConditions() clusterv1.Conditions {
+	return m.Status.Conditions
+}
+
+// SetConditions will set the given conditions on an AzureMachine object.
+func (m *AzureMachine) SetConditions(conditions clusterv1.Conditions) {
+	m.Status.Conditions = conditions
+}
+
+// GetFutures returns the list of long running operation states for an AzureMachine API object.
+func (m *AzureMachine) GetFutures() infrav1.Futures {
+	return m.Status.LongRunningOperationStates
+}
+
+// SetFutures will set the given long running operation states on an AzureMachine object.
+func (m *AzureMachine) SetFutures(futures infrav1.Futures) {
+	m.Status.LongRunningOperationStates = futures
+}
+
+func init() {
+	SchemeBuilder.Register(&AzureMachine{}, &AzureMachineList{})
+}
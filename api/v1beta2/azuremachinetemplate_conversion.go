@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this AzureMachineTemplate to the Hub version (v1beta1).
+func (src *AzureMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.AzureMachineTemplate)
+
+	restored := &infrav1.AzureMachineTemplate{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec.Template.ObjectMeta = src.Spec.Template.ObjectMeta
+	convertAzureMachineSpecToHub(&src.Spec.Template.Spec, &dst.Spec.Template.Spec)
+	dst.Status = infrav1.AzureMachineTemplateStatus(src.Status)
+	if ok {
+		restoreAzureMachineSpec(&restored.Spec.Template.Spec, &src.Spec.Template.Spec, &dst.Spec.Template.Spec)
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *AzureMachineTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.AzureMachineTemplate)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec.Template.ObjectMeta = src.Spec.Template.ObjectMeta
+	convertAzureMachineSpecFromHub(&src.Spec.Template.Spec, &dst.Spec.Template.Spec)
+	dst.Status = AzureMachineTemplateStatus(src.Status)
+
+	// Preserve Hub data on down-conversion.
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this AzureMachineTemplateList to the Hub version (v1beta1).
+func (src *AzureMachineTemplateList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.AzureMachineTemplateList)
+	dst.ListMeta = src.ListMeta
+	dst.Items = make([]infrav1.AzureMachineTemplate, len(src.Items))
+	for i := range src.Items {
+		if err := src.Items[i].ConvertTo(&dst.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *AzureMachineTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.AzureMachineTemplateList)
+	dst.ListMeta = src.ListMeta
+	dst.Items = make([]AzureMachineTemplate, len(src.Items))
+	for i := range src.Items {
+		if err := dst.Items[i].ConvertFrom(&src.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
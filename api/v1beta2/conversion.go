@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// convertAzureMachineSpecToHub converts a v1beta2 AzureMachineSpec to a v1beta1 AzureMachineSpec.
+func convertAzureMachineSpecToHub(in *AzureMachineSpec, out *infrav1.AzureMachineSpec) {
+	*out = infrav1.AzureMachineSpec{
+		ProviderID:                 in.ProviderID,
+		VMSize:                     in.VMSize,
+		ResizePolicy:               in.ResizePolicy,
+		FailureDomain:              in.FailureDomain,
+		Image:                      convertImageToHub(in.Image),
+		FIPS:                       in.FIPS,
+		Identity:                   in.Identity,
+		UserAssignedIdentities:     in.UserAssignedIdentities,
+		SystemAssignedIdentityRole: in.SystemAssignedIdentityRole,
+		OSDisk:                     in.OSDisk,
+		DataDisks:                  in.DataDisks,
+		SSHPublicKey:               in.SSHPublicKey,
+		AdditionalTags:             in.AdditionalTags,
+		AdditionalCapabilities:     in.AdditionalCapabilities,
+		AllocatePublicIP:           in.AllocatePublicIP,
+		EnablePublicIPDNSLabel:     in.EnablePublicIPDNSLabel,
+		PublicIPPrefixID:           in.PublicIPPrefixID,
+		EnableIPForwarding:         in.EnableIPForwarding,
+		Diagnostics:                in.Diagnostics,
+		SpotVMOptions:              in.SpotVMOptions,
+		SecurityProfile:            in.SecurityProfile,
+		DNSServers:                 in.DNSServers,
+		DisableExtensionOperations: in.DisableExtensionOperations,
+		VMExtensions:               in.VMExtensions,
+		InstallGPUDriver:           in.InstallGPUDriver,
+		JITAccess:                  in.JITAccess,
+		WindowsConfiguration:       in.WindowsConfiguration,
+		BootstrapDataStorage:       in.BootstrapDataStorage,
+		BootstrapDataKeyVault:      in.BootstrapDataKeyVault,
+		ApplicationSecurityGroups:  in.ApplicationSecurityGroups,
+		NetworkInterfaces:          in.NetworkInterfaces,
+		CapacityReservationGroupID: in.CapacityReservationGroupID,
+	}
+}
+
+// convertAzureMachineSpecFromHub converts a v1beta1 AzureMachineSpec to a v1beta2 AzureMachineSpec. The values of the
+// deprecated v1beta1 fields are moved to the fields replacing them, as the defaulting webhook of v1beta1 does, unless
+// those are already set.
+func convertAzureMachineSpecFromHub(in *infrav1.AzureMachineSpec, out *AzureMachineSpec) {
+	*out = AzureMachineSpec{
+		ProviderID:                 in.ProviderID,
+		VMSize:                     in.VMSize,
+		ResizePolicy:               in.ResizePolicy,
+		FailureDomain:              in.FailureDomain,
+		Image:                      convertImageFromHub(in.Image),
+		FIPS:                       in.FIPS,
+		Identity:                   in.Identity,
+		UserAssignedIdentities:     in.UserAssignedIdentities,
+		SystemAssignedIdentityRole: in.SystemAssignedIdentityRole,
+		OSDisk:                     in.OSDisk,
+		DataDisks:                  in.DataDisks,
+		SSHPublicKey:               in.SSHPublicKey,
+		AdditionalTags:             in.AdditionalTags,
+		AdditionalCapabilities:     in.AdditionalCapabilities,
+		AllocatePublicIP:           in.AllocatePublicIP,
+		EnablePublicIPDNSLabel:     in.EnablePublicIPDNSLabel,
+		PublicIPPrefixID:           in.PublicIPPrefixID,
+		EnableIPForwarding:         in.EnableIPForwarding,
+		Diagnostics:                in.Diagnostics,
+		SpotVMOptions:              in.SpotVMOptions,
+		SecurityProfile:            in.SecurityProfile,
+		DNSServers:                 in.DNSServers,
+		DisableExtensionOperations: in.DisableExtensionOperations,
+		VMExtensions:               in.VMExtensions,
+		InstallGPUDriver:           in.InstallGPUDriver,
+		JITAccess:                  in.JITAccess,
+		WindowsConfiguration:       in.WindowsConfiguration,
+		BootstrapDataStorage:       in.BootstrapDataStorage,
+		BootstrapDataKeyVault:      in.BootstrapDataKeyVault,
+		ApplicationSecurityGroups:  in.ApplicationSecurityGroups,
+		NetworkInterfaces:          in.NetworkInterfaces,
+		CapacityReservationGroupID: in.CapacityReservationGroupID,
+	}
+
+	if in.RoleAssignmentName != "" && (in.SystemAssignedIdentityRole == nil || in.SystemAssignedIdentityRole.Name == "") {
+		role := &infrav1.SystemAssignedIdentityRole{}
+		if in.SystemAssignedIdentityRole != nil {
+			role = in.SystemAssignedIdentityRole.DeepCopy()
+		}
+		role.Name = in.RoleAssignmentName
+		out.SystemAssignedIdentityRole = role
+	}
+	if (in.SubnetName != "" || in.AcceleratedNetworking != nil) && len(in.NetworkInterfaces) == 0 {
+		out.NetworkInterfaces = []infrav1.NetworkInterface{
+			{
+				SubnetName:            in.SubnetName,
+				AcceleratedNetworking: in.AcceleratedNetworking,
+			},
+		}
+	}
+}
+
+// restoreAzureMachineSpec restores a v1beta1 AzureMachineSpec converted from a v1beta2 AzureMachineSpec to the v1beta1
+// AzureMachineSpec the v1beta2 one was converted from, if it is unchanged since, so that the deprecated fields of the
+// v1beta1 AzureMachineSpec are kept.
+func restoreAzureMachineSpec(restored *infrav1.AzureMachineSpec, in *AzureMachineSpec, out *infrav1.AzureMachineSpec) {
+	var converted AzureMachineSpec
+	convertAzureMachineSpecFromHub(restored, &converted)
+	if apiequality.Semantic.DeepEqual(&converted, in) {
+		*out = *restored
+	}
+}
+
+// convertImageToHub converts a v1beta2 Image to a v1beta1 Image.
+func convertImageToHub(in *Image) *infrav1.Image {
+	if in == nil {
+		return nil
+	}
+	return &infrav1.Image{
+		ID:             in.ID,
+		Marketplace:    in.Marketplace,
+		ComputeGallery: in.ComputeGallery,
+	}
+}
+
+// convertImageFromHub converts a v1beta1 Image to a v1beta2 Image. A shared gallery image is converted to the
+// equivalent private compute gallery image.
+func convertImageFromHub(in *infrav1.Image) *Image {
+	if in == nil {
+		return nil
+	}
+	out := &Image{
+		ID:             in.ID,
+		Marketplace:    in.Marketplace,
+		ComputeGallery: in.ComputeGallery,
+	}
+	if in.SharedGallery != nil && in.ComputeGallery == nil {
+		out.ComputeGallery = &infrav1.AzureComputeGalleryImage{
+			Gallery:        in.SharedGallery.Gallery,
+			Name:           in.SharedGallery.Name,
+			Version:        in.SharedGallery.Version,
+			SubscriptionID: ptr.To(in.SharedGallery.SubscriptionID),
+			ResourceGroup:  ptr.To(in.SharedGallery.ResourceGroup),
+		}
+		if in.SharedGallery.Publisher != nil && in.SharedGallery.Offer != nil && in.SharedGallery.SKU != nil {
+			out.ComputeGallery.Plan = &infrav1.ImagePlan{
+				Publisher: *in.SharedGallery.Publisher,
+				Offer:     *in.SharedGallery.Offer,
+				SKU:       *in.SharedGallery.SKU,
+			}
+		}
+	}
+	return out
+}
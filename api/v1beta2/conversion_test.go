@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+)
+
+func TestFuzzyConversion(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	t.Run("for AzureMachine", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &infrav1.AzureMachine{},
+		Spoke:  &AzureMachine{},
+	}))
+
+	t.Run("for AzureMachineTemplate", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &infrav1.AzureMachineTemplate{},
+		Spoke:  &AzureMachineTemplate{},
+	}))
+
+	t.Run("for AzureClusterIdentity", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &infrav1.AzureClusterIdentity{},
+		Spoke:  &AzureClusterIdentity{},
+	}))
+}
+
+func TestAzureMachineConvertFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		hub      infrav1.AzureMachineSpec
+		expected AzureMachineSpec
+	}{
+		{
+			name: "role assignment name is moved to the system assigned identity role",
+			hub: infrav1.AzureMachineSpec{
+				Identity:           infrav1.VMIdentitySystemAssigned,
+				RoleAssignmentName: "role-assignment",
+			},
+			expected: AzureMachineSpec{
+				Identity:                   infrav1.VMIdentitySystemAssigned,
+				SystemAssignedIdentityRole: &infrav1.SystemAssignedIdentityRole{Name: "role-assignment"},
+			},
+		},
+		{
+			name: "role assignment name doesn't override the system assigned identity role name",
+			hub: infrav1.AzureMachineSpec{
+				Identity:                   infrav1.VMIdentitySystemAssigned,
+				RoleAssignmentName:         "role-assignment",
+				SystemAssignedIdentityRole: &infrav1.SystemAssignedIdentityRole{Name: "other-role-assignment"},
+			},
+			expected: AzureMachineSpec{
+				Identity:                   infrav1.VMIdentitySystemAssigned,
+				SystemAssignedIdentityRole: &infrav1.SystemAssignedIdentityRole{Name: "other-role-assignment"},
+			},
+		},
+		{
+			name: "subnet name and accelerated networking are moved to a network interface",
+			hub: infrav1.AzureMachineSpec{
+				SubnetName:            "node-subnet",
+				AcceleratedNetworking: ptr.To(true),
+			},
+			expected: AzureMachineSpec{
+				NetworkInterfaces: []infrav1.NetworkInterface{
+					{
+						SubnetName:            "node-subnet",
+						AcceleratedNetworking: ptr.To(true),
+					},
+				},
+			},
+		},
+		{
+			name: "subnet name doesn't override network interfaces",
+			hub: infrav1.AzureMachineSpec{
+				SubnetName:        "node-subnet",
+				NetworkInterfaces: []infrav1.NetworkInterface{{SubnetName: "other-subnet"}},
+			},
+			expected: AzureMachineSpec{
+				NetworkInterfaces: []infrav1.NetworkInterface{{SubnetName: "other-subnet"}},
+			},
+		},
+		{
+			name: "shared gallery image is converted to a compute gallery image",
+			hub: infrav1.AzureMachineSpec{
+				Image: &infrav1.Image{
+					SharedGallery: &infrav1.AzureSharedGalleryImage{
+						SubscriptionID: "subscription",
+						ResourceGroup:  "resource-group",
+						Gallery:        "gallery",
+						Name:           "image",
+						Version:        "1.0.0",
+						Publisher:      ptr.To("publisher"),
+						Offer:          ptr.To("offer"),
+						SKU:            ptr.To("sku"),
+					},
+				},
+			},
+			expected: AzureMachineSpec{
+				Image: &Image{
+					ComputeGallery: &infrav1.AzureComputeGalleryImage{
+						SubscriptionID: ptr.To("subscription"),
+						ResourceGroup:  ptr.To("resource-group"),
+						Gallery:        "gallery",
+						Name:           "image",
+						Version:        "1.0.0",
+						Plan: &infrav1.ImagePlan{
+							Publisher: "publisher",
+							Offer:     "offer",
+							SKU:       "sku",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hub := &infrav1.AzureMachine{Spec: tc.hub}
+
+			spoke := &AzureMachine{}
+			g.Expect(spoke.ConvertFrom(hub)).To(Succeed())
+			g.Expect(spoke.Spec).To(Equal(tc.expected))
+
+			// The hub is restored when the spoke is unchanged.
+			restored := &infrav1.AzureMachine{}
+			g.Expect(spoke.DeepCopy().ConvertTo(restored)).To(Succeed())
+			g.Expect(restored.Spec).To(Equal(tc.hub))
+
+			// Otherwise the spoke is converted without its deprecated fields.
+			spoke.Spec.VMSize = "Standard_D2s_v3"
+			changed := &infrav1.AzureMachine{}
+			g.Expect(spoke.ConvertTo(changed)).To(Succeed())
+			g.Expect(changed.Spec.VMSize).To(Equal("Standard_D2s_v3"))
+			g.Expect(changed.Spec.RoleAssignmentName).To(BeEmpty())
+			g.Expect(changed.Spec.SubnetName).To(BeEmpty())
+			g.Expect(changed.Spec.AcceleratedNetworking).To(BeNil())
+			if changed.Spec.Image != nil {
+				g.Expect(changed.Spec.Image.SharedGallery).To(BeNil())
+			}
+			g.Expect(changed.Annotations).NotTo(HaveKey(utilconversion.DataAnnotation))
+		})
+	}
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package armtemplate renders the resources CAPZ creates as an Azure Resource Manager template.
+package armtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	"github.com/Azure/azure-service-operator/v2/pkg/genruntime"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/aso"
+)
+
+const (
+	subscriptionSchema  = "https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#"
+	resourceGroupSchema = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+	contentVersion      = "1.0.0.0"
+
+	resourceGroupType     = "Microsoft.Resources/resourceGroups"
+	deploymentType        = "Microsoft.Resources/deployments"
+	deploymentsAPIVersion = "2022-09-01"
+
+	// The API versions of the Azure SDK for Go clients used by CAPZ.
+	computeAPIVersion    = "2024-03-01"
+	networkAPIVersion    = "2023-05-01"
+	privateDNSAPIVersion = "2020-06-01"
+
+	// maxDeploymentNameLength is the maximum length of the name of a deployment.
+	maxDeploymentNameLength = 64
+)
+
+// secretProperties are the paths of the resource properties which may hold secrets, such as the bootstrap data of
+// virtual machines. They are left out of templates.
+var secretProperties = [][]string{
+	{"properties", "osProfile", "customData"},
+	{"properties", "osProfile", "adminPassword"},
+	{"properties", "protectedSettings"},
+}
+
+// Template is an Azure Resource Manager template.
+type Template struct {
+	Schema         string     `json:"$schema"`
+	ContentVersion string     `json:"contentVersion"`
+	Resources      []Resource `json:"resources"`
+}
+
+// Resource is a resource of a Template, as defined by the Azure Resource Manager template syntax.
+type Resource map[string]interface{}
+
+// Builder builds a subscription deployment Template from the specs of the resources reconciled by CAPZ. The resources
+// of each resource group are deployed by a nested deployment, so the template can be reviewed or previewed with
+// `az deployment sub what-if`.
+type Builder struct {
+	scheme         *runtime.Scheme
+	resourceGroups []Resource
+	groups         []*group
+	// asoResources are the ARM names of the added ASO resources, keyed by their kind and Kubernetes name, so the
+	// resources owned by them can be named.
+	asoResources map[asoKey]asoResource
+}
+
+// group is the list of resources of a resource group.
+type group struct {
+	name      string
+	resources []Resource
+}
+
+type asoKey struct {
+	kind string
+	name string
+}
+
+type asoResource struct {
+	resourceGroup string
+	resourceType  string
+	name          string
+}
+
+// NewBuilder returns a new Builder. The scheme is used to apply the patches of ASO resource specs.
+func NewBuilder(scheme *runtime.Scheme) *Builder {
+	return &Builder{
+		scheme:       scheme,
+		asoResources: make(map[asoKey]asoResource),
+	}
+}
+
+// AddResource adds the resource of a spec reconciled with the Azure SDK for Go to the template.
+func (b *Builder) AddResource(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	rgName := spec.ResourceGroupName()
+	name := spec.ResourceName()
+	params, err := spec.Parameters(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get desired parameters for resource %s/%s", rgName, name)
+	}
+	if params == nil {
+		return nil
+	}
+	resourceType, apiVersion, ok := sdkResourceType(params)
+	if !ok {
+		return errors.Errorf("resource %s/%s of type %T is not supported", rgName, name, params)
+	}
+	if owner := spec.OwnerResourceName(); owner != "" {
+		name = owner + "/" + name
+	}
+
+	resource, err := toResource(params)
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert resource %s/%s", rgName, name)
+	}
+	b.add(rgName, resourceType, apiVersion, name, resource)
+	return nil
+}
+
+// AddASOResource adds the resource of a spec reconciled with Azure Service Operator to the template of a Builder.
+func AddASOResource[T genruntime.MetaObject](ctx context.Context, b *Builder, spec azure.ASOResourceSpecGetter[T]) error {
+	var zero T
+	params, err := aso.PatchedParameters(ctx, b.scheme, spec, zero)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get desired parameters for resource %s", spec.ResourceRef().GetName())
+	}
+	obj, ok := any(params).(genruntime.ARMMetaObject)
+	if !ok {
+		return errors.Errorf("resource %s of type %T is not an ARM resource", spec.ResourceRef().GetName(), params)
+	}
+	converter, ok := obj.GetSpec().(genruntime.ToARMConverter)
+	if !ok {
+		return errors.Errorf("resource %s of type %T can't be converted to ARM", obj.AzureName(), params)
+	}
+	references := make(map[genruntime.ResourceReference]string)
+	collectARMReferences(reflect.ValueOf(obj.GetSpec()), references)
+	armSpec, err := converter.ConvertToARM(genruntime.ConvertToARMResolvedDetails{
+		Name:               obj.AzureName(),
+		ResolvedReferences: genruntime.MakeResolved(references),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert resource %s to ARM", obj.AzureName())
+	}
+	resource, err := toResource(armSpec)
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert resource %s", obj.AzureName())
+	}
+
+	key := asoKey{kind: reflect.Indirect(reflect.ValueOf(params)).Type().Name(), name: spec.ResourceRef().GetName()}
+	if obj.GetType() == resourceGroupType {
+		resource["type"] = resourceGroupType
+		resource["apiVersion"] = obj.GetAPIVersion()
+		resource["name"] = obj.AzureName()
+		b.resourceGroups = append(b.resourceGroups, resource)
+		b.asoResources[key] = asoResource{resourceType: resourceGroupType, name: obj.AzureName()}
+		return nil
+	}
+
+	rgName, parent, err := b.asoOwner(obj.Owner())
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the owner of resource %s", obj.AzureName())
+	}
+	name := obj.AzureName()
+	if parent != "" {
+		name = parent + "/" + name
+	}
+	b.add(rgName, obj.GetType(), obj.GetAPIVersion(), name, resource)
+	b.asoResources[key] = asoResource{resourceGroup: rgName, resourceType: obj.GetType(), name: name}
+	return nil
+}
+
+// Template returns the template of the resources added to the Builder.
+func (b *Builder) Template() *Template {
+	template := &Template{
+		Schema:         subscriptionSchema,
+		ContentVersion: contentVersion,
+		Resources:      append([]Resource{}, b.resourceGroups...),
+	}
+	for _, g := range b.groups {
+		deployment := Resource{
+			"type":          deploymentType,
+			"apiVersion":    deploymentsAPIVersion,
+			"name":          deploymentName(g.name),
+			"resourceGroup": g.name,
+			"properties": map[string]interface{}{
+				"mode": "Incremental",
+				// Evaluate the expressions of the nested template, such as resourceId(), in its resource group.
+				"expressionEvaluationOptions": map[string]string{"scope": "inner"},
+				"template": &Template{
+					Schema:         resourceGroupSchema,
+					ContentVersion: contentVersion,
+					Resources:      g.resources,
+				},
+			},
+		}
+		if b.hasResourceGroup(g.name) {
+			deployment["dependsOn"] = []string{fmt.Sprintf("[subscriptionResourceId('%s', '%s')]", resourceGroupType, g.name)}
+		}
+		template.Resources = append(template.Resources, deployment)
+	}
+	return template
+}
+
+// add adds a resource to the nested deployment of its resource group. Child resources depend on their parent when it
+// is in the same resource group.
+func (b *Builder) add(rgName, resourceType, apiVersion, name string, resource Resource) {
+	resource["type"] = resourceType
+	resource["apiVersion"] = apiVersion
+	resource["name"] = name
+	delete(resource, "id")
+	delete(resource, "etag")
+	for _, path := range secretProperties {
+		removeProperty(resource, path)
+	}
+
+	g := b.group(rgName)
+	if i := strings.LastIndex(resourceType, "/"); strings.Count(resourceType, "/") > 1 && strings.Contains(name, "/") {
+		parentType, parentName := resourceType[:i], name[:strings.LastIndex(name, "/")]
+		for _, r := range g.resources {
+			if strings.EqualFold(r["type"].(string), parentType) && strings.EqualFold(r["name"].(string), parentName) {
+				resource["dependsOn"] = []string{resourceID(parentType, parentName)}
+				break
+			}
+		}
+	}
+	g.resources = append(g.resources, resource)
+}
+
+// group returns the group of a resource group, adding it if needed.
+func (b *Builder) group(rgName string) *group {
+	for _, g := range b.groups {
+		if strings.EqualFold(g.name, rgName) {
+			return g
+		}
+	}
+	g := &group{name: rgName, resources: []Resource{}}
+	b.groups = append(b.groups, g)
+	return g
+}
+
+// hasResourceGroup returns true if the resource group is created by the template.
+func (b *Builder) hasResourceGroup(rgName string) bool {
+	for _, rg := range b.resourceGroups {
+		if strings.EqualFold(rg["name"].(string), rgName) {
+			return true
+		}
+	}
+	return false
+}
+
+// asoOwner returns the resource group and the name of the parent resource, if any, of an ASO resource.
+func (b *Builder) asoOwner(owner *genruntime.ResourceReference) (rgName, parent string, err error) {
+	if owner == nil {
+		return "", "", errors.New("resource has no owner")
+	}
+	if owner.ARMID != "" {
+		id, err := arm.ParseResourceID(owner.ARMID)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed to parse owner ID %s", owner.ARMID)
+		}
+		if strings.EqualFold(id.ResourceType.String(), resourceGroupType) {
+			return id.Name, "", nil
+		}
+		names := []string{id.Name}
+		for parent := id.Parent; parent != nil && parent.ResourceType.Namespace == id.ResourceType.Namespace; parent = parent.Parent {
+			names = append([]string{parent.Name}, names...)
+		}
+		return id.ResourceGroupName, strings.Join(names, "/"), nil
+	}
+	r, ok := b.asoResources[asoKey{kind: owner.Kind, name: owner.Name}]
+	if !ok {
+		return "", "", errors.Errorf("owner %s %s was not added to the template", owner.Kind, owner.Name)
+	}
+	if r.resourceType == resourceGroupType {
+		return r.name, "", nil
+	}
+	return r.resourceGroup, r.name, nil
+}
+
+// sdkResourceType returns the resource type and the API version of the parameters of a resource reconciled with the
+// Azure SDK for Go.
+func sdkResourceType(params interface{}) (resourceType, apiVersion string, ok bool) {
+	switch p := params.(type) {
+	case armnetwork.ApplicationSecurityGroup:
+		return "Microsoft.Network/applicationSecurityGroups", networkAPIVersion, true
+	case armnetwork.BgpConnection:
+		return "Microsoft.Network/virtualHubs/bgpConnections", networkAPIVersion, true
+	case armnetwork.FlowLog:
+		return "Microsoft.Network/networkWatchers/flowLogs", networkAPIVersion, true
+	case armnetwork.HubIPConfiguration:
+		return "Microsoft.Network/virtualHubs/ipConfigurations", networkAPIVersion, true
+	case armnetwork.Interface:
+		return "Microsoft.Network/networkInterfaces", networkAPIVersion, true
+	case armnetwork.LoadBalancer:
+		return "Microsoft.Network/loadBalancers", networkAPIVersion, true
+	case armnetwork.PrivateLinkService:
+		return "Microsoft.Network/privateLinkServices", networkAPIVersion, true
+	case armnetwork.PublicIPAddress:
+		return "Microsoft.Network/publicIPAddresses", networkAPIVersion, true
+	case armnetwork.RouteTable:
+		return "Microsoft.Network/routeTables", networkAPIVersion, true
+	case armnetwork.SecurityGroup:
+		return "Microsoft.Network/networkSecurityGroups", networkAPIVersion, true
+	case armnetwork.VirtualHub:
+		return "Microsoft.Network/virtualHubs", networkAPIVersion, true
+	case armnetwork.VirtualNetworkGateway:
+		return "Microsoft.Network/virtualNetworkGateways", networkAPIVersion, true
+	case armnetwork.VirtualNetworkPeering:
+		return "Microsoft.Network/virtualNetworks/virtualNetworkPeerings", networkAPIVersion, true
+	case armprivatedns.PrivateZone:
+		return "Microsoft.Network/privateDnsZones", privateDNSAPIVersion, true
+	case armprivatedns.VirtualNetworkLink:
+		return "Microsoft.Network/privateDnsZones/virtualNetworkLinks", privateDNSAPIVersion, true
+	case armprivatedns.RecordSet:
+		if p.Properties != nil && len(p.Properties.AaaaRecords) > 0 {
+			return "Microsoft.Network/privateDnsZones/AAAA", privateDNSAPIVersion, true
+		}
+		return "Microsoft.Network/privateDnsZones/A", privateDNSAPIVersion, true
+	case armcompute.AvailabilitySet:
+		return "Microsoft.Compute/availabilitySets", computeAPIVersion, true
+	case armcompute.VirtualMachine:
+		return "Microsoft.Compute/virtualMachines", computeAPIVersion, true
+	case armcompute.VirtualMachineExtension:
+		return "Microsoft.Compute/virtualMachines/extensions", computeAPIVersion, true
+	default:
+		return "", "", false
+	}
+}
+
+// toResource converts the JSON representation of a resource to a Resource.
+func toResource(v interface{}) (Resource, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	resource := Resource{}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// collectARMReferences collects the references to ARM IDs of an ASO resource spec, which resolve to the IDs themselves.
+func collectARMReferences(v reflect.Value, references map[genruntime.ResourceReference]string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			collectARMReferences(v.Elem(), references)
+		}
+	case reflect.Struct:
+		if ref, ok := v.Interface().(genruntime.ResourceReference); ok {
+			if ref.ARMID != "" {
+				references[ref] = ref.ARMID
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				collectARMReferences(v.Field(i), references)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectARMReferences(v.Index(i), references)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			collectARMReferences(v.MapIndex(key), references)
+		}
+	}
+}
+
+// removeProperty removes the property at a path from a resource.
+func removeProperty(resource Resource, path []string) {
+	m := map[string]interface{}(resource)
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+	delete(m, path[len(path)-1])
+}
+
+// resourceID returns the template expression of the ID of a resource in the resource group of the deployment.
+func resourceID(resourceType, name string) string {
+	args := []string{fmt.Sprintf("'%s'", resourceType)}
+	for _, segment := range strings.Split(name, "/") {
+		args = append(args, fmt.Sprintf("'%s'", segment))
+	}
+	return fmt.Sprintf("[resourceId(%s)]", strings.Join(args, ", "))
+}
+
+// deploymentName returns the name of the nested deployment of a resource group.
+func deploymentName(rgName string) string {
+	if len(rgName) > maxDeploymentNameLength {
+		return rgName[:maxDeploymentNameLength]
+	}
+	return rgName
+}
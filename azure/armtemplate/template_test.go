@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package armtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
+)
+
+func TestBuilder(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := NewBuilder(runtime.NewScheme())
+
+	g.Expect(AddASOResource(ctx, b, &groups.GroupSpec{
+		Name:        "my-rg",
+		AzureName:   "my-rg",
+		Location:    "westus",
+		ClusterName: "my-cluster",
+	})).To(Succeed())
+	g.Expect(AddASOResource(ctx, b, &virtualnetworks.VNetSpec{
+		ResourceGroup: "my-rg",
+		Name:          "my-vnet",
+		CIDRs:         []string{"10.0.0.0/16"},
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	})).To(Succeed())
+	g.Expect(b.AddResource(ctx, &securitygroups.NSGSpec{
+		Name:          "my-nsg",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	})).To(Succeed())
+	g.Expect(AddASOResource(ctx, b, &subnets.SubnetSpec{
+		Name:              "my-subnet",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "123",
+		CIDRs:             []string{"10.0.0.0/24"},
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+		IsVNetManaged:     true,
+		SecurityGroupName: "my-nsg",
+	})).To(Succeed())
+	g.Expect(b.AddResource(ctx, &vmextensions.VMExtensionSpec{
+		ExtensionSpec: azure.ExtensionSpec{
+			Name:              "my-extension",
+			VMName:            "my-vm",
+			Publisher:         "Microsoft.Azure.Extensions",
+			Version:           "1.0",
+			ProtectedSettings: map[string]string{"token": "secret"},
+		},
+		ResourceGroup: "other-rg",
+		Location:      "westus",
+	})).To(Succeed())
+
+	data, err := json.Marshal(b.Template())
+	g.Expect(err).NotTo(HaveOccurred())
+	var template map[string]interface{}
+	g.Expect(json.Unmarshal(data, &template)).To(Succeed())
+	g.Expect(template["$schema"]).To(Equal(subscriptionSchema))
+
+	resources := template["resources"].([]interface{})
+	g.Expect(resources).To(HaveLen(3))
+	g.Expect(resources[0]).To(MatchKeys(IgnoreExtras, Keys{
+		"type":     Equal("Microsoft.Resources/resourceGroups"),
+		"name":     Equal("my-rg"),
+		"location": Equal("westus"),
+	}))
+
+	rgDeployment := resources[1].(map[string]interface{})
+	g.Expect(rgDeployment).To(MatchKeys(IgnoreExtras, Keys{
+		"type":          Equal("Microsoft.Resources/deployments"),
+		"resourceGroup": Equal("my-rg"),
+		"dependsOn":     ConsistOf("[subscriptionResourceId('Microsoft.Resources/resourceGroups', 'my-rg')]"),
+	}))
+	rgResources := rgDeployment["properties"].(map[string]interface{})["template"].(map[string]interface{})["resources"].([]interface{})
+	g.Expect(rgResources).To(HaveLen(3))
+	g.Expect(rgResources[0]).To(MatchKeys(IgnoreExtras, Keys{
+		"type":       Equal("Microsoft.Network/virtualNetworks"),
+		"name":       Equal("my-vnet"),
+		"properties": HaveKeyWithValue("addressSpace", HaveKeyWithValue("addressPrefixes", ConsistOf("10.0.0.0/16"))),
+	}))
+	g.Expect(rgResources[1]).To(MatchKeys(IgnoreExtras, Keys{
+		"type":       Equal("Microsoft.Network/networkSecurityGroups"),
+		"apiVersion": Equal(networkAPIVersion),
+		"name":       Equal("my-nsg"),
+	}))
+	g.Expect(rgResources[2]).To(MatchKeys(IgnoreExtras, Keys{
+		"type":      Equal("Microsoft.Network/virtualNetworks/subnets"),
+		"name":      Equal("my-vnet/my-subnet"),
+		"dependsOn": ConsistOf("[resourceId('Microsoft.Network/virtualNetworks', 'my-vnet')]"),
+		"properties": HaveKeyWithValue("networkSecurityGroup",
+			HaveKeyWithValue("id", "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-nsg")),
+	}))
+
+	otherDeployment := resources[2].(map[string]interface{})
+	g.Expect(otherDeployment).To(HaveKeyWithValue("resourceGroup", "other-rg"))
+	g.Expect(otherDeployment).NotTo(HaveKey("dependsOn"))
+	otherResources := otherDeployment["properties"].(map[string]interface{})["template"].(map[string]interface{})["resources"].([]interface{})
+	g.Expect(otherResources).To(ConsistOf(MatchKeys(IgnoreExtras, Keys{
+		"type":       Equal("Microsoft.Compute/virtualMachines/extensions"),
+		"name":       Equal("my-vm/my-extension"),
+		"properties": Not(HaveKey("protectedSettings")),
+	})))
+}
+
+func TestBuilderUnsupportedResource(t *testing.T) {
+	g := NewWithT(t)
+	b := NewBuilder(runtime.NewScheme())
+	err := b.AddResource(context.Background(), &unsupportedSpec{})
+	g.Expect(err).To(MatchError(ContainSubstring("of type v1beta1.Tags is not supported")))
+}
+
+type unsupportedSpec struct{}
+
+func (s *unsupportedSpec) ResourceName() string      { return "name" }
+func (s *unsupportedSpec) OwnerResourceName() string { return "" }
+func (s *unsupportedSpec) ResourceGroupName() string { return "rg" }
+func (s *unsupportedSpec) Parameters(_ context.Context, _ interface{}) (interface{}, error) {
+	return infrav1.Tags{}, nil
+}
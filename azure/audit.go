@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// AuditOperationCreateOrUpdate is the operation of a PUT request.
+	AuditOperationCreateOrUpdate = "CreateOrUpdate"
+	// AuditOperationUpdate is the operation of a PATCH request.
+	AuditOperationUpdate = "Update"
+	// AuditOperationDelete is the operation of a DELETE request.
+	AuditOperationDelete = "Delete"
+
+	// AuditResultSucceeded means Azure Resource Manager completed the operation.
+	AuditResultSucceeded = "Succeeded"
+	// AuditResultAccepted means Azure Resource Manager accepted a long-running operation, whose outcome is reported by
+	// the status of the resource.
+	AuditResultAccepted = "Accepted"
+	// AuditResultFailed means Azure Resource Manager rejected the operation, or the request could not be sent.
+	AuditResultFailed = "Failed"
+)
+
+// AuditRecord is a structured record of a mutating request sent to Azure Resource Manager.
+type AuditRecord struct {
+	// Time is when the response was received.
+	Time time.Time `json:"time"`
+	// Operation is CreateOrUpdate, Update or Delete.
+	Operation string `json:"operation"`
+	// ResourceID is the Azure Resource Manager ID of the resource.
+	ResourceID string `json:"resourceID"`
+	// Requester is the object whose reconciliation sent the request, if known.
+	Requester AuditRequester `json:"requester,omitempty"`
+	// CorrelationID is the x-ms-correlation-request-id of the request.
+	CorrelationID string `json:"correlationID,omitempty"`
+	// RequestID is the x-ms-request-id Azure Resource Manager assigned to the request.
+	RequestID string `json:"requestID,omitempty"`
+	// StatusCode is the HTTP status code of the response, or zero if no response was received.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Result is Succeeded, Accepted or Failed.
+	Result string `json:"result"`
+	// Error is the error that prevented the request from being sent.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditRequester identifies the object whose reconciliation sends requests to Azure Resource Manager.
+type AuditRequester struct {
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Cluster is the name of the Cluster the object belongs to.
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// AuditSink receives the audit records of mutating Azure Resource Manager requests.
+type AuditSink interface {
+	Audit(record AuditRecord)
+}
+
+// AuditSinks receive the audit records of mutating requests in addition to the log of the controller. They must be
+// set before the controllers start.
+var AuditSinks []AuditSink
+
+type auditRequesterKey struct{}
+
+// WithAuditRequester returns a context whose Azure Resource Manager requests are audited as sent on behalf of requester.
+func WithAuditRequester(ctx context.Context, requester AuditRequester) context.Context {
+	return context.WithValue(ctx, auditRequesterKey{}, requester)
+}
+
+// auditRequesterFromCtx returns the requester recorded in a context, if any.
+func auditRequesterFromCtx(ctx context.Context) AuditRequester {
+	requester, _ := ctx.Value(auditRequesterKey{}).(AuditRequester)
+	return requester
+}
+
+// auditOperations are the operations of the HTTP methods that create, update or delete resources.
+var auditOperations = map[string]string{
+	http.MethodPut:    AuditOperationCreateOrUpdate,
+	http.MethodPatch:  AuditOperationUpdate,
+	http.MethodDelete: AuditOperationDelete,
+}
+
+// auditPolicy logs an audit record for each request sent to Azure Resource Manager that creates, updates or deletes
+// a resource, and hands it to the AuditSinks. Data plane requests, e.g. to upload blobs, are not audited.
+// It implements the policy.Policy interface.
+type auditPolicy struct{}
+
+// Do sends the request and records its outcome if it is mutating.
+func (p auditPolicy) Do(req *policy.Request) (*http.Response, error) {
+	operation, ok := auditOperations[req.Raw().Method]
+	if !ok || !subscriptionPathRegex.MatchString(req.Raw().URL.Path) {
+		return req.Next()
+	}
+
+	resp, err := req.Next()
+
+	ctx := req.Raw().Context()
+	record := newAuditRecord(ctx, operation, req.Raw(), resp, err)
+	log.FromContext(ctx).WithName("audit").Info("Azure Resource Manager operation",
+		"operation", record.Operation,
+		"resourceID", record.ResourceID,
+		"requester", record.Requester,
+		"correlationID", record.CorrelationID,
+		"requestID", record.RequestID,
+		"statusCode", record.StatusCode,
+		"result", record.Result,
+		"error", record.Error,
+	)
+	for _, sink := range AuditSinks {
+		sink.Audit(record)
+	}
+
+	return resp, err
+}
+
+// newAuditRecord builds the audit record of a request from its response or error.
+func newAuditRecord(ctx context.Context, operation string, req *http.Request, resp *http.Response, err error) AuditRecord {
+	record := AuditRecord{
+		Time:       time.Now().UTC(),
+		Operation:  operation,
+		ResourceID: strings.TrimSuffix(req.URL.Path, "/"),
+		Requester:  auditRequesterFromCtx(ctx),
+	}
+	if corrID, ok := tele.CorrIDFromCtx(ctx); ok {
+		record.CorrelationID = string(corrID)
+	}
+
+	switch {
+	case err != nil:
+		record.Result = AuditResultFailed
+		record.Error = err.Error()
+	case resp.StatusCode == http.StatusAccepted:
+		record.Result = AuditResultAccepted
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		record.Result = AuditResultSucceeded
+	default:
+		record.Result = AuditResultFailed
+	}
+	if resp != nil {
+		record.StatusCode = resp.StatusCode
+		record.RequestID = resp.Header.Get("x-ms-request-id")
+		if record.CorrelationID == "" {
+			record.CorrelationID = resp.Header.Get(string(tele.CorrIDKeyVal))
+		}
+	}
+	return record
+}
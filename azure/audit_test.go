@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+type fakeAuditSink struct {
+	records []AuditRecord
+}
+
+func (f *fakeAuditSink) Audit(record AuditRecord) {
+	f.records = append(f.records, record)
+}
+
+func TestAuditPolicy(t *testing.T) {
+	const vmPath = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"
+	requester := AuditRequester{Kind: "AzureMachine", Namespace: "default", Name: "my-vm", Cluster: "my-cluster"}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		statusCode int
+		expected   *AuditRecord
+	}{
+		{
+			name:       "PUT that succeeds",
+			method:     http.MethodPut,
+			path:       vmPath,
+			statusCode: http.StatusOK,
+			expected: &AuditRecord{
+				Operation:     AuditOperationCreateOrUpdate,
+				ResourceID:    vmPath,
+				Requester:     requester,
+				CorrelationID: "corr-id",
+				RequestID:     "request-id",
+				StatusCode:    http.StatusOK,
+				Result:        AuditResultSucceeded,
+			},
+		},
+		{
+			name:       "PATCH of a long-running operation",
+			method:     http.MethodPatch,
+			path:       vmPath,
+			statusCode: http.StatusAccepted,
+			expected: &AuditRecord{
+				Operation:     AuditOperationUpdate,
+				ResourceID:    vmPath,
+				Requester:     requester,
+				CorrelationID: "corr-id",
+				RequestID:     "request-id",
+				StatusCode:    http.StatusAccepted,
+				Result:        AuditResultAccepted,
+			},
+		},
+		{
+			name:       "DELETE that fails",
+			method:     http.MethodDelete,
+			path:       vmPath,
+			statusCode: http.StatusForbidden,
+			expected: &AuditRecord{
+				Operation:     AuditOperationDelete,
+				ResourceID:    vmPath,
+				Requester:     requester,
+				CorrelationID: "corr-id",
+				RequestID:     "request-id",
+				StatusCode:    http.StatusForbidden,
+				Result:        AuditResultFailed,
+			},
+		},
+		{
+			name:       "GET is not audited",
+			method:     http.MethodGet,
+			path:       vmPath,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "data plane request is not audited",
+			method:     http.MethodPut,
+			path:       "/container/blob",
+			statusCode: http.StatusCreated,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("x-ms-request-id", "request-id")
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			sink := &fakeAuditSink{}
+			AuditSinks = []AuditSink{sink}
+			defer func() { AuditSinks = nil }()
+
+			ctx := WithAuditRequester(context.WithValue(context.Background(), tele.CorrIDKeyVal, tele.CorrID("corr-id")), requester)
+			req, err := runtime.NewRequest(ctx, tc.method, server.URL+tc.path)
+			g.Expect(err).NotTo(HaveOccurred())
+			resp, err := defaultTestPipeline([]policy.Policy{auditPolicy{}}).Do(req)
+			g.Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			if tc.expected == nil {
+				g.Expect(sink.records).To(BeEmpty())
+				return
+			}
+			g.Expect(sink.records).To(HaveLen(1))
+			record := sink.records[0]
+			g.Expect(record.Time).NotTo(BeZero())
+			record.Time = tc.expected.Time
+			g.Expect(record).To(Equal(*tc.expected))
+		})
+	}
+}
+
+func TestAuditPolicyCorrelationIDFromResponse(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(string(tele.CorrIDKeyVal), "response-corr-id")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := &fakeAuditSink{}
+	AuditSinks = []AuditSink{sink}
+	defer func() { AuditSinks = nil }()
+
+	req, err := runtime.NewRequest(context.Background(), http.MethodDelete, server.URL+"/subscriptions/123/resourceGroups/my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+	resp, err := defaultTestPipeline([]policy.Policy{auditPolicy{}}).Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+
+	g.Expect(sink.records).To(HaveLen(1))
+	g.Expect(sink.records[0].CorrelationID).To(Equal("response-corr-id"))
+	g.Expect(sink.records[0].Requester).To(BeZero())
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// AzureStackHubAPIProfile is the Azure Stack Hub API profile whose API versions CAPZ uses in Azure Stack Hub clouds.
+const AzureStackHubAPIProfile = "2020-09-01-hybrid"
+
+// azureStackHubAPIVersions are the API versions of the resource providers and resource types of the Azure Stack Hub
+// API profile, keyed by lower case provider or provider/type. Resource types mapped to an empty version are not
+// available in the profile.
+var azureStackHubAPIVersions = map[string]string{
+	"subscriptions":                         "2016-06-01",
+	"microsoft.resources":                   "2019-10-01",
+	"microsoft.compute":                     "2020-06-01",
+	"microsoft.compute/disks":               "2019-07-01",
+	"microsoft.compute/snapshots":           "2019-07-01",
+	"microsoft.compute/skus":                "2019-04-01",
+	"microsoft.network":                     "2018-11-01",
+	"microsoft.network/natgateways":         "",
+	"microsoft.network/bastionhosts":        "",
+	"microsoft.network/privateendpoints":    "",
+	"microsoft.network/privatelinkservices": "",
+	"microsoft.storage":                     "2019-06-01",
+	"microsoft.keyvault":                    "2019-09-01",
+	"microsoft.authorization":               "2016-09-01",
+	"microsoft.dns":                         "2016-04-01",
+}
+
+// azureStackHubAPIVersion returns the API version of the Azure Stack Hub API profile for a request to an Azure Resource
+// Manager path, and false if the profile doesn't include the resource it refers to.
+func azureStackHubAPIVersion(path string) (string, bool) {
+	segments := strings.Split(strings.Trim(strings.ToLower(path), "/"), "/")
+
+	// The resource is the one of the last provider in the path, as extension resources such as role assignments are
+	// nested under the resource they apply to.
+	var provider, resourceType string
+	for i := len(segments) - 2; i >= 0; i-- {
+		if segments[i] == "providers" {
+			provider = segments[i+1]
+			if i+2 < len(segments) {
+				resourceType = segments[i+2]
+			}
+			break
+		}
+	}
+	if provider == "" {
+		// Subscriptions and their locations are served by the subscriptions API, while resource groups and the
+		// resources in them are served by the resources API.
+		if len(segments) <= 2 || (len(segments) == 3 && segments[2] == "locations") {
+			provider = "subscriptions"
+		} else {
+			provider = "microsoft.resources"
+		}
+	}
+
+	if version, ok := azureStackHubAPIVersions[provider+"/"+resourceType]; ok {
+		return version, version != ""
+	}
+	version, ok := azureStackHubAPIVersions[provider]
+	return version, ok
+}
+
+// azureStackHubPolicy sets the API version of requests to the Azure Resource Manager of an Azure Stack Hub to the one of
+// the Azure Stack Hub API profile, as Azure Stack Hub doesn't support the newer API versions of the Azure SDK clients.
+// Requests for resources the profile doesn't include fail without being sent.
+// It implements the policy.Policy interface.
+type azureStackHubPolicy struct {
+	// host is the host of the Azure Resource Manager endpoint. Requests to other hosts are left as is.
+	host string
+}
+
+// Do sets the API version of a request to Azure Resource Manager.
+func (p azureStackHubPolicy) Do(req *policy.Request) (*http.Response, error) {
+	u := req.Raw().URL
+	if !strings.EqualFold(u.Host, p.host) {
+		return req.Next()
+	}
+	apiVersion, ok := azureStackHubAPIVersion(u.Path)
+	if !ok {
+		return nil, fmt.Errorf("%s is not supported by the Azure Stack Hub API profile %s", u.Path, AzureStackHubAPIProfile)
+	}
+	query := u.Query()
+	query.Set("api-version", apiVersion)
+	u.RawQuery = query.Encode()
+	return req.Next()
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	. "github.com/onsi/gomega"
+)
+
+func TestAzureStackHubAPIVersion(t *testing.T) {
+	tests := []struct {
+		name              string
+		path              string
+		expectedVersion   string
+		expectedSupported bool
+	}{
+		{
+			name:              "subscription",
+			path:              "/subscriptions/123",
+			expectedVersion:   "2016-06-01",
+			expectedSupported: true,
+		},
+		{
+			name:              "locations",
+			path:              "/subscriptions/123/locations",
+			expectedVersion:   "2016-06-01",
+			expectedSupported: true,
+		},
+		{
+			name:              "resource group",
+			path:              "/subscriptions/123/resourceGroups/my-rg",
+			expectedVersion:   "2019-10-01",
+			expectedSupported: true,
+		},
+		{
+			name:              "virtual machine",
+			path:              "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+			expectedVersion:   "2020-06-01",
+			expectedSupported: true,
+		},
+		{
+			name:              "disk",
+			path:              "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/disks/my-disk",
+			expectedVersion:   "2019-07-01",
+			expectedSupported: true,
+		},
+		{
+			name:              "virtual network",
+			path:              "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+			expectedVersion:   "2018-11-01",
+			expectedSupported: true,
+		},
+		{
+			name:              "role assignment on a virtual machine",
+			path:              "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm/providers/Microsoft.Authorization/roleAssignments/my-ra",
+			expectedVersion:   "2016-09-01",
+			expectedSupported: true,
+		},
+		{
+			name:              "NAT gateway",
+			path:              "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/natGateways/my-natgw",
+			expectedSupported: false,
+		},
+		{
+			name:              "managed cluster",
+			path:              "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.ContainerService/managedClusters/my-aks",
+			expectedSupported: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			version, supported := azureStackHubAPIVersion(tc.path)
+			g.Expect(supported).To(Equal(tc.expectedSupported))
+			if tc.expectedSupported {
+				g.Expect(version).To(Equal(tc.expectedVersion))
+			}
+		})
+	}
+}
+
+func TestAzureStackHubPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	var apiVersion string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		apiVersion = r.URL.Query().Get("api-version")
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	pipeline := defaultTestPipeline([]policy.Policy{azureStackHubPolicy{host: serverURL.Host}})
+
+	// Requests to Azure Resource Manager use the API version of the profile.
+	req, err := runtime.NewRequest(context.Background(), http.MethodGet,
+		server.URL+"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm?api-version=2024-03-01")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = pipeline.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(apiVersion).To(Equal("2020-06-01"))
+
+	// Requests for resources the profile doesn't include are not sent.
+	apiVersion = ""
+	req, err = runtime.NewRequest(context.Background(), http.MethodPut,
+		server.URL+"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/natGateways/my-natgw?api-version=2023-05-01")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = pipeline.Do(req)
+	g.Expect(err).To(MatchError(ContainSubstring("not supported by the Azure Stack Hub API profile")))
+	g.Expect(apiVersion).To(BeEmpty())
+
+	// Requests to other hosts, such as Key Vault data plane requests, are left as is.
+	pipeline = defaultTestPipeline([]policy.Policy{azureStackHubPolicy{host: "management.local.azurestack.external"}})
+	req, err = runtime.NewRequest(context.Background(), http.MethodGet, server.URL+"/secrets/my-secret?api-version=7.4")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = pipeline.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(apiVersion).To(Equal("7.4"))
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package converters
 
 import (
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -38,6 +39,13 @@ func SecurityRuleToSDK(rule infrav1.SecurityRule) *armnetwork.SecurityRule {
 		},
 	}
 
+	if len(rule.SourceApplicationSecurityGroups) > 0 {
+		secRule.Properties.SourceApplicationSecurityGroups = ApplicationSecurityGroupsToSDK(rule.SourceApplicationSecurityGroups)
+	}
+	if len(rule.DestinationApplicationSecurityGroups) > 0 {
+		secRule.Properties.DestinationApplicationSecurityGroups = ApplicationSecurityGroupsToSDK(rule.DestinationApplicationSecurityGroups)
+	}
+
 	switch rule.Protocol {
 	case infrav1.SecurityGroupProtocolAll:
 		secRule.Properties.Protocol = ptr.To(armnetwork.SecurityRuleProtocolAsterisk)
@@ -58,3 +66,23 @@ func SecurityRuleToSDK(rule infrav1.SecurityRule) *armnetwork.SecurityRule {
 
 	return secRule
 }
+
+// ApplicationSecurityGroupsToSDK converts a list of application security group resource IDs to Azure application
+// security group references.
+func ApplicationSecurityGroupsToSDK(ids []string) []*armnetwork.ApplicationSecurityGroup {
+	asgs := make([]*armnetwork.ApplicationSecurityGroup, 0, len(ids))
+	for _, id := range ids {
+		asgs = append(asgs, &armnetwork.ApplicationSecurityGroup{ID: ptr.To(id)})
+	}
+	return asgs
+}
+
+// ApplicationSecurityGroupsToComputeSDK converts a list of application security group resource IDs to references
+// usable in virtual machine scale set IP configurations.
+func ApplicationSecurityGroupsToComputeSDK(ids []string) []*armcompute.SubResource {
+	asgs := make([]*armcompute.SubResource, 0, len(ids))
+	for _, id := range ids {
+		asgs = append(asgs, &armcompute.SubResource{ID: ptr.To(id)})
+	}
+	return asgs
+}
@@ -33,8 +33,8 @@ const (
 	RegExpStrComputeGalleryID = `/subscriptions/(?P<subID>.*)/resourceGroups/(?P<rg>.*)/providers/Microsoft.Compute/galleries/(?P<gallery>.*)/images/(?P<name>.*)/versions/(?P<version>.*)`
 )
 
-// SDKToVMSS converts an Azure SDK VirtualMachineScaleSet to the AzureMachinePool type.
-func SDKToVMSS(sdkvmss armcompute.VirtualMachineScaleSet, sdkinstances []armcompute.VirtualMachineScaleSetVM) azure.VMSS {
+// SDKToVMSS converts an Azure SDK VirtualMachineScaleSet in the given cloud to the AzureMachinePool type.
+func SDKToVMSS(sdkvmss armcompute.VirtualMachineScaleSet, sdkinstances []armcompute.VirtualMachineScaleSetVM, cloud string) azure.VMSS {
 	vmss := azure.VMSS{
 		ID:    ptr.Deref(sdkvmss.ID, ""),
 		Name:  ptr.Deref(sdkvmss.Name, ""),
@@ -58,7 +58,7 @@ func SDKToVMSS(sdkvmss armcompute.VirtualMachineScaleSet, sdkinstances []armcomp
 		vmss.Instances = make([]azure.VMSSVM, len(sdkinstances))
 		orchestrationMode := ptr.Deref(sdkvmss.Properties.OrchestrationMode, "")
 		for i, vm := range sdkinstances {
-			vmss.Instances[i] = *SDKToVMSSVM(vm)
+			vmss.Instances[i] = *SDKToVMSSVM(vm, cloud)
 			vmss.Instances[i].OrchestrationMode = infrav1.OrchestrationModeType(orchestrationMode)
 		}
 	}
@@ -107,8 +107,8 @@ func SDKVMToVMSSVM(sdkInstance armcompute.VirtualMachine, mode infrav1.Orchestra
 	return &instance
 }
 
-// SDKToVMSSVM converts an Azure SDK VirtualMachineScaleSetVM into an infrav1exp.VMSSVM.
-func SDKToVMSSVM(sdkInstance armcompute.VirtualMachineScaleSetVM) *azure.VMSSVM {
+// SDKToVMSSVM converts an Azure SDK VirtualMachineScaleSetVM in the given cloud into an infrav1exp.VMSSVM.
+func SDKToVMSSVM(sdkInstance armcompute.VirtualMachineScaleSetVM, cloud string) *azure.VMSSVM {
 	// Convert resourceGroup Name ID ( ProviderID in capz objects )
 	var convertedID string
 	convertedID, err := azprovider.ConvertResourceGroupNameToLower(ptr.Deref(sdkInstance.ID, ""))
@@ -136,8 +136,7 @@ func SDKToVMSSVM(sdkInstance armcompute.VirtualMachineScaleSetVM) *azure.VMSSVM
 
 	if sdkInstance.Resources != nil {
 		for _, r := range sdkInstance.Resources {
-			if r.Properties.ProvisioningState != nil && r.Name != nil &&
-				(*r.Name == azure.BootstrappingExtensionLinux || *r.Name == azure.BootstrappingExtensionWindows) {
+			if r.Properties.ProvisioningState != nil && r.Name != nil && azure.IsBootstrappingVMExtension(*r.Name, cloud) {
 				instance.BootstrappingState = infrav1.ProvisioningState(ptr.Deref(r.Properties.ProvisioningState, ""))
 				break
 			}
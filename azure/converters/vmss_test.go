@@ -118,7 +118,7 @@ func Test_SDKToVMSS(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewGomegaWithT(t)
 			vmss, instances := c.SubjectFactory(g)
-			subject := converters.SDKToVMSS(vmss, instances)
+			subject := converters.SDKToVMSS(vmss, instances, azure.PublicCloudName)
 			c.Expect(g, subject)
 		})
 	}
@@ -209,7 +209,7 @@ func Test_SDKToVMSSVM(t *testing.T) {
 		t.Run(c.Name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewGomegaWithT(t)
-			g.Expect(converters.SDKToVMSSVM(c.SDKInstance)).To(gomega.Equal(c.VMSSVM))
+			g.Expect(converters.SDKToVMSSVM(c.SDKInstance, azure.PublicCloudName)).To(gomega.Equal(c.VMSSVM))
 		})
 	}
 }
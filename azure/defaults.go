@@ -18,14 +18,24 @@ package azure
 
 import (
 	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
@@ -41,6 +51,9 @@ const (
 	ChinaCloudName = "AzureChinaCloud"
 	// USGovernmentCloudName is the name of the Azure US Government cloud.
 	USGovernmentCloudName = "AzureUSGovernmentCloud"
+	// StackCloudName is the name of an Azure Stack Hub cloud, whose endpoints are read from the file
+	// referenced by the AZURE_ENVIRONMENT_FILEPATH environment variable.
+	StackCloudName = "AzureStackCloud"
 )
 
 const (
@@ -66,6 +79,39 @@ const (
 	BootstrappingExtensionLinux = "CAPZ.Linux.Bootstrapping"
 	// BootstrappingExtensionWindows is the name of the Windows CAPZ bootstrapping VM extension.
 	BootstrappingExtensionWindows = "CAPZ.Windows.Bootstrapping"
+	// CustomScriptBootstrappingExtensionLinux is the name of the Linux custom script VM extension used for bootstrapping
+	// in clouds where the CAPZ bootstrapping VM extension is not available.
+	CustomScriptBootstrappingExtensionLinux = "CustomScript"
+	// CustomScriptBootstrappingExtensionWindows is the name of the Windows custom script VM extension used for
+	// bootstrapping in clouds where the CAPZ bootstrapping VM extension is not available.
+	CustomScriptBootstrappingExtensionWindows = "CustomScriptExtension"
+)
+
+const (
+	// DomainJoinExtensionName is the name of the VM extension joining Windows VMs to an Active Directory domain.
+	DomainJoinExtensionName = "JsonADDomainExtension"
+	// DomainJoinExtensionPublisher is the publisher of the domain join VM extension.
+	DomainJoinExtensionPublisher = "Microsoft.Compute"
+	// DomainJoinExtensionVersion is the version of the domain join VM extension.
+	DomainJoinExtensionVersion = "1.3"
+)
+
+const (
+	// GPUVendorNVIDIA identifies VM sizes with NVIDIA GPUs.
+	GPUVendorNVIDIA = "NVIDIA"
+	// GPUVendorAMD identifies VM sizes with AMD GPUs.
+	GPUVendorAMD = "AMD"
+)
+
+const (
+	// GPUDriverExtensionPublisher is the publisher of the GPU driver VM extensions.
+	GPUDriverExtensionPublisher = "Microsoft.HpcCompute"
+	// NvidiaGPUDriverExtensionLinux is the name of the NVIDIA GPU driver VM extension for Linux.
+	NvidiaGPUDriverExtensionLinux = "NvidiaGpuDriverLinux"
+	// NvidiaGPUDriverExtensionWindows is the name of the NVIDIA GPU driver VM extension for Windows.
+	NvidiaGPUDriverExtensionWindows = "NvidiaGpuDriverWindows"
+	// AMDGPUDriverExtensionWindows is the name of the AMD GPU driver VM extension for Windows.
+	AMDGPUDriverExtensionWindows = "AmdGpuDriverWindows"
 )
 
 const (
@@ -141,6 +187,29 @@ func GenerateNodePublicIPName(machineName string) string {
 	return fmt.Sprintf("pip-%s", machineName)
 }
 
+// dnsLabelInvalidCharsRegex matches the characters which are not allowed in DNS name labels.
+var dnsLabelInvalidCharsRegex = regexp.MustCompile(`[^a-z0-9-]`)
+
+// GenerateNodePublicIPDNSLabel generates a DNS name label for a node public IP, based on the machine name and a hash
+// of the subscription, resource group and machine name so that the label is unique within the region.
+// DNS name labels must start with a letter, so labels of machine names which don't are prefixed with "vm-".
+func GenerateNodePublicIPDNSLabel(subscriptionID, resourceGroup, machineName string) string {
+	h := fnv.New32a()
+	if _, err := fmt.Fprintf(h, "%s/%s/%s", subscriptionID, resourceGroup, machineName); err != nil {
+		return ""
+	}
+	hash := fmt.Sprintf("%x", h.Sum32())
+	// DNS name labels only contain lowercase letters, digits and hyphens, and are limited to 63 characters.
+	label := dnsLabelInvalidCharsRegex.ReplaceAllString(strings.ToLower(machineName), "-")
+	if label == "" || label[0] < 'a' || label[0] > 'z' {
+		label = "vm-" + label
+	}
+	if maxLen := 63 - len(hash) - 1; len(label) > maxLen {
+		label = label[:maxLen]
+	}
+	return fmt.Sprintf("%s-%s", label, hash)
+}
+
 // GenerateControlPlaneOutboundLBName generates the name of the control plane outbound LB.
 func GenerateControlPlaneOutboundLBName(clusterName string) string {
 	return fmt.Sprintf("%s-outbound-lb", clusterName)
@@ -189,11 +258,77 @@ func GenerateDataDiskName(machineName, nameSuffix string) string {
 	return fmt.Sprintf("%s_%s", machineName, nameSuffix)
 }
 
+// NICName returns the name of a network interface of a machine, using the naming template of the cluster if any.
+func NICName(naming *infrav1.ResourceNamingSpec, machineName string, multiNIC bool, index int) string {
+	if naming == nil || naming.NetworkInterface == "" {
+		return naming.Apply(GenerateNICName(machineName, multiNIC, index))
+	}
+	template := naming.NetworkInterface
+	if multiNIC && !strings.Contains(template, infrav1.ResourceNamingIndexPlaceholder) {
+		template += "-" + infrav1.ResourceNamingIndexPlaceholder
+	}
+	return naming.Apply(strings.NewReplacer(
+		infrav1.ResourceNamingMachinePlaceholder, machineName,
+		infrav1.ResourceNamingIndexPlaceholder, strconv.Itoa(index),
+	).Replace(template))
+}
+
+// PodNICName returns the name of the network interface holding the pod IP addresses of a machine network interface
+// in a subnet with a dedicated pod subnet.
+func PodNICName(nicName string) string {
+	return nicName + "-pods"
+}
+
+// OSDiskName returns the name of the OS disk of a machine, using the naming template of the cluster if any.
+func OSDiskName(naming *infrav1.ResourceNamingSpec, machineName string) string {
+	if naming == nil || naming.OSDisk == "" {
+		return naming.Apply(GenerateOSDiskName(machineName))
+	}
+	return naming.Apply(strings.ReplaceAll(naming.OSDisk, infrav1.ResourceNamingMachinePlaceholder, machineName))
+}
+
+// DataDiskName returns the name of a data disk of a machine, using the naming template of the cluster if any.
+func DataDiskName(naming *infrav1.ResourceNamingSpec, machineName, nameSuffix string) string {
+	if naming == nil || naming.DataDisk == "" {
+		return naming.Apply(GenerateDataDiskName(machineName, nameSuffix))
+	}
+	return naming.Apply(strings.NewReplacer(
+		infrav1.ResourceNamingMachinePlaceholder, machineName,
+		infrav1.ResourceNamingNameSuffixPlaceholder, nameSuffix,
+	).Replace(naming.DataDisk))
+}
+
+// NodePublicIPName returns the name of the public IP of a machine, using the naming template of the cluster if any.
+func NodePublicIPName(naming *infrav1.ResourceNamingSpec, machineName string) string {
+	if naming == nil || naming.PublicIP == "" {
+		return naming.Apply(GenerateNodePublicIPName(machineName))
+	}
+	return naming.Apply(strings.ReplaceAll(naming.PublicIP, infrav1.ResourceNamingMachinePlaceholder, machineName))
+}
+
 // GenerateVnetPeeringName generates the name for a peering between two vnets.
 func GenerateVnetPeeringName(sourceVnetName string, remoteVnetName string) string {
 	return fmt.Sprintf("%s-To-%s", sourceVnetName, remoteVnetName)
 }
 
+// maxFlowLogNameLength is the maximum length of the name of a flow log.
+const maxFlowLogNameLength = 80
+
+// GenerateFlowLogName generates the name of the flow log of a virtual network.
+// Network Watchers are shared by all the resource groups of a location, so the name includes the vnet's resource group.
+// Names longer than the 80 characters allowed by Azure are truncated and made unique with a hash of the full name.
+func GenerateFlowLogName(resourceGroup, vnetName string) string {
+	name := fmt.Sprintf("%s-%s-flowlog", vnetName, resourceGroup)
+	if len(name) <= maxFlowLogNameLength {
+		return name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	hash := fmt.Sprintf("%08x", h.Sum32())
+	prefix := fmt.Sprintf("%s-%s", vnetName, resourceGroup)
+	return fmt.Sprintf("%s-%s-flowlog", prefix[:maxFlowLogNameLength-len(hash)-len("--flowlog")], hash)
+}
+
 // GenerateAvailabilitySetName generates the name of a availability set based on the cluster name and the node group.
 // node group identifies the set of nodes that belong to this availability set:
 // For control plane nodes, this will be `control-plane`.
@@ -232,6 +367,11 @@ func SubnetID(subscriptionID, resourceGroup, vnetName, subnetName string) string
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s/subnets/%s", subscriptionID, resourceGroup, vnetName, subnetName)
 }
 
+// RouteServerID returns the azure resource ID for a given Route Server, which Azure models as a virtual hub.
+func RouteServerID(subscriptionID, resourceGroup, routeServerName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualHubs/%s", subscriptionID, resourceGroup, routeServerName)
+}
+
 // PublicIPID returns the azure resource ID for a given public IP.
 func PublicIPID(subscriptionID, resourceGroup, ipName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPAddresses/%s", subscriptionID, resourceGroup, ipName)
@@ -252,6 +392,32 @@ func SecurityGroupID(subscriptionID, resourceGroup, nsgName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkSecurityGroups/%s", subscriptionID, resourceGroup, nsgName)
 }
 
+// LoadBalancerID returns the azure resource ID for a given load balancer.
+func LoadBalancerID(subscriptionID, resourceGroup, loadBalancerName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s", subscriptionID, resourceGroup, loadBalancerName)
+}
+
+// ApplicationSecurityGroupID returns the azure resource ID for a given application security group reference, which is
+// either the name of an application security group in the given resource group or already a resource ID.
+func ApplicationSecurityGroupID(subscriptionID, resourceGroup, nameOrID string) string {
+	if strings.HasPrefix(nameOrID, "/") {
+		return nameOrID
+	}
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s", subscriptionID, resourceGroup, nameOrID)
+}
+
+// ApplicationSecurityGroupIDs returns the azure resource IDs for a list of application security group references.
+func ApplicationSecurityGroupIDs(subscriptionID, resourceGroup string, refs []string) []string {
+	if len(refs) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ApplicationSecurityGroupID(subscriptionID, resourceGroup, ref))
+	}
+	return ids
+}
+
 // NatGatewayID returns the azure resource ID for a given NAT gateway.
 func NatGatewayID(subscriptionID, resourceGroup, natgatewayName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/natGateways/%s", subscriptionID, resourceGroup, natgatewayName)
@@ -333,6 +499,18 @@ func GetBootstrappingVMExtension(osType string, cloud string, vmName string, cpu
 				"commandToExecute": LinuxBootstrapExtensionCommand,
 			},
 		}
+	} else if osType == LinuxOS && (cloud == ChinaCloudName || cloud == USGovernmentCloudName) {
+		// The CAPZ bootstrapping extension is not published in sovereign clouds, so the same command runs through the
+		// custom script extension instead.
+		return &ExtensionSpec{
+			Name:      CustomScriptBootstrappingExtensionLinux,
+			VMName:    vmName,
+			Publisher: "Microsoft.Azure.Extensions",
+			Version:   "2.1",
+			ProtectedSettings: map[string]string{
+				"commandToExecute": LinuxBootstrapExtensionCommand,
+			},
+		}
 	} else if osType == WindowsOS && cloud == PublicCloudName {
 		// This command for the existence of the bootstrapSentinelFile on the machine, with retries and sleep between reties.
 		// If the file is not present after the retries are exhausted the extension fails with return code '-2' - ERROR_FILE_NOT_FOUND.
@@ -345,14 +523,96 @@ func GetBootstrappingVMExtension(osType string, cloud string, vmName string, cpu
 				"commandToExecute": WindowsBootstrapExtensionCommand,
 			},
 		}
+	} else if osType == WindowsOS && (cloud == ChinaCloudName || cloud == USGovernmentCloudName) {
+		return &ExtensionSpec{
+			Name:      CustomScriptBootstrappingExtensionWindows,
+			VMName:    vmName,
+			Publisher: "Microsoft.Compute",
+			Version:   "1.10",
+			ProtectedSettings: map[string]string{
+				"commandToExecute": WindowsBootstrapExtensionCommand,
+			},
+		}
 	}
 
 	return nil
 }
 
+// IsBootstrappingVMExtension returns true if the VM extension with the given name reports the bootstrap result in the
+// given cloud. The custom script extensions only report it in the clouds where CAPZ uses them for bootstrapping, as
+// they may be user-provided extensions elsewhere.
+func IsBootstrappingVMExtension(name string, cloud string) bool {
+	switch name {
+	case BootstrappingExtensionLinux, BootstrappingExtensionWindows:
+		return true
+	case CustomScriptBootstrappingExtensionLinux, CustomScriptBootstrappingExtensionWindows:
+		return cloud == ChinaCloudName || cloud == USGovernmentCloudName
+	default:
+		return false
+	}
+}
+
+// IsSameVMExtensionType returns true if the VM extension has the given publisher and type. The type of the VM
+// extensions created by CAPZ is their name, and a VM can only have one extension of each publisher and type.
+func (e ExtensionSpec) IsSameVMExtensionType(publisher, extensionType string) bool {
+	return strings.EqualFold(e.Publisher, publisher) && strings.EqualFold(e.Name, extensionType)
+}
+
+// GetGPUDriverVMExtension returns the GPU driver VM extension for the given OS type and GPU vendor, or nil if Azure
+// doesn't provide a driver extension for them.
+func GetGPUDriverVMExtension(osType string, gpuVendor string, vmName string) *ExtensionSpec {
+	var name, version string
+	switch {
+	case gpuVendor == GPUVendorNVIDIA && osType == LinuxOS:
+		name, version = NvidiaGPUDriverExtensionLinux, "1.9"
+	case gpuVendor == GPUVendorNVIDIA && osType == WindowsOS:
+		name, version = NvidiaGPUDriverExtensionWindows, "1.6"
+	case gpuVendor == GPUVendorAMD && osType == WindowsOS:
+		name, version = AMDGPUDriverExtensionWindows, "1.1"
+	default:
+		return nil
+	}
+	return &ExtensionSpec{
+		Name:      name,
+		VMName:    vmName,
+		Publisher: GPUDriverExtensionPublisher,
+		Version:   version,
+	}
+}
+
+var (
+	// UserAgentSuffix is appended to the user agent of the requests to Azure, e.g. to attribute them to a partner
+	// with a "pid-<GUID>" identifier. It is set from the manager flags.
+	UserAgentSuffix string
+	// TelemetryDisabled removes the identifiers of CAPZ and of the Azure SDK from the user agent of the requests to
+	// Azure. It is set from the manager flags.
+	TelemetryDisabled bool
+)
+
 // UserAgent specifies a string to append to the agent identifier.
 func UserAgent() string {
-	return fmt.Sprintf("cluster-api-provider-azure/%s", version.Get().String())
+	return userAgent(UserAgentSuffix, TelemetryDisabled)
+}
+
+func userAgent(suffix string, telemetryDisabled bool) string {
+	var parts []string
+	if !telemetryDisabled {
+		parts = append(parts, fmt.Sprintf("cluster-api-provider-azure/%s", version.Get().String()))
+	}
+	if suffix != "" {
+		parts = append(parts, suffix)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ValidateUserAgentSuffix returns an error if suffix can't be sent in the User-Agent header.
+func ValidateUserAgentSuffix(suffix string) error {
+	for _, c := range suffix {
+		if c < ' ' || c > '~' {
+			return fmt.Errorf("invalid user agent suffix %q: only printable ASCII characters are allowed", suffix)
+		}
+	}
+	return nil
 }
 
 // ARMClientOptions returns default ARM client options for CAPZ SDK v2 requests.
@@ -366,17 +626,48 @@ func ARMClientOptions(azureEnvironment string, extraPolicies ...policy.Policy) (
 		opts.Cloud = cloud.AzureChina
 	case USGovernmentCloudName:
 		opts.Cloud = cloud.AzureGovernment
+	case StackCloudName:
+		env, err := azureautorest.EnvironmentFromName(StackCloudName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Azure Stack Hub environment from %s: %w", azureautorest.EnvironmentFilepathName, err)
+		}
+		opts.Cloud = cloud.Configuration{
+			ActiveDirectoryAuthorityHost: env.ActiveDirectoryEndpoint,
+			Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+				cloud.ResourceManager: {
+					Audience: env.TokenAudience,
+					Endpoint: env.ResourceManagerEndpoint,
+				},
+			},
+		}
 	case "":
 		// No cloud name provided, so leave at defaults.
 	default:
 		return nil, fmt.Errorf("invalid cloud name %q", azureEnvironment)
 	}
+	opts.Cloud = CloudEndpointOverrides.applyToCloud(opts.Cloud)
+	if HTTPTransport != nil {
+		opts.Transport = HTTPTransport
+	}
 	opts.PerCallPolicies = []policy.Policy{
+		tracingPolicy{},
 		correlationIDPolicy{},
 		userAgentPolicy{},
+		throttlingPolicy{throttles: subscriptionThrottles},
+		metricsPolicy{},
+		auditPolicy{},
+		pollingIntervalPolicy{},
+	}
+	if azureEnvironment == StackCloudName {
+		endpoint, err := url.Parse(opts.Cloud.Services[cloud.ResourceManager].Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Azure Stack Hub resource manager endpoint: %w", err)
+		}
+		opts.PerCallPolicies = append(opts.PerCallPolicies, azureStackHubPolicy{host: endpoint.Host})
 	}
 	opts.PerCallPolicies = append(opts.PerCallPolicies, extraPolicies...)
 	opts.Retry.MaxRetries = -1 // Less than zero means one try and no retries.
+	opts.Telemetry.Disabled = TelemetryDisabled
 
 	return opts, nil
 }
@@ -399,10 +690,119 @@ type userAgentPolicy struct{}
 
 // Do extends the "User-Agent" header of a request by appending CAPZ's user agent.
 func (p userAgentPolicy) Do(req *policy.Request) (*http.Response, error) {
-	req.Raw().Header.Set("User-Agent", req.Raw().UserAgent()+" "+UserAgent())
+	if ua := UserAgent(); ua != "" {
+		req.Raw().Header.Set("User-Agent", strings.TrimSpace(req.Raw().UserAgent()+" "+ua))
+	}
 	return req.Next()
 }
 
+// subscriptionThrottles is shared by all clients so that a 429 response seen by one service backs off the others.
+var subscriptionThrottles = newThrottles()
+
+// throttles records, per subscription, when ARM is expected to accept requests again.
+type throttles struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+func newThrottles() *throttles {
+	return &throttles{until: make(map[string]time.Time)}
+}
+
+// remaining returns how long requests to the subscription should still be held back.
+func (t *throttles) remaining(subscriptionID string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until, ok := t.until[subscriptionID]
+	if !ok {
+		return 0
+	}
+	wait := time.Until(until)
+	if wait <= 0 {
+		delete(t.until, subscriptionID)
+	}
+	return wait
+}
+
+// throttle holds back requests to the subscription for the given duration.
+func (t *throttles) throttle(subscriptionID string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until := time.Now().Add(d)
+	if until.After(t.until[subscriptionID]) {
+		t.until[subscriptionID] = until
+	}
+}
+
+// subscriptionPathRegex matches the subscription ID segment of an ARM request path.
+var subscriptionPathRegex = regexp.MustCompile(`(?i)^/subscriptions/([^/]+)`)
+
+// throttlingPolicy backs off every request to a subscription once ARM has responded to one of them with
+// 429 Too Many Requests, until the Retry-After period has elapsed. Held back requests fail with a synthetic
+// 429 response carrying the remaining Retry-After so callers requeue instead of adding to the throttling.
+// It implements the policy.Policy interface.
+type throttlingPolicy struct {
+	throttles *throttles
+}
+
+// Do short-circuits requests to throttled subscriptions and records throttling responses.
+func (p throttlingPolicy) Do(req *policy.Request) (*http.Response, error) {
+	match := subscriptionPathRegex.FindStringSubmatch(req.Raw().URL.Path)
+	if match == nil {
+		return req.Next()
+	}
+	subscriptionID := strings.ToLower(match[1])
+
+	if wait := p.throttles.remaining(subscriptionID); wait > 0 {
+		apiRequestsHeldBackTotal.WithLabelValues(req.Raw().Method, resourceType(req.Raw().URL.Path)).Inc()
+		return throttledResponse(req.Raw(), subscriptionID, wait), nil
+	}
+
+	resp, err := req.Next()
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		p.throttles.throttle(subscriptionID, retryAfter(resp.Header))
+	}
+	return resp, err
+}
+
+// throttledResponse builds the response returned for requests held back by the throttlingPolicy.
+func throttledResponse(req *http.Request, subscriptionID string, wait time.Duration) *http.Response {
+	seconds := strconv.Itoa(int(math.Ceil(wait.Seconds())))
+	body := fmt.Sprintf(`{"error":{"code":"TooManyRequests","message":"requests to subscription %s are held back for %ss after ARM throttled a previous request"}}`, subscriptionID, seconds)
+	return &http.Response{
+		Status:     http.StatusText(http.StatusTooManyRequests),
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After":  []string{seconds},
+			"Content-Type": []string{"application/json"},
+		},
+		Body:    io.NopCloser(strings.NewReader(body)),
+		Request: req,
+	}
+}
+
+// retryAfter returns the duration requested by a Retry-After header, given either in seconds or as an HTTP date.
+// It returns reconciler.DefaultHTTP429RetryAfter if the header is missing or invalid.
+func retryAfter(header http.Header) time.Duration {
+	if d, ok := parseRetryAfter(header); ok {
+		return d
+	}
+	return reconciler.DefaultHTTP429RetryAfter
+}
+
+// parseRetryAfter returns the duration requested by a Retry-After header, given either in seconds or as an HTTP date,
+// and whether the header was present and valid.
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil && time.Until(t) > 0 {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
 // CustomPutPatchHeaderPolicy adds custom headers to a PUT or PATCH request.
 // It implements the policy.Policy interface.
 type CustomPutPatchHeaderPolicy struct {
@@ -21,15 +21,23 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
 
 // TestARMClientOptions tests the `ARMClientOptions()` factory function.
@@ -80,11 +88,42 @@ func TestARMClientOptions(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(opts.Cloud).To(Equal(tc.expectedCloud))
 			g.Expect(opts.Retry.MaxRetries).To(BeNumerically("==", -1))
-			g.Expect(opts.PerCallPolicies).To(HaveLen(2))
+			g.Expect(opts.PerCallPolicies).To(HaveLen(7))
 		})
 	}
 }
 
+// TestARMClientOptionsAzureStack tests the `ARMClientOptions()` factory function for Azure Stack Hub.
+func TestARMClientOptionsAzureStack(t *testing.T) {
+	g := NewWithT(t)
+
+	environmentFile := filepath.Join(t.TempDir(), "azurestackcloud.json")
+	g.Expect(os.WriteFile(environmentFile, []byte(`{
+		"name": "AzureStackCloud",
+		"resourceManagerEndpoint": "https://management.local.azurestack.external/",
+		"activeDirectoryEndpoint": "https://adfs.local.azurestack.external/adfs/",
+		"tokenAudience": "https://management.adfs.azurestack.local/"
+	}`), 0o600)).To(Succeed())
+	t.Setenv("AZURE_ENVIRONMENT_FILEPATH", environmentFile)
+
+	opts, err := ARMClientOptions(StackCloudName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(opts.Cloud).To(Equal(cloud.Configuration{
+		ActiveDirectoryAuthorityHost: "https://adfs.local.azurestack.external/adfs/",
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Audience: "https://management.adfs.azurestack.local/",
+				Endpoint: "https://management.local.azurestack.external/",
+			},
+		},
+	}))
+	g.Expect(opts.PerCallPolicies).To(ContainElement(azureStackHubPolicy{host: "management.local.azurestack.external"}))
+
+	t.Setenv("AZURE_ENVIRONMENT_FILEPATH", filepath.Join(t.TempDir(), "missing.json"))
+	_, err = ARMClientOptions(StackCloudName)
+	g.Expect(err).To(HaveOccurred())
+}
+
 // TestPerCallPolicies tests the per-call policies returned by `ARMClientOptions()`.
 func TestPerCallPolicies(t *testing.T) {
 	g := NewWithT(t)
@@ -101,9 +140,14 @@ func TestPerCallPolicies(t *testing.T) {
 	// Call the factory function and ensure it has both PerCallPolicies.
 	opts, err := ARMClientOptions("")
 	g.Expect(err).NotTo(HaveOccurred())
-	g.Expect(opts.PerCallPolicies).To(HaveLen(2))
+	g.Expect(opts.PerCallPolicies).To(HaveLen(7))
 	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(correlationIDPolicy{})))
 	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(userAgentPolicy{})))
+	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(throttlingPolicy{})))
+	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(metricsPolicy{})))
+	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(auditPolicy{})))
+	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(tracingPolicy{})))
+	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(pollingIntervalPolicy{})))
 
 	// Create a request with a correlation ID.
 	ctx := context.WithValue(context.Background(), tele.CorrIDKeyVal, tele.CorrID(corrID))
@@ -118,6 +162,143 @@ func TestPerCallPolicies(t *testing.T) {
 	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
 }
 
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		name              string
+		suffix            string
+		telemetryDisabled bool
+		expected          string
+	}{
+		{
+			name:     "CAPZ identifier",
+			expected: "cluster-api-provider-azure/" + version.Get().String(),
+		},
+		{
+			name:     "suffix is appended",
+			suffix:   "pid-00000000-0000-0000-0000-000000000000",
+			expected: "cluster-api-provider-azure/" + version.Get().String() + " pid-00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:              "telemetry disabled",
+			telemetryDisabled: true,
+			expected:          "",
+		},
+		{
+			name:              "telemetry disabled keeps the suffix",
+			suffix:            "pid-00000000-0000-0000-0000-000000000000",
+			telemetryDisabled: true,
+			expected:          "pid-00000000-0000-0000-0000-000000000000",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(userAgent(tc.suffix, tc.telemetryDisabled)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestValidateUserAgentSuffix(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ValidateUserAgentSuffix("")).To(Succeed())
+	g.Expect(ValidateUserAgentSuffix("pid-00000000-0000-0000-0000-000000000000 (partner)")).To(Succeed())
+	g.Expect(ValidateUserAgentSuffix("pid\r\nX-Injected: true")).To(MatchError(ContainSubstring("only printable ASCII characters are allowed")))
+	g.Expect(ValidateUserAgentSuffix("pid-é")).To(HaveOccurred())
+}
+
+func TestThrottlingPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	// This server throttles the first request to each subscription.
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprintf(w, "Hello, %s", r.Proto)
+	}))
+	defer server.Close()
+
+	// Retries are disabled as they are for CAPZ's clients, so every throttled response reaches the caller.
+	pipeline := runtime.NewPipeline(
+		"testmodule",
+		"v0.1.0",
+		runtime.PipelineOptions{},
+		&policy.ClientOptions{
+			PerCallPolicies: []policy.Policy{throttlingPolicy{throttles: newThrottles()}},
+			Retry:           policy.RetryOptions{MaxRetries: -1},
+		},
+	)
+	send := func(path string) *http.Response {
+		req, err := runtime.NewRequest(context.Background(), http.MethodGet, server.URL+path)
+		g.Expect(err).NotTo(HaveOccurred())
+		resp, err := pipeline.Do(req)
+		g.Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		return resp
+	}
+
+	// The first request is throttled by ARM.
+	resp := send("/subscriptions/123/resourceGroups/my-rg")
+	g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+	g.Expect(requests.Load()).To(Equal(int32(1)))
+
+	// Further requests to the same subscription are held back without reaching ARM.
+	resp = send("/Subscriptions/123/providers/Microsoft.Compute/virtualMachines")
+	g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+	g.Expect(resp.Header.Get("Retry-After")).To(Equal("60"))
+	g.Expect(requests.Load()).To(Equal(int32(1)))
+
+	// Other subscriptions and requests outside of a subscription are not affected.
+	resp = send("/subscriptions/456/resourceGroups/my-rg")
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	resp = send("/providers/Microsoft.Compute/operations")
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	g.Expect(requests.Load()).To(Equal(int32(3)))
+}
+
+func TestRetryAfter(t *testing.T) {
+	testcases := []struct {
+		name     string
+		header   string
+		expected time.Duration
+	}{
+		{
+			name:     "seconds",
+			header:   "30",
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "missing header",
+			header:   "",
+			expected: reconciler.DefaultHTTP429RetryAfter,
+		},
+		{
+			name:     "invalid header",
+			header:   "soon",
+			expected: reconciler.DefaultHTTP429RetryAfter,
+		},
+		{
+			name:     "date in the past",
+			header:   "Mon, 02 Jan 2006 15:04:05 GMT",
+			expected: reconciler.DefaultHTTP429RetryAfter,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			header := http.Header{}
+			if tc.header != "" {
+				header.Set("Retry-After", tc.header)
+			}
+			g.Expect(retryAfter(header)).To(Equal(tc.expected))
+		})
+	}
+}
+
 func TestCustomPutPatchHeaderPolicy(t *testing.T) {
 	testHeaders := map[string]string{
 		"X-Test-Header":  "test-value",
@@ -245,6 +426,22 @@ func TestGetBootstrappingVMExtension(t *testing.T) {
 			cpuArchitecture: "x64",
 			expectedVersion: "1.0",
 		},
+		{
+			name:            "Linux OS, US Government Cloud",
+			osType:          LinuxOS,
+			cloud:           USGovernmentCloudName,
+			vmName:          "test-vm",
+			cpuArchitecture: "x64",
+			expectedVersion: "2.1",
+		},
+		{
+			name:            "Windows OS, China Cloud",
+			osType:          WindowsOS,
+			cloud:           ChinaCloudName,
+			vmName:          "test-vm",
+			cpuArchitecture: "x64",
+			expectedVersion: "1.10",
+		},
 		{
 			name:            "Invalid OS Type",
 			osType:          "invalid",
@@ -273,6 +470,85 @@ func TestGetBootstrappingVMExtension(t *testing.T) {
 				g.Expect(actualExtension).To(BeNil())
 			} else {
 				g.Expect(actualExtension.Version).To(Equal(tc.expectedVersion))
+				g.Expect(IsBootstrappingVMExtension(actualExtension.Name, tc.cloud)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestIsBootstrappingVMExtension(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cloud    string
+		expected bool
+	}{
+		{name: BootstrappingExtensionLinux, cloud: PublicCloudName, expected: true},
+		{name: BootstrappingExtensionWindows, cloud: PublicCloudName, expected: true},
+		{name: CustomScriptBootstrappingExtensionLinux, cloud: PublicCloudName, expected: false},
+		{name: CustomScriptBootstrappingExtensionWindows, cloud: PublicCloudName, expected: false},
+		{name: CustomScriptBootstrappingExtensionLinux, cloud: ChinaCloudName, expected: true},
+		{name: CustomScriptBootstrappingExtensionWindows, cloud: USGovernmentCloudName, expected: true},
+		{name: "OtherExtension", cloud: ChinaCloudName, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+" in "+tc.cloud, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(IsBootstrappingVMExtension(tc.name, tc.cloud)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestGetGPUDriverVMExtension(t *testing.T) {
+	testCases := []struct {
+		name         string
+		osType       string
+		gpuVendor    string
+		expectedName string
+		expectNil    bool
+	}{
+		{
+			name:         "NVIDIA GPU on Linux",
+			osType:       LinuxOS,
+			gpuVendor:    GPUVendorNVIDIA,
+			expectedName: NvidiaGPUDriverExtensionLinux,
+		},
+		{
+			name:         "NVIDIA GPU on Windows",
+			osType:       WindowsOS,
+			gpuVendor:    GPUVendorNVIDIA,
+			expectedName: NvidiaGPUDriverExtensionWindows,
+		},
+		{
+			name:         "AMD GPU on Windows",
+			osType:       WindowsOS,
+			gpuVendor:    GPUVendorAMD,
+			expectedName: AMDGPUDriverExtensionWindows,
+		},
+		{
+			name:      "AMD GPU on Linux",
+			osType:    LinuxOS,
+			gpuVendor: GPUVendorAMD,
+			expectNil: true,
+		},
+		{
+			name:      "no GPU",
+			osType:    LinuxOS,
+			gpuVendor: "",
+			expectNil: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			actualExtension := GetGPUDriverVMExtension(tc.osType, tc.gpuVendor, "test-vm")
+			if tc.expectNil {
+				g.Expect(actualExtension).To(BeNil())
+			} else {
+				g.Expect(actualExtension.Name).To(Equal(tc.expectedName))
+				g.Expect(actualExtension.Publisher).To(Equal(GPUDriverExtensionPublisher))
+				g.Expect(actualExtension.VMName).To(Equal("test-vm"))
 			}
 		})
 	}
@@ -353,3 +629,108 @@ func TestNormalizeAzureName(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateNodePublicIPDNSLabel(t *testing.T) {
+	g := NewWithT(t)
+
+	label := GenerateNodePublicIPDNSLabel("123", "my-rg", "My-Machine")
+	g.Expect(label).To(HavePrefix("my-machine-"))
+	g.Expect(label).To(Equal(GenerateNodePublicIPDNSLabel("123", "my-rg", "My-Machine")))
+	g.Expect(label).NotTo(Equal(GenerateNodePublicIPDNSLabel("456", "my-rg", "My-Machine")))
+
+	longLabel := GenerateNodePublicIPDNSLabel("123", "my-rg", strings.Repeat("a", 80))
+	g.Expect(longLabel).To(HaveLen(63))
+
+	g.Expect(GenerateNodePublicIPDNSLabel("123", "my-rg", "0-machine")).To(MatchRegexp(`^vm-0-machine-[0-9a-f]+$`))
+	g.Expect(GenerateNodePublicIPDNSLabel("123", "my-rg", "machine.example")).To(MatchRegexp(`^machine-example-[0-9a-f]+$`))
+	g.Expect(GenerateNodePublicIPDNSLabel("123", "my-rg", "1"+strings.Repeat("a", 80))).To(And(HaveLen(63), HavePrefix("vm-1")))
+}
+
+func TestGenerateFlowLogName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(GenerateFlowLogName("my-rg", "my-vnet")).To(Equal("my-vnet-my-rg-flowlog"))
+
+	longName := GenerateFlowLogName("my-rg", strings.Repeat("a", 80))
+	g.Expect(longName).To(HaveLen(80))
+	g.Expect(longName).To(MatchRegexp(`^a+-[0-9a-f]{8}-flowlog$`))
+	g.Expect(longName).NotTo(Equal(GenerateFlowLogName("other-rg", strings.Repeat("a", 80))))
+}
+
+func TestResourceNamingNames(t *testing.T) {
+	naming := &infrav1.ResourceNamingSpec{
+		Prefix:           "corp-",
+		Suffix:           "-01",
+		NetworkInterface: "nic-{machine}",
+		OSDisk:           "disk-{machine}-os",
+		DataDisk:         "disk-{machine}-{nameSuffix}",
+		PublicIP:         "ip-{machine}",
+	}
+	tests := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{
+			name:     "default network interface name",
+			actual:   NICName(nil, "my-vm", false, 0),
+			expected: "my-vm-nic",
+		},
+		{
+			name:     "default network interface name with prefix and suffix",
+			actual:   NICName(&infrav1.ResourceNamingSpec{Prefix: "corp-", Suffix: "-01"}, "my-vm", true, 1),
+			expected: "corp-my-vm-nic-1-01",
+		},
+		{
+			name:     "network interface name from template",
+			actual:   NICName(naming, "my-vm", false, 0),
+			expected: "corp-nic-my-vm-01",
+		},
+		{
+			name:     "network interface name from template with multiple network interfaces",
+			actual:   NICName(naming, "my-vm", true, 2),
+			expected: "corp-nic-my-vm-2-01",
+		},
+		{
+			name:     "network interface name from template with index placeholder",
+			actual:   NICName(&infrav1.ResourceNamingSpec{NetworkInterface: "nic{index}-{machine}"}, "my-vm", true, 2),
+			expected: "nic2-my-vm",
+		},
+		{
+			name:     "default OS disk name",
+			actual:   OSDiskName(nil, "my-vm"),
+			expected: "my-vm_OSDisk",
+		},
+		{
+			name:     "OS disk name from template",
+			actual:   OSDiskName(naming, "my-vm"),
+			expected: "corp-disk-my-vm-os-01",
+		},
+		{
+			name:     "default data disk name",
+			actual:   DataDiskName(nil, "my-vm", "etcddisk"),
+			expected: "my-vm_etcddisk",
+		},
+		{
+			name:     "data disk name from template",
+			actual:   DataDiskName(naming, "my-vm", "etcddisk"),
+			expected: "corp-disk-my-vm-etcddisk-01",
+		},
+		{
+			name:     "default public IP name",
+			actual:   NodePublicIPName(nil, "my-vm"),
+			expected: "pip-my-vm",
+		},
+		{
+			name:     "public IP name from template",
+			actual:   NodePublicIPName(naming, "my-vm"),
+			expected: "corp-ip-my-vm-01",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tc.actual).To(Equal(tc.expected))
+		})
+	}
+}
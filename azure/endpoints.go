@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+)
+
+// CloudEndpoints are endpoints overriding those of the Azure cloud environment, for Azure environments which aren't
+// known clouds, such as air-gapped or pre-production environments fronted by gateways. Empty endpoints keep the ones
+// of the cloud environment.
+type CloudEndpoints struct {
+	// ResourceManager is the base URL of Azure Resource Manager, e.g. https://management.example.com.
+	ResourceManager string
+	// ResourceManagerAudience is the audience of the tokens sent to Azure Resource Manager.
+	ResourceManagerAudience string
+	// ActiveDirectoryAuthorityHost is the base URL of the Azure Active Directory authority, e.g. https://login.example.com.
+	ActiveDirectoryAuthorityHost string
+}
+
+// CloudEndpointOverrides are the endpoints overriding those of the cloud environment of every cluster. It is set from
+// the manager flags.
+var CloudEndpointOverrides CloudEndpoints
+
+// Validate returns an error if an endpoint isn't an absolute URL.
+func (e CloudEndpoints) Validate() error {
+	for name, endpoint := range map[string]string{
+		"resource manager endpoint":       e.ResourceManager,
+		"resource manager audience":       e.ResourceManagerAudience,
+		"active directory authority host": e.ActiveDirectoryAuthorityHost,
+	} {
+		if endpoint == "" {
+			continue
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", name, endpoint, err)
+		}
+		if !u.IsAbs() || u.Host == "" {
+			return fmt.Errorf("invalid %s %q: must be an absolute URL", name, endpoint)
+		}
+	}
+	return nil
+}
+
+// applyToCloud returns the cloud configuration with its endpoints overridden. The configuration of the Azure public
+// cloud is used when cfg is empty.
+func (e CloudEndpoints) applyToCloud(cfg cloud.Configuration) cloud.Configuration {
+	if e == (CloudEndpoints{}) {
+		return cfg
+	}
+	if cfg.ActiveDirectoryAuthorityHost == "" && len(cfg.Services) == 0 {
+		cfg = cloud.AzurePublic
+	}
+
+	// The services of the known clouds are shared, so they are copied rather than modified.
+	services := make(map[cloud.ServiceName]cloud.ServiceConfiguration, len(cfg.Services))
+	for name, service := range cfg.Services {
+		services[name] = service
+	}
+	cfg.Services = services
+
+	if e.ActiveDirectoryAuthorityHost != "" {
+		cfg.ActiveDirectoryAuthorityHost = withTrailingSlash(e.ActiveDirectoryAuthorityHost)
+	}
+	resourceManager := cfg.Services[cloud.ResourceManager]
+	if e.ResourceManager != "" {
+		resourceManager.Endpoint = strings.TrimSuffix(e.ResourceManager, "/")
+	}
+	if e.ResourceManagerAudience != "" {
+		resourceManager.Audience = e.ResourceManagerAudience
+	}
+	cfg.Services[cloud.ResourceManager] = resourceManager
+	return cfg
+}
+
+// ApplyToEnvironment overrides the endpoints of an Azure environment.
+func (e CloudEndpoints) ApplyToEnvironment(env *azureautorest.Environment) {
+	if e.ResourceManager != "" {
+		env.ResourceManagerEndpoint = withTrailingSlash(e.ResourceManager)
+	}
+	if e.ResourceManagerAudience != "" {
+		env.TokenAudience = e.ResourceManagerAudience
+	}
+	if e.ActiveDirectoryAuthorityHost != "" {
+		env.ActiveDirectoryEndpoint = withTrailingSlash(e.ActiveDirectoryAuthorityHost)
+	}
+}
+
+func withTrailingSlash(endpoint string) string {
+	return strings.TrimSuffix(endpoint, "/") + "/"
+}
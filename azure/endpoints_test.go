@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	. "github.com/onsi/gomega"
+)
+
+func TestCloudEndpointsValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpoints   CloudEndpoints
+		expectedErr string
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name: "valid overrides",
+			endpoints: CloudEndpoints{
+				ResourceManager:              "https://management.example.com",
+				ResourceManagerAudience:      "https://management.core.windows.net/",
+				ActiveDirectoryAuthorityHost: "https://login.example.com/",
+			},
+		},
+		{
+			name:        "relative resource manager endpoint",
+			endpoints:   CloudEndpoints{ResourceManager: "management.example.com"},
+			expectedErr: `invalid resource manager endpoint "management.example.com": must be an absolute URL`,
+		},
+		{
+			name:        "invalid authority host",
+			endpoints:   CloudEndpoints{ActiveDirectoryAuthorityHost: "https://login example.com"},
+			expectedErr: "invalid active directory authority host",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.endpoints.Validate()
+			if tc.expectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestCloudEndpointsApplyToCloud(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints CloudEndpoints
+		cfg       cloud.Configuration
+		expected  cloud.Configuration
+	}{
+		{
+			name:     "no overrides keep the default configuration",
+			cfg:      cloud.Configuration{},
+			expected: cloud.Configuration{},
+		},
+		{
+			name: "overrides apply to the public cloud by default",
+			endpoints: CloudEndpoints{
+				ResourceManager:              "https://management.example.com/",
+				ActiveDirectoryAuthorityHost: "https://login.example.com",
+			},
+			cfg: cloud.Configuration{},
+			expected: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: "https://login.example.com/",
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {
+						Audience: cloud.AzurePublic.Services[cloud.ResourceManager].Audience,
+						Endpoint: "https://management.example.com",
+					},
+				},
+			},
+		},
+		{
+			name:      "audience override keeps the cloud endpoints",
+			endpoints: CloudEndpoints{ResourceManagerAudience: "https://management.example.com/"},
+			cfg:       cloud.AzureChina,
+			expected: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: cloud.AzureChina.ActiveDirectoryAuthorityHost,
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {
+						Audience: "https://management.example.com/",
+						Endpoint: cloud.AzureChina.Services[cloud.ResourceManager].Endpoint,
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			publicResourceManager := cloud.AzurePublic.Services[cloud.ResourceManager]
+			g.Expect(tc.endpoints.applyToCloud(tc.cfg)).To(Equal(tc.expected))
+			g.Expect(cloud.AzurePublic.Services[cloud.ResourceManager]).To(Equal(publicResourceManager))
+		})
+	}
+}
+
+func TestCloudEndpointsApplyToEnvironment(t *testing.T) {
+	g := NewWithT(t)
+
+	env := azureautorest.PublicCloud
+	CloudEndpoints{
+		ResourceManager:              "https://management.example.com",
+		ActiveDirectoryAuthorityHost: "https://login.example.com",
+	}.ApplyToEnvironment(&env)
+
+	g.Expect(env.ResourceManagerEndpoint).To(Equal("https://management.example.com/"))
+	g.Expect(env.ActiveDirectoryEndpoint).To(Equal("https://login.example.com/"))
+	g.Expect(env.TokenAudience).To(Equal(azureautorest.PublicCloud.TokenAudience))
+	g.Expect(azureautorest.PublicCloud.ResourceManagerEndpoint).To(Equal("https://management.azure.com/"))
+}
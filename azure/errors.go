@@ -21,10 +21,54 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// capacityRetryAfter is how long to wait before retrying an operation that failed because Azure was out of
+	// capacity or quota, which is rarely resolved within seconds.
+	capacityRetryAfter = 5 * time.Minute
+
+	// ScopeLockedErrorCode is the Azure error code of operations refused because of a management lock.
+	ScopeLockedErrorCode = "ScopeLocked"
+)
+
+var (
+	// terminalErrorCodes are the Azure error codes of operations which will fail again until their specification
+	// is changed.
+	terminalErrorCodes = map[string]bool{
+		"BadRequestFormat":          true,
+		"InvalidParameter":          true,
+		"InvalidRequestContent":     true,
+		"InvalidResourceReference":  true,
+		"ImageNotFound":             true,
+		"PlatformImageNotFound":     true,
+		"PropertyChangeNotAllowed":  true,
+		ScopeLockedErrorCode:        true,
+		"SkuNotAvailable":           true,
+		"VMMarketplaceInvalidInput": true,
+	}
+
+	// capacityErrorCodes are the Azure error codes of operations which failed because Azure was out of capacity.
+	capacityErrorCodes = map[string]bool{
+		"AllocationFailed":                      true,
+		"OverconstrainedAllocationRequest":      true,
+		"OverconstrainedZonalAllocationRequest": true,
+		"ZonalAllocationFailed":                 true,
+	}
+
+	// retryableErrorCodes are the Azure error codes of operations which can succeed if they are retried as is.
+	retryableErrorCodes = map[string]bool{
+		"AnotherOperationInProgress": true,
+		"OperationPreempted":         true,
+		"RetryableError":             true,
+	}
 )
 
 // ResourceNotFound parses an error to check if its status code is Not Found (404).
@@ -33,6 +77,137 @@ func ResourceNotFound(err error) bool {
 	return errors.As(err, &rerr) && rerr.StatusCode == http.StatusNotFound
 }
 
+// IsThrottled parses an error to check if its status code is Too Many Requests (429), which is also the
+// case for requests held back because their subscription is being throttled.
+func IsThrottled(err error) bool {
+	var rerr *azcore.ResponseError
+	return errors.As(err, &rerr) && rerr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsQuotaExceeded parses an error to check if Azure refused an operation because it would exceed a quota.
+func IsQuotaExceeded(err error) bool {
+	var rerr *azcore.ResponseError
+	if !errors.As(err, &rerr) {
+		return false
+	}
+	switch rerr.ErrorCode {
+	case "QuotaExceeded":
+		return true
+	case "OperationNotAllowed":
+		// Core quotas are reported as "Operation could not be completed as it results in exceeding approved ... quota".
+		return strings.Contains(strings.ToLower(rerr.Error()), "quota")
+	default:
+		return false
+	}
+}
+
+// IsResourceLocked returns true if an operation failed because of an Azure management lock on the resource, its
+// resource group or its subscription.
+func IsResourceLocked(err error) bool {
+	var rerr *azcore.ResponseError
+	if errors.As(err, &rerr) {
+		return rerr.ErrorCode == ScopeLockedErrorCode
+	}
+	var lockedErr ResourceLockedError
+	if errors.As(err, &lockedErr) {
+		return true
+	}
+	var reconcileErr ReconcileError
+	return errors.As(err, &reconcileErr) && reconcileErr.error != nil && IsResourceLocked(reconcileErr.error)
+}
+
+// ClassifyError wraps an error returned by Azure Resource Manager in a ReconcileError according to its error code:
+// errors which can't be recovered from without changing the resource specification are terminal, and errors caused by
+// throttling, capacity, quota or conflicting operations are transient and requeued after a delay fitting the cause.
+// Errors which are already a ReconcileError, or which can't be classified, are returned unchanged.
+func ClassifyError(err error) error {
+	var reconcileErr ReconcileError
+	var rerr *azcore.ResponseError
+	if err == nil || errors.As(err, &reconcileErr) || !errors.As(err, &rerr) {
+		return err
+	}
+
+	switch {
+	case IsThrottled(err):
+		if rerr.RawResponse != nil {
+			return WithTransientError(err, retryAfter(rerr.RawResponse.Header))
+		}
+		return WithTransientError(err, reconciler.DefaultHTTP429RetryAfter)
+	case capacityErrorCodes[rerr.ErrorCode] || IsQuotaExceeded(err):
+		return WithTransientError(err, capacityRetryAfter)
+	case retryableErrorCodes[rerr.ErrorCode] || rerr.StatusCode >= http.StatusInternalServerError:
+		return WithTransientError(err, reconciler.DefaultReconcilerRequeue)
+	case terminalErrorCodes[rerr.ErrorCode]:
+		return WithTerminalError(err)
+	default:
+		return err
+	}
+}
+
+// ErrorDetails are the details of an error returned by Azure Resource Manager which identify the failure.
+type ErrorDetails struct {
+	// Code is the Azure error code, e.g. QuotaExceeded or RequestDisallowedByPolicy.
+	Code string
+	// CorrelationID is the correlation ID of the request, which can be used to find it in the Azure Activity Log.
+	CorrelationID string
+	// Target is the path of the request, usually the ID of the resource the request was about.
+	Target string
+}
+
+// String returns the details in the form "code=<code>, correlationID=<correlation ID>, target=<target>",
+// omitting the details which are unknown.
+func (d ErrorDetails) String() string {
+	var fields []string
+	for _, f := range []struct{ key, value string }{
+		{"code", d.Code},
+		{"correlationID", d.CorrelationID},
+		{"target", d.Target},
+	} {
+		if f.value != "" {
+			fields = append(fields, f.key+"="+f.value)
+		}
+	}
+	return strings.Join(fields, ", ")
+}
+
+// GetErrorDetails returns the details of the Azure Resource Manager error wrapped by err, and whether there is one.
+func GetErrorDetails(err error) (ErrorDetails, bool) {
+	reconcileErr := &ReconcileError{}
+	if errors.As(err, reconcileErr) {
+		return GetErrorDetails(reconcileErr.error)
+	}
+	var rerr *azcore.ResponseError
+	if !errors.As(err, &rerr) {
+		return ErrorDetails{}, false
+	}
+
+	details := ErrorDetails{Code: rerr.ErrorCode}
+	if resp := rerr.RawResponse; resp != nil {
+		details.CorrelationID = resp.Header.Get(string(tele.CorrIDKeyVal))
+		if resp.Request != nil {
+			if details.CorrelationID == "" {
+				details.CorrelationID = resp.Request.Header.Get(string(tele.CorrIDKeyVal))
+			}
+			if resp.Request.URL != nil {
+				details.Target = resp.Request.URL.Path
+			}
+		}
+	}
+	return details, true
+}
+
+// ErrorMessage returns the message of err, prefixed with the details of the Azure Resource Manager error it wraps
+// between brackets, e.g. "[code=QuotaExceeded, correlationID=..., target=...] failed to create ...". It is meant for
+// failure messages and conditions, so that automation can react to specific Azure errors.
+func ErrorMessage(err error) string {
+	if details, ok := GetErrorDetails(err); ok {
+		if s := details.String(); s != "" {
+			return fmt.Sprintf("[%s] %s", s, err.Error())
+		}
+	}
+	return err.Error()
+}
+
 // VMDeletedError is returned when a virtual machine is deleted outside of capz.
 type VMDeletedError struct {
 	ProviderID string
@@ -43,6 +218,18 @@ func (vde VMDeletedError) Error() string {
 	return fmt.Sprintf("VM with provider id %q has been deleted", vde.ProviderID)
 }
 
+// ResourceLockedError is returned when an Azure resource reconciled by Azure Service Operator can't be deleted
+// because of an Azure management lock.
+type ResourceLockedError struct {
+	Resource string
+	Message  string
+}
+
+// Error returns the error string.
+func (rle ResourceLockedError) Error() string {
+	return fmt.Sprintf("resource %s is locked: %s", rle.Resource, rle.Message)
+}
+
 // ReconcileError represents an error that is not automatically recoverable
 // errorType indicates what type of action is required to recover. It can take two values:
 // 1. `Transient` - Can be recovered through manual intervention, will be requeued after.
@@ -140,6 +327,24 @@ func IsOperationNotDoneError(target error) bool {
 	return errors.As(target, &OperationNotDoneError{})
 }
 
+// OperationProgress returns a description of the state and start time of the long-running operation of err, to be
+// appended to the message of a condition, or an empty string if err isn't an OperationNotDoneError.
+func OperationProgress(err error) string {
+	var reconcileErr ReconcileError
+	if errors.As(err, &reconcileErr) && reconcileErr.error != nil {
+		err = reconcileErr.error
+	}
+	var onde OperationNotDoneError
+	if !errors.As(err, &onde) || onde.Future == nil {
+		return ""
+	}
+	progress := fmt.Sprintf(": operation %s on %s/%s in progress", onde.Future.Type, onde.Future.ResourceGroup, onde.Future.Name)
+	if onde.Future.StartTime != nil {
+		progress += " since " + onde.Future.StartTime.UTC().Format(time.RFC3339)
+	}
+	return progress
+}
+
 // IsContextDeadlineExceededOrCanceledError checks if it's a context deadline
 // exceeded or canceled error.
 func IsContextDeadlineExceededOrCanceledError(err error) bool {
@@ -18,12 +18,20 @@ package azure
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 )
 
 func TestIsContextDeadlineExceededOrCanceled(t *testing.T) {
@@ -102,3 +110,326 @@ func TestResourceNotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestIsThrottled(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		success bool
+	}{
+		{
+			name:    "Too Many Requests response error",
+			err:     fmt.Errorf("failed to get resource: %w", &azcore.ResponseError{StatusCode: http.StatusTooManyRequests}),
+			success: true,
+		},
+		{
+			name:    "Conflict response error",
+			err:     &azcore.ResponseError{StatusCode: http.StatusConflict},
+			success: false,
+		},
+		{
+			name:    "generic error",
+			err:     errors.New("429: Too Many Requests"),
+			success: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsThrottled(tc.err); got != tc.success {
+				t.Errorf("IsThrottled() = %v, want %v", got, tc.success)
+			}
+		})
+	}
+}
+
+func TestIsQuotaExceeded(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		success bool
+	}{
+		{
+			name:    "QuotaExceeded response error",
+			err:     &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "QuotaExceeded"},
+			success: true,
+		},
+		{
+			name: "OperationNotAllowed response error exceeding a quota",
+			err: &azcore.ResponseError{
+				StatusCode: http.StatusConflict,
+				ErrorCode:  "OperationNotAllowed",
+				RawResponse: &http.Response{
+					StatusCode: http.StatusConflict,
+					Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"OperationNotAllowed","message":"Operation could not be completed as it results in exceeding approved standardDSv3Family Cores quota."}}`)),
+				},
+			},
+			success: true,
+		},
+		{
+			name:    "OperationNotAllowed response error for another reason",
+			err:     &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "OperationNotAllowed"},
+			success: false,
+		},
+		{
+			name:    "generic error",
+			err:     errors.New("QuotaExceeded"),
+			success: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsQuotaExceeded(tc.err); got != tc.success {
+				t.Errorf("IsQuotaExceeded() = %v, want %v", got, tc.success)
+			}
+		})
+	}
+}
+
+func TestIsResourceLocked(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		success bool
+	}{
+		{
+			name:    "ScopeLocked response error",
+			err:     fmt.Errorf("failed to delete resource: %w", &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "ScopeLocked"}),
+			success: true,
+		},
+		{
+			name:    "classified ScopeLocked response error",
+			err:     ClassifyError(&azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "ScopeLocked"}),
+			success: true,
+		},
+		{
+			name:    "locked ASO resource",
+			err:     WithTerminalError(ResourceLockedError{Resource: "default/my-rg", Message: "locked"}),
+			success: true,
+		},
+		{
+			name:    "Conflict response error for another reason",
+			err:     &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "Conflict"},
+			success: false,
+		},
+		{
+			name:    "generic error",
+			err:     errors.New("ScopeLocked"),
+			success: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsResourceLocked(tc.err); got != tc.success {
+				t.Errorf("IsResourceLocked() = %v, want %v", got, tc.success)
+			}
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		terminal     bool
+		transient    bool
+		requeueAfter time.Duration
+	}{
+		{
+			name:     "InvalidParameter is terminal",
+			err:      fmt.Errorf("failed to create resource: %w", &azcore.ResponseError{StatusCode: http.StatusBadRequest, ErrorCode: "InvalidParameter"}),
+			terminal: true,
+		},
+		{
+			name:     "ScopeLocked is terminal",
+			err:      &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "ScopeLocked"},
+			terminal: true,
+		},
+		{
+			name:         "AllocationFailed is retried after a capacity backoff",
+			err:          &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "AllocationFailed"},
+			transient:    true,
+			requeueAfter: capacityRetryAfter,
+		},
+		{
+			name:         "QuotaExceeded is retried after a capacity backoff",
+			err:          &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "QuotaExceeded"},
+			transient:    true,
+			requeueAfter: capacityRetryAfter,
+		},
+		{
+			name: "throttled request is retried after Retry-After",
+			err: &azcore.ResponseError{
+				StatusCode: http.StatusTooManyRequests,
+				RawResponse: &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{"Retry-After": []string{"42"}},
+				},
+			},
+			transient:    true,
+			requeueAfter: 42 * time.Second,
+		},
+		{
+			name:         "server error is retried",
+			err:          &azcore.ResponseError{StatusCode: http.StatusInternalServerError},
+			transient:    true,
+			requeueAfter: reconciler.DefaultReconcilerRequeue,
+		},
+		{
+			name:         "AnotherOperationInProgress is retried",
+			err:          &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "AnotherOperationInProgress"},
+			transient:    true,
+			requeueAfter: reconciler.DefaultReconcilerRequeue,
+		},
+		{
+			name: "unknown error code is not classified",
+			err:  &azcore.ResponseError{StatusCode: http.StatusBadRequest, ErrorCode: "SomethingElse"},
+		},
+		{
+			name: "generic error is not classified",
+			err:  errors.New("InvalidParameter"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			err := ClassifyError(tc.err)
+			g.Expect(err.Error()).To(ContainSubstring(tc.err.Error()))
+			var reconcileErr ReconcileError
+			if !tc.terminal && !tc.transient {
+				g.Expect(err).To(Equal(tc.err))
+				return
+			}
+			g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+			g.Expect(reconcileErr.IsTerminal()).To(Equal(tc.terminal))
+			g.Expect(reconcileErr.IsTransient()).To(Equal(tc.transient))
+			g.Expect(reconcileErr.RequeueAfter()).To(Equal(tc.requeueAfter))
+		})
+	}
+
+	g := NewWithT(t)
+	terminal := WithTerminalError(&azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "AllocationFailed"})
+	g.Expect(ClassifyError(terminal)).To(Equal(terminal))
+	g.Expect(ClassifyError(nil)).To(Succeed())
+}
+
+func TestOperationProgress(t *testing.T) {
+	future := &infrav1.Future{
+		Type:          infrav1.PutFuture,
+		ResourceGroup: "my-rg",
+		Name:          "my-vm",
+	}
+	startedFuture := future.DeepCopy()
+	startedFuture.StartTime = &metav1.Time{Time: time.Date(2024, time.January, 1, 12, 30, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+			want: "",
+		},
+		{
+			name: "other error",
+			err:  errors.New("foo"),
+			want: "",
+		},
+		{
+			name: "operation without a start time",
+			err:  NewOperationNotDoneError(future),
+			want: ": operation PUT on my-rg/my-vm in progress",
+		},
+		{
+			name: "operation with a start time wrapped in a reconcile error",
+			err:  WithTransientError(NewOperationNotDoneError(startedFuture), time.Minute),
+			want: ": operation PUT on my-rg/my-vm in progress since 2024-01-01T12:30:00Z",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(OperationProgress(tc.err)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	responseErr := &azcore.ResponseError{
+		StatusCode: http.StatusForbidden,
+		ErrorCode:  "RequestDisallowedByPolicy",
+		RawResponse: &http.Response{
+			StatusCode: http.StatusForbidden,
+			Header:     http.Header{"X-Ms-Correlation-Request-Id": []string{"corr-id"}},
+			Request: &http.Request{
+				URL: &url.URL{Path: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"},
+			},
+		},
+	}
+	requestCorrIDErr := &azcore.ResponseError{
+		StatusCode: http.StatusConflict,
+		ErrorCode:  "QuotaExceeded",
+		RawResponse: &http.Response{
+			StatusCode: http.StatusConflict,
+			Request: &http.Request{
+				Header: http.Header{"X-Ms-Correlation-Request-Id": []string{"request-corr-id"}},
+				URL:    &url.URL{Path: "/subscriptions/123/resourceGroups/my-rg"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		err     error
+		details *ErrorDetails
+		want    string
+	}{
+		{
+			name:    "response error",
+			err:     fmt.Errorf("failed to create vnet: %w", responseErr),
+			details: &ErrorDetails{Code: "RequestDisallowedByPolicy", CorrelationID: "corr-id", Target: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"},
+			want:    "[code=RequestDisallowedByPolicy, correlationID=corr-id, target=/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet] failed to create vnet: ",
+		},
+		{
+			name:    "response error with the correlation ID of the request wrapped in a reconcile error",
+			err:     WithTransientError(requestCorrIDErr, time.Minute),
+			details: &ErrorDetails{Code: "QuotaExceeded", CorrelationID: "request-corr-id", Target: "/subscriptions/123/resourceGroups/my-rg"},
+			want:    "[code=QuotaExceeded, correlationID=request-corr-id, target=/subscriptions/123/resourceGroups/my-rg] ",
+		},
+		{
+			name:    "response error without a response",
+			err:     &azcore.ResponseError{StatusCode: http.StatusBadRequest, ErrorCode: "InvalidParameter"},
+			details: &ErrorDetails{Code: "InvalidParameter"},
+			want:    "[code=InvalidParameter] ",
+		},
+		{
+			name: "generic error",
+			err:  errors.New("something went wrong"),
+			want: "something went wrong",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			details, ok := GetErrorDetails(tc.err)
+			g.Expect(ok).To(Equal(tc.details != nil))
+			if tc.details != nil {
+				g.Expect(details).To(Equal(*tc.details))
+			}
+			g.Expect(ErrorMessage(tc.err)).To(HavePrefix(tc.want))
+		})
+	}
+}
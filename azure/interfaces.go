@@ -73,9 +73,11 @@ type NetworkDescriber interface {
 	APIServerLBName() string
 	APIServerLBPoolName() string
 	IsAPIServerPrivate() bool
+	IsControlPlaneEndpointExternallyManaged() bool
 	GetPrivateDNSZoneName() string
 	OutboundLBName(string) string
 	OutboundPoolName(string) string
+	OutboundLBResourceGroup(string) string
 }
 
 // ClusterDescriber is an interface which can get common Azure Cluster information.
@@ -91,7 +93,9 @@ type ClusterDescriber interface {
 	AdditionalTags() infrav1.Tags
 	AvailabilitySetEnabled() bool
 	CloudProviderConfigOverrides() *infrav1.CloudProviderConfigOverrides
+	CloudProviderConfigDisabled() bool
 	FailureDomains() []*string
+	ResourceNaming() *infrav1.ResourceNamingSpec
 }
 
 // AsyncStatusUpdater is an interface used to keep track of long running operations in Status that has Conditions and Futures.
@@ -109,6 +113,8 @@ type AsyncStatusUpdater interface {
 type AsyncReconciler interface {
 	DefaultedAzureCallTimeout() time.Duration
 	DefaultedAzureServiceReconcileTimeout() time.Duration
+	AzureServiceReconcileTimeout(serviceName string) time.Duration
+	AzureServiceDeleteTimeout(serviceName string) time.Duration
 	DefaultedReconcilerRequeue() time.Duration
 }
 
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsSubsystem = "capz_azure_api"
+
+var (
+	// apiRequestsTotal counts the requests sent to Azure Resource Manager.
+	apiRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "requests_total",
+		Help:      "Total number of requests sent to Azure Resource Manager, by HTTP method, resource type and status code.",
+	}, []string{"method", "resource_type", "code"})
+
+	// apiRequestDuration observes the latency of the requests sent to Azure Resource Manager.
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests sent to Azure Resource Manager, by HTTP method and resource type.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"method", "resource_type"})
+
+	// apiRequestsHeldBackTotal counts the requests not sent because their subscription is being throttled.
+	apiRequestsHeldBackTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "requests_held_back_total",
+		Help:      "Total number of requests not sent to Azure Resource Manager because their subscription is being throttled, by HTTP method and resource type.",
+	}, []string{"method", "resource_type"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequestsTotal, apiRequestDuration, apiRequestsHeldBackTotal)
+}
+
+// metricsPolicy records Prometheus metrics for each request sent to Azure Resource Manager.
+// It implements the policy.Policy interface.
+type metricsPolicy struct{}
+
+// Do sends the request and records its status code and latency.
+func (p metricsPolicy) Do(req *policy.Request) (*http.Response, error) {
+	method := req.Raw().Method
+	resource := resourceType(req.Raw().URL.Path)
+
+	start := time.Now()
+	resp, err := req.Next()
+	apiRequestDuration.WithLabelValues(method, resource).Observe(time.Since(start).Seconds())
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	apiRequestsTotal.WithLabelValues(method, resource, code).Inc()
+
+	return resp, err
+}
+
+// resourceType returns the ARM resource type addressed by a request path, for example
+// "Microsoft.Network/virtualNetworks/subnets" for a subnet. Paths outside of a resource provider
+// return "resourceGroups", "subscriptions" or "unknown".
+func resourceType(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if !strings.EqualFold(segments[i], "providers") || i+2 >= len(segments) {
+			continue
+		}
+		types := []string{segments[i+1]}
+		for j := i + 2; j < len(segments); j += 2 {
+			types = append(types, segments[j])
+		}
+		return strings.Join(types, "/")
+	}
+	for _, collection := range []string{"resourceGroups", "subscriptions"} {
+		for _, segment := range segments {
+			if strings.EqualFold(segment, collection) {
+				return collection
+			}
+		}
+	}
+	return "unknown"
+}
@@ -448,6 +448,20 @@ func (mr *MockNetworkDescriberMockRecorder) IsAPIServerPrivate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAPIServerPrivate", reflect.TypeOf((*MockNetworkDescriber)(nil).IsAPIServerPrivate))
 }
 
+// IsControlPlaneEndpointExternallyManaged mocks base method.
+func (m *MockNetworkDescriber) IsControlPlaneEndpointExternallyManaged() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsControlPlaneEndpointExternallyManaged")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsControlPlaneEndpointExternallyManaged indicates an expected call of IsControlPlaneEndpointExternallyManaged.
+func (mr *MockNetworkDescriberMockRecorder) IsControlPlaneEndpointExternallyManaged() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsControlPlaneEndpointExternallyManaged", reflect.TypeOf((*MockNetworkDescriber)(nil).IsControlPlaneEndpointExternallyManaged))
+}
+
 // IsIPv6Enabled mocks base method.
 func (m *MockNetworkDescriber) IsIPv6Enabled() bool {
 	m.ctrl.T.Helper()
@@ -504,6 +518,20 @@ func (mr *MockNetworkDescriberMockRecorder) OutboundLBName(arg0 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBName", reflect.TypeOf((*MockNetworkDescriber)(nil).OutboundLBName), arg0)
 }
 
+// OutboundLBResourceGroup mocks base method.
+func (m *MockNetworkDescriber) OutboundLBResourceGroup(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundLBResourceGroup", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundLBResourceGroup indicates an expected call of OutboundLBResourceGroup.
+func (mr *MockNetworkDescriberMockRecorder) OutboundLBResourceGroup(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBResourceGroup", reflect.TypeOf((*MockNetworkDescriber)(nil).OutboundLBResourceGroup), arg0)
+}
+
 // OutboundPoolName mocks base method.
 func (m *MockNetworkDescriber) OutboundPoolName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -679,6 +707,20 @@ func (mr *MockClusterDescriberMockRecorder) CloudEnvironment() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockClusterDescriber)(nil).CloudEnvironment))
 }
 
+// CloudProviderConfigDisabled mocks base method.
+func (m *MockClusterDescriber) CloudProviderConfigDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudProviderConfigDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CloudProviderConfigDisabled indicates an expected call of CloudProviderConfigDisabled.
+func (mr *MockClusterDescriberMockRecorder) CloudProviderConfigDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudProviderConfigDisabled", reflect.TypeOf((*MockClusterDescriber)(nil).CloudProviderConfigDisabled))
+}
+
 // CloudProviderConfigOverrides mocks base method.
 func (m *MockClusterDescriber) CloudProviderConfigOverrides() *v1beta1.CloudProviderConfigOverrides {
 	m.ctrl.T.Helper()
@@ -819,6 +861,20 @@ func (mr *MockClusterDescriberMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockClusterDescriber)(nil).ResourceGroup))
 }
 
+// ResourceNaming mocks base method.
+func (m *MockClusterDescriber) ResourceNaming() *v1beta1.ResourceNamingSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceNaming")
+	ret0, _ := ret[0].(*v1beta1.ResourceNamingSpec)
+	return ret0
+}
+
+// ResourceNaming indicates an expected call of ResourceNaming.
+func (mr *MockClusterDescriberMockRecorder) ResourceNaming() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceNaming", reflect.TypeOf((*MockClusterDescriber)(nil).ResourceNaming))
+}
+
 // SubscriptionID mocks base method.
 func (m *MockClusterDescriber) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -884,6 +940,34 @@ func (m *MockAsyncStatusUpdater) EXPECT() *MockAsyncStatusUpdaterMockRecorder {
 	return m.recorder
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockAsyncStatusUpdater) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockAsyncStatusUpdaterMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockAsyncStatusUpdater)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockAsyncStatusUpdater) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockAsyncStatusUpdaterMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockAsyncStatusUpdater)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // DefaultedAzureCallTimeout mocks base method.
 func (m *MockAsyncStatusUpdater) DefaultedAzureCallTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -1023,6 +1107,34 @@ func (m *MockAsyncReconciler) EXPECT() *MockAsyncReconcilerMockRecorder {
 	return m.recorder
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockAsyncReconciler) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockAsyncReconcilerMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockAsyncReconciler)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockAsyncReconciler) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockAsyncReconcilerMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockAsyncReconciler)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // DefaultedAzureCallTimeout mocks base method.
 func (m *MockAsyncReconciler) DefaultedAzureCallTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -1158,6 +1270,34 @@ func (mr *MockClusterScoperMockRecorder) AvailabilitySetEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetEnabled", reflect.TypeOf((*MockClusterScoper)(nil).AvailabilitySetEnabled))
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockClusterScoper) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockClusterScoperMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockClusterScoper)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockClusterScoper) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockClusterScoperMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockClusterScoper)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // BaseURI mocks base method.
 func (m *MockClusterScoper) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -1214,6 +1354,20 @@ func (mr *MockClusterScoperMockRecorder) CloudEnvironment() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockClusterScoper)(nil).CloudEnvironment))
 }
 
+// CloudProviderConfigDisabled mocks base method.
+func (m *MockClusterScoper) CloudProviderConfigDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudProviderConfigDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CloudProviderConfigDisabled indicates an expected call of CloudProviderConfigDisabled.
+func (mr *MockClusterScoperMockRecorder) CloudProviderConfigDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudProviderConfigDisabled", reflect.TypeOf((*MockClusterScoper)(nil).CloudProviderConfigDisabled))
+}
+
 // CloudProviderConfigOverrides mocks base method.
 func (m *MockClusterScoper) CloudProviderConfigOverrides() *v1beta1.CloudProviderConfigOverrides {
 	m.ctrl.T.Helper()
@@ -1464,6 +1618,20 @@ func (mr *MockClusterScoperMockRecorder) IsAPIServerPrivate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAPIServerPrivate", reflect.TypeOf((*MockClusterScoper)(nil).IsAPIServerPrivate))
 }
 
+// IsControlPlaneEndpointExternallyManaged mocks base method.
+func (m *MockClusterScoper) IsControlPlaneEndpointExternallyManaged() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsControlPlaneEndpointExternallyManaged")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsControlPlaneEndpointExternallyManaged indicates an expected call of IsControlPlaneEndpointExternallyManaged.
+func (mr *MockClusterScoperMockRecorder) IsControlPlaneEndpointExternallyManaged() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsControlPlaneEndpointExternallyManaged", reflect.TypeOf((*MockClusterScoper)(nil).IsControlPlaneEndpointExternallyManaged))
+}
+
 // IsIPv6Enabled mocks base method.
 func (m *MockClusterScoper) IsIPv6Enabled() bool {
 	m.ctrl.T.Helper()
@@ -1548,6 +1716,20 @@ func (mr *MockClusterScoperMockRecorder) OutboundLBName(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBName", reflect.TypeOf((*MockClusterScoper)(nil).OutboundLBName), arg0)
 }
 
+// OutboundLBResourceGroup mocks base method.
+func (m *MockClusterScoper) OutboundLBResourceGroup(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundLBResourceGroup", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundLBResourceGroup indicates an expected call of OutboundLBResourceGroup.
+func (mr *MockClusterScoperMockRecorder) OutboundLBResourceGroup(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBResourceGroup", reflect.TypeOf((*MockClusterScoper)(nil).OutboundLBResourceGroup), arg0)
+}
+
 // OutboundPoolName mocks base method.
 func (m *MockClusterScoper) OutboundPoolName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -1576,6 +1758,20 @@ func (mr *MockClusterScoperMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockClusterScoper)(nil).ResourceGroup))
 }
 
+// ResourceNaming mocks base method.
+func (m *MockClusterScoper) ResourceNaming() *v1beta1.ResourceNamingSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceNaming")
+	ret0, _ := ret[0].(*v1beta1.ResourceNamingSpec)
+	return ret0
+}
+
+// ResourceNaming indicates an expected call of ResourceNaming.
+func (mr *MockClusterScoperMockRecorder) ResourceNaming() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceNaming", reflect.TypeOf((*MockClusterScoper)(nil).ResourceNaming))
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockClusterScoper) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -1771,6 +1967,34 @@ func (mr *MockManagedClusterScoperMockRecorder) AvailabilitySetEnabled() *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetEnabled", reflect.TypeOf((*MockManagedClusterScoper)(nil).AvailabilitySetEnabled))
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockManagedClusterScoper) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockManagedClusterScoperMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockManagedClusterScoper)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockManagedClusterScoper) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockManagedClusterScoperMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockManagedClusterScoper)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // BaseURI mocks base method.
 func (m *MockManagedClusterScoper) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -1827,6 +2051,20 @@ func (mr *MockManagedClusterScoperMockRecorder) CloudEnvironment() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockManagedClusterScoper)(nil).CloudEnvironment))
 }
 
+// CloudProviderConfigDisabled mocks base method.
+func (m *MockManagedClusterScoper) CloudProviderConfigDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudProviderConfigDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CloudProviderConfigDisabled indicates an expected call of CloudProviderConfigDisabled.
+func (mr *MockManagedClusterScoperMockRecorder) CloudProviderConfigDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudProviderConfigDisabled", reflect.TypeOf((*MockManagedClusterScoper)(nil).CloudProviderConfigDisabled))
+}
+
 // CloudProviderConfigOverrides mocks base method.
 func (m *MockManagedClusterScoper) CloudProviderConfigOverrides() *v1beta1.CloudProviderConfigOverrides {
 	m.ctrl.T.Helper()
@@ -2009,6 +2247,20 @@ func (mr *MockManagedClusterScoperMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockManagedClusterScoper)(nil).ResourceGroup))
 }
 
+// ResourceNaming mocks base method.
+func (m *MockManagedClusterScoper) ResourceNaming() *v1beta1.ResourceNamingSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceNaming")
+	ret0, _ := ret[0].(*v1beta1.ResourceNamingSpec)
+	return ret0
+}
+
+// ResourceNaming indicates an expected call of ResourceNaming.
+func (mr *MockManagedClusterScoperMockRecorder) ResourceNaming() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceNaming", reflect.TypeOf((*MockManagedClusterScoper)(nil).ResourceNaming))
+}
+
 // SubscriptionID mocks base method.
 func (m *MockManagedClusterScoper) SubscriptionID() string {
 	m.ctrl.T.Helper()
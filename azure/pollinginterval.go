@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type pollingIntervalKey struct{}
+
+// WithPollingInterval returns a context in which the polling interval requested by Azure Resource Manager for
+// long-running operations is recorded, to be read back with PollingInterval.
+func WithPollingInterval(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pollingIntervalKey{}, new(atomic.Int64))
+}
+
+// PollingInterval returns the polling interval requested by the Retry-After header of the last successful response to
+// a request sent with the context, and whether one was requested. The context must have been created by
+// WithPollingInterval.
+func PollingInterval(ctx context.Context) (time.Duration, bool) {
+	interval, ok := ctx.Value(pollingIntervalKey{}).(*atomic.Int64)
+	if !ok || interval.Load() <= 0 {
+		return 0, false
+	}
+	return time.Duration(interval.Load()), true
+}
+
+// pollingIntervalPolicy records the Retry-After header of successful responses, which Azure Resource Manager uses to
+// tell how often a long-running operation should be polled, in the request context.
+// It implements the policy.Policy interface.
+type pollingIntervalPolicy struct{}
+
+// Do sends the request and records the polling interval of the response.
+func (p pollingIntervalPolicy) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+	if err != nil || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, err
+	}
+	if interval, ok := req.Raw().Context().Value(pollingIntervalKey{}).(*atomic.Int64); ok {
+		if d, ok := parseRetryAfter(resp.Header); ok {
+			interval.Store(int64(d))
+		}
+	}
+	return resp, err
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	. "github.com/onsi/gomega"
+)
+
+func TestPollingIntervalPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		recordInterval   bool
+		retryAfter       string
+		statusCode       int
+		expectedInterval time.Duration
+		expectedOK       bool
+	}{
+		{
+			name:             "records Retry-After of accepted operation",
+			recordInterval:   true,
+			retryAfter:       "10",
+			statusCode:       http.StatusAccepted,
+			expectedInterval: 10 * time.Second,
+			expectedOK:       true,
+		},
+		{
+			name:           "no Retry-After header",
+			recordInterval: true,
+			statusCode:     http.StatusCreated,
+		},
+		{
+			name:           "ignores Retry-After of failed request",
+			recordInterval: true,
+			retryAfter:     "30",
+			statusCode:     http.StatusConflict,
+		},
+		{
+			name:       "context without polling interval",
+			retryAfter: "10",
+			statusCode: http.StatusAccepted,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			if tc.recordInterval {
+				ctx = WithPollingInterval(ctx)
+			}
+			req, err := runtime.NewRequest(ctx, http.MethodPut, server.URL)
+			g.Expect(err).NotTo(HaveOccurred())
+			resp, err := defaultTestPipeline([]policy.Policy{pollingIntervalPolicy{}}).Do(req)
+			g.Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			interval, ok := PollingInterval(ctx)
+			g.Expect(ok).To(Equal(tc.expectedOK))
+			g.Expect(interval).To(Equal(tc.expectedInterval))
+		})
+	}
+}
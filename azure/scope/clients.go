@@ -20,14 +20,33 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+)
+
+const (
+	// credentialCacheSize is the maximum number of token credentials kept in the credential cache.
+	credentialCacheSize = 1024
+	// credentialCacheTTL is how long a token credential is reused before it is created again.
+	credentialCacheTTL = time.Hour
+)
+
+var (
+	credentialCacheOnce sync.Once
+	credentialCache     ttllru.PeekingCacher
+	errCredentialCache  error
 )
 
 // AzureClients contains all the Azure clients used by the scopes.
@@ -112,7 +131,7 @@ func (c *AzureClients) setCredentialsWithProvider(ctx context.Context, subscript
 
 	c.authType = credentialsProvider.Type()
 
-	tokenCredential, err := credentialsProvider.GetTokenCredential(ctx, c.ResourceManagerEndpoint, c.Environment.ActiveDirectoryEndpoint, c.Environment.TokenAudience)
+	tokenCredential, err := c.getTokenCredential(ctx, credentialsProvider)
 	if err != nil {
 		return err
 	}
@@ -120,6 +139,58 @@ func (c *AzureClients) setCredentialsWithProvider(ctx context.Context, subscript
 	return err
 }
 
+// getTokenCredential returns the token credential of the credentials provider, reusing the one created by a previous
+// reconcile for the same tenant, subscription and identity. Token credentials cache the tokens they acquire, so reusing
+// them avoids requesting a new token from Azure Active Directory for every reconcile.
+func (c *AzureClients) getTokenCredential(ctx context.Context, credentialsProvider CredentialsProvider) (azcore.TokenCredential, error) {
+	credentialCacheOnce.Do(func() {
+		credentialCache, errCredentialCache = ttllru.New(credentialCacheSize, credentialCacheTTL)
+	})
+	if errCredentialCache != nil {
+		return nil, errors.Wrap(errCredentialCache, "failed to create token credential cache")
+	}
+
+	var certificate, password []byte
+	if certificateProvider, ok := credentialsProvider.(clientCertificateProvider); ok && c.authType == infrav1.ServicePrincipalCertificate {
+		var err error
+		if certificate, password, err = certificateProvider.GetClientCertificate(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to get client certificate")
+		}
+	}
+	key := c.credentialKey(certificate, password)
+	// Peek doesn't extend the lifetime of the credential, so it is recreated once the TTL has elapsed.
+	if cred, _, ok := credentialCache.Peek(key); ok {
+		return cred.(azcore.TokenCredential), nil
+	}
+
+	cred, err := credentialsProvider.GetTokenCredential(ctx, c.ResourceManagerEndpoint, c.Environment.ActiveDirectoryEndpoint, c.Environment.TokenAudience)
+	if err != nil {
+		return nil, err
+	}
+	credentialCache.Add(key, cred)
+	return cred, nil
+}
+
+// clientCertificateProvider is implemented by the credentials providers of identities which may authenticate with a
+// client certificate.
+type clientCertificateProvider interface {
+	GetClientCertificate(ctx context.Context) (certificate, password []byte, err error)
+}
+
+// credentialKey returns the key of the token credential in the credential cache. It includes the client secret, and the
+// client certificate and its password if any, so that a credential is not reused once they have been changed.
+func (c *AzureClients) credentialKey(certificate, password []byte) string {
+	hasher := sha256.New()
+	for _, v := range [][]byte{[]byte(c.TenantID()), []byte(c.SubscriptionID()), []byte(c.ClientID()), []byte(c.ClientSecret()),
+		certificate, password, []byte(c.authType), []byte(c.ResourceManagerEndpoint), []byte(c.Environment.ActiveDirectoryEndpoint),
+		[]byte(c.Environment.TokenAudience)} {
+		// Values are length-prefixed as certificates and passwords may contain any byte.
+		_ = binary.Write(hasher, binary.BigEndian, uint64(len(v)))
+		_, _ = hasher.Write(v)
+	}
+	return base64.URLEncoding.EncodeToString(hasher.Sum(nil))
+}
+
 func (c *AzureClients) getSettingsFromEnvironment(environmentName string) (s auth.EnvironmentSettings, err error) {
 	s = auth.EnvironmentSettings{
 		Values: map[string]string{},
@@ -140,6 +211,7 @@ func (c *AzureClients) getSettingsFromEnvironment(environmentName string) (s aut
 	} else {
 		s.Environment, err = azureautorest.EnvironmentFromName(v)
 	}
+	azure.CloudEndpointOverrides.ApplyToEnvironment(&s.Environment)
 	if s.Values["AZURE_AD_RESOURCE"] == "" {
 		s.Values["AZURE_AD_RESOURCE"] = s.Environment.ResourceManagerEndpoint
 	}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+type fakeTokenCredential struct {
+	azcore.TokenCredential
+	id int
+}
+
+type fakeCredentialsProvider struct {
+	tenantID     string
+	clientID     string
+	clientSecret string
+	calls        int
+}
+
+func (p *fakeCredentialsProvider) GetClientID() string { return p.clientID }
+
+func (p *fakeCredentialsProvider) GetClientSecret(_ context.Context) (string, error) {
+	return p.clientSecret, nil
+}
+
+func (p *fakeCredentialsProvider) GetTenantID() string { return p.tenantID }
+
+func (p *fakeCredentialsProvider) GetTokenCredential(_ context.Context, _, _, _ string) (azcore.TokenCredential, error) {
+	p.calls++
+	return &fakeTokenCredential{id: p.calls}, nil
+}
+
+func (p *fakeCredentialsProvider) Type() infrav1.IdentityType { return infrav1.ServicePrincipal }
+
+func TestSetCredentialsWithProviderCachesTokenCredential(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := &fakeCredentialsProvider{tenantID: "tenant", clientID: "client", clientSecret: "secret"}
+
+	first := &AzureClients{}
+	g.Expect(first.setCredentialsWithProvider(context.Background(), "subscription", "", provider)).To(Succeed())
+	second := &AzureClients{}
+	g.Expect(second.setCredentialsWithProvider(context.Background(), "subscription", "", provider)).To(Succeed())
+	g.Expect(provider.calls).To(Equal(1))
+	g.Expect(second.Token()).To(BeIdenticalTo(first.Token()))
+
+	otherSubscription := &AzureClients{}
+	g.Expect(otherSubscription.setCredentialsWithProvider(context.Background(), "other-subscription", "", provider)).To(Succeed())
+	g.Expect(provider.calls).To(Equal(2))
+
+	provider.clientSecret = "rotated-secret"
+	rotated := &AzureClients{}
+	g.Expect(rotated.setCredentialsWithProvider(context.Background(), "subscription", "", provider)).To(Succeed())
+	g.Expect(provider.calls).To(Equal(3))
+	g.Expect(rotated.Token()).NotTo(BeIdenticalTo(first.Token()))
+}
+
+type fakeCertificateCredentialsProvider struct {
+	fakeCredentialsProvider
+	certificate []byte
+	password    []byte
+}
+
+func (p *fakeCertificateCredentialsProvider) GetClientCertificate(_ context.Context) (certificate, password []byte, err error) {
+	return p.certificate, p.password, nil
+}
+
+func (p *fakeCertificateCredentialsProvider) Type() infrav1.IdentityType {
+	return infrav1.ServicePrincipalCertificate
+}
+
+func TestSetCredentialsWithProviderRecreatesTokenCredentialForNewCertificate(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := &fakeCertificateCredentialsProvider{
+		fakeCredentialsProvider: fakeCredentialsProvider{tenantID: "certificate-tenant", clientID: "client"},
+		certificate:             []byte("certificate"),
+		password:                []byte("password"),
+	}
+
+	first := &AzureClients{}
+	g.Expect(first.setCredentialsWithProvider(context.Background(), "subscription", "", provider)).To(Succeed())
+	second := &AzureClients{}
+	g.Expect(second.setCredentialsWithProvider(context.Background(), "subscription", "", provider)).To(Succeed())
+	g.Expect(provider.calls).To(Equal(1))
+	g.Expect(second.Token()).To(BeIdenticalTo(first.Token()))
+
+	provider.certificate = []byte("rotated-certificate")
+	rotated := &AzureClients{}
+	g.Expect(rotated.setCredentialsWithProvider(context.Background(), "subscription", "", provider)).To(Succeed())
+	g.Expect(provider.calls).To(Equal(2))
+	g.Expect(rotated.Token()).NotTo(BeIdenticalTo(first.Token()))
+
+	provider.password = []byte("rotated-password")
+	rotatedPassword := &AzureClients{}
+	g.Expect(rotatedPassword.setCredentialsWithProvider(context.Background(), "subscription", "", provider)).To(Succeed())
+	g.Expect(provider.calls).To(Equal(3))
+	g.Expect(rotatedPassword.Token()).NotTo(BeIdenticalTo(rotated.Token()))
+}
@@ -21,10 +21,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
+	asonetworkv1api20180501 "github.com/Azure/azure-service-operator/v2/api/network/v1api20180501"
 	asonetworkv1api20201101 "github.com/Azure/azure-service-operator/v2/api/network/v1api20201101"
 	asonetworkv1api20220701 "github.com/Azure/azure-service-operator/v2/api/network/v1api20220701"
 	asoresourcesv1 "github.com/Azure/azure-service-operator/v2/api/resources/v1api20200601"
@@ -34,16 +37,22 @@ import (
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dnsrecords"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routeservers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworkgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
@@ -111,6 +120,8 @@ type ClusterScope struct {
 	Client      client.Client
 	patchHelper *patch.Helper
 	cache       *ClusterCache
+	// mu guards the AzureCluster status and annotations against concurrent updates by services reconciled concurrently.
+	mu sync.Mutex
 
 	AzureClients
 	Cluster      *clusterv1.Cluster
@@ -161,12 +172,14 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 					IsIPv6:           false, // Set to default value
 					Location:         s.Location(),
 					ExtendedLocation: s.ExtendedLocation(),
-					FailureDomains:   s.FailureDomains(),
-					AdditionalTags:   s.AdditionalTags(),
+					FailureDomains:   s.publicIPZones(ip.PublicIP),
+					AdditionalTags:   s.resourceTags(ip.PublicIP.AdditionalTags),
+					PublicIPPrefixID: ip.PublicIP.PublicIPPrefixID,
 				})
 			}
 		}
-	} else {
+	} else if !s.IsControlPlaneEndpointExternallyManaged() && s.APIServerLB().ResourceGroup == "" && s.APIServerPublicIP().ResourceGroup == "" {
+		// An API server public IP in a separate resource group, or of an existing load balancer, is brought by the user and never reconciled.
 		controlPlaneOutboundIPSpecs = []azure.ResourceSpecGetter{
 			&publicips.PublicIPSpec{
 				Name:             s.APIServerPublicIP().Name,
@@ -176,16 +189,17 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 				ClusterName:      s.ClusterName(),
 				Location:         s.Location(),
 				ExtendedLocation: s.ExtendedLocation(),
-				FailureDomains:   s.FailureDomains(),
-				AdditionalTags:   s.AdditionalTags(),
+				FailureDomains:   s.publicIPZones(s.APIServerPublicIP()),
+				AdditionalTags:   s.resourceTags(s.APIServerPublicIP().AdditionalTags),
 				IPTags:           s.APIServerPublicIP().IPTags,
+				PublicIPPrefixID: s.APIServerPublicIP().PublicIPPrefixID,
 			},
 		}
 	}
 	publicIPSpecs = append(publicIPSpecs, controlPlaneOutboundIPSpecs...)
 
 	// Public IP specs for node outbound lb
-	if s.NodeOutboundLB() != nil {
+	if s.NodeOutboundLB() != nil && s.NodeOutboundLB().ResourceGroup == "" {
 		for _, ip := range s.NodeOutboundLB().FrontendIPs {
 			publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
 				Name:             ip.PublicIP.Name,
@@ -195,8 +209,9 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 				IsIPv6:           false, // Set to default value
 				Location:         s.Location(),
 				ExtendedLocation: s.ExtendedLocation(),
-				FailureDomains:   s.FailureDomains(),
-				AdditionalTags:   s.AdditionalTags(),
+				FailureDomains:   s.publicIPZones(ip.PublicIP),
+				AdditionalTags:   s.resourceTags(ip.PublicIP.AdditionalTags),
+				PublicIPPrefixID: ip.PublicIP.PublicIPPrefixID,
 			})
 		}
 	}
@@ -206,15 +221,16 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	for _, subnet := range s.NodeSubnets() {
 		if subnet.IsNatGatewayEnabled() {
 			nodeNatGatewayIPSpecs = append(nodeNatGatewayIPSpecs, &publicips.PublicIPSpec{
-				Name:           subnet.NatGateway.NatGatewayIP.Name,
-				ResourceGroup:  s.ResourceGroup(),
-				DNSName:        subnet.NatGateway.NatGatewayIP.DNSName,
-				IsIPv6:         false, // Public IP is IPv4 by default
-				ClusterName:    s.ClusterName(),
-				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
-				AdditionalTags: s.AdditionalTags(),
-				IPTags:         subnet.NatGateway.NatGatewayIP.IPTags,
+				Name:             subnet.NatGateway.NatGatewayIP.Name,
+				ResourceGroup:    s.ResourceGroup(),
+				DNSName:          subnet.NatGateway.NatGatewayIP.DNSName,
+				IsIPv6:           false, // Public IP is IPv4 by default
+				ClusterName:      s.ClusterName(),
+				Location:         s.Location(),
+				FailureDomains:   s.publicIPZones(&subnet.NatGateway.NatGatewayIP),
+				AdditionalTags:   s.resourceTags(subnet.NatGateway.NatGatewayIP.AdditionalTags),
+				IPTags:           subnet.NatGateway.NatGatewayIP.IPTags,
+				PublicIPPrefixID: subnet.NatGateway.NatGatewayIP.PublicIPPrefixID,
 			})
 		}
 		publicIPSpecs = append(publicIPSpecs, nodeNatGatewayIPSpecs...)
@@ -229,20 +245,53 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 			IsIPv6:         false, // Public IP is IPv4 by default
 			ClusterName:    s.ClusterName(),
 			Location:       s.Location(),
-			FailureDomains: s.FailureDomains(),
-			AdditionalTags: s.AdditionalTags(),
+			FailureDomains: s.publicIPZones(&azureBastion.PublicIP),
+			AdditionalTags: s.resourceTags(azureBastion.PublicIP.AdditionalTags),
 			IPTags:         azureBastion.PublicIP.IPTags,
 		}
 		publicIPSpecs = append(publicIPSpecs, azureBastionPublicIP)
 	}
 
+	if gateway := s.AzureCluster.Spec.NetworkSpec.VirtualNetworkGateway; gateway != nil {
+		// public IP for the virtual network gateway.
+		publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			Name:           gateway.PublicIP.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			DNSName:        gateway.PublicIP.DNSName,
+			IsIPv6:         false, // Public IP is IPv4 by default
+			ClusterName:    s.ClusterName(),
+			Location:       s.Location(),
+			FailureDomains: s.publicIPZones(&gateway.PublicIP),
+			AdditionalTags: s.resourceTags(gateway.PublicIP.AdditionalTags),
+			IPTags:         gateway.PublicIP.IPTags,
+		})
+	}
+
+	if routeServer := s.AzureCluster.Spec.NetworkSpec.RouteServer; routeServer != nil {
+		// public IP for the Route Server.
+		publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			Name:           routeServer.PublicIP.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			DNSName:        routeServer.PublicIP.DNSName,
+			IsIPv6:         false, // Public IP is IPv4 by default
+			ClusterName:    s.ClusterName(),
+			Location:       s.Location(),
+			FailureDomains: s.publicIPZones(&routeServer.PublicIP),
+			AdditionalTags: s.resourceTags(routeServer.PublicIP.AdditionalTags),
+			IPTags:         routeServer.PublicIP.IPTags,
+		})
+	}
+
 	return publicIPSpecs
 }
 
 // LBSpecs returns the load balancer specs.
+// Existing load balancers from another resource group, and the API server load balancer of a cluster with an externally
+// managed control plane endpoint, are not reconciled.
 func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
-	specs := []azure.ResourceSpecGetter{
-		&loadbalancers.LBSpec{
+	var specs []azure.ResourceSpecGetter
+	if !s.IsControlPlaneEndpointExternallyManaged() && s.APIServerLB().ResourceGroup == "" {
+		specs = append(specs, &loadbalancers.LBSpec{
 			// API Server LB
 			Name:                 s.APIServerLB().Name,
 			ResourceGroup:        s.ResourceGroup(),
@@ -260,12 +309,12 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			Role:                 infrav1.APIServerRole,
 			BackendPoolName:      s.APIServerLB().BackendPool.Name,
 			IdleTimeoutInMinutes: s.APIServerLB().IdleTimeoutInMinutes,
-			AdditionalTags:       s.AdditionalTags(),
-		},
+			AdditionalTags:       s.resourceTags(s.APIServerLB().AdditionalTags),
+		})
 	}
 
 	// Node outbound LB
-	if s.NodeOutboundLB() != nil {
+	if s.NodeOutboundLB() != nil && s.NodeOutboundLB().ResourceGroup == "" {
 		specs = append(specs, &loadbalancers.LBSpec{
 			Name:                 s.NodeOutboundLB().Name,
 			ResourceGroup:        s.ResourceGroup(),
@@ -281,7 +330,7 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			BackendPoolName:      s.NodeOutboundLB().BackendPool.Name,
 			IdleTimeoutInMinutes: s.NodeOutboundLB().IdleTimeoutInMinutes,
 			Role:                 infrav1.NodeOutboundRole,
-			AdditionalTags:       s.AdditionalTags(),
+			AdditionalTags:       s.resourceTags(s.NodeOutboundLB().AdditionalTags),
 		})
 	}
 
@@ -302,7 +351,7 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			BackendPoolName:      s.ControlPlaneOutboundLB().BackendPool.Name,
 			IdleTimeoutInMinutes: s.ControlPlaneOutboundLB().IdleTimeoutInMinutes,
 			Role:                 infrav1.ControlPlaneOutboundRole,
-			AdditionalTags:       s.AdditionalTags(),
+			AdditionalTags:       s.resourceTags(s.ControlPlaneOutboundLB().AdditionalTags),
 		})
 	}
 
@@ -312,15 +361,29 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 // RouteTableSpecs returns the subnet route tables.
 func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
+	// subnets may share a route table, in which case their routes are merged into a single spec.
+	routeTables := make(map[string]*routetables.RouteTableSpec)
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
-		if subnet.RouteTable.Name != "" {
-			specs = append(specs, &routetables.RouteTableSpec{
+		if subnet.RouteTable.Name == "" {
+			continue
+		}
+		spec, ok := routeTables[subnet.RouteTable.Name]
+		if !ok {
+			spec = &routetables.RouteTableSpec{
 				Name:           subnet.RouteTable.Name,
 				Location:       s.Location(),
 				ResourceGroup:  s.Vnet().ResourceGroup,
 				ClusterName:    s.ClusterName(),
 				AdditionalTags: s.AdditionalTags(),
-			})
+			}
+			routeTables[subnet.RouteTable.Name] = spec
+			specs = append(specs, spec)
+		}
+		spec.AdditionalTags.Merge(subnet.RouteTable.AdditionalTags)
+		for _, route := range subnet.RouteTable.Routes {
+			if !slices.ContainsFunc(spec.Routes, func(r infrav1.Route) bool { return r.Name == route.Name }) {
+				spec.Routes = append(spec.Routes, route)
+			}
 		}
 	}
 
@@ -359,22 +422,57 @@ func (s *ClusterScope) NatGatewaySpecs() []azure.ASOResourceSpecGetter[*asonetwo
 
 // NSGSpecs returns the security group specs.
 func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
-	nsgspecs := make([]azure.ResourceSpecGetter, len(s.AzureCluster.Spec.NetworkSpec.Subnets))
-	for i, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
-		nsgspecs[i] = &securitygroups.NSGSpec{
+	nsgspecs := make([]azure.ResourceSpecGetter, 0, len(s.AzureCluster.Spec.NetworkSpec.Subnets))
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		// Security groups from another resource group are brought by the user and never reconciled.
+		if subnet.SecurityGroup.ResourceGroup != "" {
+			continue
+		}
+		nsgspecs = append(nsgspecs, &securitygroups.NSGSpec{
 			Name:                     subnet.SecurityGroup.Name,
-			SecurityRules:            subnet.SecurityGroup.SecurityRules,
+			SecurityRules:            s.resolveApplicationSecurityGroups(subnet.SecurityGroup.SecurityRules),
 			ResourceGroup:            s.Vnet().ResourceGroup,
 			Location:                 s.Location(),
 			ClusterName:              s.ClusterName(),
-			AdditionalTags:           s.AdditionalTags(),
+			AdditionalTags:           s.resourceTags(subnet.SecurityGroup.AdditionalTags),
 			LastAppliedSecurityRules: s.getLastAppliedSecurityRules(subnet.SecurityGroup.Name),
-		}
+		})
 	}
 
 	return nsgspecs
 }
 
+// resolveApplicationSecurityGroups returns a copy of the security rules in which the application security groups
+// referenced by name are replaced with their resource IDs in the cluster resource group.
+func (s *ClusterScope) resolveApplicationSecurityGroups(rules infrav1.SecurityRules) infrav1.SecurityRules {
+	if rules == nil {
+		return nil
+	}
+	resolved := make(infrav1.SecurityRules, len(rules))
+	for i, rule := range rules {
+		rule.SourceApplicationSecurityGroups = azure.ApplicationSecurityGroupIDs(s.SubscriptionID(), s.ResourceGroup(), rule.SourceApplicationSecurityGroups)
+		rule.DestinationApplicationSecurityGroups = azure.ApplicationSecurityGroupIDs(s.SubscriptionID(), s.ResourceGroup(), rule.DestinationApplicationSecurityGroups)
+		resolved[i] = rule
+	}
+	return resolved
+}
+
+// ApplicationSecurityGroupSpecs returns the application security group specs.
+func (s *ClusterScope) ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter {
+	asgs := s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups
+	specs := make([]azure.ResourceSpecGetter, 0, len(asgs))
+	for _, asg := range asgs {
+		specs = append(specs, &applicationsecuritygroups.ApplicationSecurityGroupSpec{
+			Name:           asg.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+	return specs
+}
+
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1api20201101.VirtualNetworksSubnet] {
 	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
@@ -397,8 +495,31 @@ func (s *ClusterScope) SubnetSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1
 			SecurityGroupName: subnet.SecurityGroup.Name,
 			NatGatewayName:    subnet.NatGateway.Name,
 			ServiceEndpoints:  subnet.ServiceEndpoints,
+			Delegations:       subnet.Delegations,
+
+			SecurityGroupResourceGroup: subnet.SecurityGroup.ResourceGroup,
+
+			DisablePrivateLinkServiceNetworkPolicies: s.isAPIServerPrivateLinkServiceSubnet(subnet.Name),
 		}
 		subnetSpecs = append(subnetSpecs, subnetSpec)
+
+		// The dedicated pod subnet shares the security group, route table and NAT gateway of its subnet.
+		if subnet.PodSubnet != nil {
+			subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+				Name:              subnet.PodSubnet.Name,
+				ResourceGroup:     s.ResourceGroup(),
+				SubscriptionID:    s.SubscriptionID(),
+				CIDRs:             subnet.PodSubnet.CIDRBlocks,
+				VNetName:          s.Vnet().Name,
+				VNetResourceGroup: s.Vnet().ResourceGroup,
+				IsVNetManaged:     s.IsVnetManaged(),
+				RouteTableName:    subnet.RouteTable.Name,
+				SecurityGroupName: subnet.SecurityGroup.Name,
+				NatGatewayName:    subnet.NatGateway.Name,
+
+				SecurityGroupResourceGroup: subnet.SecurityGroup.ResourceGroup,
+			})
+		}
 	}
 
 	if s.IsAzureBastionEnabled() {
@@ -417,9 +538,133 @@ func (s *ClusterScope) SubnetSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1
 		})
 	}
 
+	// Azure doesn't support security groups on the subnet of virtual network gateways.
+	if gateway := s.AzureCluster.Spec.NetworkSpec.VirtualNetworkGateway; gateway != nil {
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              gateway.Subnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             gateway.Subnet.CIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+			RouteTableName:    gateway.Subnet.RouteTable.Name,
+		})
+	}
+
+	// Azure supports neither security groups nor route tables on the subnet of Route Servers.
+	if routeServer := s.AzureCluster.Spec.NetworkSpec.RouteServer; routeServer != nil {
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              routeServer.Subnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             routeServer.Subnet.CIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+		})
+	}
+
 	return subnetSpecs
 }
 
+// VirtualNetworkGatewaySpec returns the virtual network gateway spec, or nil if the cluster has no virtual network
+// gateway. Azure requires the gateway to be in the resource group of the virtual network.
+func (s *ClusterScope) VirtualNetworkGatewaySpec() azure.ResourceSpecGetter {
+	gateway := s.AzureCluster.Spec.NetworkSpec.VirtualNetworkGateway
+	if gateway == nil {
+		return nil
+	}
+	return &virtualnetworkgateways.VirtualNetworkGatewaySpec{
+		Name:           gateway.Name,
+		ResourceGroup:  s.Vnet().ResourceGroup,
+		Location:       s.Location(),
+		ClusterName:    s.ClusterName(),
+		Type:           gateway.Type,
+		SKU:            gateway.SKU,
+		VPNType:        gateway.VPNType,
+		SubnetID:       azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, gateway.Subnet.Name),
+		PublicIPID:     azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), gateway.PublicIP.Name),
+		AdditionalTags: s.AdditionalTags(),
+	}
+}
+
+// RouteServerSpecs returns the specs of the Route Server, its IP configuration and its BGP connections, or nil specs if
+// the cluster has no Route Server. Azure requires the Route Server to be in the resource group of the virtual network.
+func (s *ClusterScope) RouteServerSpecs() (routeServerSpec, ipConfigSpec azure.ResourceSpecGetter, bgpConnectionSpecs []azure.ResourceSpecGetter) {
+	routeServer := s.AzureCluster.Spec.NetworkSpec.RouteServer
+	if routeServer == nil {
+		return nil, nil, nil
+	}
+
+	routeServerSpec = &routeservers.RouteServerSpec{
+		Name:                  routeServer.Name,
+		ResourceGroup:         s.Vnet().ResourceGroup,
+		Location:              s.Location(),
+		ClusterName:           s.ClusterName(),
+		BranchToBranchTraffic: routeServer.BranchToBranchTraffic,
+		AdditionalTags:        s.AdditionalTags(),
+	}
+	ipConfigSpec = &routeservers.IPConfigSpec{
+		Name:            "ipconfig1",
+		RouteServerName: routeServer.Name,
+		ResourceGroup:   s.Vnet().ResourceGroup,
+		SubnetID:        azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, routeServer.Subnet.Name),
+		PublicIPID:      azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), routeServer.PublicIP.Name),
+	}
+	bgpConnectionSpecs = make([]azure.ResourceSpecGetter, 0, len(routeServer.BGPPeers))
+	for _, peer := range routeServer.BGPPeers {
+		bgpConnectionSpecs = append(bgpConnectionSpecs, &routeservers.BGPConnectionSpec{
+			Name:            peer.Name,
+			RouteServerName: routeServer.Name,
+			ResourceGroup:   s.Vnet().ResourceGroup,
+			PeerIP:          peer.PeerIP,
+			PeerASN:         peer.PeerASN,
+		})
+	}
+
+	return routeServerSpec, ipConfigSpec, bgpConnectionSpecs
+}
+
+// PrivateLinkServiceSpec returns the spec of the Private Link Service of the API server load balancer, or nil if the
+// cluster has no API server Private Link Service.
+func (s *ClusterScope) PrivateLinkServiceSpec() azure.ResourceSpecGetter {
+	pls := s.AzureCluster.Spec.NetworkSpec.APIServerPrivateLinkService
+	if pls == nil || s.IsControlPlaneEndpointExternallyManaged() || len(s.APIServerLB().FrontendIPs) == 0 {
+		return nil
+	}
+	lbResourceGroup := s.ResourceGroup()
+	if s.APIServerLB().ResourceGroup != "" {
+		lbResourceGroup = s.APIServerLB().ResourceGroup
+	}
+	return &privatelinkservices.PrivateLinkServiceSpec{
+		Name:                      pls.Name,
+		ResourceGroup:             s.ResourceGroup(),
+		Location:                  s.Location(),
+		ClusterName:               s.ClusterName(),
+		FrontendIPConfigID:        azure.FrontendIPConfigID(s.SubscriptionID(), lbResourceGroup, s.APIServerLB().Name, s.APIServerLB().FrontendIPs[0].Name),
+		SubnetID:                  azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, pls.SubnetName),
+		AllowedSubscriptions:      pls.AllowedSubscriptions,
+		AutoApprovedSubscriptions: pls.AutoApprovedSubscriptions,
+		AdditionalTags:            s.AdditionalTags(),
+	}
+}
+
+// isAPIServerPrivateLinkServiceSubnet returns true if the API server Private Link Service allocates its IP addresses
+// from the subnet.
+func (s *ClusterScope) isAPIServerPrivateLinkServiceSubnet(subnetName string) bool {
+	pls := s.AzureCluster.Spec.NetworkSpec.APIServerPrivateLinkService
+	return pls != nil && pls.SubnetName == subnetName
+}
+
+// SetAPIServerPrivateLinkServiceAlias records the alias of the API server Private Link Service in the AzureCluster status.
+func (s *ClusterScope) SetAPIServerPrivateLinkServiceAlias(alias string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.AzureCluster.Status.APIServerPrivateLinkServiceAlias = alias
+}
+
 // GroupSpecs returns the resource group spec.
 func (s *ClusterScope) GroupSpecs() []azure.ASOResourceSpecGetter[*asoresourcesv1.ResourceGroup] {
 	specs := []azure.ASOResourceSpecGetter[*asoresourcesv1.ResourceGroup]{
@@ -429,6 +674,7 @@ func (s *ClusterScope) GroupSpecs() []azure.ASOResourceSpecGetter[*asoresourcesv
 			Location:       s.Location(),
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
+			Adopt:          s.AdoptResources(),
 		},
 	}
 	if s.Vnet().ResourceGroup != "" && s.Vnet().ResourceGroup != s.ResourceGroup() {
@@ -438,11 +684,46 @@ func (s *ClusterScope) GroupSpecs() []azure.ASOResourceSpecGetter[*asoresourcesv
 			Location:       s.Location(),
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
+			Adopt:          s.AdoptResources(),
+		})
+	}
+	if nodeRG := s.NodeResourceGroup(); nodeRG != s.ResourceGroup() && nodeRG != s.Vnet().ResourceGroup {
+		specs = append(specs, &groups.GroupSpec{
+			Name:           azure.GetNormalizedKubernetesName(nodeRG),
+			AzureName:      nodeRG,
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+			Adopt:          s.AdoptResources(),
 		})
 	}
 	return specs
 }
 
+// FlowLogSpecs returns the flow log spec of the virtual network managed by CAPZ. Network Watcher flow logs are enabled
+// on the virtual network rather than on its security groups, as Azure no longer allows creating NSG flow logs.
+func (s *ClusterScope) FlowLogSpecs() []azure.ResourceSpecGetter {
+	flowLogs := s.AzureCluster.Spec.NetworkSpec.FlowLogs
+	if flowLogs == nil {
+		return nil
+	}
+
+	return []azure.ResourceSpecGetter{
+		&flowlogs.FlowLogSpec{
+			Name:                        azure.GenerateFlowLogName(s.Vnet().ResourceGroup, s.Vnet().Name),
+			NetworkWatcherName:          flowLogs.NetworkWatcherName,
+			NetworkWatcherResourceGroup: flowLogs.NetworkWatcherResourceGroup,
+			Location:                    s.Location(),
+			ClusterName:                 s.ClusterName(),
+			TargetResourceID:            azure.VNetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name),
+			StorageAccountID:            flowLogs.StorageAccountID,
+			RetentionDays:               flowLogs.RetentionDays,
+			TrafficAnalytics:            flowLogs.TrafficAnalytics,
+			AdditionalTags:              s.AdditionalTags(),
+		},
+	}
+}
+
 // VnetPeeringSpecs returns the virtual network peering specs.
 func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	peeringSpecs := make([]azure.ResourceSpecGetter, 2*len(s.Vnet().Peerings))
@@ -487,13 +768,14 @@ func (s *ClusterScope) VNetSpec() azure.ASOResourceSpecGetter[*asonetworkv1api20
 		ExtendedLocation: s.ExtendedLocation(),
 		Location:         s.Location(),
 		ClusterName:      s.ClusterName(),
-		AdditionalTags:   s.AdditionalTags(),
+		AdditionalTags:   s.resourceTags(s.Vnet().AdditionalTags),
+		Adopt:            s.AdoptResources(),
 	}
 }
 
 // PrivateDNSSpec returns the private dns zone spec.
 func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linkSpec, recordSpec []azure.ResourceSpecGetter) {
-	if s.IsAPIServerPrivate() {
+	if s.IsAPIServerPrivate() && !s.IsControlPlaneEndpointExternallyManaged() {
 		zone := privatedns.ZoneSpec{
 			Name:           s.GetPrivateDNSZoneName(),
 			ResourceGroup:  s.ResourceGroup(),
@@ -651,8 +933,14 @@ func (s *ClusterScope) SetNatGatewayIDInSubnets(name string, id string) {
 	}
 }
 
-// UpdateSubnetCIDRs updates the subnet CIDRs for the subnet with the same name.
+// UpdateSubnetCIDRs updates the subnet CIDRs for the subnet or the dedicated pod subnet with the same name.
 func (s *ClusterScope) UpdateSubnetCIDRs(name string, cidrBlocks []string) {
+	for i, sn := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		if sn.PodSubnetName() == name {
+			s.AzureCluster.Spec.NetworkSpec.Subnets[i].PodSubnet.CIDRBlocks = cidrBlocks
+			return
+		}
+	}
 	subnetSpecInfra := s.Subnet(name)
 	subnetSpecInfra.CIDRBlocks = cidrBlocks
 	s.SetSubnet(subnetSpecInfra)
@@ -696,6 +984,12 @@ func (s *ClusterScope) IsAPIServerPrivate() bool {
 	return s.APIServerLB().Type == infrav1.Internal
 }
 
+// IsControlPlaneEndpointExternallyManaged returns true if the control plane endpoint is served by infrastructure
+// managed outside of CAPZ, in which case the API server load balancer is not reconciled.
+func (s *ClusterScope) IsControlPlaneEndpointExternallyManaged() bool {
+	return s.AzureCluster.Spec.ExternallyManagedControlPlaneEndpoint
+}
+
 // APIServerPublicIP returns the API Server public IP.
 func (s *ClusterScope) APIServerPublicIP() *infrav1.PublicIPSpec {
 	return s.APIServerLB().FrontendIPs[0].PublicIP
@@ -724,7 +1018,7 @@ func (s *ClusterScope) outboundLB(role string) *infrav1.LoadBalancerSpec {
 	if role == infrav1.Node {
 		return s.NodeOutboundLB()
 	}
-	if s.IsAPIServerPrivate() {
+	if s.IsAPIServerPrivate() || s.IsControlPlaneEndpointExternallyManaged() {
 		return s.ControlPlaneOutboundLB()
 	}
 	return s.APIServerLB()
@@ -748,14 +1042,29 @@ func (s *ClusterScope) OutboundPoolName(role string) string {
 	return lb.BackendPool.Name
 }
 
+// OutboundLBResourceGroup returns the resource group of the outbound LB.
+func (s *ClusterScope) OutboundLBResourceGroup(role string) string {
+	lb := s.outboundLB(role)
+	if lb == nil {
+		return ""
+	}
+	if lb.ResourceGroup == "" {
+		return s.ResourceGroup()
+	}
+	return lb.ResourceGroup
+}
+
 // ResourceGroup returns the cluster resource group.
 func (s *ClusterScope) ResourceGroup() string {
 	return s.AzureCluster.Spec.ResourceGroup
 }
 
 // NodeResourceGroup returns the resource group where nodes live.
-// For AzureClusters this is the same as the cluster RG.
+// For AzureClusters this is the cluster RG unless a separate node resource group is set.
 func (s *ClusterScope) NodeResourceGroup() string {
+	if s.AzureCluster.Spec.NodeResourceGroup != "" {
+		return s.AzureCluster.Spec.NodeResourceGroup
+	}
 	return s.ResourceGroup()
 }
 
@@ -784,6 +1093,16 @@ func (s *ClusterScope) CloudProviderConfigOverrides() *infrav1.CloudProviderConf
 	return s.AzureCluster.Spec.CloudProviderConfigOverrides
 }
 
+// CloudProviderConfigDisabled returns whether the generation of the cloud provider config secrets is disabled.
+func (s *ClusterScope) CloudProviderConfigDisabled() bool {
+	return s.AzureCluster.Spec.DisableCloudProviderConfig
+}
+
+// ResourceNaming returns the naming templates of the resources of the cluster.
+func (s *ClusterScope) ResourceNaming() *infrav1.ResourceNamingSpec {
+	return s.AzureCluster.Spec.ResourceNaming
+}
+
 // ExtendedLocationName returns ExtendedLocation name for the cluster.
 func (s *ClusterScope) ExtendedLocationName() string {
 	if s.ExtendedLocation() == nil {
@@ -854,6 +1173,9 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			infrav1.NATGatewaysReadyCondition,
 			infrav1.LoadBalancersReadyCondition,
 			infrav1.BastionHostReadyCondition,
+			infrav1.VirtualNetworkGatewayReadyCondition,
+			infrav1.PrivateLinkServiceReadyCondition,
+			infrav1.RouteServerReadyCondition,
 			infrav1.VNetReadyCondition,
 			infrav1.SubnetsReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
@@ -861,6 +1183,10 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			infrav1.PrivateDNSLinkReadyCondition,
 			infrav1.PrivateDNSRecordReadyCondition,
 			infrav1.PrivateEndpointsReadyCondition,
+			infrav1.PublicIPsReadyCondition,
+			infrav1.FlowLogsReadyCondition,
+			infrav1.DNSRecordsReadyCondition,
+			infrav1.IdentityPermissionsReadyCondition,
 		}})
 }
 
@@ -878,6 +1204,14 @@ func (s *ClusterScope) AdditionalTags() infrav1.Tags {
 	return tags
 }
 
+// resourceTags merges the AdditionalTags of the scope's AzureCluster with the tags of a resource. If the same key is
+// present in both, the value from the resource takes precedence.
+func (s *ClusterScope) resourceTags(resourceTags infrav1.Tags) infrav1.Tags {
+	tags := s.AdditionalTags()
+	tags.Merge(resourceTags)
+	return tags
+}
+
 // APIServerPort returns the APIServerPort to use when creating the load balancer.
 func (s *ClusterScope) APIServerPort() int32 {
 	if s.Cluster.Spec.ClusterNetwork != nil && s.Cluster.Spec.ClusterNetwork.APIServerPort != nil {
@@ -908,6 +1242,18 @@ func (s *ClusterScope) SetFailureDomain(id string, spec clusterv1.FailureDomainS
 	s.AzureCluster.Status.FailureDomains[id] = spec
 }
 
+// publicIPZones returns the availability zones of a public IP, defaulting to the failure domains of the cluster.
+func (s *ClusterScope) publicIPZones(ip *infrav1.PublicIPSpec) []*string {
+	if ip == nil || len(ip.Zones) == 0 {
+		return s.FailureDomains()
+	}
+	zones := make([]*string, len(ip.Zones))
+	for i, zone := range ip.Zones {
+		zones[i] = ptr.To(zone)
+	}
+	return zones
+}
+
 // FailureDomains returns the failure domains for the cluster.
 func (s *ClusterScope) FailureDomains() []*string {
 	fds := make([]*string, len(s.AzureCluster.Status.FailureDomains))
@@ -928,10 +1274,11 @@ func (s *ClusterScope) FailureDomains() []*string {
 // SetControlPlaneSecurityRules sets the default security rules of the control plane subnet.
 // Note that this is not done in a webhook as it requires a valid Cluster object to exist to get the API Server port.
 func (s *ClusterScope) SetControlPlaneSecurityRules() {
-	if s.ControlPlaneSubnet().SecurityGroup.SecurityRules == nil {
+	if s.ControlPlaneSubnet().SecurityGroup.SecurityRules == nil && s.ControlPlaneSubnet().SecurityGroup.ResourceGroup == "" {
 		subnet := s.ControlPlaneSubnet()
-		subnet.SecurityGroup.SecurityRules = infrav1.SecurityRules{
-			infrav1.SecurityRule{
+		rules := infrav1.SecurityRules{}
+		if sshRule := subnet.SecurityGroup.DefaultSSHRule; sshRule == nil || !sshRule.Disabled {
+			rule := infrav1.SecurityRule{
 				Name:             "allow_ssh",
 				Description:      "Allow SSH",
 				Priority:         2200,
@@ -942,9 +1289,16 @@ func (s *ClusterScope) SetControlPlaneSecurityRules() {
 				Destination:      ptr.To("*"),
 				DestinationPorts: ptr.To("22"),
 				Action:           infrav1.SecurityRuleActionAllow,
-			},
+			}
+			if sshRule != nil && len(sshRule.SourceCIDRs) > 0 {
+				rule.Source = nil
+				rule.Sources = azure.PtrSlice(&sshRule.SourceCIDRs)
+			}
+			rules = append(rules, rule)
+		}
+		subnet.SecurityGroup.SecurityRules = append(rules,
 			infrav1.SecurityRule{
-				Name:             "allow_apiserver",
+				Name:             infrav1.APIServerSecurityRuleName,
 				Description:      "Allow K8s API Server",
 				Priority:         2201,
 				Protocol:         infrav1.SecurityGroupProtocolTCP,
@@ -955,6 +1309,18 @@ func (s *ClusterScope) SetControlPlaneSecurityRules() {
 				DestinationPorts: ptr.To(strconv.Itoa(int(s.APIServerPort()))),
 				Action:           infrav1.SecurityRuleActionAllow,
 			},
+		)
+		s.AzureCluster.Spec.NetworkSpec.UpdateControlPlaneSubnet(subnet)
+	}
+
+	// The access list is applied on every reconcile so that changes to it update the existing rule.
+	if accessList := s.AzureCluster.Spec.NetworkSpec.APIServerAccessList; len(accessList) > 0 && s.ControlPlaneSubnet().SecurityGroup.ResourceGroup == "" {
+		subnet := s.ControlPlaneSubnet()
+		for i, rule := range subnet.SecurityGroup.SecurityRules {
+			if rule.Name == infrav1.APIServerSecurityRuleName {
+				subnet.SecurityGroup.SecurityRules[i].Source = nil
+				subnet.SecurityGroup.SecurityRules[i].Sources = azure.PtrSlice(&accessList)
+			}
 		}
 		s.AzureCluster.Spec.NetworkSpec.UpdateControlPlaneSubnet(subnet)
 	}
@@ -996,57 +1362,83 @@ func (s *ClusterScope) SetDNSName() {
 // SetLongRunningOperationState will set the future on the AzureCluster status to allow the resource to continue
 // in the next reconciliation.
 func (s *ClusterScope) SetLongRunningOperationState(future *infrav1.Future) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	futures.Set(s.AzureCluster, future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureCluster status.
 func (s *ClusterScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	return futures.Get(s.AzureCluster, name, service, futureType)
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureCluster status.
 func (s *ClusterScope) DeleteLongRunningOperationState(name, service, futureType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	futures.Delete(s.AzureCluster, name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureCluster status after a DELETE operation.
 func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	switch {
 	case err == nil:
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
+// IdentityPermissionsResource refers to the AzureCluster.
+func (s *ClusterScope) IdentityPermissionsResource() conditions.Setter {
+	return s.AzureCluster
+}
+
 // UpdatePutStatus updates a condition on the AzureCluster status after a PUT operation.
 func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	switch {
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
 // UpdatePatchStatus updates a condition on the AzureCluster status after a PATCH operation.
 func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	switch {
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (s *ClusterScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	out := map[string]interface{}{}
 	jsonAnnotation := s.AzureCluster.GetAnnotations()[annotation]
 	if jsonAnnotation == "" {
@@ -1074,12 +1466,94 @@ func (s *ClusterScope) UpdateAnnotationJSON(annotation string, content map[strin
 
 // SetAnnotation sets a key value annotation on the AzureCluster.
 func (s *ClusterScope) SetAnnotation(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.AzureCluster.Annotations == nil {
 		s.AzureCluster.Annotations = map[string]string{}
 	}
 	s.AzureCluster.Annotations[key] = value
 }
 
+// EventObject returns the AzureCluster, which receives Events about the operations performed on its Azure resources.
+func (s *ClusterScope) EventObject() client.Object {
+	return s.AzureCluster
+}
+
+// AdoptResources returns true if the AzureCluster has the adopt-resources annotation set to "true".
+func (s *ClusterScope) AdoptResources() bool {
+	return s.annotation(infrav1.AdoptResourcesAnnotation) == "true"
+}
+
+// SetResourceOwnership records in the AzureCluster status whether an Azure resource is managed by CAPZ.
+func (s *ClusterScope) SetResourceOwnership(ownership infrav1.ResourceOwnership) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.AzureCluster.Status.Resources {
+		if existing.Kind == ownership.Kind && existing.Name == ownership.Name {
+			s.AzureCluster.Status.Resources[i] = ownership
+			return
+		}
+	}
+	s.AzureCluster.Status.Resources = append(s.AzureCluster.Status.Resources, ownership)
+}
+
+// SetResourceIDs records in the AzureCluster status the Azure Resource Manager IDs of the virtual network, subnets,
+// network security groups, route tables, load balancers and public IPs of the cluster.
+func (s *ClusterScope) SetResourceIDs() {
+	var ids []infrav1.AzureResourceID
+	seen := make(map[string]bool)
+	add := func(kind, name, id string) {
+		if name == "" || seen[id] {
+			return
+		}
+		seen[id] = true
+		ids = append(ids, infrav1.AzureResourceID{Kind: kind, Name: name, ID: id})
+	}
+
+	vnet := s.Vnet()
+	add("VirtualNetwork", vnet.Name, azure.VNetID(s.SubscriptionID(), vnet.ResourceGroup, vnet.Name))
+	for _, subnet := range s.Subnets() {
+		add("Subnet", subnet.Name, azure.SubnetID(s.SubscriptionID(), vnet.ResourceGroup, vnet.Name, subnet.Name))
+	}
+	for _, spec := range s.NSGSpecs() {
+		add("NetworkSecurityGroup", spec.ResourceName(), azure.SecurityGroupID(s.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName()))
+	}
+	for _, spec := range s.RouteTableSpecs() {
+		add("RouteTable", spec.ResourceName(), azure.RouteTableID(s.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName()))
+	}
+	for _, spec := range s.LBSpecs() {
+		add("LoadBalancer", spec.ResourceName(), azure.LoadBalancerID(s.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName()))
+	}
+	for _, spec := range s.PublicIPSpecs() {
+		add("PublicIPAddress", spec.ResourceName(), azure.PublicIPID(s.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName()))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.AzureCluster.Status.ResourceIDs = ids
+}
+
+// DryRun returns true if the AzureCluster has the dry-run annotation set to "true".
+func (s *ClusterScope) DryRun() bool {
+	return s.annotation(infrav1.DryRunAnnotation) == "true"
+}
+
+// OrphanLockedResources returns true if the AzureCluster has the locked-resource-policy annotation set to "Orphan".
+func (s *ClusterScope) OrphanLockedResources() bool {
+	return s.annotation(infrav1.LockedResourcePolicyAnnotation) == infrav1.LockedResourcePolicyOrphan
+}
+
+// annotation returns the value of an annotation of the AzureCluster. Annotations are read under the lock as they are
+// written by services reconciled concurrently.
+func (s *ClusterScope) annotation(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.AzureCluster.Annotations[key]
+}
+
 // PrivateEndpointSpecs returns the private endpoint specs.
 func (s *ClusterScope) PrivateEndpointSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1api20220701.PrivateEndpoint] {
 	subnetsList := s.AzureCluster.Spec.NetworkSpec.Subnets
@@ -1124,6 +1598,39 @@ func (s *ClusterScope) PrivateEndpointSpecs() []azure.ASOResourceSpecGetter[*aso
 	return privateEndpointSpecs
 }
 
+// DNSRecordSpecs returns the specs of the DNS records for the custom API server hostname.
+func (s *ClusterScope) DNSRecordSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1api20180501.DnsZonesARecord] {
+	apiServerDNS := s.AzureCluster.Spec.NetworkSpec.APIServerDNS
+	if apiServerDNS == nil {
+		return nil
+	}
+
+	zoneName := apiServerDNS.DNSZoneID[strings.LastIndex(apiServerDNS.DNSZoneID, "/")+1:]
+	recordSpec := &dnsrecords.DNSRecordSpec{
+		Name:           strings.TrimSuffix(strings.ToLower(apiServerDNS.Hostname), "."+strings.ToLower(zoneName)),
+		DNSZoneID:      apiServerDNS.DNSZoneID,
+		TTL:            ptr.Deref(apiServerDNS.TTL, infrav1.DefaultAPIServerDNSTTL),
+		ClusterName:    s.ClusterName(),
+		AdditionalTags: s.AdditionalTags(),
+	}
+
+	if s.IsAPIServerPrivate() {
+		recordSpec.IPv4Address = s.APIServerPrivateIP()
+	} else {
+		// Alias the public IP so the record follows the IP address of the API server load balancer.
+		publicIPResourceGroup := s.ResourceGroup()
+		if s.APIServerLB().ResourceGroup != "" {
+			publicIPResourceGroup = s.APIServerLB().ResourceGroup
+		}
+		if s.APIServerPublicIP().ResourceGroup != "" {
+			publicIPResourceGroup = s.APIServerPublicIP().ResourceGroup
+		}
+		recordSpec.TargetResourceID = azure.PublicIPID(s.SubscriptionID(), publicIPResourceGroup, s.APIServerPublicIP().Name)
+	}
+
+	return []azure.ASOResourceSpecGetter[*asonetworkv1api20180501.DnsZonesARecord]{recordSpec}
+}
+
 func (s *ClusterScope) getLastAppliedSecurityRules(nsgName string) map[string]interface{} {
 	// Retrieve the last applied security rules for all NSGs.
 	lastAppliedSecurityRulesAll, err := s.AnnotationJSON(azure.SecurityRuleLastAppliedAnnotation)
@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
+	asonetworkv1api20180501 "github.com/Azure/azure-service-operator/v2/api/network/v1api20180501"
 	asonetworkv1api20201101 "github.com/Azure/azure-service-operator/v2/api/network/v1api20201101"
 	asonetworkv1api20220701 "github.com/Azure/azure-service-operator/v2/api/network/v1api20220701"
 	asoresourcesv1 "github.com/Azure/azure-service-operator/v2/api/resources/v1api20200601"
@@ -36,14 +38,19 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dnsrecords"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routeservers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworkgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -283,6 +290,152 @@ func TestGettingSecurityRules(t *testing.T) {
 	g.Expect(subnet.SecurityGroup.SecurityRules).To(HaveLen(2))
 }
 
+func TestSetControlPlaneSecurityRulesDefaultSSHRule(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultSSHRule *infrav1.DefaultSSHRule
+		wantRuleNames  []string
+		wantSSHSources []*string
+	}{
+		{
+			name:           "SSH is allowed from any source by default",
+			defaultSSHRule: nil,
+			wantRuleNames:  []string{"allow_ssh", "allow_apiserver"},
+		},
+		{
+			name:           "SSH is allowed from the given sources",
+			defaultSSHRule: &infrav1.DefaultSSHRule{SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+			wantRuleNames:  []string{"allow_ssh", "allow_apiserver"},
+			wantSSHSources: []*string{ptr.To("10.0.0.0/8"), ptr.To("192.168.0.0/16")},
+		},
+		{
+			name:           "SSH rule is disabled",
+			defaultSSHRule: &infrav1.DefaultSSHRule{Disabled: true},
+			wantRuleNames:  []string{"allow_apiserver"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				Cluster: &clusterv1.Cluster{},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Name: "cp-subnet",
+										Role: infrav1.SubnetControlPlane,
+									},
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "cp-nsg",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											DefaultSSHRule: tc.defaultSSHRule,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			clusterScope.SetControlPlaneSecurityRules()
+
+			rules := clusterScope.ControlPlaneSubnet().SecurityGroup.SecurityRules
+			ruleNames := make([]string, len(rules))
+			for i, rule := range rules {
+				ruleNames[i] = rule.Name
+			}
+			g.Expect(ruleNames).To(Equal(tc.wantRuleNames))
+			if tc.wantSSHSources != nil {
+				g.Expect(rules[0].Source).To(BeNil())
+				g.Expect(rules[0].Sources).To(Equal(tc.wantSSHSources))
+			}
+		})
+	}
+}
+
+func TestSetControlPlaneSecurityRulesAPIServerAccessList(t *testing.T) {
+	apiServerRule := infrav1.SecurityRule{
+		Name:             infrav1.APIServerSecurityRuleName,
+		Description:      "Allow K8s API Server",
+		Priority:         2201,
+		Protocol:         infrav1.SecurityGroupProtocolTCP,
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+		Source:           ptr.To("*"),
+		SourcePorts:      ptr.To("*"),
+		Destination:      ptr.To("*"),
+		DestinationPorts: ptr.To("6443"),
+		Action:           infrav1.SecurityRuleActionAllow,
+	}
+	tests := []struct {
+		name        string
+		accessList  []string
+		rules       infrav1.SecurityRules
+		wantSource  *string
+		wantSources []*string
+	}{
+		{
+			name:       "API server is allowed from any source by default",
+			wantSource: ptr.To("*"),
+		},
+		{
+			name:        "API server is allowed from the access list",
+			accessList:  []string{"10.0.0.0/8", "203.0.113.0/24"},
+			wantSources: []*string{ptr.To("10.0.0.0/8"), ptr.To("203.0.113.0/24")},
+		},
+		{
+			name:        "access list is applied to an existing API server rule",
+			accessList:  []string{"198.51.100.0/24"},
+			rules:       infrav1.SecurityRules{apiServerRule},
+			wantSources: []*string{ptr.To("198.51.100.0/24")},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				Cluster: &clusterv1.Cluster{},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								APIServerAccessList: tc.accessList,
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Name: "cp-subnet",
+										Role: infrav1.SubnetControlPlane,
+									},
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "cp-nsg",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											SecurityRules: tc.rules,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			clusterScope.SetControlPlaneSecurityRules()
+
+			rules := clusterScope.ControlPlaneSubnet().SecurityGroup.SecurityRules
+			idx := slices.IndexFunc(rules, func(rule infrav1.SecurityRule) bool {
+				return rule.Name == infrav1.APIServerSecurityRuleName
+			})
+			g.Expect(idx).NotTo(Equal(-1))
+			g.Expect(rules[idx].Source).To(Equal(tc.wantSource))
+			g.Expect(rules[idx].Sources).To(Equal(tc.wantSources))
+		})
+	}
+}
+
 func TestPublicIPSpecs(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -625,6 +778,104 @@ func TestPublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Azure cluster with public type apiserver LB and a zonal public IP",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "cluster.x-k8s.io/v1beta1",
+							Kind:       "Cluster",
+							Name:       "my-cluster",
+						},
+					},
+				},
+				Status: infrav1.AzureClusterStatus{
+					FailureDomains: map[string]clusterv1.FailureDomainSpec{
+						"1": {},
+						"2": {},
+						"3": {},
+					},
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "centralIndia",
+						IdentityRef: &corev1.ObjectReference{
+							Kind: infrav1.AzureClusterIdentityKind,
+						},
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{},
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:    "40.60.89.22",
+										DNSName: "fake-dns",
+										Zones:   []string{"2"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedPublicIPSpec: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:           "40.60.89.22",
+					ResourceGroup:  "my-rg",
+					DNSName:        "fake-dns",
+					IsIPv6:         false,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []*string{ptr.To("2")},
+					AdditionalTags: infrav1.Tags{},
+				},
+			},
+		},
+		{
+			name: "Azure cluster with public type apiserver LB using an existing public IP from another resource group",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "cluster.x-k8s.io/v1beta1",
+							Kind:       "Cluster",
+							Name:       "my-cluster",
+						},
+					},
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "centralIndia",
+						IdentityRef: &corev1.ObjectReference{
+							Kind: infrav1.AzureClusterIdentityKind,
+						},
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{},
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:          "my-static-ip",
+										DNSName:       "my-cluster.eastus.cloudapp.azure.com",
+										ResourceGroup: "my-static-ip-rg",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedPublicIPSpec: nil,
+		},
 		{
 			name: "Azure cluster with public type apiserver LB and public node outbound lb",
 			azureCluster: &infrav1.AzureCluster{
@@ -843,12 +1094,12 @@ func TestPublicIPSpecs(t *testing.T) {
 func TestRouteTableSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -862,7 +1113,7 @@ func TestRouteTableSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified route tables if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -916,6 +1167,138 @@ func TestRouteTableSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "merges the routes of subnets sharing a route table",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup: "my-rg",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-route-table-1",
+										Routes: []infrav1.Route{
+											{
+												Name:             "to-firewall",
+												AddressPrefix:    "0.0.0.0/0",
+												NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+												NextHopIPAddress: "10.1.0.4",
+											},
+										},
+									},
+								},
+								{
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-route-table-1",
+										Routes: []infrav1.Route{
+											{
+												Name:             "to-firewall",
+												AddressPrefix:    "0.0.0.0/0",
+												NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+												NextHopIPAddress: "10.1.0.4",
+											},
+											{
+												Name:          "to-onprem",
+												AddressPrefix: "192.168.0.0/16",
+												NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:          "fake-route-table-1",
+					ResourceGroup: "my-rg",
+					Location:      "centralIndia",
+					ClusterName:   "my-cluster",
+					Routes: []infrav1.Route{
+						{
+							Name:             "to-firewall",
+							AddressPrefix:    "0.0.0.0/0",
+							NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+							NextHopIPAddress: "10.1.0.4",
+						},
+						{
+							Name:          "to-onprem",
+							AddressPrefix: "192.168.0.0/16",
+							NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+						},
+					},
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+		{
+			name: "merges the cluster tags with the tags of the route tables",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+							AdditionalTags: infrav1.Tags{
+								"costcenter": "1234",
+								"env":        "prod",
+							},
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup: "my-rg",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									RouteTable: infrav1.RouteTable{
+										Name:           "fake-route-table-1",
+										AdditionalTags: infrav1.Tags{"env": "network"},
+									},
+								},
+								{
+									RouteTable: infrav1.RouteTable{
+										Name:           "fake-route-table-1",
+										AdditionalTags: infrav1.Tags{"owner": "network-team"},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:          "fake-route-table-1",
+					ResourceGroup: "my-rg",
+					Location:      "centralIndia",
+					ClusterName:   "my-cluster",
+					AdditionalTags: infrav1.Tags{
+						"costcenter": "1234",
+						"env":        "network",
+						"owner":      "network-team",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -932,12 +1315,12 @@ func TestRouteTableSpecs(t *testing.T) {
 func TestNatGatewaySpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ASOResourceSpecGetter[*asonetworkv1api20220701.NatGateway]
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -951,7 +1334,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1015,7 +1398,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present and ignores duplicate",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1097,7 +1480,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present and ignores control plane nat gateway",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1192,12 +1575,12 @@ func TestNatGatewaySpecs(t *testing.T) {
 func TestSetNatGatewayIDInSubnets(t *testing.T) {
 	tests := []struct {
 		name          string
-		clusterScope  ClusterScope
+		clusterScope  *ClusterScope
 		asoNatgateway *asonetworkv1api20220701.NatGateway
 	}{
 		{
 			name: "sets nat gateway id in the matching subnet",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1264,12 +1647,12 @@ func TestSetNatGatewayIDInSubnets(t *testing.T) {
 func TestNSGSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1281,8 +1664,8 @@ func TestNSGSpecs(t *testing.T) {
 			want: []azure.ResourceSpecGetter{},
 		},
 		{
-			name: "returns specified security groups if present",
-			clusterScope: ClusterScope{
+			name: "returns specified security groups if present and skips security groups from another resource group",
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1313,6 +1696,12 @@ func TestNSGSpecs(t *testing.T) {
 										},
 									},
 								},
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name:          "fake-existing-security-group",
+										ResourceGroup: "my-network-team-rg",
+									},
+								},
 							},
 						},
 					},
@@ -1335,6 +1724,50 @@ func TestNSGSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "returns security groups with the cluster tags overridden by the security group tags",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location:       "centralIndia",
+							AdditionalTags: infrav1.Tags{"env": "prod"},
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup: "my-rg",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "fake-security-group-1",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											AdditionalTags: infrav1.Tags{"env": "network", "owner": "network-team"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&securitygroups.NSGSpec{
+					Name:                     "fake-security-group-1",
+					ResourceGroup:            "my-rg",
+					Location:                 "centralIndia",
+					ClusterName:              "my-cluster",
+					AdditionalTags:           infrav1.Tags{"env": "network", "owner": "network-team"},
+					LastAppliedSecurityRules: map[string]interface{}{},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1348,15 +1781,231 @@ func TestNSGSpecs(t *testing.T) {
 	}
 }
 
+func TestFlowLogSpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if flow logs are not configured",
+			clusterScope: &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "fake-security-group-1",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns a flow log for the virtual network",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							SubscriptionID: "123",
+							Location:       "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup: "my-rg",
+								Name:          "my-vnet",
+							},
+							FlowLogs: &infrav1.FlowLogsSpec{
+								StorageAccountID:            "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+								RetentionDays:               7,
+								NetworkWatcherName:          "NetworkWatcher_centralIndia",
+								NetworkWatcherResourceGroup: "NetworkWatcherRG",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "fake-security-group-1",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&flowlogs.FlowLogSpec{
+					Name:                        "my-vnet-my-rg-flowlog",
+					NetworkWatcherName:          "NetworkWatcher_centralIndia",
+					NetworkWatcherResourceGroup: "NetworkWatcherRG",
+					Location:                    "centralIndia",
+					ClusterName:                 "my-cluster",
+					TargetResourceID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+					StorageAccountID:            "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+					RetentionDays:               7,
+					AdditionalTags:              make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.FlowLogSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlowLogSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestDNSRecordSpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         []azure.ASOResourceSpecGetter[*asonetworkv1api20180501.DnsZonesARecord]
+	}{
+		{
+			name: "returns nil if API server DNS is not configured",
+			clusterScope: &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns an alias record to the API server public IP",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							APIServerDNS: &infrav1.APIServerDNSSpec{
+								Hostname:  "API.my-cluster.example.com",
+								DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+								TTL:       ptr.To[int32](60),
+							},
+							APIServerLB: infrav1.LoadBalancerSpec{
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Public,
+								},
+								FrontendIPs: []infrav1.FrontendIP{
+									{
+										PublicIP: &infrav1.PublicIPSpec{
+											Name: "my-publicip",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ASOResourceSpecGetter[*asonetworkv1api20180501.DnsZonesARecord]{
+				&dnsrecords.DNSRecordSpec{
+					Name:             "api.my-cluster",
+					DNSZoneID:        "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+					TTL:              60,
+					TargetResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip",
+					ClusterName:      "my-cluster",
+					AdditionalTags:   make(infrav1.Tags),
+				},
+			},
+		},
+		{
+			name: "returns a record to the API server private IP",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							APIServerDNS: &infrav1.APIServerDNSSpec{
+								Hostname:  "api.my-cluster.example.com",
+								DNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+							},
+							APIServerLB: infrav1.LoadBalancerSpec{
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Internal,
+								},
+								FrontendIPs: []infrav1.FrontendIP{
+									{
+										FrontendIPClass: infrav1.FrontendIPClass{
+											PrivateIPAddress: "10.0.0.100",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ASOResourceSpecGetter[*asonetworkv1api20180501.DnsZonesARecord]{
+				&dnsrecords.DNSRecordSpec{
+					Name:           "api.my-cluster",
+					DNSZoneID:      "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+					TTL:            infrav1.DefaultAPIServerDNSTTL,
+					IPv4Address:    "10.0.0.100",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			g.Expect(tt.clusterScope.DNSRecordSpecs()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestSubnetSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ASOResourceSpecGetter[*asonetworkv1api20201101.VirtualNetworksSubnet]
 	}{
 		{
 			name: "returns empty if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1370,7 +2019,7 @@ func TestSubnetSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified subnet spec",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1447,9 +2096,89 @@ func TestSubnetSpecs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "returns dedicated pod subnet spec sharing the security group, route table and NAT gateway of its subnet",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "fake-vnet-1",
+								ResourceGroup: "my-rg",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role:       infrav1.SubnetNode,
+										CIDRBlocks: []string{"10.1.0.0/16"},
+										Name:       "fake-subnet-1",
+										PodSubnet: &infrav1.PodSubnetSpec{
+											Name:       "fake-pod-subnet-1",
+											CIDRBlocks: []string{"10.2.0.0/16"},
+										},
+									},
+									NatGateway: infrav1.NatGateway{
+										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+											Name: "fake-natgateway-1",
+										},
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-route-table-1",
+									},
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "fake-security-group-1",
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ASOResourceSpecGetter[*asonetworkv1api20201101.VirtualNetworksSubnet]{
+				&subnets.SubnetSpec{
+					Name:              "fake-subnet-1",
+					ResourceGroup:     "my-rg",
+					SubscriptionID:    "123",
+					CIDRs:             []string{"10.1.0.0/16"},
+					VNetName:          "fake-vnet-1",
+					VNetResourceGroup: "my-rg",
+					IsVNetManaged:     true,
+					RouteTableName:    "fake-route-table-1",
+					SecurityGroupName: "fake-security-group-1",
+					NatGatewayName:    "fake-natgateway-1",
+				},
+				&subnets.SubnetSpec{
+					Name:              "fake-pod-subnet-1",
+					ResourceGroup:     "my-rg",
+					SubscriptionID:    "123",
+					CIDRs:             []string{"10.2.0.0/16"},
+					VNetName:          "fake-vnet-1",
+					VNetResourceGroup: "my-rg",
+					IsVNetManaged:     true,
+					RouteTableName:    "fake-route-table-1",
+					SecurityGroupName: "fake-security-group-1",
+					NatGatewayName:    "fake-natgateway-1",
+				},
+			},
+		},
+
 		{
 			name: "returns specified subnet spec and bastion spec if enabled",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1578,12 +2307,12 @@ func TestSubnetSpecs(t *testing.T) {
 func TestIsVnetManaged(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         bool
 	}{
 		{
 			name: "VNET ID is empty",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1604,7 +2333,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Wrong tags",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1628,7 +2357,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has owning tags",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1652,7 +2381,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has cached value of false",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{},
 				},
@@ -1664,7 +2393,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has cached value of true",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{},
 				},
@@ -1694,12 +2423,12 @@ func TestIsVnetManaged(t *testing.T) {
 func TestAzureBastionSpec(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         azure.ASOResourceSpecGetter[*asonetworkv1api20220701.BastionHost]
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1712,7 +2441,7 @@ func TestAzureBastionSpec(t *testing.T) {
 		},
 		{
 			name: "returns bastion spec if enabled",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1771,46 +2500,237 @@ func TestAzureBastionSpec(t *testing.T) {
 							},
 							Subnets: infrav1.Subnets{
 								{
-									SubnetClassSpec: infrav1.SubnetClassSpec{
-										Role:       infrav1.SubnetNode,
-										CIDRBlocks: []string{"192.168.1.1/16"},
-										Name:       "fake-subnet-1",
-									},
-									NatGateway: infrav1.NatGateway{
-										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
-											Name: "fake-natgateway-1",
-										},
-									},
-									RouteTable: infrav1.RouteTable{
-										ID:   "fake-route-table-id-1",
-										Name: "fake-route-table-1",
-									},
-									SecurityGroup: infrav1.SecurityGroup{
-										Name: "fake-security-group-1",
-										SecurityGroupClass: infrav1.SecurityGroupClass{
-											SecurityRules: infrav1.SecurityRules{
-												{
-													Name: "fake-rule-1",
-												},
-											},
-										},
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role:       infrav1.SubnetNode,
+										CIDRBlocks: []string{"192.168.1.1/16"},
+										Name:       "fake-subnet-1",
+									},
+									NatGateway: infrav1.NatGateway{
+										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+											Name: "fake-natgateway-1",
+										},
+									},
+									RouteTable: infrav1.RouteTable{
+										ID:   "fake-route-table-id-1",
+										Name: "fake-route-table-1",
+									},
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "fake-security-group-1",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											SecurityRules: infrav1.SecurityRules{
+												{
+													Name: "fake-rule-1",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: &bastionhosts.AzureBastionSpec{
+				Name:          "fake-azure-bastion-1",
+				ResourceGroup: "my-rg",
+				Location:      "centralIndia",
+				ClusterName:   "my-cluster",
+				SubnetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "fake-vnet-1", "fake-bastion-subnet-1"),
+				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"publicIPAddresses/%s", "123", "my-rg", "fake-public-ip-1"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.AzureBastionSpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AzureBastionSpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkGatewaySpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no virtual network gateway is specified",
+			clusterScope: &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns virtual network gateway spec if specified",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "fake-vnet-1",
+								ResourceGroup: "my-rg-vnet",
+							},
+							VirtualNetworkGateway: &infrav1.VirtualNetworkGatewaySpec{
+								Name:    "my-cluster-vnet-gateway",
+								Type:    infrav1.VirtualNetworkGatewayTypeVPN,
+								SKU:     "VpnGw1AZ",
+								VPNType: infrav1.VPNTypeRouteBased,
+								Subnet: infrav1.SubnetSpec{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role:       infrav1.SubnetGateway,
+										CIDRBlocks: []string{"10.255.255.192/27"},
+										Name:       "GatewaySubnet",
+									},
+								},
+								PublicIP: infrav1.PublicIPSpec{
+									Name: "my-cluster-vnet-gateway-pip",
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: &virtualnetworkgateways.VirtualNetworkGatewaySpec{
+				Name:          "my-cluster-vnet-gateway",
+				ResourceGroup: "my-rg-vnet",
+				Location:      "centralIndia",
+				ClusterName:   "my-cluster",
+				Type:          infrav1.VirtualNetworkGatewayTypeVPN,
+				SKU:           "VpnGw1AZ",
+				VPNType:       infrav1.VPNTypeRouteBased,
+				SubnetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "fake-vnet-1", "GatewaySubnet"),
+				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"publicIPAddresses/%s", "123", "my-rg", "my-cluster-vnet-gateway-pip"),
+				AdditionalTags: infrav1.Tags{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.VirtualNetworkGatewaySpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VirtualNetworkGatewaySpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestPrivateLinkServiceSpec(t *testing.T) {
+	privateClusterScope := func(externallyManaged bool, pls *infrav1.PrivateLinkServiceSpec) *ClusterScope {
+		return &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+			},
+			AzureClients: AzureClients{
+				EnvironmentSettings: auth.EnvironmentSettings{
+					Values: map[string]string{
+						auth.SubscriptionID: "123",
+					},
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						Location: "centralIndia",
+					},
+					ExternallyManagedControlPlaneEndpoint: externallyManaged,
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name:          "fake-vnet-1",
+							ResourceGroup: "my-rg-vnet",
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "my-cluster-internal-lb",
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "my-cluster-internal-lb-frontEnd",
+									FrontendIPClass: infrav1.FrontendIPClass{
+										PrivateIPAddress: "10.0.0.100",
 									},
 								},
 							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Internal,
+							},
 						},
+						APIServerPrivateLinkService: pls,
 					},
 				},
-				cache: &ClusterCache{},
 			},
-			want: &bastionhosts.AzureBastionSpec{
-				Name:          "fake-azure-bastion-1",
+			cache: &ClusterCache{},
+		}
+	}
+	pls := &infrav1.PrivateLinkServiceSpec{
+		Name:                      "my-cluster-apiserver-pls",
+		SubnetName:                "my-cluster-controlplane-subnet",
+		AllowedSubscriptions:      []string{"00000000-0000-0000-0000-000000000001"},
+		AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+	}
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name:         "returns nil if no private link service is specified",
+			clusterScope: privateClusterScope(false, nil),
+			want:         nil,
+		},
+		{
+			name:         "returns nil if the control plane endpoint is externally managed",
+			clusterScope: privateClusterScope(true, pls),
+			want:         nil,
+		},
+		{
+			name:         "returns private link service spec if specified",
+			clusterScope: privateClusterScope(false, pls),
+			want: &privatelinkservices.PrivateLinkServiceSpec{
+				Name:          "my-cluster-apiserver-pls",
 				ResourceGroup: "my-rg",
 				Location:      "centralIndia",
 				ClusterName:   "my-cluster",
+				FrontendIPConfigID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"loadBalancers/%s/frontendIPConfigurations/%s", "123", "my-rg", "my-cluster-internal-lb", "my-cluster-internal-lb-frontEnd"),
 				SubnetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
-					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "fake-vnet-1", "fake-bastion-subnet-1"),
-				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
-					"publicIPAddresses/%s", "123", "my-rg", "fake-public-ip-1"),
+					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "fake-vnet-1", "my-cluster-controlplane-subnet"),
+				AllowedSubscriptions:      []string{"00000000-0000-0000-0000-000000000001"},
+				AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+				AdditionalTags:            infrav1.Tags{},
 			},
 		},
 	}
@@ -1819,13 +2739,133 @@ func TestAzureBastionSpec(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := tt.clusterScope.AzureBastionSpec(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("AzureBastionSpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			if got := tt.clusterScope.PrivateLinkServiceSpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PrivateLinkServiceSpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
 			}
 		})
 	}
 }
 
+func TestSubnetSpecsPrivateLinkServiceSubnet(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:          "fake-vnet-1",
+						ResourceGroup: "my-rg",
+					},
+					Subnets: infrav1.Subnets{
+						{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp-subnet", Role: infrav1.SubnetControlPlane}},
+						{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet", Role: infrav1.SubnetNode}},
+					},
+					APIServerPrivateLinkService: &infrav1.PrivateLinkServiceSpec{
+						Name:       "my-cluster-apiserver-pls",
+						SubnetName: "cp-subnet",
+					},
+				},
+			},
+		},
+		cache: &ClusterCache{},
+	}
+
+	specs := clusterScope.SubnetSpecs()
+	g.Expect(specs).To(HaveLen(2))
+	g.Expect(specs[0].(*subnets.SubnetSpec).DisablePrivateLinkServiceNetworkPolicies).To(BeTrue())
+	g.Expect(specs[1].(*subnets.SubnetSpec).DisablePrivateLinkServiceNetworkPolicies).To(BeFalse())
+}
+
+func TestRouteServerSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{},
+			},
+		},
+	}
+	routeServerSpec, ipConfigSpec, bgpConnectionSpecs := clusterScope.RouteServerSpecs()
+	g.Expect(routeServerSpec).To(BeNil())
+	g.Expect(ipConfigSpec).To(BeNil())
+	g.Expect(bgpConnectionSpecs).To(BeNil())
+
+	clusterScope = &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "centralIndia",
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:          "fake-vnet-1",
+						ResourceGroup: "my-rg-vnet",
+					},
+					RouteServer: &infrav1.RouteServerSpec{
+						Name: "my-cluster-routeserver",
+						Subnet: infrav1.SubnetSpec{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role:       infrav1.SubnetRouteServer,
+								CIDRBlocks: []string{"10.255.255.160/27"},
+								Name:       "RouteServerSubnet",
+							},
+						},
+						PublicIP: infrav1.PublicIPSpec{
+							Name: "my-cluster-routeserver-pip",
+						},
+						BranchToBranchTraffic: true,
+						BGPPeers: []infrav1.BGPPeer{
+							{Name: "nva-1", PeerIP: "10.0.0.4", PeerASN: 65001},
+						},
+					},
+				},
+			},
+		},
+		cache: &ClusterCache{},
+	}
+	routeServerSpec, ipConfigSpec, bgpConnectionSpecs = clusterScope.RouteServerSpecs()
+	g.Expect(routeServerSpec).To(Equal(&routeservers.RouteServerSpec{
+		Name:                  "my-cluster-routeserver",
+		ResourceGroup:         "my-rg-vnet",
+		Location:              "centralIndia",
+		ClusterName:           "my-cluster",
+		BranchToBranchTraffic: true,
+		AdditionalTags:        infrav1.Tags{},
+	}))
+	g.Expect(ipConfigSpec).To(Equal(&routeservers.IPConfigSpec{
+		Name:            "ipconfig1",
+		RouteServerName: "my-cluster-routeserver",
+		ResourceGroup:   "my-rg-vnet",
+		SubnetID:        "/subscriptions/123/resourceGroups/my-rg-vnet/providers/Microsoft.Network/virtualNetworks/fake-vnet-1/subnets/RouteServerSubnet",
+		PublicIPID:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-cluster-routeserver-pip",
+	}))
+	g.Expect(bgpConnectionSpecs).To(Equal([]azure.ResourceSpecGetter{
+		&routeservers.BGPConnectionSpec{
+			Name:            "nva-1",
+			RouteServerName: "my-cluster-routeserver",
+			ResourceGroup:   "my-rg-vnet",
+			PeerIP:          "10.0.0.4",
+			PeerASN:         65001,
+		},
+	}))
+}
+
 func TestSubnet(t *testing.T) {
 	tests := []struct {
 		clusterName             string
@@ -3216,6 +4256,110 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Existing API Server LB from another resource group",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "westus2",
+						IdentityRef: &corev1.ObjectReference{
+							Kind: infrav1.AzureClusterIdentityKind,
+						},
+					},
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name:          "my-vnet",
+							ResourceGroup: "my-rg",
+						},
+						Subnets: []infrav1.SubnetSpec{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "cp-subnet",
+									Role: infrav1.SubnetControlPlane,
+								},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "node-subnet",
+									Role: infrav1.SubnetNode,
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name:          "api-server-lb",
+							ResourceGroup: "my-lb-rg",
+							BackendPool: infrav1.BackendPool{
+								Name: "api-server-lb-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Internal,
+								SKU:  infrav1.SKUStandard,
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "API Server LB of an externally managed control plane endpoint",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "westus2",
+						IdentityRef: &corev1.ObjectReference{
+							Kind: infrav1.AzureClusterIdentityKind,
+						},
+					},
+					ResourceGroup: "my-rg",
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{
+						Host: "api.example.com",
+						Port: 443,
+					},
+					ExternallyManagedControlPlaneEndpoint: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name:          "my-vnet",
+							ResourceGroup: "my-rg",
+						},
+						Subnets: []infrav1.SubnetSpec{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "cp-subnet",
+									Role: infrav1.SubnetControlPlane,
+								},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "node-subnet",
+									Role: infrav1.SubnetNode,
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "api-server-lb",
+							BackendPool: infrav1.BackendPool{
+								Name: "api-server-lb-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Public,
+								SKU:  infrav1.SKUStandard,
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -3680,12 +4824,12 @@ func TestVNetPeerings(t *testing.T) {
 func TestPrivateEndpointSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ASOResourceSpecGetter[*asonetworkv1api20220701.PrivateEndpoint]
 	}{
 		{
 			name: "returns empty private endpoints list if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -3699,7 +4843,7 @@ func TestPrivateEndpointSpecs(t *testing.T) {
 		},
 		{
 			name: "returns empty private endpoints list if no private endpoints are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -3719,7 +4863,7 @@ func TestPrivateEndpointSpecs(t *testing.T) {
 		},
 		{
 			name: "returns list of private endpoint specs if private endpoints are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "my-cluster",
@@ -3993,12 +5137,12 @@ func TestSetFailureDomain(t *testing.T) {
 func TestGroupSpecs(t *testing.T) {
 	cases := []struct {
 		name     string
-		input    ClusterScope
+		input    *ClusterScope
 		expected []azure.ASOResourceSpecGetter[*asoresourcesv1.ResourceGroup]
 	}{
 		{
 			name: "virtualNetwork belongs to a different resource group",
-			input: ClusterScope{
+			input: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "cluster1",
@@ -4032,9 +5176,53 @@ func TestGroupSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "nodes belong to a different resource group",
+			input: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "cluster1",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup:     "dummy-rg",
+						NodeResourceGroup: "compute-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup: "network-rg",
+							},
+						},
+					},
+				},
+			},
+			expected: []azure.ASOResourceSpecGetter[*asoresourcesv1.ResourceGroup]{
+				&groups.GroupSpec{
+					Name:           "dummy-rg",
+					AzureName:      "dummy-rg",
+					ClusterName:    "cluster1",
+					Location:       "",
+					AdditionalTags: make(infrav1.Tags, 0),
+				},
+				&groups.GroupSpec{
+					Name:           "network-rg",
+					AzureName:      "network-rg",
+					ClusterName:    "cluster1",
+					Location:       "",
+					AdditionalTags: make(infrav1.Tags, 0),
+				},
+				&groups.GroupSpec{
+					Name:           "compute-rg",
+					AzureName:      "compute-rg",
+					ClusterName:    "cluster1",
+					Location:       "",
+					AdditionalTags: make(infrav1.Tags, 0),
+				},
+			},
+		},
 		{
 			name: "virtualNetwork belongs to a same resource group",
-			input: ClusterScope{
+			input: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "cluster1",
@@ -4063,7 +5251,7 @@ func TestGroupSpecs(t *testing.T) {
 		},
 		{
 			name: "virtualNetwork resource group not specified",
-			input: ClusterScope{
+			input: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "cluster1",
@@ -4093,7 +5281,7 @@ func TestGroupSpecs(t *testing.T) {
 		},
 		{
 			name: "virtualNetwork belongs to different resource group with non-k8s name",
-			input: ClusterScope{
+			input: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "cluster1",
@@ -4144,3 +5332,95 @@ func TestGroupSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestSetResourceOwnership(t *testing.T) {
+	g := NewWithT(t)
+
+	c := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{},
+	}
+
+	c.SetResourceOwnership(infrav1.ResourceOwnership{Kind: "ResourceGroup", Name: "rg", Managed: false})
+	c.SetResourceOwnership(infrav1.ResourceOwnership{Kind: "VirtualNetwork", Name: "vnet", Managed: true})
+	c.SetResourceOwnership(infrav1.ResourceOwnership{Kind: "ResourceGroup", Name: "rg", Managed: true})
+
+	g.Expect(c.AzureCluster.Status.Resources).To(Equal([]infrav1.ResourceOwnership{
+		{Kind: "ResourceGroup", Name: "rg", Managed: true},
+		{Kind: "VirtualNetwork", Name: "vnet", Managed: true},
+	}))
+}
+
+func TestSetResourceIDs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "westus2",
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:          "my-vnet",
+						ResourceGroup: "my-rg",
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role: infrav1.SubnetControlPlane,
+								Name: "cp-subnet",
+							},
+							SecurityGroup: infrav1.SecurityGroup{Name: "my-nsg"},
+						},
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role: infrav1.SubnetNode,
+								Name: "node-subnet",
+							},
+							SecurityGroup: infrav1.SecurityGroup{Name: "my-nsg"},
+							RouteTable:    infrav1.RouteTable{Name: "node-routetable"},
+						},
+					},
+					APIServerLB: infrav1.LoadBalancerSpec{
+						Name: "my-cluster-public-lb",
+						FrontendIPs: []infrav1.FrontendIP{
+							{
+								Name:     "my-cluster-public-lb-frontEnd",
+								PublicIP: &infrav1.PublicIPSpec{Name: "pip-my-cluster-apiserver"},
+							},
+						},
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Public,
+						},
+					},
+				},
+			},
+		},
+		cache: &ClusterCache{},
+	}
+
+	clusterScope.SetResourceIDs()
+
+	g.Expect(clusterScope.AzureCluster.Status.ResourceIDs).To(Equal([]infrav1.AzureResourceID{
+		{Kind: "VirtualNetwork", Name: "my-vnet", ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"},
+		{Kind: "Subnet", Name: "cp-subnet", ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/cp-subnet"},
+		{Kind: "Subnet", Name: "node-subnet", ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/node-subnet"},
+		{Kind: "NetworkSecurityGroup", Name: "my-nsg", ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-nsg"},
+		{Kind: "RouteTable", Name: "node-routetable", ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/routeTables/node-routetable"},
+		{Kind: "LoadBalancer", Name: "my-cluster-public-lb", ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster-public-lb"},
+		{Kind: "PublicIPAddress", Name: "pip-my-cluster-apiserver", ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-my-cluster-apiserver"},
+	}))
+}
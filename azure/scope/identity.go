@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"reflect"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
@@ -28,12 +29,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// AzureSecretKey is the value for they client secret key.
-const AzureSecretKey = "clientSecret"
+const (
+	// AzureSecretKey is the value for they client secret key.
+	AzureSecretKey = "clientSecret"
+	// AzureCertificateKey is the value for the client certificate key of a ServicePrincipalCertificate identity.
+	AzureCertificateKey = "certificate"
+	// AzureCertificatePasswordKey is the value for the client certificate password key of a ServicePrincipalCertificate identity.
+	AzureCertificatePasswordKey = "password"
+	// adfsTenantID is the tenant ID of identities authenticating against Active Directory Federation Services,
+	// as used by disconnected Azure Stack Hub deployments.
+	adfsTenantID = "adfs"
+)
 
 // CredentialsProvider defines the behavior for azure identity based credential providers.
 type CredentialsProvider interface {
@@ -137,6 +148,16 @@ func (p *AzureCredentialsProvider) GetTokenCredential(ctx context.Context, resou
 	var authErr error
 	var cred azcore.TokenCredential
 
+	cloudConfig := cloud.Configuration{
+		ActiveDirectoryAuthorityHost: activeDirectoryEndpoint,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Audience: tokenAudience,
+				Endpoint: resourceManagerEndpoint,
+			},
+		},
+	}
+
 	switch p.Identity.Spec.Type {
 	case infrav1.WorkloadIdentity:
 		azwiCredOptions, err := NewWorkloadIdentityCredentialOptions().
@@ -146,6 +167,11 @@ func (p *AzureCredentialsProvider) GetTokenCredential(ctx context.Context, resou
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to setup azwi options for identity %s", p.Identity.Name)
 		}
+		if azure.CloudEndpointOverrides.ActiveDirectoryAuthorityHost != "" {
+			azwiCredOptions.Cloud = cloudConfig
+		}
+		azwiCredOptions.Transport = azure.HTTPTransport
+		azwiCredOptions.Telemetry.Disabled = azure.TelemetryDisabled
 		cred, authErr = NewWorkloadIdentityCredential(azwiCredOptions)
 
 	case infrav1.ManualServicePrincipal:
@@ -158,34 +184,38 @@ func (p *AzureCredentialsProvider) GetTokenCredential(ctx context.Context, resou
 		}
 		options := azidentity.ClientSecretCredentialOptions{
 			ClientOptions: azcore.ClientOptions{
-				Cloud: cloud.Configuration{
-					ActiveDirectoryAuthorityHost: activeDirectoryEndpoint,
-					Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
-						cloud.ResourceManager: {
-							Audience: tokenAudience,
-							Endpoint: resourceManagerEndpoint,
-						},
-					},
-				},
+				Cloud:     cloudConfig,
+				Transport: azure.HTTPTransport,
 			},
+			DisableInstanceDiscovery: p.isADFS(),
 		}
+		options.Telemetry.Disabled = azure.TelemetryDisabled
 		cred, authErr = azidentity.NewClientSecretCredential(p.GetTenantID(), p.Identity.Spec.ClientID, clientSecret, &options)
 
 	case infrav1.ServicePrincipalCertificate:
-		clientSecret, err := p.GetClientSecret(ctx)
+		certificate, password, err := p.GetClientCertificate(ctx)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to get client secret")
+			return nil, errors.Wrap(err, "failed to get client certificate")
 		}
-		certs, key, err := azidentity.ParseCertificates([]byte(clientSecret), nil)
+		certs, key, err := azidentity.ParseCertificates(certificate, password)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to parse certificate data")
 		}
-		cred, authErr = azidentity.NewClientCertificateCredential(p.GetTenantID(), p.Identity.Spec.ClientID, certs, key, nil)
+		options := azidentity.ClientCertificateCredentialOptions{
+			DisableInstanceDiscovery: p.isADFS(),
+		}
+		if azure.CloudEndpointOverrides.ActiveDirectoryAuthorityHost != "" {
+			options.Cloud = cloudConfig
+		}
+		options.Transport = azure.HTTPTransport
+		options.Telemetry.Disabled = azure.TelemetryDisabled
+		cred, authErr = azidentity.NewClientCertificateCredential(p.GetTenantID(), p.Identity.Spec.ClientID, certs, key, &options)
 
 	case infrav1.UserAssignedMSI:
 		options := azidentity.ManagedIdentityCredentialOptions{
 			ID: azidentity.ClientID(p.Identity.Spec.ClientID),
 		}
+		options.Telemetry.Disabled = azure.TelemetryDisabled
 		cred, authErr = azidentity.NewManagedIdentityCredential(&options)
 
 	default:
@@ -209,21 +239,45 @@ func (p *AzureCredentialsProvider) GetClientID() string {
 // If using another type of credentials, such a Certificate, we return an empty string.
 func (p *AzureCredentialsProvider) GetClientSecret(ctx context.Context) (string, error) {
 	if p.hasClientSecret() {
-		secretRef := p.Identity.Spec.ClientSecret
-		key := types.NamespacedName{
-			Namespace: secretRef.Namespace,
-			Name:      secretRef.Name,
-		}
-		secret := &corev1.Secret{}
-
-		if err := p.Client.Get(ctx, key, secret); err != nil {
-			return "", errors.Wrap(err, "Unable to fetch ClientSecret")
+		secret, err := p.getSecret(ctx)
+		if err != nil {
+			return "", err
 		}
 		return string(secret.Data[AzureSecretKey]), nil
 	}
 	return "", nil
 }
 
+// GetClientCertificate returns the PEM or PKCS12 client certificate and its optional password associated with
+// the AzureCredentialsProvider's Identity. The certificate is read from the "certificate" key of the secret,
+// falling back to the "clientSecret" key for PEM certificates stored the way client secrets are.
+func (p *AzureCredentialsProvider) GetClientCertificate(ctx context.Context) (certificate, password []byte, err error) {
+	secret, err := p.getSecret(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	certificate, ok := secret.Data[AzureCertificateKey]
+	if !ok {
+		certificate = secret.Data[AzureSecretKey]
+	}
+	return certificate, secret.Data[AzureCertificatePasswordKey], nil
+}
+
+// getSecret fetches the secret referenced by the AzureCredentialsProvider's Identity.
+func (p *AzureCredentialsProvider) getSecret(ctx context.Context) (*corev1.Secret, error) {
+	secretRef := p.Identity.Spec.ClientSecret
+	key := types.NamespacedName{
+		Namespace: secretRef.Namespace,
+		Name:      secretRef.Name,
+	}
+	secret := &corev1.Secret{}
+
+	if err := p.Client.Get(ctx, key, secret); err != nil {
+		return nil, errors.Wrap(err, "Unable to fetch ClientSecret")
+	}
+	return secret, nil
+}
+
 // GetTenantID returns the Tenant ID associated with the AzureCredentialsProvider's Identity.
 func (p *AzureCredentialsProvider) GetTenantID() string {
 	return p.Identity.Spec.TenantID
@@ -234,6 +288,12 @@ func (p *AzureCredentialsProvider) Type() infrav1.IdentityType {
 	return p.Identity.Spec.Type
 }
 
+// isADFS returns true if the identity authenticates against Active Directory Federation Services.
+// ADFS doesn't support AAD instance discovery, so it must be disabled.
+func (p *AzureCredentialsProvider) isADFS() bool {
+	return strings.EqualFold(p.GetTenantID(), adfsTenantID)
+}
+
 // hasClientSecret returns true if the identity has a Service Principal Client Secret.
 // This does not include managed identities.
 func (p *AzureCredentialsProvider) hasClientSecret() bool {
@@ -200,6 +200,12 @@ func TestGetTokenCredential(t *testing.T) {
 	certPEM, err := base64.StdEncoding.DecodeString(encodedCertData)
 	g.Expect(err).NotTo(HaveOccurred())
 
+	// Test PKCS12 data was generated from the certificate above with this command:
+	//    openssl pkcs12 -export -in cert.pem -inkey cert.pem -passout pass:fake-password -certpbe PBE-SHA1-3DES -keypbe PBE-SHA1-3DES -macalg sha1 | base64
+	encodedPFXData := "MIIJSQIBAzCCCQ8GCSqGSIb3DQEHAaCCCQAEggj8MIII+DCCA68GCSqGSIb3DQEHBqCCA6AwggOcAgEAMIIDlQYJKoZIhvcNAQcBMBwGCiqGSIb3DQEMAQMwDgQIeOJDG/RmPn4CAggAgIIDaPguYLm1u/SdyKJLMniBePttx1K3PZ5W1zGZnmdLKLlPxv6El+KTBY4QRvDTkP+5vR+Wftnts1vx0/SnKkVf1RGliVLW7qDlKEobdD2B+FEvvToCHjLCksA9LBkQFVHQ9LhM/jRKRQEQc2h9rxqWu/EfVaQoRlTHtqF9pI7NCKitRshPSy1teR7u4FSchZcfOTdstBSCL8QgkEyN2ihPs3lsOUqo98UbJOzs1ROjSuFJimozRUCObyzLm4t266G4aIn7PVoaeQQ/v2Mz0dzuYRaNTUf8AW3ieQHuhKig28AxT3Is/B3eE0gijIOnEQoSFbks4UsDPLsRp41Fdi4vbuSe5PmR63BNvjQ34swBHt/nwkIigl3So7hoe15lSPfK4lBxgTVnpUJhUz6xgy7pb9Z2AfUQHexIac/tdWwE01dITE3V0o5fQVegr78aneN5tzs5gMUKlOsZaogwWWQ2u4JQ6r0F81+3kxWEt5A4LYU4Em22QXETJNJem568maSlgiT2QbVTYwnDmHFPKk7Z+2OcbuVHUYF0lYJ3kM2E1WavWoeCyz2GRDKtFzMvX0AYVQ2HaMoslcCDTfauwVZqKpAr3pv79qRiPQkCIdgbNeF/WWsI+HzZXpvEre0kvFoDN3HGWdB++ASqAjFfG8alK66Kx52SLJxkoJhwGlHqc60K7igChxcAuuznO38daybdoIBfvuzhfqB+uWTOpjmmqimfSR2HMG2JOcYalFx6tZj7trs7MjFV1VtiruHL5EqOdjl5nTKSNNjbn2dy4us5jqnSiaZdLrJdyg6V61oONlCQoLTwp09vBkain6vFVE2If4/NnPe6nLRbCGMAUxBvxMCtIFZBYyTKjibPTw55PF4fyx1i7vrss+6FqNjhuyp0AYJGhKUyJBPZs/2lwU/HEtB5BTnQzNanP36mOLgZxQctaL7CLM4y+inQQUWG1fRWUvCIxXLX+IVYTx4yGlRXFanhHrMq5dGOql13gtraNy4u9gVKWTS5dX2UdJ4eOzUtL53k87TuA4gIG961TxDz+WbOV5U4Vx6mXoaqj/KuvaumRcbphJXtA8WPZNsvXqs0Hhc00Nbb3fIkyhnoR6GWaW12F8PgKbl2zvXa/TnWFHsj4qod8lnSs8Hn9nni+ERcmHwahZHwnzgrMIIFQQYJKoZIhvcNAQcBoIIFMgSCBS4wggUqMIIFJgYLKoZIhvcNAQwKAQKgggTuMIIE6jAcBgoqhkiG9w0BDAEDMA4ECJkrQ1u+8STeAgIIAASCBMhqpr7C38nufRJiHsxkc52AdJdlpWsGiT2lWwpU6z5dIca/WgzijBom4P2Z1Pd2ZAaO7Qcje/wrJy0cU3T7KCYiJvJ1NrrM37IHjwHw/Up8YZpASanS5SxWA/q9Ua/18GB4xsPQD9lNedcBYFwFJGovOVMDjnSr6Z8JLwl4e7obyccj8EhrKk9rhpV6VZXGGoa2YPHRrlAIwOAZwxlEhSbxaWXvBOK0W5hIuLlgFfJ2q+beZbOgtUtQhGO6kRWxJAcg75qktH6QuJ43TC6W0oh8dAE5gs8mOyPt37e5LI8If03S8oW5DJFizoy9cb4Xdtr5I8lmf0wk7khHOjJgCKE4E+Ju+/MlL2QDLIWlIHBvo8pvTE5IFk2hSInKgdo5ehMi9ul1ntryMss5DKqhPrO2SUZgOcMrCq8WutsMjrfpXq7wu4aK29WvJEh3fjIZvYKPZ6CmFfd9Vx+9iA1q/Xag8f12Aq25YyxJfyVa4HNemNtAueFXu4180kNbVy/8QFVdbsYoMt6wqpc49VdCajyV4mRoz7W04OqBxXjuFqdX+WReYl4va0BB6WdJpXo3oTxaQ3OvXlQhjG0Ab7tCPzVvmJiFs5DN2UCTIsMl4JYUj0iGfOaCzfzv/a+SW5Fr2hmTu6r580c4cSgZt17yDAcvesjFuNZ9c1sBp7JGuhu3ImVFbOf2O+bIcVitPbGso3IiztB4rodSJF4XeRXuOb6Cx3U36zT0eHKaHhT2/D+2SNsyNJL52e7kk6bYOTpgPsy7RPFDbxnQ3g4d0DWDt80a51Js82/3S2NoM33EpFZp+ugBV3VxHrSnydS5Dgpi2WC3WAlyvy/OdifXcJEZ+rjEb/dAEYnKgUnSLI5IPqdL/dB5azOHvHpYVCKzUMTJk7oEPJk2034K6bKBEYXv+R+snir5h7Rx00MBVKcJ6Iuzpg+677siZz9GOO6JPYgFR/sJ74XgYMMXv6wwWGVinpJrhZtNNhidNlpa1+Iw2yZCls0GymLnRXdb0bjyAAmYtNKOZaAFrH4ulDbO0Q4H92eSKBtrsUh6SvXJgjOEPE4GrbjukrbUJ1YvQWyk1DkX8PYFmgv42OpoLIPyrJg68tNN1hPiYS/I6q24g0ZOKLSxON5T28G3rlK2uJz2v0ZXGPnWIX//j3Ky+/YjGqdkaMcbXe1T/Pw6cQ7PYERZl4eRtIShZB32kpPT5aC12Sx/mKUxkvVp27Si61TCW7ZYW/C987eGsWycBZOBpfOw1106AEnhC8eZYvxXhgWxYdiaEzf0/um0Ow5/nPgdMEVQSvwYicKI+LCiDKvORYqZFk3anAmBISztXfBN5cw4KMrfB3Fm9a+ndmd1jUyeFQ8MV110kYgUJ6DUrLNanzOhSjDJLsT2+vCRiY8ISwPGuN1ggXNIjKrRJFRIsqMl3QX4/luq83gZoMA0SO8J4esLX9Vpuq2Zcbm8T8U2k6fw3uU1uljTsaiHGTAgIGpuW4NQoec0UcZiQRJdvLLs/RPGXU5HjHQC4Axbeo+VYutGNRX/6fHaGpM9NgBNCovEtWdW4ilmiS4DuDil+Io3mg9w6W6bUWIX4+LD1+0OyQ4/Aq703w09DjCv4MYBm9ljG8iw7J+Mm2ebGHpqWBQxJTAjBgkqhkiG9w0BCRUxFgQULtFePLtks/HkUE5f9Nl4sQ2cepQwMTAhMAkGBSsOAwIaBQAEFBw44CRLEyCsZqQ7YPDDJtXmXJpxBAiq4oReXYYDFQICCAA="
+	certPFX, err := base64.StdEncoding.DecodeString(encodedPFXData)
+	g.Expect(err).NotTo(HaveOccurred())
+
 	tests := []struct {
 		name                         string
 		cluster                      *infrav1.AzureCluster
@@ -315,6 +321,67 @@ func TestGetTokenCredential(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "service principal with ADFS",
+			cluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						IdentityRef: &corev1.ObjectReference{
+							Kind: infrav1.AzureClusterIdentityKind,
+						},
+					},
+				},
+			},
+			identity: &infrav1.AzureClusterIdentity{
+				Spec: infrav1.AzureClusterIdentitySpec{
+					Type:     infrav1.ServicePrincipal,
+					TenantID: "adfs",
+					ClientSecret: corev1.SecretReference{
+						Name: "test-identity-secret",
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-identity-secret",
+				},
+				Data: map[string][]byte{
+					"clientSecret": []byte("fooSecret"),
+				},
+			},
+			ActiveDirectoryAuthorityHost: "https://adfs.local.azurestack.external/adfs/",
+		},
+		{
+			name: "service principal certificate with password protected PKCS12 data",
+			cluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						IdentityRef: &corev1.ObjectReference{
+							Kind: infrav1.AzureClusterIdentityKind,
+						},
+					},
+				},
+			},
+			identity: &infrav1.AzureClusterIdentity{
+				Spec: infrav1.AzureClusterIdentitySpec{
+					Type:     infrav1.ServicePrincipalCertificate,
+					TenantID: fakeTenantID,
+					ClientSecret: corev1.SecretReference{
+						Name: "test-identity-secret",
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-identity-secret",
+				},
+				Data: map[string][]byte{
+					"certificate": certPFX,
+					"password":    []byte("fake-password"),
+				},
+			},
+			ActiveDirectoryAuthorityHost: "https://login.microsoftonline.com",
+		},
 		{
 			name: "user-assigned identity",
 			cluster: &infrav1.AzureCluster{
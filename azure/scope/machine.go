@@ -18,21 +18,29 @@ package scope
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/features"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/jitaccesspolicies"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
@@ -51,6 +59,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// DomainJoinPasswordSecretKey is the key of the domain join password in the secret referenced by an AzureMachine's
+	// windowsConfiguration.domainJoin.passwordSecretRef.
+	DomainJoinPasswordSecretKey = "password"
+)
+
 // MachineScopeParams defines the input parameters used to create a new MachineScope.
 type MachineScopeParams struct {
 	Client       client.Client
@@ -109,10 +123,12 @@ type SKUCacher interface {
 
 // MachineCache stores common machine information so we don't have to hit the API multiple times within the same reconcile loop.
 type MachineCache struct {
-	BootstrapData      string
-	VMImage            *infrav1.Image
-	VMSKU              resourceskus.SKU
-	availabilitySetSKU resourceskus.SKU
+	BootstrapData       string
+	BootstrapDataFormat string
+	DomainJoinPassword  string
+	VMImage             *infrav1.Image
+	VMSKU               resourceskus.SKU
+	availabilitySetSKU  resourceskus.SKU
 }
 
 // InitMachineCache sets cached information about the machine to be used in the scope.
@@ -129,11 +145,21 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 			return err
 		}
 
+		m.cache.BootstrapDataFormat, err = m.GetBootstrapDataFormat(ctx)
+		if err != nil {
+			return err
+		}
+
 		m.cache.VMImage, err = m.GetVMImage(ctx)
 		if err != nil {
 			return err
 		}
 
+		m.cache.DomainJoinPassword, err = m.GetDomainJoinPassword(ctx)
+		if err != nil {
+			return err
+		}
+
 		skuCache := m.skuCache
 		if skuCache == nil {
 			cache, err := resourceskus.GetCache(m, m.Location())
@@ -179,10 +205,12 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 		SecurityProfile:            m.AzureMachine.Spec.SecurityProfile,
 		DiagnosticsProfile:         m.AzureMachine.Spec.Diagnostics,
 		DisableExtensionOperations: ptr.Deref(m.AzureMachine.Spec.DisableExtensionOperations, false),
+		WindowsConfiguration:       m.AzureMachine.Spec.WindowsConfiguration,
 		AdditionalTags:             m.AdditionalTags(),
 		AdditionalCapabilities:     m.AzureMachine.Spec.AdditionalCapabilities,
 		CapacityReservationGroupID: m.GetCapacityReservationGroupID(),
 		ProviderID:                 m.ProviderID(),
+		ResourceNaming:             m.ResourceNaming(),
 	}
 	if m.cache != nil {
 		spec.SKU = m.cache.VMSKU
@@ -207,16 +235,21 @@ func (m *MachineScope) TagsSpecs() []azure.TagsSpec {
 func (m *MachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
 	if m.AzureMachine.Spec.AllocatePublicIP {
+		var dnsName string
+		if m.AzureMachine.Spec.EnablePublicIPDNSLabel {
+			dnsName = azure.GenerateNodePublicIPDNSLabel(m.SubscriptionID(), m.NodeResourceGroup(), m.Name())
+		}
 		specs = append(specs, &publicips.PublicIPSpec{
-			Name:             azure.GenerateNodePublicIPName(m.Name()),
+			Name:             azure.NodePublicIPName(m.ResourceNaming(), m.Name()),
 			ResourceGroup:    m.NodeResourceGroup(),
 			ClusterName:      m.ClusterName(),
-			DNSName:          "",    // Set to default value
+			DNSName:          dnsName,
 			IsIPv6:           false, // Set to default value
 			Location:         m.Location(),
 			ExtendedLocation: m.ExtendedLocation(),
 			FailureDomains:   m.FailureDomains(),
 			AdditionalTags:   m.ClusterScoper.AdditionalTags(),
+			PublicIPPrefixID: m.AzureMachine.Spec.PublicIPPrefixID,
 		})
 	}
 	return specs
@@ -225,16 +258,18 @@ func (m *MachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 // InboundNatSpecs returns the inbound NAT specs.
 func (m *MachineScope) InboundNatSpecs() []azure.ResourceSpecGetter {
 	// The existing inbound NAT rules are needed in order to find an available SSH port for each new inbound NAT rule.
-	if m.Role() == infrav1.ControlPlane {
+	// Inbound NAT rules are not created on an existing load balancer from another resource group, nor when there is
+	// no API server load balancer because the control plane endpoint is externally managed.
+	if m.Role() == infrav1.ControlPlane && !m.IsControlPlaneEndpointExternallyManaged() && m.APIServerLB().ResourceGroup == "" {
 		spec := &inboundnatrules.InboundNatSpec{
 			Name:                      m.Name(),
-			ResourceGroup:             m.NodeResourceGroup(),
+			ResourceGroup:             m.ResourceGroup(),
 			LoadBalancerName:          m.APIServerLBName(),
 			FrontendIPConfigurationID: nil,
 		}
 		if frontEndIPs := m.APIServerLB().FrontendIPs; len(frontEndIPs) > 0 {
 			ipConfig := frontEndIPs[0].Name
-			id := azure.FrontendIPConfigID(m.SubscriptionID(), m.NodeResourceGroup(), m.APIServerLBName(), ipConfig)
+			id := azure.FrontendIPConfigID(m.SubscriptionID(), m.ResourceGroup(), m.APIServerLBName(), ipConfig)
 			spec.FrontendIPConfigurationID = ptr.To(id)
 		}
 
@@ -251,12 +286,40 @@ func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
 	// created prior to multiple NIC support
 	isMultiNIC := len(m.AzureMachine.Spec.NetworkInterfaces) > 1
 
+	var podNICSpecs []azure.ResourceSpecGetter
 	for i := 0; i < len(m.AzureMachine.Spec.NetworkInterfaces); i++ {
 		isPrimary := i == 0
-		nicName := azure.GenerateNICName(m.Name(), isMultiNIC, i)
-		nicSpecs = append(nicSpecs, m.BuildNICSpec(nicName, m.AzureMachine.Spec.NetworkInterfaces[i], isPrimary))
+		nicName := azure.NICName(m.ResourceNaming(), m.Name(), isMultiNIC, i)
+		iface := m.AzureMachine.Spec.NetworkInterfaces[i]
+
+		// All the IP configurations of a network interface must be in the same subnet, so the secondary private IPs
+		// of a network interface in a subnet with a dedicated pod subnet are allocated on a second network interface
+		// in the pod subnet.
+		if podSubnetName := m.podSubnetName(iface.SubnetName); podSubnetName != "" && iface.PrivateIPConfigs > 1 {
+			podIface := iface
+			podIface.SubnetName = podSubnetName
+			podIface.PrivateIPConfigs = iface.PrivateIPConfigs - 1
+			podNICSpec := m.BuildNICSpec(azure.PodNICName(nicName), podIface, false)
+			podNICSpec.IPv6Enabled = false
+			podNICSpecs = append(podNICSpecs, podNICSpec)
+			iface.PrivateIPConfigs = 1
+		}
+
+		nicSpecs = append(nicSpecs, m.BuildNICSpec(nicName, iface, isPrimary))
+	}
+	// The pod network interfaces come after all the other ones so that the names of the network interfaces don't depend
+	// on whether there are pod subnets.
+	return append(nicSpecs, podNICSpecs...)
+}
+
+// podSubnetName returns the name of the dedicated pod subnet of a subnet, or an empty string if it has none.
+func (m *MachineScope) podSubnetName(subnetName string) string {
+	for _, subnet := range m.Subnets() {
+		if subnet.Name == subnetName {
+			return subnet.PodSubnetName()
+		}
 	}
-	return nicSpecs
+	return ""
 }
 
 // BuildNICSpec takes a NetworkInterface from the AzureMachineSpec and returns a NICSpec for use by the networkinterfaces service.
@@ -287,27 +350,54 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 		spec.IPConfigs = append(spec.IPConfigs, networkinterfaces.IPConfig{})
 	}
 
+	var asgs []string
+	for _, subnet := range m.Subnets() {
+		// A pod subnet shares the application security groups of its subnet.
+		if subnet.Name == infrav1NetworkInterface.SubnetName || (subnet.PodSubnet != nil && subnet.PodSubnet.Name == infrav1NetworkInterface.SubnetName) {
+			asgs = append(asgs, subnet.ApplicationSecurityGroups...)
+		}
+	}
+	for _, asg := range m.AzureMachine.Spec.ApplicationSecurityGroups {
+		if !slices.Contains(asgs, asg) {
+			asgs = append(asgs, asg)
+		}
+	}
+	spec.ApplicationSecurityGroupIDs = azure.ApplicationSecurityGroupIDs(m.SubscriptionID(), m.ResourceGroup(), asgs)
+
 	if primaryNetworkInterface {
 		spec.DNSServers = m.AzureMachine.Spec.DNSServers
 
 		if m.Role() == infrav1.ControlPlane {
 			spec.PublicLBName = m.OutboundLBName(m.Role())
+			spec.PublicLBResourceGroup = m.OutboundLBResourceGroup(m.Role())
 			spec.PublicLBAddressPoolName = m.OutboundPoolName(m.Role())
-			if m.IsAPIServerPrivate() {
+			switch {
+			case m.IsControlPlaneEndpointExternallyManaged():
+				// There is no API server load balancer to add the machine to.
+			case m.IsAPIServerPrivate():
 				spec.InternalLBName = m.APIServerLBName()
+				spec.InternalLBResourceGroup = m.APIServerLB().ResourceGroup
+				// The load balancer lives in the cluster resource group, which may differ from the NIC resource group.
+				if spec.InternalLBResourceGroup == "" && m.NodeResourceGroup() != m.ResourceGroup() {
+					spec.InternalLBResourceGroup = m.ResourceGroup()
+				}
 				spec.InternalLBAddressPoolName = m.APIServerLBPoolName()
-			} else {
-				spec.PublicLBNATRuleName = m.Name()
+			default:
+				// Inbound NAT rules are not created on an existing load balancer.
+				if m.APIServerLB().ResourceGroup == "" {
+					spec.PublicLBNATRuleName = m.Name()
+				}
 				spec.PublicLBAddressPoolName = m.APIServerLBPoolName()
 			}
 		}
 
 		if m.Role() == infrav1.Node && m.AzureMachine.Spec.AllocatePublicIP {
-			spec.PublicIPName = azure.GenerateNodePublicIPName(m.Name())
+			spec.PublicIPName = azure.NodePublicIPName(m.ResourceNaming(), m.Name())
 		}
 		// If the NAT gateway is not enabled and node has no public IP, then the NIC needs to reference the LB to get outbound traffic.
 		if m.Role() == infrav1.Node && !m.Subnet().IsNatGatewayEnabled() && !m.AzureMachine.Spec.AllocatePublicIP {
 			spec.PublicLBName = m.OutboundLBName(m.Role())
+			spec.PublicLBResourceGroup = m.OutboundLBResourceGroup(m.Role())
 			spec.PublicLBAddressPoolName = m.OutboundPoolName(m.Role())
 		}
 	}
@@ -330,13 +420,13 @@ func (m *MachineScope) NICIDs() []string {
 func (m *MachineScope) DiskSpecs() []azure.ResourceSpecGetter {
 	diskSpecs := make([]azure.ResourceSpecGetter, 1+len(m.AzureMachine.Spec.DataDisks))
 	diskSpecs[0] = &disks.DiskSpec{
-		Name:          azure.GenerateOSDiskName(m.Name()),
+		Name:          azure.OSDiskName(m.ResourceNaming(), m.Name()),
 		ResourceGroup: m.NodeResourceGroup(),
 	}
 
 	for i, dd := range m.AzureMachine.Spec.DataDisks {
 		diskSpecs[i+1] = &disks.DiskSpec{
-			Name:          azure.GenerateDataDiskName(m.Name(), dd.NameSuffix),
+			Name:          azure.DataDiskName(m.ResourceNaming(), m.Name(), dd.NameSuffix),
 			ResourceGroup: m.NodeResourceGroup(),
 		}
 	}
@@ -395,10 +485,49 @@ func (m *MachineScope) VMExtensionSpecs() []azure.ResourceSpecGetter {
 		})
 	}
 
+	if windowsConfiguration := m.AzureMachine.Spec.WindowsConfiguration; windowsConfiguration != nil && windowsConfiguration.DomainJoin != nil {
+		domainJoin := windowsConfiguration.DomainJoin
+		extensionSpecs = append(extensionSpecs, &vmextensions.VMExtensionSpec{
+			ExtensionSpec: azure.ExtensionSpec{
+				Name:      azure.DomainJoinExtensionName,
+				VMName:    m.Name(),
+				Publisher: azure.DomainJoinExtensionPublisher,
+				Version:   azure.DomainJoinExtensionVersion,
+				Settings: map[string]string{
+					"Name":    domainJoin.DomainName,
+					"OUPath":  domainJoin.OUPath,
+					"User":    domainJoin.User,
+					"Restart": "true",
+					// Options 3 joins the domain and creates the computer account if it doesn't exist.
+					"Options": "3",
+				},
+				ProtectedSettings: map[string]string{
+					"Password": m.cache.DomainJoinPassword,
+				},
+			},
+			ResourceGroup: m.NodeResourceGroup(),
+			Location:      m.Location(),
+		})
+	}
+
+	if ptr.Deref(m.AzureMachine.Spec.InstallGPUDriver, false) {
+		if gpuDriverExtensionSpec := azure.GetGPUDriverVMExtension(m.AzureMachine.Spec.OSDisk.OSType, m.cache.VMSKU.GPUVendor(), m.Name()); gpuDriverExtensionSpec != nil {
+			extensionSpecs = append(extensionSpecs, &vmextensions.VMExtensionSpec{
+				ExtensionSpec: *gpuDriverExtensionSpec,
+				ResourceGroup: m.NodeResourceGroup(),
+				Location:      m.Location(),
+			})
+		}
+	}
+
 	cpuArchitectureType, _ := m.cache.VMSKU.GetCapability(resourceskus.CPUArchitectureType)
 	bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachine.Spec.OSDisk.OSType, m.CloudEnvironment(), m.Name(), cpuArchitectureType)
 
-	if bootstrapExtensionSpec != nil {
+	// A user-provided extension of the same publisher and type takes precedence, as a VM can only have one extension
+	// of each publisher and type.
+	if bootstrapExtensionSpec != nil && !slices.ContainsFunc(m.AzureMachine.Spec.VMExtensions, func(extension infrav1.VMExtension) bool {
+		return bootstrapExtensionSpec.IsSameVMExtensionType(extension.Publisher, extension.Name)
+	}) {
 		extensionSpecs = append(extensionSpecs, &vmextensions.VMExtensionSpec{
 			ExtensionSpec: *bootstrapExtensionSpec,
 			ResourceGroup: m.NodeResourceGroup(),
@@ -409,6 +538,59 @@ func (m *MachineScope) VMExtensionSpecs() []azure.ResourceSpecGetter {
 	return extensionSpecs
 }
 
+// JITAccessPolicySpec returns the just-in-time VM access policy spec, or nil if the machine has no just-in-time VM
+// access.
+func (m *MachineScope) JITAccessPolicySpec() azure.ResourceSpecGetter {
+	if m.AzureMachine.Spec.JITAccess == nil {
+		return nil
+	}
+	return &jitaccesspolicies.JITAccessPolicySpec{
+		Name:          m.Name(),
+		ResourceGroup: m.NodeResourceGroup(),
+		Location:      m.Location(),
+		VMID:          azure.VMID(m.SubscriptionID(), m.NodeResourceGroup(), m.Name()),
+		Ports:         m.AzureMachine.Spec.JITAccess.Ports,
+	}
+}
+
+// RequiredFeatures returns the subscription features required by the machine spec.
+func (m *MachineScope) RequiredFeatures() []string {
+	var requiredFeatures []string
+	if m.AzureMachine.Spec.SecurityProfile != nil && ptr.Deref(m.AzureMachine.Spec.SecurityProfile.EncryptionAtHost, false) {
+		requiredFeatures = append(requiredFeatures, features.EncryptionAtHost)
+	}
+	return requiredFeatures
+}
+
+// FeaturesResource returns the AzureMachine, on which the registration state of the required features is reported.
+func (m *MachineScope) FeaturesResource() conditions.Setter {
+	return m.AzureMachine
+}
+
+// SpotPlacementCheckSpec returns the spec of the preflight check of the Spot VM, or nil if the machine isn't a Spot VM
+// with a placement check, or if its VM was already created.
+func (m *MachineScope) SpotPlacementCheckSpec() *azure.SpotPlacementCheckSpec {
+	spotVMOptions := m.AzureMachine.Spec.SpotVMOptions
+	if spotVMOptions == nil || spotVMOptions.PlacementCheck == nil || m.ProviderID() != "" {
+		return nil
+	}
+	spec := &azure.SpotPlacementCheckSpec{
+		VMSize:       m.AzureMachine.Spec.VMSize,
+		Location:     m.Location(),
+		DesiredCount: 1,
+		Check:        *spotVMOptions.PlacementCheck,
+	}
+	if zone := m.AvailabilityZone(); zone != "" {
+		spec.Zones = []string{zone}
+	}
+	return spec
+}
+
+// SpotPlacementResource returns the AzureMachine, on which the result of the Spot VM preflight check is reported.
+func (m *MachineScope) SpotPlacementResource() conditions.Setter {
+	return m.AzureMachine
+}
+
 // Subnet returns the machine's subnet.
 func (m *MachineScope) Subnet() infrav1.SubnetSpec {
 	for _, subnet := range m.Subnets() {
@@ -437,6 +619,98 @@ func (m *MachineScope) AvailabilityZone() string {
 	return ""
 }
 
+// SetFailureDomain defaults the failure domain of a control plane machine which has none to the failure domain eligible
+// for the control plane which hosts the fewest control plane machines of the cluster, so that control planes whose
+// provider doesn't pick failure domains are still spread across availability zones. Failure domains which don't support
+// the VM size of the machine are skipped, and the failure domain of a machine whose VM was already created is left alone.
+func (m *MachineScope) SetFailureDomain(ctx context.Context) error {
+	if !m.IsControlPlane() || m.AvailabilityZone() != "" || m.ProviderID() != "" {
+		return nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := m.client.Get(ctx, client.ObjectKey{Namespace: m.Machine.Namespace, Name: m.Machine.Spec.ClusterName}, cluster); err != nil {
+		return errors.Wrap(err, "failed to get cluster")
+	}
+	supportedZones := m.vmSKUZones()
+	var candidates []string
+	for _, id := range cluster.Status.FailureDomains.FilterControlPlane().GetIDs() {
+		if supportedZones == nil || supportedZones[*id] {
+			candidates = append(candidates, *id)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+
+	machines := &clusterv1.MachineList{}
+	if err := m.client.List(ctx, machines, client.InNamespace(m.Machine.Namespace), client.MatchingLabels{
+		clusterv1.ClusterNameLabel:         m.Machine.Spec.ClusterName,
+		clusterv1.MachineControlPlaneLabel: "",
+	}); err != nil {
+		return errors.Wrap(err, "failed to list control plane machines")
+	}
+	machinesPerZone := make(map[string]int)
+	for _, machine := range machines.Items {
+		if machine.Name == m.Machine.Name {
+			continue
+		}
+		if machine.Spec.FailureDomain != nil {
+			machinesPerZone[*machine.Spec.FailureDomain]++
+			continue
+		}
+		azureMachine := &infrav1.AzureMachine{}
+		key := client.ObjectKey{Namespace: machine.Namespace, Name: machine.Spec.InfrastructureRef.Name}
+		if err := m.client.Get(ctx, key, azureMachine); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrap(err, "failed to get control plane AzureMachine")
+		}
+		if azureMachine.Spec.FailureDomain != nil {
+			machinesPerZone[*azureMachine.Spec.FailureDomain]++
+		}
+	}
+
+	// Ties are broken in favor of the lowest failure domain.
+	zone := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if machinesPerZone[candidate] < machinesPerZone[zone] {
+			zone = candidate
+		}
+	}
+	m.AzureMachine.Spec.FailureDomain = ptr.To(zone)
+	return nil
+}
+
+// vmSKUZones returns the availability zones of the location of the machine where its VM size is available, or nil when
+// they are unknown.
+func (m *MachineScope) vmSKUZones() map[string]bool {
+	if m.cache == nil {
+		return nil
+	}
+	for _, info := range m.cache.VMSKU.LocationInfo {
+		if info == nil || !strings.EqualFold(ptr.Deref(info.Location, ""), m.Location()) {
+			continue
+		}
+		zones := make(map[string]bool)
+		for _, zone := range info.Zones {
+			zones[ptr.Deref(zone, "")] = true
+		}
+		for _, restriction := range m.cache.VMSKU.Restrictions {
+			if restriction == nil || restriction.RestrictionInfo == nil {
+				continue
+			}
+			for _, zone := range restriction.RestrictionInfo.Zones {
+				delete(zones, ptr.Deref(zone, ""))
+			}
+		}
+		return zones
+	}
+	return nil
+}
+
 // Name returns the AzureMachine name.
 func (m *MachineScope) Name() string {
 	if id := m.GetVMID(); id != "" {
@@ -592,7 +866,7 @@ func (m *MachineScope) SetNotReady() {
 
 // SetFailureMessage sets the AzureMachine status failure message.
 func (m *MachineScope) SetFailureMessage(v error) {
-	m.AzureMachine.Status.FailureMessage = ptr.To(v.Error())
+	m.AzureMachine.Status.FailureMessage = ptr.To(azure.ErrorMessage(v))
 }
 
 // SetFailureReason sets the AzureMachine status failure reason.
@@ -613,6 +887,21 @@ func (m *MachineScope) SetAnnotation(key, value string) {
 	m.AzureMachine.Annotations[key] = value
 }
 
+// EventObject returns the AzureMachine, which receives Events about the operations performed on its Azure resources.
+func (m *MachineScope) EventObject() client.Object {
+	return m.AzureMachine
+}
+
+// DryRun returns true if the AzureMachine has the dry-run annotation set to "true".
+func (m *MachineScope) DryRun() bool {
+	return m.AzureMachine.Annotations[infrav1.DryRunAnnotation] == "true"
+}
+
+// OrphanLockedResources returns true if the AzureMachine has the locked-resource-policy annotation set to "Orphan".
+func (m *MachineScope) OrphanLockedResources() bool {
+	return m.AzureMachine.Annotations[infrav1.LockedResourcePolicyAnnotation] == infrav1.LockedResourcePolicyOrphan
+}
+
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (m *MachineScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
@@ -657,6 +946,11 @@ func (m *MachineScope) PatchObject(ctx context.Context) error {
 			infrav1.VMRunningCondition,
 			infrav1.AvailabilitySetReadyCondition,
 			infrav1.NetworkInterfaceReadyCondition,
+			infrav1.PublicIPsReadyCondition,
+			infrav1.InboundNATRulesReadyCondition,
+			infrav1.DisksReadyCondition,
+			infrav1.VMIdentitiesReadyCondition,
+			infrav1.BootstrapSucceededCondition,
 		}})
 }
 
@@ -700,6 +994,117 @@ func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
 	return base64.StdEncoding.EncodeToString(value), nil
 }
 
+// GetBootstrapDataFormat returns the format of the bootstrap data, e.g. "cloud-config" or "ignition", from the
+// secret in the Machine's bootstrap.dataSecretName. It returns an empty format if the secret doesn't specify it.
+func (m *MachineScope) GetBootstrapDataFormat(ctx context.Context) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachineScope.GetBootstrapDataFormat")
+	defer done()
+
+	if m.Machine.Spec.Bootstrap.DataSecretName == nil {
+		return "", errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: m.Namespace(), Name: *m.Machine.Spec.Bootstrap.DataSecretName}
+	if err := m.client.Get(ctx, key, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve bootstrap data secret for AzureMachine %s/%s", m.Namespace(), m.Name())
+	}
+	return string(secret.Data["format"]), nil
+}
+
+// BootstrapData returns the base64 encoded bootstrap data passed to the VM as custom data.
+func (m *MachineScope) BootstrapData() string {
+	if m.cache == nil {
+		return ""
+	}
+	return m.cache.BootstrapData
+}
+
+// BootstrapDataFormat returns the format of the bootstrap data of the machine.
+func (m *MachineScope) BootstrapDataFormat() string {
+	if m.cache == nil {
+		return ""
+	}
+	return m.cache.BootstrapDataFormat
+}
+
+// SetBootstrapData replaces the base64 encoded bootstrap data passed to the VM as custom data, e.g. by custom data
+// fetching it from a storage account.
+func (m *MachineScope) SetBootstrapData(data string) {
+	if m.cache == nil {
+		return
+	}
+	m.cache.BootstrapData = data
+}
+
+// BootstrapDataBlobSpec returns the spec of the blob the bootstrap data of the machine is uploaded to when it exceeds
+// the custom data size limit, or nil if the machine has no bootstrap data storage.
+func (m *MachineScope) BootstrapDataBlobSpec() *azure.BootstrapDataBlobSpec {
+	storage := m.AzureMachine.Spec.BootstrapDataStorage
+	if storage == nil {
+		return nil
+	}
+	resourceGroup := storage.ResourceGroup
+	if resourceGroup == "" {
+		resourceGroup = m.ResourceGroup()
+	}
+	return &azure.BootstrapDataBlobSpec{
+		StorageAccountName: storage.StorageAccountName,
+		ResourceGroup:      resourceGroup,
+		ContainerName:      storage.ContainerName,
+		BlobName:           m.Namespace() + "/" + m.Name(),
+		Access:             storage.Access,
+	}
+}
+
+// keyVaultSecretNameRegex matches the characters which can't be part of the name of a key vault secret.
+var keyVaultSecretNameRegex = regexp.MustCompile(`[^0-9a-zA-Z-]`)
+
+// BootstrapDataKeyVaultSpec returns the spec of the key vault secrets the bootstrap data of the machine is stored in,
+// or nil if the machine has no bootstrap data key vault.
+func (m *MachineScope) BootstrapDataKeyVaultSpec() *azure.BootstrapDataKeyVaultSpec {
+	keyVault := m.AzureMachine.Spec.BootstrapDataKeyVault
+	if keyVault == nil {
+		return nil
+	}
+	// Secret names are at most 127 characters long, leaving room for the index suffix. Sanitizing and truncating the
+	// namespace and name of the machine can make them collide, so the name ends with a hash of both.
+	hash := sha256.Sum256([]byte(m.Namespace() + "/" + m.Name()))
+	suffix := "-" + hex.EncodeToString(hash[:8])
+	secretName := keyVaultSecretNameRegex.ReplaceAllString(m.Namespace()+"-"+m.Name(), "-")
+	if maxLength := 120 - len(suffix); len(secretName) > maxLength {
+		secretName = secretName[:maxLength]
+	}
+	return &azure.BootstrapDataKeyVaultSpec{
+		VaultName:        keyVault.VaultName,
+		SecretName:       secretName + suffix,
+		IdentityClientID: keyVault.IdentityClientID,
+	}
+}
+
+// GetDomainJoinPassword returns the password of the domain account joining a Windows machine to an Active Directory
+// domain, from the secret referenced by the machine's windowsConfiguration.domainJoin.passwordSecretRef. It returns an
+// empty password when the machine doesn't join a domain.
+func (m *MachineScope) GetDomainJoinPassword(ctx context.Context) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachineScope.GetDomainJoinPassword")
+	defer done()
+
+	windowsConfiguration := m.AzureMachine.Spec.WindowsConfiguration
+	if windowsConfiguration == nil || windowsConfiguration.DomainJoin == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: m.Namespace(), Name: windowsConfiguration.DomainJoin.PasswordSecretRef.Name}
+	if err := m.client.Get(ctx, key, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve domain join password secret for AzureMachine %s/%s", m.Namespace(), m.Name())
+	}
+
+	password, ok := secret.Data[DomainJoinPasswordSecretKey]
+	if !ok {
+		return "", errors.Errorf("error retrieving domain join password: secret %s key is missing", DomainJoinPasswordSecretKey)
+	}
+	return string(password), nil
+}
+
 // GetVMImage returns the image from the machine configuration, or a default one.
 func (m *MachineScope) GetVMImage(ctx context.Context) (*infrav1.Image, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "scope.MachineScope.GetVMImage")
@@ -722,6 +1127,11 @@ func (m *MachineScope) GetVMImage(ctx context.Context) (*infrav1.Image, error) {
 		return svc.GetDefaultWindowsImage(ctx, m.Location(), ptr.Deref(m.Machine.Spec.Version, ""), runtime, windowsServerVersion)
 	}
 
+	if m.AzureMachine.Spec.FIPS {
+		log.Info("No image specified for machine, using default FIPS-enabled Linux Image", "machine", m.AzureMachine.GetName())
+		return svc.GetDefaultFIPSUbuntuImage(ctx, m.Location(), ptr.Deref(m.Machine.Spec.Version, ""))
+	}
+
 	log.Info("No image specified for machine, using default Linux Image", "machine", m.AzureMachine.GetName())
 	return svc.GetDefaultUbuntuImage(ctx, m.Location(), ptr.Deref(m.Machine.Spec.Version, ""))
 }
@@ -760,6 +1170,45 @@ func (m *MachineScope) SetSubnetName() error {
 	return nil
 }
 
+// CaptureDiagnosticsOnDelete returns whether the diagnostics of the VM should be captured before it is deleted, which is
+// the case when they are enabled and the machine failed or is unhealthy.
+func (m *MachineScope) CaptureDiagnosticsOnDelete() bool {
+	if m.AzureMachine.Spec.Diagnostics == nil || !m.AzureMachine.Spec.Diagnostics.CaptureOnFailure {
+		return false
+	}
+	return m.AzureMachine.Status.FailureReason != nil || conditions.IsFalse(m.Machine, clusterv1.MachineHealthCheckSucceededCondition)
+}
+
+// RestartRequested returns whether the restart of the VM was requested with the restart annotation.
+func (m *MachineScope) RestartRequested() bool {
+	return m.AzureMachine.Annotations[infrav1.RestartAnnotation] == "true" && !m.DryRun()
+}
+
+// ClearRestartRequest removes the restart annotation once the restart of the VM was requested.
+func (m *MachineScope) ClearRestartRequest() {
+	delete(m.AzureMachine.Annotations, infrav1.RestartAnnotation)
+}
+
+// RedeployRequested returns whether the redeployment of the VM was requested with the redeploy annotation.
+func (m *MachineScope) RedeployRequested() bool {
+	return m.AzureMachine.Annotations[infrav1.RedeployAnnotation] == "true" && !m.DryRun()
+}
+
+// ClearRedeployRequest removes the redeploy annotation once the redeployment of the VM was requested.
+func (m *MachineScope) ClearRedeployRequest() {
+	delete(m.AzureMachine.Annotations, infrav1.RedeployAnnotation)
+}
+
+// ResizeInPlace returns whether the VM should be resized in place when the VM size of the AzureMachine changes.
+func (m *MachineScope) ResizeInPlace() bool {
+	return m.AzureMachine.Spec.ResizePolicy == infrav1.VMResizePolicyInPlace && !m.DryRun()
+}
+
+// IsVMResizing returns whether an in-place resize of the VM is in progress.
+func (m *MachineScope) IsVMResizing() bool {
+	return conditions.IsFalse(m.AzureMachine, infrav1.VMResizedCondition)
+}
+
 // SetLongRunningOperationState will set the future on the AzureMachine status to allow the resource to continue
 // in the next reconciliation.
 func (m *MachineScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -782,9 +1231,9 @@ func (m *MachineScope) UpdateDeleteStatus(condition clusterv1.ConditionType, ser
 	case err == nil:
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -794,9 +1243,9 @@ func (m *MachineScope) UpdatePutStatus(condition clusterv1.ConditionType, servic
 	case err == nil:
 		conditions.MarkTrue(m.AzureMachine, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -806,9 +1255,9 @@ func (m *MachineScope) UpdatePatchStatus(condition clusterv1.ConditionType, serv
 	case err == nil:
 		conditions.MarkTrue(m.AzureMachine, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
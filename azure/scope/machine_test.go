@@ -19,17 +19,21 @@ package scope
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -44,6 +48,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestMachineScope_Name(t *testing.T) {
@@ -329,6 +336,49 @@ func TestMachineScope_PublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "sets a DNS name label on the PublicIPSpec if EnablePublicIPDNSLabel is true",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						AllocatePublicIP:       true,
+						EnablePublicIPDNSLabel: true,
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-cluster",
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "centralIndia",
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:           "pip-machine-name",
+					ResourceGroup:  "my-rg",
+					DNSName:        azure.GenerateNodePublicIPDNSLabel("", "my-rg", "machine-name"),
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []*string{},
+					AdditionalTags: infrav1.Tags{},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -409,6 +459,68 @@ func TestMachineScope_InboundNatSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "returns empty when the control plane endpoint is externally managed",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineControlPlaneLabel: "",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup:                         "my-rg",
+							ExternallyManagedControlPlaneEndpoint: true,
+							NetworkSpec: infrav1.NetworkSpec{
+								APIServerLB: infrav1.LoadBalancerSpec{
+									Name: "foo-loadbalancer",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
+		{
+			name: "returns empty when the API server load balancer is an existing one from another resource group",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineControlPlaneLabel: "",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							NetworkSpec: infrav1.NetworkSpec{
+								APIServerLB: infrav1.LoadBalancerSpec{
+									Name:          "foo-loadbalancer",
+									ResourceGroup: "my-lb-rg",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -598,6 +710,103 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "If InstallGPUDriver is true and the VM size has NVIDIA GPUs, it returns the GPU driver ExtensionSpec",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						InstallGPUDriver: ptr.To(true),
+						OSDisk: infrav1.OSDisk{
+							OSType: "Linux",
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.GermanCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+						},
+					},
+				},
+				cache: &MachineCache{
+					VMSKU: resourceskus.SKU{
+						Name: ptr.To("Standard_NC6s_v3"),
+						Capabilities: []*armcompute.ResourceSKUCapabilities{
+							{Name: ptr.To(resourceskus.GPUs), Value: ptr.To("1")},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vmextensions.VMExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      azure.NvidiaGPUDriverExtensionLinux,
+						VMName:    "machine-name",
+						Publisher: azure.GPUDriverExtensionPublisher,
+						Version:   "1.9",
+					},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+				},
+			},
+		},
+		{
+			name: "If InstallGPUDriver is true and the VM size has AMD GPUs on Linux, it returns empty",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						InstallGPUDriver: ptr.To(true),
+						OSDisk: infrav1.OSDisk{
+							OSType: "Linux",
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.GermanCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+						},
+					},
+				},
+				cache: &MachineCache{
+					VMSKU: resourceskus.SKU{
+						Name: ptr.To("Standard_NV8as_v4"),
+						Capabilities: []*armcompute.ResourceSKUCapabilities{
+							{Name: ptr.To(resourceskus.GPUs), Value: ptr.To("1")},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
 		{
 			name: "If OS type is Linux and cloud is AzurePublicCloud and DisableExtensionOperations is true, it returns empty",
 			machineScope: MachineScope{
@@ -629,15 +838,183 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 							},
 						},
 					},
-				},
-				cache: &MachineCache{
-					VMSKU: resourceskus.SKU{},
+				},
+				cache: &MachineCache{
+					VMSKU: resourceskus.SKU{},
+				},
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
+		{
+			name: "If OS type is Linux and cloud is not AzurePublicCloud, it returns empty",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							OSType: "Linux",
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.GermanCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+						},
+					},
+				},
+				cache: &MachineCache{
+					VMSKU: resourceskus.SKU{},
+				},
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
+		{
+			name: "If OS type is Windows and cloud is AzurePublicCloud, it returns ExtensionSpec",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							OSType: "Windows",
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.PublicCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+						},
+					},
+				},
+				cache: &MachineCache{
+					VMSKU: resourceskus.SKU{},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vmextensions.VMExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "CAPZ.Windows.Bootstrapping",
+						VMName:    "machine-name",
+						Publisher: "Microsoft.Azure.ContainerUpstream",
+						Version:   "1.0",
+						ProtectedSettings: map[string]string{
+							"commandToExecute": azure.WindowsBootstrapExtensionCommand,
+						},
+					},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+				},
+			},
+		},
+		{
+			name: "If OS type is Windows and the machine joins a domain, it returns the domain join ExtensionSpec",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							OSType: "Windows",
+						},
+						WindowsConfiguration: &infrav1.WindowsConfiguration{
+							DomainJoin: &infrav1.WindowsDomainJoin{
+								DomainName:        "contoso.com",
+								OUPath:            "OU=k8s,DC=contoso,DC=com",
+								User:              "contoso.com\\joiner",
+								PasswordSecretRef: corev1.LocalObjectReference{Name: "domain-join"},
+							},
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.PublicCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+						},
+					},
+				},
+				cache: &MachineCache{
+					VMSKU:              resourceskus.SKU{},
+					DomainJoinPassword: "secret",
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vmextensions.VMExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "JsonADDomainExtension",
+						VMName:    "machine-name",
+						Publisher: "Microsoft.Compute",
+						Version:   "1.3",
+						Settings: map[string]string{
+							"Name":    "contoso.com",
+							"OUPath":  "OU=k8s,DC=contoso,DC=com",
+							"User":    "contoso.com\\joiner",
+							"Restart": "true",
+							"Options": "3",
+						},
+						ProtectedSettings: map[string]string{
+							"Password": "secret",
+						},
+					},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+				},
+				&vmextensions.VMExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "CAPZ.Windows.Bootstrapping",
+						VMName:    "machine-name",
+						Publisher: "Microsoft.Azure.ContainerUpstream",
+						Version:   "1.0",
+						ProtectedSettings: map[string]string{
+							"commandToExecute": azure.WindowsBootstrapExtensionCommand,
+						},
+					},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
 				},
 			},
-			want: []azure.ResourceSpecGetter{},
 		},
 		{
-			name: "If OS type is Linux and cloud is not AzurePublicCloud, it returns empty",
+			name: "If OS type is Windows and cloud is not AzurePublicCloud, it returns empty",
 			machineScope: MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
@@ -646,7 +1023,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 					},
 					Spec: infrav1.AzureMachineSpec{
 						OSDisk: infrav1.OSDisk{
-							OSType: "Linux",
+							OSType: "Windows",
 						},
 					},
 				},
@@ -654,7 +1031,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
 							Environment: azureautorest.Environment{
-								Name: azureautorest.USGovernmentCloud.Name,
+								Name: azureautorest.GermanCloud.Name,
 							},
 						},
 					},
@@ -674,7 +1051,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 			want: []azure.ResourceSpecGetter{},
 		},
 		{
-			name: "If OS type is Windows and cloud is AzurePublicCloud, it returns ExtensionSpec",
+			name: "If OS type is Linux and cloud is AzureUSGovernmentCloud, it returns the custom script ExtensionSpec",
 			machineScope: MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
@@ -683,7 +1060,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 					},
 					Spec: infrav1.AzureMachineSpec{
 						OSDisk: infrav1.OSDisk{
-							OSType: "Windows",
+							OSType: "Linux",
 						},
 					},
 				},
@@ -691,7 +1068,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
 							Environment: azureautorest.Environment{
-								Name: azureautorest.PublicCloud.Name,
+								Name: azureautorest.USGovernmentCloud.Name,
 							},
 						},
 					},
@@ -711,12 +1088,12 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 			want: []azure.ResourceSpecGetter{
 				&vmextensions.VMExtensionSpec{
 					ExtensionSpec: azure.ExtensionSpec{
-						Name:      "CAPZ.Windows.Bootstrapping",
+						Name:      "CustomScript",
 						VMName:    "machine-name",
-						Publisher: "Microsoft.Azure.ContainerUpstream",
-						Version:   "1.0",
+						Publisher: "Microsoft.Azure.Extensions",
+						Version:   "2.1",
 						ProtectedSettings: map[string]string{
-							"commandToExecute": azure.WindowsBootstrapExtensionCommand,
+							"commandToExecute": azure.LinuxBootstrapExtensionCommand,
 						},
 					},
 					ResourceGroup: "my-rg",
@@ -725,7 +1102,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 			},
 		},
 		{
-			name: "If OS type is Windows and cloud is not AzurePublicCloud, it returns empty",
+			name: "If a custom VM extension has the publisher and type of the bootstrapping extension, it returns only the custom VM extension",
 			machineScope: MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
@@ -733,8 +1110,15 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 						Name: "machine-name",
 					},
 					Spec: infrav1.AzureMachineSpec{
+						VMExtensions: []infrav1.VMExtension{
+							{
+								Name:      "customscript",
+								Publisher: "microsoft.azure.extensions",
+								Version:   "2.1",
+							},
+						},
 						OSDisk: infrav1.OSDisk{
-							OSType: "Windows",
+							OSType: "Linux",
 						},
 					},
 				},
@@ -759,7 +1143,18 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 					VMSKU: resourceskus.SKU{},
 				},
 			},
-			want: []azure.ResourceSpecGetter{},
+			want: []azure.ResourceSpecGetter{
+				&vmextensions.VMExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "customscript",
+						VMName:    "machine-name",
+						Publisher: "microsoft.azure.extensions",
+						Version:   "2.1",
+					},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+				},
+			},
 		},
 		{
 			name: "If OS type is not Linux or Windows and cloud is AzurePublicCloud, it returns empty",
@@ -816,7 +1211,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
 							Environment: azureautorest.Environment{
-								Name: azureautorest.USGovernmentCloud.Name,
+								Name: azureautorest.GermanCloud.Name,
 							},
 						},
 					},
@@ -1092,6 +1487,268 @@ func TestMachineScope_AvailabilityZone(t *testing.T) {
 	}
 }
 
+func TestMachineScope_SetFailureDomain(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Status: clusterv1.ClusterStatus{
+			FailureDomains: clusterv1.FailureDomains{
+				"1": {ControlPlane: true},
+				"2": {ControlPlane: true},
+				"3": {ControlPlane: true},
+				"4": {ControlPlane: false},
+			},
+		},
+	}
+	controlPlaneMachine := func(name string, failureDomain *string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:         "my-cluster",
+					clusterv1.MachineControlPlaneLabel: "",
+				},
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName:       "my-cluster",
+				FailureDomain:     failureDomain,
+				InfrastructureRef: corev1.ObjectReference{Name: name},
+			},
+		}
+	}
+	azureMachine := func(name string, failureDomain *string) *infrav1.AzureMachine {
+		return &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       infrav1.AzureMachineSpec{FailureDomain: failureDomain},
+		}
+	}
+	vmSKU := resourceskus.SKU{
+		LocationInfo: []*armcompute.ResourceSKULocationInfo{
+			{
+				Location: ptr.To("westus2"),
+				Zones:    []*string{ptr.To("1"), ptr.To("2")},
+			},
+		},
+		Restrictions: []*armcompute.ResourceSKURestrictions{
+			{
+				RestrictionInfo: &armcompute.ResourceSKURestrictionInfo{Zones: []*string{ptr.To("2")}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		machine      *clusterv1.Machine
+		azureMachine *infrav1.AzureMachine
+		objects      []runtime.Object
+		cache        *MachineCache
+		want         *string
+	}{
+		{
+			name:         "worker machine is left alone",
+			machine:      &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"}},
+			azureMachine: azureMachine("worker", nil),
+			objects:      []runtime.Object{cluster},
+		},
+		{
+			name:         "control plane machine with a failure domain is left alone",
+			machine:      controlPlaneMachine("cp-0", ptr.To("2")),
+			azureMachine: azureMachine("cp-0", nil),
+			objects:      []runtime.Object{cluster},
+		},
+		{
+			name:    "control plane machine whose VM exists is left alone",
+			machine: controlPlaneMachine("cp-0", nil),
+			azureMachine: func() *infrav1.AzureMachine {
+				am := azureMachine("cp-0", nil)
+				am.Spec.ProviderID = ptr.To("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/cp-0")
+				return am
+			}(),
+			objects: []runtime.Object{cluster},
+		},
+		{
+			name:         "control plane machine gets the failure domain with the fewest control plane machines",
+			machine:      controlPlaneMachine("cp-2", nil),
+			azureMachine: azureMachine("cp-2", nil),
+			objects: []runtime.Object{
+				cluster,
+				controlPlaneMachine("cp-0", ptr.To("1")),
+				controlPlaneMachine("cp-1", nil),
+				azureMachine("cp-1", ptr.To("2")),
+			},
+			want: ptr.To("3"),
+		},
+		{
+			name:         "ties are broken in favor of the lowest failure domain",
+			machine:      controlPlaneMachine("cp-1", nil),
+			azureMachine: azureMachine("cp-1", nil),
+			objects:      []runtime.Object{cluster, controlPlaneMachine("cp-0", ptr.To("1"))},
+			want:         ptr.To("2"),
+		},
+		{
+			name:         "failure domains not supporting the VM size are skipped",
+			machine:      controlPlaneMachine("cp-1", nil),
+			azureMachine: azureMachine("cp-1", nil),
+			objects:      []runtime.Object{cluster, controlPlaneMachine("cp-0", ptr.To("1"))},
+			cache:        &MachineCache{VMSKU: vmSKU},
+			want:         ptr.To("1"),
+		},
+		{
+			name:         "cluster without failure domains",
+			machine:      controlPlaneMachine("cp-0", nil),
+			azureMachine: azureMachine("cp-0", nil),
+			objects:      []runtime.Object{&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clusterScoper := mock_azure.NewMockClusterScoper(mockCtrl)
+			clusterScoper.EXPECT().Location().Return("westus2").AnyTimes()
+
+			machineScope := MachineScope{
+				client:        fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tt.objects...).Build(),
+				ClusterScoper: clusterScoper,
+				Machine:       tt.machine,
+				AzureMachine:  tt.azureMachine,
+				cache:         tt.cache,
+			}
+			g.Expect(machineScope.SetFailureDomain(context.Background())).To(Succeed())
+			g.Expect(machineScope.AzureMachine.Spec.FailureDomain).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMachineScope_CaptureDiagnosticsOnDelete(t *testing.T) {
+	captureOnFailure := &infrav1.Diagnostics{
+		Boot:             &infrav1.BootDiagnostics{StorageAccountType: infrav1.UserManagedDiagnosticsStorage},
+		CaptureOnFailure: true,
+	}
+	unhealthyMachine := &clusterv1.Machine{}
+	conditions.MarkFalse(unhealthyMachine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.UnhealthyNodeConditionReason, clusterv1.ConditionSeverityWarning, "")
+
+	tests := []struct {
+		name         string
+		machine      *clusterv1.Machine
+		azureMachine *infrav1.AzureMachine
+		want         bool
+	}{
+		{
+			name:         "capture is not enabled",
+			machine:      unhealthyMachine,
+			azureMachine: &infrav1.AzureMachine{},
+			want:         false,
+		},
+		{
+			name:         "healthy machine",
+			machine:      &clusterv1.Machine{},
+			azureMachine: &infrav1.AzureMachine{Spec: infrav1.AzureMachineSpec{Diagnostics: captureOnFailure}},
+			want:         false,
+		},
+		{
+			name:         "machine failing its health check",
+			machine:      unhealthyMachine,
+			azureMachine: &infrav1.AzureMachine{Spec: infrav1.AzureMachineSpec{Diagnostics: captureOnFailure}},
+			want:         true,
+		},
+		{
+			name:    "failed machine",
+			machine: &clusterv1.Machine{},
+			azureMachine: &infrav1.AzureMachine{
+				Spec:   infrav1.AzureMachineSpec{Diagnostics: captureOnFailure},
+				Status: infrav1.AzureMachineStatus{FailureReason: ptr.To(capierrors.CreateMachineError)},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{Machine: tt.machine, AzureMachine: tt.azureMachine}
+			g.Expect(machineScope.CaptureDiagnosticsOnDelete()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMachineScope_RestartRequested(t *testing.T) {
+	g := NewWithT(t)
+	machineScope := MachineScope{
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{infrav1.RestartAnnotation: "true"},
+			},
+		},
+	}
+	g.Expect(machineScope.RestartRequested()).To(BeTrue())
+
+	machineScope.AzureMachine.Annotations[infrav1.DryRunAnnotation] = "true"
+	g.Expect(machineScope.RestartRequested()).To(BeFalse())
+
+	machineScope.ClearRestartRequest()
+	g.Expect(machineScope.AzureMachine.Annotations).NotTo(HaveKey(infrav1.RestartAnnotation))
+}
+
+func TestMachineScope_RedeployRequested(t *testing.T) {
+	g := NewWithT(t)
+	machineScope := MachineScope{
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{infrav1.RedeployAnnotation: "true"},
+			},
+		},
+	}
+	g.Expect(machineScope.RedeployRequested()).To(BeTrue())
+
+	machineScope.AzureMachine.Annotations[infrav1.DryRunAnnotation] = "true"
+	g.Expect(machineScope.RedeployRequested()).To(BeFalse())
+
+	machineScope.ClearRedeployRequest()
+	g.Expect(machineScope.AzureMachine.Annotations).NotTo(HaveKey(infrav1.RedeployAnnotation))
+}
+
+func TestMachineScope_SpotPlacementCheckSpec(t *testing.T) {
+	g := NewWithT(t)
+	machineScope := MachineScope{
+		Machine: &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{FailureDomain: ptr.To("2")},
+		},
+		AzureMachine: &infrav1.AzureMachine{
+			Spec: infrav1.AzureMachineSpec{
+				VMSize:        "Standard_D2s_v3",
+				SpotVMOptions: &infrav1.SpotVMOptions{},
+			},
+		},
+		ClusterScoper: &ClusterScope{
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						Location: "westus2",
+					},
+				},
+			},
+		},
+	}
+	g.Expect(machineScope.SpotPlacementCheckSpec()).To(BeNil())
+
+	machineScope.AzureMachine.Spec.SpotVMOptions.PlacementCheck = &infrav1.SpotPlacementCheck{Policy: infrav1.SpotPlacementCheckPolicyFail}
+	g.Expect(machineScope.SpotPlacementCheckSpec()).To(Equal(&azure.SpotPlacementCheckSpec{
+		VMSize:       "Standard_D2s_v3",
+		Location:     "westus2",
+		Zones:        []string{"2"},
+		DesiredCount: 1,
+		Check:        infrav1.SpotPlacementCheck{Policy: infrav1.SpotPlacementCheckPolicyFail},
+	}))
+
+	machineScope.AzureMachine.Spec.ProviderID = ptr.To("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")
+	g.Expect(machineScope.SpotPlacementCheckSpec()).To(BeNil())
+}
+
 func TestMachineScope_Namespace(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1855,6 +2512,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "outbound-lb",
+					PublicLBResourceGroup:     "my-rg",
 					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
 					PublicLBNATRuleName:       "",
 					InternalLBName:            "",
@@ -1967,6 +2625,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "outbound-lb",
+					PublicLBResourceGroup:     "my-rg",
 					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
 					PublicLBNATRuleName:       "",
 					InternalLBName:            "",
@@ -2406,6 +3065,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "api-lb",
+					PublicLBResourceGroup:     "my-rg",
 					PublicLBAddressPoolName:   "api-lb-backendPool",
 					PublicLBNATRuleName:       "machine-name",
 					InternalLBName:            "",
@@ -2517,6 +3177,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "api-lb",
+					PublicLBResourceGroup:     "my-rg",
 					PublicLBAddressPoolName:   "api-lb-backendPool",
 					PublicLBNATRuleName:       "machine-name",
 					InternalLBName:            "",
@@ -2633,6 +3294,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "outbound-lb",
+					PublicLBResourceGroup:     "my-rg",
 					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
 					PublicLBNATRuleName:       "",
 					InternalLBName:            "",
@@ -2906,6 +3568,121 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "outbound-lb",
+					PublicLBResourceGroup:     "my-rg",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     ptr.To(true),
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: map[string]string{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
+		{
+			name: "Node Machine with multiple IPConfigs in a subnet with a pod subnet",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+											PodSubnet: &infrav1.PodSubnetSpec{
+												Name: "pod-subnet1",
+											},
+										},
+									},
+								},
+								APIServerLB: infrav1.LoadBalancerSpec{
+									Name: "api-lb",
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+									BackendPool: infrav1.BackendPool{
+										Name: "outbound-lb-outboundBackendPool",
+									},
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: ptr.To("azure:///subscriptions/1234-5678/resourceGroups/my-cluster/providers/Microsoft.Compute/virtualMachines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{
+							{
+								SubnetName:            "subnet1",
+								AcceleratedNetworking: ptr.To(true),
+								PrivateIPConfigs:      3,
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}},
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBResourceGroup:     "my-rg",
 					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
 					PublicLBNATRuleName:       "",
 					InternalLBName:            "",
@@ -2920,6 +3697,25 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 						"kubernetes.io_cluster_cluster": "owned",
 					},
 				},
+				&networkinterfaces.NICSpec{
+					Name:                  "machine-name-nic-pods",
+					ResourceGroup:         "my-rg",
+					Location:              "westus",
+					SubscriptionID:        "123",
+					MachineName:           "machine-name",
+					SubnetName:            "pod-subnet1",
+					IPConfigs:             []networkinterfaces.IPConfig{{}, {}},
+					VNetName:              "vnet1",
+					VNetResourceGroup:     "rg1",
+					AcceleratedNetworking: ptr.To(true),
+					IPv6Enabled:           false,
+					EnableIPForwarding:    false,
+					SKU:                   nil,
+					ClusterName:           "cluster",
+					AdditionalTags: map[string]string{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
 			},
 		},
 	}
@@ -3149,3 +3945,30 @@ func TestMachineScope_GetCapacityReservationGroupID(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineScope_BootstrapDataKeyVaultSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	machineScope := func(namespace, name string) *MachineScope {
+		return &MachineScope{
+			AzureMachine: &infrav1.AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: infrav1.AzureMachineSpec{
+					BootstrapDataKeyVault: &infrav1.BootstrapDataKeyVault{VaultName: "my-vault"},
+				},
+			},
+		}
+	}
+
+	spec := machineScope("team-a", "x").BootstrapDataKeyVaultSpec()
+	g.Expect(spec.VaultName).To(Equal("my-vault"))
+	g.Expect(spec.SecretName).To(MatchRegexp(`^team-a-x-[0-9a-f]{16}$`))
+	g.Expect(spec.SecretName).NotTo(Equal(machineScope("team", "a-x").BootstrapDataKeyVaultSpec().SecretName))
+
+	long := machineScope("default", strings.Repeat("a", 200)).BootstrapDataKeyVaultSpec().SecretName
+	g.Expect(long).To(HaveLen(120))
+	g.Expect(long).NotTo(Equal(machineScope("default", strings.Repeat("a", 199)).BootstrapDataKeyVaultSpec().SecretName))
+
+	g.Expect(machineScope("default", "my-vm").BootstrapDataKeyVaultSpec()).NotTo(BeNil())
+	g.Expect((&MachineScope{AzureMachine: &infrav1.AzureMachine{}}).BootstrapDataKeyVaultSpec()).To(BeNil())
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
@@ -35,6 +36,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	machinepool "sigs.k8s.io/cluster-api-provider-azure/azure/scope/strategies/machinepool_deployments"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/features"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
@@ -194,6 +196,7 @@ func (m *MachinePoolScope) ScaleSetSpec(ctx context.Context) azure.ResourceSpecG
 		VNetName:                     m.Vnet().Name,
 		VNetResourceGroup:            m.Vnet().ResourceGroup,
 		PublicLBName:                 m.OutboundLBName(infrav1.Node),
+		PublicLBResourceGroup:        m.OutboundLBResourceGroup(infrav1.Node),
 		PublicLBAddressPoolName:      m.OutboundPoolName(infrav1.Node),
 		AcceleratedNetworking:        m.AzureMachinePool.Spec.Template.NetworkInterfaces[0].AcceleratedNetworking,
 		Identity:                     m.AzureMachinePool.Spec.Identity,
@@ -208,6 +211,7 @@ func (m *MachinePoolScope) ScaleSetSpec(ctx context.Context) azure.ResourceSpecG
 		OrchestrationMode:            m.AzureMachinePool.Spec.OrchestrationMode,
 		Location:                     m.AzureMachinePool.Spec.Location,
 		SubscriptionID:               m.SubscriptionID(),
+		CloudEnvironment:             m.CloudEnvironment(),
 		HasReplicasExternallyManaged: m.HasReplicasExternallyManaged(ctx),
 		ClusterName:                  m.ClusterName(),
 		AdditionalTags:               m.AzureMachinePool.Spec.AdditionalTags,
@@ -215,6 +219,21 @@ func (m *MachinePoolScope) ScaleSetSpec(ctx context.Context) azure.ResourceSpecG
 		ZoneBalance:                  m.AzureMachinePool.Spec.ZoneBalance,
 	}
 
+	for _, subnet := range m.Subnets() {
+		if subnet.PodSubnet != nil {
+			if spec.PodSubnetNames == nil {
+				spec.PodSubnetNames = map[string]string{}
+			}
+			spec.PodSubnetNames[subnet.Name] = subnet.PodSubnet.Name
+		}
+		if len(subnet.ApplicationSecurityGroups) > 0 {
+			if spec.ApplicationSecurityGroupIDs == nil {
+				spec.ApplicationSecurityGroupIDs = map[string][]string{}
+			}
+			spec.ApplicationSecurityGroupIDs[subnet.Name] = azure.ApplicationSecurityGroupIDs(m.SubscriptionID(), m.ResourceGroup(), subnet.ApplicationSecurityGroups)
+		}
+	}
+
 	if m.AzureMachinePool.Spec.ZoneBalance != nil && len(m.MachinePool.Spec.FailureDomains) <= 1 {
 		log.V(4).Info("zone balance is enabled but one or less failure domains are specified, zone balance will be disabled")
 		spec.ZoneBalance = nil
@@ -619,7 +638,7 @@ func (m *MachinePoolScope) SetNotReady() {
 
 // SetFailureMessage sets the AzureMachinePool status failure message.
 func (m *MachinePoolScope) SetFailureMessage(v error) {
-	m.AzureMachinePool.Status.FailureMessage = ptr.To(v.Error())
+	m.AzureMachinePool.Status.FailureMessage = ptr.To(azure.ErrorMessage(v))
 }
 
 // SetFailureReason sets the AzureMachinePool status failure reason.
@@ -649,6 +668,21 @@ func (m *MachinePoolScope) SetAnnotation(key, value string) {
 	m.AzureMachinePool.Annotations[key] = value
 }
 
+// EventObject returns the AzureMachinePool, which receives Events about the operations performed on its Azure resources.
+func (m *MachinePoolScope) EventObject() client.Object {
+	return m.AzureMachinePool
+}
+
+// DryRun returns true if the AzureMachinePool has the dry-run annotation set to "true".
+func (m *MachinePoolScope) DryRun() bool {
+	return m.AzureMachinePool.Annotations[infrav1.DryRunAnnotation] == "true"
+}
+
+// OrphanLockedResources returns true if the AzureMachinePool has the locked-resource-policy annotation set to "Orphan".
+func (m *MachinePoolScope) OrphanLockedResources() bool {
+	return m.AzureMachinePool.Annotations[infrav1.LockedResourcePolicyAnnotation] == infrav1.LockedResourcePolicyOrphan
+}
+
 // PatchObject persists the AzureMachinePool spec and status.
 func (m *MachinePoolScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolScope.PatchObject")
@@ -776,6 +810,8 @@ func (m *MachinePoolScope) GetVMImage(ctx context.Context) (*infrav1.Image, erro
 		windowsServerVersion := m.AzureMachinePool.Annotations["windowsServerVersion"]
 		log.V(4).Info("No image specified for machine, using default Windows Image", "machine", m.MachinePool.GetName(), "runtime", runtime, "windowsServerVersion", windowsServerVersion)
 		defaultImage, err = svc.GetDefaultWindowsImage(ctx, m.Location(), ptr.Deref(m.MachinePool.Spec.Template.Spec.Version, ""), runtime, windowsServerVersion)
+	} else if m.AzureMachinePool.Spec.Template.FIPS {
+		defaultImage, err = svc.GetDefaultFIPSUbuntuImage(ctx, m.Location(), ptr.Deref(m.MachinePool.Spec.Template.Spec.Version, ""))
 	} else {
 		defaultImage, err = svc.GetDefaultUbuntuImage(ctx, m.Location(), ptr.Deref(m.MachinePool.Spec.Template.Spec.Version, ""))
 	}
@@ -792,6 +828,42 @@ func (m *MachinePoolScope) SaveVMImageToStatus(image *infrav1.Image) {
 	m.AzureMachinePool.Status.Image = image
 }
 
+// RequiredFeatures returns the subscription features required by the machine pool spec.
+func (m *MachinePoolScope) RequiredFeatures() []string {
+	var requiredFeatures []string
+	if m.AzureMachinePool.Spec.Template.SecurityProfile != nil && ptr.Deref(m.AzureMachinePool.Spec.Template.SecurityProfile.EncryptionAtHost, false) {
+		requiredFeatures = append(requiredFeatures, features.EncryptionAtHost)
+	}
+	return requiredFeatures
+}
+
+// FeaturesResource returns the AzureMachinePool, on which the registration state of the required features is reported.
+func (m *MachinePoolScope) FeaturesResource() conditions.Setter {
+	return m.AzureMachinePool
+}
+
+// SpotPlacementCheckSpec returns the spec of the preflight check of the Spot VMs, or nil if the machine pool doesn't
+// use Spot VMs with a placement check, or if its scale set was already created.
+func (m *MachinePoolScope) SpotPlacementCheckSpec() *azure.SpotPlacementCheckSpec {
+	spotVMOptions := m.AzureMachinePool.Spec.Template.SpotVMOptions
+	if spotVMOptions == nil || spotVMOptions.PlacementCheck == nil || m.AzureMachinePool.Spec.ProviderID != "" {
+		return nil
+	}
+	return &azure.SpotPlacementCheckSpec{
+		VMSize:       m.AzureMachinePool.Spec.Template.VMSize,
+		Location:     m.Location(),
+		Zones:        m.MachinePool.Spec.FailureDomains,
+		DesiredCount: ptr.Deref(m.MachinePool.Spec.Replicas, 1),
+		Check:        *spotVMOptions.PlacementCheck,
+	}
+}
+
+// SpotPlacementResource returns the AzureMachinePool, on which the result of the Spot VMs preflight check is
+// reported.
+func (m *MachinePoolScope) SpotPlacementResource() conditions.Setter {
+	return m.AzureMachinePool
+}
+
 // RoleAssignmentSpecs returns the role assignment specs.
 func (m *MachinePoolScope) RoleAssignmentSpecs(principalID *string) []azure.ResourceSpecGetter {
 	roles := make([]azure.ResourceSpecGetter, 1)
@@ -843,7 +915,11 @@ func (m *MachinePoolScope) VMSSExtensionSpecs() []azure.ResourceSpecGetter {
 	cpuArchitectureType, _ := m.cache.VMSKU.GetCapability(resourceskus.CPUArchitectureType)
 	bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachinePool.Spec.Template.OSDisk.OSType, m.CloudEnvironment(), m.Name(), cpuArchitectureType)
 
-	if bootstrapExtensionSpec != nil {
+	// A user-provided extension of the same publisher and type takes precedence, as a VM can only have one extension
+	// of each publisher and type.
+	if bootstrapExtensionSpec != nil && !slices.ContainsFunc(m.AzureMachinePool.Spec.Template.VMExtensions, func(extension infrav1.VMExtension) bool {
+		return bootstrapExtensionSpec.IsSameVMExtensionType(extension.Publisher, extension.Name)
+	}) {
 		extensionSpecs = append(extensionSpecs, &scalesets.VMSSExtensionSpec{
 			ExtensionSpec: *bootstrapExtensionSpec,
 			ResourceGroup: m.NodeResourceGroup(),
@@ -858,7 +934,7 @@ func (m *MachinePoolScope) getDeploymentStrategy() machinepool.TypedDeleteSelect
 		return nil
 	}
 
-	return machinepool.NewMachinePoolDeploymentStrategy(m.AzureMachinePool.Spec.Strategy)
+	return machinepool.NewMachinePoolDeploymentStrategy(m.AzureMachinePool.Spec.Strategy, m.AzureMachinePool.Spec.DeletePolicy)
 }
 
 // SetSubnetName defaults the AzureMachinePool subnet name to the name of the subnet with role 'node' when there is only one of them.
@@ -886,9 +962,9 @@ func (m *MachinePoolScope) UpdateDeleteStatus(condition clusterv1.ConditionType,
 	case err == nil:
 		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -898,9 +974,9 @@ func (m *MachinePoolScope) UpdatePutStatus(condition clusterv1.ConditionType, se
 	case err == nil:
 		conditions.MarkTrue(m.AzureMachinePool, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -910,9 +986,9 @@ func (m *MachinePoolScope) UpdatePatchStatus(condition clusterv1.ConditionType,
 	case err == nil:
 		conditions.MarkTrue(m.AzureMachinePool, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
+		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -938,7 +938,7 @@ func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
 							Environment: azureautorest.Environment{
-								Name: azureautorest.USGovernmentCloud.Name,
+								Name: azureautorest.GermanCloud.Name,
 							},
 						},
 					},
@@ -1025,7 +1025,7 @@ func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
 							Environment: azureautorest.Environment{
-								Name: azureautorest.USGovernmentCloud.Name,
+								Name: azureautorest.GermanCloud.Name,
 							},
 						},
 					},
@@ -1041,6 +1041,108 @@ func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 			},
 			want: []azure.ResourceSpecGetter{},
 		},
+		{
+			name: "If a custom VM extension has the publisher and type of the bootstrapping extension, it returns only the custom VM extension",
+			machinePoolScope: MachinePoolScope{
+				MachinePool: &expv1.MachinePool{},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "winpool",
+					},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							OSDisk: infrav1.OSDisk{
+								OSType: "Windows",
+							},
+							VMExtensions: []infrav1.VMExtension{
+								{
+									Name:      "CustomScriptExtension",
+									Publisher: "Microsoft.Compute",
+									Version:   "1.10",
+								},
+							},
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.ChinaCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+						},
+					},
+				},
+				cache: &MachinePoolCache{
+					VMSKU: resourceskus.SKU{},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&scalesets.VMSSExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "CustomScriptExtension",
+						VMName:    "winpool",
+						Publisher: "Microsoft.Compute",
+						Version:   "1.10",
+					},
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
+		{
+			name: "If OS type is Windows and cloud is AzureChinaCloud, it returns the custom script ExtensionSpec",
+			machinePoolScope: MachinePoolScope{
+				MachinePool: &expv1.MachinePool{},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "winpool",
+					},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							OSDisk: infrav1.OSDisk{
+								OSType: "Windows",
+							},
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.ChinaCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+						},
+					},
+				},
+				cache: &MachinePoolCache{
+					VMSKU: resourceskus.SKU{},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&scalesets.VMSSExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "CustomScriptExtension",
+						VMName:    "winpool",
+						Publisher: "Microsoft.Compute",
+						Version:   "1.10",
+						ProtectedSettings: map[string]string{
+							"commandToExecute": azure.WindowsBootstrapExtensionCommand,
+						},
+					},
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
 		{
 			name: "If OS type is not Linux or Windows and cloud is AzurePublicCloud, it returns empty",
 			machinePoolScope: MachinePoolScope{
@@ -1097,7 +1199,7 @@ func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
 							Environment: azureautorest.Environment{
-								Name: azureautorest.USGovernmentCloud.Name,
+								Name: azureautorest.GermanCloud.Name,
 							},
 						},
 					},
@@ -20,10 +20,14 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/drain"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -214,9 +218,9 @@ func (s *MachinePoolMachineScope) UpdateDeleteStatus(condition clusterv1.Conditi
 	case err == nil:
 		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -226,9 +230,9 @@ func (s *MachinePoolMachineScope) UpdatePutStatus(condition clusterv1.ConditionT
 	case err == nil:
 		conditions.MarkTrue(s.AzureMachinePoolMachine, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -238,9 +242,9 @@ func (s *MachinePoolMachineScope) UpdatePatchStatus(condition clusterv1.Conditio
 	case err == nil:
 		conditions.MarkTrue(s.AzureMachinePoolMachine, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -272,7 +276,7 @@ func (s *MachinePoolMachineScope) IsReady() bool {
 
 // SetFailureMessage sets the AzureMachinePoolMachine status failure message.
 func (s *MachinePoolMachineScope) SetFailureMessage(v error) {
-	s.AzureMachinePoolMachine.Status.FailureMessage = ptr.To(v.Error())
+	s.AzureMachinePoolMachine.Status.FailureMessage = ptr.To(azure.ErrorMessage(v))
 }
 
 // SetFailureReason sets the AzureMachinePoolMachine status failure reason.
@@ -447,6 +451,143 @@ func (s *MachinePoolMachineScope) hasLatestModelApplied(ctx context.Context) (bo
 	return reflect.DeepEqual(s.instance.Image, *image), nil
 }
 
+// CordonAndDrain cordons and drains the Kubernetes node of the AzureMachinePoolMachine, so that its pods are evicted
+// gracefully before the instance is removed from the scale set.
+func (s *MachinePoolMachineScope) CordonAndDrain(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolMachineScope.CordonAndDrain")
+	defer done()
+
+	if !s.isNodeDrainAllowed() {
+		return nil
+	}
+
+	node, found, err := s.GetNode(ctx)
+	switch {
+	case err != nil && apierrors.IsNotFound(err):
+		// The node is already gone, so there is nothing to drain.
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "failed to find node")
+	case !found:
+		return nil
+	}
+
+	log.V(4).Info("Draining node", "node", node.Name)
+	// The DrainingSucceededCondition never exists before the node is drained for the first time, so its transition
+	// time records when draining started, which the NodeDrainTimeout is measured from.
+	if conditions.Get(s.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition) == nil {
+		conditions.MarkFalse(s.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition, clusterv1.DrainingReason, clusterv1.ConditionSeverityInfo, "Draining the node before deletion")
+		// Make the start of draining visible to users, and persist its time should draining take several reconciles.
+		if err := s.PatchObject(ctx); err != nil {
+			return errors.Wrap(err, "failed to patch AzureMachinePoolMachine")
+		}
+	}
+
+	if err := s.drainNode(ctx, node); err != nil {
+		conditions.MarkFalse(s.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition, clusterv1.DrainingFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	conditions.MarkTrue(s.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition)
+	return nil
+}
+
+func (s *MachinePoolMachineScope) drainNode(ctx context.Context, node *corev1.Node) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolMachineScope.drainNode")
+	defer done()
+
+	restConfig, err := remote.RESTConfig(ctx, MachinePoolMachineScopeName, s.client, client.ObjectKey{
+		Name:      s.ClusterName(),
+		Namespace: s.AzureMachinePoolMachine.Namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get the workload cluster REST config")
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the workload cluster client")
+	}
+
+	drainer := &drain.Helper{
+		Client:              kubeClient,
+		Ctx:                 ctx,
+		Force:               true,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+		// If a pod isn't evicted within 20 seconds, retry on the next reconcile so that other machines can be
+		// reconciled meanwhile.
+		Timeout: 20 * time.Second,
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			verb := "Deleted"
+			if usingEviction {
+				verb = "Evicted"
+			}
+			log.V(4).Info(verb+" pod from node", "pod", klog.KObj(pod))
+		},
+		Out:    drainLogWriter{logFunc: func(msg string) { log.V(4).Info(msg) }},
+		ErrOut: drainLogWriter{logFunc: func(msg string) { log.Error(nil, msg) }},
+	}
+	if noderefutil.IsNodeUnreachable(node) {
+		// Pods of an unreachable node can't be deleted gracefully, so stop waiting for them after 5 minutes.
+		drainer.SkipWaitForDeleteTimeoutSeconds = 60 * 5
+	}
+
+	if err := drain.RunCordonOrUncordon(drainer, node, true); err != nil {
+		return azure.WithTransientError(errors.Wrapf(err, "failed to cordon node %s", node.Name), 20*time.Second)
+	}
+	if err := drain.RunNodeDrain(drainer, node.Name); err != nil {
+		return azure.WithTransientError(errors.Wrapf(err, "failed to drain node %s", node.Name), 20*time.Second)
+	}
+
+	log.V(4).Info("Drained node", "node", node.Name)
+	return nil
+}
+
+// isNodeDrainAllowed returns false if the node was already drained by the owner Machine, is excluded from draining or
+// if the NodeDrainTimeout of the AzureMachinePool has expired.
+func (s *MachinePoolMachineScope) isNodeDrainAllowed() bool {
+	if s.Machine != nil {
+		if conditions.IsTrue(s.Machine, clusterv1.DrainingSucceededCondition) {
+			return false
+		}
+		if _, exists := s.Machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]; exists {
+			return false
+		}
+	}
+	if _, exists := s.AzureMachinePoolMachine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]; exists {
+		return false
+	}
+
+	return !s.nodeDrainTimeoutExceeded()
+}
+
+// nodeDrainTimeoutExceeded returns true if draining the node started longer than the NodeDrainTimeout of the
+// AzureMachinePool ago.
+func (s *MachinePoolMachineScope) nodeDrainTimeoutExceeded() bool {
+	timeout := s.AzureMachinePool.Spec.NodeDrainTimeout
+	if timeout == nil || timeout.Seconds() <= 0 {
+		return false
+	}
+	if conditions.Get(s.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition) == nil {
+		return false
+	}
+
+	firstDrain := conditions.GetLastTransitionTime(s.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition)
+	return time.Since(firstDrain.Time) >= timeout.Duration
+}
+
+// drainLogWriter is an io.Writer passing the output of the drain helper to a logger.
+type drainLogWriter struct {
+	logFunc func(msg string)
+}
+
+// Write logs the given bytes.
+func (w drainLogWriter) Write(p []byte) (int, error) {
+	w.logFunc(strings.TrimSpace(string(p)))
+	return len(p), nil
+}
+
 func newWorkloadClusterProxy(c client.Client, cluster client.ObjectKey) *workloadClusterProxy {
 	return &workloadClusterProxy{
 		Client:  c,
@@ -20,12 +20,14 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
@@ -463,6 +465,155 @@ func TestMachineScope_UpdateNodeStatus(t *testing.T) {
 	}
 }
 
+func TestMachinePoolMachineScope_CordonAndDrain(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	clusterScope := mock_azure.NewMockClusterScoper(mockCtrl)
+	clusterScope.EXPECT().BaseURI().AnyTimes()
+	clusterScope.EXPECT().Location().AnyTimes()
+	clusterScope.EXPECT().SubscriptionID().AnyTimes()
+	clusterScope.EXPECT().ClusterName().Return("cluster-foo").AnyTimes()
+
+	cases := []struct {
+		Name   string
+		Setup  func(mockNodeGetter *mock_scope.MocknodeGetter, params *MachinePoolMachineScopeParams)
+		Verify func(g *WithT, scope *MachinePoolMachineScope)
+		Err    string
+	}{
+		{
+			Name: "should not drain the node if the owner Machine already drained it",
+			Setup: func(_ *mock_scope.MocknodeGetter, params *MachinePoolMachineScopeParams) {
+				conditions.MarkTrue(params.Machine, clusterv1.DrainingSucceededCondition)
+			},
+			Verify: func(g *WithT, scope *MachinePoolMachineScope) {
+				g.Expect(conditions.Has(scope.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition)).To(BeFalse())
+			},
+		},
+		{
+			Name: "should not drain the node if the AzureMachinePoolMachine is excluded from draining",
+			Setup: func(_ *mock_scope.MocknodeGetter, params *MachinePoolMachineScopeParams) {
+				params.AzureMachinePoolMachine.Annotations = map[string]string{clusterv1.ExcludeNodeDrainingAnnotation: ""}
+			},
+		},
+		{
+			Name: "should not drain the node if the owner Machine is excluded from draining",
+			Setup: func(_ *mock_scope.MocknodeGetter, params *MachinePoolMachineScopeParams) {
+				params.Machine.Annotations = map[string]string{clusterv1.ExcludeNodeDrainingAnnotation: ""}
+			},
+		},
+		{
+			Name: "should stop draining the node once the node drain timeout is exceeded",
+			Setup: func(_ *mock_scope.MocknodeGetter, params *MachinePoolMachineScopeParams) {
+				params.AzureMachinePool.Spec.NodeDrainTimeout = &metav1.Duration{Duration: time.Minute}
+				params.AzureMachinePoolMachine.Status.Conditions = clusterv1.Conditions{
+					{
+						Type:               clusterv1.DrainingSucceededCondition,
+						Status:             corev1.ConditionFalse,
+						Reason:             clusterv1.DrainingFailedReason,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+					},
+				}
+			},
+		},
+		{
+			Name: "should succeed if the node is not found by providerID",
+			Setup: func(mockNodeGetter *mock_scope.MocknodeGetter, _ *MachinePoolMachineScopeParams) {
+				mockNodeGetter.EXPECT().GetNodeByProviderID(gomock2.AContext(), FakeProviderID).Return(nil, nil)
+			},
+		},
+		{
+			Name: "should succeed if the node referenced by the AzureMachinePoolMachine no longer exists",
+			Setup: func(mockNodeGetter *mock_scope.MocknodeGetter, params *MachinePoolMachineScopeParams) {
+				nodeRef := corev1.ObjectReference{
+					Name: "node1",
+				}
+				params.AzureMachinePoolMachine.Status.NodeRef = &nodeRef
+				mockNodeGetter.EXPECT().GetNodeByObjectReference(gomock2.AContext(), nodeRef).Return(nil, apierrors.NewNotFound(corev1.Resource("nodes"), "node1"))
+			},
+		},
+		{
+			Name: "fails fetching the node",
+			Setup: func(mockNodeGetter *mock_scope.MocknodeGetter, _ *MachinePoolMachineScopeParams) {
+				mockNodeGetter.EXPECT().GetNodeByProviderID(gomock2.AContext(), FakeProviderID).Return(nil, errors.New("boom"))
+			},
+			Err: "failed to find node: failed to get node by providerID: boom",
+		},
+		{
+			Name: "should mark draining as failed if the workload cluster can't be reached",
+			Setup: func(mockNodeGetter *mock_scope.MocknodeGetter, _ *MachinePoolMachineScopeParams) {
+				mockNodeGetter.EXPECT().GetNodeByProviderID(gomock2.AContext(), FakeProviderID).Return(getReadyNode(), nil)
+			},
+			Verify: func(g *WithT, scope *MachinePoolMachineScope) {
+				condition := conditions.Get(scope.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal(clusterv1.DrainingFailedReason))
+			},
+			Err: "failed to get the workload cluster REST config",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var (
+				controller = gomock.NewController(t)
+				mockClient = mock_scope.NewMocknodeGetter(controller)
+				g          = NewWithT(t)
+				ampm       = &infrav1exp.AzureMachinePoolMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "ampm1",
+						Namespace: "default",
+					},
+					Spec: infrav1exp.AzureMachinePoolMachineSpec{
+						ProviderID: FakeProviderID,
+					},
+				}
+				params = MachinePoolMachineScopeParams{
+					ClusterScope: clusterScope,
+					MachinePool: &expv1.MachinePool{
+						Spec: expv1.MachinePoolSpec{
+							Template: clusterv1.MachineTemplateSpec{
+								Spec: clusterv1.MachineSpec{
+									Version: ptr.To("v1.19.11"),
+								},
+							},
+						},
+					},
+					AzureMachinePool:        new(infrav1exp.AzureMachinePool),
+					AzureMachinePoolMachine: ampm,
+					Machine:                 new(clusterv1.Machine),
+				}
+			)
+
+			defer controller.Finish()
+
+			c.Setup(mockClient, &params)
+			params.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(ampm).WithStatusSubresource(ampm).Build()
+			s, err := NewMachinePoolMachineScope(params)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(s).NotTo(BeNil())
+			s.workloadNodeGetter = mockClient
+
+			err = s.CordonAndDrain(context.TODO())
+			if c.Err == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(c.Err)))
+			}
+
+			if c.Verify != nil {
+				c.Verify(g, s)
+			}
+		})
+	}
+}
+
 func getReadyNode() *corev1.Node {
 	return &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -462,6 +462,12 @@ func (s *ManagedControlPlaneScope) APIServerLBPoolName() string {
 	return "" // does not apply for AKS
 }
 
+// IsControlPlaneEndpointExternallyManaged returns true if the control plane endpoint is managed outside of CAPZ.
+// Always false as the control plane endpoint of managed clusters is served by AKS.
+func (s *ManagedControlPlaneScope) IsControlPlaneEndpointExternallyManaged() bool {
+	return false
+}
+
 // IsAPIServerPrivate returns true if the API Server LB is of type Internal.
 // Currently always false as managed control planes do not currently implement private clusters.
 func (s *ManagedControlPlaneScope) IsAPIServerPrivate() bool {
@@ -479,6 +485,11 @@ func (s *ManagedControlPlaneScope) OutboundPoolName(_ string) string {
 	return "aksOutboundBackendPool" // hard-coded in aks
 }
 
+// OutboundLBResourceGroup returns the resource group of the outbound LB.
+func (s *ManagedControlPlaneScope) OutboundLBResourceGroup(_ string) string {
+	return s.NodeResourceGroup()
+}
+
 // GetPrivateDNSZoneName returns the Private DNS Zone from the spec or generate it from cluster name.
 // Currently always empty as managed control planes do not currently implement private clusters.
 func (s *ManagedControlPlaneScope) GetPrivateDNSZoneName() string {
@@ -490,6 +501,16 @@ func (s *ManagedControlPlaneScope) CloudProviderConfigOverrides() *infrav1.Cloud
 	return nil
 }
 
+// CloudProviderConfigDisabled returns whether the generation of the cloud provider config secrets is disabled.
+func (s *ManagedControlPlaneScope) CloudProviderConfigDisabled() bool {
+	return false
+}
+
+// ResourceNaming has not been implemented for AzureManagedControlPlane.
+func (s *ManagedControlPlaneScope) ResourceNaming() *infrav1.ResourceNamingSpec {
+	return nil
+}
+
 // FailureDomains returns the failure domains for the cluster.
 func (s *ManagedControlPlaneScope) FailureDomains() []*string {
 	return []*string{}
@@ -876,9 +897,9 @@ func (s *ManagedControlPlaneScope) UpdateDeleteStatus(condition clusterv1.Condit
 	case err == nil:
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -888,9 +909,9 @@ func (s *ManagedControlPlaneScope) UpdatePutStatus(condition clusterv1.Condition
 	case err == nil:
 		conditions.MarkTrue(s.ControlPlane, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+		conditions.MarkFalse(s.ControlPlane, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.ControlPlane, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -900,9 +921,9 @@ func (s *ManagedControlPlaneScope) UpdatePatchStatus(condition clusterv1.Conditi
 	case err == nil:
 		conditions.MarkTrue(s.ControlPlane, condition)
 	case azure.IsOperationNotDoneError(err):
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
+		conditions.MarkFalse(s.ControlPlane, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating%s", service, azure.OperationProgress(err))
 	default:
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.ControlPlane, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, azure.ErrorMessage(err))
 	}
 }
 
@@ -51,12 +51,16 @@ type (
 
 	rollingUpdateStrategy struct {
 		infrav1exp.MachineRollingUpdateDeployment
+
+		// scaleDownDeletePolicy overrides the DeletePolicy of the rolling update when removing surplus ready machines.
+		scaleDownDeletePolicy infrav1exp.AzureMachinePoolDeletePolicyType
 	}
 )
 
 // NewMachinePoolDeploymentStrategy constructs a strategy implementation described in the AzureMachinePoolDeploymentStrategy
-// specification.
-func NewMachinePoolDeploymentStrategy(strategy infrav1exp.AzureMachinePoolDeploymentStrategy) TypedDeleteSelector {
+// specification. The deletePolicy, when set, selects the machines removed on scale down instead of the strategy's own
+// DeletePolicy.
+func NewMachinePoolDeploymentStrategy(strategy infrav1exp.AzureMachinePoolDeploymentStrategy, deletePolicy infrav1exp.AzureMachinePoolDeletePolicyType) TypedDeleteSelector {
 	switch strategy.Type {
 	case infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType:
 		rollingUpdate := strategy.RollingUpdate
@@ -66,11 +70,13 @@ func NewMachinePoolDeploymentStrategy(strategy infrav1exp.AzureMachinePoolDeploy
 
 		return &rollingUpdateStrategy{
 			MachineRollingUpdateDeployment: *rollingUpdate,
+			scaleDownDeletePolicy:          deletePolicy,
 		}
 	default:
 		// default to a rolling update strategy if unknown type
 		return &rollingUpdateStrategy{
 			MachineRollingUpdateDeployment: infrav1exp.MachineRollingUpdateDeployment{},
+			scaleDownDeletePolicy:          deletePolicy,
 		}
 	}
 }
@@ -118,20 +124,12 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 	}
 
 	var (
-		order = func() func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
-			switch rollingUpdateStrategy.DeletePolicy {
-			case infrav1exp.OldestDeletePolicyType:
-				return orderByOldest
-			case infrav1exp.NewestDeletePolicyType:
-				return orderByNewest
-			default:
-				return orderRandom
-			}
-		}()
+		order                      = orderByDeletePolicy(rollingUpdateStrategy.DeletePolicy)
 		log                        = ctrl.LoggerFrom(ctx).V(4)
 		failedMachines             = order(getFailedMachines(machinesByProviderID))
 		deletingMachines           = order(getDeletingMachines(machinesByProviderID))
 		readyMachines              = order(getReadyMachines(machinesByProviderID))
+		scaleDownMachines          = rollingUpdateStrategy.orderForScaleDown(getReadyMachines(machinesByProviderID))
 		machinesWithoutLatestModel = order(getMachinesWithoutLatestModel(machinesByProviderID))
 		overProvisionCount         = len(readyMachines) - int(desiredReplicaCount)
 		disruptionBudget           = func() int {
@@ -148,6 +146,7 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 	failedMachines = orderByDeleteMachineAnnotation(failedMachines)
 	deletingMachines = orderByDeleteMachineAnnotation(deletingMachines)
 	readyMachines = orderByDeleteMachineAnnotation(readyMachines)
+	scaleDownMachines = orderByDeleteMachineAnnotation(scaleDownMachines)
 	machinesWithoutLatestModel = orderByDeleteMachineAnnotation(machinesWithoutLatestModel)
 
 	log.Info("selecting machines to delete",
@@ -185,9 +184,9 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 			toDelete = append(toDelete, v)
 		}
 
-		log.Info("over-provisioned ready", "desiredReplicaCount", desiredReplicaCount, "overProvisionCount", overProvisionCount, "readyMachines", getProviderIDs(readyMachines))
+		log.Info("over-provisioned ready", "desiredReplicaCount", desiredReplicaCount, "overProvisionCount", overProvisionCount, "readyMachines", getProviderIDs(scaleDownMachines))
 		// remove ready machines
-		for _, v := range readyMachines {
+		for _, v := range scaleDownMachines {
 			if len(toDelete) >= overProvisionCount {
 				return toDelete, nil
 			}
@@ -224,6 +223,27 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 	return toDelete, nil
 }
 
+// orderForScaleDown orders the machines by the scale down delete policy, falling back to the DeletePolicy of the
+// rolling update when no scale down delete policy is set.
+func (rollingUpdateStrategy rollingUpdateStrategy) orderForScaleDown(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+	if rollingUpdateStrategy.scaleDownDeletePolicy == "" {
+		return orderByDeletePolicy(rollingUpdateStrategy.DeletePolicy)(machines)
+	}
+
+	return orderByDeletePolicy(rollingUpdateStrategy.scaleDownDeletePolicy)(machines)
+}
+
+func orderByDeletePolicy(deletePolicy infrav1exp.AzureMachinePoolDeletePolicyType) func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+	switch deletePolicy {
+	case infrav1exp.OldestDeletePolicyType:
+		return orderByOldest
+	case infrav1exp.NewestDeletePolicyType:
+		return orderByNewest
+	default:
+		return orderRandom
+	}
+}
+
 func getFailedMachines(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
 	var machines []infrav1exp.AzureMachinePoolMachine
 	for _, v := range machinesByProviderID {
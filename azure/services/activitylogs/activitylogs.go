@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activitylogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"k8s.io/utils/ptr"
+)
+
+// failedStatus is the status of the Activity Log events of failed operations.
+const failedStatus = "Failed"
+
+// Operation is a failed operation on a resource found in the Activity Log.
+type Operation struct {
+	// Name is the name of the operation, e.g. "Create or Update Virtual Machine".
+	Name string
+	// CorrelationID identifies the request the operation was part of, and is the ID Azure support asks for.
+	CorrelationID string
+	// Code is the error code of the operation.
+	Code string
+	// Message is the error message of the operation.
+	Message string
+	// Timestamp is when the operation failed.
+	Timestamp time.Time
+}
+
+// String returns a description of the failed operation suitable for a condition message.
+func (o *Operation) String() string {
+	var b strings.Builder
+	b.WriteString(o.Name)
+	b.WriteString(" failed")
+	switch {
+	case o.Code != "" && o.Message != "":
+		fmt.Fprintf(&b, ": %s: %s", o.Code, o.Message)
+	case o.Code != "":
+		fmt.Fprintf(&b, ": %s", o.Code)
+	case o.Message != "":
+		fmt.Fprintf(&b, ": %s", o.Message)
+	}
+	if o.CorrelationID != "" {
+		fmt.Fprintf(&b, " (correlation ID: %s)", o.CorrelationID)
+	}
+	return b.String()
+}
+
+// statusMessage is the error reported in the statusMessage property of an Activity Log event.
+type statusMessage struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// failedOperation returns the failed operation described by an Activity Log event, or nil if the event is not the
+// failure of an operation.
+func failedOperation(event *armmonitor.EventData) *Operation {
+	if event == nil || event.Status == nil || !strings.EqualFold(ptr.Deref(event.Status.Value, ""), failedStatus) {
+		return nil
+	}
+	operation := &Operation{
+		CorrelationID: ptr.Deref(event.CorrelationID, ""),
+		Timestamp:     ptr.Deref(event.EventTimestamp, time.Time{}),
+	}
+	if event.OperationName != nil {
+		operation.Name = ptr.Deref(event.OperationName.LocalizedValue, ptr.Deref(event.OperationName.Value, ""))
+	}
+
+	var status statusMessage
+	if err := json.Unmarshal([]byte(ptr.Deref(event.Properties["statusMessage"], "")), &status); err == nil {
+		operation.Code = status.Error.Code
+		operation.Message = status.Error.Message
+		// The details of the error are usually more specific than the error itself.
+		if len(status.Error.Details) > 0 {
+			operation.Code = status.Error.Details[0].Code
+			operation.Message = status.Error.Details[0].Message
+		}
+	}
+	if operation.Message == "" {
+		operation.Message = ptr.Deref(event.Description, "")
+	}
+	if operation.Code == "" && event.SubStatus != nil {
+		operation.Code = ptr.Deref(event.SubStatus.Value, "")
+	}
+	return operation
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activitylogs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestFailedOperation(t *testing.T) {
+	timestamp := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		event    *armmonitor.EventData
+		expected *Operation
+	}{
+		{
+			name: "event of a succeeded operation",
+			event: &armmonitor.EventData{
+				Status: &armmonitor.LocalizableString{Value: ptr.To("Succeeded")},
+			},
+		},
+		{
+			name: "failed operation with error details",
+			event: &armmonitor.EventData{
+				CorrelationID:  ptr.To("correlation-id"),
+				EventTimestamp: &timestamp,
+				OperationName: &armmonitor.LocalizableString{
+					Value:          ptr.To("Microsoft.Compute/virtualMachines/write"),
+					LocalizedValue: ptr.To("Create or Update Virtual Machine"),
+				},
+				Status: &armmonitor.LocalizableString{Value: ptr.To("Failed")},
+				Properties: map[string]*string{
+					"statusMessage": ptr.To(`{"status":"Failed","error":{"code":"ResourceOperationFailure","message":"The resource operation completed with terminal provisioning state 'Failed'.","details":[{"code":"OSProvisioningTimedOut","message":"OS Provisioning did not finish in the allotted time."}]}}`),
+				},
+			},
+			expected: &Operation{
+				Name:          "Create or Update Virtual Machine",
+				CorrelationID: "correlation-id",
+				Code:          "OSProvisioningTimedOut",
+				Message:       "OS Provisioning did not finish in the allotted time.",
+				Timestamp:     timestamp,
+			},
+		},
+		{
+			name: "failed operation without status message",
+			event: &armmonitor.EventData{
+				CorrelationID: ptr.To("correlation-id"),
+				OperationName: &armmonitor.LocalizableString{Value: ptr.To("Microsoft.Compute/virtualMachines/write")},
+				Status:        &armmonitor.LocalizableString{Value: ptr.To("Failed")},
+				SubStatus:     &armmonitor.LocalizableString{Value: ptr.To("Conflict")},
+				Description:   ptr.To("Operation was preempted."),
+			},
+			expected: &Operation{
+				Name:          "Microsoft.Compute/virtualMachines/write",
+				CorrelationID: "correlation-id",
+				Code:          "Conflict",
+				Message:       "Operation was preempted.",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(failedOperation(tc.event)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestOperationString(t *testing.T) {
+	g := NewWithT(t)
+
+	operation := &Operation{
+		Name:          "Create or Update Virtual Machine",
+		CorrelationID: "correlation-id",
+		Code:          "SkuNotAvailable",
+		Message:       "The requested VM size is not available.",
+	}
+	g.Expect(operation.String()).To(Equal("Create or Update Virtual Machine failed: SkuNotAvailable: The requested VM size is not available. (correlation ID: correlation-id)"))
+
+	operation = &Operation{Name: "Create or Update Virtual Machine"}
+	g.Expect(operation.String()).To(Equal("Create or Update Virtual Machine failed"))
+}
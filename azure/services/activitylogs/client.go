@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activitylogs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// lookback is how far back the Activity Log is searched for failed operations.
+const lookback = 7 * 24 * time.Hour
+
+// Client wraps go-sdk.
+type Client interface {
+	GetFailedOperation(ctx context.Context, resourceID string) (*Operation, error)
+}
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	activityLogs *armmonitor.ActivityLogsClient
+}
+
+// NewClient creates a new Activity Log client from an authorizer.
+func NewClient(auth azure.Authorizer) (Client, error) {
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create activity logs client options")
+	}
+	factory, err := armmonitor.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armmonitor client factory")
+	}
+	return &AzureClient{factory.NewActivityLogsClient()}, nil
+}
+
+// GetFailedOperation returns the most recent failed operation on a resource found in the Activity Log, or nil if
+// there is none.
+func (ac *AzureClient) GetFailedOperation(ctx context.Context, resourceID string) (*Operation, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "activitylogs.AzureClient.GetFailedOperation")
+	defer done()
+
+	filter := fmt.Sprintf("eventTimestamp ge '%s' and resourceUri eq '%s'", time.Now().Add(-lookback).UTC().Format(time.RFC3339), resourceID)
+	pager := ac.activityLogs.NewListPager(filter, &armmonitor.ActivityLogsClientListOptions{
+		Select: ptr.To("correlationId,eventTimestamp,operationName,properties,status,subStatus,description"),
+	})
+	var latest *Operation
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list activity log events of resource %s", resourceID)
+		}
+		for _, event := range page.Value {
+			operation := failedOperation(event)
+			if operation != nil && (latest == nil || operation.Timestamp.After(latest.Timestamp)) {
+				latest = operation
+			}
+		}
+	}
+	return latest, nil
+}
@@ -88,6 +88,34 @@ func (mr *MockAgentPoolScopeMockRecorder) AgentPoolSpec() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AgentPoolSpec", reflect.TypeOf((*MockAgentPoolScope)(nil).AgentPoolSpec))
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockAgentPoolScope) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockAgentPoolScopeMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockAgentPoolScope)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockAgentPoolScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockAgentPoolScopeMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockAgentPoolScope)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // ClusterName mocks base method.
 func (m *MockAgentPoolScope) ClusterName() string {
 	m.ctrl.T.Helper()
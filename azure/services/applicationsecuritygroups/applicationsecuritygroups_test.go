@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups/mock_applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+var (
+	fakeWebASG = ApplicationSecurityGroupSpec{
+		Name:          "web",
+		ResourceGroup: "my-rg",
+		Location:      "eastus",
+		ClusterName:   "my-cluster",
+	}
+	fakeDBASG = ApplicationSecurityGroupSpec{
+		Name:          "db",
+		ResourceGroup: "my-rg",
+		Location:      "eastus",
+		ClusterName:   "my-cluster",
+	}
+	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func internalError() *azcore.ResponseError {
+	return &azcore.ResponseError{
+		RawResponse: &http.Response{
+			Body:       io.NopCloser(strings.NewReader("#: Internal Server Error: StatusCode=500")),
+			StatusCode: http.StatusInternalServerError,
+		},
+	}
+}
+
+func TestReconcileApplicationSecurityGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationsecuritygroups.MockApplicationSecurityGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "create application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockApplicationSecurityGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeWebASG, &fakeDBASG})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeWebASG, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeDBASG, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "noop if no application security group specs are found",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockApplicationSecurityGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationSecurityGroupSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "error creating the first application security group is returned over a not done error",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_applicationsecuritygroups.MockApplicationSecurityGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeWebASG, &fakeDBASG})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeWebASG, ServiceName).Return(nil, internalError())
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeDBASG, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, ServiceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationsecuritygroups.NewMockApplicationSecurityGroupScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteApplicationSecurityGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationsecuritygroups.MockApplicationSecurityGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "delete application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockApplicationSecurityGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeWebASG, &fakeDBASG})
+				r.DeleteResource(gomockinternal.AContext(), &fakeWebASG, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeDBASG, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "error deleting an application security group",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_applicationsecuritygroups.MockApplicationSecurityGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeWebASG})
+				r.DeleteResource(gomockinternal.AContext(), &fakeWebASG, ServiceName).Return(internalError())
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, ServiceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationsecuritygroups.NewMockApplicationSecurityGroupScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -33,8 +33,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/aso"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -133,6 +135,7 @@ func (r *reconciler[T]) CreateOrUpdateResource(ctx context.Context, spec azure.A
 					Type:          createOrUpdateFutureType,
 					ResourceGroup: existing.GetNamespace(),
 					Name:          existing.GetName(),
+					StartTime:     ptr.To(cond.LastTransitionTime),
 				})
 			default:
 				readyErr = fmt.Errorf("resource is not Ready: %s", conds[i].Message)
@@ -225,6 +228,19 @@ func (r *reconciler[T]) CreateOrUpdateResource(ctx context.Context, spec azure.A
 		log.V(2).Info("resource up to date")
 		return existing, nil
 	}
+	if r.owner.GetAnnotations()[infrav1.DryRunAnnotation] == "true" {
+		if resourceExists {
+			log.V(2).Info("dry run: would update resource", "diff", diff)
+			record.Eventf(r.owner, infrav1.DryRunReason, "Would update resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
+			if readyErr != nil {
+				return zero, readyErr
+			}
+			return existing, nil
+		}
+		record.Eventf(r.owner, infrav1.DryRunReason, "Would create resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
+		return zero, azure.WithTransientError(errors.Errorf("dry run: would create resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName), requeueInterval)
+	}
+
 	log.V(2).Info("creating or updating resource", "diff", diff)
 	return r.createOrUpdateResource(ctx, existing, parameters, resourceExists, serviceName)
 }
@@ -330,6 +346,37 @@ func (r *reconciler[T]) DeleteResource(ctx context.Context, resource T, serviceN
 		return nil
 	}
 
+	if r.owner.GetAnnotations()[infrav1.DryRunAnnotation] == "true" {
+		record.Eventf(r.owner, infrav1.DryRunReason, "Would delete resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
+		return azure.WithTransientError(errors.Errorf("dry run: would delete resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName), requeueInterval)
+	}
+
+	if !resource.GetDeletionTimestamp().IsZero() {
+		conds := resource.GetConditions()
+		if i, ok := conds.FindIndexByType(conditions.ConditionTypeReady); ok && conds[i].Reason == azure.ScopeLockedErrorCode {
+			if r.owner.GetAnnotations()[infrav1.LockedResourcePolicyAnnotation] != infrav1.LockedResourcePolicyOrphan {
+				return azure.WithTerminalError(azure.ResourceLockedError{
+					Resource: resourceNamespace + "/" + resourceName,
+					Message:  conds[i].Message,
+				})
+			}
+			if resource.GetAnnotations()[asoannotations.ReconcilePolicy] != string(asoannotations.ReconcilePolicyDetachOnDelete) {
+				log.V(2).Info("resource is locked in Azure, detaching it")
+				before := resource.DeepCopyObject().(genruntime.MetaObject)
+				annotations := resource.GetAnnotations()
+				if annotations == nil {
+					annotations = make(map[string]string, 1)
+				}
+				annotations[asoannotations.ReconcilePolicy] = string(asoannotations.ReconcilePolicyDetachOnDelete)
+				resource.SetAnnotations(annotations)
+				if err := r.Client.Patch(ctx, resource, client.MergeFrom(before)); err != nil {
+					return errors.Wrapf(err, "failed to detach locked resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
+				}
+				record.Eventf(r.owner, infrav1.ResourceLockedReason, "Skipped deleting locked resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
+			}
+		}
+	}
+
 	log.V(2).Info("deleting resource")
 	err = r.Client.Delete(ctx, resource)
 	if err != nil {
@@ -341,10 +388,15 @@ func (r *reconciler[T]) DeleteResource(ctx context.Context, resource T, serviceN
 		return errors.Wrapf(err, "failed to delete resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
 	}
 
+	startTime := resource.GetDeletionTimestamp()
+	if startTime == nil {
+		startTime = ptr.To(metav1.Now())
+	}
 	return azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{
 		Type:          deleteFutureType,
 		ResourceGroup: resourceNamespace,
 		Name:          resourceName,
+		StartTime:     startTime,
 	}), requeueInterval)
 }
 
@@ -364,6 +416,26 @@ func IsManaged[T genruntime.MetaObject](ctx context.Context, ctrlClient client.C
 	return isOwnedBy(resource, owner, ctrlClient.Scheme())
 }
 
+// RecordOwnership records whether an Azure resource is managed by CAPZ in the status of scope, if it is an
+// OwnershipRecorder. A resource is managed when its ASO resource was created by CAPZ and ASO reconciles it,
+// which isn't the case of resources which already existed in Azure unless they were adopted.
+func RecordOwnership(scope Scope, kind, name string, resource genruntime.MetaObject) error {
+	recorder, ok := scope.(OwnershipRecorder)
+	if !ok {
+		return nil
+	}
+	owned, err := isOwnedBy(resource, scope.ASOOwner(), scope.GetClient().Scheme())
+	if err != nil {
+		return err
+	}
+	recorder.SetResourceOwnership(infrav1.ResourceOwnership{
+		Kind:    kind,
+		Name:    name,
+		Managed: owned && resource.GetAnnotations()[asoannotations.ReconcilePolicy] != string(asoannotations.ReconcilePolicySkip),
+	})
+	return nil
+}
+
 func isOwnedBy(resource client.Object, owner client.Object, scheme *runtime.Scheme) (bool, error) {
 	ownerGVK, err := apiutil.GVKForObject(owner, scheme)
 	if err != nil {
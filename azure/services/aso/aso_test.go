@@ -20,12 +20,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	asoresourcesv1 "github.com/Azure/azure-service-operator/v2/api/resources/v1api20200601"
 	asoannotations "github.com/Azure/azure-service-operator/v2/pkg/common/annotations"
 	"github.com/Azure/azure-service-operator/v2/pkg/genruntime/conditions"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -182,6 +184,43 @@ func TestCreateOrUpdateResource(t *testing.T) {
 		}))
 	})
 
+	t.Run("dry run does not create resource", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			Build()
+		owner := newOwner()
+		owner.Annotations = map[string]string{infrav1.DryRunAnnotation: "true"}
+		s := New[*asoresourcesv1.ResourceGroup](c, clusterName, owner)
+
+		mockCtrl := gomock.NewController(t)
+		specMock := mock_azure.NewMockASOResourceSpecGetter[*asoresourcesv1.ResourceGroup](mockCtrl)
+		specMock.EXPECT().ResourceRef().Return(&asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "name",
+			},
+		})
+		specMock.EXPECT().Parameters(gomockinternal.AContext(), gomock.Nil()).Return(&asoresourcesv1.ResourceGroup{
+			Spec: asoresourcesv1.ResourceGroup_Spec{
+				Location: ptr.To("location"),
+			},
+		}, nil)
+
+		ctx := context.Background()
+		result, err := s.CreateOrUpdateResource(ctx, specMock, "service")
+		g.Expect(result).To(BeNil())
+		g.Expect(err).To(MatchError(ContainSubstring("dry run: would create resource namespace/name (service: service)")))
+		var recerr azure.ReconcileError
+		g.Expect(errors.As(err, &recerr)).To(BeTrue())
+		g.Expect(recerr.IsTransient()).To(BeTrue())
+
+		err = c.Get(ctx, types.NamespacedName{Name: "name", Namespace: "namespace"}, &asoresourcesv1.ResourceGroup{})
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
 	t.Run("resource is not ready in non-terminal state", func(t *testing.T) {
 		g := NewGomegaWithT(t)
 
@@ -1141,6 +1180,80 @@ func TestDeleteResource(t *testing.T) {
 		g.Expect(recerr.IsTransient()).To(BeTrue())
 	})
 
+	t.Run("dry run does not delete resource", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			Build()
+		owner := newOwner()
+		owner.Annotations = map[string]string{infrav1.DryRunAnnotation: "true"}
+		s := New[*asoresourcesv1.ResourceGroup](c, clusterName, owner)
+
+		ctx := context.Background()
+		resource := &asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "name",
+				Namespace:       "namespace",
+				OwnerReferences: ownerRefs(),
+			},
+		}
+		g.Expect(c.Create(ctx, resource)).To(Succeed())
+
+		err := s.DeleteResource(ctx, resource, "service")
+		g.Expect(err).To(MatchError(ContainSubstring("dry run: would delete resource namespace/name (service: service)")))
+		var recerr azure.ReconcileError
+		g.Expect(errors.As(err, &recerr)).To(BeTrue())
+		g.Expect(recerr.IsTransient()).To(BeTrue())
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(resource), resource)).To(Succeed())
+	})
+
+	t.Run("locked resource deletion fails", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			WithObjects(lockedResourceGroup()).
+			Build()
+		s := New[*asoresourcesv1.ResourceGroup](c, clusterName, newOwner())
+
+		ctx := context.Background()
+		resource := lockedResourceGroup()
+
+		err := s.DeleteResource(ctx, resource, "service")
+		g.Expect(err).To(MatchError(ContainSubstring("resource namespace/name is locked: the scope is locked")))
+		g.Expect(azure.IsResourceLocked(err)).To(BeTrue())
+		var recerr azure.ReconcileError
+		g.Expect(errors.As(err, &recerr)).To(BeTrue())
+		g.Expect(recerr.IsTerminal()).To(BeTrue())
+	})
+
+	t.Run("locked resource is detached with the orphan policy", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			WithObjects(lockedResourceGroup()).
+			Build()
+		owner := newOwner()
+		owner.Annotations = map[string]string{infrav1.LockedResourcePolicyAnnotation: infrav1.LockedResourcePolicyOrphan}
+		s := New[*asoresourcesv1.ResourceGroup](c, clusterName, owner)
+
+		ctx := context.Background()
+		resource := lockedResourceGroup()
+
+		err := s.DeleteResource(ctx, resource, "service")
+		g.Expect(azure.IsOperationNotDoneError(err)).To(BeTrue())
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(resource), resource)).To(Succeed())
+		g.Expect(resource.GetAnnotations()).To(HaveKeyWithValue(asoannotations.ReconcilePolicy, string(asoannotations.ReconcilePolicyDetachOnDelete)))
+	})
+
 	t.Run("skip delete for unmanaged resource", func(t *testing.T) {
 		g := NewGomegaWithT(t)
 
@@ -1415,3 +1528,27 @@ func TestPauseResource(t *testing.T) {
 		})
 	}
 }
+
+// lockedResourceGroup returns a ResourceGroup being deleted which ASO failed to delete because of a lock.
+func lockedResourceGroup() *asoresourcesv1.ResourceGroup {
+	return &asoresourcesv1.ResourceGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "name",
+			Namespace:         "namespace",
+			OwnerReferences:   ownerRefs(),
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"serviceoperator.azure.com/finalizer"},
+		},
+		Status: asoresourcesv1.ResourceGroup_STATUS{
+			Conditions: []conditions.Condition{
+				{
+					Type:     conditions.ConditionTypeReady,
+					Status:   metav1.ConditionFalse,
+					Severity: conditions.ConditionSeverityError,
+					Reason:   azure.ScopeLockedErrorCode,
+					Message:  "the scope is locked",
+				},
+			},
+		},
+	}
+}
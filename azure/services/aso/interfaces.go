@@ -44,6 +44,12 @@ type Patcher interface {
 	ExtraPatches() []string
 }
 
+// OwnershipRecorder is implemented by scopes which report in their status whether their Azure resources are
+// managed by CAPZ.
+type OwnershipRecorder interface {
+	SetResourceOwnership(ownership infrav1.ResourceOwnership)
+}
+
 // Scope represents the common functionality related to all scopes needed for ASO services.
 type Scope interface {
 	azure.AsyncStatusUpdater
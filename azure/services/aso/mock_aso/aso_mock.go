@@ -241,6 +241,34 @@ func (mr *MockScopeMockRecorder) ASOOwner() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ASOOwner", reflect.TypeOf((*MockScope)(nil).ASOOwner))
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockScope) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockScopeMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockScope)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockScopeMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockScope)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // ClusterName mocks base method.
 func (m *MockScope) ClusterName() string {
 	m.ctrl.T.Helper()
@@ -24,9 +24,12 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -63,6 +66,7 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 
 	// Check if there is an ongoing long-running operation.
 	resumeToken := ""
+	startTime := ptr.To(metav1.Now())
 	if future := s.Scope.GetLongRunningOperationState(resourceName, serviceName, futureType); future != nil {
 		t, err := converters.FutureToResumeToken(*future)
 		if err != nil {
@@ -70,6 +74,9 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 			return "", errors.Wrap(err, "could not decode future data, resetting long-running operation state")
 		}
 		resumeToken = t
+		if future.StartTime != nil {
+			startTime = future.StartTime
+		}
 	}
 
 	// Only when no long running operation is currently in progress do we need to get the parameters.
@@ -80,6 +87,7 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 		var existingResource interface{}
 		if existing, err := s.Creator.Get(ctx, spec); err != nil && !azure.ResourceNotFound(err) {
 			errWrapped := errors.Wrapf(err, "failed to get existing resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+			s.recordErrorEvent(errWrapped)
 			return nil, azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
 		} else if err == nil {
 			existingResource = existing
@@ -96,14 +104,26 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 			return existingResource, nil
 		}
 
+		if s.dryRun() {
+			if existingResource != nil {
+				s.recordEvent(infrav1.DryRunReason, "Would update resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+				return existingResource, nil
+			}
+			s.recordEvent(infrav1.DryRunReason, "Would create resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+			return nil, azure.WithTransientError(errors.Errorf("dry run: would create resource %s/%s (service: %s)", rgName, resourceName, serviceName), requeueTime(s.Scope))
+		}
+
 		// Create or update the resource with the desired parameters.
 		if existingResource != nil {
 			log.V(2).Info("updating resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+			s.recordEvent("Updating", "Updating resource %s/%s (service: %s)", rgName, resourceName, serviceName)
 		} else {
 			log.V(2).Info("creating resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+			s.recordEvent("Creating", "Creating resource %s/%s (service: %s)", rgName, resourceName, serviceName)
 		}
 	}
 
+	ctx = azure.WithPollingInterval(ctx)
 	result, poller, err := s.Creator.CreateOrUpdateAsync(ctx, spec, resumeToken, parameters)
 	errWrapped := errors.Wrapf(err, "failed to create or update resource %s/%s (service: %s)", rgName, resourceName, serviceName)
 	if poller != nil && azure.IsContextDeadlineExceededOrCanceledError(err) {
@@ -111,8 +131,9 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 		if err != nil {
 			return nil, errWrapped
 		}
+		future.StartTime = startTime
 		s.Scope.SetLongRunningOperationState(future)
-		return nil, azure.WithTransientError(azure.NewOperationNotDoneError(future), requeueTime(s.Scope))
+		return nil, azure.WithTransientError(azure.NewOperationNotDoneError(future), pollingRequeueTime(ctx, s.Scope))
 	}
 
 	// Once the operation is done, delete the long-running operation state. Even if the operation ended with
@@ -120,10 +141,12 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 	s.Scope.DeleteLongRunningOperationState(resourceName, serviceName, futureType)
 
 	if err != nil {
-		return nil, errWrapped
+		s.recordErrorEvent(errWrapped)
+		return nil, azure.ClassifyError(errWrapped)
 	}
 
 	log.V(2).Info("successfully created or updated resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	s.recordEvent("Provisioned", "Successfully created or updated resource %s/%s (service: %s)", rgName, resourceName, serviceName)
 	return result, nil
 }
 
@@ -138,6 +161,7 @@ func (s *Service[C, D]) DeleteResource(ctx context.Context, spec azure.ResourceS
 
 	// Check for an ongoing long-running operation.
 	resumeToken := ""
+	startTime := ptr.To(metav1.Now())
 	if future := s.Scope.GetLongRunningOperationState(resourceName, serviceName, futureType); future != nil {
 		t, err := converters.FutureToResumeToken(*future)
 		if err != nil {
@@ -145,32 +169,112 @@ func (s *Service[C, D]) DeleteResource(ctx context.Context, spec azure.ResourceS
 			return errors.Wrap(err, "could not decode future data, resetting long-running operation state")
 		}
 		resumeToken = t
+		if future.StartTime != nil {
+			startTime = future.StartTime
+		}
+	}
+
+	if resumeToken == "" && s.dryRun() {
+		// Only report resources that exist as to be deleted.
+		if getter := s.getter(); getter != nil {
+			if _, err := getter.Get(ctx, spec); azure.ResourceNotFound(err) {
+				log.V(2).Info("resource does not exist", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+				return nil
+			} else if err != nil {
+				errWrapped := errors.Wrapf(err, "failed to get existing resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+				s.recordErrorEvent(errWrapped)
+				return azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
+			}
+		}
+		s.recordEvent(infrav1.DryRunReason, "Would delete resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		return azure.WithTransientError(errors.Errorf("dry run: would delete resource %s/%s (service: %s)", rgName, resourceName, serviceName), requeueTime(s.Scope))
 	}
 
 	// Delete the resource.
 	log.V(2).Info("deleting resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	if resumeToken == "" {
+		s.recordEvent("Deleting", "Deleting resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	}
+	ctx = azure.WithPollingInterval(ctx)
 	poller, err := s.Deleter.DeleteAsync(ctx, spec, resumeToken)
 	if poller != nil && azure.IsContextDeadlineExceededOrCanceledError(err) {
 		future, err := converters.PollerToFuture(poller, infrav1.DeleteFuture, serviceName, resourceName, rgName)
 		if err != nil {
 			return errors.Wrap(err, "failed to convert poller to future")
 		}
+		future.StartTime = startTime
 		s.Scope.SetLongRunningOperationState(future)
-		return azure.WithTransientError(azure.NewOperationNotDoneError(future), requeueTime(s.Scope))
+		return azure.WithTransientError(azure.NewOperationNotDoneError(future), pollingRequeueTime(ctx, s.Scope))
 	}
 
 	// Once the operation is done, delete the long-running operation state. Even if the operation ended with
 	// an error, clear out any lingering state to try the operation again.
 	s.Scope.DeleteLongRunningOperationState(resourceName, serviceName, futureType)
 
+	if err != nil && azure.IsResourceLocked(err) && s.orphanLockedResources() {
+		log.V(2).Info("orphaning locked resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+		s.recordEvent(infrav1.ResourceLockedReason, "Skipped deleting locked resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		return nil
+	}
+
 	if err != nil && !azure.ResourceNotFound(err) {
-		return errors.Wrapf(err, "failed to delete resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		errWrapped := errors.Wrapf(err, "failed to delete resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		s.recordErrorEvent(errWrapped)
+		return azure.ClassifyError(errWrapped)
 	}
 
 	log.V(2).Info("successfully deleted resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	if err == nil {
+		s.recordEvent("Deleted", "Successfully deleted resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	}
 	return nil
 }
 
+// dryRun returns true if the scope is reconciled in dry-run mode.
+func (s *Service[C, D]) dryRun() bool {
+	d, ok := s.Scope.(DryRunner)
+	return ok && d.DryRun()
+}
+
+// getter returns the client of the service which gets resources, or nil if it has none.
+func (s *Service[C, D]) getter() Getter {
+	if s.Creator != nil {
+		return s.Creator
+	}
+	if g, ok := s.Deleter.(Getter); ok {
+		return g
+	}
+	return nil
+}
+
+// orphanLockedResources returns true if the resources of the scope which are locked should be left in Azure.
+func (s *Service[C, D]) orphanLockedResources() bool {
+	o, ok := s.Scope.(LockedResourceOrphaner)
+	return ok && o.OrphanLockedResources()
+}
+
+// recordEvent records a Normal Event on the scope's object, if the scope has one.
+func (s *Service[C, D]) recordEvent(reason, message string, args ...interface{}) {
+	if getter, ok := s.Scope.(EventObjectGetter); ok {
+		record.Eventf(getter.EventObject(), reason, message, args...)
+	}
+}
+
+// recordErrorEvent records a Warning Event on the scope's object, if the scope has one, when an operation
+// failed because of throttling or an exceeded quota. Other errors are reported by the controllers.
+func (s *Service[C, D]) recordErrorEvent(err error) {
+	getter, ok := s.Scope.(EventObjectGetter)
+	if !ok {
+		return
+	}
+	switch {
+	case azure.IsThrottled(err):
+		record.Warn(getter.EventObject(), "Throttled", err.Error())
+	case azure.IsQuotaExceeded(err):
+		record.Warn(getter.EventObject(), "QuotaExceeded", err.Error())
+	}
+}
+
 // requeueTime returns the time to wait before requeuing a reconciliation.
 // It would be ideal to use the "retry-after" header from the API response, but
 // that is not readily accessible in the SDK v2 Poller framework.
@@ -178,6 +282,16 @@ func requeueTime(timeouts azure.AsyncReconciler) time.Duration {
 	return timeouts.DefaultedReconcilerRequeue()
 }
 
+// pollingRequeueTime returns how long to wait before checking an in-progress long-running operation again.
+// It is the polling interval requested by Azure for the operation if there is one, and the default requeue time
+// of the reconciler otherwise.
+func pollingRequeueTime(ctx context.Context, timeouts azure.AsyncReconciler) time.Duration {
+	if interval, ok := azure.PollingInterval(ctx); ok {
+		return interval
+	}
+	return requeueTime(timeouts)
+}
+
 // getRetryAfterFromError returns the time.Duration from the http.Response in the azcore.ResponseError.
 // If there is no Response object, or if there is no meaningful Retry-After header data, it returns a default.
 func getRetryAfterFromError(err error) time.Duration {
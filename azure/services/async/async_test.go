@@ -26,18 +26,23 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cgrecord "k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestServiceCreateOrUpdateResource(t *testing.T) {
@@ -86,7 +91,7 @@ func TestServiceCreateOrUpdateResource(t *testing.T) {
 					r.ResourceGroupName().Return(resourceGroupName),
 					s.GetLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture).Return(validPutFuture),
 					c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), resumeToken, gomock.Any()).Return(nil, fakePoller[MockCreator](g, http.StatusAccepted), context.DeadlineExceeded),
-					s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{})),
+					s.SetLongRunningOperationState(hasStartTime(validPutFuture.StartTime)),
 					s.DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue),
 				)
 			},
@@ -105,6 +110,20 @@ func TestServiceCreateOrUpdateResource(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:          "operation failed with terminal error",
+			serviceName:   serviceName,
+			expectedError: "reconcile error that cannot be recovered occurred: failed to create or update resource mock-resourcegroup/mock-resource (service: mock-service)",
+			expect: func(g *WithT, s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					s.GetLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture).Return(validPutFuture),
+					c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), resumeToken, gomock.Any()).Return(nil, nil, &azcore.ResponseError{StatusCode: http.StatusBadRequest, ErrorCode: "InvalidParameter"}),
+					s.DeleteLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture),
+				)
+			},
+		},
 		{
 			name:          "get returns resource not found error",
 			serviceName:   serviceName,
@@ -229,7 +248,7 @@ func TestServiceDeleteResource(t *testing.T) {
 					r.ResourceGroupName().Return(resourceGroupName),
 					s.GetLongRunningOperationState(resourceName, serviceName, infrav1.DeleteFuture).Return(validDeleteFuture),
 					d.DeleteAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), gomock.Any()).Return(fakePoller[MockDeleter](g, http.StatusAccepted), context.DeadlineExceeded),
-					s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{})),
+					s.SetLongRunningOperationState(hasStartTime(validDeleteFuture.StartTime)),
 					s.DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue),
 				)
 			},
@@ -290,6 +309,185 @@ func TestServiceDeleteResource(t *testing.T) {
 	}
 }
 
+func TestServiceEvents(t *testing.T) {
+	g := NewWithT(t)
+	record.InitFromRecorder(recorder)
+	drainEvents()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+	creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+	deleterMock := mock_async.NewMockDeleter[MockDeleter](mockCtrl)
+	specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+	scope := eventScope{MockFutureScope: scopeMock, object: &infrav1.AzureCluster{}}
+	svc := New[MockCreator, MockDeleter](scope, creatorMock, deleterMock)
+
+	specMock.EXPECT().ResourceName().Return(resourceName).AnyTimes()
+	specMock.EXPECT().ResourceGroupName().Return(resourceGroupName).AnyTimes()
+	specMock.EXPECT().Parameters(gomockinternal.AContext(), nil).Return(fakeParameters, nil).AnyTimes()
+	scopeMock.EXPECT().GetLongRunningOperationState(resourceName, serviceName, gomock.Any()).Return(nil).AnyTimes()
+	scopeMock.EXPECT().DeleteLongRunningOperationState(resourceName, serviceName, gomock.Any()).AnyTimes()
+	creatorMock.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound}).AnyTimes()
+	gomock.InOrder(
+		creatorMock.EXPECT().CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "", gomock.Any()).Return(fakeResource, nil, nil),
+		creatorMock.EXPECT().CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "", gomock.Any()).Return(nil, nil, &azcore.ResponseError{StatusCode: http.StatusTooManyRequests}),
+	)
+	deleterMock.EXPECT().DeleteAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "").Return(nil, nil)
+
+	_, err := svc.CreateOrUpdateResource(context.TODO(), specMock, serviceName)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = svc.CreateOrUpdateResource(context.TODO(), specMock, serviceName)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(svc.DeleteResource(context.TODO(), specMock, serviceName)).To(Succeed())
+
+	events := drainEvents()
+	g.Expect(events).To(HaveLen(6))
+	g.Expect(events[0]).To(Equal("Normal Creating Creating resource mock-resourcegroup/mock-resource (service: mock-service)"))
+	g.Expect(events[1]).To(Equal("Normal Provisioned Successfully created or updated resource mock-resourcegroup/mock-resource (service: mock-service)"))
+	g.Expect(events[2]).To(HavePrefix("Normal Creating "))
+	g.Expect(events[3]).To(HavePrefix("Warning Throttled failed to create or update resource mock-resourcegroup/mock-resource (service: mock-service)"))
+	g.Expect(events[4]).To(Equal("Normal Deleting Deleting resource mock-resourcegroup/mock-resource (service: mock-service)"))
+	g.Expect(events[5]).To(Equal("Normal Deleted Successfully deleted resource mock-resourcegroup/mock-resource (service: mock-service)"))
+}
+
+func TestServiceDryRun(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+	creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+	deleterMock := mock_async.NewMockDeleter[MockDeleter](mockCtrl)
+	specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+	scope := eventScope{MockFutureScope: scopeMock, object: &infrav1.AzureCluster{}, dryRun: true}
+	svc := New[MockCreator, MockDeleter](scope, creatorMock, deleterMock)
+
+	specMock.EXPECT().ResourceName().Return(resourceName).AnyTimes()
+	specMock.EXPECT().ResourceGroupName().Return(resourceGroupName).AnyTimes()
+	scopeMock.EXPECT().GetLongRunningOperationState(resourceName, serviceName, gomock.Any()).Return(nil).AnyTimes()
+	scopeMock.EXPECT().DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue).AnyTimes()
+	gomock.InOrder(
+		creatorMock.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound}),
+		specMock.EXPECT().Parameters(gomockinternal.AContext(), nil).Return(fakeParameters, nil),
+		creatorMock.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(fakeResource, nil),
+		specMock.EXPECT().Parameters(gomockinternal.AContext(), fakeResource).Return(fakeParameters, nil),
+		creatorMock.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(fakeResource, nil),
+		creatorMock.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound}),
+	)
+
+	result, err := svc.CreateOrUpdateResource(context.TODO(), specMock, serviceName)
+	g.Expect(result).To(BeNil())
+	g.Expect(err).To(MatchError("dry run: would create resource mock-resourcegroup/mock-resource (service: mock-service). Object will be requeued after 15s"))
+
+	result, err = svc.CreateOrUpdateResource(context.TODO(), specMock, serviceName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(fakeResource))
+
+	drainEvents()
+	err = svc.DeleteResource(context.TODO(), specMock, serviceName)
+	g.Expect(err).To(MatchError("dry run: would delete resource mock-resourcegroup/mock-resource (service: mock-service). Object will be requeued after 15s"))
+	g.Expect(drainEvents()).To(Equal([]string{"Normal DryRun Would delete resource mock-resourcegroup/mock-resource (service: mock-service)"}))
+
+	// A resource that doesn't exist isn't reported as to be deleted.
+	g.Expect(svc.DeleteResource(context.TODO(), specMock, serviceName)).To(Succeed())
+	g.Expect(drainEvents()).To(BeEmpty())
+}
+
+func TestServiceDeleteLockedResource(t *testing.T) {
+	tests := []struct {
+		name                  string
+		orphanLockedResources bool
+		expectedError         string
+		expectedEvent         string
+	}{
+		{
+			name:          "locked resource deletion fails",
+			expectedError: "reconcile error that cannot be recovered occurred",
+		},
+		{
+			name:                  "locked resource is orphaned",
+			orphanLockedResources: true,
+			expectedEvent:         "Normal ResourceLocked Skipped deleting locked resource mock-resourcegroup/mock-resource (service: mock-service)",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+			deleterMock := mock_async.NewMockDeleter[MockDeleter](mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+			scope := eventScope{MockFutureScope: scopeMock, object: &infrav1.AzureCluster{}, orphanLockedResources: tc.orphanLockedResources}
+			svc := New[MockCreator, MockDeleter](scope, creatorMock, deleterMock)
+			drainEvents()
+
+			specMock.EXPECT().ResourceName().Return(resourceName).AnyTimes()
+			specMock.EXPECT().ResourceGroupName().Return(resourceGroupName).AnyTimes()
+			scopeMock.EXPECT().GetLongRunningOperationState(resourceName, serviceName, infrav1.DeleteFuture).Return(nil)
+			deleterMock.EXPECT().DeleteAsync(gomockinternal.AContext(), specMock, "").Return(nil, &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "ScopeLocked"})
+			scopeMock.EXPECT().DeleteLongRunningOperationState(resourceName, serviceName, infrav1.DeleteFuture)
+
+			err := svc.DeleteResource(context.TODO(), specMock, serviceName)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				g.Expect(azure.IsResourceLocked(err)).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			events := drainEvents()
+			if tc.expectedEvent != "" {
+				g.Expect(events).To(ContainElement(HavePrefix(tc.expectedEvent)))
+			} else {
+				g.Expect(events).NotTo(ContainElement(ContainSubstring(infrav1.ResourceLockedReason)))
+			}
+		})
+	}
+}
+
+// hasStartTime matches a future started at startTime.
+func hasStartTime(startTime *metav1.Time) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		future, ok := x.(*infrav1.Future)
+		return ok && future.StartTime.Equal(startTime)
+	})
+}
+
+// recorder receives the Events recorded by the services.
+var recorder = cgrecord.NewFakeRecorder(100)
+
+// drainEvents returns the Events recorded since the last call.
+func drainEvents() []string {
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	return events
+}
+
+// eventScope is a FutureScope whose object receives Events.
+type eventScope struct {
+	*mock_async.MockFutureScope
+	object                client.Object
+	dryRun                bool
+	orphanLockedResources bool
+}
+
+func (s eventScope) EventObject() client.Object {
+	return s.object
+}
+
+func (s eventScope) DryRun() bool {
+	return s.dryRun
+}
+
+func (s eventScope) OrphanLockedResources() bool {
+	return s.orphanLockedResources
+}
+
 const (
 	resourceGroupName  = "mock-resourcegroup"
 	resourceName       = "mock-resource"
@@ -299,12 +497,14 @@ const (
 )
 
 var (
-	validPutFuture = &infrav1.Future{
+	futureStartTime = &metav1.Time{Time: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	validPutFuture  = &infrav1.Future{
 		Type:          infrav1.PutFuture,
 		ServiceName:   serviceName,
 		Name:          resourceName,
 		ResourceGroup: resourceGroupName,
 		Data:          base64.URLEncoding.EncodeToString([]byte(resumeToken)),
+		StartTime:     futureStartTime,
 	}
 	invalidPutFuture = &infrav1.Future{
 		Type:          infrav1.PutFuture,
@@ -319,6 +519,7 @@ var (
 		Name:          resourceName,
 		ResourceGroup: resourceGroupName,
 		Data:          base64.URLEncoding.EncodeToString([]byte(resumeToken)),
+		StartTime:     futureStartTime,
 	}
 	invalidDeleteFuture = &infrav1.Future{
 		Type:          infrav1.DeleteFuture,
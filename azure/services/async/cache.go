@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"golang.org/x/sync/singleflight"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// getCacheSize is the maximum number of GET responses kept in the cache.
+const getCacheSize = 10000
+
+var (
+	// GetCacheTTL is how long a successful GET response is served from the cache instead of
+	// calling Azure again. A value of 0 disables the cache.
+	GetCacheTTL time.Duration
+
+	// ListBasedGet makes a cache miss list all the resources of the same kind in the resource group
+	// and cache each of them, so that reconciling many machines of a resource group costs one LIST
+	// call instead of one GET call per machine. It has no effect if the cache is disabled.
+	ListBasedGet bool
+
+	getCacheOnce sync.Once
+	getCache     ttllru.PeekingCacher
+	getCacheErr  error
+
+	// lists deduplicates the LIST calls of concurrent cache misses in the same resource group.
+	lists singleflight.Group
+)
+
+// CachingGetter serves GET responses from a short-lived cache shared by all reconcilers, so that
+// resources which are read by several controllers in quick succession are only fetched once.
+type CachingGetter struct {
+	Getter
+	auth   azure.Authorizer
+	lister Lister
+}
+
+// NewCachingGetter wraps a Getter with the GET response cache. If the Getter is also a Lister, cache
+// misses are served by listing the resource group when ListBasedGet is enabled.
+func NewCachingGetter(auth azure.Authorizer, getter Getter) *CachingGetter {
+	lister, _ := getter.(Lister)
+	return &CachingGetter{
+		Getter: getter,
+		auth:   auth,
+		lister: lister,
+	}
+}
+
+// Get returns the cached response for the resource if there is one, otherwise it gets the
+// resource from Azure and caches the response.
+func (c *CachingGetter) Get(ctx context.Context, spec azure.ResourceSpecGetter) (interface{}, error) {
+	cache := sharedGetCache()
+	if cache == nil {
+		return c.Getter.Get(ctx, spec)
+	}
+
+	key := getCacheKey(c.auth, spec)
+	// Peek does not extend the lifetime of the entry, so a resource is fetched again at least
+	// once every GetCacheTTL.
+	if result, _, ok := cache.Peek(key); ok {
+		return result, nil
+	}
+	if c.listResourceGroup(ctx, cache, spec) {
+		if result, _, ok := cache.Peek(key); ok {
+			return result, nil
+		}
+	}
+	// Resources which don't exist yet, or which were created after the resource group was listed,
+	// are still fetched one by one.
+	result, err := c.Getter.Get(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	cache.Add(key, result)
+	return result, nil
+}
+
+// listResourceGroup caches all the resources of the resource group of spec, unless it was listed
+// within GetCacheTTL. It returns whether the resource group was listed successfully.
+func (c *CachingGetter) listResourceGroup(ctx context.Context, cache ttllru.PeekingCacher, spec azure.ResourceSpecGetter) bool {
+	// Child resources, such as subnets or VMSS VMs, are not listed by resource group.
+	if !ListBasedGet || c.lister == nil || spec.OwnerResourceName() != "" {
+		return false
+	}
+	listKey := cacheKey(c.auth, spec, spec.ResourceGroupName(), "", "") + "/list"
+	if _, _, ok := cache.Peek(listKey); ok {
+		return false
+	}
+
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.CachingGetter.listResourceGroup")
+	defer done()
+
+	_, err, _ := lists.Do(listKey, func() (interface{}, error) {
+		resources, err := c.lister.ListByResourceGroup(ctx, spec.ResourceGroupName())
+		// The resource group isn't listed again within GetCacheTTL even if listing failed, so that a
+		// failing LIST call doesn't precede every GET call.
+		cache.Add(listKey, true)
+		if err != nil {
+			return nil, err
+		}
+		for name, resource := range resources {
+			cache.Add(cacheKey(c.auth, spec, spec.ResourceGroupName(), "", name), resource)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		// Listing only saves calls, the resource is fetched with a GET instead.
+		log.Error(err, "failed to list resources, falling back to GET", "resourceGroup", spec.ResourceGroupName())
+		return false
+	}
+	return true
+}
+
+// CachingClient wraps a client that creates, updates and deletes resources with the GET response
+// cache. The cached response of a resource is dropped whenever it is created, updated or deleted.
+type CachingClient[C, D any] struct {
+	*CachingGetter
+	creator Creator[C]
+	deleter Deleter[D]
+}
+
+// NewCachingClient wraps a client with the GET response cache.
+func NewCachingClient[C, D any](auth azure.Authorizer, client interface {
+	Creator[C]
+	Deleter[D]
+}) *CachingClient[C, D] {
+	return &CachingClient[C, D]{
+		CachingGetter: NewCachingGetter(auth, client),
+		creator:       client,
+		deleter:       client,
+	}
+}
+
+// CreateOrUpdateAsync drops the cached response of the resource and creates or updates it.
+func (c *CachingClient[C, D]) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, parameters interface{}) (interface{}, *runtime.Poller[C], error) {
+	Forget(c.auth, spec)
+	return c.creator.CreateOrUpdateAsync(ctx, spec, resumeToken, parameters)
+}
+
+// DeleteAsync drops the cached response of the resource and deletes it.
+func (c *CachingClient[C, D]) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (*runtime.Poller[D], error) {
+	Forget(c.auth, spec)
+	return c.deleter.DeleteAsync(ctx, spec, resumeToken)
+}
+
+// Forget drops the cached GET response of a resource. It must be called after changing a resource
+// through any client that is not wrapped with the cache.
+func Forget(auth azure.Authorizer, spec azure.ResourceSpecGetter) {
+	cache := sharedGetCache()
+	if cache == nil {
+		return
+	}
+	cache.Remove(getCacheKey(auth, spec))
+}
+
+// sharedGetCache returns the GET response cache, or nil if it is disabled.
+func sharedGetCache() ttllru.PeekingCacher {
+	if GetCacheTTL <= 0 {
+		return nil
+	}
+	getCacheOnce.Do(func() {
+		getCache, getCacheErr = ttllru.New(getCacheSize, GetCacheTTL)
+	})
+	if getCacheErr != nil {
+		return nil
+	}
+	return getCache
+}
+
+// getCacheKey identifies the resource of a spec across credentials, resource types and resource groups.
+func getCacheKey(auth azure.Authorizer, spec azure.ResourceSpecGetter) string {
+	return cacheKey(auth, spec, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+}
+
+// cacheKey identifies a resource of the same type as spec. Azure resource names are case-insensitive.
+func cacheKey(auth azure.Authorizer, spec azure.ResourceSpecGetter, resourceGroup, owner, name string) string {
+	return fmt.Sprintf("%s/%T/%s", auth.HashKey(), spec, strings.ToLower(resourceGroup+"/"+owner+"/"+name))
+}
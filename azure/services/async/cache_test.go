@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+type cacheTestSpec struct {
+	name string
+}
+
+func (s *cacheTestSpec) ResourceName() string      { return s.name }
+func (s *cacheTestSpec) ResourceGroupName() string { return "my-rg" }
+func (s *cacheTestSpec) OwnerResourceName() string { return "" }
+func (s *cacheTestSpec) Parameters(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+type cacheTestClient struct {
+	*mock_async.MockCreator[MockCreator]
+	*mock_async.MockDeleter[MockDeleter]
+}
+
+func TestCachingClient(t *testing.T) {
+	spec := &cacheTestSpec{name: "my-vm"}
+	otherSpec := &cacheTestSpec{name: "other-vm"}
+
+	testcases := []struct {
+		name   string
+		ttl    time.Duration
+		expect func(c *mock_async.MockCreatorMockRecorder[MockCreator], d *mock_async.MockDeleterMockRecorder[MockDeleter])
+		run    func(g *WithT, client *CachingClient[MockCreator, MockDeleter])
+	}{
+		{
+			name: "every get calls Azure when the cache is disabled",
+			ttl:  0,
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], _ *mock_async.MockDeleterMockRecorder[MockDeleter]) {
+				c.Get(gomockinternal.AContext(), spec).Return("my-vm", nil).Times(2)
+			},
+			run: func(g *WithT, client *CachingClient[MockCreator, MockDeleter]) {
+				for range 2 {
+					result, err := client.Get(context.TODO(), spec)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(result).To(Equal("my-vm"))
+				}
+			},
+		},
+		{
+			name: "a second get of the same resource is served from the cache",
+			ttl:  time.Minute,
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], _ *mock_async.MockDeleterMockRecorder[MockDeleter]) {
+				c.Get(gomockinternal.AContext(), spec).Return("my-vm", nil)
+				c.Get(gomockinternal.AContext(), otherSpec).Return("other-vm", nil)
+			},
+			run: func(g *WithT, client *CachingClient[MockCreator, MockDeleter]) {
+				for range 2 {
+					result, err := client.Get(context.TODO(), spec)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(result).To(Equal("my-vm"))
+				}
+				result, err := client.Get(context.TODO(), otherSpec)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal("other-vm"))
+			},
+		},
+		{
+			name: "errors are not cached",
+			ttl:  time.Minute,
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], _ *mock_async.MockDeleterMockRecorder[MockDeleter]) {
+				gomock.InOrder(
+					c.Get(gomockinternal.AContext(), spec).Return(nil, errors.New("foo")),
+					c.Get(gomockinternal.AContext(), spec).Return("my-vm", nil),
+				)
+			},
+			run: func(g *WithT, client *CachingClient[MockCreator, MockDeleter]) {
+				_, err := client.Get(context.TODO(), spec)
+				g.Expect(err).To(MatchError("foo"))
+				result, err := client.Get(context.TODO(), spec)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal("my-vm"))
+			},
+		},
+		{
+			name: "create or update drops the cached response",
+			ttl:  time.Minute,
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], _ *mock_async.MockDeleterMockRecorder[MockDeleter]) {
+				gomock.InOrder(
+					c.Get(gomockinternal.AContext(), spec).Return("my-vm", nil),
+					c.CreateOrUpdateAsync(gomockinternal.AContext(), spec, "", "params").Return(nil, nil, nil),
+					c.Get(gomockinternal.AContext(), spec).Return("my-updated-vm", nil),
+				)
+			},
+			run: func(g *WithT, client *CachingClient[MockCreator, MockDeleter]) {
+				_, err := client.Get(context.TODO(), spec)
+				g.Expect(err).NotTo(HaveOccurred())
+				_, _, err = client.CreateOrUpdateAsync(context.TODO(), spec, "", "params")
+				g.Expect(err).NotTo(HaveOccurred())
+				result, err := client.Get(context.TODO(), spec)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal("my-updated-vm"))
+			},
+		},
+		{
+			name: "delete drops the cached response",
+			ttl:  time.Minute,
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], d *mock_async.MockDeleterMockRecorder[MockDeleter]) {
+				gomock.InOrder(
+					c.Get(gomockinternal.AContext(), spec).Return("my-vm", nil),
+					d.DeleteAsync(gomockinternal.AContext(), spec, "").Return(nil, nil),
+					c.Get(gomockinternal.AContext(), spec).Return(nil, errors.New("not found")),
+				)
+			},
+			run: func(g *WithT, client *CachingClient[MockCreator, MockDeleter]) {
+				_, err := client.Get(context.TODO(), spec)
+				g.Expect(err).NotTo(HaveOccurred())
+				_, err = client.DeleteAsync(context.TODO(), spec, "")
+				g.Expect(err).NotTo(HaveOccurred())
+				_, err = client.Get(context.TODO(), spec)
+				g.Expect(err).To(MatchError("not found"))
+			},
+		},
+		{
+			name: "forget drops the cached response",
+			ttl:  time.Minute,
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], _ *mock_async.MockDeleterMockRecorder[MockDeleter]) {
+				c.Get(gomockinternal.AContext(), spec).Return("my-vm", nil).Times(2)
+			},
+			run: func(g *WithT, client *CachingClient[MockCreator, MockDeleter]) {
+				_, err := client.Get(context.TODO(), spec)
+				g.Expect(err).NotTo(HaveOccurred())
+				Forget(client.auth, spec)
+				_, err = client.Get(context.TODO(), spec)
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		// The cache is shared by the whole process, so these tests can't run in parallel.
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			GetCacheTTL = tc.ttl
+			getCacheOnce = sync.Once{}
+			defer func() {
+				GetCacheTTL = 0
+				getCacheOnce = sync.Once{}
+			}()
+
+			authMock := mock_azure.NewMockAuthorizer(mockCtrl)
+			authMock.EXPECT().HashKey().Return("my-hash").AnyTimes()
+			creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+			deleterMock := mock_async.NewMockDeleter[MockDeleter](mockCtrl)
+			tc.expect(creatorMock.EXPECT(), deleterMock.EXPECT())
+
+			client := NewCachingClient[MockCreator, MockDeleter](authMock, cacheTestClient{MockCreator: creatorMock, MockDeleter: deleterMock})
+			tc.run(g, client)
+		})
+	}
+}
+
+type cacheTestLister struct {
+	*mock_async.MockGetter
+	resources map[string]interface{}
+	err       error
+	lists     int
+}
+
+func (l *cacheTestLister) ListByResourceGroup(_ context.Context, _ string) (map[string]interface{}, error) {
+	l.lists++
+	return l.resources, l.err
+}
+
+func TestCachingGetterListBasedGet(t *testing.T) {
+	testcases := []struct {
+		name          string
+		listBasedGet  bool
+		listErr       error
+		expect        func(g *mock_async.MockGetterMockRecorder)
+		expectedLists int
+	}{
+		{
+			name:         "machines of a resource group are served by a single list",
+			listBasedGet: true,
+			expect: func(g *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), &cacheTestSpec{name: "vm-2"}).Return("vm-2", nil)
+			},
+			expectedLists: 1,
+		},
+		{
+			name:         "resources are fetched one by one without listing again if listing fails",
+			listBasedGet: true,
+			listErr:      errors.New("foo"),
+			expect: func(g *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), &cacheTestSpec{name: "vm-0"}).Return("vm-0", nil)
+				g.Get(gomockinternal.AContext(), &cacheTestSpec{name: "vm-1"}).Return("vm-1", nil)
+				g.Get(gomockinternal.AContext(), &cacheTestSpec{name: "vm-2"}).Return("vm-2", nil)
+			},
+			expectedLists: 1,
+		},
+		{
+			name:         "resources are fetched one by one if list-based get is disabled",
+			listBasedGet: false,
+			expect: func(g *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), &cacheTestSpec{name: "vm-0"}).Return("vm-0", nil)
+				g.Get(gomockinternal.AContext(), &cacheTestSpec{name: "vm-1"}).Return("vm-1", nil)
+				g.Get(gomockinternal.AContext(), &cacheTestSpec{name: "vm-2"}).Return("vm-2", nil)
+			},
+			expectedLists: 0,
+		},
+	}
+
+	for _, tc := range testcases {
+		// The cache is shared by the whole process, so these tests can't run in parallel.
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			GetCacheTTL = time.Minute
+			ListBasedGet = tc.listBasedGet
+			getCacheOnce = sync.Once{}
+			defer func() {
+				GetCacheTTL = 0
+				ListBasedGet = false
+				getCacheOnce = sync.Once{}
+			}()
+
+			authMock := mock_azure.NewMockAuthorizer(mockCtrl)
+			authMock.EXPECT().HashKey().Return("my-hash").AnyTimes()
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			tc.expect(getterMock.EXPECT())
+			lister := &cacheTestLister{
+				MockGetter: getterMock,
+				// vm-2 was created after the resource group was listed.
+				resources: map[string]interface{}{"vm-0": "vm-0", "VM-1": "vm-1"},
+				err:       tc.listErr,
+			}
+
+			getter := NewCachingGetter(authMock, lister)
+			for _, name := range []string{"vm-0", "vm-1", "vm-2"} {
+				result, err := getter.Get(context.TODO(), &cacheTestSpec{name: name})
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal(name))
+			}
+			g.Expect(lister.lists).To(Equal(tc.expectedLists))
+		})
+	}
+}
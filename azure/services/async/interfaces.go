@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // FutureScope stores and retrieves Futures and Conditions.
@@ -29,11 +30,34 @@ type FutureScope interface {
 	azure.AsyncStatusUpdater
 }
 
+// EventObjectGetter is implemented by scopes whose object should receive Kubernetes Events about the
+// operations performed on its Azure resources.
+type EventObjectGetter interface {
+	EventObject() client.Object
+}
+
+// DryRunner is implemented by scopes which can be reconciled in dry-run mode, in which resources are
+// not created, updated or deleted.
+type DryRunner interface {
+	DryRun() bool
+}
+
+// LockedResourceOrphaner is implemented by scopes which can leave the resources which can't be deleted because of an
+// Azure management lock in Azure.
+type LockedResourceOrphaner interface {
+	OrphanLockedResources() bool
+}
+
 // Getter gets a resource.
 type Getter interface {
 	Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error)
 }
 
+// Lister lists the resources of a resource group, keyed by their name.
+type Lister interface {
+	ListByResourceGroup(ctx context.Context, resourceGroupName string) (result map[string]interface{}, err error)
+}
+
 // TagsGetter is an interface that can get a tags resource.
 type TagsGetter interface {
 	GetAtScope(ctx context.Context, scope string) (result armresources.TagsResource, err error)
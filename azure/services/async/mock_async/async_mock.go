@@ -36,6 +36,7 @@ import (
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
 	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // MockFutureScope is a mock of FutureScope interface.
@@ -61,6 +62,34 @@ func (m *MockFutureScope) EXPECT() *MockFutureScopeMockRecorder {
 	return m.recorder
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockFutureScope) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockFutureScopeMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockFutureScope)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockFutureScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockFutureScopeMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockFutureScope)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // DefaultedAzureCallTimeout mocks base method.
 func (m *MockFutureScope) DefaultedAzureCallTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -177,6 +206,80 @@ func (mr *MockFutureScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockFutureScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
 
+// MockEventObjectGetter is a mock of EventObjectGetter interface.
+type MockEventObjectGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventObjectGetterMockRecorder
+}
+
+// MockEventObjectGetterMockRecorder is the mock recorder for MockEventObjectGetter.
+type MockEventObjectGetterMockRecorder struct {
+	mock *MockEventObjectGetter
+}
+
+// NewMockEventObjectGetter creates a new mock instance.
+func NewMockEventObjectGetter(ctrl *gomock.Controller) *MockEventObjectGetter {
+	mock := &MockEventObjectGetter{ctrl: ctrl}
+	mock.recorder = &MockEventObjectGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventObjectGetter) EXPECT() *MockEventObjectGetterMockRecorder {
+	return m.recorder
+}
+
+// EventObject mocks base method.
+func (m *MockEventObjectGetter) EventObject() client.Object {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventObject")
+	ret0, _ := ret[0].(client.Object)
+	return ret0
+}
+
+// EventObject indicates an expected call of EventObject.
+func (mr *MockEventObjectGetterMockRecorder) EventObject() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventObject", reflect.TypeOf((*MockEventObjectGetter)(nil).EventObject))
+}
+
+// MockDryRunner is a mock of DryRunner interface.
+type MockDryRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockDryRunnerMockRecorder
+}
+
+// MockDryRunnerMockRecorder is the mock recorder for MockDryRunner.
+type MockDryRunnerMockRecorder struct {
+	mock *MockDryRunner
+}
+
+// NewMockDryRunner creates a new mock instance.
+func NewMockDryRunner(ctrl *gomock.Controller) *MockDryRunner {
+	mock := &MockDryRunner{ctrl: ctrl}
+	mock.recorder = &MockDryRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDryRunner) EXPECT() *MockDryRunnerMockRecorder {
+	return m.recorder
+}
+
+// DryRun mocks base method.
+func (m *MockDryRunner) DryRun() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRun")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DryRun indicates an expected call of DryRun.
+func (mr *MockDryRunnerMockRecorder) DryRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRun", reflect.TypeOf((*MockDryRunner)(nil).DryRun))
+}
+
 // MockGetter is a mock of Getter interface.
 type MockGetter struct {
 	ctrl     *gomock.Controller
@@ -215,6 +318,44 @@ func (mr *MockGetterMockRecorder) Get(ctx, spec any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGetter)(nil).Get), ctx, spec)
 }
 
+// MockLister is a mock of Lister interface.
+type MockLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockListerMockRecorder
+}
+
+// MockListerMockRecorder is the mock recorder for MockLister.
+type MockListerMockRecorder struct {
+	mock *MockLister
+}
+
+// NewMockLister creates a new mock instance.
+func NewMockLister(ctrl *gomock.Controller) *MockLister {
+	mock := &MockLister{ctrl: ctrl}
+	mock.recorder = &MockListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLister) EXPECT() *MockListerMockRecorder {
+	return m.recorder
+}
+
+// ListByResourceGroup mocks base method.
+func (m *MockLister) ListByResourceGroup(ctx context.Context, resourceGroupName string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByResourceGroup", ctx, resourceGroupName)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByResourceGroup indicates an expected call of ListByResourceGroup.
+func (mr *MockListerMockRecorder) ListByResourceGroup(ctx, resourceGroupName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByResourceGroup", reflect.TypeOf((*MockLister)(nil).ListByResourceGroup), ctx, resourceGroupName)
+}
+
 // MockTagsGetter is a mock of TagsGetter interface.
 type MockTagsGetter struct {
 	ctrl     *gomock.Controller
@@ -101,6 +101,34 @@ func (mr *MockAvailabilitySetScopeMockRecorder) AvailabilitySetSpec() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetSpec", reflect.TypeOf((*MockAvailabilitySetScope)(nil).AvailabilitySetSpec))
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockAvailabilitySetScope) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockAvailabilitySetScopeMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockAvailabilitySetScope)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockAvailabilitySetScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockAvailabilitySetScopeMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockAvailabilitySetScope)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // BaseURI mocks base method.
 func (m *MockAvailabilitySetScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -157,6 +185,20 @@ func (mr *MockAvailabilitySetScopeMockRecorder) CloudEnvironment() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockAvailabilitySetScope)(nil).CloudEnvironment))
 }
 
+// CloudProviderConfigDisabled mocks base method.
+func (m *MockAvailabilitySetScope) CloudProviderConfigDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudProviderConfigDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CloudProviderConfigDisabled indicates an expected call of CloudProviderConfigDisabled.
+func (mr *MockAvailabilitySetScopeMockRecorder) CloudProviderConfigDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudProviderConfigDisabled", reflect.TypeOf((*MockAvailabilitySetScope)(nil).CloudProviderConfigDisabled))
+}
+
 // CloudProviderConfigOverrides mocks base method.
 func (m *MockAvailabilitySetScope) CloudProviderConfigOverrides() *v1beta1.CloudProviderConfigOverrides {
 	m.ctrl.T.Helper()
@@ -365,6 +407,20 @@ func (mr *MockAvailabilitySetScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockAvailabilitySetScope)(nil).ResourceGroup))
 }
 
+// ResourceNaming mocks base method.
+func (m *MockAvailabilitySetScope) ResourceNaming() *v1beta1.ResourceNamingSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceNaming")
+	ret0, _ := ret[0].(*v1beta1.ResourceNamingSpec)
+	return ret0
+}
+
+// ResourceNaming indicates an expected call of ResourceNaming.
+func (mr *MockAvailabilitySetScopeMockRecorder) ResourceNaming() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceNaming", reflect.TypeOf((*MockAvailabilitySetScope)(nil).ResourceNaming))
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockAvailabilitySetScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,312 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	serviceName = "bootstrapdata"
+
+	// maxCustomDataLength is the maximum length of the base64 encoded custom data of a virtual machine, which
+	// encodes up to 64 KiB of data.
+	maxCustomDataLength = 87380
+
+	// CloudConfigFormat is the format of cloud-init bootstrap data.
+	CloudConfigFormat = "cloud-config"
+	// IgnitionFormat is the format of ignition bootstrap data.
+	IgnitionFormat = "ignition"
+
+	// maxSecretLength is the maximum length of the part of the bootstrap data stored in each key vault secret, below
+	// the 25 KiB limit of the value of a secret.
+	maxSecretLength = 24 * 1024
+
+	// keyVaultDataPath is the path the bootstrap data read from key vault secrets is written to on the virtual
+	// machine.
+	keyVaultDataPath = "/run/capz-bootstrap-data.yaml"
+)
+
+// keyVaultShim is the multipart user data making a virtual machine read its bootstrap data from key vault secrets
+// with its managed identity. The boothook part runs before the other parts are processed and writes the bootstrap
+// data to /run, which isn't persisted, where the include part hands it to cloud-init as a whole.
+var keyVaultShim = template.Must(template.New("shim").Parse(`Content-Type: multipart/mixed; boundary="==CAPZBOUNDARY=="
+MIME-Version: 1.0
+
+--==CAPZBOUNDARY==
+Content-Type: text/cloud-boothook; charset="us-ascii"
+MIME-Version: 1.0
+
+#!/bin/bash
+set -euo pipefail
+umask 077
+token=$(curl -sSf --retry 10 --retry-connrefused -H Metadata:true \
+  "http://169.254.169.254/metadata/identity/oauth2/token?api-version=2018-02-01&resource={{ .Resource }}{{ if .IdentityClientID }}&client_id={{ .IdentityClientID }}{{ end }}" \
+  | grep -o '"access_token":"[^"]*"' | cut -d'"' -f4)
+config={{ .ConfigPath }}
+: > "${config}.b64"
+for secret in{{ range .SecretNames }} {{ . }}{{ end }}; do
+  curl -sSf --retry 10 -H "Authorization: Bearer ${token}" "{{ .VaultURL }}/secrets/${secret}?api-version={{ .APIVersion }}" \
+    | grep -o '"value":"[^"]*"' | cut -d'"' -f4 | tr -d '\n' >> "${config}.b64"
+done
+data=$(tr '_-' '/+' < "${config}.b64")
+rm -f "${config}.b64"
+while [ $((${#data} % 4)) -ne 0 ]; do data="${data}="; done
+echo "${data}" | base64 -d | gunzip > "${config}"
+
+--==CAPZBOUNDARY==
+Content-Type: text/x-include-url; charset="us-ascii"
+MIME-Version: 1.0
+
+file://{{ .ConfigPath }}
+
+--==CAPZBOUNDARY==--
+`))
+
+// BootstrapDataScope defines the scope interface for a bootstrap data service.
+type BootstrapDataScope interface {
+	azure.Authorizer
+	BootstrapDataBlobSpec() *azure.BootstrapDataBlobSpec
+	BootstrapDataKeyVaultSpec() *azure.BootstrapDataKeyVaultSpec
+	BootstrapData() string
+	BootstrapDataFormat() string
+	SetBootstrapData(string)
+	ProviderID() string
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope BootstrapDataScope
+	client
+	keyVaultDNSSuffix string
+}
+
+// New creates a new service.
+func New(scope BootstrapDataScope) (*Service, error) {
+	suffix, err := keyVaultDNSSuffix(scope.CloudEnvironment())
+	if err != nil {
+		return nil, err
+	}
+	cli, err := newClient(scope, suffix)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope:             scope,
+		client:            cli,
+		keyVaultDNSSuffix: suffix,
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile stores the bootstrap data of a virtual machine which doesn't exist yet in key vault secrets, or uploads
+// it to a blob when it exceeds the custom data size limit, and replaces it with custom data fetching it at boot.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.Service.Reconcile")
+	defer done()
+
+	// The custom data of a virtual machine can't be changed once it is created.
+	if s.Scope.ProviderID() != "" {
+		return nil
+	}
+	if vault := s.Scope.BootstrapDataKeyVaultSpec(); vault != nil {
+		return s.reconcileKeyVault(ctx, vault)
+	}
+	spec := s.Scope.BootstrapDataBlobSpec()
+	if spec == nil {
+		return nil
+	}
+	data := s.Scope.BootstrapData()
+	if len(data) <= maxCustomDataLength {
+		return nil
+	}
+
+	format := s.Scope.BootstrapDataFormat()
+	if spec.Access == infrav1.BootstrapDataAccessManagedIdentity && format != IgnitionFormat {
+		return azure.WithTerminalError(errors.Errorf("bootstrap data in %s format can't be read with a managed identity, only ignition bootstrap data can", format))
+	}
+	if s.dryRun() {
+		s.recordEvent(infrav1.DryRunReason, "Would upload bootstrap data to storage account %s (service: %s)", spec.StorageAccountName, serviceName)
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode bootstrap data")
+	}
+
+	blobURL, err := s.UploadBlob(ctx, spec, decoded)
+	if err != nil {
+		return err
+	}
+	shim, err := fetchShim(format, decoded, blobURL)
+	if err != nil {
+		return err
+	}
+	s.Scope.SetBootstrapData(base64.StdEncoding.EncodeToString(shim))
+	return nil
+}
+
+// reconcileKeyVault stores the bootstrap data of a virtual machine in key vault secrets, and replaces it with custom
+// data reading the secrets with the managed identity of the virtual machine.
+func (s *Service) reconcileKeyVault(ctx context.Context, vault *azure.BootstrapDataKeyVaultSpec) error {
+	if format := s.Scope.BootstrapDataFormat(); format != "" && format != CloudConfigFormat {
+		return azure.WithTerminalError(errors.Errorf("bootstrap data in %s format can't be read from a key vault, only cloud-config bootstrap data can", format))
+	}
+	if s.dryRun() {
+		s.recordEvent(infrav1.DryRunReason, "Would store bootstrap data in key vault %s (service: %s)", vault.VaultName, serviceName)
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(s.Scope.BootstrapData())
+	if err != nil {
+		return errors.Wrap(err, "failed to decode bootstrap data")
+	}
+
+	// Compressing the bootstrap data reduces the number of secrets it is split into.
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(decoded); err != nil {
+		return errors.Wrap(err, "failed to compress bootstrap data")
+	}
+	if err := writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to compress bootstrap data")
+	}
+	// The unpadded URL-safe alphabet has no characters which could be escaped in the JSON responses of Key Vault.
+	value := base64.RawURLEncoding.EncodeToString(compressed.Bytes())
+
+	vaultURL := "https://" + vault.VaultName + "." + s.keyVaultDNSSuffix
+	var secretNames []string
+	for i := 0; i*maxSecretLength < len(value); i++ {
+		name := fmt.Sprintf("%s-%d", vault.SecretName, i)
+		if err := s.SetSecret(ctx, vaultURL, name, value[i*maxSecretLength:min((i+1)*maxSecretLength, len(value))]); err != nil {
+			return err
+		}
+		secretNames = append(secretNames, name)
+	}
+
+	var shim bytes.Buffer
+	if err := keyVaultShim.Execute(&shim, map[string]interface{}{
+		"Resource":         "https://" + s.keyVaultDNSSuffix,
+		"IdentityClientID": vault.IdentityClientID,
+		"VaultURL":         vaultURL,
+		"SecretNames":      secretNames,
+		"APIVersion":       keyVaultAPIVersion,
+		"ConfigPath":       keyVaultDataPath,
+	}); err != nil {
+		return errors.Wrap(err, "failed to render key vault bootstrap script")
+	}
+	s.Scope.SetBootstrapData(base64.StdEncoding.EncodeToString(shim.Bytes()))
+	return nil
+}
+
+// Delete deletes the blob or the key vault secrets holding the bootstrap data of a virtual machine.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.Service.Delete")
+	defer done()
+
+	if s.dryRun() && (s.Scope.BootstrapDataKeyVaultSpec() != nil || s.Scope.BootstrapDataBlobSpec() != nil) {
+		s.recordEvent(infrav1.DryRunReason, "Would delete bootstrap data (service: %s)", serviceName)
+		return azure.WithTransientError(errors.Errorf("dry run: would delete bootstrap data (service: %s)", serviceName), reconciler.DefaultReconcilerRequeue)
+	}
+
+	if vault := s.Scope.BootstrapDataKeyVaultSpec(); vault != nil {
+		vaultURL := "https://" + vault.VaultName + "." + s.keyVaultDNSSuffix
+		// The number of secrets the bootstrap data was split into isn't recorded, so secrets are deleted until one
+		// isn't found.
+		for i := 0; ; i++ {
+			err := s.DeleteSecret(ctx, vaultURL, fmt.Sprintf("%s-%d", vault.SecretName, i))
+			if azure.ResourceNotFound(err) {
+				return nil
+			} else if err != nil {
+				return errors.Wrap(err, "failed to delete bootstrap data secret")
+			}
+		}
+	}
+
+	spec := s.Scope.BootstrapDataBlobSpec()
+	if spec == nil {
+		return nil
+	}
+	return s.DeleteBlob(ctx, spec)
+}
+
+// dryRun returns true if the scope is reconciled in dry-run mode.
+func (s *Service) dryRun() bool {
+	d, ok := s.Scope.(async.DryRunner)
+	return ok && d.DryRun()
+}
+
+// recordEvent records a Normal Event on the scope's object, if the scope has one.
+func (s *Service) recordEvent(reason, message string, args ...interface{}) {
+	if getter, ok := s.Scope.(async.EventObjectGetter); ok {
+		record.Eventf(getter.EventObject(), reason, message, args...)
+	}
+}
+
+// IsManaged always returns true.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// fetchShim returns the custom data making a virtual machine fetch its bootstrap data from blobURL.
+func fetchShim(format string, data []byte, blobURL string) ([]byte, error) {
+	switch format {
+	case "", CloudConfigFormat:
+		// cloud-init processes the content of the URLs listed in #include user data.
+		return []byte(fmt.Sprintf("#include\n%s\n", blobURL)), nil
+	case IgnitionFormat:
+		// The configuration replacing the shim must have the same specification version.
+		var config struct {
+			Ignition struct {
+				Version string `json:"version"`
+			} `json:"ignition"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, errors.Wrap(err, "failed to parse ignition bootstrap data")
+		}
+		shim := map[string]interface{}{
+			"ignition": map[string]interface{}{
+				"version": config.Ignition.Version,
+				"config": map[string]interface{}{
+					"replace": map[string]interface{}{
+						"source": blobURL,
+					},
+				},
+			},
+		}
+		return json.Marshal(shim)
+	default:
+		return nil, azure.WithTerminalError(errors.Errorf("bootstrap data in %s format can't be fetched from a storage account", format))
+	}
+}
@@ -0,0 +1,461 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/rand"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	cgrecord "k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootstrapdata/mock_bootstrapdata"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	fakeBlobSpec = azure.BootstrapDataBlobSpec{
+		StorageAccountName: "mystorageaccount",
+		ResourceGroup:      "my-rg",
+		ContainerName:      "bootstrap-data",
+		BlobName:           "default/my-vm",
+		Access:             infrav1.BootstrapDataAccessSAS,
+	}
+	fakeBlobURL = "https://mystorageaccount.blob.core.windows.net/bootstrap-data/default/my-vm?sig=abc"
+
+	fakeKeyVaultSpec = azure.BootstrapDataKeyVaultSpec{
+		VaultName:  "my-vault",
+		SecretName: "default-my-vm",
+	}
+	fakeVaultURL = "https://my-vault.vault.azure.net"
+
+	smallCloudConfig = "#cloud-config\n"
+	largeCloudConfig = "#cloud-config\nwrite_files:\n" + strings.Repeat("- path: /etc/foo\n  content: bar\n", 3000)
+	largeIgnition    = `{"ignition":{"version":"3.3.0"},"storage":{"files":[{"path":"/etc/foo","contents":{"source":"data:,` + strings.Repeat("a", 70000) + `"}}]}}`
+)
+
+func TestReconcileBootstrapData(t *testing.T) {
+	managedIdentityBlobSpec := fakeBlobSpec
+	managedIdentityBlobSpec.Access = infrav1.BootstrapDataAccessManagedIdentity
+
+	testcases := []struct {
+		name                  string
+		spec                  *azure.BootstrapDataBlobSpec
+		providerID            string
+		data                  string
+		format                string
+		expect                func(m *mock_bootstrapdata.MockclientMockRecorder)
+		expectedBootstrapData string
+		expectedError         string
+		expectTerminal        bool
+	}{
+		{
+			name: "no bootstrap data storage",
+			spec: nil,
+			data: largeCloudConfig,
+			expect: func(_ *mock_bootstrapdata.MockclientMockRecorder) {
+			},
+		},
+		{
+			name:       "virtual machine already exists",
+			spec:       &fakeBlobSpec,
+			providerID: "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+			data:       largeCloudConfig,
+			expect: func(_ *mock_bootstrapdata.MockclientMockRecorder) {
+			},
+		},
+		{
+			name: "bootstrap data fits in custom data",
+			spec: &fakeBlobSpec,
+			data: smallCloudConfig,
+			expect: func(_ *mock_bootstrapdata.MockclientMockRecorder) {
+			},
+		},
+		{
+			name:   "large cloud-config bootstrap data is included from a blob",
+			spec:   &fakeBlobSpec,
+			data:   largeCloudConfig,
+			format: CloudConfigFormat,
+			expect: func(m *mock_bootstrapdata.MockclientMockRecorder) {
+				m.UploadBlob(gomockinternal.AContext(), &fakeBlobSpec, []byte(largeCloudConfig)).Return(fakeBlobURL, nil)
+			},
+			expectedBootstrapData: "#include\n" + fakeBlobURL + "\n",
+		},
+		{
+			name:   "large ignition bootstrap data is replaced by a blob",
+			spec:   &managedIdentityBlobSpec,
+			data:   largeIgnition,
+			format: IgnitionFormat,
+			expect: func(m *mock_bootstrapdata.MockclientMockRecorder) {
+				m.UploadBlob(gomockinternal.AContext(), &managedIdentityBlobSpec, []byte(largeIgnition)).Return("https://mystorageaccount.blob.core.windows.net/bootstrap-data/default/my-vm", nil)
+			},
+			expectedBootstrapData: `{"ignition":{"config":{"replace":{"source":"https://mystorageaccount.blob.core.windows.net/bootstrap-data/default/my-vm"}},"version":"3.3.0"}}`,
+		},
+		{
+			name:   "cloud-config bootstrap data can't be read with a managed identity",
+			spec:   &managedIdentityBlobSpec,
+			data:   largeCloudConfig,
+			format: CloudConfigFormat,
+			expect: func(_ *mock_bootstrapdata.MockclientMockRecorder) {
+			},
+			expectedError:  "bootstrap data in cloud-config format can't be read with a managed identity, only ignition bootstrap data can",
+			expectTerminal: true,
+		},
+		{
+			name:   "upload fails",
+			spec:   &fakeBlobSpec,
+			data:   largeCloudConfig,
+			format: CloudConfigFormat,
+			expect: func(m *mock_bootstrapdata.MockclientMockRecorder) {
+				m.UploadBlob(gomockinternal.AContext(), &fakeBlobSpec, []byte(largeCloudConfig)).Return("", errors.New("some API error"))
+			},
+			expectedError: "some API error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_bootstrapdata.NewMockBootstrapDataScope(mockCtrl)
+			clientMock := mock_bootstrapdata.NewMockclient(mockCtrl)
+
+			bootstrapData := base64.StdEncoding.EncodeToString([]byte(tc.data))
+			scopeMock.EXPECT().BootstrapDataKeyVaultSpec().Return(nil).AnyTimes()
+			scopeMock.EXPECT().BootstrapDataBlobSpec().Return(tc.spec).AnyTimes()
+			scopeMock.EXPECT().ProviderID().Return(tc.providerID).AnyTimes()
+			scopeMock.EXPECT().BootstrapData().Return(bootstrapData).AnyTimes()
+			scopeMock.EXPECT().BootstrapDataFormat().Return(tc.format).AnyTimes()
+			var newBootstrapData string
+			if tc.expectedBootstrapData != "" {
+				scopeMock.EXPECT().SetBootstrapData(gomock.Any()).Do(func(data string) {
+					newBootstrapData = data
+				})
+			}
+			tc.expect(clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				var recerr azure.ReconcileError
+				g.Expect(errors.As(err, &recerr)).To(Equal(tc.expectTerminal))
+				g.Expect(recerr.IsTerminal()).To(Equal(tc.expectTerminal))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expectedBootstrapData != "" {
+				decoded, err := base64.StdEncoding.DecodeString(newBootstrapData)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(string(decoded)).To(Equal(tc.expectedBootstrapData))
+			}
+		})
+	}
+}
+
+func TestReconcileBootstrapDataKeyVault(t *testing.T) {
+	// Random data doesn't compress, so that it is split into several secrets.
+	random := make([]byte, 20*1024)
+	rand.New(rand.NewSource(0)).Read(random)
+	largeRandomCloudConfig := "#cloud-config\n# " + base64.StdEncoding.EncodeToString(random)
+
+	testcases := []struct {
+		name                string
+		data                string
+		format              string
+		identityClientID    string
+		expectedSecrets     int
+		expectedShimContent []string
+		expectedError       string
+	}{
+		{
+			name:                "bootstrap data is stored in a secret",
+			data:                largeCloudConfig,
+			format:              CloudConfigFormat,
+			expectedSecrets:     1,
+			expectedShimContent: []string{"resource=https://vault.azure.net\"", "for secret in default-my-vm-0; do", fakeVaultURL + "/secrets/${secret}?api-version=7.4", "umask 077"},
+		},
+		{
+			name:                "large bootstrap data is split into several secrets",
+			data:                largeRandomCloudConfig,
+			format:              "",
+			expectedSecrets:     2,
+			expectedShimContent: []string{"for secret in default-my-vm-0 default-my-vm-1; do"},
+		},
+		{
+			name:                "secrets are read with a user-assigned identity",
+			data:                smallCloudConfig,
+			format:              CloudConfigFormat,
+			identityClientID:    "my-client-id",
+			expectedSecrets:     1,
+			expectedShimContent: []string{"resource=https://vault.azure.net&client_id=my-client-id\""},
+		},
+		{
+			name:          "ignition bootstrap data isn't supported",
+			data:          largeIgnition,
+			format:        IgnitionFormat,
+			expectedError: "bootstrap data in ignition format can't be read from a key vault, only cloud-config bootstrap data can",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_bootstrapdata.NewMockBootstrapDataScope(mockCtrl)
+			clientMock := mock_bootstrapdata.NewMockclient(mockCtrl)
+
+			vault := fakeKeyVaultSpec
+			vault.IdentityClientID = tc.identityClientID
+			scopeMock.EXPECT().ProviderID().Return("")
+			scopeMock.EXPECT().BootstrapDataKeyVaultSpec().Return(&vault)
+			scopeMock.EXPECT().BootstrapData().Return(base64.StdEncoding.EncodeToString([]byte(tc.data))).AnyTimes()
+			scopeMock.EXPECT().BootstrapDataFormat().Return(tc.format)
+			var secrets []string
+			clientMock.EXPECT().SetSecret(gomockinternal.AContext(), fakeVaultURL, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _, name, value string) error {
+				g.Expect(name).To(Equal(fmt.Sprintf("default-my-vm-%d", len(secrets))))
+				g.Expect(len(value)).To(BeNumerically("<=", maxSecretLength))
+				secrets = append(secrets, value)
+				return nil
+			}).Times(tc.expectedSecrets)
+			var shim string
+			if tc.expectedError == "" {
+				scopeMock.EXPECT().SetBootstrapData(gomock.Any()).Do(func(data string) {
+					decoded, err := base64.StdEncoding.DecodeString(data)
+					g.Expect(err).NotTo(HaveOccurred())
+					shim = string(decoded)
+				})
+			}
+
+			s := &Service{
+				Scope:             scopeMock,
+				client:            clientMock,
+				keyVaultDNSSuffix: "vault.azure.net",
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				var recerr azure.ReconcileError
+				g.Expect(errors.As(err, &recerr)).To(BeTrue())
+				g.Expect(recerr.IsTerminal()).To(BeTrue())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(shim).NotTo(ContainSubstring(tc.data))
+			for _, content := range tc.expectedShimContent {
+				g.Expect(shim).To(ContainSubstring(content))
+			}
+
+			// cloud-init runs the boothook, then processes the whole bootstrap data it wrote.
+			message, err := mail.ReadMessage(strings.NewReader(shim))
+			g.Expect(err).NotTo(HaveOccurred())
+			_, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+			g.Expect(err).NotTo(HaveOccurred())
+			parts := multipart.NewReader(message.Body, params["boundary"])
+			boothook, err := parts.NextPart()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(boothook.Header.Get("Content-Type")).To(HavePrefix("text/cloud-boothook"))
+			include, err := parts.NextPart()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(include.Header.Get("Content-Type")).To(HavePrefix("text/x-include-url"))
+			includeURL, err := io.ReadAll(include)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(strings.TrimSpace(string(includeURL))).To(Equal("file://" + keyVaultDataPath))
+
+			// The VM decodes the concatenated secrets the same way.
+			compressed, err := base64.RawURLEncoding.DecodeString(strings.Join(secrets, ""))
+			g.Expect(err).NotTo(HaveOccurred())
+			reader, err := gzip.NewReader(bytes.NewReader(compressed))
+			g.Expect(err).NotTo(HaveOccurred())
+			data, err := io.ReadAll(reader)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(data)).To(Equal(tc.data))
+		})
+	}
+}
+
+func TestDeleteBootstrapData(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *azure.BootstrapDataBlobSpec
+		vault         *azure.BootstrapDataKeyVaultSpec
+		expect        func(m *mock_bootstrapdata.MockclientMockRecorder)
+		expectedError string
+	}{
+		{
+			name: "no bootstrap data storage",
+			spec: nil,
+			expect: func(_ *mock_bootstrapdata.MockclientMockRecorder) {
+			},
+		},
+		{
+			name: "blob is deleted",
+			spec: &fakeBlobSpec,
+			expect: func(m *mock_bootstrapdata.MockclientMockRecorder) {
+				m.DeleteBlob(gomockinternal.AContext(), &fakeBlobSpec).Return(nil)
+			},
+		},
+		{
+			name:  "key vault secrets are deleted",
+			vault: &fakeKeyVaultSpec,
+			expect: func(m *mock_bootstrapdata.MockclientMockRecorder) {
+				gomock.InOrder(
+					m.DeleteSecret(gomockinternal.AContext(), fakeVaultURL, "default-my-vm-0").Return(nil),
+					m.DeleteSecret(gomockinternal.AContext(), fakeVaultURL, "default-my-vm-1").Return(nil),
+					m.DeleteSecret(gomockinternal.AContext(), fakeVaultURL, "default-my-vm-2").Return(&azcore.ResponseError{StatusCode: http.StatusNotFound}),
+				)
+			},
+		},
+		{
+			name:  "deleting a key vault secret fails",
+			vault: &fakeKeyVaultSpec,
+			expect: func(m *mock_bootstrapdata.MockclientMockRecorder) {
+				m.DeleteSecret(gomockinternal.AContext(), fakeVaultURL, "default-my-vm-0").Return(errors.New("some API error"))
+			},
+			expectedError: "failed to delete bootstrap data secret: some API error",
+		},
+		{
+			name: "delete fails",
+			spec: &fakeBlobSpec,
+			expect: func(m *mock_bootstrapdata.MockclientMockRecorder) {
+				m.DeleteBlob(gomockinternal.AContext(), &fakeBlobSpec).Return(errors.New("some API error"))
+			},
+			expectedError: "some API error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_bootstrapdata.NewMockBootstrapDataScope(mockCtrl)
+			clientMock := mock_bootstrapdata.NewMockclient(mockCtrl)
+
+			scopeMock.EXPECT().BootstrapDataKeyVaultSpec().Return(tc.vault)
+			scopeMock.EXPECT().BootstrapDataBlobSpec().Return(tc.spec).AnyTimes()
+			tc.expect(clientMock.EXPECT())
+
+			s := &Service{
+				Scope:             scopeMock,
+				client:            clientMock,
+				keyVaultDNSSuffix: "vault.azure.net",
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestBootstrapDataDryRun(t *testing.T) {
+	g := NewWithT(t)
+
+	recorder := cgrecord.NewFakeRecorder(10)
+	record.InitFromRecorder(recorder)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_bootstrapdata.NewMockBootstrapDataScope(mockCtrl)
+	clientMock := mock_bootstrapdata.NewMockclient(mockCtrl)
+
+	scopeMock.EXPECT().ProviderID().Return("")
+	scopeMock.EXPECT().BootstrapDataKeyVaultSpec().Return(&fakeKeyVaultSpec).AnyTimes()
+	scopeMock.EXPECT().BootstrapDataFormat().Return(CloudConfigFormat)
+
+	s := &Service{
+		Scope:             dryRunScope{MockBootstrapDataScope: scopeMock, object: &infrav1.AzureMachine{}},
+		client:            clientMock,
+		keyVaultDNSSuffix: "vault.azure.net",
+	}
+
+	g.Expect(s.Reconcile(context.TODO())).To(Succeed())
+	g.Expect(recorder.Events).To(Receive(Equal("Normal DryRun Would store bootstrap data in key vault my-vault (service: bootstrapdata)")))
+
+	g.Expect(s.Delete(context.TODO())).To(MatchError(ContainSubstring("dry run: would delete bootstrap data (service: bootstrapdata)")))
+	g.Expect(recorder.Events).To(Receive(Equal("Normal DryRun Would delete bootstrap data (service: bootstrapdata)")))
+}
+
+// dryRunScope is a BootstrapDataScope reconciled in dry-run mode whose object receives Events.
+type dryRunScope struct {
+	*mock_bootstrapdata.MockBootstrapDataScope
+	object ctrlclient.Object
+}
+
+func (s dryRunScope) EventObject() ctrlclient.Object {
+	return s.object
+}
+
+func (s dryRunScope) DryRun() bool {
+	return true
+}
+
+func TestBlobSAS(t *testing.T) {
+	g := NewWithT(t)
+
+	key := base64.StdEncoding.EncodeToString([]byte("my-key"))
+	expiry := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sas, err := blobSAS("mystorageaccount", key, "bootstrap-data", "default/my-vm", "r", expiry)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	query, err := url.ParseQuery(sas)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(query.Get("sv")).To(Equal(storageAPIVersion))
+	g.Expect(query.Get("sr")).To(Equal("b"))
+	g.Expect(query.Get("sp")).To(Equal("r"))
+	g.Expect(query.Get("se")).To(Equal("2024-01-02T03:04:05Z"))
+	g.Expect(query.Get("spr")).To(Equal("https"))
+
+	mac := hmac.New(sha256.New, []byte("my-key"))
+	mac.Write([]byte("r\n\n2024-01-02T03:04:05Z\n/blob/mystorageaccount/bootstrap-data/default/my-vm\n\n\nhttps\n" + storageAPIVersion + "\nb\n\n\n\n\n\n\n"))
+	g.Expect(query.Get("sig")).To(Equal(base64.StdEncoding.EncodeToString(mac.Sum(nil))))
+
+	_, err = blobSAS("mystorageaccount", "not base64!", "bootstrap-data", "default/my-vm", "r", expiry)
+	g.Expect(err).To(HaveOccurred())
+}
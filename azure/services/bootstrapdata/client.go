@@ -0,0 +1,298 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api-provider-azure/version"
+)
+
+const (
+	// storageAPIVersion is the version of the Blob service REST API used to upload and delete blobs, which are sent
+	// as plain requests as the Azure SDK for Go used by CAPZ has no data plane client for Azure Storage.
+	storageAPIVersion = "2021-08-06"
+
+	// storageScope is the scope of the tokens used to access blobs with the identity of the controller.
+	storageScope = "https://storage.azure.com/.default"
+
+	// writeSASExpiry is how long the shared access signature used by the controller to upload or delete a blob is
+	// valid.
+	writeSASExpiry = 15 * time.Minute
+
+	// readSASExpiry is how long the shared access signature used by a virtual machine to read its bootstrap data is
+	// valid. The bootstrap data is only read at the first boot of the virtual machine.
+	readSASExpiry = 24 * time.Hour
+
+	// keyVaultAPIVersion is the version of the Key Vault REST API used to set and delete secrets.
+	keyVaultAPIVersion = "7.4"
+)
+
+// client wraps go-sdk.
+type client interface {
+	UploadBlob(ctx context.Context, spec *azure.BootstrapDataBlobSpec, data []byte) (blobURL string, err error)
+	DeleteBlob(ctx context.Context, spec *azure.BootstrapDataBlobSpec) error
+	SetSecret(ctx context.Context, vaultURL, name, value string) error
+	DeleteSecret(ctx context.Context, vaultURL, name string) error
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	accounts   *armstorage.AccountsClient
+	containers *armstorage.BlobContainersClient
+	// sasPipeline sends requests authorized by the shared access signature in their URL.
+	sasPipeline runtime.Pipeline
+	// tokenPipeline sends requests authorized by a token of the identity of the controller.
+	tokenPipeline runtime.Pipeline
+	// vaultPipeline sends requests to key vaults authorized by a token of the identity of the controller.
+	vaultPipeline runtime.Pipeline
+}
+
+// newClient creates a new bootstrap data client from an authorizer.
+func newClient(auth azure.Authorizer, keyVaultDNSSuffix string) (*azureClient, error) {
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create bootstrapdata client options")
+	}
+	factory, err := armstorage.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armstorage client factory")
+	}
+	tokenPolicy := runtime.NewBearerTokenPolicy(auth.Token(), []string{storageScope}, nil)
+	vaultPolicy := runtime.NewBearerTokenPolicy(auth.Token(), []string{"https://" + keyVaultDNSSuffix + "/.default"}, nil)
+	return &azureClient{
+		accounts:      factory.NewAccountsClient(),
+		containers:    factory.NewBlobContainersClient(),
+		sasPipeline:   runtime.NewPipeline("bootstrapdata", version.Get().String(), runtime.PipelineOptions{}, &opts.ClientOptions),
+		tokenPipeline: runtime.NewPipeline("bootstrapdata", version.Get().String(), runtime.PipelineOptions{PerRetry: []policy.Policy{tokenPolicy}}, &opts.ClientOptions),
+		vaultPipeline: runtime.NewPipeline("bootstrapdata", version.Get().String(), runtime.PipelineOptions{PerRetry: []policy.Policy{vaultPolicy}}, &opts.ClientOptions),
+	}, nil
+}
+
+// UploadBlob uploads data to the blob of the spec, creating its container if needed, and returns the URL the virtual
+// machine reads it from.
+func (ac *azureClient) UploadBlob(ctx context.Context, spec *azure.BootstrapDataBlobSpec, data []byte) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.AzureClient.UploadBlob")
+	defer done()
+
+	blobURL, err := ac.blobURL(ctx, spec)
+	if err != nil {
+		return "", err
+	}
+	if err := ac.ensureContainer(ctx, spec); err != nil {
+		return "", err
+	}
+
+	if spec.Access == infrav1.BootstrapDataAccessManagedIdentity {
+		if err := sendBlobRequest(ctx, ac.tokenPipeline, http.MethodPut, blobURL, data); err != nil {
+			return "", errors.Wrap(err, "failed to upload bootstrap data")
+		}
+		return blobURL, nil
+	}
+
+	key, err := ac.accountKey(ctx, spec)
+	if err != nil {
+		return "", err
+	}
+	writeSAS, err := blobSAS(spec.StorageAccountName, key, spec.ContainerName, spec.BlobName, "cw", time.Now().Add(writeSASExpiry))
+	if err != nil {
+		return "", err
+	}
+	if err := sendBlobRequest(ctx, ac.sasPipeline, http.MethodPut, blobURL+"?"+writeSAS, data); err != nil {
+		return "", errors.Wrap(err, "failed to upload bootstrap data")
+	}
+	readSAS, err := blobSAS(spec.StorageAccountName, key, spec.ContainerName, spec.BlobName, "r", time.Now().Add(readSASExpiry))
+	if err != nil {
+		return "", err
+	}
+	return blobURL + "?" + readSAS, nil
+}
+
+// DeleteBlob deletes the blob of the spec. It is not an error if the blob or its storage account doesn't exist.
+func (ac *azureClient) DeleteBlob(ctx context.Context, spec *azure.BootstrapDataBlobSpec) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.AzureClient.DeleteBlob")
+	defer done()
+
+	blobURL, err := ac.blobURL(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if spec.Access == infrav1.BootstrapDataAccessManagedIdentity {
+		err = sendBlobRequest(ctx, ac.tokenPipeline, http.MethodDelete, blobURL, nil)
+	} else {
+		var key, deleteSAS string
+		key, err = ac.accountKey(ctx, spec)
+		if err != nil {
+			return err
+		}
+		deleteSAS, err = blobSAS(spec.StorageAccountName, key, spec.ContainerName, spec.BlobName, "d", time.Now().Add(writeSASExpiry))
+		if err != nil {
+			return err
+		}
+		err = sendBlobRequest(ctx, ac.sasPipeline, http.MethodDelete, blobURL+"?"+deleteSAS, nil)
+	}
+	if err != nil && !azure.ResourceNotFound(err) {
+		return errors.Wrap(err, "failed to delete bootstrap data")
+	}
+	return nil
+}
+
+// SetSecret sets the value of a secret of a key vault.
+func (ac *azureClient) SetSecret(ctx context.Context, vaultURL, name, value string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.AzureClient.SetSecret")
+	defer done()
+
+	req, err := runtime.NewRequest(ctx, http.MethodPut, secretURL(vaultURL, name))
+	if err != nil {
+		return err
+	}
+	if err := runtime.MarshalAsJSON(req, map[string]string{"value": value}); err != nil {
+		return err
+	}
+	resp, err := ac.vaultPipeline.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set secret %s", name)
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return errors.Wrapf(runtime.NewResponseError(resp), "failed to set secret %s", name)
+	}
+	return nil
+}
+
+// DeleteSecret deletes a secret of a key vault.
+func (ac *azureClient) DeleteSecret(ctx context.Context, vaultURL, name string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.AzureClient.DeleteSecret")
+	defer done()
+
+	req, err := runtime.NewRequest(ctx, http.MethodDelete, secretURL(vaultURL, name))
+	if err != nil {
+		return err
+	}
+	resp, err := ac.vaultPipeline.Do(req)
+	if err != nil {
+		return err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return runtime.NewResponseError(resp)
+	}
+	return nil
+}
+
+// secretURL returns the URL of a secret of a key vault.
+func secretURL(vaultURL, name string) string {
+	return fmt.Sprintf("%s/secrets/%s?api-version=%s", vaultURL, name, keyVaultAPIVersion)
+}
+
+// keyVaultDNSSuffix returns the DNS suffix of the key vaults of an Azure cloud.
+func keyVaultDNSSuffix(cloudName string) (string, error) {
+	switch cloudName {
+	case azure.ChinaCloudName:
+		return "vault.azure.cn", nil
+	case azure.USGovernmentCloudName:
+		return "vault.usgovcloudapi.net", nil
+	case azure.StackCloudName:
+		env, err := azureautorest.EnvironmentFromName(azure.StackCloudName)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to load Azure Stack Hub environment from %s", azureautorest.EnvironmentFilepathName)
+		}
+		return env.KeyVaultDNSSuffix, nil
+	default:
+		return "vault.azure.net", nil
+	}
+}
+
+// blobURL returns the URL of the blob of the spec, without authorization.
+func (ac *azureClient) blobURL(ctx context.Context, spec *azure.BootstrapDataBlobSpec) (string, error) {
+	resp, err := ac.accounts.GetProperties(ctx, spec.ResourceGroup, spec.StorageAccountName, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get storage account %s", spec.StorageAccountName)
+	}
+	if resp.Properties == nil || resp.Properties.PrimaryEndpoints == nil || resp.Properties.PrimaryEndpoints.Blob == nil {
+		return "", errors.Errorf("storage account %s has no blob endpoint", spec.StorageAccountName)
+	}
+	endpoint := strings.TrimSuffix(*resp.Properties.PrimaryEndpoints.Blob, "/")
+	return endpoint + "/" + spec.ContainerName + "/" + spec.BlobName, nil
+}
+
+// ensureContainer creates the container of the spec if it doesn't exist.
+func (ac *azureClient) ensureContainer(ctx context.Context, spec *azure.BootstrapDataBlobSpec) error {
+	_, err := ac.containers.Get(ctx, spec.ResourceGroup, spec.StorageAccountName, spec.ContainerName, nil)
+	if err == nil {
+		return nil
+	} else if !azure.ResourceNotFound(err) {
+		return errors.Wrapf(err, "failed to get blob container %s", spec.ContainerName)
+	}
+	if _, err := ac.containers.Create(ctx, spec.ResourceGroup, spec.StorageAccountName, spec.ContainerName, armstorage.BlobContainer{}, nil); err != nil {
+		return errors.Wrapf(err, "failed to create blob container %s", spec.ContainerName)
+	}
+	return nil
+}
+
+// accountKey returns a key of the storage account of the spec.
+func (ac *azureClient) accountKey(ctx context.Context, spec *azure.BootstrapDataBlobSpec) (string, error) {
+	resp, err := ac.accounts.ListKeys(ctx, spec.ResourceGroup, spec.StorageAccountName, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list keys of storage account %s", spec.StorageAccountName)
+	}
+	for _, key := range resp.Keys {
+		if key != nil && ptr.Deref(key.Value, "") != "" {
+			return *key.Value, nil
+		}
+	}
+	return "", errors.Errorf("storage account %s has no key", spec.StorageAccountName)
+}
+
+// sendBlobRequest sends a request to the Blob service, uploading body as a block blob if it isn't nil.
+func sendBlobRequest(ctx context.Context, pipeline runtime.Pipeline, method, blobURL string, body []byte) error {
+	req, err := runtime.NewRequest(ctx, method, blobURL)
+	if err != nil {
+		return err
+	}
+	req.Raw().Header.Set("x-ms-version", storageAPIVersion)
+	if body != nil {
+		req.Raw().Header.Set("x-ms-blob-type", "BlockBlob")
+		if err := req.SetBody(streaming.NopCloser(bytes.NewReader(body)), "application/octet-stream"); err != nil {
+			return err
+		}
+	}
+	resp, err := pipeline.Do(req)
+	if err != nil {
+		return err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated, http.StatusAccepted) {
+		return runtime.NewResponseError(resp)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// sasTimeFormat is the format of the times of a shared access signature.
+const sasTimeFormat = "2006-01-02T15:04:05Z"
+
+// blobSAS returns the query string of a service shared access signature (SAS) granting the permissions, e.g. "r"
+// or "cw", on a blob until expiry. The signature is computed with a key of the storage account.
+func blobSAS(accountName, accountKey, containerName, blobName, permissions string, expiry time.Time) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode storage account key")
+	}
+
+	signedExpiry := expiry.UTC().Format(sasTimeFormat)
+	stringToSign := strings.Join([]string{
+		permissions,
+		"", // signed start
+		signedExpiry,
+		fmt.Sprintf("/blob/%s/%s/%s", accountName, containerName, blobName),
+		"", // signed identifier
+		"", // signed IP
+		"https",
+		storageAPIVersion,
+		"b", // signed resource
+		"",  // signed snapshot time
+		"",  // signed encryption scope
+		"",  // Cache-Control
+		"",  // Content-Disposition
+		"",  // Content-Encoding
+		"",  // Content-Language
+		"",  // Content-Type
+	}, "\n")
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+
+	return url.Values{
+		"sv":  {storageAPIVersion},
+		"sr":  {"b"},
+		"sp":  {permissions},
+		"se":  {signedExpiry},
+		"spr": {"https"},
+		"sig": {base64.StdEncoding.EncodeToString(mac.Sum(nil))},
+	}.Encode(), nil
+}
@@ -47,6 +47,18 @@ func newClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*azureClien
 	return &azureClient{factory.NewDisksClient(), apiCallTimeout}, nil
 }
 
+// Get gets a disk.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "disks.azureClient.Get")
+	defer done()
+
+	resp, err := ac.disks.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Disk, nil
+}
+
 // DeleteAsync deletes a disk asynchronously. DeleteAsync sends a DELETE
 // request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
 // progress of the operation.
@@ -87,6 +87,34 @@ func (mr *MockDiskScopeMockRecorder) AvailabilitySetEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetEnabled", reflect.TypeOf((*MockDiskScope)(nil).AvailabilitySetEnabled))
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockDiskScope) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockDiskScopeMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockDiskScope)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockDiskScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockDiskScopeMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockDiskScope)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // BaseURI mocks base method.
 func (m *MockDiskScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -143,6 +171,20 @@ func (mr *MockDiskScopeMockRecorder) CloudEnvironment() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockDiskScope)(nil).CloudEnvironment))
 }
 
+// CloudProviderConfigDisabled mocks base method.
+func (m *MockDiskScope) CloudProviderConfigDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudProviderConfigDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CloudProviderConfigDisabled indicates an expected call of CloudProviderConfigDisabled.
+func (mr *MockDiskScopeMockRecorder) CloudProviderConfigDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudProviderConfigDisabled", reflect.TypeOf((*MockDiskScope)(nil).CloudProviderConfigDisabled))
+}
+
 // CloudProviderConfigOverrides mocks base method.
 func (m *MockDiskScope) CloudProviderConfigOverrides() *v1beta1.CloudProviderConfigOverrides {
 	m.ctrl.T.Helper()
@@ -365,6 +407,20 @@ func (mr *MockDiskScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockDiskScope)(nil).ResourceGroup))
 }
 
+// ResourceNaming mocks base method.
+func (m *MockDiskScope) ResourceNaming() *v1beta1.ResourceNamingSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceNaming")
+	ret0, _ := ret[0].(*v1beta1.ResourceNamingSpec)
+	return ret0
+}
+
+// ResourceNaming indicates an expected call of ResourceNaming.
+func (mr *MockDiskScopeMockRecorder) ResourceNaming() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceNaming", reflect.TypeOf((*MockDiskScope)(nil).ResourceNaming))
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockDiskScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsrecords
+
+import (
+	asonetworkv1 "github.com/Azure/azure-service-operator/v2/api/network/v1api20180501"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/aso"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "dnsrecords"
+
+// DNSRecordScope defines the scope interface for a DNS record.
+type DNSRecordScope interface {
+	aso.Scope
+	DNSRecordSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1.DnsZonesARecord]
+}
+
+// New creates a new service.
+func New(scope DNSRecordScope) *aso.Service[*asonetworkv1.DnsZonesARecord, DNSRecordScope] {
+	svc := aso.NewService[*asonetworkv1.DnsZonesARecord, DNSRecordScope](ServiceName, scope)
+	svc.ConditionType = infrav1.DNSRecordsReadyCondition
+	svc.Specs = scope.DNSRecordSpecs()
+	return svc
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsrecords
+
+import (
+	"context"
+
+	asonetworkv1 "github.com/Azure/azure-service-operator/v2/api/network/v1api20180501"
+	"github.com/Azure/azure-service-operator/v2/pkg/genruntime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// DNSRecordSpec defines the specification for an A record in an Azure DNS zone.
+// The record either aliases a public IP resource or points at a static IPv4 address.
+type DNSRecordSpec struct {
+	Name             string
+	DNSZoneID        string
+	TTL              int32
+	TargetResourceID string
+	IPv4Address      string
+	ClusterName      string
+	AdditionalTags   infrav1.Tags
+}
+
+// ResourceRef implements azure.ASOResourceSpecGetter.
+func (s *DNSRecordSpec) ResourceRef() *asonetworkv1.DnsZonesARecord {
+	return &asonetworkv1.DnsZonesARecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: azure.GetNormalizedKubernetesName(s.ClusterName + "-" + s.Name),
+		},
+	}
+}
+
+// Parameters implements azure.ASOResourceSpecGetter.
+func (s *DNSRecordSpec) Parameters(ctx context.Context, existingRecord *asonetworkv1.DnsZonesARecord) (*asonetworkv1.DnsZonesARecord, error) {
+	record := &asonetworkv1.DnsZonesARecord{}
+	if existingRecord != nil {
+		record = existingRecord
+	}
+
+	record.Spec.AzureName = s.Name
+	record.Spec.Owner = &genruntime.KnownResourceReference{
+		ARMID: s.DNSZoneID,
+	}
+	record.Spec.TTL = ptr.To(int(s.TTL))
+
+	if s.TargetResourceID != "" {
+		record.Spec.TargetResource = &asonetworkv1.SubResource{
+			Reference: &genruntime.ResourceReference{
+				ARMID: s.TargetResourceID,
+			},
+		}
+		record.Spec.ARecords = nil
+	} else {
+		record.Spec.ARecords = []asonetworkv1.ARecord{
+			{Ipv4Address: ptr.To(s.IPv4Address)},
+		}
+		record.Spec.TargetResource = nil
+	}
+
+	record.Spec.Metadata = infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        ptr.To(s.Name),
+		Additional:  s.AdditionalTags,
+	})
+
+	return record, nil
+}
+
+// WasManaged implements azure.ASOResourceSpecGetter.
+// It always returns true since the record is always created by CAPZ.
+func (s *DNSRecordSpec) WasManaged(record *asonetworkv1.DnsZonesARecord) bool {
+	return true
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsrecords
+
+import (
+	"context"
+	"testing"
+
+	asonetworkv1 "github.com/Azure/azure-service-operator/v2/api/network/v1api20180501"
+	"github.com/Azure/azure-service-operator/v2/pkg/genruntime"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var (
+	fakeAliasRecordSpec = &DNSRecordSpec{
+		Name:             "api.my-cluster",
+		DNSZoneID:        "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+		TTL:              300,
+		TargetResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip",
+		ClusterName:      "my-cluster",
+		AdditionalTags:   infrav1.Tags{"foo": "bar"},
+	}
+	fakeStaticRecordSpec = &DNSRecordSpec{
+		Name:        "api.my-cluster",
+		DNSZoneID:   "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+		TTL:         60,
+		IPv4Address: "10.0.0.100",
+		ClusterName: "my-cluster",
+	}
+	existingAliasRecord = &asonetworkv1.DnsZonesARecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-api-my-cluster",
+			Namespace: "dummy-ns",
+		},
+		Spec: asonetworkv1.DnsZones_A_Spec{
+			AzureName: "api.my-cluster",
+			TTL:       ptr.To(300),
+			TargetResource: &asonetworkv1.SubResource{
+				Reference: &genruntime.ResourceReference{
+					ARMID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip",
+				},
+			},
+		},
+	}
+)
+
+func TestResourceRef(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(fakeAliasRecordSpec.ResourceRef().Name).To(Equal("my-cluster-api-my-cluster"))
+}
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name         string
+		spec         *DNSRecordSpec
+		existingSpec *asonetworkv1.DnsZonesARecord
+		expect       func(g *WithT, parameters *asonetworkv1.DnsZonesARecord)
+	}{
+		{
+			name:         "create an alias record to the API server public IP",
+			spec:         fakeAliasRecordSpec,
+			existingSpec: nil,
+			expect: func(g *WithT, parameters *asonetworkv1.DnsZonesARecord) {
+				g.Expect(parameters).NotTo(BeNil())
+				g.Expect(parameters.Spec.AzureName).To(Equal("api.my-cluster"))
+				g.Expect(parameters.Spec.Owner).NotTo(BeNil())
+				g.Expect(parameters.Spec.Owner.ARMID).To(Equal("/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com"))
+				g.Expect(parameters.Spec.TTL).To(Equal(ptr.To(300)))
+				g.Expect(parameters.Spec.TargetResource).NotTo(BeNil())
+				g.Expect(parameters.Spec.TargetResource.Reference.ARMID).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip"))
+				g.Expect(parameters.Spec.ARecords).To(BeNil())
+				g.Expect(parameters.Spec.Metadata).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", "owned"))
+				g.Expect(parameters.Spec.Metadata).To(HaveKeyWithValue("foo", "bar"))
+			},
+		},
+		{
+			name:         "create a record to the API server private IP",
+			spec:         fakeStaticRecordSpec,
+			existingSpec: nil,
+			expect: func(g *WithT, parameters *asonetworkv1.DnsZonesARecord) {
+				g.Expect(parameters).NotTo(BeNil())
+				g.Expect(parameters.Spec.TTL).To(Equal(ptr.To(60)))
+				g.Expect(parameters.Spec.TargetResource).To(BeNil())
+				g.Expect(parameters.Spec.ARecords).To(Equal([]asonetworkv1.ARecord{{Ipv4Address: ptr.To("10.0.0.100")}}))
+			},
+		},
+		{
+			name:         "switch an existing alias record to a private IP",
+			spec:         fakeStaticRecordSpec,
+			existingSpec: existingAliasRecord,
+			expect: func(g *WithT, parameters *asonetworkv1.DnsZonesARecord) {
+				g.Expect(parameters.Namespace).To(Equal("dummy-ns"))
+				g.Expect(parameters.Spec.TTL).To(Equal(ptr.To(60)))
+				g.Expect(parameters.Spec.TargetResource).To(BeNil())
+				g.Expect(parameters.Spec.ARecords).To(Equal([]asonetworkv1.ARecord{{Ipv4Address: ptr.To("10.0.0.100")}}))
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, _ := tc.spec.Parameters(context.TODO(), tc.existingSpec.DeepCopy())
+			tc.expect(g, result)
+		})
+	}
+}
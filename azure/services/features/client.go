@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// apiVersion is the version of the Microsoft.Features API used to get the registration state of features, which are
+// read as generic resources as the Azure SDK for Go has no client for them.
+const apiVersion = "2021-07-01"
+
+// client wraps go-sdk.
+type client interface {
+	GetState(ctx context.Context, namespace, name string) (string, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	resources      *armresources.Client
+	subscriptionID string
+}
+
+// newClient creates a new features client from an authorizer.
+func newClient(auth azure.Authorizer) (*azureClient, error) {
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create features client options")
+	}
+	factory, err := armresources.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armresources client factory")
+	}
+	return &azureClient{factory.NewClient(), auth.SubscriptionID()}, nil
+}
+
+// GetState returns the registration state of a feature of a resource provider namespace on the subscription,
+// e.g. "Registered" or "NotRegistered".
+func (ac *azureClient) GetState(ctx context.Context, namespace, name string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "features.AzureClient.GetState")
+	defer done()
+
+	id := fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Features/providers/%s/features/%s", ac.subscriptionID, namespace, name)
+	resp, err := ac.resources.GetByID(ctx, id, apiVersion, nil)
+	if err != nil {
+		return "", err
+	}
+	properties, ok := resp.Properties.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	state, _ := properties["state"].(string)
+	return state, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	serviceName = "features"
+
+	// EncryptionAtHost is the feature which must be registered on the subscription to create virtual machines
+	// with encryption at host.
+	EncryptionAtHost = "Microsoft.Compute/EncryptionAtHost"
+
+	// registeredState is the state of a feature registered on the subscription.
+	registeredState = "Registered"
+
+	// registrationRequeueInterval is how long to wait before checking again features that are not registered,
+	// as registering a feature can take several minutes.
+	registrationRequeueInterval = time.Minute
+)
+
+// FeaturesScope defines the scope interface for a features service.
+type FeaturesScope interface {
+	azure.Authorizer
+	RequiredFeatures() []string
+	FeaturesResource() conditions.Setter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope FeaturesScope
+	client
+}
+
+// New creates a new service.
+func New(scope FeaturesScope) (*Service, error) {
+	cli, err := newClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope:  scope,
+		client: cli,
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile verifies the features required by the resource spec, given as "<namespace>/<name>", are registered on
+// the subscription and reports the features that are not in the FeaturesRegistered condition, so that resources
+// aren't created only to fail several minutes later.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "features.Service.Reconcile")
+	defer done()
+
+	features := s.Scope.RequiredFeatures()
+	if len(features) == 0 {
+		return nil
+	}
+
+	resource := s.Scope.FeaturesResource()
+	if conditions.IsTrue(resource, infrav1.FeaturesRegisteredCondition) {
+		return nil
+	}
+
+	var unregistered []string
+	for _, feature := range features {
+		namespace, name, ok := strings.Cut(feature, "/")
+		if !ok {
+			return errors.Errorf("invalid feature %s, expected <namespace>/<name>", feature)
+		}
+		state, err := s.GetState(ctx, namespace, name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get registration state of feature %s", feature)
+		}
+		if !strings.EqualFold(state, registeredState) {
+			unregistered = append(unregistered, feature)
+		}
+	}
+
+	if len(unregistered) > 0 {
+		msg := fmt.Sprintf("features are not registered on subscription %s: %s", s.Scope.SubscriptionID(), strings.Join(unregistered, ", "))
+		conditions.MarkFalse(resource, infrav1.FeaturesRegisteredCondition, infrav1.FeatureNotRegisteredReason, clusterv1.ConditionSeverityError, "%s", msg)
+		return azure.WithTransientError(errors.New(msg), registrationRequeueInterval)
+	}
+
+	conditions.MarkTrue(resource, infrav1.FeaturesRegisteredCondition)
+	return nil
+}
+
+// Delete is a no-op.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "features.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// IsManaged always returns true.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/features/mock_features"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileFeatures(t *testing.T) {
+	testcases := []struct {
+		name              string
+		features          []string
+		alreadyRegistered bool
+		expect            func(s *mock_features.MockFeaturesScopeMockRecorder, m *mock_features.MockclientMockRecorder)
+		expectedCondition *corev1.ConditionStatus
+		expectedError     string
+		expectTransient   bool
+	}{
+		{
+			name:     "no required features",
+			features: nil,
+			expect: func(_ *mock_features.MockFeaturesScopeMockRecorder, _ *mock_features.MockclientMockRecorder) {
+			},
+		},
+		{
+			name:     "required features are registered",
+			features: []string{EncryptionAtHost},
+			expect: func(s *mock_features.MockFeaturesScopeMockRecorder, m *mock_features.MockclientMockRecorder) {
+				m.GetState(gomockinternal.AContext(), "Microsoft.Compute", "EncryptionAtHost").Return("Registered", nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name:     "required feature is not registered",
+			features: []string{EncryptionAtHost},
+			expect: func(s *mock_features.MockFeaturesScopeMockRecorder, m *mock_features.MockclientMockRecorder) {
+				m.GetState(gomockinternal.AContext(), "Microsoft.Compute", "EncryptionAtHost").Return("NotRegistered", nil)
+				s.SubscriptionID().Return("123")
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedError:     "features are not registered on subscription 123: Microsoft.Compute/EncryptionAtHost. Object will be requeued after 1m0s",
+			expectTransient:   true,
+		},
+		{
+			name:              "condition is already true",
+			features:          []string{EncryptionAtHost},
+			alreadyRegistered: true,
+			expect: func(_ *mock_features.MockFeaturesScopeMockRecorder, _ *mock_features.MockclientMockRecorder) {
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name:     "invalid feature",
+			features: []string{"EncryptionAtHost"},
+			expect: func(_ *mock_features.MockFeaturesScopeMockRecorder, _ *mock_features.MockclientMockRecorder) {
+			},
+			expectedError: "invalid feature EncryptionAtHost, expected <namespace>/<name>",
+		},
+		{
+			name:     "API error",
+			features: []string{EncryptionAtHost},
+			expect: func(_ *mock_features.MockFeaturesScopeMockRecorder, m *mock_features.MockclientMockRecorder) {
+				m.GetState(gomockinternal.AContext(), "Microsoft.Compute", "EncryptionAtHost").Return("", errors.New("some API error"))
+			},
+			expectedError: "failed to get registration state of feature Microsoft.Compute/EncryptionAtHost: some API error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_features.NewMockFeaturesScope(mockCtrl)
+			clientMock := mock_features.NewMockclient(mockCtrl)
+
+			machine := &infrav1.AzureMachine{}
+			if tc.alreadyRegistered {
+				conditions.MarkTrue(machine, infrav1.FeaturesRegisteredCondition)
+			}
+			scopeMock.EXPECT().RequiredFeatures().Return(tc.features)
+			scopeMock.EXPECT().FeaturesResource().Return(machine).AnyTimes()
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+				var reconcileErr azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileErr) && reconcileErr.IsTransient()).To(Equal(tc.expectTransient))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			cond := conditions.Get(machine, infrav1.FeaturesRegisteredCondition)
+			if tc.expectedCondition == nil {
+				g.Expect(cond).To(BeNil())
+			} else {
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(*tc.expectedCondition))
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../features.go
+//
+// Generated by this command:
+//
+//	mockgen -destination features_mock.go -package mock_features -source ../features.go FeaturesScope
+//
+
+// Package mock_features is a generated GoMock package.
+package mock_features
+
+import (
+	reflect "reflect"
+
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	gomock "go.uber.org/mock/gomock"
+	conditions "sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// MockFeaturesScope is a mock of FeaturesScope interface.
+type MockFeaturesScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeaturesScopeMockRecorder
+}
+
+// MockFeaturesScopeMockRecorder is the mock recorder for MockFeaturesScope.
+type MockFeaturesScopeMockRecorder struct {
+	mock *MockFeaturesScope
+}
+
+// NewMockFeaturesScope creates a new mock instance.
+func NewMockFeaturesScope(ctrl *gomock.Controller) *MockFeaturesScope {
+	mock := &MockFeaturesScope{ctrl: ctrl}
+	mock.recorder = &MockFeaturesScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeaturesScope) EXPECT() *MockFeaturesScopeMockRecorder {
+	return m.recorder
+}
+
+// BaseURI mocks base method.
+func (m *MockFeaturesScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockFeaturesScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockFeaturesScope)(nil).BaseURI))
+}
+
+// ClientID mocks base method.
+func (m *MockFeaturesScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockFeaturesScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockFeaturesScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockFeaturesScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockFeaturesScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockFeaturesScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockFeaturesScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockFeaturesScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockFeaturesScope)(nil).CloudEnvironment))
+}
+
+// FeaturesResource mocks base method.
+func (m *MockFeaturesScope) FeaturesResource() conditions.Setter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeaturesResource")
+	ret0, _ := ret[0].(conditions.Setter)
+	return ret0
+}
+
+// FeaturesResource indicates an expected call of FeaturesResource.
+func (mr *MockFeaturesScopeMockRecorder) FeaturesResource() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeaturesResource", reflect.TypeOf((*MockFeaturesScope)(nil).FeaturesResource))
+}
+
+// HashKey mocks base method.
+func (m *MockFeaturesScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockFeaturesScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockFeaturesScope)(nil).HashKey))
+}
+
+// RequiredFeatures mocks base method.
+func (m *MockFeaturesScope) RequiredFeatures() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequiredFeatures")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// RequiredFeatures indicates an expected call of RequiredFeatures.
+func (mr *MockFeaturesScopeMockRecorder) RequiredFeatures() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiredFeatures", reflect.TypeOf((*MockFeaturesScope)(nil).RequiredFeatures))
+}
+
+// SubscriptionID mocks base method.
+func (m *MockFeaturesScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockFeaturesScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockFeaturesScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockFeaturesScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockFeaturesScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockFeaturesScope)(nil).TenantID))
+}
+
+// Token mocks base method.
+func (m *MockFeaturesScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockFeaturesScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockFeaturesScope)(nil).Token))
+}
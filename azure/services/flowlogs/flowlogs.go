@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "flowlogs"
+
+// FlowLogScope defines the scope interface for a flow logs service.
+type FlowLogScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	FlowLogSpecs() []azure.ResourceSpecGetter
+	IsVnetManaged() bool
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope FlowLogScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope FlowLogScope) (*Service, error) {
+	client, err := newClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope: scope,
+		Reconciler: async.New[armnetwork.FlowLogsClientCreateOrUpdateResponse,
+			armnetwork.FlowLogsClientDeleteResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the flow log of the virtual network.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "flowlogs.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceReconcileTimeout(s.Name()))
+	defer cancel()
+
+	// Flow logs are only created for the virtual networks managed by this controller.
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping flow logs reconcile in custom VNet mode")
+		return nil
+	}
+
+	specs := s.Scope.FlowLogSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We go through the list of FlowLogSpecs to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var result error
+	for _, flowLogSpec := range specs {
+		if _, err := s.CreateOrUpdateResource(ctx, flowLogSpec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, result)
+	return result
+}
+
+// Delete deletes the flow log of the virtual network.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "flowlogs.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceDeleteTimeout(s.Name()))
+	defer cancel()
+
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping flow logs delete in custom VNet mode")
+		return nil
+	}
+
+	specs := s.Scope.FlowLogSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We go through the list of FlowLogSpecs to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var result error
+	for _, flowLogSpec := range specs {
+		if err := s.DeleteResource(ctx, flowLogSpec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.FlowLogsReadyCondition, ServiceName, result)
+	return result
+}
+
+// IsManaged returns true if the flow logs' lifecycles are managed, which is the case when the virtual network is.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "flowlogs.Service.IsManaged")
+	defer done()
+
+	return s.Scope.IsVnetManaged(), nil
+}
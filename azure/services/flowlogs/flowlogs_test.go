@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs/mock_flowlogs"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+var (
+	fakeControlPlaneFlowLog = FlowLogSpec{
+		Name:                        "my-vnet-my-rg-flowlog",
+		NetworkWatcherName:          "NetworkWatcher_eastus",
+		NetworkWatcherResourceGroup: "NetworkWatcherRG",
+		Location:                    "eastus",
+		ClusterName:                 "my-cluster",
+		TargetResourceID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+		StorageAccountID:            "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+	}
+	fakeNodeFlowLog = FlowLogSpec{
+		Name:                        "other-vnet-my-rg-flowlog",
+		NetworkWatcherName:          "NetworkWatcher_eastus",
+		NetworkWatcherResourceGroup: "NetworkWatcherRG",
+		Location:                    "eastus",
+		ClusterName:                 "my-cluster",
+		TargetResourceID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/other-vnet",
+		StorageAccountID:            "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+	}
+	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func internalError() *azcore.ResponseError {
+	return &azcore.ResponseError{
+		RawResponse: &http.Response{
+			Body:       io.NopCloser(strings.NewReader("#: Internal Server Error: StatusCode=500")),
+			StatusCode: http.StatusInternalServerError,
+		},
+	}
+}
+
+func TestReconcileFlowLogs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "create flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneFlowLog, &fakeNodeFlowLog})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeControlPlaneFlowLog, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeFlowLog, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "noop if no flow log specs are found",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.FlowLogSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "skip in custom vnet mode",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(false)
+			},
+		},
+		{
+			name:          "error creating the first flow log is returned over a not done error",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneFlowLog, &fakeNodeFlowLog})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeControlPlaneFlowLog, ServiceName).Return(nil, internalError())
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeFlowLog, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.FlowLogsReadyCondition, ServiceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_flowlogs.NewMockFlowLogScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteFlowLogs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "delete flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneFlowLog, &fakeNodeFlowLog})
+				r.DeleteResource(gomockinternal.AContext(), &fakeControlPlaneFlowLog, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNodeFlowLog, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.FlowLogsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "skip in custom vnet mode",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(false)
+			},
+		},
+		{
+			name:          "error deleting a flow log",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneFlowLog})
+				r.DeleteResource(gomockinternal.AContext(), &fakeControlPlaneFlowLog, ServiceName).Return(internalError())
+				s.UpdateDeleteStatus(infrav1.FlowLogsReadyCondition, ServiceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_flowlogs.NewMockFlowLogScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// FlowLogSpec defines the specification for the flow log of a virtual network.
+type FlowLogSpec struct {
+	Name                        string
+	NetworkWatcherName          string
+	NetworkWatcherResourceGroup string
+	Location                    string
+	ClusterName                 string
+	TargetResourceID            string
+	StorageAccountID            string
+	RetentionDays               int32
+	TrafficAnalytics            *infrav1.TrafficAnalyticsSpec
+	AdditionalTags              infrav1.Tags
+}
+
+// ResourceName returns the name of the flow log.
+func (s *FlowLogSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the network watcher.
+func (s *FlowLogSpec) ResourceGroupName() string {
+	return s.NetworkWatcherResourceGroup
+}
+
+// OwnerResourceName returns the name of the network watcher the flow log belongs to.
+func (s *FlowLogSpec) OwnerResourceName() string {
+	return s.NetworkWatcherName
+}
+
+// Parameters returns the parameters for the flow log.
+func (s *FlowLogSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	properties := &armnetwork.FlowLogPropertiesFormat{
+		Enabled:          ptr.To(true),
+		StorageID:        ptr.To(s.StorageAccountID),
+		TargetResourceID: ptr.To(s.TargetResourceID),
+		RetentionPolicy: &armnetwork.RetentionPolicyParameters{
+			Enabled: ptr.To(s.RetentionDays > 0),
+			Days:    ptr.To(s.RetentionDays),
+		},
+	}
+	if s.TrafficAnalytics != nil {
+		properties.FlowAnalyticsConfiguration = &armnetwork.TrafficAnalyticsProperties{
+			NetworkWatcherFlowAnalyticsConfiguration: &armnetwork.TrafficAnalyticsConfigurationProperties{
+				Enabled:                  ptr.To(true),
+				WorkspaceID:              ptr.To(s.TrafficAnalytics.WorkspaceID),
+				WorkspaceRegion:          ptr.To(s.TrafficAnalytics.WorkspaceRegion),
+				WorkspaceResourceID:      ptr.To(s.TrafficAnalytics.WorkspaceResourceID),
+				TrafficAnalyticsInterval: s.TrafficAnalytics.IntervalInMinutes,
+			},
+		}
+	}
+
+	if existing != nil {
+		existingFlowLog, ok := existing.(armnetwork.FlowLog)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.FlowLog", existing)
+		}
+		if flowLogUpToDate(existingFlowLog.Properties, properties) {
+			// flow log already exists with the desired configuration
+			return nil, nil
+		}
+	}
+
+	return armnetwork.FlowLog{
+		Location:   ptr.To(s.Location),
+		Properties: properties,
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
+
+// flowLogUpToDate returns true if the existing flow log properties match the desired ones.
+func flowLogUpToDate(existing, desired *armnetwork.FlowLogPropertiesFormat) bool {
+	if existing == nil {
+		return false
+	}
+	if !ptr.Deref(existing.Enabled, false) ||
+		!strings.EqualFold(ptr.Deref(existing.StorageID, ""), ptr.Deref(desired.StorageID, "")) ||
+		!strings.EqualFold(ptr.Deref(existing.TargetResourceID, ""), ptr.Deref(desired.TargetResourceID, "")) {
+		return false
+	}
+	if existing.RetentionPolicy == nil ||
+		ptr.Deref(existing.RetentionPolicy.Enabled, false) != ptr.Deref(desired.RetentionPolicy.Enabled, false) ||
+		ptr.Deref(existing.RetentionPolicy.Days, 0) != ptr.Deref(desired.RetentionPolicy.Days, 0) {
+		return false
+	}
+
+	var existingTA, desiredTA *armnetwork.TrafficAnalyticsConfigurationProperties
+	if existing.FlowAnalyticsConfiguration != nil {
+		existingTA = existing.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration
+	}
+	if desired.FlowAnalyticsConfiguration != nil {
+		desiredTA = desired.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration
+	}
+	if desiredTA == nil {
+		return existingTA == nil || !ptr.Deref(existingTA.Enabled, false)
+	}
+	return existingTA != nil && ptr.Deref(existingTA.Enabled, false) &&
+		strings.EqualFold(ptr.Deref(existingTA.WorkspaceResourceID, ""), ptr.Deref(desiredTA.WorkspaceResourceID, "")) &&
+		strings.EqualFold(ptr.Deref(existingTA.WorkspaceID, ""), ptr.Deref(desiredTA.WorkspaceID, "")) &&
+		strings.EqualFold(ptr.Deref(existingTA.WorkspaceRegion, ""), ptr.Deref(desiredTA.WorkspaceRegion, "")) &&
+		ptr.Deref(existingTA.TrafficAnalyticsInterval, 0) == ptr.Deref(desiredTA.TrafficAnalyticsInterval, 0)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	trafficAnalytics := &infrav1.TrafficAnalyticsSpec{
+		WorkspaceResourceID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+		WorkspaceID:         "00000000-0000-0000-0000-000000000000",
+		WorkspaceRegion:     "eastus",
+		IntervalInMinutes:   ptr.To[int32](10),
+	}
+	specWithTrafficAnalytics := fakeControlPlaneFlowLog
+	specWithTrafficAnalytics.RetentionDays = 30
+	specWithTrafficAnalytics.TrafficAnalytics = trafficAnalytics
+
+	upToDateFlowLog := armnetwork.FlowLog{
+		Properties: &armnetwork.FlowLogPropertiesFormat{
+			Enabled:          ptr.To(true),
+			StorageID:        ptr.To(specWithTrafficAnalytics.StorageAccountID),
+			TargetResourceID: ptr.To(specWithTrafficAnalytics.TargetResourceID),
+			RetentionPolicy: &armnetwork.RetentionPolicyParameters{
+				Enabled: ptr.To(true),
+				Days:    ptr.To[int32](30),
+			},
+			FlowAnalyticsConfiguration: &armnetwork.TrafficAnalyticsProperties{
+				NetworkWatcherFlowAnalyticsConfiguration: &armnetwork.TrafficAnalyticsConfigurationProperties{
+					Enabled:                  ptr.To(true),
+					WorkspaceID:              ptr.To(trafficAnalytics.WorkspaceID),
+					WorkspaceRegion:          ptr.To(trafficAnalytics.WorkspaceRegion),
+					WorkspaceResourceID:      ptr.To(trafficAnalytics.WorkspaceResourceID),
+					TrafficAnalyticsInterval: ptr.To[int32](10),
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *FlowLogSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new flow log",
+			spec:     &fakeControlPlaneFlowLog,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.FlowLog{}))
+				flowLog := result.(armnetwork.FlowLog)
+				g.Expect(flowLog.Location).To(Equal(ptr.To("eastus")))
+				g.Expect(flowLog.Properties.Enabled).To(Equal(ptr.To(true)))
+				g.Expect(flowLog.Properties.StorageID).To(Equal(ptr.To(fakeControlPlaneFlowLog.StorageAccountID)))
+				g.Expect(flowLog.Properties.TargetResourceID).To(Equal(ptr.To(fakeControlPlaneFlowLog.TargetResourceID)))
+				g.Expect(flowLog.Properties.RetentionPolicy.Enabled).To(Equal(ptr.To(false)))
+				g.Expect(flowLog.Properties.FlowAnalyticsConfiguration).To(BeNil())
+				g.Expect(flowLog.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", ptr.To("owned")))
+			},
+		},
+		{
+			name:     "new flow log with retention and traffic analytics",
+			spec:     &specWithTrafficAnalytics,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.FlowLog{}))
+				flowLog := result.(armnetwork.FlowLog)
+				g.Expect(flowLog.Properties.RetentionPolicy).To(Equal(upToDateFlowLog.Properties.RetentionPolicy))
+				g.Expect(flowLog.Properties.FlowAnalyticsConfiguration).To(Equal(upToDateFlowLog.Properties.FlowAnalyticsConfiguration))
+			},
+		},
+		{
+			name:     "existing flow log is up to date",
+			spec:     &specWithTrafficAnalytics,
+			existing: upToDateFlowLog,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing flow log was disabled",
+			spec: &specWithTrafficAnalytics,
+			existing: armnetwork.FlowLog{
+				Properties: &armnetwork.FlowLogPropertiesFormat{
+					Enabled:                    ptr.To(false),
+					StorageID:                  upToDateFlowLog.Properties.StorageID,
+					TargetResourceID:           upToDateFlowLog.Properties.TargetResourceID,
+					RetentionPolicy:            upToDateFlowLog.Properties.RetentionPolicy,
+					FlowAnalyticsConfiguration: upToDateFlowLog.Properties.FlowAnalyticsConfiguration,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.FlowLog{}))
+				g.Expect(result.(armnetwork.FlowLog).Properties.Enabled).To(Equal(ptr.To(true)))
+			},
+		},
+		{
+			name:          "existing is not a flow log",
+			spec:          &fakeControlPlaneFlowLog,
+			existing:      struct{}{},
+			expectedError: "struct {} is not an armnetwork.FlowLog",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
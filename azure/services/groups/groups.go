@@ -49,12 +49,20 @@ func New(scope GroupScope) *Service {
 	svc.ListFunc = list
 	svc.Specs = scope.GroupSpecs()
 	svc.ConditionType = infrav1.ResourceGroupReadyCondition
+	svc.PostCreateOrUpdateResourceHook = postCreateOrUpdateResourceHook
 	return &Service{
 		Scope:   scope,
 		Service: svc,
 	}
 }
 
+func postCreateOrUpdateResourceHook(_ context.Context, scope GroupScope, group *asoresourcesv1.ResourceGroup, err error) error {
+	if err != nil {
+		return err
+	}
+	return aso.RecordOwnership(scope, "ResourceGroup", group.AzureName(), group)
+}
+
 // IsManaged returns true if all resource groups are
 // managed and reconciled by ASO, meaning that we can rely on a single resource
 // group delete operation as opposed to deleting every individual resource.
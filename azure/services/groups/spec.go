@@ -34,6 +34,7 @@ type GroupSpec struct {
 	Location       string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+	Adopt          bool
 }
 
 // ResourceRef implements aso.ResourceSpecGetter.
@@ -48,6 +49,14 @@ func (s *GroupSpec) ResourceRef() *asoresourcesv1.ResourceGroup {
 // Parameters implements aso.ResourceSpecGetter.
 func (s *GroupSpec) Parameters(ctx context.Context, existing *asoresourcesv1.ResourceGroup) (*asoresourcesv1.ResourceGroup, error) {
 	if existing != nil {
+		if s.Adopt && !infrav1.Tags(existing.Status.Tags).HasOwned(s.ClusterName) {
+			// Keep the tags of the resource group when it is adopted and tag it as owned by the cluster.
+			tags := infrav1.Tags{}
+			tags.Merge(existing.Status.Tags)
+			tags.Merge(existing.Spec.Tags)
+			tags[infrav1.ClusterTagKey(s.ClusterName)] = string(infrav1.ResourceLifecycleOwned)
+			existing.Spec.Tags = tags
+		}
 		return existing, nil
 	}
 
@@ -71,7 +80,7 @@ func (s *GroupSpec) Parameters(ctx context.Context, existing *asoresourcesv1.Res
 
 // WasManaged implements azure.ASOResourceSpecGetter.
 func (s *GroupSpec) WasManaged(resource *asoresourcesv1.ResourceGroup) bool {
-	return infrav1.Tags(resource.Status.Tags).HasOwned(s.ClusterName)
+	return s.Adopt || infrav1.Tags(resource.Status.Tags).HasOwned(s.ClusterName)
 }
 
 var _ aso.TagsGetterSetter[*asoresourcesv1.ResourceGroup] = (*GroupSpec)(nil)
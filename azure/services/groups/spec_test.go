@@ -59,6 +59,9 @@ func TestParameters(t *testing.T) {
 		},
 		{
 			name: "existing group",
+			spec: &GroupSpec{
+				ClusterName: "cluster",
+			},
 			existing: &asoresourcesv1.ResourceGroup{
 				ObjectMeta: metav1.ObjectMeta{Name: "a unique name"},
 			},
@@ -66,6 +69,31 @@ func TestParameters(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "a unique name"},
 			},
 		},
+		{
+			name: "adopted existing group",
+			spec: &GroupSpec{
+				ClusterName: "cluster",
+				Adopt:       true,
+			},
+			existing: &asoresourcesv1.ResourceGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "a unique name"},
+				Status: asoresourcesv1.ResourceGroup_STATUS{
+					Tags: map[string]string{"existing": "tag"},
+				},
+			},
+			expected: &asoresourcesv1.ResourceGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "a unique name"},
+				Spec: asoresourcesv1.ResourceGroup_Spec{
+					Tags: map[string]string{
+						"existing": "tag",
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster": "owned",
+					},
+				},
+				Status: asoresourcesv1.ResourceGroup_STATUS{
+					Tags: map[string]string{"existing": "tag"},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -88,6 +116,7 @@ func TestWasManaged(t *testing.T) {
 	tests := []struct {
 		name     string
 		object   *asoresourcesv1.ResourceGroup
+		adopt    bool
 		expected bool
 	}{
 		{
@@ -107,6 +136,12 @@ func TestWasManaged(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name:     "adopted",
+			object:   &asoresourcesv1.ResourceGroup{},
+			adopt:    true,
+			expected: true,
+		},
 		{
 			name: "with owned label",
 			object: &asoresourcesv1.ResourceGroup{
@@ -127,6 +162,7 @@ func TestWasManaged(t *testing.T) {
 
 			s := &GroupSpec{
 				ClusterName: clusterName,
+				Adopt:       test.adopt,
 			}
 
 			g.Expect(s.WasManaged(test.object)).To(Equal(test.expected))
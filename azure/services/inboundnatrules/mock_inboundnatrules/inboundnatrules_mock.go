@@ -101,6 +101,34 @@ func (mr *MockInboundNatScopeMockRecorder) AvailabilitySetEnabled() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetEnabled", reflect.TypeOf((*MockInboundNatScope)(nil).AvailabilitySetEnabled))
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockInboundNatScope) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockInboundNatScopeMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockInboundNatScope)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockInboundNatScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockInboundNatScopeMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockInboundNatScope)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // BaseURI mocks base method.
 func (m *MockInboundNatScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -157,6 +185,20 @@ func (mr *MockInboundNatScopeMockRecorder) CloudEnvironment() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockInboundNatScope)(nil).CloudEnvironment))
 }
 
+// CloudProviderConfigDisabled mocks base method.
+func (m *MockInboundNatScope) CloudProviderConfigDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudProviderConfigDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CloudProviderConfigDisabled indicates an expected call of CloudProviderConfigDisabled.
+func (mr *MockInboundNatScopeMockRecorder) CloudProviderConfigDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudProviderConfigDisabled", reflect.TypeOf((*MockInboundNatScope)(nil).CloudProviderConfigDisabled))
+}
+
 // CloudProviderConfigOverrides mocks base method.
 func (m *MockInboundNatScope) CloudProviderConfigOverrides() *v1beta1.CloudProviderConfigOverrides {
 	m.ctrl.T.Helper()
@@ -379,6 +421,20 @@ func (mr *MockInboundNatScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockInboundNatScope)(nil).ResourceGroup))
 }
 
+// ResourceNaming mocks base method.
+func (m *MockInboundNatScope) ResourceNaming() *v1beta1.ResourceNamingSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceNaming")
+	ret0, _ := ret[0].(*v1beta1.ResourceNamingSpec)
+	return ret0
+}
+
+// ResourceNaming indicates an expected call of ResourceNaming.
+func (mr *MockInboundNatScopeMockRecorder) ResourceNaming() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceNaming", reflect.TypeOf((*MockInboundNatScope)(nil).ResourceNaming))
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockInboundNatScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitaccesspolicies
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "jitaccesspolicies"
+
+// JITAccessPolicyScope defines the scope interface for a just-in-time VM access policies service.
+type JITAccessPolicyScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	JITAccessPolicySpec() azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope JITAccessPolicyScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope JITAccessPolicyScope) (*Service, error) {
+	client, err := newClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope: scope,
+		Reconciler: async.New[armresources.ClientCreateOrUpdateByIDResponse,
+			armresources.ClientDeleteByIDResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates the just-in-time VM access policy of a virtual machine.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "jitaccesspolicies.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceReconcileTimeout(s.Name()))
+	defer cancel()
+
+	spec := s.Scope.JITAccessPolicySpec()
+	if spec == nil {
+		return nil
+	}
+
+	_, err := s.CreateOrUpdateResource(ctx, spec, serviceName)
+	s.Scope.UpdatePutStatus(infrav1.JITAccessPolicyReadyCondition, serviceName, err)
+	return err
+}
+
+// Delete deletes the just-in-time VM access policy of a virtual machine.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "jitaccesspolicies.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceDeleteTimeout(s.Name()))
+	defer cancel()
+
+	spec := s.Scope.JITAccessPolicySpec()
+	if spec == nil {
+		return nil
+	}
+
+	err := s.DeleteResource(ctx, spec, serviceName)
+	s.Scope.UpdateDeleteStatus(infrav1.JITAccessPolicyReadyCondition, serviceName, err)
+	return err
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO just-in-time VM access policies.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
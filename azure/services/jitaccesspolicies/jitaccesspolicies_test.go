@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitaccesspolicies
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/jitaccesspolicies/mock_jitaccesspolicies"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+var fakePolicy = JITAccessPolicySpec{
+	Name:          "my-vm",
+	ResourceGroup: "my-rg",
+	Location:      "eastus",
+	VMID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+	Ports:         []infrav1.JITAccessPort{{Port: 22, Protocol: infrav1.JITAccessProtocolTCP}},
+}
+
+func internalError() *azcore.ResponseError {
+	return &azcore.ResponseError{
+		RawResponse: &http.Response{
+			Body:       io.NopCloser(strings.NewReader("#: Internal Server Error: StatusCode=500")),
+			StatusCode: http.StatusInternalServerError,
+		},
+	}
+}
+
+func TestReconcileJITAccessPolicies(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_jitaccesspolicies.MockJITAccessPolicyScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "create just-in-time VM access policy",
+			expectedError: "",
+			expect: func(s *mock_jitaccesspolicies.MockJITAccessPolicyScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.JITAccessPolicySpec().Return(&fakePolicy)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePolicy, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.JITAccessPolicyReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "noop if the machine has no just-in-time VM access",
+			expectedError: "",
+			expect: func(s *mock_jitaccesspolicies.MockJITAccessPolicyScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.JITAccessPolicySpec().Return(nil)
+			},
+		},
+		{
+			name:          "error creating just-in-time VM access policy",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_jitaccesspolicies.MockJITAccessPolicyScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.JITAccessPolicySpec().Return(&fakePolicy)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePolicy, serviceName).Return(nil, internalError())
+				s.UpdatePutStatus(infrav1.JITAccessPolicyReadyCondition, serviceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_jitaccesspolicies.NewMockJITAccessPolicyScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteJITAccessPolicies(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_jitaccesspolicies.MockJITAccessPolicyScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "delete just-in-time VM access policy",
+			expectedError: "",
+			expect: func(s *mock_jitaccesspolicies.MockJITAccessPolicyScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.JITAccessPolicySpec().Return(&fakePolicy)
+				r.DeleteResource(gomockinternal.AContext(), &fakePolicy, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.JITAccessPolicyReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "noop if the machine has no just-in-time VM access",
+			expectedError: "",
+			expect: func(s *mock_jitaccesspolicies.MockJITAccessPolicyScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.JITAccessPolicySpec().Return(nil)
+			},
+		},
+		{
+			name:          "error deleting just-in-time VM access policy",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_jitaccesspolicies.MockJITAccessPolicyScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.JITAccessPolicySpec().Return(&fakePolicy)
+				r.DeleteResource(gomockinternal.AContext(), &fakePolicy, serviceName).Return(internalError())
+				s.UpdateDeleteStatus(infrav1.JITAccessPolicyReadyCondition, serviceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_jitaccesspolicies.NewMockJITAccessPolicyScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitaccesspolicies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// defaultMaxRequestAccessDuration is the default maximum duration of the access granted by a request.
+const defaultMaxRequestAccessDuration = 3 * time.Hour
+
+// JITAccessPolicySpec defines the specification for the just-in-time VM access policy of a virtual machine.
+type JITAccessPolicySpec struct {
+	Name          string
+	ResourceGroup string
+	Location      string
+	VMID          string
+	Ports         []infrav1.JITAccessPort
+}
+
+// policyProperties are the properties of a just-in-time VM access policy.
+type policyProperties struct {
+	VirtualMachines []policyVirtualMachine `json:"virtualMachines"`
+}
+
+// policyVirtualMachine is a virtual machine of a just-in-time VM access policy.
+type policyVirtualMachine struct {
+	ID    string       `json:"id"`
+	Ports []policyPort `json:"ports"`
+}
+
+// policyPort is a port of a virtual machine of a just-in-time VM access policy.
+type policyPort struct {
+	Number                       int32    `json:"number"`
+	Protocol                     string   `json:"protocol"`
+	AllowedSourceAddressPrefix   *string  `json:"allowedSourceAddressPrefix,omitempty"`
+	AllowedSourceAddressPrefixes []string `json:"allowedSourceAddressPrefixes,omitempty"`
+	MaxRequestAccessDuration     string   `json:"maxRequestAccessDuration"`
+}
+
+// ResourceName returns the name of the just-in-time VM access policy.
+func (s *JITAccessPolicySpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the just-in-time VM access policy.
+func (s *JITAccessPolicySpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the Microsoft Defender for Cloud location the just-in-time VM access policy belongs to,
+// which is the location of the virtual machine.
+func (s *JITAccessPolicySpec) OwnerResourceName() string {
+	return s.Location
+}
+
+// Parameters returns the parameters for the just-in-time VM access policy.
+func (s *JITAccessPolicySpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(armresources.GenericResource); !ok {
+			return nil, errors.Errorf("%T is not an armresources.GenericResource", existing)
+		}
+		// The ports of the policy are immutable, so there is nothing to update. Access requests update the policy,
+		// which must not be overwritten.
+		return nil, nil
+	}
+
+	ports := make([]policyPort, 0, len(s.Ports))
+	for _, port := range s.Ports {
+		p := policyPort{
+			Number:                     port.Port,
+			Protocol:                   string(port.Protocol),
+			AllowedSourceAddressPrefix: ptr.To("*"),
+			MaxRequestAccessDuration:   isoDuration(defaultMaxRequestAccessDuration),
+		}
+		if p.Protocol == "" {
+			p.Protocol = string(infrav1.JITAccessProtocolTCP)
+		}
+		if len(port.AllowedSourceCIDRs) > 0 {
+			p.AllowedSourceAddressPrefix = nil
+			p.AllowedSourceAddressPrefixes = port.AllowedSourceCIDRs
+		}
+		if port.MaxRequestAccessDuration != nil {
+			p.MaxRequestAccessDuration = isoDuration(port.MaxRequestAccessDuration.Duration)
+		}
+		ports = append(ports, p)
+	}
+
+	return armresources.GenericResource{
+		Kind: ptr.To("Basic"),
+		Properties: policyProperties{
+			VirtualMachines: []policyVirtualMachine{
+				{
+					ID:    s.VMID,
+					Ports: ports,
+				},
+			},
+		},
+	}, nil
+}
+
+// isoDuration formats a duration of whole minutes as an ISO 8601 duration, e.g. "PT1H30M".
+func isoDuration(d time.Duration) string {
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	switch {
+	case minutes == 0:
+		return fmt.Sprintf("PT%dH", hours)
+	case hours == 0:
+		return fmt.Sprintf("PT%dM", minutes)
+	default:
+		return fmt.Sprintf("PT%dH%dM", hours, minutes)
+	}
+}
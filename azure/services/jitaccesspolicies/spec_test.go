@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitaccesspolicies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name     string
+		spec     *JITAccessPolicySpec
+		existing interface{}
+		expected interface{}
+	}{
+		{
+			name: "policy with default settings",
+			spec: &JITAccessPolicySpec{
+				Name:          "my-vm",
+				ResourceGroup: "my-rg",
+				Location:      "eastus",
+				VMID:          "my-vm-id",
+				Ports:         []infrav1.JITAccessPort{{Port: 22}},
+			},
+			expected: armresources.GenericResource{
+				Kind: ptr.To("Basic"),
+				Properties: policyProperties{
+					VirtualMachines: []policyVirtualMachine{
+						{
+							ID: "my-vm-id",
+							Ports: []policyPort{
+								{
+									Number:                     22,
+									Protocol:                   "TCP",
+									AllowedSourceAddressPrefix: ptr.To("*"),
+									MaxRequestAccessDuration:   "PT3H",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "policy with restricted sources and duration",
+			spec: &JITAccessPolicySpec{
+				Name:          "my-vm",
+				ResourceGroup: "my-rg",
+				Location:      "eastus",
+				VMID:          "my-vm-id",
+				Ports: []infrav1.JITAccessPort{
+					{
+						Port:                     3389,
+						Protocol:                 infrav1.JITAccessProtocolAll,
+						AllowedSourceCIDRs:       []string{"10.0.0.0/8"},
+						MaxRequestAccessDuration: &metav1.Duration{Duration: 90 * time.Minute},
+					},
+				},
+			},
+			expected: armresources.GenericResource{
+				Kind: ptr.To("Basic"),
+				Properties: policyProperties{
+					VirtualMachines: []policyVirtualMachine{
+						{
+							ID: "my-vm-id",
+							Ports: []policyPort{
+								{
+									Number:                       3389,
+									Protocol:                     "*",
+									AllowedSourceAddressPrefixes: []string{"10.0.0.0/8"},
+									MaxRequestAccessDuration:     "PT1H30M",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "existing policy is not updated",
+			spec: &JITAccessPolicySpec{
+				Name:  "my-vm",
+				Ports: []infrav1.JITAccessPort{{Port: 22}},
+			},
+			existing: armresources.GenericResource{},
+			expected: nil,
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expected == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expected))
+			}
+		})
+	}
+}
@@ -131,6 +131,34 @@ func (mr *MockLBScopeMockRecorder) AvailabilitySetEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetEnabled", reflect.TypeOf((*MockLBScope)(nil).AvailabilitySetEnabled))
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockLBScope) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockLBScopeMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockLBScope)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockLBScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockLBScopeMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockLBScope)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // BaseURI mocks base method.
 func (m *MockLBScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -187,6 +215,20 @@ func (mr *MockLBScopeMockRecorder) CloudEnvironment() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockLBScope)(nil).CloudEnvironment))
 }
 
+// CloudProviderConfigDisabled mocks base method.
+func (m *MockLBScope) CloudProviderConfigDisabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudProviderConfigDisabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CloudProviderConfigDisabled indicates an expected call of CloudProviderConfigDisabled.
+func (mr *MockLBScopeMockRecorder) CloudProviderConfigDisabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudProviderConfigDisabled", reflect.TypeOf((*MockLBScope)(nil).CloudProviderConfigDisabled))
+}
+
 // CloudProviderConfigOverrides mocks base method.
 func (m *MockLBScope) CloudProviderConfigOverrides() *v1beta1.CloudProviderConfigOverrides {
 	m.ctrl.T.Helper()
@@ -437,6 +479,20 @@ func (mr *MockLBScopeMockRecorder) IsAPIServerPrivate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAPIServerPrivate", reflect.TypeOf((*MockLBScope)(nil).IsAPIServerPrivate))
 }
 
+// IsControlPlaneEndpointExternallyManaged mocks base method.
+func (m *MockLBScope) IsControlPlaneEndpointExternallyManaged() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsControlPlaneEndpointExternallyManaged")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsControlPlaneEndpointExternallyManaged indicates an expected call of IsControlPlaneEndpointExternallyManaged.
+func (mr *MockLBScopeMockRecorder) IsControlPlaneEndpointExternallyManaged() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsControlPlaneEndpointExternallyManaged", reflect.TypeOf((*MockLBScope)(nil).IsControlPlaneEndpointExternallyManaged))
+}
+
 // IsIPv6Enabled mocks base method.
 func (m *MockLBScope) IsIPv6Enabled() bool {
 	m.ctrl.T.Helper()
@@ -535,6 +591,20 @@ func (mr *MockLBScopeMockRecorder) OutboundLBName(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBName", reflect.TypeOf((*MockLBScope)(nil).OutboundLBName), arg0)
 }
 
+// OutboundLBResourceGroup mocks base method.
+func (m *MockLBScope) OutboundLBResourceGroup(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundLBResourceGroup", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundLBResourceGroup indicates an expected call of OutboundLBResourceGroup.
+func (mr *MockLBScopeMockRecorder) OutboundLBResourceGroup(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBResourceGroup", reflect.TypeOf((*MockLBScope)(nil).OutboundLBResourceGroup), arg0)
+}
+
 // OutboundPoolName mocks base method.
 func (m *MockLBScope) OutboundPoolName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -563,6 +633,20 @@ func (mr *MockLBScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockLBScope)(nil).ResourceGroup))
 }
 
+// ResourceNaming mocks base method.
+func (m *MockLBScope) ResourceNaming() *v1beta1.ResourceNamingSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceNaming")
+	ret0, _ := ret[0].(*v1beta1.ResourceNamingSpec)
+	return ret0
+}
+
+// ResourceNaming indicates an expected call of ResourceNaming.
+func (mr *MockLBScopeMockRecorder) ResourceNaming() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceNaming", reflect.TypeOf((*MockLBScope)(nil).ResourceNaming))
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockLBScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
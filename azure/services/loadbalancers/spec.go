@@ -18,6 +18,8 @@ package loadbalancers
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
@@ -25,6 +27,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 // LBSpec defines the specification for a Load Balancer.
@@ -65,6 +68,9 @@ func (s *LBSpec) OwnerResourceName() string {
 
 // Parameters returns the parameters for the load balancer.
 func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
+	_, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.LBSpec.Parameters")
+	defer done()
+
 	var (
 		etag                *string
 		frontendIDs         []*armnetwork.SubResource
@@ -95,11 +101,19 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 			}
 		}
 
-		loadBalancingRules = existingLB.Properties.LoadBalancingRules
+		loadBalancingRules = slices.Clone(existingLB.Properties.LoadBalancingRules)
 		for _, rule := range getLoadBalancingRules(*s, wantedFrontendIDs) {
-			if !lbRuleExists(loadBalancingRules, *rule) {
+			i := slices.IndexFunc(loadBalancingRules, func(r *armnetwork.LoadBalancingRule) bool {
+				return ptr.Deref(r.Name, "") == ptr.Deref(rule.Name, "")
+			})
+			switch {
+			case i < 0:
 				update = true
 				loadBalancingRules = append(loadBalancingRules, rule)
+			case !lbRuleMatches(*loadBalancingRules[i], *rule):
+				log.V(2).Info("load balancing rule differs from spec, restoring it", "loadBalancer", s.Name, "rule", ptr.Deref(rule.Name, ""))
+				update = true
+				loadBalancingRules[i] = rule
 			}
 		}
 
@@ -119,11 +133,19 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 			}
 		}
 
-		probes = existingLB.Properties.Probes
+		probes = slices.Clone(existingLB.Properties.Probes)
 		for _, probe := range getProbes(*s) {
-			if !probeExists(probes, *probe) {
+			i := slices.IndexFunc(probes, func(p *armnetwork.Probe) bool {
+				return ptr.Deref(p.Name, "") == ptr.Deref(probe.Name, "")
+			})
+			switch {
+			case i < 0:
 				update = true
 				probes = append(probes, probe)
+			case !probeMatches(*probes[i], *probe):
+				log.V(2).Info("probe differs from spec, restoring it", "loadBalancer", s.Name, "probe", ptr.Deref(probe.Name, ""))
+				update = true
+				probes[i] = probe
 			}
 		}
 
@@ -176,9 +198,13 @@ func getFrontendIPConfigs(lbSpec LBSpec) ([]*armnetwork.FrontendIPConfiguration,
 				PrivateIPAddress: ptr.To(ipConfig.PrivateIPAddress),
 			}
 		} else {
+			publicIPResourceGroup := lbSpec.ResourceGroup
+			if ipConfig.PublicIP.ResourceGroup != "" {
+				publicIPResourceGroup = ipConfig.PublicIP.ResourceGroup
+			}
 			properties = armnetwork.FrontendIPConfigurationPropertiesFormat{
 				PublicIPAddress: &armnetwork.PublicIPAddress{
-					ID: ptr.To(azure.PublicIPID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, ipConfig.PublicIP.Name)),
+					ID: ptr.To(azure.PublicIPID(lbSpec.SubscriptionID, publicIPResourceGroup, ipConfig.PublicIP.Name)),
 				},
 			}
 		}
@@ -271,13 +297,17 @@ func getProbes(lbSpec LBSpec) []*armnetwork.Probe {
 	return []*armnetwork.Probe{}
 }
 
-func probeExists(probes []*armnetwork.Probe, probe armnetwork.Probe) bool {
-	for _, p := range probes {
-		if ptr.Deref(p.Name, "") == ptr.Deref(probe.Name, "") {
-			return true
-		}
+// probeMatches returns true if the existing probe has the properties of the wanted probe.
+func probeMatches(existing, wanted armnetwork.Probe) bool {
+	if existing.Properties == nil || wanted.Properties == nil {
+		return existing.Properties == wanted.Properties
 	}
-	return false
+	e, w := existing.Properties, wanted.Properties
+	return ptr.Deref(e.Protocol, "") == ptr.Deref(w.Protocol, "") &&
+		ptr.Deref(e.Port, 0) == ptr.Deref(w.Port, 0) &&
+		ptr.Deref(e.RequestPath, "") == ptr.Deref(w.RequestPath, "") &&
+		ptr.Deref(e.IntervalInSeconds, 0) == ptr.Deref(w.IntervalInSeconds, 0) &&
+		ptr.Deref(e.NumberOfProbes, 0) == ptr.Deref(w.NumberOfProbes, 0)
 }
 
 func outboundRuleExists(rules []*armnetwork.OutboundRule, rule armnetwork.OutboundRule) bool {
@@ -298,13 +328,36 @@ func poolExists(pools []*armnetwork.BackendAddressPool, pool armnetwork.BackendA
 	return false
 }
 
-func lbRuleExists(rules []*armnetwork.LoadBalancingRule, rule armnetwork.LoadBalancingRule) bool {
-	for _, r := range rules {
-		if ptr.Deref(r.Name, "") == ptr.Deref(rule.Name, "") {
-			return true
-		}
+// lbRuleMatches returns true if the existing load balancing rule has the properties of the wanted rule.
+// The idle timeout is only compared when the wanted rule sets it, as Azure defaults it otherwise.
+func lbRuleMatches(existing, wanted armnetwork.LoadBalancingRule) bool {
+	if existing.Properties == nil || wanted.Properties == nil {
+		return existing.Properties == wanted.Properties
 	}
-	return false
+	e, w := existing.Properties, wanted.Properties
+	if w.IdleTimeoutInMinutes != nil && ptr.Deref(e.IdleTimeoutInMinutes, 0) != *w.IdleTimeoutInMinutes {
+		return false
+	}
+	return ptr.Deref(e.Protocol, "") == ptr.Deref(w.Protocol, "") &&
+		ptr.Deref(e.FrontendPort, 0) == ptr.Deref(w.FrontendPort, 0) &&
+		ptr.Deref(e.BackendPort, 0) == ptr.Deref(w.BackendPort, 0) &&
+		ptr.Deref(e.EnableFloatingIP, false) == ptr.Deref(w.EnableFloatingIP, false) &&
+		ptr.Deref(e.DisableOutboundSnat, false) == ptr.Deref(w.DisableOutboundSnat, false) &&
+		ptr.Deref(e.LoadDistribution, "") == ptr.Deref(w.LoadDistribution, "") &&
+		subResourceIDsEqual(e.Probe, w.Probe) &&
+		subResourceIDsEqual(e.BackendAddressPool, w.BackendAddressPool)
+}
+
+// subResourceIDsEqual returns true if both sub-resources reference the same ID, ignoring case.
+func subResourceIDsEqual(a, b *armnetwork.SubResource) bool {
+	var aID, bID string
+	if a != nil {
+		aID = ptr.Deref(a.ID, "")
+	}
+	if b != nil {
+		bID = ptr.Deref(b.ID, "")
+	}
+	return strings.EqualFold(aID, bID)
 }
 
 func ipExists(configs []*armnetwork.FrontendIPConfiguration, config armnetwork.FrontendIPConfiguration) bool {
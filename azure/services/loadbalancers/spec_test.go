@@ -54,6 +54,20 @@ func getExistingLBWithMissingProbes() armnetwork.LoadBalancer {
 	return existingLB
 }
 
+func getExistingLBWithModifiedLBRules() armnetwork.LoadBalancer {
+	existingLB := newSamplePublicAPIServerLB(true, true, true, true, true)
+	existingLB.Properties.LoadBalancingRules[0].Properties.FrontendPort = ptr.To[int32](443)
+
+	return existingLB
+}
+
+func getExistingLBWithModifiedProbes() armnetwork.LoadBalancer {
+	existingLB := newSamplePublicAPIServerLB(true, true, true, true, true)
+	existingLB.Properties.Probes[0].Properties.IntervalInSeconds = ptr.To[int32](60)
+
+	return existingLB
+}
+
 func getExistingLBWithMissingOutboundRules() armnetwork.LoadBalancer {
 	existingLB := newSamplePublicAPIServerLB(true, true, true, true, false)
 	existingLB.Properties.OutboundRules = []*armnetwork.OutboundRule{}
@@ -61,6 +75,22 @@ func getExistingLBWithMissingOutboundRules() armnetwork.LoadBalancer {
 	return existingLB
 }
 
+func getPublicAPILBSpecWithPublicIPResourceGroup(resourceGroup string) *LBSpec {
+	spec := fakePublicAPILBSpec
+	spec.FrontendIPConfigs = []infrav1.FrontendIP{
+		{
+			Name: "my-publiclb-frontEnd",
+			PublicIP: &infrav1.PublicIPSpec{
+				Name:          "my-publicip",
+				DNSName:       "my-cluster.12345.mydomain.com",
+				ResourceGroup: resourceGroup,
+			},
+		},
+	}
+
+	return &spec
+}
+
 func TestParameters(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -136,6 +166,26 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "load balancer exists with modified load balancing rules",
+			spec:     &fakePublicAPILBSpec,
+			existing: getExistingLBWithModifiedLBRules(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.LoadBalancer{}))
+				g.Expect(result.(armnetwork.LoadBalancer)).To(Equal(newSamplePublicAPIServerLB(true, true, false, true, true)))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "load balancer exists with modified probes",
+			spec:     &fakePublicAPILBSpec,
+			existing: getExistingLBWithModifiedProbes(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.LoadBalancer{}))
+				g.Expect(result.(armnetwork.LoadBalancer)).To(Equal(newSamplePublicAPIServerLB(true, true, true, false, true)))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "load balancer exists with missing outbound rules",
 			spec:     &fakePublicAPILBSpec,
@@ -146,6 +196,18 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "public API load balancer with an existing public IP from another resource group",
+			spec:     getPublicAPILBSpecWithPublicIPResourceGroup("my-static-ip-rg"),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.LoadBalancer{}))
+				frontendIPConfigs := result.(armnetwork.LoadBalancer).Properties.FrontendIPConfigurations
+				g.Expect(frontendIPConfigs).To(HaveLen(1))
+				g.Expect(*frontendIPConfigs[0].Properties.PublicIPAddress.ID).To(Equal("/subscriptions/123/resourceGroups/my-static-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip"))
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -211,8 +273,8 @@ func newDefaultNodeOutboundLB() armnetwork.LoadBalancer {
 func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools bool, verifyLBRules bool, verifyProbes bool, verifyOutboundRules bool) armnetwork.LoadBalancer {
 	var subnet *armnetwork.Subnet
 	var backendAddressPoolProps *armnetwork.BackendAddressPoolPropertiesFormat
-	enableFloatingIP := ptr.To(false)
-	numProbes := ptr.To[int32](4)
+	var enableTCPReset *bool
+	var probeThreshold *int32
 	idleTimeout := ptr.To[int32](4)
 
 	if verifyFrontendIP {
@@ -226,10 +288,10 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 		}
 	}
 	if verifyLBRules {
-		enableFloatingIP = ptr.To(true)
+		enableTCPReset = ptr.To(true)
 	}
 	if verifyProbes {
-		numProbes = ptr.To[int32](999)
+		probeThreshold = ptr.To[int32](2)
 	}
 	if verifyOutboundRules {
 		idleTimeout = ptr.To[int32](1000)
@@ -267,7 +329,8 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 						FrontendPort:         ptr.To[int32](6443),
 						BackendPort:          ptr.To[int32](6443),
 						IdleTimeoutInMinutes: ptr.To[int32](4),
-						EnableFloatingIP:     enableFloatingIP, // Add to verify that LoadBalancingRules aren't overwritten on update
+						EnableFloatingIP:     ptr.To(false),
+						EnableTCPReset:       enableTCPReset, // Add to verify that LoadBalancingRules aren't overwritten on update
 						LoadDistribution:     ptr.To(armnetwork.LoadDistributionDefault),
 						FrontendIPConfiguration: &armnetwork.SubResource{
 							ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd"),
@@ -289,7 +352,8 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 						Port:              ptr.To[int32](6443),
 						RequestPath:       ptr.To(httpsProbeRequestPath),
 						IntervalInSeconds: ptr.To[int32](15),
-						NumberOfProbes:    numProbes, // Add to verify that Probes aren't overwritten on update
+						NumberOfProbes:    ptr.To[int32](4),
+						ProbeThreshold:    probeThreshold, // Add to verify that Probes aren't overwritten on update
 					},
 				},
 			},
@@ -23,6 +23,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -34,6 +35,8 @@ type azureClient struct {
 	apiCallTimeout time.Duration
 }
 
+var _ async.Lister = &azureClient{}
+
 // NewClient creates a new network interfaces client from an authorizer.
 func NewClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*azureClient, error) {
 	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
@@ -59,6 +62,25 @@ func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (
 	return resp.Interface, nil
 }
 
+// ListByResourceGroup returns all network interfaces in a resource group, keyed by name.
+func (ac *azureClient) ListByResourceGroup(ctx context.Context, resourceGroupName string) (map[string]interface{}, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkinterfaces.AzureClient.ListByResourceGroup")
+	defer done()
+
+	nics := make(map[string]interface{})
+	pager := ac.interfaces.NewListPager(resourceGroupName, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not iterate network interfaces")
+		}
+		for _, nic := range nextResult.Value {
+			nics[ptr.Deref(nic.Name, "")] = *nic
+		}
+	}
+	return nics, nil
+}
+
 // CreateOrUpdateAsync creates or updates a network interface asynchronously.
 // It sends a PUT request to Azure and if accepted without error, the func will return a poller which can be used to track the ongoing
 // progress of the operation.
@@ -49,10 +49,12 @@ func New(scope NICScope, skuCache *resourceskus.Cache) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	cachingClient := async.NewCachingClient[armnetwork.InterfacesClientCreateOrUpdateResponse,
+		armnetwork.InterfacesClientDeleteResponse](scope, client)
 	return &Service{
 		Scope: scope,
 		Reconciler: async.New[armnetwork.InterfacesClientCreateOrUpdateResponse,
-			armnetwork.InterfacesClientDeleteResponse](scope, client, client),
+			armnetwork.InterfacesClientDeleteResponse](scope, cachingClient, cachingClient),
 		resourceSKUCache: skuCache,
 	}, nil
 }
@@ -67,7 +69,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkinterfaces.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceReconcileTimeout(s.Name()))
 	defer cancel()
 
 	specs := s.Scope.NICSpecs()
@@ -96,7 +98,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkinterfaces.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceDeleteTimeout(s.Name()))
 	defer cancel()
 
 	specs := s.Scope.NICSpecs()
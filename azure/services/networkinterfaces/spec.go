@@ -42,9 +42,11 @@ type NICSpec struct {
 	VNetResourceGroup         string
 	StaticIPAddress           string
 	PublicLBName              string
+	PublicLBResourceGroup     string
 	PublicLBAddressPoolName   string
 	PublicLBNATRuleName       string
 	InternalLBName            string
+	InternalLBResourceGroup   string
 	InternalLBAddressPoolName string
 	PublicIPName              string
 	AcceleratedNetworking     *bool
@@ -55,6 +57,8 @@ type NICSpec struct {
 	AdditionalTags            infrav1.Tags
 	ClusterName               string
 	IPConfigs                 []IPConfig
+	// ApplicationSecurityGroupIDs are the resource IDs of the application security groups all the IP configurations join.
+	ApplicationSecurityGroupIDs []string
 }
 
 // IPConfig defines the specification for an IP address configuration.
@@ -105,28 +109,43 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 
 	backendAddressPools := []*armnetwork.BackendAddressPool{}
 	if s.PublicLBName != "" {
+		// Load balancers live in the NIC resource group unless an existing load balancer from another resource group is used.
+		publicLBResourceGroup := s.ResourceGroup
+		if s.PublicLBResourceGroup != "" {
+			publicLBResourceGroup = s.PublicLBResourceGroup
+		}
 		if s.PublicLBAddressPoolName != "" {
 			backendAddressPools = append(backendAddressPools,
 				&armnetwork.BackendAddressPool{
-					ID: ptr.To(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, s.PublicLBAddressPoolName)),
+					ID: ptr.To(azure.AddressPoolID(s.SubscriptionID, publicLBResourceGroup, s.PublicLBName, s.PublicLBAddressPoolName)),
 				})
 		}
 		if s.PublicLBNATRuleName != "" {
 			primaryIPConfig.LoadBalancerInboundNatRules = []*armnetwork.InboundNatRule{
 				{
-					ID: ptr.To(azure.NATRuleID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, s.PublicLBNATRuleName)),
+					ID: ptr.To(azure.NATRuleID(s.SubscriptionID, publicLBResourceGroup, s.PublicLBName, s.PublicLBNATRuleName)),
 				},
 			}
 		}
 	}
 	if s.InternalLBName != "" && s.InternalLBAddressPoolName != "" {
+		internalLBResourceGroup := s.ResourceGroup
+		if s.InternalLBResourceGroup != "" {
+			internalLBResourceGroup = s.InternalLBResourceGroup
+		}
 		backendAddressPools = append(backendAddressPools,
 			&armnetwork.BackendAddressPool{
-				ID: ptr.To(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.InternalLBName, s.InternalLBAddressPoolName)),
+				ID: ptr.To(azure.AddressPoolID(s.SubscriptionID, internalLBResourceGroup, s.InternalLBName, s.InternalLBAddressPoolName)),
 			})
 	}
 	primaryIPConfig.LoadBalancerBackendAddressPools = backendAddressPools
 
+	var applicationSecurityGroups []*armnetwork.ApplicationSecurityGroup
+	if len(s.ApplicationSecurityGroupIDs) > 0 {
+		applicationSecurityGroups = converters.ApplicationSecurityGroupsToSDK(s.ApplicationSecurityGroupIDs)
+	}
+	primaryIPConfig.ApplicationSecurityGroups = applicationSecurityGroups
+
 	if s.PublicIPName != "" {
 		primaryIPConfig.PublicIPAddress = &armnetwork.PublicIPAddress{
 			ID: ptr.To(azure.PublicIPID(s.SubscriptionID, s.ResourceGroup, s.PublicIPName)),
@@ -141,6 +160,8 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 
 		accelNet := s.SKU.HasCapability(resourceskus.AcceleratedNetworking)
 		s.AcceleratedNetworking = &accelNet
+	} else if *s.AcceleratedNetworking && s.SKU != nil && !s.SKU.HasCapability(resourceskus.AcceleratedNetworking) {
+		return nil, azure.WithTerminalError(errors.Errorf("accelerated networking is not supported for VM size %s", ptr.Deref(s.SKU.Name, "")))
 	}
 
 	dnsSettings := armnetwork.InterfaceDNSSettings{}
@@ -160,6 +181,7 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		c := s.IPConfigs[i]
 		newIPConfigPropertiesFormat := &armnetwork.InterfaceIPConfigurationPropertiesFormat{}
 		newIPConfigPropertiesFormat.Subnet = subnet
+		newIPConfigPropertiesFormat.ApplicationSecurityGroups = applicationSecurityGroups
 		config := &armnetwork.InterfaceIPConfiguration{
 			Name:       ptr.To(s.Name + "-" + strconv.Itoa(i)),
 			Properties: newIPConfigPropertiesFormat,
@@ -192,9 +214,10 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		ipv6Config := &armnetwork.InterfaceIPConfiguration{
 			Name: ptr.To("ipConfigv6"),
 			Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
-				PrivateIPAddressVersion: ptr.To(armnetwork.IPVersionIPv6),
-				Primary:                 ptr.To(false),
-				Subnet:                  &armnetwork.Subnet{ID: subnet.ID},
+				PrivateIPAddressVersion:   ptr.To(armnetwork.IPVersionIPv6),
+				Primary:                   ptr.To(false),
+				Subnet:                    &armnetwork.Subnet{ID: subnet.ID},
+				ApplicationSecurityGroups: applicationSecurityGroups,
 			},
 		}
 
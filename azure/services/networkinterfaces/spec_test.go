@@ -62,6 +62,28 @@ var (
 		},
 	}
 
+	fakeSkuWithoutAcceleratedNetworking = resourceskus.SKU{
+		Name: ptr.To("Standard_B2s"),
+		Kind: ptr.To(string(resourceskus.VirtualMachines)),
+		Locations: []*string{
+			ptr.To("fake-location"),
+		},
+	}
+
+	fakeUnsupportedAcceleratedNetworkingNICSpec = NICSpec{
+		Name:                  "my-net-interface",
+		ResourceGroup:         "my-rg",
+		Location:              "fake-location",
+		SubscriptionID:        "123",
+		MachineName:           "azure-test1",
+		SubnetName:            "my-subnet",
+		VNetName:              "my-vnet",
+		VNetResourceGroup:     "my-rg",
+		AcceleratedNetworking: ptr.To(true),
+		SKU:                   &fakeSkuWithoutAcceleratedNetworking,
+		ClusterName:           "my-cluster",
+	}
+
 	fakeCustomDNSServers = []string{"123.123.123.123", "124.124.124.124"}
 
 	fakeStaticPrivateIPNICSpec = NICSpec{
@@ -116,6 +138,26 @@ var (
 		ClusterName:               "my-cluster",
 	}
 
+	fakeControlPlaneExistingLBNICSpec = NICSpec{
+		Name:                      "my-net-interface",
+		ResourceGroup:             "my-rg",
+		Location:                  "fake-location",
+		SubscriptionID:            "123",
+		MachineName:               "azure-test1",
+		SubnetName:                "my-subnet",
+		VNetName:                  "my-vnet",
+		VNetResourceGroup:         "my-rg",
+		PublicLBName:              "my-public-lb",
+		PublicLBResourceGroup:     "my-lb-rg",
+		PublicLBAddressPoolName:   "my-public-lb-backendPool",
+		InternalLBName:            "my-internal-lb",
+		InternalLBResourceGroup:   "my-lb-rg",
+		InternalLBAddressPoolName: "my-internal-lb-backendPool",
+		AcceleratedNetworking:     nil,
+		SKU:                       &fakeSku,
+		ClusterName:               "my-cluster",
+	}
+
 	fakeAcceleratedNetworkingNICSpec = NICSpec{
 		Name:                  "my-net-interface",
 		ResourceGroup:         "my-rg",
@@ -232,6 +274,25 @@ var (
 		IPConfigs:             []IPConfig{{}, {}},
 		ClusterName:           "my-cluster",
 	}
+	fakeTwoIPconfigWithApplicationSecurityGroupsNICSpec = NICSpec{
+		Name:                  "my-net-interface",
+		ResourceGroup:         "my-rg",
+		Location:              "fake-location",
+		SubscriptionID:        "123",
+		MachineName:           "azure-test1",
+		SubnetName:            "my-subnet",
+		VNetName:              "my-vnet",
+		IPv6Enabled:           false,
+		VNetResourceGroup:     "my-rg",
+		AcceleratedNetworking: nil,
+		SKU:                   &fakeSku,
+		EnableIPForwarding:    true,
+		IPConfigs:             []IPConfig{{}, {}},
+		ClusterName:           "my-cluster",
+		ApplicationSecurityGroupIDs: []string{
+			"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/web",
+		},
+	}
 	fakeTwoIPconfigWithPublicNICSpec = NICSpec{
 		Name:                  "my-net-interface",
 		ResourceGroup:         "my-rg",
@@ -268,6 +329,15 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "unable to get required network interface SKU from machine cache",
 		},
+		{
+			name:     "error when accelerated networking is enabled and not supported by the SKU",
+			spec:     &fakeUnsupportedAcceleratedNetworkingNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: accelerated networking is not supported for VM size Standard_B2s. Object will not be requeued",
+		},
 		{
 			name:     "get parameters for network interface with static private IP",
 			spec:     &fakeStaticPrivateIPNICSpec,
@@ -371,6 +441,21 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for control plane network interface with existing load balancers from another resource group",
+			spec:     &fakeControlPlaneExistingLBNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Interface{}))
+				ipConfig := result.(armnetwork.Interface).Properties.IPConfigurations[0]
+				g.Expect(ipConfig.Properties.LoadBalancerInboundNatRules).To(BeNil())
+				g.Expect(ipConfig.Properties.LoadBalancerBackendAddressPools).To(Equal([]*armnetwork.BackendAddressPool{
+					{ID: ptr.To("/subscriptions/123/resourceGroups/my-lb-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/my-public-lb-backendPool")},
+					{ID: ptr.To("/subscriptions/123/resourceGroups/my-lb-rg/providers/Microsoft.Network/loadBalancers/my-internal-lb/backendAddressPools/my-internal-lb-backendPool")},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get parameters for network interface with accelerated networking",
 			spec:     &fakeAcceleratedNetworkingNICSpec,
@@ -586,6 +671,22 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for network interface with two ipconfigs and application security groups",
+			spec:     &fakeTwoIPconfigWithApplicationSecurityGroupsNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Interface{}))
+				ipConfigs := result.(armnetwork.Interface).Properties.IPConfigurations
+				g.Expect(ipConfigs).To(HaveLen(2))
+				for _, ipConfig := range ipConfigs {
+					g.Expect(ipConfig.Properties.ApplicationSecurityGroups).To(Equal([]*armnetwork.ApplicationSecurityGroup{
+						{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/web")},
+					}))
+				}
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get parameters for network interface with two ipconfigs and a public ip",
 			spec:     &fakeTwoIPconfigWithPublicNICSpec,
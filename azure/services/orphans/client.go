@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk.
+type client interface {
+	ListResourceGroups(context.Context) ([]*armresources.ResourceGroup, error)
+	ListResources(context.Context, string, string) ([]*armresources.GenericResourceExpanded, error)
+	DeleteResourceGroup(context.Context, string) error
+	DeleteResource(context.Context, string, string) error
+}
+
+// azureClient contains the Azure go-sdk Clients.
+type azureClient struct {
+	groups    *armresources.ResourceGroupsClient
+	resources *armresources.Client
+}
+
+// newClient creates a new orphans client from an authorizer.
+func newClient(auth azure.Authorizer) (*azureClient, error) {
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create orphans client options")
+	}
+	factory, err := armresources.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armresources client factory")
+	}
+	return &azureClient{
+		groups:    factory.NewResourceGroupsClient(),
+		resources: factory.NewClient(),
+	}, nil
+}
+
+// ListResourceGroups lists the resource groups of the subscription.
+func (ac *azureClient) ListResourceGroups(ctx context.Context) ([]*armresources.ResourceGroup, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "orphans.AzureClient.ListResourceGroups")
+	defer done()
+
+	var groups []*armresources.ResourceGroup
+	pager := ac.groups.NewListPager(nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return groups, errors.Wrap(err, "could not iterate resource groups")
+		}
+		groups = append(groups, nextResult.Value...)
+	}
+	return groups, nil
+}
+
+// ListResources lists the resources of a resource group matching an OData filter.
+func (ac *azureClient) ListResources(ctx context.Context, resourceGroupName, filter string) ([]*armresources.GenericResourceExpanded, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "orphans.AzureClient.ListResources")
+	defer done()
+
+	var resources []*armresources.GenericResourceExpanded
+	pager := ac.resources.NewListByResourceGroupPager(resourceGroupName, &armresources.ClientListByResourceGroupOptions{Filter: &filter})
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return resources, errors.Wrap(err, "could not iterate resources")
+		}
+		resources = append(resources, nextResult.Value...)
+	}
+	return resources, nil
+}
+
+// DeleteResourceGroup deletes a resource group and waits for the deletion to complete.
+func (ac *azureClient) DeleteResourceGroup(ctx context.Context, resourceGroupName string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "orphans.AzureClient.DeleteResourceGroup")
+	defer done()
+
+	poller, err := ac.groups.BeginDelete(ctx, resourceGroupName, nil)
+	if err != nil {
+		return err
+	}
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+// DeleteResource deletes a resource by ID and waits for the deletion to complete.
+func (ac *azureClient) DeleteResource(ctx context.Context, resourceID, apiVersion string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "orphans.AzureClient.DeleteResource")
+	defer done()
+
+	poller, err := ac.resources.BeginDeleteByID(ctx, resourceID, apiVersion, nil)
+	if err != nil {
+		return err
+	}
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
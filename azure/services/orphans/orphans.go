@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	resourceGroupType     = "Microsoft.Resources/resourceGroups"
+	virtualMachineType    = "Microsoft.Compute/virtualMachines"
+	networkInterfaceType  = "Microsoft.Network/networkInterfaces"
+	diskType              = "Microsoft.Compute/disks"
+	reasonClusterNotFound = "cluster not found"
+	reasonMachineNotFound = "machine not found"
+)
+
+// apiVersions are the API versions used to delete orphaned resources, by resource type.
+var apiVersions = map[string]string{
+	virtualMachineType:   "2024-03-01",
+	networkInterfaceType: "2023-05-01",
+	diskType:             "2023-10-02",
+}
+
+// OrphansScope defines the scope interface for an orphans service.
+type OrphansScope interface {
+	azure.Authorizer
+	ClusterName() string
+	NodeResourceGroup() string
+	ResourceNaming() *infrav1.ResourceNamingSpec
+}
+
+// Resource is an Azure resource which is owned by a cluster or machine that no longer exists.
+type Resource struct {
+	ID     string
+	Name   string
+	Type   string
+	Reason string
+}
+
+// Service finds and deletes orphaned Azure resources.
+type Service struct {
+	Scope OrphansScope
+	client
+}
+
+// New creates a new service.
+func New(scope OrphansScope) (*Service, error) {
+	cli, err := newClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope:  scope,
+		client: cli,
+	}, nil
+}
+
+// FindResourceGroups returns the resource groups of the scope's subscription which are tagged as owned
+// by a cluster whose name is not in clusters.
+func (s *Service) FindResourceGroups(ctx context.Context, clusters sets.Set[string]) ([]Resource, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "orphans.Service.FindResourceGroups")
+	defer done()
+
+	groups, err := s.ListResourceGroups(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list resource groups")
+	}
+	var orphans []Resource
+	for _, group := range groups {
+		if group.Properties != nil && strings.EqualFold(ptr.Deref(group.Properties.ProvisioningState, ""), "Deleting") {
+			continue
+		}
+		if owner, ok := ownerCluster(group.Tags); ok && !clusters.Has(owner) {
+			orphans = append(orphans, Resource{
+				ID:     ptr.Deref(group.ID, ""),
+				Name:   ptr.Deref(group.Name, ""),
+				Type:   resourceGroupType,
+				Reason: fmt.Sprintf("%s: %s", reasonClusterNotFound, owner),
+			})
+		}
+	}
+	return orphans, nil
+}
+
+// Find returns the virtual machines of the scope's cluster whose name is not in machines, along with
+// their network interfaces and disks. Virtual machines are returned before the network interfaces and
+// disks attached to them.
+func (s *Service) Find(ctx context.Context, machines sets.Set[string]) ([]Resource, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "orphans.Service.Find")
+	defer done()
+
+	var orphans []Resource
+	filter := fmt.Sprintf("tagName eq '%s' and tagValue eq '%s'", infrav1.ClusterTagKey(s.Scope.ClusterName()), infrav1.ResourceLifecycleOwned)
+	owned, err := s.ListResources(ctx, s.Scope.NodeResourceGroup(), filter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list resources owned by cluster %s", s.Scope.ClusterName())
+	}
+	var vms []string
+	for _, resource := range owned {
+		name := ptr.Deref(resource.Name, "")
+		if strings.EqualFold(ptr.Deref(resource.Type, ""), virtualMachineType) && !machines.Has(name) {
+			vms = append(vms, name)
+			orphans = append(orphans, newResource(resource, fmt.Sprintf("%s: %s", reasonMachineNotFound, name)))
+		}
+	}
+	if len(vms) == 0 {
+		return orphans, nil
+	}
+
+	naming := s.Scope.ResourceNaming()
+	for _, resource := range owned {
+		if !strings.EqualFold(ptr.Deref(resource.Type, ""), networkInterfaceType) {
+			continue
+		}
+		if vm, ok := orphanedMachine(ptr.Deref(resource.Name, ""), vms, machines, naming, isMachineNIC); ok {
+			orphans = append(orphans, newResource(resource, fmt.Sprintf("%s: %s", reasonMachineNotFound, vm)))
+		}
+	}
+
+	// Disks created along with a virtual machine are not tagged, they are found by name instead.
+	disks, err := s.ListResources(ctx, s.Scope.NodeResourceGroup(), fmt.Sprintf("resourceType eq '%s'", diskType))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list disks in resource group %s", s.Scope.NodeResourceGroup())
+	}
+	for _, disk := range disks {
+		if vm, ok := orphanedMachine(ptr.Deref(disk.Name, ""), vms, machines, naming, isMachineDisk); ok {
+			orphans = append(orphans, newResource(disk, fmt.Sprintf("%s: %s", reasonMachineNotFound, vm)))
+		}
+	}
+
+	return orphans, nil
+}
+
+// Delete deletes the given resources in order, waiting for each deletion to complete.
+func (s *Service) Delete(ctx context.Context, resources []Resource) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "orphans.Service.Delete")
+	defer done()
+
+	for _, resource := range resources {
+		log.Info("deleting orphaned resource", "id", resource.ID, "reason", resource.Reason)
+		var err error
+		if resource.Type == resourceGroupType {
+			err = s.DeleteResourceGroup(ctx, resource.Name)
+		} else {
+			apiVersion, ok := apiVersions[resource.Type]
+			if !ok {
+				return errors.Errorf("cannot delete resource %s of unsupported type %s", resource.ID, resource.Type)
+			}
+			err = s.DeleteResource(ctx, resource.ID, apiVersion)
+		}
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete orphaned resource %s", resource.ID)
+		}
+	}
+	return nil
+}
+
+// ownerCluster returns the name of the cluster owning a resource with the given tags.
+func ownerCluster(tags map[string]*string) (string, bool) {
+	for key, value := range tags {
+		if strings.HasPrefix(key, infrav1.NameAzureProviderOwned) && ptr.Deref(value, "") == string(infrav1.ResourceLifecycleOwned) {
+			return strings.TrimPrefix(key, infrav1.NameAzureProviderOwned), true
+		}
+	}
+	return "", false
+}
+
+// orphanedMachine returns the orphaned virtual machine a network interface or disk is named after. Resources whose
+// name also matches an existing machine are never considered orphaned, as naming templates may make the names of
+// the resources of a machine look like the ones of another machine whose name is a prefix of it.
+func orphanedMachine(name string, vms []string, machines sets.Set[string], naming *infrav1.ResourceNamingSpec, isMachineResource func(name, machineName string, naming *infrav1.ResourceNamingSpec) bool) (string, bool) {
+	for machine := range machines {
+		if isMachineResource(name, machine, naming) {
+			return "", false
+		}
+	}
+	for _, vm := range vms {
+		if isMachineResource(name, vm, naming) {
+			return vm, true
+		}
+	}
+	return "", false
+}
+
+// isMachineNIC returns true if name is the name of one of the network interfaces of a machine.
+func isMachineNIC(name, machineName string, naming *infrav1.ResourceNamingSpec) bool {
+	// Network interfaces in a subnet with a dedicated pod subnet have a second network interface for the pod IPs.
+	if nicName, ok := strings.CutSuffix(name, azure.PodNICName("")); ok && isMachineNIC(nicName, machineName, naming) {
+		return true
+	}
+	if name == azure.NICName(naming, machineName, false, 0) {
+		return true
+	}
+	// The names of the network interfaces of a machine with several of them only differ by their index.
+	first, second := azure.NICName(naming, machineName, true, 0), azure.NICName(naming, machineName, true, 1)
+	i := 0
+	for i < len(first) && first[i] == second[i] {
+		i++
+	}
+	prefix, suffix := first[:i], first[min(i+1, len(first)):]
+	if second != prefix+"1"+suffix || len(name) <= len(prefix)+len(suffix) ||
+		!strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return false
+	}
+	index := name[len(prefix) : len(name)-len(suffix)]
+	n, err := strconv.Atoi(index)
+	return err == nil && strconv.Itoa(n) == index
+}
+
+// isMachineDisk returns true if name is the name of the OS disk or of one of the data disks of a machine.
+func isMachineDisk(name, machineName string, naming *infrav1.ResourceNamingSpec) bool {
+	if name == azure.OSDiskName(naming, machineName) {
+		return true
+	}
+	// Azure resource names can't contain slashes, so splitting a data disk name with a slash as name suffix gives the
+	// prefix and suffix shared by all the data disks of the machine.
+	prefix, suffix, ok := strings.Cut(azure.DataDiskName(naming, machineName, "/"), "/")
+	return ok && len(name) > len(prefix)+len(suffix) && strings.HasPrefix(name, prefix) && strings.HasSuffix(name, suffix)
+}
+
+func newResource(resource *armresources.GenericResourceExpanded, reason string) Resource {
+	return Resource{
+		ID:     ptr.Deref(resource.ID, ""),
+		Name:   ptr.Deref(resource.Name, ""),
+		Type:   ptr.Deref(resource.Type, ""),
+		Reason: reason,
+	}
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/orphans/mock_orphans"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	ownedFilter = "tagName eq 'sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster' and tagValue eq 'owned'"
+	disksFilter = "resourceType eq 'Microsoft.Compute/disks'"
+)
+
+func TestFindResourceGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(m *mock_orphans.MockclientMockRecorder)
+		expected      []Resource
+		expectedError string
+	}{
+		{
+			name: "no orphans",
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				m.ListResourceGroups(gomockinternal.AContext()).Return([]*armresources.ResourceGroup{
+					resourceGroup("my-cluster", "my-cluster"),
+					resourceGroup("unrelated", ""),
+				}, nil)
+			},
+		},
+		{
+			name: "resource group owned by a cluster which no longer exists",
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				m.ListResourceGroups(gomockinternal.AContext()).Return([]*armresources.ResourceGroup{
+					resourceGroup("my-cluster", "my-cluster"),
+					resourceGroup("old-cluster", "old-cluster"),
+					deletingResourceGroup("deleted-cluster"),
+				}, nil)
+			},
+			expected: []Resource{
+				{ID: "/subscriptions/123/resourceGroups/old-cluster", Name: "old-cluster", Type: resourceGroupType, Reason: "cluster not found: old-cluster"},
+			},
+		},
+		{
+			name: "error listing resource groups",
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				m.ListResourceGroups(gomockinternal.AContext()).Return(nil, errors.New("an error"))
+			},
+			expectedError: "failed to list resource groups: an error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clientMock := mock_orphans.NewMockclient(mockCtrl)
+			tc.expect(clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  mock_orphans.NewMockOrphansScope(mockCtrl),
+				client: clientMock,
+			}
+
+			orphans, err := s.FindResourceGroups(context.TODO(), sets.New("my-cluster"))
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(orphans).To(Equal(tc.expected))
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	testcases := []struct {
+		name          string
+		naming        *infrav1.ResourceNamingSpec
+		expect        func(m *mock_orphans.MockclientMockRecorder)
+		expected      []Resource
+		expectedError string
+	}{
+		{
+			name: "no orphans",
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				m.ListResources(gomockinternal.AContext(), "my-node-rg", ownedFilter).Return([]*armresources.GenericResourceExpanded{
+					resource("my-vm", virtualMachineType),
+					resource("my-vm-nic", networkInterfaceType),
+				}, nil)
+			},
+		},
+		{
+			name: "virtual machine whose machine no longer exists",
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				m.ListResources(gomockinternal.AContext(), "my-node-rg", ownedFilter).Return([]*armresources.GenericResourceExpanded{
+					resource("my-vm-nic", networkInterfaceType),
+					resource("old-vm-nic", networkInterfaceType),
+					resource("old-vm-nic-1", networkInterfaceType),
+					resource("old-vm-nic-pods", networkInterfaceType),
+					resource("old-vm-2-nic", networkInterfaceType),
+					resource("my-vm", virtualMachineType),
+					resource("old-vm", virtualMachineType),
+				}, nil)
+				m.ListResources(gomockinternal.AContext(), "my-node-rg", disksFilter).Return([]*armresources.GenericResourceExpanded{
+					resource("my-vm_OSDisk", diskType),
+					resource("old-vm_OSDisk", diskType),
+					resource("old-vm_etcddisk", diskType),
+				}, nil)
+			},
+			expected: []Resource{
+				{ID: "/subscriptions/123/resourceGroups/my-rg/old-vm", Name: "old-vm", Type: virtualMachineType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/old-vm-nic", Name: "old-vm-nic", Type: networkInterfaceType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/old-vm-nic-1", Name: "old-vm-nic-1", Type: networkInterfaceType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/old-vm-nic-pods", Name: "old-vm-nic-pods", Type: networkInterfaceType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/old-vm_OSDisk", Name: "old-vm_OSDisk", Type: diskType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/old-vm_etcddisk", Name: "old-vm_etcddisk", Type: diskType, Reason: "machine not found: old-vm"},
+			},
+		},
+		{
+			name: "virtual machine whose machine no longer exists with resource naming templates",
+			naming: &infrav1.ResourceNamingSpec{
+				Prefix:           "corp-",
+				NetworkInterface: "nic-{machine}",
+				OSDisk:           "osdisk-{machine}",
+				DataDisk:         "{machine}-{nameSuffix}",
+			},
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				m.ListResources(gomockinternal.AContext(), "my-node-rg", ownedFilter).Return([]*armresources.GenericResourceExpanded{
+					resource("corp-nic-my-vm", networkInterfaceType),
+					resource("corp-nic-old-vm", networkInterfaceType),
+					resource("corp-nic-old-vm-1", networkInterfaceType),
+					resource("old-vm-nic", networkInterfaceType),
+					resource("my-vm", virtualMachineType),
+					resource("old-vm", virtualMachineType),
+				}, nil)
+				m.ListResources(gomockinternal.AContext(), "my-node-rg", disksFilter).Return([]*armresources.GenericResourceExpanded{
+					resource("corp-osdisk-my-vm", diskType),
+					resource("corp-osdisk-old-vm", diskType),
+					resource("corp-old-vm-etcddisk", diskType),
+					resource("corp-old-vm-2-etcddisk", diskType),
+					resource("old-vm_OSDisk", diskType),
+				}, nil)
+			},
+			expected: []Resource{
+				{ID: "/subscriptions/123/resourceGroups/my-rg/old-vm", Name: "old-vm", Type: virtualMachineType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/corp-nic-old-vm", Name: "corp-nic-old-vm", Type: networkInterfaceType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/corp-nic-old-vm-1", Name: "corp-nic-old-vm-1", Type: networkInterfaceType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/corp-osdisk-old-vm", Name: "corp-osdisk-old-vm", Type: diskType, Reason: "machine not found: old-vm"},
+				{ID: "/subscriptions/123/resourceGroups/my-rg/corp-old-vm-etcddisk", Name: "corp-old-vm-etcddisk", Type: diskType, Reason: "machine not found: old-vm"},
+			},
+		},
+		{
+			name: "error listing owned resources",
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				m.ListResources(gomockinternal.AContext(), "my-node-rg", ownedFilter).Return(nil, errors.New("an error"))
+			},
+			expectedError: "failed to list resources owned by cluster my-cluster: an error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_orphans.NewMockOrphansScope(mockCtrl)
+			clientMock := mock_orphans.NewMockclient(mockCtrl)
+
+			scopeMock.EXPECT().ClusterName().Return("my-cluster").AnyTimes()
+			scopeMock.EXPECT().NodeResourceGroup().Return("my-node-rg").AnyTimes()
+			scopeMock.EXPECT().ResourceNaming().Return(tc.naming).AnyTimes()
+			tc.expect(clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			orphans, err := s.Find(context.TODO(), sets.New("my-vm", "old-vm-2"))
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(orphans).To(Equal(tc.expected))
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	testcases := []struct {
+		name          string
+		resources     []Resource
+		expect        func(m *mock_orphans.MockclientMockRecorder)
+		expectedError string
+	}{
+		{
+			name: "deletes resources in order",
+			resources: []Resource{
+				{ID: "/subscriptions/123/resourceGroups/old-cluster", Name: "old-cluster", Type: resourceGroupType},
+				{ID: "old-vm-id", Name: "old-vm", Type: virtualMachineType},
+				{ID: "old-vm-nic-id", Name: "old-vm-nic", Type: networkInterfaceType},
+			},
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				gomock.InOrder(
+					m.DeleteResourceGroup(gomockinternal.AContext(), "old-cluster").Return(nil),
+					m.DeleteResource(gomockinternal.AContext(), "old-vm-id", "2024-03-01").Return(&azcore.ResponseError{StatusCode: http.StatusNotFound}),
+					m.DeleteResource(gomockinternal.AContext(), "old-vm-nic-id", "2023-05-01").Return(nil),
+				)
+			},
+		},
+		{
+			name: "stops at the first error",
+			resources: []Resource{
+				{ID: "old-vm-id", Name: "old-vm", Type: virtualMachineType},
+				{ID: "old-vm-nic-id", Name: "old-vm-nic", Type: networkInterfaceType},
+			},
+			expect: func(m *mock_orphans.MockclientMockRecorder) {
+				m.DeleteResource(gomockinternal.AContext(), "old-vm-id", "2024-03-01").Return(errors.New("an error"))
+			},
+			expectedError: "failed to delete orphaned resource old-vm-id: an error",
+		},
+		{
+			name: "unsupported resource type",
+			resources: []Resource{
+				{ID: "lb-id", Name: "lb", Type: "Microsoft.Network/loadBalancers"},
+			},
+			expect:        func(_ *mock_orphans.MockclientMockRecorder) {},
+			expectedError: "cannot delete resource lb-id of unsupported type Microsoft.Network/loadBalancers",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clientMock := mock_orphans.NewMockclient(mockCtrl)
+			tc.expect(clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  mock_orphans.NewMockOrphansScope(mockCtrl),
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO(), tc.resources)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func resourceGroup(name, owner string) *armresources.ResourceGroup {
+	group := &armresources.ResourceGroup{
+		ID:         ptr.To("/subscriptions/123/resourceGroups/" + name),
+		Name:       ptr.To(name),
+		Properties: &armresources.ResourceGroupProperties{ProvisioningState: ptr.To("Succeeded")},
+	}
+	if owner != "" {
+		group.Tags = map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_" + owner: ptr.To("owned")}
+	}
+	return group
+}
+
+func deletingResourceGroup(owner string) *armresources.ResourceGroup {
+	group := resourceGroup(owner, owner)
+	group.Properties.ProvisioningState = ptr.To("Deleting")
+	return group
+}
+
+func resource(name, resourceType string) *armresources.GenericResourceExpanded {
+	return &armresources.GenericResourceExpanded{
+		ID:   ptr.To("/subscriptions/123/resourceGroups/my-rg/" + name),
+		Name: ptr.To(name),
+		Type: ptr.To(resourceType),
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissions
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api-provider-azure/version"
+)
+
+// permissionsAPIVersion is the version of the Azure RBAC permissions API used to list the permissions held on a
+// subscription, which the Azure SDK for Go used by CAPZ has no client for.
+const permissionsAPIVersion = "2022-04-01"
+
+// client wraps go-sdk.
+type client interface {
+	ListForResourceGroup(context.Context, string) ([]*armauthorization.Permission, error)
+	ListForSubscription(context.Context) ([]*armauthorization.Permission, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	permissions    *armauthorization.PermissionsClient
+	arm            *arm.Client
+	subscriptionID string
+}
+
+// newClient creates a new permissions client from an authorizer.
+func newClient(auth azure.Authorizer) (*azureClient, error) {
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create permissions client options")
+	}
+	factory, err := armauthorization.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armauthorization client factory")
+	}
+	armClient, err := arm.NewClient("permissions", version.Get().String(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create permissions ARM client")
+	}
+	return &azureClient{
+		permissions:    factory.NewPermissionsClient(),
+		arm:            armClient,
+		subscriptionID: auth.SubscriptionID(),
+	}, nil
+}
+
+// ListForResourceGroup lists the permissions the caller holds on the specified resource group.
+func (ac *azureClient) ListForResourceGroup(ctx context.Context, resourceGroupName string) ([]*armauthorization.Permission, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "permissions.AzureClient.ListForResourceGroup")
+	defer done()
+
+	var permissions []*armauthorization.Permission
+	pager := ac.permissions.NewListForResourceGroupPager(resourceGroupName, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return permissions, errors.Wrap(err, "could not iterate permissions")
+		}
+		permissions = append(permissions, nextResult.Value...)
+	}
+	return permissions, nil
+}
+
+// ListForSubscription lists the permissions the caller holds on the subscription.
+func (ac *azureClient) ListForSubscription(ctx context.Context) ([]*armauthorization.Permission, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "permissions.AzureClient.ListForSubscription")
+	defer done()
+
+	var permissions []*armauthorization.Permission
+	nextURL := runtime.JoinPaths(ac.arm.Endpoint(), "/subscriptions/"+url.PathEscape(ac.subscriptionID)+"/providers/Microsoft.Authorization/permissions")
+	for nextURL != "" {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, nextURL)
+		if err != nil {
+			return permissions, err
+		}
+		if req.Raw().URL.Query().Get("api-version") == "" {
+			query := req.Raw().URL.Query()
+			query.Set("api-version", permissionsAPIVersion)
+			req.Raw().URL.RawQuery = query.Encode()
+		}
+		req.Raw().Header["Accept"] = []string{"application/json"}
+		resp, err := ac.arm.Pipeline().Do(req)
+		if err != nil {
+			return permissions, errors.Wrap(err, "could not iterate permissions")
+		}
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return permissions, errors.Wrap(runtime.NewResponseError(resp), "could not iterate permissions")
+		}
+		var result armauthorization.PermissionGetResult
+		if err := runtime.UnmarshalAsJSON(resp, &result); err != nil {
+			return permissions, err
+		}
+		permissions = append(permissions, result.Value...)
+		nextURL = ""
+		if result.NextLink != nil {
+			nextURL = *result.NextLink
+		}
+	}
+	return permissions, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const serviceName = "permissions"
+
+// RequiredActions are the Azure RBAC actions the cluster identity must hold on the resource groups CAPZ creates
+// the cluster infrastructure in.
+var RequiredActions = []string{
+	"Microsoft.Network/virtualNetworks/write",
+	"Microsoft.Network/virtualNetworks/subnets/write",
+	"Microsoft.Network/networkSecurityGroups/write",
+	"Microsoft.Network/routeTables/write",
+	"Microsoft.Network/publicIPAddresses/write",
+	"Microsoft.Network/loadBalancers/write",
+	"Microsoft.Network/networkInterfaces/write",
+	"Microsoft.Compute/virtualMachines/write",
+	"Microsoft.Authorization/roleAssignments/write",
+}
+
+// ResourceGroupWriteAction is the Azure RBAC action the cluster identity must hold on the subscription to create
+// a resource group that doesn't exist yet.
+const ResourceGroupWriteAction = "Microsoft.Resources/subscriptions/resourceGroups/write"
+
+// PermissionsScope defines the scope interface for a permissions service.
+type PermissionsScope interface {
+	azure.Authorizer
+	ResourceGroup() string
+	NodeResourceGroup() string
+	Vnet() *infrav1.VnetSpec
+	IsVnetManaged() bool
+	IdentityPermissionsResource() conditions.Setter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PermissionsScope
+	client
+}
+
+// New creates a new service.
+func New(scope PermissionsScope) (*Service, error) {
+	cli, err := newClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope:  scope,
+		client: cli,
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile verifies the cluster identity holds the actions required to create the cluster infrastructure on every
+// resource group CAPZ writes to, or on the subscription for resource groups that don't exist yet, and reports any
+// missing actions in the IdentityPermissionsReady condition. Permissions are checked on every reconcile so that
+// revoked permissions are reported too.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "permissions.Service.Reconcile")
+	defer done()
+
+	resource := s.Scope.IdentityPermissionsResource()
+
+	var missing []string
+	var checkSubscription bool
+	for _, resourceGroup := range s.resourceGroups() {
+		permissions, err := s.ListForResourceGroup(ctx, resourceGroup)
+		if azure.ResourceNotFound(err) {
+			// The resource group has not been created yet, so the permissions it will get are those on the subscription.
+			log.V(4).Info("resource group not found, checking permissions on the subscription", "resourceGroup", resourceGroup)
+			checkSubscription = true
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to list permissions for resource group %s", resourceGroup)
+		}
+		if actions := MissingActions(permissions, RequiredActions); len(actions) > 0 {
+			missing = append(missing, fmt.Sprintf("resource group %s: %s", resourceGroup, strings.Join(actions, ", ")))
+		}
+	}
+	if checkSubscription {
+		permissions, err := s.ListForSubscription(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list permissions for subscription %s", s.Scope.SubscriptionID())
+		}
+		if actions := MissingActions(permissions, append([]string{ResourceGroupWriteAction}, RequiredActions...)); len(actions) > 0 {
+			missing = append(missing, fmt.Sprintf("subscription %s: %s", s.Scope.SubscriptionID(), strings.Join(actions, ", ")))
+		}
+	}
+
+	if len(missing) > 0 {
+		msg := fmt.Sprintf("identity is missing permissions on %s", strings.Join(missing, "; "))
+		conditions.MarkFalse(resource, infrav1.IdentityPermissionsReadyCondition, infrav1.MissingPermissionsReason, clusterv1.ConditionSeverityError, "%s", msg)
+		return errors.New(msg)
+	}
+
+	conditions.MarkTrue(resource, infrav1.IdentityPermissionsReadyCondition)
+	return nil
+}
+
+// resourceGroups returns the resource groups CAPZ creates resources in: the cluster resource group, the node
+// resource group and the resource group of the virtual network if CAPZ manages it.
+func (s *Service) resourceGroups() []string {
+	var resourceGroups []string
+	candidates := []string{s.Scope.ResourceGroup(), s.Scope.NodeResourceGroup()}
+	if s.Scope.IsVnetManaged() {
+		candidates = append(candidates, s.Scope.Vnet().ResourceGroup)
+	}
+	for _, resourceGroup := range candidates {
+		if resourceGroup != "" && !slices.ContainsFunc(resourceGroups, func(rg string) bool { return strings.EqualFold(rg, resourceGroup) }) {
+			resourceGroups = append(resourceGroups, resourceGroup)
+		}
+	}
+	return resourceGroups
+}
+
+// Delete is a no-op.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "permissions.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// IsManaged always returns true.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// MissingActions returns the actions that are not granted by any of the given permissions.
+// An action is granted when it matches one of a permission's actions and none of the same permission's not actions.
+func MissingActions(permissions []*armauthorization.Permission, actions []string) []string {
+	var missing []string
+	for _, action := range actions {
+		if !isGranted(permissions, action) {
+			missing = append(missing, action)
+		}
+	}
+	return missing
+}
+
+func isGranted(permissions []*armauthorization.Permission, action string) bool {
+	for _, permission := range permissions {
+		if permission == nil {
+			continue
+		}
+		if matchesAny(permission.Actions, action) && !matchesAny(permission.NotActions, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether the action matches any of the given patterns. Patterns may contain "*" wildcards
+// and are compared case-insensitively, as Azure RBAC does.
+func matchesAny(patterns []*string, action string) bool {
+	for _, pattern := range patterns {
+		if pattern == nil {
+			continue
+		}
+		expr := "(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(*pattern), `\*`, ".*") + "$"
+		if matched, err := regexp.MatchString(expr, action); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissions
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/permissions/mock_permissions"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcilePermissions(t *testing.T) {
+	testcases := []struct {
+		name              string
+		alreadyReady      bool
+		expect            func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder)
+		expectedCondition *corev1.ConditionStatus
+		expectedError     string
+	}{
+		{
+			name: "identity holds all required actions",
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				expectResourceGroups(s, "my-rg", "my-rg", "my-rg")
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return([]*armauthorization.Permission{
+					{Actions: []*string{ptr.To("*")}},
+				}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name: "identity is missing required actions",
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				expectResourceGroups(s, "my-rg", "my-rg", "my-rg")
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return([]*armauthorization.Permission{
+					{
+						Actions:    []*string{ptr.To("Microsoft.Network/*"), ptr.To("Microsoft.Compute/*"), ptr.To("Microsoft.Authorization/*")},
+						NotActions: []*string{ptr.To("Microsoft.Network/loadBalancers/*")},
+					},
+				}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedError:     "identity is missing permissions on resource group my-rg: Microsoft.Network/loadBalancers/write",
+		},
+		{
+			name: "identity is missing role assignment action",
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				expectResourceGroups(s, "my-rg", "my-rg", "my-rg")
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return([]*armauthorization.Permission{
+					{Actions: []*string{ptr.To("*")}, NotActions: []*string{ptr.To("Microsoft.Authorization/*/write")}},
+				}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedError:     "identity is missing permissions on resource group my-rg: Microsoft.Authorization/roleAssignments/write",
+		},
+		{
+			name:         "condition is already true and permissions were revoked",
+			alreadyReady: true,
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				expectResourceGroups(s, "my-rg", "my-rg", "my-rg")
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedError:     "identity is missing permissions on resource group my-rg: " + strings.Join(RequiredActions, ", "),
+		},
+		{
+			name: "node and vnet resource groups are checked",
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				expectResourceGroups(s, "my-rg", "my-node-rg", "my-vnet-rg")
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return([]*armauthorization.Permission{
+					{Actions: []*string{ptr.To("*")}},
+				}, nil)
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-node-rg").Return([]*armauthorization.Permission{
+					{Actions: []*string{ptr.To("*")}},
+				}, nil)
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-vnet-rg").Return([]*armauthorization.Permission{
+					{Actions: []*string{ptr.To("Microsoft.Compute/*")}},
+				}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedError: "identity is missing permissions on resource group my-vnet-rg: Microsoft.Network/virtualNetworks/write, " +
+				"Microsoft.Network/virtualNetworks/subnets/write, Microsoft.Network/networkSecurityGroups/write, Microsoft.Network/routeTables/write, " +
+				"Microsoft.Network/publicIPAddresses/write, Microsoft.Network/loadBalancers/write, Microsoft.Network/networkInterfaces/write, " +
+				"Microsoft.Authorization/roleAssignments/write",
+		},
+		{
+			name: "resource group of an unmanaged vnet is not checked",
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				s.ResourceGroup().Return("my-rg")
+				s.NodeResourceGroup().Return("my-rg")
+				s.IsVnetManaged().Return(false)
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return([]*armauthorization.Permission{
+					{Actions: []*string{ptr.To("*")}},
+				}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name: "resource group not found and identity holds the required actions on the subscription",
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				expectResourceGroups(s, "my-rg", "my-rg", "my-rg")
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound})
+				m.ListForSubscription(gomockinternal.AContext()).Return([]*armauthorization.Permission{
+					{Actions: []*string{ptr.To("*")}},
+				}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name: "resource group not found and identity is missing actions on the subscription",
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				expectResourceGroups(s, "my-rg", "my-rg", "my-rg")
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound})
+				m.ListForSubscription(gomockinternal.AContext()).Return([]*armauthorization.Permission{
+					{Actions: []*string{ptr.To("*")}, NotActions: []*string{ptr.To("Microsoft.Resources/*")}},
+				}, nil)
+				s.SubscriptionID().Return("123")
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedError:     "identity is missing permissions on subscription 123: Microsoft.Resources/subscriptions/resourceGroups/write",
+		},
+		{
+			name: "API error",
+			expect: func(s *mock_permissions.MockPermissionsScopeMockRecorder, m *mock_permissions.MockclientMockRecorder) {
+				expectResourceGroups(s, "my-rg", "my-rg", "my-rg")
+				m.ListForResourceGroup(gomockinternal.AContext(), "my-rg").Return(nil, errors.New("some API error"))
+			},
+			expectedError: "failed to list permissions for resource group my-rg: some API error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_permissions.NewMockPermissionsScope(mockCtrl)
+			clientMock := mock_permissions.NewMockclient(mockCtrl)
+
+			cluster := &infrav1.AzureCluster{}
+			if tc.alreadyReady {
+				conditions.MarkTrue(cluster, infrav1.IdentityPermissionsReadyCondition)
+			}
+			scopeMock.EXPECT().IdentityPermissionsResource().Return(cluster)
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			cond := conditions.Get(cluster, infrav1.IdentityPermissionsReadyCondition)
+			if tc.expectedCondition == nil {
+				g.Expect(cond).To(BeNil())
+			} else {
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(*tc.expectedCondition))
+			}
+		})
+	}
+}
+
+func expectResourceGroups(s *mock_permissions.MockPermissionsScopeMockRecorder, resourceGroup, nodeResourceGroup, vnetResourceGroup string) {
+	s.ResourceGroup().Return(resourceGroup)
+	s.NodeResourceGroup().Return(nodeResourceGroup)
+	s.IsVnetManaged().Return(true)
+	s.Vnet().Return(&infrav1.VnetSpec{ResourceGroup: vnetResourceGroup})
+}
+
+func TestMissingActions(t *testing.T) {
+	testcases := []struct {
+		name        string
+		permissions []*armauthorization.Permission
+		expected    []string
+	}{
+		{
+			name:        "no permissions",
+			permissions: nil,
+			expected:    []string{"Microsoft.Network/virtualNetworks/write", "Microsoft.Compute/virtualMachines/write"},
+		},
+		{
+			name: "exact and case-insensitive matches",
+			permissions: []*armauthorization.Permission{
+				{Actions: []*string{ptr.To("microsoft.network/virtualnetworks/write"), ptr.To("Microsoft.Compute/virtualMachines/write")}},
+			},
+			expected: nil,
+		},
+		{
+			name: "not actions only exclude actions of the same permission",
+			permissions: []*armauthorization.Permission{
+				{Actions: []*string{ptr.To("*")}, NotActions: []*string{ptr.To("Microsoft.Compute/*")}},
+				{Actions: []*string{ptr.To("Microsoft.Compute/virtualMachines/*")}},
+			},
+			expected: nil,
+		},
+		{
+			name: "not actions exclude wildcard actions",
+			permissions: []*armauthorization.Permission{
+				{Actions: []*string{ptr.To("*/write")}, NotActions: []*string{ptr.To("Microsoft.Compute/*")}},
+			},
+			expected: []string{"Microsoft.Compute/virtualMachines/write"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			actions := []string{"Microsoft.Network/virtualNetworks/write", "Microsoft.Compute/virtualMachines/write"}
+			g.Expect(MissingActions(tc.permissions, actions)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -44,9 +45,20 @@ func newRecordSetsClient(auth azure.Authorizer) (*azureRecordsClient, error) {
 	return &azureRecordsClient{factory.NewRecordSetsClient()}, nil
 }
 
-// Get gets the specified record set. Noop for records.
+// Get gets the specified record set.
 func (arc *azureRecordsClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
-	return nil, nil
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureRecordsClient.Get")
+	defer done()
+
+	recordSpec, ok := spec.(RecordSpec)
+	if !ok {
+		return nil, errors.Errorf("%T is not a privatedns.RecordSpec", spec)
+	}
+	resp, err := arc.recordsets.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), converters.GetRecordType(recordSpec.Record.IP), spec.ResourceName(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.RecordSet, nil
 }
 
 // CreateOrUpdateAsync creates or updates a record asynchronously.
@@ -72,7 +84,9 @@ func (arc *azureRecordsClient) CreateOrUpdateAsync(ctx context.Context, spec azu
 		recordType = armprivatedns.RecordTypeAAAA
 	}
 
-	recordSet, err := arc.recordsets.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordType, spec.ResourceName(), set, nil)
+	// The etag of an existing record set is sent to only update it if it wasn't modified since it was read.
+	opts := &armprivatedns.RecordSetsClientCreateOrUpdateOptions{IfMatch: set.Etag}
+	recordSet, err := arc.recordsets.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordType, spec.ResourceName(), set, opts)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -26,6 +26,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
+// recordTTL is the time to live of the record sets, in seconds.
+const recordTTL = 300
+
 // RecordSpec defines the specification for a record set.
 type RecordSpec struct {
 	Record        infrav1.AddressRecord
@@ -50,16 +53,23 @@ func (s RecordSpec) ResourceGroupName() string {
 
 // Parameters returns the parameters for a record set.
 func (s RecordSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
-	if existing != nil {
-		if _, ok := existing.(armprivatedns.RecordSet); !ok {
-			return nil, errors.Errorf("%T is not an armprivatedns.RecordSet", existing)
-		}
-	}
 	set := armprivatedns.RecordSet{
 		Properties: &armprivatedns.RecordSetProperties{
-			TTL: ptr.To[int64](300),
+			TTL: ptr.To[int64](recordTTL),
 		},
 	}
+	if existing != nil {
+		existingSet, ok := existing.(armprivatedns.RecordSet)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armprivatedns.RecordSet", existing)
+		}
+		if s.isUpToDate(existingSet) {
+			// record set already exists with the desired address
+			return nil, nil
+		}
+		// We append the existing record set etag to the header to ensure we only apply the updates if the record set has not been modified.
+		set.Etag = existingSet.Etag
+	}
 	recordType := converters.GetRecordType(s.Record.IP)
 	switch recordType {
 	case armprivatedns.RecordTypeA:
@@ -76,3 +86,19 @@ func (s RecordSpec) Parameters(ctx context.Context, existing interface{}) (param
 
 	return set, nil
 }
+
+// isUpToDate returns true if the record set has the desired TTL and only the address of the record.
+func (s RecordSpec) isUpToDate(existing armprivatedns.RecordSet) bool {
+	props := existing.Properties
+	if props == nil || ptr.Deref(props.TTL, 0) != recordTTL {
+		return false
+	}
+	switch converters.GetRecordType(s.Record.IP) {
+	case armprivatedns.RecordTypeA:
+		return len(props.ARecords) == 1 && ptr.Deref(props.ARecords[0].IPv4Address, "") == s.Record.IP
+	case armprivatedns.RecordTypeAAAA:
+		return len(props.AaaaRecords) == 1 && ptr.Deref(props.AaaaRecords[0].IPv6Address, "") == s.Record.IP
+	default:
+		return false
+	}
+}
@@ -97,6 +97,52 @@ func TestRecordSpec_Parameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name:          "existing private dns record is up to date",
+			expectedError: "",
+			spec:          recordSpec,
+			existing: armprivatedns.RecordSet{
+				Etag: ptr.To("etag"),
+				Properties: &armprivatedns.RecordSetProperties{
+					TTL:      ptr.To[int64](300),
+					ARecords: []*armprivatedns.ARecord{{IPv4Address: ptr.To("10.0.0.8")}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing private dns record with another address is updated if not modified",
+			expectedError: "",
+			spec:          recordSpec,
+			existing: armprivatedns.RecordSet{
+				Etag: ptr.To("etag"),
+				Properties: &armprivatedns.RecordSetProperties{
+					TTL:      ptr.To[int64](300),
+					ARecords: []*armprivatedns.ARecord{{IPv4Address: ptr.To("10.0.0.9")}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armprivatedns.RecordSet{
+					Etag: ptr.To("etag"),
+					Properties: &armprivatedns.RecordSetProperties{
+						TTL: ptr.To[int64](300),
+						ARecords: []*armprivatedns.ARecord{
+							{
+								IPv4Address: ptr.To("10.0.0.8"),
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:          "existing resource is not a record set",
+			expectedError: "string is not an armprivatedns.RecordSet",
+			spec:          recordSpec,
+			existing:      "not a record set",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "privatelinkservices"
+
+// PrivateLinkServiceScope defines the scope interface for a private link services service.
+type PrivateLinkServiceScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	PrivateLinkServiceSpec() azure.ResourceSpecGetter
+	SetAPIServerPrivateLinkServiceAlias(alias string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PrivateLinkServiceScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope PrivateLinkServiceScope) (*Service, error) {
+	client, err := newClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope: scope,
+		Reconciler: async.New[armnetwork.PrivateLinkServicesClientCreateOrUpdateResponse,
+			armnetwork.PrivateLinkServicesClientDeleteResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the private link service of the API server load balancer, and records
+// its alias.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatelinkservices.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceReconcileTimeout(s.Name()))
+	defer cancel()
+
+	spec := s.Scope.PrivateLinkServiceSpec()
+	if spec == nil {
+		return nil
+	}
+
+	result, err := s.CreateOrUpdateResource(ctx, spec, ServiceName)
+	s.Scope.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, err)
+	if err == nil && result != nil {
+		pls, ok := result.(armnetwork.PrivateLinkService)
+		if !ok {
+			return errors.Errorf("%T is not an armnetwork.PrivateLinkService", result)
+		}
+		if pls.Properties != nil {
+			s.Scope.SetAPIServerPrivateLinkServiceAlias(ptr.Deref(pls.Properties.Alias, ""))
+		}
+	}
+	return err
+}
+
+// Delete deletes the private link service.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatelinkservices.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceDeleteTimeout(s.Name()))
+	defer cancel()
+
+	spec := s.Scope.PrivateLinkServiceSpec()
+	if spec == nil {
+		return nil
+	}
+
+	err := s.DeleteResource(ctx, spec, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, err)
+	return err
+}
+
+// IsManaged always returns true as the spec only describes the private link service created by CAPZ.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
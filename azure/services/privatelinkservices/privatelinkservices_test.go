@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices/mock_privatelinkservices"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+var (
+	fakePrivateLinkServiceSpec = PrivateLinkServiceSpec{
+		Name:                      "my-cluster-apiserver-pls",
+		ResourceGroup:             "my-rg",
+		Location:                  "eastus",
+		ClusterName:               "my-cluster",
+		FrontendIPConfigID:        "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster-internal-lb/frontendIPConfigurations/my-cluster-internal-lb-frontEnd",
+		SubnetID:                  "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-cluster-controlplane-subnet",
+		AllowedSubscriptions:      []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"},
+		AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+	}
+	fakePrivateLinkService = armnetwork.PrivateLinkService{
+		ID:   ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateLinkServices/my-cluster-apiserver-pls"),
+		Name: ptr.To("my-cluster-apiserver-pls"),
+		Properties: &armnetwork.PrivateLinkServiceProperties{
+			Alias: ptr.To("my-cluster-apiserver-pls.00000000-0000-0000-0000-000000000000.eastus.azure.privatelinkservice"),
+		},
+	}
+	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func internalError() *azcore.ResponseError {
+	return &azcore.ResponseError{
+		RawResponse: &http.Response{
+			Body:       io.NopCloser(strings.NewReader("#: Internal Server Error: StatusCode=500")),
+			StatusCode: http.StatusInternalServerError,
+		},
+	}
+}
+
+func TestReconcilePrivateLinkService(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no private link service spec is found",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create private link service and record its alias",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(fakePrivateLinkService, nil)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, nil)
+				s.SetAPIServerPrivateLinkServiceAlias("my-cluster-apiserver-pls.00000000-0000-0000-0000-000000000000.eastus.azure.privatelinkservice")
+			},
+		},
+		{
+			name:          "private link service is still provisioning",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "error creating the private link service",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(nil, internalError())
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, internalError())
+			},
+		},
+		{
+			name:          "result is not a private link service",
+			expectedError: "string is not an armnetwork.PrivateLinkService",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return("not a private link service", nil)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatelinkservices.NewMockPrivateLinkServiceScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePrivateLinkService(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no private link service spec is found",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete private link service",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "error deleting the private link service",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(internalError())
+				s.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatelinkservices.NewMockPrivateLinkServiceScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
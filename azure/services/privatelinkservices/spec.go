@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PrivateLinkServiceSpec defines the specification for a private link service.
+type PrivateLinkServiceSpec struct {
+	Name                      string
+	ResourceGroup             string
+	Location                  string
+	ClusterName               string
+	FrontendIPConfigID        string
+	SubnetID                  string
+	AllowedSubscriptions      []string
+	AutoApprovedSubscriptions []string
+	AdditionalTags            infrav1.Tags
+}
+
+// ResourceName returns the name of the private link service.
+func (s *PrivateLinkServiceSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateLinkServiceSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for private link services.
+func (s *PrivateLinkServiceSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the private link service.
+func (s *PrivateLinkServiceSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingPLS, ok := existing.(armnetwork.PrivateLinkService)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.PrivateLinkService", existing)
+		}
+		// Only the subscriptions of a private link service are updated, as its load balancer and subnet are immutable.
+		if existingPLS.Properties == nil {
+			return nil, nil
+		}
+		var allowed, autoApproved []*string
+		if existingPLS.Properties.Visibility != nil {
+			allowed = existingPLS.Properties.Visibility.Subscriptions
+		}
+		if existingPLS.Properties.AutoApproval != nil {
+			autoApproved = existingPLS.Properties.AutoApproval.Subscriptions
+		}
+		if subscriptionsEqual(allowed, s.AllowedSubscriptions) && subscriptionsEqual(autoApproved, s.AutoApprovedSubscriptions) {
+			return nil, nil
+		}
+		existingPLS.Properties.Visibility = s.visibility()
+		existingPLS.Properties.AutoApproval = s.autoApproval()
+		return existingPLS, nil
+	}
+
+	return armnetwork.PrivateLinkService{
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.PrivateLinkServiceProperties{
+			LoadBalancerFrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{
+				{ID: ptr.To(s.FrontendIPConfigID)},
+			},
+			IPConfigurations: []*armnetwork.PrivateLinkServiceIPConfiguration{
+				{
+					Name: ptr.To("default"),
+					Properties: &armnetwork.PrivateLinkServiceIPConfigurationProperties{
+						Primary:                   ptr.To(true),
+						PrivateIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodDynamic),
+						PrivateIPAddressVersion:   ptr.To(armnetwork.IPVersionIPv4),
+						Subnet:                    &armnetwork.Subnet{ID: ptr.To(s.SubnetID)},
+					},
+				},
+			},
+			Visibility:   s.visibility(),
+			AutoApproval: s.autoApproval(),
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
+
+// visibility returns the subscriptions in which private endpoints can connect to the private link service, or nil to
+// only allow the subscription of the private link service.
+func (s *PrivateLinkServiceSpec) visibility() *armnetwork.PrivateLinkServicePropertiesVisibility {
+	if len(s.AllowedSubscriptions) == 0 {
+		return nil
+	}
+	return &armnetwork.PrivateLinkServicePropertiesVisibility{Subscriptions: azure.PtrSlice(&s.AllowedSubscriptions)}
+}
+
+// autoApproval returns the subscriptions whose private endpoint connections are approved automatically.
+func (s *PrivateLinkServiceSpec) autoApproval() *armnetwork.PrivateLinkServicePropertiesAutoApproval {
+	if len(s.AutoApprovedSubscriptions) == 0 {
+		return nil
+	}
+	return &armnetwork.PrivateLinkServicePropertiesAutoApproval{Subscriptions: azure.PtrSlice(&s.AutoApprovedSubscriptions)}
+}
+
+// subscriptionsEqual returns true if the subscriptions of an existing private link service are the desired ones.
+// Azure may return the subscriptions in another order or case than the ones it was given.
+func subscriptionsEqual(existing []*string, desired []string) bool {
+	existingSet := sets.New[string]()
+	for _, e := range existing {
+		existingSet.Insert(strings.ToLower(ptr.Deref(e, "")))
+	}
+	desiredSet := sets.New[string]()
+	for _, d := range desired {
+		desiredSet.Insert(strings.ToLower(d))
+	}
+	return existingSet.Equal(desiredSet)
+}
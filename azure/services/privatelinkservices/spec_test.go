@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func existingPrivateLinkService(allowed, autoApproved []*string) armnetwork.PrivateLinkService {
+	pls := armnetwork.PrivateLinkService{
+		ID:       fakePrivateLinkService.ID,
+		Name:     fakePrivateLinkService.Name,
+		Location: ptr.To("eastus"),
+		Properties: &armnetwork.PrivateLinkServiceProperties{
+			LoadBalancerFrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{
+				{ID: ptr.To(fakePrivateLinkServiceSpec.FrontendIPConfigID)},
+			},
+			Alias: fakePrivateLinkService.Properties.Alias,
+		},
+	}
+	if allowed != nil {
+		pls.Properties.Visibility = &armnetwork.PrivateLinkServicePropertiesVisibility{Subscriptions: allowed}
+	}
+	if autoApproved != nil {
+		pls.Properties.AutoApproval = &armnetwork.PrivateLinkServicePropertiesAutoApproval{Subscriptions: autoApproved}
+	}
+	return pls
+}
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *PrivateLinkServiceSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new private link service",
+			spec:     &fakePrivateLinkServiceSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.PrivateLinkService{}))
+				pls := result.(armnetwork.PrivateLinkService)
+				g.Expect(pls.Location).To(Equal(ptr.To("eastus")))
+				g.Expect(pls.Properties.LoadBalancerFrontendIPConfigurations).To(HaveLen(1))
+				g.Expect(pls.Properties.LoadBalancerFrontendIPConfigurations[0].ID).To(Equal(ptr.To(fakePrivateLinkServiceSpec.FrontendIPConfigID)))
+				g.Expect(pls.Properties.IPConfigurations).To(HaveLen(1))
+				g.Expect(pls.Properties.IPConfigurations[0].Properties.Subnet.ID).To(Equal(ptr.To(fakePrivateLinkServiceSpec.SubnetID)))
+				g.Expect(pls.Properties.IPConfigurations[0].Properties.PrivateIPAllocationMethod).To(Equal(ptr.To(armnetwork.IPAllocationMethodDynamic)))
+				g.Expect(pls.Properties.Visibility.Subscriptions).To(HaveExactElements(
+					ptr.To("00000000-0000-0000-0000-000000000001"), ptr.To("00000000-0000-0000-0000-000000000002")))
+				g.Expect(pls.Properties.AutoApproval.Subscriptions).To(HaveExactElements(ptr.To("00000000-0000-0000-0000-000000000001")))
+				g.Expect(pls.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", ptr.To("owned")))
+			},
+		},
+		{
+			name: "new private link service without subscriptions",
+			spec: &PrivateLinkServiceSpec{
+				Name:               fakePrivateLinkServiceSpec.Name,
+				ResourceGroup:      fakePrivateLinkServiceSpec.ResourceGroup,
+				Location:           fakePrivateLinkServiceSpec.Location,
+				ClusterName:        fakePrivateLinkServiceSpec.ClusterName,
+				FrontendIPConfigID: fakePrivateLinkServiceSpec.FrontendIPConfigID,
+				SubnetID:           fakePrivateLinkServiceSpec.SubnetID,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.PrivateLinkService{}))
+				pls := result.(armnetwork.PrivateLinkService)
+				g.Expect(pls.Properties.Visibility).To(BeNil())
+				g.Expect(pls.Properties.AutoApproval).To(BeNil())
+			},
+		},
+		{
+			name: "existing private link service with the same subscriptions is not updated",
+			spec: &fakePrivateLinkServiceSpec,
+			existing: existingPrivateLinkService(
+				[]*string{ptr.To("00000000-0000-0000-0000-000000000001"), ptr.To("00000000-0000-0000-0000-000000000002")},
+				[]*string{ptr.To("00000000-0000-0000-0000-000000000001")}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing private link service with the same subscriptions in another order and case is not updated",
+			spec: &fakePrivateLinkServiceSpec,
+			existing: existingPrivateLinkService(
+				[]*string{ptr.To("00000000-0000-0000-0000-000000000002"), ptr.To("00000000-0000-0000-0000-000000000001")},
+				[]*string{ptr.To("00000000-0000-0000-0000-000000000001")}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "existing private link service with different subscriptions is updated",
+			spec:     &fakePrivateLinkServiceSpec,
+			existing: existingPrivateLinkService([]*string{ptr.To("00000000-0000-0000-0000-000000000001")}, nil),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.PrivateLinkService{}))
+				pls := result.(armnetwork.PrivateLinkService)
+				g.Expect(pls.ID).To(Equal(fakePrivateLinkService.ID))
+				g.Expect(pls.Properties.LoadBalancerFrontendIPConfigurations).To(HaveLen(1))
+				g.Expect(pls.Properties.Visibility.Subscriptions).To(HaveExactElements(
+					ptr.To("00000000-0000-0000-0000-000000000001"), ptr.To("00000000-0000-0000-0000-000000000002")))
+				g.Expect(pls.Properties.AutoApproval.Subscriptions).To(HaveExactElements(ptr.To("00000000-0000-0000-0000-000000000001")))
+			},
+		},
+		{
+			name:     "error when existing is not a private link service",
+			spec:     &fakePrivateLinkServiceSpec,
+			existing: "not a private link service",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "string is not an armnetwork.PrivateLinkService",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestSubscriptionsEqual(t *testing.T) {
+	testcases := []struct {
+		name     string
+		existing []*string
+		desired  []string
+		expected bool
+	}{
+		{
+			name:     "both empty",
+			expected: true,
+		},
+		{
+			name:     "same subscriptions in another order and case",
+			existing: []*string{ptr.To("BBBBBBBB-0000-0000-0000-000000000000"), ptr.To("aaaaaaaa-0000-0000-0000-000000000000")},
+			desired:  []string{"AAAAAAAA-0000-0000-0000-000000000000", "bbbbbbbb-0000-0000-0000-000000000000"},
+			expected: true,
+		},
+		{
+			name:     "missing subscription",
+			existing: []*string{ptr.To("aaaaaaaa-0000-0000-0000-000000000000")},
+			desired:  []string{"aaaaaaaa-0000-0000-0000-000000000000", "bbbbbbbb-0000-0000-0000-000000000000"},
+			expected: false,
+		},
+		{
+			name:     "different subscription",
+			existing: []*string{ptr.To("aaaaaaaa-0000-0000-0000-000000000000")},
+			desired:  []string{"bbbbbbbb-0000-0000-0000-000000000000"},
+			expected: false,
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			g.Expect(subscriptionsEqual(tc.existing, tc.desired)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -39,6 +39,7 @@ type PublicIPSpec struct {
 	FailureDomains   []*string
 	AdditionalTags   infrav1.Tags
 	IPTags           []infrav1.IPTag
+	PublicIPPrefixID *string
 }
 
 // ResourceName returns the name of the public IP.
@@ -76,8 +77,16 @@ func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (pa
 	if s.DNSName != "" {
 		dnsSettings = &armnetwork.PublicIPAddressDNSSettings{
 			DomainNameLabel: ptr.To(strings.Split(s.DNSName, ".")[0]),
-			Fqdn:            ptr.To(s.DNSName),
 		}
+		// a DNS name without a domain is a bare DNS name label, the FQDN is then computed by Azure.
+		if strings.Contains(s.DNSName, ".") {
+			dnsSettings.Fqdn = ptr.To(s.DNSName)
+		}
+	}
+
+	var publicIPPrefix *armnetwork.SubResource
+	if s.PublicIPPrefixID != nil {
+		publicIPPrefix = &armnetwork.SubResource{ID: s.PublicIPPrefixID}
 	}
 
 	return armnetwork.PublicIPAddress{
@@ -96,6 +105,7 @@ func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (pa
 			PublicIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodStatic),
 			DNSSettings:              dnsSettings,
 			IPTags:                   converters.IPTagsToSDK(s.IPTags),
+			PublicIPPrefix:           publicIPPrefix,
 		},
 		Zones: s.FailureDomains,
 	}, nil
@@ -50,6 +50,20 @@ var (
 		FailureDomains: []*string{ptr.To("failure-domain-id-1"), ptr.To("failure-domain-id-2"), ptr.To("failure-domain-id-3")},
 	}
 
+	fakePublicIPSpecWithDNSLabel = PublicIPSpec{
+		Name:        "my-publicip-3",
+		DNSName:     "fakelabel",
+		Location:    "centralIndia",
+		ClusterName: "my-cluster",
+	}
+
+	fakePublicIPSpecWithPrefix = PublicIPSpec{
+		Name:             "my-publicip-4",
+		Location:         "centralIndia",
+		ClusterName:      "my-cluster",
+		PublicIPPrefixID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+	}
+
 	fakePublicIPWithDNS = armnetwork.PublicIPAddress{
 		Name:     ptr.To("my-publicip"),
 		SKU:      &armnetwork.PublicIPAddressSKU{Name: ptr.To(armnetwork.PublicIPAddressSKUNameStandard)},
@@ -86,6 +100,40 @@ var (
 		Zones: []*string{ptr.To("failure-domain-id-1"), ptr.To("failure-domain-id-2"), ptr.To("failure-domain-id-3")},
 	}
 
+	fakePublicIPWithDNSLabel = armnetwork.PublicIPAddress{
+		Name:     ptr.To("my-publicip-3"),
+		SKU:      &armnetwork.PublicIPAddressSKU{Name: ptr.To(armnetwork.PublicIPAddressSKUNameStandard)},
+		Location: ptr.To("centralIndia"),
+		Tags: map[string]*string{
+			"Name": ptr.To("my-publicip-3"),
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+		},
+		Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+			PublicIPAddressVersion:   ptr.To(armnetwork.IPVersionIPv4),
+			PublicIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodStatic),
+			DNSSettings: &armnetwork.PublicIPAddressDNSSettings{
+				DomainNameLabel: ptr.To("fakelabel"),
+			},
+		},
+	}
+
+	fakePublicIPWithPrefix = armnetwork.PublicIPAddress{
+		Name:     ptr.To("my-publicip-4"),
+		SKU:      &armnetwork.PublicIPAddressSKU{Name: ptr.To(armnetwork.PublicIPAddressSKUNameStandard)},
+		Location: ptr.To("centralIndia"),
+		Tags: map[string]*string{
+			"Name": ptr.To("my-publicip-4"),
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+		},
+		Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+			PublicIPAddressVersion:   ptr.To(armnetwork.IPVersionIPv4),
+			PublicIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodStatic),
+			PublicIPPrefix: &armnetwork.SubResource{
+				ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+			},
+		},
+	}
+
 	fakePublicIPIpv6 = armnetwork.PublicIPAddress{
 		Name:     ptr.To("my-publicip-ipv6"),
 		SKU:      &armnetwork.PublicIPAddressSKU{Name: ptr.To(armnetwork.PublicIPAddressSKUNameStandard)},
@@ -136,6 +184,20 @@ func TestParameters(t *testing.T) {
 			expected:      fakePublicIPWithoutDNS,
 			expectedError: "",
 		},
+		{
+			name:          "public ipv4 address with dns label only",
+			existing:      nil,
+			spec:          fakePublicIPSpecWithDNSLabel,
+			expected:      fakePublicIPWithDNSLabel,
+			expectedError: "",
+		},
+		{
+			name:          "public ipv4 address from public IP prefix",
+			existing:      nil,
+			spec:          fakePublicIPSpecWithPrefix,
+			expected:      fakePublicIPWithPrefix,
+			expectedError: "",
+		},
 		{
 			name:          "public ipv6 address with dns",
 			existing:      nil,
@@ -17,12 +17,14 @@ limitations under the License.
 package resourceskus
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/pkg/errors"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
 // SKU is a thin layer over the Azure resource SKU API to better introspect capabilities.
@@ -75,6 +77,8 @@ const (
 	ConfidentialComputingType = "ConfidentialComputingType"
 	// CPUArchitectureType identifies the capability for cpu architecture.
 	CPUArchitectureType = "CpuArchitectureType"
+	// GPUs identifies the capability for the number of GPUs.
+	GPUs = "GPUs"
 )
 
 // HasCapability return true for a capability which can be either
@@ -138,6 +142,34 @@ func (s SKU) GetCapability(name string) (string, bool) {
 	return "", false
 }
 
+// amdGPUVMSizes matches the VM sizes with AMD GPUs: the NVv4, NGads V620 and ND MI300X v5 series.
+var amdGPUVMSizes = regexp.MustCompile(`(?i)^Standard_(NV\d+as_v4|NG\d+ads_V620_v1|ND\d+isr_MI300X_v5)$`)
+
+// GPUCount returns the number of GPUs of a VM size, or 0 if it has none.
+func (s SKU) GPUCount() int64 {
+	value, ok := s.GetCapability(GPUs)
+	if !ok {
+		return 0
+	}
+	count, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// GPUVendor returns the vendor of the GPUs of a VM size, i.e. azure.GPUVendorNVIDIA or azure.GPUVendorAMD, or an
+// empty string if it has no GPUs.
+func (s SKU) GPUVendor() string {
+	if s.GPUCount() == 0 {
+		return ""
+	}
+	if amdGPUVMSizes.MatchString(ptr.Deref(s.Name, "")) {
+		return azure.GPUVendorAMD
+	}
+	return azure.GPUVendorNVIDIA
+}
+
 // HasLocationCapability returns true if the provided resource supports the location capability.
 func (s SKU) HasLocationCapability(capabilityName, location, zone string) bool {
 	if s.LocationInfo == nil {
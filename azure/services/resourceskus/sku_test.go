@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+func TestSKUGPUs(t *testing.T) {
+	cases := map[string]struct {
+		name           string
+		gpus           *string
+		expectedCount  int64
+		expectedVendor string
+	}{
+		"no GPUs": {
+			name:           "Standard_D2s_v3",
+			expectedCount:  0,
+			expectedVendor: "",
+		},
+		"NVIDIA GPUs": {
+			name:           "Standard_NC24s_v3",
+			gpus:           ptr.To("4"),
+			expectedCount:  4,
+			expectedVendor: azure.GPUVendorNVIDIA,
+		},
+		"NVIDIA A10 GPUs": {
+			name:           "Standard_NV36ads_A10_v5",
+			gpus:           ptr.To("1"),
+			expectedCount:  1,
+			expectedVendor: azure.GPUVendorNVIDIA,
+		},
+		"AMD NVv4 GPUs": {
+			name:           "Standard_NV32as_v4",
+			gpus:           ptr.To("1"),
+			expectedCount:  1,
+			expectedVendor: azure.GPUVendorAMD,
+		},
+		"AMD V620 GPUs": {
+			name:           "Standard_NG32ads_V620_v1",
+			gpus:           ptr.To("1"),
+			expectedCount:  1,
+			expectedVendor: azure.GPUVendorAMD,
+		},
+		"AMD MI300X GPUs": {
+			name:           "Standard_ND96isr_MI300X_v5",
+			gpus:           ptr.To("8"),
+			expectedCount:  8,
+			expectedVendor: azure.GPUVendorAMD,
+		},
+		"invalid GPU count": {
+			name:           "Standard_NC6",
+			gpus:           ptr.To("one"),
+			expectedCount:  0,
+			expectedVendor: "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			sku := SKU{Name: ptr.To(tc.name)}
+			if tc.gpus != nil {
+				sku.Capabilities = []*armcompute.ResourceSKUCapabilities{{Name: ptr.To(GPUs), Value: tc.gpus}}
+			}
+			g.Expect(sku.GPUCount()).To(Equal(tc.expectedCount))
+			g.Expect(sku.GPUVendor()).To(Equal(tc.expectedVendor))
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeservers
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// bgpConnectionLister lists the BGP connections of a Route Server.
+type bgpConnectionLister interface {
+	List(ctx context.Context, resourceGroupName, routeServerName string) (result []armnetwork.BgpConnection, err error)
+}
+
+// azureBGPConnectionsClient contains the Azure go-sdk Clients for Route Server BGP connections.
+type azureBGPConnectionsClient struct {
+	bgpconnection  *armnetwork.VirtualHubBgpConnectionClient
+	bgpconnections *armnetwork.VirtualHubBgpConnectionsClient
+	apiCallTimeout time.Duration
+}
+
+var _ bgpConnectionLister = (*azureBGPConnectionsClient)(nil)
+
+// newBGPConnectionsClient creates a new Route Server BGP connections client from an authorizer.
+func newBGPConnectionsClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*azureBGPConnectionsClient, error) {
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create bgpconnections client options")
+	}
+	factory, err := armnetwork.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armnetwork client factory")
+	}
+	return &azureBGPConnectionsClient{factory.NewVirtualHubBgpConnectionClient(), factory.NewVirtualHubBgpConnectionsClient(), apiCallTimeout}, nil
+}
+
+// Get gets the specified Route Server BGP connection.
+func (ac *azureBGPConnectionsClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "routeservers.azureBGPConnectionsClient.Get")
+	defer done()
+
+	resp, err := ac.bgpconnection.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.BgpConnection, nil
+}
+
+// List returns all BGP connections of a Route Server.
+func (ac *azureBGPConnectionsClient) List(ctx context.Context, resourceGroupName, routeServerName string) (result []armnetwork.BgpConnection, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "routeservers.azureBGPConnectionsClient.List")
+	defer done()
+
+	var connections []armnetwork.BgpConnection
+	pager := ac.bgpconnections.NewListPager(resourceGroupName, routeServerName, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return connections, errors.Wrap(err, "could not iterate Route Server BGP connections")
+		}
+		for _, connection := range nextResult.Value {
+			connections = append(connections, *connection)
+		}
+	}
+
+	return connections, nil
+}
+
+// CreateOrUpdateAsync creates or updates a Route Server BGP connection asynchronously.
+// It sends a PUT request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureBGPConnectionsClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, parameters interface{}) (result interface{}, poller *runtime.Poller[armnetwork.VirtualHubBgpConnectionClientCreateOrUpdateResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "routeservers.azureBGPConnectionsClient.CreateOrUpdateAsync")
+	defer done()
+
+	params, ok := parameters.(armnetwork.BgpConnection)
+	if !ok && parameters != nil {
+		return nil, nil, errors.Errorf("%T is not an armnetwork.BgpConnection", parameters)
+	}
+
+	opts := &armnetwork.VirtualHubBgpConnectionClientBeginCreateOrUpdateOptions{ResumeToken: resumeToken}
+	poller, err = ac.bgpconnection.BeginCreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), params, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ac.apiCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	resp, err := poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// if an error occurs, return the poller.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, poller, err
+	}
+
+	// if the operation completed, return a nil poller
+	return resp.BgpConnection, nil, err
+}
+
+// DeleteAsync deletes a Route Server BGP connection asynchronously. DeleteAsync sends a DELETE
+// request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureBGPConnectionsClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armnetwork.VirtualHubBgpConnectionClientDeleteResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "routeservers.azureBGPConnectionsClient.DeleteAsync")
+	defer done()
+
+	opts := &armnetwork.VirtualHubBgpConnectionClientBeginDeleteOptions{ResumeToken: resumeToken}
+	poller, err = ac.bgpconnection.BeginDelete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ac.apiCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// if an error occurs, return the poller.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return poller, err
+	}
+
+	// if the operation completed, return a nil poller.
+	return nil, err
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeservers
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+)
+
+// BGPConnectionSpec defines the specification for a BGP connection of a Route Server to a peer.
+type BGPConnectionSpec struct {
+	Name            string
+	RouteServerName string
+	ResourceGroup   string
+	PeerIP          string
+	PeerASN         int64
+}
+
+// ResourceName returns the name of the BGP connection.
+func (s *BGPConnectionSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *BGPConnectionSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the Route Server.
+func (s *BGPConnectionSpec) OwnerResourceName() string {
+	return s.RouteServerName
+}
+
+// Parameters returns the parameters for the BGP connection.
+func (s *BGPConnectionSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingConnection, ok := existing.(armnetwork.BgpConnection)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.BgpConnection", existing)
+		}
+		if existingConnection.Properties != nil &&
+			ptr.Deref(existingConnection.Properties.PeerIP, "") == s.PeerIP &&
+			ptr.Deref(existingConnection.Properties.PeerAsn, 0) == s.PeerASN {
+			return nil, nil
+		}
+	}
+
+	return armnetwork.BgpConnection{
+		Name: ptr.To(s.Name),
+		Properties: &armnetwork.BgpConnectionProperties{
+			PeerIP:  ptr.To(s.PeerIP),
+			PeerAsn: ptr.To(s.PeerASN),
+		},
+	}, nil
+}
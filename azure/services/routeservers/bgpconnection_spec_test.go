@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeservers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestBGPConnectionSpec_Parameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *BGPConnectionSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new BGP connection",
+			spec:     fakePeer1,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(armnetwork.BgpConnection{
+					Name: ptr.To("nva-1"),
+					Properties: &armnetwork.BgpConnectionProperties{
+						PeerIP:  ptr.To("10.0.0.4"),
+						PeerAsn: ptr.To[int64](65001),
+					},
+				}))
+			},
+		},
+		{
+			name: "existing BGP connection is not updated",
+			spec: fakePeer1,
+			existing: armnetwork.BgpConnection{
+				Name: ptr.To("nva-1"),
+				Properties: &armnetwork.BgpConnectionProperties{
+					PeerIP:  ptr.To("10.0.0.4"),
+					PeerAsn: ptr.To[int64](65001),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing BGP connection is updated when the peer changes",
+			spec: fakePeer1,
+			existing: armnetwork.BgpConnection{
+				Name: ptr.To("nva-1"),
+				Properties: &armnetwork.BgpConnectionProperties{
+					PeerIP:  ptr.To("10.0.0.4"),
+					PeerAsn: ptr.To[int64](65002),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.BgpConnection{}))
+				g.Expect(result.(armnetwork.BgpConnection).Properties.PeerAsn).To(Equal(ptr.To[int64](65001)))
+			},
+		},
+		{
+			name:     "error when existing is not a BGP connection",
+			spec:     fakePeer1,
+			existing: "not a BGP connection",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "string is not an armnetwork.BgpConnection",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeservers
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+)
+
+// IPConfigSpec defines the specification for the IP configuration attaching a Route Server to its subnet.
+type IPConfigSpec struct {
+	Name            string
+	RouteServerName string
+	ResourceGroup   string
+	SubnetID        string
+	PublicIPID      string
+}
+
+// ResourceName returns the name of the IP configuration.
+func (s *IPConfigSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *IPConfigSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the Route Server.
+func (s *IPConfigSpec) OwnerResourceName() string {
+	return s.RouteServerName
+}
+
+// Parameters returns the parameters for the IP configuration.
+func (s *IPConfigSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(armnetwork.HubIPConfiguration); !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.HubIPConfiguration", existing)
+		}
+		// The IP configuration of a Route Server can't be updated.
+		return nil, nil
+	}
+
+	return armnetwork.HubIPConfiguration{
+		Name: ptr.To(s.Name),
+		Properties: &armnetwork.HubIPConfigurationPropertiesFormat{
+			Subnet:          &armnetwork.Subnet{ID: ptr.To(s.SubnetID)},
+			PublicIPAddress: &armnetwork.PublicIPAddress{ID: ptr.To(s.PublicIPID)},
+		},
+	}, nil
+}
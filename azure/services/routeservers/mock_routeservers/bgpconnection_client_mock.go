@@ -0,0 +1,72 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../bgpconnection_client.go
+//
+// Generated by this command:
+//
+//	mockgen -destination bgpconnection_client_mock.go -package mock_routeservers -source ../bgpconnection_client.go bgpConnectionLister
+//
+
+// Package mock_routeservers is a generated GoMock package.
+package mock_routeservers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	armnetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockbgpConnectionLister is a mock of bgpConnectionLister interface.
+type MockbgpConnectionLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockbgpConnectionListerMockRecorder
+}
+
+// MockbgpConnectionListerMockRecorder is the mock recorder for MockbgpConnectionLister.
+type MockbgpConnectionListerMockRecorder struct {
+	mock *MockbgpConnectionLister
+}
+
+// NewMockbgpConnectionLister creates a new mock instance.
+func NewMockbgpConnectionLister(ctrl *gomock.Controller) *MockbgpConnectionLister {
+	mock := &MockbgpConnectionLister{ctrl: ctrl}
+	mock.recorder = &MockbgpConnectionListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbgpConnectionLister) EXPECT() *MockbgpConnectionListerMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockbgpConnectionLister) List(ctx context.Context, resourceGroupName, routeServerName string) ([]armnetwork.BgpConnection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, resourceGroupName, routeServerName)
+	ret0, _ := ret[0].([]armnetwork.BgpConnection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockbgpConnectionListerMockRecorder) List(ctx, resourceGroupName, routeServerName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockbgpConnectionLister)(nil).List), ctx, resourceGroupName, routeServerName)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeservers
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// routeServerSKU is the only SKU of virtual hubs that Azure supports for Route Servers.
+const routeServerSKU = "Standard"
+
+// RouteServerSpec defines the specification for a Route Server.
+type RouteServerSpec struct {
+	Name                  string
+	ResourceGroup         string
+	Location              string
+	ClusterName           string
+	BranchToBranchTraffic bool
+	AdditionalTags        infrav1.Tags
+}
+
+// ResourceName returns the name of the Route Server.
+func (s *RouteServerSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *RouteServerSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for Route Servers.
+func (s *RouteServerSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the Route Server.
+func (s *RouteServerSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingRouteServer, ok := existing.(armnetwork.VirtualHub)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.VirtualHub", existing)
+		}
+		// Only branch-to-branch traffic can be changed on an existing Route Server.
+		if existingRouteServer.Properties == nil ||
+			ptr.Deref(existingRouteServer.Properties.AllowBranchToBranchTraffic, false) == s.BranchToBranchTraffic {
+			return nil, nil
+		}
+		existingRouteServer.Properties.AllowBranchToBranchTraffic = ptr.To(s.BranchToBranchTraffic)
+		return existingRouteServer, nil
+	}
+
+	return armnetwork.VirtualHub{
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.VirtualHubProperties{
+			SKU:                        ptr.To(routeServerSKU),
+			AllowBranchToBranchTraffic: ptr.To(s.BranchToBranchTraffic),
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
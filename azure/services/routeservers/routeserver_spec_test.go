@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeservers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestRouteServerSpec_Parameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *RouteServerSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new route server",
+			spec:     fakeRouteServer,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.VirtualHub{}))
+				routeServer := result.(armnetwork.VirtualHub)
+				g.Expect(routeServer.Location).To(Equal(ptr.To("eastus")))
+				g.Expect(routeServer.Properties.SKU).To(Equal(ptr.To("Standard")))
+				g.Expect(routeServer.Properties.AllowBranchToBranchTraffic).To(Equal(ptr.To(false)))
+				g.Expect(routeServer.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", ptr.To("owned")))
+			},
+		},
+		{
+			name: "existing route server is not updated",
+			spec: fakeRouteServer,
+			existing: armnetwork.VirtualHub{
+				Properties: &armnetwork.VirtualHubProperties{AllowBranchToBranchTraffic: ptr.To(false)},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing route server is updated when branch-to-branch traffic changes",
+			spec: &RouteServerSpec{
+				Name:                  routeServerName,
+				ResourceGroup:         resourceGroup,
+				Location:              "eastus",
+				ClusterName:           clusterName,
+				BranchToBranchTraffic: true,
+			},
+			existing: armnetwork.VirtualHub{
+				ID:         ptr.To(routeServerID),
+				Properties: &armnetwork.VirtualHubProperties{AllowBranchToBranchTraffic: ptr.To(false)},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.VirtualHub{}))
+				routeServer := result.(armnetwork.VirtualHub)
+				g.Expect(routeServer.ID).To(Equal(ptr.To(routeServerID)))
+				g.Expect(routeServer.Properties.AllowBranchToBranchTraffic).To(Equal(ptr.To(true)))
+			},
+		},
+		{
+			name:     "error when existing is not a virtual hub",
+			spec:     fakeRouteServer,
+			existing: "not a route server",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "string is not an armnetwork.VirtualHub",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestIPConfigSpec_Parameters(t *testing.T) {
+	g := NewWithT(t)
+
+	result, err := fakeIPConfig.Parameters(context.TODO(), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(BeAssignableToTypeOf(armnetwork.HubIPConfiguration{}))
+	ipConfig := result.(armnetwork.HubIPConfiguration)
+	g.Expect(ipConfig.Name).To(Equal(ptr.To("ipconfig1")))
+	g.Expect(ipConfig.Properties.Subnet.ID).To(Equal(ptr.To(fakeIPConfig.SubnetID)))
+	g.Expect(ipConfig.Properties.PublicIPAddress.ID).To(Equal(ptr.To(fakeIPConfig.PublicIPID)))
+
+	result, err = fakeIPConfig.Parameters(context.TODO(), armnetwork.HubIPConfiguration{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(BeNil())
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeservers
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "routeservers"
+
+// RouteServerScope defines the scope interface for a Route Server service.
+type RouteServerScope interface {
+	azure.ClusterDescriber
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	RouteServerSpecs() (routeServerSpec, ipConfigSpec azure.ResourceSpecGetter, bgpConnectionSpecs []azure.ResourceSpecGetter)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope                   RouteServerScope
+	TagsGetter              async.TagsGetter
+	bgpConnectionLister     bgpConnectionLister
+	routeServerReconciler   async.Reconciler
+	ipConfigReconciler      async.Reconciler
+	bgpConnectionReconciler async.Reconciler
+}
+
+// New creates a new Route Server service.
+func New(scope RouteServerScope) (*Service, error) {
+	routeServersClient, err := newRouteServersClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	ipConfigsClient, err := newIPConfigsClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	bgpConnectionsClient, err := newBGPConnectionsClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	tagsClient, err := tags.NewClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope:               scope,
+		TagsGetter:          tagsClient,
+		bgpConnectionLister: bgpConnectionsClient,
+		routeServerReconciler: async.New[armnetwork.VirtualHubsClientCreateOrUpdateResponse,
+			armnetwork.VirtualHubsClientDeleteResponse](scope, routeServersClient, routeServersClient),
+		ipConfigReconciler: async.New[armnetwork.VirtualHubIPConfigurationClientCreateOrUpdateResponse,
+			armnetwork.VirtualHubIPConfigurationClientDeleteResponse](scope, ipConfigsClient, ipConfigsClient),
+		bgpConnectionReconciler: async.New[armnetwork.VirtualHubBgpConnectionClientCreateOrUpdateResponse,
+			armnetwork.VirtualHubBgpConnectionClientDeleteResponse](scope, bgpConnectionsClient, bgpConnectionsClient),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile creates or updates the Route Server and its IP configuration, unless the Route Server is referenced
+// rather than managed, and then the BGP connections to its peers.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "routeservers.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceReconcileTimeout(s.Name()))
+	defer cancel()
+
+	routeServerSpec, ipConfigSpec, bgpConnectionSpecs := s.Scope.RouteServerSpecs()
+	if routeServerSpec == nil {
+		return nil
+	}
+
+	managed, err := s.IsManaged(ctx)
+	if err != nil {
+		if !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "could not get Route Server state of %s in resource group %s",
+				routeServerSpec.ResourceName(), routeServerSpec.ResourceGroupName())
+		}
+		managed = true
+	}
+
+	if managed {
+		_, err = s.routeServerReconciler.CreateOrUpdateResource(ctx, routeServerSpec, ServiceName)
+		if err == nil {
+			_, err = s.ipConfigReconciler.CreateOrUpdateResource(ctx, ipConfigSpec, ServiceName)
+		}
+	} else {
+		log.V(1).Info("Skipping reconciliation of unmanaged Route Server", "route server", routeServerSpec.ResourceName())
+	}
+	if err == nil {
+		err = s.reconcileBGPConnections(ctx, routeServerSpec, bgpConnectionSpecs, managed)
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.RouteServerReadyCondition, ServiceName, err)
+	return err
+}
+
+// reconcileBGPConnections creates or updates the BGP connections of the Route Server. The BGP connections of a managed
+// Route Server that are no longer specified are deleted.
+func (s *Service) reconcileBGPConnections(ctx context.Context, routeServerSpec azure.ResourceSpecGetter, specs []azure.ResourceSpecGetter, managed bool) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "routeservers.Service.reconcileBGPConnections")
+	defer done()
+
+	var resErr error
+
+	// We go through the list of BGP connections to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	specified := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		specified[spec.ResourceName()] = true
+		if _, err := s.bgpConnectionReconciler.CreateOrUpdateResource(ctx, spec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	if !managed {
+		return resErr
+	}
+
+	stale, err := s.unspecifiedBGPConnections(ctx, routeServerSpec, specified)
+	if err != nil {
+		return err
+	}
+	if err := s.deleteBGPConnections(ctx, stale); err != nil {
+		if !azure.IsOperationNotDoneError(err) || resErr == nil {
+			resErr = err
+		}
+	}
+
+	return resErr
+}
+
+// unspecifiedBGPConnections returns the specs of the BGP connections of the Route Server whose names are not specified.
+func (s *Service) unspecifiedBGPConnections(ctx context.Context, routeServerSpec azure.ResourceSpecGetter, specified map[string]bool) ([]azure.ResourceSpecGetter, error) {
+	existing, err := s.bgpConnectionLister.List(ctx, routeServerSpec.ResourceGroupName(), routeServerSpec.ResourceName())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list BGP connections of Route Server %s", routeServerSpec.ResourceName())
+	}
+
+	var specs []azure.ResourceSpecGetter
+	for _, connection := range existing {
+		name := ptr.Deref(connection.Name, "")
+		if specified[name] {
+			continue
+		}
+		specs = append(specs, &BGPConnectionSpec{
+			Name:            name,
+			RouteServerName: routeServerSpec.ResourceName(),
+			ResourceGroup:   routeServerSpec.ResourceGroupName(),
+		})
+	}
+	return specs, nil
+}
+
+// Delete deletes the BGP connections of the Route Server, and the Route Server itself if it is managed.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "routeservers.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceDeleteTimeout(s.Name()))
+	defer cancel()
+
+	routeServerSpec, ipConfigSpec, bgpConnectionSpecs := s.Scope.RouteServerSpecs()
+	if routeServerSpec == nil {
+		return nil
+	}
+
+	managed, err := s.IsManaged(ctx)
+	if err != nil {
+		if azure.ResourceNotFound(err) {
+			// already deleted or doesn't exist, cleanup status and return.
+			s.Scope.DeleteLongRunningOperationState(routeServerSpec.ResourceName(), ServiceName, infrav1.DeleteFuture)
+			return nil
+		}
+		return errors.Wrapf(err, "could not get Route Server state of %s in resource group %s",
+			routeServerSpec.ResourceName(), routeServerSpec.ResourceGroupName())
+	}
+
+	if !managed {
+		// Only the BGP connections were created for the cluster on a referenced Route Server.
+		log.V(1).Info("Skipping deletion of unmanaged Route Server", "route server", routeServerSpec.ResourceName())
+		err = s.deleteBGPConnections(ctx, bgpConnectionSpecs)
+		s.Scope.UpdateDeleteStatus(infrav1.RouteServerReadyCondition, ServiceName, err)
+		return err
+	}
+
+	// Azure requires the BGP connections and the IP configuration to be deleted before the Route Server. All BGP
+	// connections of a managed Route Server are deleted, including those that are no longer specified.
+	connections, err := s.unspecifiedBGPConnections(ctx, routeServerSpec, nil)
+	if err == nil {
+		err = s.deleteBGPConnections(ctx, connections)
+	}
+	if err == nil {
+		err = s.ipConfigReconciler.DeleteResource(ctx, ipConfigSpec, ServiceName)
+	}
+	if err == nil {
+		err = s.routeServerReconciler.DeleteResource(ctx, routeServerSpec, ServiceName)
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.RouteServerReadyCondition, ServiceName, err)
+	return err
+}
+
+// deleteBGPConnections deletes the given BGP connections.
+func (s *Service) deleteBGPConnections(ctx context.Context, specs []azure.ResourceSpecGetter) error {
+	var resErr error
+
+	// We go through the list of BGP connections to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	for _, spec := range specs {
+		if err := s.bgpConnectionReconciler.DeleteResource(ctx, spec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	return resErr
+}
+
+// IsManaged returns true if the Route Server has an owned tag with the cluster name as value,
+// meaning that the Route Server lifecycle is managed.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	routeServerSpec, _, _ := s.Scope.RouteServerSpecs()
+	if routeServerSpec == nil {
+		return false, errors.Errorf("no Route Server spec available")
+	}
+
+	scope := azure.RouteServerID(s.Scope.SubscriptionID(), routeServerSpec.ResourceGroupName(), routeServerSpec.ResourceName())
+	result, err := s.TagsGetter.GetAtScope(ctx, scope)
+	if err != nil {
+		return false, err
+	}
+
+	tagsMap := make(map[string]*string)
+	if result.Properties != nil && result.Properties.Tags != nil {
+		tagsMap = result.Properties.Tags
+	}
+
+	tags := converters.MapToTags(tagsMap)
+	return tags.HasOwned(s.Scope.ClusterName()), nil
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeservers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routeservers/mock_routeservers"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+const (
+	routeServerName = "my-cluster-routeserver"
+	resourceGroup   = "my-vnet-rg"
+	clusterName     = "my-cluster"
+	subscriptionID  = "123"
+)
+
+var (
+	fakeRouteServer = &RouteServerSpec{
+		Name:          routeServerName,
+		ResourceGroup: resourceGroup,
+		Location:      "eastus",
+		ClusterName:   clusterName,
+	}
+	fakeIPConfig = &IPConfigSpec{
+		Name:            "ipconfig1",
+		RouteServerName: routeServerName,
+		ResourceGroup:   resourceGroup,
+		SubnetID:        azure.SubnetID(subscriptionID, resourceGroup, "my-vnet", "RouteServerSubnet"),
+		PublicIPID:      azure.PublicIPID(subscriptionID, "my-rg", "my-cluster-routeserver-pip"),
+	}
+	fakePeer1 = &BGPConnectionSpec{
+		Name:            "nva-1",
+		RouteServerName: routeServerName,
+		ResourceGroup:   resourceGroup,
+		PeerIP:          "10.0.0.4",
+		PeerASN:         65001,
+	}
+	fakePeer2 = &BGPConnectionSpec{
+		Name:            "nva-2",
+		RouteServerName: routeServerName,
+		ResourceGroup:   resourceGroup,
+		PeerIP:          "10.0.0.5",
+		PeerASN:         65001,
+	}
+	fakeStalePeer = &BGPConnectionSpec{
+		Name:            "nva-old",
+		RouteServerName: routeServerName,
+		ResourceGroup:   resourceGroup,
+	}
+
+	managedTags = armresources.TagsResource{
+		Properties: &armresources.Tags{
+			Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_" + clusterName: ptr.To("owned"),
+			},
+		},
+	}
+	unmanagedTags = armresources.TagsResource{
+		Properties: &armresources.Tags{
+			Tags: map[string]*string{
+				"foo": ptr.To("bar"),
+			},
+		},
+	}
+
+	routeServerID = azure.RouteServerID(subscriptionID, resourceGroup, routeServerName)
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{Type: "resourceType", ResourceGroup: resourceGroup, Name: "resourceName"})
+	errFake       = errors.New("this is an error")
+	notFoundError = &azcore.ResponseError{StatusCode: http.StatusNotFound}
+)
+
+func TestReconcileRouteServer(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+			lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder)
+	}{
+		{
+			name:          "no route server",
+			expectedError: "",
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(nil, nil, nil)
+			},
+		},
+		{
+			name:          "create route server with peers and delete stale peers",
+			expectedError: "",
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, []azure.ResourceSpecGetter{fakePeer1, fakePeer2}).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(armresources.TagsResource{}, notFoundError)
+				rs.CreateOrUpdateResource(gomockinternal.AContext(), fakeRouteServer, ServiceName).Return(nil, nil)
+				ip.CreateOrUpdateResource(gomockinternal.AContext(), fakeIPConfig, ServiceName).Return(nil, nil)
+				bgp.CreateOrUpdateResource(gomockinternal.AContext(), fakePeer1, ServiceName).Return(nil, nil)
+				bgp.CreateOrUpdateResource(gomockinternal.AContext(), fakePeer2, ServiceName).Return(nil, nil)
+				lister.List(gomockinternal.AContext(), resourceGroup, routeServerName).Return([]armnetwork.BgpConnection{
+					{Name: ptr.To("nva-1")}, {Name: ptr.To("nva-2")}, {Name: ptr.To("nva-old")},
+				}, nil)
+				bgp.DeleteResource(gomockinternal.AContext(), fakeStalePeer, ServiceName).Return(nil)
+				s.UpdatePutStatus(infrav1.RouteServerReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "route server creation in progress",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, []azure.ResourceSpecGetter{fakePeer1}).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(armresources.TagsResource{}, notFoundError)
+				rs.CreateOrUpdateResource(gomockinternal.AContext(), fakeRouteServer, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.RouteServerReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "only peers are reconciled on an unmanaged route server",
+			expectedError: "",
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, []azure.ResourceSpecGetter{fakePeer1, fakePeer2}).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(unmanagedTags, nil)
+				bgp.CreateOrUpdateResource(gomockinternal.AContext(), fakePeer1, ServiceName).Return(nil, nil)
+				bgp.CreateOrUpdateResource(gomockinternal.AContext(), fakePeer2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.RouteServerReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "error creating a peer is returned over a not done error",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, []azure.ResourceSpecGetter{fakePeer1, fakePeer2}).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(managedTags, nil)
+				rs.CreateOrUpdateResource(gomockinternal.AContext(), fakeRouteServer, ServiceName).Return(nil, nil)
+				ip.CreateOrUpdateResource(gomockinternal.AContext(), fakeIPConfig, ServiceName).Return(nil, nil)
+				bgp.CreateOrUpdateResource(gomockinternal.AContext(), fakePeer1, ServiceName).Return(nil, errFake)
+				bgp.CreateOrUpdateResource(gomockinternal.AContext(), fakePeer2, ServiceName).Return(nil, notDoneError)
+				lister.List(gomockinternal.AContext(), resourceGroup, routeServerName).Return([]armnetwork.BgpConnection{
+					{Name: ptr.To("nva-1")},
+				}, nil)
+				s.UpdatePutStatus(infrav1.RouteServerReadyCondition, ServiceName, errFake)
+			},
+		},
+		{
+			name:          "error getting route server tags",
+			expectedError: "could not get Route Server state of my-cluster-routeserver in resource group my-vnet-rg: this is an error",
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, nil).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(armresources.TagsResource{}, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_routeservers.NewMockRouteServerScope(mockCtrl)
+			routeServerReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			ipConfigReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			bgpConnectionReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			listerMock := mock_routeservers.NewMockbgpConnectionLister(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), routeServerReconcilerMock.EXPECT(), ipConfigReconcilerMock.EXPECT(),
+				bgpConnectionReconcilerMock.EXPECT(), listerMock.EXPECT(), tagsGetterMock.EXPECT())
+
+			s := &Service{
+				Scope:                   scopeMock,
+				TagsGetter:              tagsGetterMock,
+				bgpConnectionLister:     listerMock,
+				routeServerReconciler:   routeServerReconcilerMock,
+				ipConfigReconciler:      ipConfigReconcilerMock,
+				bgpConnectionReconciler: bgpConnectionReconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteRouteServer(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+			lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder)
+	}{
+		{
+			name:          "no route server",
+			expectedError: "",
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(nil, nil, nil)
+			},
+		},
+		{
+			name:          "route server already deleted",
+			expectedError: "",
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, []azure.ResourceSpecGetter{fakePeer1}).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(armresources.TagsResource{}, notFoundError)
+				s.DeleteLongRunningOperationState(routeServerName, ServiceName, infrav1.DeleteFuture)
+			},
+		},
+		{
+			name:          "delete managed route server with all its peers",
+			expectedError: "",
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, []azure.ResourceSpecGetter{fakePeer1}).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(managedTags, nil)
+				lister.List(gomockinternal.AContext(), resourceGroup, routeServerName).Return([]armnetwork.BgpConnection{
+					{Name: ptr.To("nva-old")},
+				}, nil)
+				bgp.DeleteResource(gomockinternal.AContext(), fakeStalePeer, ServiceName).Return(nil)
+				ip.DeleteResource(gomockinternal.AContext(), fakeIPConfig, ServiceName).Return(nil)
+				rs.DeleteResource(gomockinternal.AContext(), fakeRouteServer, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.RouteServerReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "route server is not deleted while peers are being deleted",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, nil).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(managedTags, nil)
+				lister.List(gomockinternal.AContext(), resourceGroup, routeServerName).Return([]armnetwork.BgpConnection{
+					{Name: ptr.To("nva-old")},
+				}, nil)
+				bgp.DeleteResource(gomockinternal.AContext(), fakeStalePeer, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.RouteServerReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "only peers are deleted from an unmanaged route server",
+			expectedError: "",
+			expect: func(s *mock_routeservers.MockRouteServerScopeMockRecorder, rs, ip, bgp *mock_async.MockReconcilerMockRecorder,
+				lister *mock_routeservers.MockbgpConnectionListerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.RouteServerSpecs().Return(fakeRouteServer, fakeIPConfig, []azure.ResourceSpecGetter{fakePeer1, fakePeer2}).Times(2)
+				s.SubscriptionID().Return(subscriptionID)
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), routeServerID).Return(unmanagedTags, nil)
+				bgp.DeleteResource(gomockinternal.AContext(), fakePeer1, ServiceName).Return(nil)
+				bgp.DeleteResource(gomockinternal.AContext(), fakePeer2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.RouteServerReadyCondition, ServiceName, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_routeservers.NewMockRouteServerScope(mockCtrl)
+			routeServerReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			ipConfigReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			bgpConnectionReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			listerMock := mock_routeservers.NewMockbgpConnectionLister(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), routeServerReconcilerMock.EXPECT(), ipConfigReconcilerMock.EXPECT(),
+				bgpConnectionReconcilerMock.EXPECT(), listerMock.EXPECT(), tagsGetterMock.EXPECT())
+
+			s := &Service{
+				Scope:                   scopeMock,
+				TagsGetter:              tagsGetterMock,
+				bgpConnectionLister:     listerMock,
+				routeServerReconciler:   routeServerReconcilerMock,
+				ipConfigReconciler:      ipConfigReconcilerMock,
+				bgpConnectionReconciler: bgpConnectionReconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
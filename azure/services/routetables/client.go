@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
@@ -31,6 +32,7 @@ import (
 // azureClient contains the Azure go-sdk Client.
 type azureClient struct {
 	routetables    *armnetwork.RouteTablesClient
+	auth           azure.Authorizer
 	apiCallTimeout time.Duration
 }
 
@@ -44,7 +46,7 @@ func newClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*azureClien
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create armnetwork client factory")
 	}
-	return &azureClient{factory.NewRouteTablesClient(), apiCallTimeout}, nil
+	return &azureClient{factory.NewRouteTablesClient(), auth, apiCallTimeout}, nil
 }
 
 // Get gets the specified route table.
@@ -71,8 +73,28 @@ func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 		return nil, nil, errors.Errorf("%T is not an armnetwork.RouteTable", parameters)
 	}
 
+	var extraPolicies []policy.Policy
+	if rt.Etag != nil {
+		extraPolicies = append(extraPolicies, azure.CustomPutPatchHeaderPolicy{
+			Headers: map[string]string{
+				"If-Match": *rt.Etag,
+			},
+		})
+	}
+
+	// Create a new client that knows how to add the etag header.
+	clientOpts, err := azure.ARMClientOptions(ac.auth.CloudEnvironment(), extraPolicies...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create routetables client options")
+	}
+	factory, err := armnetwork.NewClientFactory(ac.auth.SubscriptionID(), ac.auth.Token(), clientOpts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create armnetwork client factory")
+	}
+	client := factory.NewRouteTablesClient()
+
 	opts := &armnetwork.RouteTablesClientBeginCreateOrUpdateOptions{ResumeToken: resumeToken}
-	poller, err = ac.routetables.BeginCreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), rt, opts)
+	poller, err = client.BeginCreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), rt, opts)
 	if err != nil {
 		return nil, nil, err
 	}
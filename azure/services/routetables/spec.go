@@ -18,6 +18,7 @@ package routetables
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
@@ -32,6 +33,7 @@ type RouteTableSpec struct {
 	ResourceGroup  string
 	Location       string
 	ClusterName    string
+	Routes         []infrav1.Route
 	AdditionalTags infrav1.Tags
 }
 
@@ -52,17 +54,57 @@ func (s *RouteTableSpec) OwnerResourceName() string {
 
 // Parameters returns the parameters for the route table.
 func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	var routes []*armnetwork.Route
+	var etag *string
+
 	if existing != nil {
-		if _, ok := existing.(armnetwork.RouteTable); !ok {
+		existingRT, ok := existing.(armnetwork.RouteTable)
+		if !ok {
 			return nil, errors.Errorf("%T is not an armnetwork.RouteTable", existing)
 		}
 		// route table already exists
-		// currently don't support specifying your own routes via spec.
-		return nil, nil
+		// We append the existing route table etag to the header to ensure we only apply the updates if the route table has not been modified,
+		// as the cloud provider may add its own routes to it.
+		etag = existingRT.Etag
+		var existingRoutes []*armnetwork.Route
+		if existingRT.Properties != nil {
+			existingRoutes = existingRT.Properties.Routes
+		}
+
+		update := false
+		desired := make(map[string]*armnetwork.Route, len(s.Routes))
+		for _, route := range s.Routes {
+			sdkRoute := converters.RouteToSDK(route)
+			desired[strings.ToLower(route.Name)] = sdkRoute
+			if !routeExists(existingRoutes, sdkRoute) {
+				update = true
+			}
+		}
+
+		if !update {
+			// Skip update for route table as the desired routes are present
+			return nil, nil
+		}
+
+		// Keep the routes that are not part of the spec, such as the ones added by the cloud provider.
+		for _, oldRoute := range existingRoutes {
+			if _, ok := desired[strings.ToLower(ptr.Deref(oldRoute.Name, ""))]; ok {
+				continue
+			}
+			routes = append(routes, oldRoute)
+		}
 	}
+
+	for _, route := range s.Routes {
+		routes = append(routes, converters.RouteToSDK(route))
+	}
+
 	return armnetwork.RouteTable{
-		Location:   ptr.To(s.Location),
-		Properties: &armnetwork.RouteTablePropertiesFormat{},
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.RouteTablePropertiesFormat{
+			Routes: routes,
+		},
+		Etag: etag,
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -71,3 +113,19 @@ func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (
 		})),
 	}, nil
 }
+
+// routeExists returns true if a route with the same name, address prefix and next hop exists.
+func routeExists(routes []*armnetwork.Route, route *armnetwork.Route) bool {
+	for _, existingRoute := range routes {
+		if !strings.EqualFold(ptr.Deref(existingRoute.Name, ""), ptr.Deref(route.Name, "")) {
+			continue
+		}
+		if existingRoute.Properties == nil {
+			return false
+		}
+		return ptr.Deref(existingRoute.Properties.AddressPrefix, "") == ptr.Deref(route.Properties.AddressPrefix, "") &&
+			ptr.Deref(existingRoute.Properties.NextHopType, "") == ptr.Deref(route.Properties.NextHopType, "") &&
+			ptr.Deref(existingRoute.Properties.NextHopIPAddress, "") == ptr.Deref(route.Properties.NextHopIPAddress, "")
+	}
+	return false
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
 var (
@@ -39,6 +40,47 @@ var (
 			"foo": "bar",
 		},
 	}
+	fakeRouteTableSpecWithRoutes = RouteTableSpec{
+		Name:        "test-rt-1",
+		Location:    "fake-location",
+		ClusterName: "cluster",
+		Routes: []infrav1.Route{
+			{
+				Name:             "to-firewall",
+				AddressPrefix:    "0.0.0.0/0",
+				NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+				NextHopIPAddress: "10.1.0.4",
+			},
+			{
+				Name:          "to-onprem",
+				AddressPrefix: "192.168.0.0/16",
+				NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+			},
+		},
+	}
+	firewallRoute = &armnetwork.Route{
+		Name: ptr.To("to-firewall"),
+		Properties: &armnetwork.RoutePropertiesFormat{
+			AddressPrefix:    ptr.To("0.0.0.0/0"),
+			NextHopType:      ptr.To(armnetwork.RouteNextHopTypeVirtualAppliance),
+			NextHopIPAddress: ptr.To("10.1.0.4"),
+		},
+	}
+	onPremRoute = &armnetwork.Route{
+		Name: ptr.To("to-onprem"),
+		Properties: &armnetwork.RoutePropertiesFormat{
+			AddressPrefix: ptr.To("192.168.0.0/16"),
+			NextHopType:   ptr.To(armnetwork.RouteNextHopTypeVirtualNetworkGateway),
+		},
+	}
+	cloudProviderRoute = &armnetwork.Route{
+		Name: ptr.To("aks-node-1____10244000024"),
+		Properties: &armnetwork.RoutePropertiesFormat{
+			AddressPrefix:    ptr.To("10.244.0.0/24"),
+			NextHopType:      ptr.To(armnetwork.RouteNextHopTypeVirtualAppliance),
+			NextHopIPAddress: ptr.To("10.1.0.5"),
+		},
+	}
 	fakeRouteTableTags = map[string]*string{
 		"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster": ptr.To("owned"),
 		"foo":  ptr.To("bar"),
@@ -81,6 +123,56 @@ func TestRouteTableSpec_Parameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "get result as nil when existing RouteTable has the expected routes",
+			spec: &fakeRouteTableSpecWithRoutes,
+			existing: armnetwork.RouteTable{
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes: []*armnetwork.Route{cloudProviderRoute, firewallRoute, onPremRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "update routes and keep the routes not in the spec when existing RouteTable is outdated",
+			spec: &fakeRouteTableSpecWithRoutes,
+			existing: armnetwork.RouteTable{
+				Etag: ptr.To("fake-etag"),
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes: []*armnetwork.Route{
+						cloudProviderRoute,
+						{
+							Name: ptr.To("to-firewall"),
+							Properties: &armnetwork.RoutePropertiesFormat{
+								AddressPrefix:    ptr.To("0.0.0.0/0"),
+								NextHopType:      ptr.To(armnetwork.RouteNextHopTypeVirtualAppliance),
+								NextHopIPAddress: ptr.To("10.1.0.10"),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.RouteTable{}))
+				g.Expect(result.(armnetwork.RouteTable).Etag).To(Equal(ptr.To("fake-etag")))
+				g.Expect(result.(armnetwork.RouteTable).Properties.Routes).To(Equal([]*armnetwork.Route{cloudProviderRoute, firewallRoute, onPremRoute}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "get RouteTable with routes when it does not exist",
+			spec:     &fakeRouteTableSpecWithRoutes,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.RouteTable{}))
+				g.Expect(result.(armnetwork.RouteTable).Etag).To(BeNil())
+				g.Expect(result.(armnetwork.RouteTable).Properties.Routes).To(Equal([]*armnetwork.Route{firewallRoute, onPremRoute}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get RouteTable when all values are present",
 			spec:     &fakeRouteTableSpec,
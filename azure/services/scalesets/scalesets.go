@@ -82,7 +82,7 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceReconcileTimeout(s.Name()))
 	defer cancel()
 
 	if err := s.validateSpec(ctx); err != nil {
@@ -118,7 +118,7 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 			return errors.Errorf("%T is not an armcompute.VirtualMachineScaleSet", result)
 		}
 
-		fetchedVMSS := converters.SDKToVMSS(vmss, scaleSetSpec.VMSSInstances)
+		fetchedVMSS := converters.SDKToVMSS(vmss, scaleSetSpec.VMSSInstances, s.Scope.CloudEnvironment())
 		if err := s.Scope.ReconcileReplicas(ctx, &fetchedVMSS); err != nil {
 			return errors.Wrap(err, "unable to reconcile VMSS replicas")
 		}
@@ -141,7 +141,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceDeleteTimeout(s.Name()))
 	defer cancel()
 
 	scaleSetSpec := s.Scope.ScaleSetSpec(ctx)
@@ -291,7 +291,7 @@ func (s *Service) getVirtualMachineScaleSet(ctx context.Context, spec azure.Reso
 		return nil, errors.Wrap(err, "failed to list instances")
 	}
 
-	result := converters.SDKToVMSS(vmss, vmssInstances)
+	result := converters.SDKToVMSS(vmss, vmssInstances, s.Scope.CloudEnvironment())
 
 	return &result, nil
 }
@@ -46,6 +46,7 @@ type ScaleSetSpec struct {
 	VNetName                     string
 	VNetResourceGroup            string
 	PublicLBName                 string
+	PublicLBResourceGroup        string
 	PublicLBAddressPoolName      string
 	AcceleratedNetworking        *bool
 	TerminateNotificationTimeout *int
@@ -58,10 +59,13 @@ type ScaleSetSpec struct {
 	FailureDomains               []string
 	VMExtensions                 []infrav1.VMExtension
 	NetworkInterfaces            []infrav1.NetworkInterface
+	PodSubnetNames               map[string]string
+	ApplicationSecurityGroupIDs  map[string][]string
 	IPv6Enabled                  bool
 	OrchestrationMode            infrav1.OrchestrationModeType
 	Location                     string
 	SubscriptionID               string
+	CloudEnvironment             string
 	SKU                          resourceskus.SKU
 	VMSSExtensionSpecs           []azure.ResourceSpecGetter
 	VMImage                      *infrav1.Image
@@ -97,7 +101,7 @@ func (s *ScaleSetSpec) existingParameters(ctx context.Context, existing interfac
 		return nil, errors.Errorf("%T is not an armcompute.VirtualMachineScaleSet", existing)
 	}
 
-	existingInfraVMSS := converters.SDKToVMSS(existingVMSS, s.VMSSInstances)
+	existingInfraVMSS := converters.SDKToVMSS(existingVMSS, s.VMSSInstances, s.CloudEnvironment)
 
 	params, err := s.Parameters(ctx, nil)
 	if err != nil {
@@ -124,6 +128,12 @@ func (s *ScaleSetSpec) existingParameters(ctx context.Context, existing interfac
 		vmss.SKU.Capacity = ptr.To[int64](surge)
 	}
 
+	// Never lower the capacity when updating the VMSS, since Azure would remove instances of its choice without draining
+	// their nodes first. Instances are removed by deleting their AzureMachinePoolMachines instead.
+	if *vmss.SKU.Capacity < existingInfraVMSS.Capacity && !s.HasReplicasExternallyManaged {
+		vmss.SKU.Capacity = ptr.To[int64](existingInfraVMSS.Capacity)
+	}
+
 	// If there are no model changes and no increase in the replica count, do not update the VMSS.
 	// Decreases in replica count is handled by deleting AzureMachinePoolMachine instances in the MachinePoolScope
 	if *vmss.SKU.Capacity <= existingInfraVMSS.Capacity && !hasModelChanges && !s.ShouldPatchCustomData {
@@ -281,7 +291,8 @@ func (s *ScaleSetSpec) Parameters(ctx context.Context, existing interface{}) (pa
 }
 
 func hasModelModifyingDifferences(infraVMSS *azure.VMSS, vmss armcompute.VirtualMachineScaleSet) bool {
-	other := converters.SDKToVMSS(vmss, []armcompute.VirtualMachineScaleSetVM{})
+	// The cloud only matters to convert instances.
+	other := converters.SDKToVMSS(vmss, []armcompute.VirtualMachineScaleSetVM{}, "")
 	return infraVMSS.HasModelChanges(other)
 }
 
@@ -306,69 +317,100 @@ func (s *ScaleSetSpec) generateExtensions(ctx context.Context) ([]armcompute.Vir
 func (s *ScaleSetSpec) getVirtualMachineScaleSetNetworkConfiguration() *[]armcompute.VirtualMachineScaleSetNetworkConfiguration {
 	var backendAddressPools []armcompute.SubResource
 	if s.PublicLBName != "" {
+		// The load balancer lives in the scale set resource group unless an existing load balancer from another resource group is used.
+		publicLBResourceGroup := s.ResourceGroup
+		if s.PublicLBResourceGroup != "" {
+			publicLBResourceGroup = s.PublicLBResourceGroup
+		}
 		if s.PublicLBAddressPoolName != "" {
 			backendAddressPools = append(backendAddressPools,
 				armcompute.SubResource{
-					ID: ptr.To(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, s.PublicLBAddressPoolName)),
+					ID: ptr.To(azure.AddressPoolID(s.SubscriptionID, publicLBResourceGroup, s.PublicLBName, s.PublicLBAddressPoolName)),
 				})
 		}
 	}
 	nicConfigs := []armcompute.VirtualMachineScaleSetNetworkConfiguration{}
+	var podNICConfigs []armcompute.VirtualMachineScaleSetNetworkConfiguration
 	for i, n := range s.NetworkInterfaces {
-		nicConfig := armcompute.VirtualMachineScaleSetNetworkConfiguration{}
-		nicConfig.Properties = &armcompute.VirtualMachineScaleSetNetworkConfigurationProperties{}
-		nicConfig.Name = ptr.To(s.Name + "-nic-" + strconv.Itoa(i))
-		nicConfig.Properties.EnableIPForwarding = ptr.To(true)
-		if n.AcceleratedNetworking != nil {
-			nicConfig.Properties.EnableAcceleratedNetworking = n.AcceleratedNetworking
-		} else {
-			// If AcceleratedNetworking is not specified, use the value from the VMSS spec.
-			// It will be set to true if the VMSS SKU supports it.
-			nicConfig.Properties.EnableAcceleratedNetworking = s.AcceleratedNetworking
+		name := s.Name + "-nic-" + strconv.Itoa(i)
+		privateIPConfigs := n.PrivateIPConfigs
+
+		// All the IP configurations of a network interface must be in the same subnet, so the secondary private IPs
+		// of a network interface in a subnet with a dedicated pod subnet are allocated on a second network interface
+		// in the pod subnet.
+		if podSubnetName := s.PodSubnetNames[n.SubnetName]; podSubnetName != "" && privateIPConfigs > 1 {
+			podNICConfigs = append(podNICConfigs, s.networkConfiguration(azure.PodNICName(name), n, podSubnetName, privateIPConfigs-1, false))
+			privateIPConfigs = 1
 		}
 
-		// Create IPConfigs
-		ipconfigs := []armcompute.VirtualMachineScaleSetIPConfiguration{}
-		for j := 0; j < n.PrivateIPConfigs; j++ {
-			ipconfig := armcompute.VirtualMachineScaleSetIPConfiguration{
-				Name: ptr.To(fmt.Sprintf("ipConfig" + strconv.Itoa(j))),
-				Properties: &armcompute.VirtualMachineScaleSetIPConfigurationProperties{
-					PrivateIPAddressVersion: ptr.To(armcompute.IPVersionIPv4),
-					Subnet: &armcompute.APIEntityReference{
-						ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, n.SubnetName)),
-					},
-				},
-			}
-
-			if j == 0 {
-				// Always use the first IPConfig as the Primary
-				ipconfig.Properties.Primary = ptr.To(true)
-			}
-			ipconfigs = append(ipconfigs, ipconfig)
-		}
-		if s.IPv6Enabled {
-			ipv6Config := armcompute.VirtualMachineScaleSetIPConfiguration{
-				Name: ptr.To("ipConfigv6"),
-				Properties: &armcompute.VirtualMachineScaleSetIPConfigurationProperties{
-					PrivateIPAddressVersion: ptr.To(armcompute.IPVersionIPv6),
-					Primary:                 ptr.To(false),
-					Subnet: &armcompute.APIEntityReference{
-						ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, n.SubnetName)),
-					},
-				},
-			}
-			ipconfigs = append(ipconfigs, ipv6Config)
-		}
+		nicConfig := s.networkConfiguration(name, n, n.SubnetName, privateIPConfigs, s.IPv6Enabled)
 		if i == 0 {
+			ipconfigs := nicConfig.Properties.IPConfigurations
 			ipconfigs[0].Properties.LoadBalancerBackendAddressPools = azure.PtrSlice(&backendAddressPools)
 			nicConfig.Properties.Primary = ptr.To(true)
 		}
-		nicConfig.Properties.IPConfigurations = azure.PtrSlice(&ipconfigs)
 		nicConfigs = append(nicConfigs, nicConfig)
 	}
+	nicConfigs = append(nicConfigs, podNICConfigs...)
 	return &nicConfigs
 }
 
+// networkConfiguration returns the network configuration of a network interface with the given number of IPv4
+// configurations in a subnet. Its first IP configuration is the primary one.
+func (s *ScaleSetSpec) networkConfiguration(name string, n infrav1.NetworkInterface, subnetName string, privateIPConfigs int, ipv6Enabled bool) armcompute.VirtualMachineScaleSetNetworkConfiguration {
+	nicConfig := armcompute.VirtualMachineScaleSetNetworkConfiguration{}
+	nicConfig.Properties = &armcompute.VirtualMachineScaleSetNetworkConfigurationProperties{}
+	nicConfig.Name = ptr.To(name)
+	nicConfig.Properties.EnableIPForwarding = ptr.To(true)
+	if n.AcceleratedNetworking != nil {
+		nicConfig.Properties.EnableAcceleratedNetworking = n.AcceleratedNetworking
+	} else {
+		// If AcceleratedNetworking is not specified, use the value from the VMSS spec.
+		// It will be set to true if the VMSS SKU supports it.
+		nicConfig.Properties.EnableAcceleratedNetworking = s.AcceleratedNetworking
+	}
+
+	// Create IPConfigs
+	ipconfigs := []armcompute.VirtualMachineScaleSetIPConfiguration{}
+	for j := 0; j < privateIPConfigs; j++ {
+		ipconfig := armcompute.VirtualMachineScaleSetIPConfiguration{
+			Name: ptr.To(fmt.Sprintf("ipConfig" + strconv.Itoa(j))),
+			Properties: &armcompute.VirtualMachineScaleSetIPConfigurationProperties{
+				PrivateIPAddressVersion: ptr.To(armcompute.IPVersionIPv4),
+				Subnet: &armcompute.APIEntityReference{
+					ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, subnetName)),
+				},
+			},
+		}
+
+		// A pod subnet shares the application security groups of its subnet.
+		if asgIDs := s.ApplicationSecurityGroupIDs[n.SubnetName]; len(asgIDs) > 0 {
+			ipconfig.Properties.ApplicationSecurityGroups = converters.ApplicationSecurityGroupsToComputeSDK(asgIDs)
+		}
+
+		if j == 0 {
+			// Always use the first IPConfig as the Primary
+			ipconfig.Properties.Primary = ptr.To(true)
+		}
+		ipconfigs = append(ipconfigs, ipconfig)
+	}
+	if ipv6Enabled {
+		ipv6Config := armcompute.VirtualMachineScaleSetIPConfiguration{
+			Name: ptr.To("ipConfigv6"),
+			Properties: &armcompute.VirtualMachineScaleSetIPConfigurationProperties{
+				PrivateIPAddressVersion: ptr.To(armcompute.IPVersionIPv6),
+				Primary:                 ptr.To(false),
+				Subnet: &armcompute.APIEntityReference{
+					ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, subnetName)),
+				},
+			},
+		}
+		ipconfigs = append(ipconfigs, ipv6Config)
+	}
+	nicConfig.Properties.IPConfigurations = azure.PtrSlice(&ipconfigs)
+	return nicConfig
+}
+
 // generateStorageProfile generates a pointer to an armcompute.VirtualMachineScaleSetStorageProfile which can utilized for VM creation.
 func (s *ScaleSetSpec) generateStorageProfile(ctx context.Context) (*armcompute.VirtualMachineScaleSetStorageProfile, error) {
 	_, _, done := tele.StartSpanWithLogger(ctx, "scalesets.ScaleSetSpec.generateStorageProfile")
@@ -37,6 +37,7 @@ var (
 	acceleratedNetworkingSpec, acceleratedNetworkingVMSS                               = getAcceleratedNetworkingVMSS()
 	customSubnetSpec, customSubnetVMSS                                                 = getCustomSubnetVMSS()
 	customNetworkingSpec, customNetworkingVMSS                                         = getCustomNetworkingVMSS()
+	podSubnetSpec, podSubnetVMSS                                                       = getPodSubnetVMSS()
 	spotVMSpec, spotVMVMSS                                                             = getSpotVMVMSS()
 	ephemeralSpec, ephemeralVMSS                                                       = getEPHVMSSS()
 	resourceDiskSpec, resourceDiskVMSS                                                 = getResourceDiskVMSS()
@@ -48,6 +49,7 @@ var (
 	hostEncryptionUnsupportedSpec                                                      = getHostEncryptionUnsupportedSpec()
 	ephemeralReadSpec, ephemeralReadVMSS                                               = getEphemeralReadOnlyVMSS()
 	defaultExistingSpec, defaultExistingVMSS, defaultExistingVMSSClone                 = getExistingDefaultVMSS()
+	scaleInSpec, scaleInExistingVMSS, scaleInVMSS                                      = getScaleInExistingVMSS()
 	userManagedStorageAccountDiagnosticsSpec, userManagedStorageAccountDiagnosticsVMSS = getUserManagedAndStorageAcccountDiagnosticsVMSS()
 	managedDiagnosticsSpec, managedDiagnoisticsVMSS                                    = getManagedDiagnosticsVMSS()
 	disabledDiagnosticsSpec, disabledDiagnosticsVMSS                                   = getDisabledDiagnosticsVMSS()
@@ -192,6 +194,68 @@ func getCustomNetworkingVMSS() (ScaleSetSpec, armcompute.VirtualMachineScaleSet)
 	return spec, vmss
 }
 
+func getPodSubnetVMSS() (ScaleSetSpec, armcompute.VirtualMachineScaleSet) {
+	spec := newDefaultVMSSSpec()
+	spec.NetworkInterfaces = []infrav1.NetworkInterface{
+		{
+			SubnetName:            "my-subnet",
+			PrivateIPConfigs:      3,
+			AcceleratedNetworking: ptr.To(true),
+		},
+	}
+	spec.PodSubnetNames = map[string]string{"my-subnet": "my-pod-subnet"}
+	spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+		NameSuffix: "my_disk_with_ultra_disks",
+		DiskSizeGB: 128,
+		Lun:        ptr.To[int32](3),
+		ManagedDisk: &infrav1.ManagedDiskParameters{
+			StorageAccountType: "UltraSSD_LRS",
+		},
+	})
+	vmss := newDefaultVMSS("VM_SIZE")
+	vmss.Properties.AdditionalCapabilities = &armcompute.AdditionalCapabilities{UltraSSDEnabled: ptr.To(true)}
+	netConfigs := vmss.Properties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+	netConfigs[0].Name = ptr.To("my-vmss-nic-0")
+	netConfigs[0].Properties.EnableIPForwarding = ptr.To(true)
+	nic1IPConfigs := netConfigs[0].Properties.IPConfigurations
+	nic1IPConfigs[0].Name = ptr.To("ipConfig0")
+	nic1IPConfigs[0].Properties.PrivateIPAddressVersion = ptr.To(armcompute.IPVersionIPv4)
+	netConfigs[0].Properties.EnableAcceleratedNetworking = ptr.To(true)
+	netConfigs[0].Properties.Primary = ptr.To(true)
+	podIPConfigs := []armcompute.VirtualMachineScaleSetIPConfiguration{
+		{
+			Name: ptr.To("ipConfig0"),
+			Properties: &armcompute.VirtualMachineScaleSetIPConfigurationProperties{
+				Primary:                 ptr.To(true),
+				PrivateIPAddressVersion: ptr.To(armcompute.IPVersionIPv4),
+				Subnet: &armcompute.APIEntityReference{
+					ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-pod-subnet"),
+				},
+			},
+		},
+		{
+			Name: ptr.To("ipConfig1"),
+			Properties: &armcompute.VirtualMachineScaleSetIPConfigurationProperties{
+				PrivateIPAddressVersion: ptr.To(armcompute.IPVersionIPv4),
+				Subnet: &armcompute.APIEntityReference{
+					ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-pod-subnet"),
+				},
+			},
+		},
+	}
+	netConfigs = append(netConfigs, &armcompute.VirtualMachineScaleSetNetworkConfiguration{
+		Name: ptr.To("my-vmss-nic-0-pods"),
+		Properties: &armcompute.VirtualMachineScaleSetNetworkConfigurationProperties{
+			EnableAcceleratedNetworking: ptr.To(true),
+			IPConfigurations:            azure.PtrSlice(&podIPConfigs),
+			EnableIPForwarding:          ptr.To(true),
+		},
+	})
+	vmss.Properties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations = netConfigs
+
+	return spec, vmss
+}
+
 func getSpotVMVMSS() (ScaleSetSpec, armcompute.VirtualMachineScaleSet) {
 	spec := newDefaultVMSSSpec()
 	spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
@@ -442,6 +506,17 @@ func getExistingDefaultVMSS() (s ScaleSetSpec, existing armcompute.VirtualMachin
 	return spec, existingVMSS, clone
 }
 
+func getScaleInExistingVMSS() (s ScaleSetSpec, existing armcompute.VirtualMachineScaleSet, result armcompute.VirtualMachineScaleSet) {
+	spec, existingVMSS, clone := getExistingDefaultVMSS()
+	spec.Capacity = 1
+	spec.MaxSurge = 0
+
+	// The capacity isn't lowered, the instances are removed by deleting their AzureMachinePoolMachines instead.
+	clone.SKU.Capacity = ptr.To[int64](2)
+
+	return spec, existingVMSS, clone
+}
+
 func getUserManagedAndStorageAcccountDiagnosticsVMSS() (ScaleSetSpec, armcompute.VirtualMachineScaleSet) {
 	storageURI := "https://fakeurl"
 	spec := newDefaultVMSSSpec()
@@ -599,6 +674,13 @@ func TestScaleSetParameters(t *testing.T) {
 			expected:      customNetworkingVMSS,
 			expectedError: "",
 		},
+		{
+			name:          "pod subnet vmss",
+			spec:          podSubnetSpec,
+			existing:      nil,
+			expected:      podSubnetVMSS,
+			expectedError: "",
+		},
 		{
 			name:          "spot vm vmss",
 			spec:          spotVMSpec,
@@ -683,6 +765,13 @@ func TestScaleSetParameters(t *testing.T) {
 			expected:      defaultExistingVMSSClone,
 			expectedError: "",
 		},
+		{
+			name:          "update for existing vmss with a lower capacity",
+			spec:          scaleInSpec,
+			existing:      scaleInExistingVMSS,
+			expected:      scaleInVMSS,
+			expectedError: "",
+		},
 		{
 			name:          "vm with diagnostics set to User Managed and StorageAccountURI set",
 			spec:          userManagedStorageAccountDiagnosticsSpec,
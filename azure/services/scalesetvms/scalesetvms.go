@@ -123,7 +123,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		if !ok {
 			return errors.Errorf("%T is not of type armcompute.VirtualMachineScaleSetVM", result)
 		}
-		s.Scope.SetVMSSVM(converters.SDKToVMSSVM(instance))
+		s.Scope.SetVMSSVM(converters.SDKToVMSSVM(instance, s.Scope.CloudEnvironment()))
 	}
 
 	return nil
@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesetvms/mock_scalesetvms"
@@ -87,7 +88,7 @@ func TestReconcileVMSS(t *testing.T) {
 			expect: func(g *WithT, s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetVMSpec().Return(uniformScaleSetVMSpec)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), uniformScaleSetVMSpec, serviceName).Return(uniformScaleSetVM, nil)
-				s.SetVMSSVM(converters.SDKToVMSSVM(uniformScaleSetVM))
+				s.SetVMSSVM(converters.SDKToVMSSVM(uniformScaleSetVM, azure.PublicCloudName))
 			},
 		},
 		{
@@ -142,6 +143,7 @@ func TestReconcileVMSS(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			scopeMock := mock_scalesetvms.NewMockScaleSetVMScope(mockCtrl)
+			scopeMock.EXPECT().CloudEnvironment().Return(azure.PublicCloudName).AnyTimes()
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
 			vmAsyncMock := mock_async.NewMockReconciler(mockCtrl)
 
@@ -217,6 +219,7 @@ func TestDeleteVMSS(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			scopeMock := mock_scalesetvms.NewMockScaleSetVMScope(mockCtrl)
+			scopeMock.EXPECT().CloudEnvironment().Return(azure.PublicCloudName).AnyTimes()
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
 			vmAsyncMock := mock_async.NewMockReconciler(mockCtrl)
 
@@ -25,6 +25,7 @@ import (
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 // NSGSpec defines the specification for a security group.
@@ -55,6 +56,9 @@ func (s *NSGSpec) OwnerResourceName() string {
 
 // Parameters returns the parameters for the security group.
 func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
+	_, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.NSGSpec.Parameters")
+	defer done()
+
 	securityRules := make([]*armnetwork.SecurityRule, 0)
 	newAnnotation := map[string]string{}
 	var etag *string
@@ -67,12 +71,18 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 		// security group already exists
 		// We append the existing NSG etag to the header to ensure we only apply the updates if the NSG has not been modified.
 		etag = existingNSG.Etag
-		// Check if the expected rules are present
+		// Check if the expected rules are present and match the spec
 		update := false
+		replaced := map[string]bool{}
 
 		for _, rule := range s.SecurityRules {
 			sdkRule := converters.SecurityRuleToSDK(rule)
 			if !ruleExists(existingNSG.Properties.SecurityRules, sdkRule) {
+				if ruleNameExists(existingNSG.Properties.SecurityRules, rule.Name) {
+					// The rule was modified outside of CAPZ, replace it with the one from the spec.
+					log.V(2).Info("restoring security rule which differs from spec", "securityGroup", s.Name, "rule", rule.Name)
+					replaced[strings.ToLower(rule.Name)] = true
+				}
 				update = true
 				securityRules = append(securityRules, sdkRule)
 			}
@@ -80,10 +90,14 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 		}
 
 		for _, oldRule := range existingNSG.Properties.SecurityRules {
+			if replaced[strings.ToLower(ptr.Deref(oldRule.Name, ""))] {
+				continue
+			}
 			_, tracked := s.LastAppliedSecurityRules[*oldRule.Name]
 			// If rule is owned by CAPZ and applied last, and not found in the new rules, then it has been deleted
 			if _, ok := newAnnotation[*oldRule.Name]; !ok && tracked {
 				// Rule has been deleted
+				log.V(2).Info("removing security rule which was deleted from spec", "securityGroup", s.Name, "rule", *oldRule.Name)
 				update = true
 				continue
 			}
@@ -118,26 +132,68 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 	}, nil
 }
 
-// TODO: review this logic and make sure it is what we want. It seems incorrect to skip rules that don't have a certain protocol, etc.
+// ruleExists returns true if rules contains a rule with the same name and properties as rule.
 func ruleExists(rules []*armnetwork.SecurityRule, rule *armnetwork.SecurityRule) bool {
 	for _, existingRule := range rules {
 		if !strings.EqualFold(ptr.Deref(existingRule.Name, ""), ptr.Deref(rule.Name, "")) {
 			continue
 		}
-		if !strings.EqualFold(ptr.Deref(existingRule.Properties.DestinationPortRange, ""), ptr.Deref(rule.Properties.DestinationPortRange, "")) {
-			continue
-		}
-		if ptr.Deref(existingRule.Properties.Protocol, "") != armnetwork.SecurityRuleProtocolTCP &&
-			ptr.Deref(existingRule.Properties.Access, "") != armnetwork.SecurityRuleAccessAllow &&
-			ptr.Deref(existingRule.Properties.Direction, "") != armnetwork.SecurityRuleDirectionInbound {
-			continue
+		if existingRule.Properties == nil {
+			return false
 		}
-		if !strings.EqualFold(ptr.Deref(existingRule.Properties.SourcePortRange, ""), "*") &&
-			!strings.EqualFold(ptr.Deref(existingRule.Properties.SourceAddressPrefix, ""), "*") &&
-			!strings.EqualFold(ptr.Deref(existingRule.Properties.DestinationAddressPrefix, ""), "*") {
-			continue
+		existing, desired := existingRule.Properties, rule.Properties
+		return ptr.Deref(existing.Description, "") == ptr.Deref(desired.Description, "") &&
+			ptr.Deref(existing.Protocol, "") == ptr.Deref(desired.Protocol, "") &&
+			ptr.Deref(existing.Access, "") == ptr.Deref(desired.Access, "") &&
+			ptr.Deref(existing.Direction, "") == ptr.Deref(desired.Direction, "") &&
+			ptr.Deref(existing.Priority, 0) == ptr.Deref(desired.Priority, 0) &&
+			strings.EqualFold(ptr.Deref(existing.SourcePortRange, ""), ptr.Deref(desired.SourcePortRange, "")) &&
+			strings.EqualFold(ptr.Deref(existing.DestinationPortRange, ""), ptr.Deref(desired.DestinationPortRange, "")) &&
+			strings.EqualFold(ptr.Deref(existing.SourceAddressPrefix, ""), ptr.Deref(desired.SourceAddressPrefix, "")) &&
+			strings.EqualFold(ptr.Deref(existing.DestinationAddressPrefix, ""), ptr.Deref(desired.DestinationAddressPrefix, "")) &&
+			prefixesEqual(existing.SourceAddressPrefixes, desired.SourceAddressPrefixes) &&
+			prefixesEqual(applicationSecurityGroupIDs(existing.SourceApplicationSecurityGroups), applicationSecurityGroupIDs(desired.SourceApplicationSecurityGroups)) &&
+			prefixesEqual(applicationSecurityGroupIDs(existing.DestinationApplicationSecurityGroups), applicationSecurityGroupIDs(desired.DestinationApplicationSecurityGroups))
+	}
+	return false
+}
+
+// ruleNameExists returns true if rules contains a rule with the given name.
+func ruleNameExists(rules []*armnetwork.SecurityRule, name string) bool {
+	for _, rule := range rules {
+		if strings.EqualFold(ptr.Deref(rule.Name, ""), name) {
+			return true
 		}
-		return true
 	}
 	return false
 }
+
+// prefixesEqual returns true if both lists contain the same address prefixes, regardless of order.
+func prefixesEqual(a, b []*string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, prefix := range a {
+		counts[strings.ToLower(ptr.Deref(prefix, ""))]++
+	}
+	for _, prefix := range b {
+		key := strings.ToLower(ptr.Deref(prefix, ""))
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
+
+// applicationSecurityGroupIDs returns the resource IDs of a list of application security groups.
+func applicationSecurityGroupIDs(asgs []*armnetwork.ApplicationSecurityGroup) []*string {
+	ids := make([]*string, 0, len(asgs))
+	for _, asg := range asgs {
+		if asg != nil {
+			ids = append(ids, asg.ID)
+		}
+	}
+	return ids
+}
@@ -40,6 +40,18 @@ var (
 		DestinationPorts: ptr.To("22"),
 		Action:           infrav1.SecurityRuleActionAllow,
 	}
+	sshRuleModified = infrav1.SecurityRule{
+		Name:             "allow_ssh",
+		Description:      "Allow SSH",
+		Priority:         2200,
+		Protocol:         infrav1.SecurityGroupProtocolTCP,
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+		Source:           ptr.To("*"),
+		SourcePorts:      ptr.To("*"),
+		Destination:      ptr.To("*"),
+		DestinationPorts: ptr.To("22"),
+		Action:           infrav1.SecurityRuleActionDeny,
+	}
 	otherRule = infrav1.SecurityRule{
 		Name:             "other_rule",
 		Description:      "Test Rule",
@@ -289,6 +301,49 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG already exists and a rule was modified outside of CAPZ",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					sshRule,
+					otherRule,
+				},
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+			},
+			existing: armnetwork.SecurityGroup{
+				Name:     ptr.To("test-nsg"),
+				Location: ptr.To("test-location"),
+				Etag:     ptr.To("fake-etag"),
+				Properties: &armnetwork.SecurityGroupPropertiesFormat{
+					SecurityRules: []*armnetwork.SecurityRule{
+						converters.SecurityRuleToSDK(sshRuleModified),
+						converters.SecurityRuleToSDK(otherRule),
+						converters.SecurityRuleToSDK(customRule),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.SecurityGroup{}))
+				g.Expect(result).To(Equal(armnetwork.SecurityGroup{
+					Location: ptr.To("test-location"),
+					Etag:     ptr.To("fake-etag"),
+					Properties: &armnetwork.SecurityGroupPropertiesFormat{
+						SecurityRules: []*armnetwork.SecurityRule{
+							converters.SecurityRuleToSDK(sshRule),
+							converters.SecurityRuleToSDK(otherRule),
+							converters.SecurityRuleToSDK(customRule),
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+						"Name": ptr.To("test-nsg"),
+					},
+				}))
+			},
+		},
 		{
 			name: "NSG already exists and a rule not owned by CAPZ is present",
 			spec: &NSGSpec{
@@ -396,6 +451,24 @@ func TestRuleExists(t *testing.T) {
 			rule:     ruleBModified,
 			expected: false,
 		},
+		{
+			name:     "rule exists but its access has been modified",
+			rules:    []*armnetwork.SecurityRule{converters.SecurityRuleToSDK(sshRuleModified)},
+			rule:     converters.SecurityRuleToSDK(sshRule),
+			expected: false,
+		},
+		{
+			name:     "rule exists with source address prefixes in a different order",
+			rules:    []*armnetwork.SecurityRule{converters.SecurityRuleToSDK(sourcesRule("10.0.0.0/16", "192.168.0.0/24"))},
+			rule:     converters.SecurityRuleToSDK(sourcesRule("192.168.0.0/24", "10.0.0.0/16")),
+			expected: true,
+		},
+		{
+			name:     "rule exists with different source address prefixes",
+			rules:    []*armnetwork.SecurityRule{converters.SecurityRuleToSDK(sourcesRule("10.0.0.0/16"))},
+			rule:     converters.SecurityRuleToSDK(sourcesRule("10.0.0.0/16", "192.168.0.0/24")),
+			expected: false,
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -407,3 +480,13 @@ func TestRuleExists(t *testing.T) {
 		})
 	}
 }
+
+func sourcesRule(sources ...string) infrav1.SecurityRule {
+	rule := sshRule
+	rule.Source = nil
+	rule.Sources = make([]*string, 0, len(sources))
+	for _, source := range sources {
+		rule.Sources = append(rule.Sources, ptr.To(source))
+	}
+	return rule
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spotplacement
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api-provider-azure/version"
+)
+
+const (
+	// placementScoresAPIVersion is the version of the Spot Placement Score API, which is called directly as the Azure
+	// SDK for Go used by CAPZ has no client for it.
+	placementScoresAPIVersion = "2025-06-05"
+
+	// resourceGraphAPIVersion is the version of the Azure Resource Graph API used to query Spot eviction rates.
+	resourceGraphAPIVersion = "2021-03-01"
+
+	// evictionRateQuery queries the eviction rate of a VM size in a location from the SpotResources table of Azure
+	// Resource Graph.
+	evictionRateQuery = `SpotResources
+| where type =~ 'microsoft.compute/skuspotevictionrate/location'
+| where sku.name =~ '%s' and location =~ '%s'
+| project evictionRate = tostring(properties.evictionRate)`
+)
+
+// client wraps go-sdk.
+type client interface {
+	GetPlacementScores(ctx context.Context, location, vmSize string, desiredCount int32, availabilityZones bool) ([]azure.SpotPlacementScore, error)
+	GetEvictionRate(ctx context.Context, location, vmSize string) (string, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	pipeline       runtime.Pipeline
+	endpoint       string
+	subscriptionID string
+}
+
+// newClient creates a new spot placement client from an authorizer.
+func newClient(auth azure.Authorizer) (*azureClient, error) {
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create spotplacement client options")
+	}
+	resourceManager, ok := opts.Cloud.Services[cloud.ResourceManager]
+	if !ok {
+		resourceManager = cloud.AzurePublic.Services[cloud.ResourceManager]
+	}
+	tokenPolicy := runtime.NewBearerTokenPolicy(auth.Token(), []string{resourceManager.Audience + "/.default"}, nil)
+	return &azureClient{
+		pipeline:       runtime.NewPipeline("spotplacement", version.Get().String(), runtime.PipelineOptions{PerRetry: []policy.Policy{tokenPolicy}}, &opts.ClientOptions),
+		endpoint:       resourceManager.Endpoint,
+		subscriptionID: auth.SubscriptionID(),
+	}, nil
+}
+
+// GetPlacementScores returns the Spot Placement Scores of a VM size in a location, per availability zone if
+// availabilityZones is true.
+func (ac *azureClient) GetPlacementScores(ctx context.Context, location, vmSize string, desiredCount int32, availabilityZones bool) ([]azure.SpotPlacementScore, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "spotplacement.AzureClient.GetPlacementScores")
+	defer done()
+
+	path := fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Compute/locations/%s/placementScores/spot/generate", ac.subscriptionID, location)
+	body := map[string]interface{}{
+		"desiredLocations":  []string{location},
+		"desiredSizes":      []map[string]string{{"sku": vmSize}},
+		"desiredCount":      desiredCount,
+		"availabilityZones": availabilityZones,
+	}
+	var result struct {
+		PlacementScores []azure.SpotPlacementScore `json:"placementScores"`
+	}
+	if err := ac.post(ctx, path, placementScoresAPIVersion, body, &result); err != nil {
+		return nil, err
+	}
+	return result.PlacementScores, nil
+}
+
+// GetEvictionRate returns the eviction rate of Spot VMs of a VM size in a location, as a range in percent, e.g.
+// "5-10" or "20+". It returns an empty string if Azure has no eviction rate for them.
+func (ac *azureClient) GetEvictionRate(ctx context.Context, location, vmSize string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "spotplacement.AzureClient.GetEvictionRate")
+	defer done()
+
+	if strings.ContainsAny(vmSize+location, `'\`) {
+		return "", errors.Errorf("invalid VM size %q or location %q", vmSize, location)
+	}
+	body := map[string]interface{}{
+		"subscriptions": []string{ac.subscriptionID},
+		"query":         fmt.Sprintf(evictionRateQuery, vmSize, location),
+		"options":       map[string]string{"resultFormat": "objectArray"},
+	}
+	var result struct {
+		Data []struct {
+			EvictionRate string `json:"evictionRate"`
+		} `json:"data"`
+	}
+	if err := ac.post(ctx, "/providers/Microsoft.ResourceGraph/resources", resourceGraphAPIVersion, body, &result); err != nil {
+		return "", err
+	}
+	if len(result.Data) == 0 {
+		return "", nil
+	}
+	return result.Data[0].EvictionRate, nil
+}
+
+// post sends a POST request with a JSON body to an ARM path and unmarshals the JSON response into result.
+func (ac *azureClient) post(ctx context.Context, path, apiVersion string, body, result interface{}) error {
+	req, err := runtime.NewRequest(ctx, http.MethodPost, runtime.JoinPaths(ac.endpoint, path))
+	if err != nil {
+		return err
+	}
+	query := req.Raw().URL.Query()
+	query.Set("api-version", apiVersion)
+	req.Raw().URL.RawQuery = query.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	if err := runtime.MarshalAsJSON(req, body); err != nil {
+		return err
+	}
+	resp, err := ac.pipeline.Do(req)
+	if err != nil {
+		return err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return runtime.NewResponseError(resp)
+	}
+	return runtime.UnmarshalAsJSON(resp, result)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../client.go
+//
+// Generated by this command:
+//
+//	mockgen -destination client_mock.go -package mock_spotplacement -source ../client.go Client
+//
+
+// Package mock_spotplacement is a generated GoMock package.
+package mock_spotplacement
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// Mockclient is a mock of client interface.
+type Mockclient struct {
+	ctrl     *gomock.Controller
+	recorder *MockclientMockRecorder
+}
+
+// MockclientMockRecorder is the mock recorder for Mockclient.
+type MockclientMockRecorder struct {
+	mock *Mockclient
+}
+
+// NewMockclient creates a new mock instance.
+func NewMockclient(ctrl *gomock.Controller) *Mockclient {
+	mock := &Mockclient{ctrl: ctrl}
+	mock.recorder = &MockclientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockclient) EXPECT() *MockclientMockRecorder {
+	return m.recorder
+}
+
+// GetEvictionRate mocks base method.
+func (m *Mockclient) GetEvictionRate(ctx context.Context, location, vmSize string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvictionRate", ctx, location, vmSize)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvictionRate indicates an expected call of GetEvictionRate.
+func (mr *MockclientMockRecorder) GetEvictionRate(ctx, location, vmSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvictionRate", reflect.TypeOf((*Mockclient)(nil).GetEvictionRate), ctx, location, vmSize)
+}
+
+// GetPlacementScores mocks base method.
+func (m *Mockclient) GetPlacementScores(ctx context.Context, location, vmSize string, desiredCount int32, availabilityZones bool) ([]azure.SpotPlacementScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlacementScores", ctx, location, vmSize, desiredCount, availabilityZones)
+	ret0, _ := ret[0].([]azure.SpotPlacementScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlacementScores indicates an expected call of GetPlacementScores.
+func (mr *MockclientMockRecorder) GetPlacementScores(ctx, location, vmSize, desiredCount, availabilityZones any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlacementScores", reflect.TypeOf((*Mockclient)(nil).GetPlacementScores), ctx, location, vmSize, desiredCount, availabilityZones)
+}
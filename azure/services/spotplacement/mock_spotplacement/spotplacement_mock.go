@@ -0,0 +1,198 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../spotplacement.go
+//
+// Generated by this command:
+//
+//	mockgen -destination spotplacement_mock.go -package mock_spotplacement -source ../spotplacement.go SpotPlacementScope
+//
+
+// Package mock_spotplacement is a generated GoMock package.
+package mock_spotplacement
+
+import (
+	reflect "reflect"
+
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	gomock "go.uber.org/mock/gomock"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
+	conditions "sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// MockSpotPlacementScope is a mock of SpotPlacementScope interface.
+type MockSpotPlacementScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpotPlacementScopeMockRecorder
+}
+
+// MockSpotPlacementScopeMockRecorder is the mock recorder for MockSpotPlacementScope.
+type MockSpotPlacementScopeMockRecorder struct {
+	mock *MockSpotPlacementScope
+}
+
+// NewMockSpotPlacementScope creates a new mock instance.
+func NewMockSpotPlacementScope(ctrl *gomock.Controller) *MockSpotPlacementScope {
+	mock := &MockSpotPlacementScope{ctrl: ctrl}
+	mock.recorder = &MockSpotPlacementScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpotPlacementScope) EXPECT() *MockSpotPlacementScopeMockRecorder {
+	return m.recorder
+}
+
+// BaseURI mocks base method.
+func (m *MockSpotPlacementScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockSpotPlacementScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockSpotPlacementScope)(nil).BaseURI))
+}
+
+// ClientID mocks base method.
+func (m *MockSpotPlacementScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockSpotPlacementScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockSpotPlacementScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockSpotPlacementScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockSpotPlacementScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockSpotPlacementScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockSpotPlacementScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockSpotPlacementScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockSpotPlacementScope)(nil).CloudEnvironment))
+}
+
+// HashKey mocks base method.
+func (m *MockSpotPlacementScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockSpotPlacementScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockSpotPlacementScope)(nil).HashKey))
+}
+
+// SpotPlacementCheckSpec mocks base method.
+func (m *MockSpotPlacementScope) SpotPlacementCheckSpec() *azure.SpotPlacementCheckSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpotPlacementCheckSpec")
+	ret0, _ := ret[0].(*azure.SpotPlacementCheckSpec)
+	return ret0
+}
+
+// SpotPlacementCheckSpec indicates an expected call of SpotPlacementCheckSpec.
+func (mr *MockSpotPlacementScopeMockRecorder) SpotPlacementCheckSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpotPlacementCheckSpec", reflect.TypeOf((*MockSpotPlacementScope)(nil).SpotPlacementCheckSpec))
+}
+
+// SpotPlacementResource mocks base method.
+func (m *MockSpotPlacementScope) SpotPlacementResource() conditions.Setter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpotPlacementResource")
+	ret0, _ := ret[0].(conditions.Setter)
+	return ret0
+}
+
+// SpotPlacementResource indicates an expected call of SpotPlacementResource.
+func (mr *MockSpotPlacementScopeMockRecorder) SpotPlacementResource() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpotPlacementResource", reflect.TypeOf((*MockSpotPlacementScope)(nil).SpotPlacementResource))
+}
+
+// SubscriptionID mocks base method.
+func (m *MockSpotPlacementScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockSpotPlacementScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockSpotPlacementScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockSpotPlacementScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockSpotPlacementScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockSpotPlacementScope)(nil).TenantID))
+}
+
+// Token mocks base method.
+func (m *MockSpotPlacementScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockSpotPlacementScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockSpotPlacementScope)(nil).Token))
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spotplacement
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	serviceName = "spotplacement"
+
+	// requeueInterval is how long to wait before checking again Spot VMs whose check failed with the Fail policy,
+	// as Spot Placement Scores and eviction rates change over time.
+	requeueInterval = 5 * time.Minute
+
+	// restrictedSKUScore is the score of a VM size which isn't available to the subscription in the location.
+	restrictedSKUScore = "RestrictedSkuNotAvailable"
+)
+
+// scoreRanks orders the Spot Placement Scores. Scores missing from it, e.g. "DataNotFoundOrStale", are ignored.
+var scoreRanks = map[string]int{
+	restrictedSKUScore:                       0,
+	string(infrav1.SpotPlacementScoreLow):    1,
+	string(infrav1.SpotPlacementScoreMedium): 2,
+	string(infrav1.SpotPlacementScoreHigh):   3,
+}
+
+// SpotPlacementScope defines the scope interface for a spot placement service.
+type SpotPlacementScope interface {
+	azure.Authorizer
+	SpotPlacementCheckSpec() *azure.SpotPlacementCheckSpec
+	SpotPlacementResource() conditions.Setter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope SpotPlacementScope
+	client
+}
+
+// New creates a new service.
+func New(scope SpotPlacementScope) (*Service, error) {
+	cli, err := newClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope:  scope,
+		client: cli,
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile checks the Spot Placement Score and the eviction rate of the VM size of Spot VMs before they are
+// created, and reports in the SpotCapacityAvailable condition when their allocation is likely to fail or they are
+// likely to be evicted quickly. With the Fail policy, the Spot VMs aren't created until the check passes.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "spotplacement.Service.Reconcile")
+	defer done()
+
+	spec := s.Scope.SpotPlacementCheckSpec()
+	if spec == nil {
+		return nil
+	}
+
+	resource := s.Scope.SpotPlacementResource()
+	failOnCheck := spec.Check.Policy == infrav1.SpotPlacementCheckPolicyFail
+	if conditions.IsTrue(resource, infrav1.SpotCapacityAvailableCondition) ||
+		(!failOnCheck && conditions.Has(resource, infrav1.SpotCapacityAvailableCondition)) {
+		return nil
+	}
+
+	reason, msg, err := s.check(ctx, spec)
+	if err != nil {
+		return err
+	}
+	if msg == "" {
+		conditions.MarkTrue(resource, infrav1.SpotCapacityAvailableCondition)
+		return nil
+	}
+
+	if !failOnCheck {
+		log.Info("Creating Spot VMs despite the failed placement check", "reason", msg)
+		conditions.MarkFalse(resource, infrav1.SpotCapacityAvailableCondition, reason, clusterv1.ConditionSeverityWarning, "%s", msg)
+		return nil
+	}
+	conditions.MarkFalse(resource, infrav1.SpotCapacityAvailableCondition, reason, clusterv1.ConditionSeverityError, "%s", msg)
+	return azure.WithTransientError(errors.New(msg), requeueInterval)
+}
+
+// check returns the reason and message of the failed check, or empty strings if the check passed.
+func (s *Service) check(ctx context.Context, spec *azure.SpotPlacementCheckSpec) (reason string, msg string, err error) {
+	scores, err := s.GetPlacementScores(ctx, spec.Location, spec.VMSize, max(spec.DesiredCount, 1), len(spec.Zones) > 0)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get Spot Placement Score of VM size %s", spec.VMSize)
+	}
+	minimumScore := spec.Check.MinimumScore
+	if minimumScore == "" {
+		minimumScore = infrav1.SpotPlacementScoreMedium
+	}
+	for _, score := range scores {
+		rank, ok := scoreRanks[score.Score]
+		if !ok || (score.Zone != "" && len(spec.Zones) > 0 && !slices.Contains(spec.Zones, score.Zone)) {
+			continue
+		}
+		if rank < scoreRanks[string(minimumScore)] {
+			location := spec.Location
+			if score.Zone != "" {
+				location = fmt.Sprintf("%s zone %s", spec.Location, score.Zone)
+			}
+			return infrav1.SpotPlacementScoreLowReason, fmt.Sprintf("Spot Placement Score of VM size %s in %s is %s, below %s", spec.VMSize, location, score.Score, minimumScore), nil
+		}
+	}
+
+	if spec.Check.MaximumEvictionRate == nil {
+		return "", "", nil
+	}
+	evictionRate, err := s.GetEvictionRate(ctx, spec.Location, spec.VMSize)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get Spot eviction rate of VM size %s", spec.VMSize)
+	}
+	upperBound, ok := evictionRateUpperBound(evictionRate)
+	if ok && upperBound > int(*spec.Check.MaximumEvictionRate) {
+		return infrav1.SpotEvictionRateHighReason, fmt.Sprintf("Spot eviction rate of VM size %s in %s is %s%%, above %d%%", spec.VMSize, spec.Location, evictionRate, *spec.Check.MaximumEvictionRate), nil
+	}
+	return "", "", nil
+}
+
+// evictionRateUpperBound returns the upper bound of an eviction rate range in percent, e.g. 10 for "5-10". The
+// unbounded range, e.g. "20+", is given an upper bound of 101 so that it exceeds any maximum eviction rate.
+func evictionRateUpperBound(evictionRate string) (int, bool) {
+	if strings.HasSuffix(evictionRate, "+") {
+		return 101, true
+	}
+	_, upper, ok := strings.Cut(evictionRate, "-")
+	if !ok {
+		return 0, false
+	}
+	upperBound, err := strconv.Atoi(strings.TrimSpace(upper))
+	if err != nil {
+		return 0, false
+	}
+	return upperBound, true
+}
+
+// Delete is a no-op.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "spotplacement.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// IsManaged always returns true.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
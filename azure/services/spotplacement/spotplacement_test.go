@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spotplacement
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/spotplacement/mock_spotplacement"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileSpotPlacement(t *testing.T) {
+	warnSpec := &azure.SpotPlacementCheckSpec{
+		VMSize:       "Standard_D2s_v3",
+		Location:     "westus2",
+		DesiredCount: 1,
+		Check:        infrav1.SpotPlacementCheck{Policy: infrav1.SpotPlacementCheckPolicyWarn},
+	}
+	zonalSpec := &azure.SpotPlacementCheckSpec{
+		VMSize:       "Standard_D2s_v3",
+		Location:     "westus2",
+		Zones:        []string{"2"},
+		DesiredCount: 3,
+		Check:        infrav1.SpotPlacementCheck{Policy: infrav1.SpotPlacementCheckPolicyFail, MinimumScore: infrav1.SpotPlacementScoreHigh},
+	}
+	evictionSpec := &azure.SpotPlacementCheckSpec{
+		VMSize:       "Standard_D2s_v3",
+		Location:     "westus2",
+		DesiredCount: 1,
+		Check:        infrav1.SpotPlacementCheck{Policy: infrav1.SpotPlacementCheckPolicyFail, MaximumEvictionRate: ptr.To[int32](10)},
+	}
+
+	testcases := []struct {
+		name              string
+		spec              *azure.SpotPlacementCheckSpec
+		existingCondition *corev1.ConditionStatus
+		expect            func(m *mock_spotplacement.MockclientMockRecorder)
+		expectedCondition *corev1.ConditionStatus
+		expectedReason    string
+		expectedError     string
+		expectTransient   bool
+	}{
+		{
+			name:   "no placement check",
+			spec:   nil,
+			expect: func(_ *mock_spotplacement.MockclientMockRecorder) {},
+		},
+		{
+			name: "placement score is high enough",
+			spec: warnSpec,
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(1), false).Return([]azure.SpotPlacementScore{{Score: "Medium"}}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name: "stale placement score is ignored",
+			spec: warnSpec,
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(1), false).Return([]azure.SpotPlacementScore{{Score: "DataNotFoundOrStale"}}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name: "low placement score with the Warn policy",
+			spec: warnSpec,
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(1), false).Return([]azure.SpotPlacementScore{{Score: "Low"}}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedReason:    infrav1.SpotPlacementScoreLowReason,
+		},
+		{
+			name:              "failed check with the Warn policy is not repeated",
+			spec:              warnSpec,
+			existingCondition: ptr.To(corev1.ConditionFalse),
+			expect:            func(_ *mock_spotplacement.MockclientMockRecorder) {},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+		},
+		{
+			name: "restricted VM size in the zone with the Fail policy",
+			spec: zonalSpec,
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(3), true).Return([]azure.SpotPlacementScore{
+					{Zone: "1", Score: "High"},
+					{Zone: "2", Score: "RestrictedSkuNotAvailable"},
+				}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedReason:    infrav1.SpotPlacementScoreLowReason,
+			expectedError:     "Spot Placement Score of VM size Standard_D2s_v3 in westus2 zone 2 is RestrictedSkuNotAvailable, below High. Object will be requeued after 5m0s",
+			expectTransient:   true,
+		},
+		{
+			name: "low placement score in another zone is ignored",
+			spec: zonalSpec,
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(3), true).Return([]azure.SpotPlacementScore{
+					{Zone: "1", Score: "Low"},
+					{Zone: "2", Score: "High"},
+				}, nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name: "eviction rate is low enough",
+			spec: evictionSpec,
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(1), false).Return([]azure.SpotPlacementScore{{Score: "High"}}, nil)
+				m.GetEvictionRate(gomockinternal.AContext(), "westus2", "Standard_D2s_v3").Return("5-10", nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name: "eviction rate is too high with the Fail policy",
+			spec: evictionSpec,
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(1), false).Return([]azure.SpotPlacementScore{{Score: "High"}}, nil)
+				m.GetEvictionRate(gomockinternal.AContext(), "westus2", "Standard_D2s_v3").Return("20+", nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionFalse),
+			expectedReason:    infrav1.SpotEvictionRateHighReason,
+			expectedError:     "Spot eviction rate of VM size Standard_D2s_v3 in westus2 is 20+%, above 10%. Object will be requeued after 5m0s",
+			expectTransient:   true,
+		},
+		{
+			name:              "failed check with the Fail policy is repeated",
+			spec:              evictionSpec,
+			existingCondition: ptr.To(corev1.ConditionFalse),
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(1), false).Return([]azure.SpotPlacementScore{{Score: "High"}}, nil)
+				m.GetEvictionRate(gomockinternal.AContext(), "westus2", "Standard_D2s_v3").Return("", nil)
+			},
+			expectedCondition: ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name: "API error",
+			spec: warnSpec,
+			expect: func(m *mock_spotplacement.MockclientMockRecorder) {
+				m.GetPlacementScores(gomockinternal.AContext(), "westus2", "Standard_D2s_v3", int32(1), false).Return(nil, errors.New("some API error"))
+			},
+			expectedError: "failed to get Spot Placement Score of VM size Standard_D2s_v3: some API error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_spotplacement.NewMockSpotPlacementScope(mockCtrl)
+			clientMock := mock_spotplacement.NewMockclient(mockCtrl)
+
+			machine := &infrav1.AzureMachine{}
+			if tc.existingCondition != nil {
+				conditions.Set(machine, conditions.FalseCondition(infrav1.SpotCapacityAvailableCondition, infrav1.SpotPlacementScoreLowReason, clusterv1.ConditionSeverityWarning, ""))
+			}
+			scopeMock.EXPECT().SpotPlacementCheckSpec().Return(tc.spec)
+			scopeMock.EXPECT().SpotPlacementResource().Return(machine).AnyTimes()
+			tc.expect(clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+				var reconcileErr azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileErr) && reconcileErr.IsTransient()).To(Equal(tc.expectTransient))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			cond := conditions.Get(machine, infrav1.SpotCapacityAvailableCondition)
+			if tc.expectedCondition == nil {
+				g.Expect(cond).To(BeNil())
+			} else {
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(*tc.expectedCondition))
+				if tc.expectedReason != "" {
+					g.Expect(cond.Reason).To(Equal(tc.expectedReason))
+				}
+			}
+		})
+	}
+}
+
+func TestEvictionRateUpperBound(t *testing.T) {
+	g := NewWithT(t)
+
+	upperBound, ok := evictionRateUpperBound("5-10")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(upperBound).To(Equal(10))
+
+	upperBound, ok = evictionRateUpperBound("20+")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(upperBound).To(Equal(101))
+
+	_, ok = evictionRateUpperBound("")
+	g.Expect(ok).To(BeFalse())
+}
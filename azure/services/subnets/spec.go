@@ -22,6 +22,7 @@ import (
 
 	asonetworkv1 "github.com/Azure/azure-service-operator/v2/api/network/v1api20201101"
 	"github.com/Azure/azure-service-operator/v2/pkg/genruntime"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -41,6 +42,14 @@ type SubnetSpec struct {
 	SecurityGroupName string
 	NatGatewayName    string
 	ServiceEndpoints  infrav1.ServiceEndpoints
+	Delegations       infrav1.Delegations
+
+	// SecurityGroupResourceGroup is the resource group of an existing security group not managed by CAPZ.
+	SecurityGroupResourceGroup string
+
+	// DisablePrivateLinkServiceNetworkPolicies is true for the subnet of a private link service, which Azure requires
+	// to have network policies for private link services disabled.
+	DisablePrivateLinkServiceNetworkPolicies bool
 }
 
 // ResourceRef implements azure.ASOResourceSpecGetter.
@@ -90,19 +99,51 @@ func (s *SubnetSpec) Parameters(ctx context.Context, existing *asonetworkv1.Virt
 	}
 
 	if s.SecurityGroupName != "" {
-		subnet.Spec.NetworkSecurityGroup = &asonetworkv1.NetworkSecurityGroupSpec_VirtualNetworks_Subnet_SubResourceEmbedded{
-			Reference: &genruntime.ResourceReference{
-				ARMID: azure.SecurityGroupID(s.SubscriptionID, s.VNetResourceGroup, s.SecurityGroupName),
-			},
+		securityGroupID := azure.SecurityGroupID(s.SubscriptionID, s.VNetResourceGroup, s.SecurityGroupName)
+		if s.SecurityGroupResourceGroup != "" {
+			securityGroupID = azure.SecurityGroupID(s.SubscriptionID, s.SecurityGroupResourceGroup, s.SecurityGroupName)
+		}
+		if s.SecurityGroupResourceGroup != "" && !s.IsVNetManaged {
+			// Neither the security group nor the subnet is managed by CAPZ, so only verify the subnet is attached to it
+			// once its status is known.
+			if existing != nil && existing.Status.Id != nil {
+				var attachedID string
+				if existing.Status.NetworkSecurityGroup != nil {
+					attachedID = ptr.Deref(existing.Status.NetworkSecurityGroup.Id, "")
+				}
+				if attachedID == "" {
+					return nil, errors.Errorf("subnet %s is not attached to network security group %s", s.Name, securityGroupID)
+				}
+				if !strings.EqualFold(attachedID, securityGroupID) {
+					return nil, errors.Errorf("subnet %s is attached to network security group %s, expected %s",
+						s.Name, attachedID, securityGroupID)
+				}
+			}
+		} else {
+			subnet.Spec.NetworkSecurityGroup = &asonetworkv1.NetworkSecurityGroupSpec_VirtualNetworks_Subnet_SubResourceEmbedded{
+				Reference: &genruntime.ResourceReference{
+					ARMID: securityGroupID,
+				},
+			}
 		}
 	}
 
+	if s.DisablePrivateLinkServiceNetworkPolicies {
+		subnet.Spec.PrivateLinkServiceNetworkPolicies = ptr.To(asonetworkv1.SubnetPropertiesFormat_PrivateLinkServiceNetworkPolicies_Disabled)
+	}
+
 	var serviceEndpoints []asonetworkv1.ServiceEndpointPropertiesFormat
 	for _, se := range s.ServiceEndpoints {
 		serviceEndpoints = append(serviceEndpoints, asonetworkv1.ServiceEndpointPropertiesFormat{Service: ptr.To(se.Service), Locations: se.Locations})
 	}
 	subnet.Spec.ServiceEndpoints = serviceEndpoints
 
+	var delegations []asonetworkv1.Delegation
+	for _, d := range s.Delegations {
+		delegations = append(delegations, asonetworkv1.Delegation{Name: ptr.To(d.Name), ServiceName: ptr.To(d.ServiceName)})
+	}
+	subnet.Spec.Delegations = delegations
+
 	return subnet, nil
 }
 
@@ -30,10 +30,11 @@ import (
 
 func TestParameters(t *testing.T) {
 	tests := []struct {
-		name     string
-		spec     *SubnetSpec
-		existing *asonetworkv1.VirtualNetworksSubnet
-		expected *asonetworkv1.VirtualNetworksSubnet
+		name        string
+		spec        *SubnetSpec
+		existing    *asonetworkv1.VirtualNetworksSubnet
+		expected    *asonetworkv1.VirtualNetworksSubnet
+		expectedErr string
 	}{
 		{
 			name: "no existing subnet",
@@ -54,6 +55,12 @@ func TestParameters(t *testing.T) {
 						Locations: []string{"location"},
 					},
 				},
+				Delegations: infrav1.Delegations{
+					{
+						Name:        "delegation",
+						ServiceName: "Microsoft.NetApp/volumes",
+					},
+				},
 			},
 			existing: nil,
 			expected: &asonetworkv1.VirtualNetworksSubnet{
@@ -85,6 +92,12 @@ func TestParameters(t *testing.T) {
 							Locations: []string{"location"},
 						},
 					},
+					Delegations: []asonetworkv1.Delegation{
+						{
+							Name:        ptr.To("delegation"),
+							ServiceName: ptr.To("Microsoft.NetApp/volumes"),
+						},
+					},
 				},
 			},
 		},
@@ -148,6 +161,150 @@ func TestParameters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with existing security group from another resource group in a managed vnet",
+			spec: &SubnetSpec{
+				Name:                       "subnet",
+				SubscriptionID:             "sub",
+				ResourceGroup:              "rg",
+				VNetName:                   "vnet",
+				VNetResourceGroup:          "vnet-rg",
+				IsVNetManaged:              true,
+				CIDRs:                      []string{"cidr"},
+				SecurityGroupName:          "securitygroup",
+				SecurityGroupResourceGroup: "nsg-rg",
+			},
+			existing: &asonetworkv1.VirtualNetworksSubnet{
+				Status: asonetworkv1.VirtualNetworks_Subnet_STATUS{
+					NetworkSecurityGroup: &asonetworkv1.NetworkSecurityGroup_STATUS_VirtualNetworks_Subnet_SubResourceEmbedded{
+						Id: ptr.To("/subscriptions/sub/resourceGroups/nsg-rg/providers/Microsoft.Network/networkSecurityGroups/securitygroup"),
+					},
+				},
+			},
+			expected: &asonetworkv1.VirtualNetworksSubnet{
+				Spec: asonetworkv1.VirtualNetworks_Subnet_Spec{
+					AzureName: "subnet",
+					Owner: &genruntime.KnownResourceReference{
+						Name: "vnet",
+					},
+					AddressPrefixes: []string{"cidr"},
+					AddressPrefix:   ptr.To("cidr"),
+					NetworkSecurityGroup: &asonetworkv1.NetworkSecurityGroupSpec_VirtualNetworks_Subnet_SubResourceEmbedded{
+						Reference: &genruntime.ResourceReference{
+							ARMID: "/subscriptions/sub/resourceGroups/nsg-rg/providers/Microsoft.Network/networkSecurityGroups/securitygroup",
+						},
+					},
+				},
+				Status: asonetworkv1.VirtualNetworks_Subnet_STATUS{
+					NetworkSecurityGroup: &asonetworkv1.NetworkSecurityGroup_STATUS_VirtualNetworks_Subnet_SubResourceEmbedded{
+						Id: ptr.To("/subscriptions/sub/resourceGroups/nsg-rg/providers/Microsoft.Network/networkSecurityGroups/securitygroup"),
+					},
+				},
+			},
+		},
+		{
+			name: "with private link service network policies disabled",
+			spec: &SubnetSpec{
+				Name:              "subnet",
+				SubscriptionID:    "sub",
+				ResourceGroup:     "rg",
+				VNetName:          "vnet",
+				VNetResourceGroup: "vnet-rg",
+				CIDRs:             []string{"cidr"},
+
+				DisablePrivateLinkServiceNetworkPolicies: true,
+			},
+			existing: nil,
+			expected: &asonetworkv1.VirtualNetworksSubnet{
+				Spec: asonetworkv1.VirtualNetworks_Subnet_Spec{
+					AzureName: "subnet",
+					Owner: &genruntime.KnownResourceReference{
+						Name: "vnet",
+					},
+					AddressPrefixes:                   []string{"cidr"},
+					AddressPrefix:                     ptr.To("cidr"),
+					PrivateLinkServiceNetworkPolicies: ptr.To(asonetworkv1.SubnetPropertiesFormat_PrivateLinkServiceNetworkPolicies_Disabled),
+				},
+			},
+		},
+		{
+			name: "with existing security group from another resource group in an unmanaged vnet",
+			spec: &SubnetSpec{
+				Name:                       "subnet",
+				SubscriptionID:             "sub",
+				ResourceGroup:              "rg",
+				VNetName:                   "vnet",
+				VNetResourceGroup:          "vnet-rg",
+				CIDRs:                      []string{"cidr"},
+				SecurityGroupName:          "securitygroup",
+				SecurityGroupResourceGroup: "nsg-rg",
+			},
+			existing: &asonetworkv1.VirtualNetworksSubnet{
+				Status: asonetworkv1.VirtualNetworks_Subnet_STATUS{
+					Id: ptr.To("/subscriptions/sub/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"),
+					NetworkSecurityGroup: &asonetworkv1.NetworkSecurityGroup_STATUS_VirtualNetworks_Subnet_SubResourceEmbedded{
+						Id: ptr.To("/subscriptions/sub/resourceGroups/NSG-RG/providers/Microsoft.Network/networkSecurityGroups/securitygroup"),
+					},
+				},
+			},
+			expected: &asonetworkv1.VirtualNetworksSubnet{
+				Spec: asonetworkv1.VirtualNetworks_Subnet_Spec{
+					AzureName: "subnet",
+					Owner: &genruntime.KnownResourceReference{
+						Name: "vnet",
+					},
+					AddressPrefixes: []string{"cidr"},
+					AddressPrefix:   ptr.To("cidr"),
+				},
+				Status: asonetworkv1.VirtualNetworks_Subnet_STATUS{
+					Id: ptr.To("/subscriptions/sub/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"),
+					NetworkSecurityGroup: &asonetworkv1.NetworkSecurityGroup_STATUS_VirtualNetworks_Subnet_SubResourceEmbedded{
+						Id: ptr.To("/subscriptions/sub/resourceGroups/NSG-RG/providers/Microsoft.Network/networkSecurityGroups/securitygroup"),
+					},
+				},
+			},
+		},
+		{
+			name: "with existing subnet not attached to the security group from another resource group",
+			spec: &SubnetSpec{
+				Name:                       "subnet",
+				SubscriptionID:             "sub",
+				ResourceGroup:              "rg",
+				VNetName:                   "vnet",
+				VNetResourceGroup:          "vnet-rg",
+				CIDRs:                      []string{"cidr"},
+				SecurityGroupName:          "securitygroup",
+				SecurityGroupResourceGroup: "nsg-rg",
+			},
+			existing: &asonetworkv1.VirtualNetworksSubnet{
+				Status: asonetworkv1.VirtualNetworks_Subnet_STATUS{
+					Id: ptr.To("/subscriptions/sub/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"),
+				},
+			},
+			expectedErr: "subnet subnet is not attached to network security group /subscriptions/sub/resourceGroups/nsg-rg/providers/Microsoft.Network/networkSecurityGroups/securitygroup",
+		},
+		{
+			name: "with existing subnet attached to another security group than the one from another resource group",
+			spec: &SubnetSpec{
+				Name:                       "subnet",
+				SubscriptionID:             "sub",
+				ResourceGroup:              "rg",
+				VNetName:                   "vnet",
+				VNetResourceGroup:          "vnet-rg",
+				CIDRs:                      []string{"cidr"},
+				SecurityGroupName:          "securitygroup",
+				SecurityGroupResourceGroup: "nsg-rg",
+			},
+			existing: &asonetworkv1.VirtualNetworksSubnet{
+				Status: asonetworkv1.VirtualNetworks_Subnet_STATUS{
+					Id: ptr.To("/subscriptions/sub/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"),
+					NetworkSecurityGroup: &asonetworkv1.NetworkSecurityGroup_STATUS_VirtualNetworks_Subnet_SubResourceEmbedded{
+						Id: ptr.To("/subscriptions/sub/resourceGroups/vnet-rg/providers/Microsoft.Network/networkSecurityGroups/other"),
+					},
+				},
+			},
+			expectedErr: "subnet subnet is attached to network security group /subscriptions/sub/resourceGroups/vnet-rg/providers/Microsoft.Network/networkSecurityGroups/other, expected /subscriptions/sub/resourceGroups/nsg-rg/providers/Microsoft.Network/networkSecurityGroups/securitygroup",
+		},
 	}
 
 	for _, test := range tests {
@@ -155,6 +312,10 @@ func TestParameters(t *testing.T) {
 			g := NewGomegaWithT(t)
 
 			result, err := test.spec.Parameters(context.Background(), test.existing)
+			if test.expectedErr != "" {
+				g.Expect(err).To(MatchError(test.expectedErr))
+				return
+			}
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(cmp.Diff(test.expected, result)).To(BeEmpty())
 		})
@@ -22,8 +22,11 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/pkg/errors"
 	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -94,6 +97,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			return err
 		}
 		changed, createdOrUpdated, deleted, newAnnotation := TagsChanged(lastAppliedTags, tagsSpec.Tags, tags)
+		if changed && s.dryRun() {
+			// The annotation isn't updated either, so that the tags are still updated once dry-run mode is disabled.
+			s.recordEvent(infrav1.DryRunReason, "Would update tags of resource %s (service: %s)", tagsSpec.Scope, serviceName)
+			continue
+		}
 		if changed {
 			log.V(2).Info("Updating tags")
 			if len(createdOrUpdated) > 0 {
@@ -129,6 +137,19 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return nil
 }
 
+// dryRun returns true if the scope is reconciled in dry-run mode.
+func (s *Service) dryRun() bool {
+	d, ok := s.Scope.(async.DryRunner)
+	return ok && d.DryRun()
+}
+
+// recordEvent records a Normal Event on the scope's object, if the scope has one.
+func (s *Service) recordEvent(reason, message string, args ...interface{}) {
+	if getter, ok := s.Scope.(async.EventObjectGetter); ok {
+		record.Eventf(getter.EventObject(), reason, message, args...)
+	}
+}
+
 func (s *Service) isResourceManaged(tags map[string]*string) bool {
 	return converters.MapToTags(tags).HasOwned(s.Scope.ClusterName())
 }
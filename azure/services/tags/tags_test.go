@@ -27,10 +27,14 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	cgrecord "k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags/mock_tags"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func internalError() *azcore.ResponseError {
@@ -294,6 +298,55 @@ func TestReconcileTags(t *testing.T) {
 	}
 }
 
+func TestReconcileTagsDryRun(t *testing.T) {
+	g := NewWithT(t)
+
+	recorder := cgrecord.NewFakeRecorder(10)
+	record.InitFromRecorder(recorder)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_tags.NewMockTagScope(mockCtrl)
+	clientMock := mock_tags.NewMockclient(mockCtrl)
+
+	scopeMock.EXPECT().ClusterName().AnyTimes().Return("test-cluster")
+	scopeMock.EXPECT().TagsSpecs().Return([]azure.TagsSpec{
+		{
+			Scope:      "/sub/123/fake/scope",
+			Tags:       map[string]string{"foo": "bar"},
+			Annotation: "my-annotation",
+		},
+	})
+	clientMock.EXPECT().GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(armresources.TagsResource{Properties: &armresources.Tags{
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": ptr.To("owned"),
+		},
+	}}, nil)
+	scopeMock.EXPECT().AnnotationJSON("my-annotation")
+
+	s := &Service{
+		Scope:  dryRunScope{MockTagScope: scopeMock, object: &infrav1.AzureCluster{}},
+		client: clientMock,
+	}
+
+	g.Expect(s.Reconcile(context.TODO())).To(Succeed())
+	g.Expect(recorder.Events).To(Receive(Equal("Normal DryRun Would update tags of resource /sub/123/fake/scope (service: tags)")))
+}
+
+// dryRunScope is a TagScope reconciled in dry-run mode whose object receives Events.
+type dryRunScope struct {
+	*mock_tags.MockTagScope
+	object ctrlclient.Object
+}
+
+func (s dryRunScope) EventObject() ctrlclient.Object {
+	return s.object
+}
+
+func (s dryRunScope) DryRun() bool {
+	return true
+}
+
 func TestTagsChanged(t *testing.T) {
 	g := NewWithT(t)
 
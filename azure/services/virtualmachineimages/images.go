@@ -49,15 +49,31 @@ func New(auth azure.Authorizer) (*Service, error) {
 
 // GetDefaultUbuntuImage returns the default image spec for Ubuntu.
 func (s *Service) GetDefaultUbuntuImage(ctx context.Context, location, k8sVersion string) (*infrav1.Image, error) {
+	return s.getDefaultUbuntuImage(ctx, location, k8sVersion, false)
+}
+
+// GetDefaultFIPSUbuntuImage returns the default image spec for FIPS-enabled Ubuntu.
+func (s *Service) GetDefaultFIPSUbuntuImage(ctx context.Context, location, k8sVersion string) (*infrav1.Image, error) {
+	return s.getDefaultUbuntuImage(ctx, location, k8sVersion, true)
+}
+
+func (s *Service) getDefaultUbuntuImage(ctx context.Context, location, k8sVersion string, fips bool) (*infrav1.Image, error) {
 	v, err := semver.ParseTolerant(k8sVersion)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to parse Kubernetes version \"%s\"", k8sVersion)
 	}
 
-	osVersion := getUbuntuOSVersion(v.Major, v.Minor, v.Patch)
+	osAndVersion := fmt.Sprintf("ubuntu-%s", getUbuntuOSVersion(v.Major, v.Minor, v.Patch))
+	if fips {
+		// FIPS-enabled images are only published with the newer SKU names, like "ubuntu-2204-fips-gen1".
+		if k8sVersionInSKUName(v.Major, v.Minor, v.Patch) {
+			return nil, azure.WithTerminalError(errors.Errorf("no FIPS-enabled default image for Kubernetes version \"%s\"", k8sVersion))
+		}
+		osAndVersion += "-fips"
+	}
 	publisher, offer := azure.DefaultImagePublisherID, azure.DefaultImageOfferID
 	skuID, version, err := s.getSKUAndVersion(
-		ctx, location, publisher, offer, k8sVersion, fmt.Sprintf("ubuntu-%s", osVersion))
+		ctx, location, publisher, offer, k8sVersion, osAndVersion)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get default image")
 	}
@@ -174,7 +190,8 @@ func (s *Service) getSKUAndVersion(ctx context.Context, location, publisher, off
 		}
 	}
 	if version == "" {
-		return "", "", errors.Errorf("no VM image found for publisher \"%s\" offer \"%s\" sku \"%s\" with Kubernetes version \"%s\"", publisher, offer, sku, k8sVersion)
+		// There are images for the SKU, but none for the version of Kubernetes, so the machine can't be created.
+		return "", "", azure.WithTerminalError(errors.Errorf("no VM image found for publisher \"%s\" offer \"%s\" sku \"%s\" with Kubernetes version \"%s\"", publisher, offer, sku, k8sVersion))
 	}
 
 	log.V(4).Info("Found VM image SKU and version", "location", location, "publisher", publisher, "offer", offer, "sku", sku, "version", version)
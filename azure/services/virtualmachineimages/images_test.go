@@ -247,6 +247,65 @@ func TestGetDefaultUbuntuImage(t *testing.T) {
 	}
 }
 
+func TestGetDefaultFIPSUbuntuImage(t *testing.T) {
+	tests := []struct {
+		k8sVersion      string
+		expectedSKU     string
+		expectedVersion string
+		versions        armcompute.VirtualMachineImagesClientListResponse
+		expectedError   bool
+	}{
+		{
+			k8sVersion:    "v1.21.12",
+			expectedError: true,
+		},
+		{
+			k8sVersion:      "v1.28.3",
+			expectedSKU:     "ubuntu-2204-fips-gen1",
+			expectedVersion: "128.3.20231101",
+			versions: armcompute.VirtualMachineImagesClientListResponse{
+				VirtualMachineImageResourceArray: []*armcompute.VirtualMachineImageResource{
+					{Name: ptr.To("128.3.20231101")},
+				},
+			},
+		},
+	}
+
+	location := "usgovvirginia"
+	for _, test := range tests {
+		test := test
+		t.Run(test.k8sVersion, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockAuth := mock_azure.NewMockAuthorizer(mockCtrl)
+			mockAuth.EXPECT().HashKey().Return(t.Name()).AnyTimes()
+			mockAuth.EXPECT().SubscriptionID().AnyTimes()
+			mockAuth.EXPECT().CloudEnvironment().AnyTimes()
+			mockAuth.EXPECT().Token().Return(&azidentity.DefaultAzureCredential{}).AnyTimes()
+			mockClient := mock_virtualmachineimages.NewMockClient(mockCtrl)
+			svc := Service{Client: mockClient, Authorizer: mockAuth}
+
+			if test.versions.VirtualMachineImageResourceArray != nil {
+				mockClient.EXPECT().
+					List(gomock.Any(), location, azure.DefaultImagePublisherID, azure.DefaultImageOfferID, test.expectedSKU).
+					Return(test.versions, nil)
+			}
+			image, err := svc.GetDefaultFIPSUbuntuImage(context.TODO(), location, test.k8sVersion)
+
+			g := NewWithT(t)
+			if test.expectedError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(image.Marketplace.Version).To(Equal(test.expectedVersion))
+			g.Expect(image.Marketplace.SKU).To(Equal(test.expectedSKU))
+		})
+	}
+}
+
 func TestGetDefaultWindowsImage(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
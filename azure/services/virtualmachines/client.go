@@ -18,6 +18,7 @@ package virtualmachines
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
@@ -41,10 +42,20 @@ type (
 		Get(context.Context, azure.ResourceSpecGetter) (interface{}, error)
 		CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, parameters interface{}) (result interface{}, poller *runtime.Poller[armcompute.VirtualMachinesClientCreateOrUpdateResponse], err error)
 		DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armcompute.VirtualMachinesClientDeleteResponse], err error)
+		RetrieveBootDiagnosticsData(ctx context.Context, spec azure.ResourceSpecGetter) (serialConsoleLogURI, consoleScreenshotURI string, err error)
+		Restart(ctx context.Context, spec azure.ResourceSpecGetter) error
+		Redeploy(ctx context.Context, spec azure.ResourceSpecGetter) error
+		GetPowerState(ctx context.Context, spec azure.ResourceSpecGetter) (string, error)
+		Deallocate(ctx context.Context, spec azure.ResourceSpecGetter) error
+		UpdateSize(ctx context.Context, spec azure.ResourceSpecGetter, size string) error
+		Start(ctx context.Context, spec azure.ResourceSpecGetter) error
 	}
 )
 
-var _ Client = &AzureClient{}
+var (
+	_ Client       = &AzureClient{}
+	_ async.Lister = &AzureClient{}
+)
 
 // NewClient creates a VMs client from an authorizer.
 func NewClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*AzureClient, error) {
@@ -71,6 +82,25 @@ func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (
 	return resp.VirtualMachine, nil
 }
 
+// ListByResourceGroup returns the model view of all virtual machines in a resource group, keyed by name.
+func (ac *AzureClient) ListByResourceGroup(ctx context.Context, resourceGroupName string) (map[string]interface{}, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.ListByResourceGroup")
+	defer done()
+
+	vms := make(map[string]interface{})
+	pager := ac.virtualmachines.NewListPager(resourceGroupName, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not iterate virtual machines")
+		}
+		for _, vm := range nextResult.Value {
+			vms[ptr.Deref(vm.Name, "")] = *vm
+		}
+	}
+	return vms, nil
+}
+
 // CreateOrUpdateAsync creates or updates a virtual machine asynchronously.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
 // progress of the operation.
@@ -132,3 +162,96 @@ func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	// if the operation completed, return a nil poller.
 	return nil, err
 }
+
+// RetrieveBootDiagnosticsData returns the URIs of the serial log and the screenshot of a virtual machine, without the
+// SAS tokens granting access to them.
+func (ac *AzureClient) RetrieveBootDiagnosticsData(ctx context.Context, spec azure.ResourceSpecGetter) (serialConsoleLogURI, consoleScreenshotURI string, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.RetrieveBootDiagnosticsData")
+	defer done()
+
+	resp, err := ac.virtualmachines.RetrieveBootDiagnosticsData(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	if err != nil {
+		return "", "", err
+	}
+	return withoutQuery(ptr.Deref(resp.SerialConsoleLogBlobURI, "")), withoutQuery(ptr.Deref(resp.ConsoleScreenshotBlobURI, "")), nil
+}
+
+// Restart requests the restart of a virtual machine, without waiting for the virtual machine to be restarted.
+func (ac *AzureClient) Restart(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Restart")
+	defer done()
+
+	_, err := ac.virtualmachines.BeginRestart(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	return err
+}
+
+// Redeploy requests the redeployment of a virtual machine to a new Azure host, without waiting for the virtual
+// machine to be redeployed.
+func (ac *AzureClient) Redeploy(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Redeploy")
+	defer done()
+
+	_, err := ac.virtualmachines.BeginRedeploy(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	return err
+}
+
+// GetPowerState returns the power state of a virtual machine from its instance view, e.g. "running" or "deallocated".
+// It returns an empty string if the power state is unknown.
+func (ac *AzureClient) GetPowerState(ctx context.Context, spec azure.ResourceSpecGetter) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.GetPowerState")
+	defer done()
+
+	resp, err := ac.virtualmachines.InstanceView(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	if err != nil {
+		return "", err
+	}
+	for _, status := range resp.Statuses {
+		if status == nil {
+			continue
+		}
+		if powerState, ok := strings.CutPrefix(ptr.Deref(status.Code, ""), "PowerState/"); ok {
+			return powerState, nil
+		}
+	}
+	return "", nil
+}
+
+// Deallocate requests the deallocation of a virtual machine, without waiting for the virtual machine to be deallocated.
+func (ac *AzureClient) Deallocate(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Deallocate")
+	defer done()
+
+	_, err := ac.virtualmachines.BeginDeallocate(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	return err
+}
+
+// UpdateSize requests the change of the size of a virtual machine, without waiting for the virtual machine to be updated.
+func (ac *AzureClient) UpdateSize(ctx context.Context, spec azure.ResourceSpecGetter, size string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.UpdateSize")
+	defer done()
+
+	update := armcompute.VirtualMachineUpdate{
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{
+				VMSize: ptr.To(armcompute.VirtualMachineSizeTypes(size)),
+			},
+		},
+	}
+	_, err := ac.virtualmachines.BeginUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), update, nil)
+	return err
+}
+
+// Start requests the start of a virtual machine, without waiting for the virtual machine to be running.
+func (ac *AzureClient) Start(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Start")
+	defer done()
+
+	_, err := ac.virtualmachines.BeginStart(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	return err
+}
+
+// withoutQuery returns a URI without its query.
+func withoutQuery(uri string) string {
+	before, _, _ := strings.Cut(uri, "?")
+	return before
+}
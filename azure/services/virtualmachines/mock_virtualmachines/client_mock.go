@@ -74,6 +74,20 @@ func (mr *MockClientMockRecorder) CreateOrUpdateAsync(ctx, spec, resumeToken, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateAsync", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateAsync), ctx, spec, resumeToken, parameters)
 }
 
+// Deallocate mocks base method.
+func (m *MockClient) Deallocate(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deallocate", ctx, spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deallocate indicates an expected call of Deallocate.
+func (mr *MockClientMockRecorder) Deallocate(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deallocate", reflect.TypeOf((*MockClient)(nil).Deallocate), ctx, spec)
+}
+
 // DeleteAsync mocks base method.
 func (m *MockClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (*runtime.Poller[armcompute.VirtualMachinesClientDeleteResponse], error) {
 	m.ctrl.T.Helper()
@@ -103,3 +117,90 @@ func (mr *MockClientMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockClient)(nil).Get), arg0, arg1)
 }
+
+// GetPowerState mocks base method.
+func (m *MockClient) GetPowerState(ctx context.Context, spec azure.ResourceSpecGetter) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPowerState", ctx, spec)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPowerState indicates an expected call of GetPowerState.
+func (mr *MockClientMockRecorder) GetPowerState(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerState", reflect.TypeOf((*MockClient)(nil).GetPowerState), ctx, spec)
+}
+
+// Redeploy mocks base method.
+func (m *MockClient) Redeploy(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeploy", ctx, spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeploy indicates an expected call of Redeploy.
+func (mr *MockClientMockRecorder) Redeploy(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeploy", reflect.TypeOf((*MockClient)(nil).Redeploy), ctx, spec)
+}
+
+// Restart mocks base method.
+func (m *MockClient) Restart(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restart", ctx, spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restart indicates an expected call of Restart.
+func (mr *MockClientMockRecorder) Restart(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restart", reflect.TypeOf((*MockClient)(nil).Restart), ctx, spec)
+}
+
+// RetrieveBootDiagnosticsData mocks base method.
+func (m *MockClient) RetrieveBootDiagnosticsData(ctx context.Context, spec azure.ResourceSpecGetter) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveBootDiagnosticsData", ctx, spec)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RetrieveBootDiagnosticsData indicates an expected call of RetrieveBootDiagnosticsData.
+func (mr *MockClientMockRecorder) RetrieveBootDiagnosticsData(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveBootDiagnosticsData", reflect.TypeOf((*MockClient)(nil).RetrieveBootDiagnosticsData), ctx, spec)
+}
+
+// Start mocks base method.
+func (m *MockClient) Start(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockClientMockRecorder) Start(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockClient)(nil).Start), ctx, spec)
+}
+
+// UpdateSize mocks base method.
+func (m *MockClient) UpdateSize(ctx context.Context, spec azure.ResourceSpecGetter, size string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSize", ctx, spec, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSize indicates an expected call of UpdateSize.
+func (mr *MockClientMockRecorder) UpdateSize(ctx, spec, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSize", reflect.TypeOf((*MockClient)(nil).UpdateSize), ctx, spec, size)
+}
@@ -60,6 +60,34 @@ func (m *MockVMScope) EXPECT() *MockVMScopeMockRecorder {
 	return m.recorder
 }
 
+// AzureServiceDeleteTimeout mocks base method.
+func (m *MockVMScope) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceDeleteTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceDeleteTimeout indicates an expected call of AzureServiceDeleteTimeout.
+func (mr *MockVMScopeMockRecorder) AzureServiceDeleteTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceDeleteTimeout", reflect.TypeOf((*MockVMScope)(nil).AzureServiceDeleteTimeout), serviceName)
+}
+
+// AzureServiceReconcileTimeout mocks base method.
+func (m *MockVMScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureServiceReconcileTimeout", serviceName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// AzureServiceReconcileTimeout indicates an expected call of AzureServiceReconcileTimeout.
+func (mr *MockVMScopeMockRecorder) AzureServiceReconcileTimeout(serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureServiceReconcileTimeout", reflect.TypeOf((*MockVMScope)(nil).AzureServiceReconcileTimeout), serviceName)
+}
+
 // BaseURI mocks base method.
 func (m *MockVMScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -74,6 +102,44 @@ func (mr *MockVMScopeMockRecorder) BaseURI() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockVMScope)(nil).BaseURI))
 }
 
+// CaptureDiagnosticsOnDelete mocks base method.
+func (m *MockVMScope) CaptureDiagnosticsOnDelete() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureDiagnosticsOnDelete")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CaptureDiagnosticsOnDelete indicates an expected call of CaptureDiagnosticsOnDelete.
+func (mr *MockVMScopeMockRecorder) CaptureDiagnosticsOnDelete() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureDiagnosticsOnDelete", reflect.TypeOf((*MockVMScope)(nil).CaptureDiagnosticsOnDelete))
+}
+
+// ClearRedeployRequest mocks base method.
+func (m *MockVMScope) ClearRedeployRequest() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearRedeployRequest")
+}
+
+// ClearRedeployRequest indicates an expected call of ClearRedeployRequest.
+func (mr *MockVMScopeMockRecorder) ClearRedeployRequest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearRedeployRequest", reflect.TypeOf((*MockVMScope)(nil).ClearRedeployRequest))
+}
+
+// ClearRestartRequest mocks base method.
+func (m *MockVMScope) ClearRestartRequest() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearRestartRequest")
+}
+
+// ClearRestartRequest indicates an expected call of ClearRestartRequest.
+func (mr *MockVMScopeMockRecorder) ClearRestartRequest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearRestartRequest", reflect.TypeOf((*MockVMScope)(nil).ClearRestartRequest))
+}
+
 // ClientID mocks base method.
 func (m *MockVMScope) ClientID() string {
 	m.ctrl.T.Helper()
@@ -198,6 +264,62 @@ func (mr *MockVMScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockVMScope)(nil).HashKey))
 }
 
+// IsVMResizing mocks base method.
+func (m *MockVMScope) IsVMResizing() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsVMResizing")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsVMResizing indicates an expected call of IsVMResizing.
+func (mr *MockVMScopeMockRecorder) IsVMResizing() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsVMResizing", reflect.TypeOf((*MockVMScope)(nil).IsVMResizing))
+}
+
+// RedeployRequested mocks base method.
+func (m *MockVMScope) RedeployRequested() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeployRequested")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RedeployRequested indicates an expected call of RedeployRequested.
+func (mr *MockVMScopeMockRecorder) RedeployRequested() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeployRequested", reflect.TypeOf((*MockVMScope)(nil).RedeployRequested))
+}
+
+// ResizeInPlace mocks base method.
+func (m *MockVMScope) ResizeInPlace() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeInPlace")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ResizeInPlace indicates an expected call of ResizeInPlace.
+func (mr *MockVMScopeMockRecorder) ResizeInPlace() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeInPlace", reflect.TypeOf((*MockVMScope)(nil).ResizeInPlace))
+}
+
+// RestartRequested mocks base method.
+func (m *MockVMScope) RestartRequested() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestartRequested")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RestartRequested indicates an expected call of RestartRequested.
+func (mr *MockVMScopeMockRecorder) RestartRequested() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestartRequested", reflect.TypeOf((*MockVMScope)(nil).RestartRequested))
+}
+
 // SetAddresses mocks base method.
 func (m *MockVMScope) SetAddresses(arg0 []v1.NodeAddress) {
 	m.ctrl.T.Helper()
@@ -54,11 +54,13 @@ type VMSpec struct {
 	AdditionalCapabilities     *infrav1.AdditionalCapabilities
 	DiagnosticsProfile         *infrav1.Diagnostics
 	DisableExtensionOperations bool
+	WindowsConfiguration       *infrav1.WindowsConfiguration
 	CapacityReservationGroupID string
 	SKU                        resourceskus.SKU
 	Image                      *infrav1.Image
 	BootstrapData              string
 	ProviderID                 string
+	ResourceNaming             *infrav1.ResourceNamingSpec
 }
 
 // ResourceName returns the name of the virtual machine.
@@ -153,7 +155,7 @@ func (s *VMSpec) Parameters(ctx context.Context, existing interface{}) (params i
 // generateStorageProfile generates a pointer to an armcompute.StorageProfile which can utilized for VM creation.
 func (s *VMSpec) generateStorageProfile() (*armcompute.StorageProfile, error) {
 	osDisk := &armcompute.OSDisk{
-		Name:         ptr.To(azure.GenerateOSDiskName(s.Name)),
+		Name:         ptr.To(azure.OSDiskName(s.ResourceNaming, s.Name)),
 		OSType:       ptr.To(armcompute.OperatingSystemTypes(s.OSDisk.OSType)),
 		CreateOption: ptr.To(armcompute.DiskCreateOptionTypesFromImage),
 		DiskSizeGB:   s.OSDisk.DiskSizeGB,
@@ -229,7 +231,7 @@ func (s *VMSpec) generateStorageProfile() (*armcompute.StorageProfile, error) {
 			CreateOption: ptr.To(armcompute.DiskCreateOptionTypesEmpty),
 			DiskSizeGB:   ptr.To[int32](disk.DiskSizeGB),
 			Lun:          disk.Lun,
-			Name:         ptr.To(azure.GenerateDataDiskName(s.Name, disk.NameSuffix)),
+			Name:         ptr.To(azure.DataDiskName(s.ResourceNaming, s.Name, disk.NameSuffix)),
 		}
 		if disk.CachingType != "" {
 			dataDisks[i].Caching = ptr.To(armcompute.CachingTypes(disk.CachingType))
@@ -285,9 +287,7 @@ func (s *VMSpec) generateOSProfile() (*armcompute.OSProfile, error) {
 		// Access is provided via SSH public key that is set during deployment
 		// Azure also provides a way to reset user passwords in the case of need.
 		osProfile.AdminPassword = ptr.To(generators.SudoRandomPassword(123))
-		osProfile.WindowsConfiguration = &armcompute.WindowsConfiguration{
-			EnableAutomaticUpdates: ptr.To(false),
-		}
+		osProfile.WindowsConfiguration = s.generateWindowsConfiguration()
 	default:
 		osProfile.LinuxConfiguration = &armcompute.LinuxConfiguration{
 			DisablePasswordAuthentication: ptr.To(true),
@@ -305,6 +305,41 @@ func (s *VMSpec) generateOSProfile() (*armcompute.OSProfile, error) {
 	return osProfile, nil
 }
 
+// generateWindowsConfiguration generates the Windows configuration of the OS profile of a Windows VM.
+func (s *VMSpec) generateWindowsConfiguration() *armcompute.WindowsConfiguration {
+	windowsConfiguration := &armcompute.WindowsConfiguration{
+		EnableAutomaticUpdates: ptr.To(false),
+	}
+	if s.WindowsConfiguration == nil {
+		return windowsConfiguration
+	}
+
+	if patchMode := s.WindowsConfiguration.PatchMode; patchMode != "" {
+		windowsConfiguration.PatchSettings = &armcompute.PatchSettings{
+			PatchMode: ptr.To(armcompute.WindowsVMGuestPatchMode(patchMode)),
+		}
+		// Automatic patching requires automatic updates.
+		if patchMode != infrav1.WindowsPatchModeManual {
+			windowsConfiguration.EnableAutomaticUpdates = ptr.To(true)
+		}
+	}
+	if s.WindowsConfiguration.EnableAutomaticUpdates != nil {
+		windowsConfiguration.EnableAutomaticUpdates = ptr.To(*s.WindowsConfiguration.EnableAutomaticUpdates)
+	}
+	if s.WindowsConfiguration.TimeZone != "" {
+		windowsConfiguration.TimeZone = ptr.To(s.WindowsConfiguration.TimeZone)
+	}
+	for _, content := range s.WindowsConfiguration.AdditionalUnattendContent {
+		windowsConfiguration.AdditionalUnattendContent = append(windowsConfiguration.AdditionalUnattendContent, &armcompute.AdditionalUnattendContent{
+			ComponentName: ptr.To("Microsoft-Windows-Shell-Setup"),
+			PassName:      ptr.To("OobeSystem"),
+			SettingName:   ptr.To(armcompute.SettingNames(content.SettingName)),
+			Content:       ptr.To(content.Content),
+		})
+	}
+	return windowsConfiguration
+}
+
 func (s *VMSpec) generateSecurityProfile(storageProfile *armcompute.StorageProfile) (*armcompute.SecurityProfile, error) {
 	if s.SecurityProfile == nil {
 		return nil, nil
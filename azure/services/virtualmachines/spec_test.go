@@ -365,6 +365,87 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a windows vm with windows configuration",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: ptr.To("fake-image-id")},
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Windows",
+					DiskSizeGB: ptr.To[int32](128),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: string(armcompute.StorageAccountTypesPremiumLRS),
+					},
+				},
+				WindowsConfiguration: &infrav1.WindowsConfiguration{
+					EnableAutomaticUpdates: ptr.To(true),
+					TimeZone:               "Pacific Standard Time",
+					AdditionalUnattendContent: []infrav1.AdditionalUnattendContent{
+						{
+							SettingName: infrav1.UnattendSettingNameFirstLogonCommands,
+							Content:     "<FirstLogonCommands></FirstLogonCommands>",
+						},
+					},
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcompute.VirtualMachine{}))
+				g.Expect(result.(armcompute.VirtualMachine).Properties.OSProfile.WindowsConfiguration).To(Equal(&armcompute.WindowsConfiguration{
+					EnableAutomaticUpdates: ptr.To(true),
+					TimeZone:               ptr.To("Pacific Standard Time"),
+					AdditionalUnattendContent: []*armcompute.AdditionalUnattendContent{
+						{
+							ComponentName: ptr.To("Microsoft-Windows-Shell-Setup"),
+							PassName:      ptr.To("OobeSystem"),
+							SettingName:   ptr.To(armcompute.SettingNamesFirstLogonCommands),
+							Content:       ptr.To("<FirstLogonCommands></FirstLogonCommands>"),
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a windows vm patched by the platform",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: ptr.To("fake-image-id")},
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Windows",
+					DiskSizeGB: ptr.To[int32](128),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: string(armcompute.StorageAccountTypesPremiumLRS),
+					},
+				},
+				WindowsConfiguration: &infrav1.WindowsConfiguration{
+					PatchMode: infrav1.WindowsPatchModeAutomaticByPlatform,
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcompute.VirtualMachine{}))
+				g.Expect(result.(armcompute.VirtualMachine).Properties.OSProfile.WindowsConfiguration).To(Equal(&armcompute.WindowsConfiguration{
+					EnableAutomaticUpdates: ptr.To(true),
+					PatchSettings: &armcompute.PatchSettings{
+						PatchMode: ptr.To(armcompute.WindowsVMGuestPatchModeAutomaticByPlatform),
+					},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name: "can create a vm with encryption",
 			spec: &VMSpec{
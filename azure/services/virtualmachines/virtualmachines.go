@@ -18,7 +18,9 @@ package virtualmachines
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
@@ -29,10 +31,12 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/activitylogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -41,6 +45,15 @@ import (
 const serviceName = "virtualmachine"
 const vmMissingUAI = "VM is missing expected user assigned identity with client ID: "
 
+const (
+	powerStateRunning      = "running"
+	powerStateDeallocating = "deallocating"
+	powerStateDeallocated  = "deallocated"
+
+	// resizeRequeue is how long to wait before checking the progress of an in-place resize again.
+	resizeRequeue = 30 * time.Second
+)
+
 // VMScope defines the scope interface for a virtual machines service.
 type VMScope interface {
 	azure.Authorizer
@@ -51,15 +64,24 @@ type VMScope interface {
 	SetAddresses([]corev1.NodeAddress)
 	SetVMState(infrav1.ProvisioningState)
 	SetConditionFalse(clusterv1.ConditionType, string, clusterv1.ConditionSeverity, string)
+	CaptureDiagnosticsOnDelete() bool
+	RestartRequested() bool
+	ClearRestartRequest()
+	RedeployRequested() bool
+	ClearRedeployRequest()
+	ResizeInPlace() bool
+	IsVMResizing() bool
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VMScope
 	async.Reconciler
+	client           Client
 	interfacesGetter async.Getter
 	publicIPsGetter  async.Getter
 	identitiesGetter identities.Client
+	activityLogs     activitylogs.Client
 }
 
 // New creates a new service.
@@ -80,13 +102,21 @@ func New(scope VMScope) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	activityLogsSvc, err := activitylogs.NewClient(scope)
+	if err != nil {
+		return nil, err
+	}
+	cachingClient := async.NewCachingClient[armcompute.VirtualMachinesClientCreateOrUpdateResponse,
+		armcompute.VirtualMachinesClientDeleteResponse](scope, Client)
 	return &Service{
 		Scope:            scope,
-		interfacesGetter: interfacesSvc,
-		publicIPsGetter:  publicIPsSvc,
+		client:           Client,
+		interfacesGetter: async.NewCachingGetter(scope, interfacesSvc),
+		publicIPsGetter:  async.NewCachingGetter(scope, publicIPsSvc),
 		identitiesGetter: identitiesSvc,
+		activityLogs:     activityLogsSvc,
 		Reconciler: async.New[armcompute.VirtualMachinesClientCreateOrUpdateResponse,
-			armcompute.VirtualMachinesClientDeleteResponse](scope, Client, Client),
+			armcompute.VirtualMachinesClientDeleteResponse](scope, cachingClient, cachingClient),
 	}, nil
 }
 
@@ -100,7 +130,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceReconcileTimeout(s.Name()))
 	defer cancel()
 
 	vmSpec := s.Scope.VMSpec()
@@ -133,6 +163,9 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 		s.Scope.SetAddresses(addresses)
 		s.Scope.SetVMState(infraVM.State)
+		if infraVM.State == infrav1.Failed {
+			s.setProvisionFailedCondition(ctx, infraVM.ID)
+		}
 
 		spec, ok := vmSpec.(*VMSpec)
 		if !ok {
@@ -143,16 +176,120 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to check user assigned identities")
 		}
+
+		if s.Scope.RestartRequested() && infraVM.State == infrav1.Succeeded {
+			if err := s.client.Restart(ctx, vmSpec); err != nil {
+				return errors.Wrap(err, "failed to restart VM")
+			}
+			async.Forget(s.Scope, vmSpec)
+			s.Scope.ClearRestartRequest()
+			if getter, ok := s.Scope.(async.EventObjectGetter); ok {
+				record.Eventf(getter.EventObject(), "VMRestarted", "Restarted VM %s", vmSpec.ResourceName())
+			}
+		}
+
+		if s.Scope.RedeployRequested() && infraVM.State == infrav1.Succeeded {
+			if err := s.client.Redeploy(ctx, vmSpec); err != nil {
+				return errors.Wrap(err, "failed to redeploy VM")
+			}
+			async.Forget(s.Scope, vmSpec)
+			s.Scope.ClearRedeployRequest()
+			if getter, ok := s.Scope.(async.EventObjectGetter); ok {
+				record.Eventf(getter.EventObject(), "VMRedeployed", "Redeployed VM %s", vmSpec.ResourceName())
+			}
+		}
+
+		if s.Scope.ResizeInPlace() && infraVM.State == infrav1.Succeeded {
+			if err := s.resizeVM(ctx, spec, vm); err != nil {
+				return err
+			}
+		}
 	}
 	return err
 }
 
+// setProvisionFailedCondition marks the VMRunning condition false with the error details and correlation ID of the
+// failed operation found in the Activity Log, so that users don't have to look them up in the portal.
+func (s *Service) setProvisionFailedCondition(ctx context.Context, vmID string) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.setProvisionFailedCondition")
+	defer done()
+
+	message := "VM is in a failed provisioning state"
+	operation, err := s.activityLogs.GetFailedOperation(ctx, vmID)
+	if err != nil {
+		// The Activity Log only adds details, failing to read it must not block the reconciliation.
+		log.Error(err, "failed to get the failed operation of the VM from the Activity Log", "id", vmID)
+	} else if operation != nil {
+		message = fmt.Sprintf("%s: %s", message, operation)
+	}
+	s.Scope.SetConditionFalse(infrav1.VMRunningCondition, infrav1.VMProvisionFailedReason, clusterv1.ConditionSeverityError, message)
+}
+
+// resizeVM changes the size of an existing VM to the size of its spec by deallocating it, updating its size and
+// starting it again, taking one step per reconciliation. It returns a transient error until the VM is running with
+// its new size, and reports the progress in the VMResized condition.
+func (s *Service) resizeVM(ctx context.Context, spec *VMSpec, vm armcompute.VirtualMachine) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.resizeVM")
+	defer done()
+
+	var currentSize string
+	if vm.Properties != nil && vm.Properties.HardwareProfile != nil {
+		currentSize = string(ptr.Deref(vm.Properties.HardwareProfile.VMSize, ""))
+	}
+	resized := strings.EqualFold(currentSize, spec.Size)
+	if resized && !s.Scope.IsVMResizing() {
+		return nil
+	}
+
+	powerState, err := s.client.GetPowerState(ctx, spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to get VM power state")
+	}
+
+	switch {
+	case !resized && powerState == powerStateDeallocated:
+		log.V(2).Info("resizing VM", "from", currentSize, "to", spec.Size)
+		if err := s.client.UpdateSize(ctx, spec, spec.Size); err != nil {
+			s.Scope.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMResizeFailedReason, clusterv1.ConditionSeverityError, azure.ErrorMessage(err))
+			return errors.Wrap(err, "failed to resize VM")
+		}
+		async.Forget(s.Scope, spec)
+		s.Scope.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMResizingReason, clusterv1.ConditionSeverityInfo,
+			fmt.Sprintf("resizing VM from %s to %s", currentSize, spec.Size))
+	case !resized && powerState != powerStateDeallocating:
+		log.V(2).Info("deallocating VM to resize it", "from", currentSize, "to", spec.Size)
+		if err := s.client.Deallocate(ctx, spec); err != nil {
+			s.Scope.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMResizeFailedReason, clusterv1.ConditionSeverityError, azure.ErrorMessage(err))
+			return errors.Wrap(err, "failed to deallocate VM")
+		}
+		async.Forget(s.Scope, spec)
+		s.Scope.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMDeallocatingReason, clusterv1.ConditionSeverityInfo,
+			fmt.Sprintf("deallocating VM to resize it from %s to %s", currentSize, spec.Size))
+	case resized && powerState == powerStateDeallocated:
+		log.V(2).Info("starting resized VM")
+		if err := s.client.Start(ctx, spec); err != nil {
+			s.Scope.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMResizeFailedReason, clusterv1.ConditionSeverityError, azure.ErrorMessage(err))
+			return errors.Wrap(err, "failed to start VM")
+		}
+		async.Forget(s.Scope, spec)
+		s.Scope.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMStartingReason, clusterv1.ConditionSeverityInfo, "starting resized VM")
+	case resized && powerState == powerStateRunning:
+		s.Scope.UpdatePatchStatus(infrav1.VMResizedCondition, serviceName, nil)
+		if getter, ok := s.Scope.(async.EventObjectGetter); ok {
+			record.Eventf(getter.EventObject(), "VMResized", "Resized VM %s to %s", spec.ResourceName(), spec.Size)
+		}
+		return nil
+	}
+
+	return azure.WithTransientError(errors.Errorf("VM %s is being resized to %s", spec.ResourceName(), spec.Size), resizeRequeue)
+}
+
 // Delete deletes the virtual machine with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.AzureServiceDeleteTimeout(s.Name()))
 	defer cancel()
 
 	vmSpec := s.Scope.VMSpec()
@@ -160,6 +297,11 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
+	// Capture the diagnostics only once, before the deletion of the VM starts.
+	if s.Scope.CaptureDiagnosticsOnDelete() && s.Scope.GetLongRunningOperationState(vmSpec.ResourceName(), serviceName, infrav1.DeleteFuture) == nil {
+		s.captureDiagnostics(ctx, vmSpec)
+	}
+
 	err := s.DeleteResource(ctx, vmSpec, serviceName)
 	if err != nil {
 		s.Scope.SetVMState(infrav1.Deleting)
@@ -170,6 +312,25 @@ func (s *Service) Delete(ctx context.Context) error {
 	return err
 }
 
+// captureDiagnostics records an Event referencing the serial log and the screenshot of the VM, which are kept in its
+// boot diagnostics storage account after it is deleted. Failing to capture them doesn't block the deletion of the VM.
+func (s *Service) captureDiagnostics(ctx context.Context, vmSpec azure.ResourceSpecGetter) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.captureDiagnostics")
+	defer done()
+
+	serialConsoleLogURI, consoleScreenshotURI, err := s.client.RetrieveBootDiagnosticsData(ctx, vmSpec)
+	if err != nil {
+		if !azure.ResourceNotFound(err) {
+			log.Error(err, "failed to capture the boot diagnostics of the VM before deleting it", "vm", vmSpec.ResourceName())
+		}
+		return
+	}
+	if getter, ok := s.Scope.(async.EventObjectGetter); ok {
+		record.Eventf(getter.EventObject(), "DiagnosticsCaptured", "Captured the diagnostics of VM %s before deleting it. Serial log: %s, screenshot: %s",
+			vmSpec.ResourceName(), serialConsoleLogURI, consoleScreenshotURI)
+	}
+}
+
 func (s *Service) checkUserAssignedIdentities(ctx context.Context, specIdentities []infrav1.UserAssignedIdentity, vmIdentities []infrav1.UserAssignedIdentity) error {
 	expectedMap := make(map[string]struct{})
 	actualMap := make(map[string]struct{})
@@ -251,7 +412,10 @@ func (s *Service) getAddresses(ctx context.Context, vm armcompute.VirtualMachine
 			continue
 		}
 		for _, ipConfig := range nic.Properties.IPConfigurations {
-			if ipConfig != nil && ipConfig.Properties != nil && ipConfig.Properties.PrivateIPAddress != nil {
+			if ipConfig == nil || ipConfig.Properties == nil {
+				continue
+			}
+			if ipConfig.Properties.PrivateIPAddress != nil {
 				addresses = append(addresses,
 					corev1.NodeAddress{
 						Type:    corev1.NodeInternalIP,
@@ -266,40 +430,53 @@ func (s *Service) getAddresses(ctx context.Context, vm armcompute.VirtualMachine
 			// ID is the only field populated in PublicIPAddress sub-resource.
 			// Thus, we have to go fetch the publicIP with the name.
 			publicIPName := getResourceNameByID(ptr.Deref(ipConfig.Properties.PublicIPAddress.ID, ""))
-			publicNodeAddress, err := s.getPublicIPAddress(ctx, publicIPName, rgName)
+			publicNodeAddresses, err := s.getPublicIPAddresses(ctx, publicIPName, rgName)
 			if err != nil {
 				return addresses, err
 			}
-			addresses = append(addresses, publicNodeAddress)
+			addresses = append(addresses, publicNodeAddresses...)
 		}
 	}
 
 	return addresses, nil
 }
 
-// getPublicIPAddress will fetch a public ip address resource by name and return a nodeaddresss representation.
-func (s *Service) getPublicIPAddress(ctx context.Context, publicIPAddressName string, rgName string) (corev1.NodeAddress, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.getPublicIPAddress")
+// getPublicIPAddresses will fetch a public ip address resource by name and return the nodeaddresss representations of
+// its IP address and, if it has one, its FQDN.
+func (s *Service) getPublicIPAddresses(ctx context.Context, publicIPAddressName string, rgName string) ([]corev1.NodeAddress, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.getPublicIPAddresses")
 	defer done()
 
-	retAddress := corev1.NodeAddress{}
 	result, err := s.publicIPsGetter.Get(ctx, &publicips.PublicIPSpec{
 		Name:          publicIPAddressName,
 		ResourceGroup: rgName,
 	})
 	if err != nil {
-		return retAddress, err
+		return nil, err
 	}
 
 	publicIP, ok := result.(armnetwork.PublicIPAddress)
 	if !ok {
-		return retAddress, errors.Errorf("%T is not an armnetwork.PublicIPAddress", result)
+		return nil, errors.Errorf("%T is not an armnetwork.PublicIPAddress", result)
+	}
+	if publicIP.Properties == nil {
+		return nil, nil
 	}
 
-	retAddress.Type = corev1.NodeExternalIP
-	retAddress.Address = ptr.Deref(publicIP.Properties.IPAddress, "")
+	addresses := []corev1.NodeAddress{
+		{
+			Type:    corev1.NodeExternalIP,
+			Address: ptr.Deref(publicIP.Properties.IPAddress, ""),
+		},
+	}
+	if publicIP.Properties.DNSSettings != nil && ptr.Deref(publicIP.Properties.DNSSettings.Fqdn, "") != "" {
+		addresses = append(addresses, corev1.NodeAddress{
+			Type:    corev1.NodeExternalDNS,
+			Address: *publicIP.Properties.DNSSettings.Fqdn,
+		})
+	}
 
-	return retAddress, nil
+	return addresses, nil
 }
 
 // getResourceNameById takes a resource ID like
@@ -32,6 +32,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/activitylogs"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/activitylogs/mock_activitylogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities/mock_identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
@@ -134,21 +136,21 @@ func TestReconcileVM(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder)
 	}{
 		{
 			name:          "noop if no vm spec is found",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().Return(nil)
 			},
 		},
 		{
 			name:          "create vm succeeds",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().Return(&fakeVMSpec)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
 				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
@@ -159,13 +161,99 @@ func TestReconcileVM(t *testing.T) {
 				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
 				s.SetAddresses(fakeNodeAddresses)
 				s.SetVMState(infrav1.Succeeded)
+				s.RestartRequested().Return(false)
+				s.RedeployRequested().Return(false)
+				s.ResizeInPlace().Return(false)
+			},
+		},
+		{
+			name:          "restart of the vm is requested",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(&fakeVMSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+				s.SetAddresses(fakeNodeAddresses)
+				s.SetVMState(infrav1.Succeeded)
+				s.RestartRequested().Return(true)
+				c.Restart(gomockinternal.AContext(), &fakeVMSpec).Return(nil)
+				s.ClearRestartRequest()
+				s.RedeployRequested().Return(false)
+				s.ResizeInPlace().Return(false)
+			},
+		},
+		{
+			name:          "restart of the vm fails",
+			expectedError: "failed to restart VM:.*#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(&fakeVMSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+				s.SetAddresses(fakeNodeAddresses)
+				s.SetVMState(infrav1.Succeeded)
+				s.RestartRequested().Return(true)
+				c.Restart(gomockinternal.AContext(), &fakeVMSpec).Return(internalError())
+			},
+		},
+		{
+			name:          "redeploy of the vm is requested",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(&fakeVMSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+				s.SetAddresses(fakeNodeAddresses)
+				s.SetVMState(infrav1.Succeeded)
+				s.RestartRequested().Return(false)
+				s.RedeployRequested().Return(true)
+				c.Redeploy(gomockinternal.AContext(), &fakeVMSpec).Return(nil)
+				s.ClearRedeployRequest()
+				s.ResizeInPlace().Return(false)
+			},
+		},
+		{
+			name:          "redeploy of the vm fails",
+			expectedError: "failed to redeploy VM:.*#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(&fakeVMSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+				s.SetAddresses(fakeNodeAddresses)
+				s.SetVMState(infrav1.Succeeded)
+				s.RestartRequested().Return(false)
+				s.RedeployRequested().Return(true)
+				c.Redeploy(gomockinternal.AContext(), &fakeVMSpec).Return(internalError())
 			},
 		},
 		{
 			name:          "creating vm fails",
 			expectedError: "#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().Return(&fakeVMSpec)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil, internalError())
 				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, internalError())
@@ -175,8 +263,8 @@ func TestReconcileVM(t *testing.T) {
 		{
 			name:          "create vm succeeds but failed to get network interfaces",
 			expectedError: "failed to fetch VM addresses:.*#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().Return(&fakeVMSpec)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
 				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
@@ -189,8 +277,8 @@ func TestReconcileVM(t *testing.T) {
 		{
 			name:          "create vm succeeds but failed to get public IPs",
 			expectedError: "failed to fetch VM addresses:.*#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().Return(&fakeVMSpec)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
 				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
@@ -215,11 +303,13 @@ func TestReconcileVM(t *testing.T) {
 			interfaceMock := mock_async.NewMockGetter(mockCtrl)
 			publicIPMock := mock_async.NewMockGetter(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), interfaceMock.EXPECT(), publicIPMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), interfaceMock.EXPECT(), publicIPMock.EXPECT(), asyncMock.EXPECT(), clientMock.EXPECT())
 
 			s := &Service{
 				Scope:            scopeMock,
+				client:           clientMock,
 				interfacesGetter: interfaceMock,
 				publicIPsGetter:  publicIPMock,
 				Reconciler:       asyncMock,
@@ -236,26 +326,321 @@ func TestReconcileVM(t *testing.T) {
 	}
 }
 
+func TestReconcileFailedVM(t *testing.T) {
+	failedVM := fakeExistingVM
+	failedVM.Properties = &armcompute.VirtualMachineProperties{
+		ProvisioningState: ptr.To("Failed"),
+		NetworkProfile:    fakeExistingVM.Properties.NetworkProfile,
+	}
+	testcases := []struct {
+		name            string
+		operation       *activitylogs.Operation
+		activityLogsErr error
+		expectedMessage string
+	}{
+		{
+			name: "failed operation found in the activity log",
+			operation: &activitylogs.Operation{
+				Name:          "Create or Update Virtual Machine",
+				CorrelationID: "00000000-0000-0000-0000-000000000001",
+				Code:          "OSProvisioningTimedOut",
+				Message:       "OS Provisioning for VM 'my-vm' did not finish in the allotted time.",
+			},
+			expectedMessage: "VM is in a failed provisioning state: Create or Update Virtual Machine failed: OSProvisioningTimedOut: " +
+				"OS Provisioning for VM 'my-vm' did not finish in the allotted time. (correlation ID: 00000000-0000-0000-0000-000000000001)",
+		},
+		{
+			name:            "no failed operation found in the activity log",
+			expectedMessage: "VM is in a failed provisioning state",
+		},
+		{
+			name:            "failure to read the activity log",
+			activityLogsErr: internalError(),
+			expectedMessage: "VM is in a failed provisioning state",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			interfaceMock := mock_async.NewMockGetter(mockCtrl)
+			publicIPMock := mock_async.NewMockGetter(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			activityLogsMock := mock_activitylogs.NewMockClient(mockCtrl)
+
+			s := scopeMock.EXPECT()
+			s.AzureServiceReconcileTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			s.VMSpec().Return(&fakeVMSpec)
+			asyncMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(failedVM, nil)
+			s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
+			s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+			s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+			s.SetAnnotation("cluster-api-provider-azure", "true")
+			interfaceMock.EXPECT().Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+			publicIPMock.EXPECT().Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+			s.SetAddresses(fakeNodeAddresses)
+			s.SetVMState(infrav1.Failed)
+			activityLogsMock.EXPECT().GetFailedOperation(gomockinternal.AContext(), ptr.Deref(failedVM.ID, "")).Return(tc.operation, tc.activityLogsErr)
+			s.SetConditionFalse(infrav1.VMRunningCondition, infrav1.VMProvisionFailedReason, clusterv1.ConditionSeverityError, tc.expectedMessage)
+			s.RestartRequested().Return(false)
+			s.RedeployRequested().Return(false)
+			s.ResizeInPlace().Return(false)
+
+			svc := &Service{
+				Scope:            scopeMock,
+				interfacesGetter: interfaceMock,
+				publicIPsGetter:  publicIPMock,
+				activityLogs:     activityLogsMock,
+				Reconciler:       asyncMock,
+			}
+
+			err := svc.Reconcile(context.TODO())
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestResizeVM(t *testing.T) {
+	resizedVM := armcompute.VirtualMachine{
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{
+				VMSize: ptr.To(armcompute.VirtualMachineSizeTypes("standard_fake_size")),
+			},
+		},
+	}
+	vmToResize := armcompute.VirtualMachine{
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{
+				VMSize: ptr.To(armcompute.VirtualMachineSizeTypes("Standard_Old_Size")),
+			},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		vm            armcompute.VirtualMachine
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder)
+		expectedError string
+	}{
+		{
+			name: "noop if the vm has the size of its spec",
+			vm:   resizedVM,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.IsVMResizing().Return(false)
+			},
+		},
+		{
+			name: "deallocates a running vm with another size",
+			vm:   vmToResize,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				c.GetPowerState(gomockinternal.AContext(), &fakeVMSpec).Return(powerStateRunning, nil)
+				c.Deallocate(gomockinternal.AContext(), &fakeVMSpec).Return(nil)
+				s.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMDeallocatingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+			},
+			expectedError: "VM test-vm is being resized to Standard_Fake_Size",
+		},
+		{
+			name: "waits for the vm to be deallocated",
+			vm:   vmToResize,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				c.GetPowerState(gomockinternal.AContext(), &fakeVMSpec).Return(powerStateDeallocating, nil)
+			},
+			expectedError: "VM test-vm is being resized to Standard_Fake_Size",
+		},
+		{
+			name: "resizes a deallocated vm",
+			vm:   vmToResize,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				c.GetPowerState(gomockinternal.AContext(), &fakeVMSpec).Return(powerStateDeallocated, nil)
+				c.UpdateSize(gomockinternal.AContext(), &fakeVMSpec, "Standard_Fake_Size").Return(nil)
+				s.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMResizingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+			},
+			expectedError: "VM test-vm is being resized to Standard_Fake_Size",
+		},
+		{
+			name: "reports the failure to resize the vm",
+			vm:   vmToResize,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				c.GetPowerState(gomockinternal.AContext(), &fakeVMSpec).Return(powerStateDeallocated, nil)
+				c.UpdateSize(gomockinternal.AContext(), &fakeVMSpec, "Standard_Fake_Size").Return(internalError())
+				s.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMResizeFailedReason, clusterv1.ConditionSeverityError, gomock.Any())
+			},
+			expectedError: "failed to resize VM:.*#: Internal Server Error: StatusCode=500",
+		},
+		{
+			name: "starts the resized vm",
+			vm:   resizedVM,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.IsVMResizing().Return(true)
+				c.GetPowerState(gomockinternal.AContext(), &fakeVMSpec).Return(powerStateDeallocated, nil)
+				c.Start(gomockinternal.AContext(), &fakeVMSpec).Return(nil)
+				s.SetConditionFalse(infrav1.VMResizedCondition, infrav1.VMStartingReason, clusterv1.ConditionSeverityInfo, "starting resized VM")
+			},
+			expectedError: "VM test-vm is being resized to Standard_Fake_Size",
+		},
+		{
+			name: "completes the resize once the vm is running",
+			vm:   resizedVM,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.IsVMResizing().Return(true)
+				c.GetPowerState(gomockinternal.AContext(), &fakeVMSpec).Return(powerStateRunning, nil)
+				s.UpdatePatchStatus(infrav1.VMResizedCondition, serviceName, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.resizeVM(context.TODO(), &fakeVMSpec, tc.vm)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(strings.ReplaceAll(err.Error(), "\n", "")).To(MatchRegexp(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestGetAddresses(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder)
+		expected      []corev1.NodeAddress
+		expectedError string
+	}{
+		{
+			name: "returns the addresses of all IP configurations and the FQDN of their public IPs",
+			expect: func(mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder) {
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(armnetwork.Interface{
+					Properties: &armnetwork.InterfacePropertiesFormat{
+						IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
+							{
+								Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+									PrivateIPAddress: ptr.To("10.0.0.5"),
+									PublicIPAddress: &armnetwork.PublicIPAddress{
+										ID: ptr.To("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Network/publicIPAddresses/pip-1"),
+									},
+								},
+							},
+							{
+								Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+									PrivateIPAddress: ptr.To("10.0.0.7"),
+								},
+							},
+							nil,
+							{},
+						},
+					},
+				}, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(armnetwork.PublicIPAddress{
+					Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+						IPAddress: ptr.To("10.0.0.6"),
+						DNSSettings: &armnetwork.PublicIPAddressDNSSettings{
+							Fqdn: ptr.To("test-vm.test-location.cloudapp.azure.com"),
+						},
+					},
+				}, nil)
+			},
+			expected: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalDNS, Address: "test-vm-name"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.5"},
+				{Type: corev1.NodeExternalIP, Address: "10.0.0.6"},
+				{Type: corev1.NodeExternalDNS, Address: "test-vm.test-location.cloudapp.azure.com"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.7"},
+			},
+		},
+		{
+			name: "omits the FQDN of public IPs without DNS settings",
+			expect: func(mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder) {
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+			},
+			expected: fakeNodeAddresses,
+		},
+		{
+			name: "fails to get the public IP",
+			expect: func(mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder) {
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(armnetwork.PublicIPAddress{}, internalError())
+			},
+			expectedError: "#: Internal Server Error: StatusCode=500",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			interfaceMock := mock_async.NewMockGetter(mockCtrl)
+			publicIPMock := mock_async.NewMockGetter(mockCtrl)
+
+			tc.expect(interfaceMock.EXPECT(), publicIPMock.EXPECT())
+
+			s := &Service{
+				interfacesGetter: interfaceMock,
+				publicIPsGetter:  publicIPMock,
+			}
+
+			addresses, err := s.getAddresses(context.TODO(), fakeExistingVM, "test-group")
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(addresses).To(Equal(tc.expected))
+			}
+		})
+	}
+}
+
 func TestDeleteVM(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder)
 	}{
 		{
 			name:          "noop if no vm spec is found",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().Return(nil)
 			},
 		},
 		{
 			name:          "vm doesn't exist",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
+				s.CaptureDiagnosticsOnDelete().Return(false)
 				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil)
 				s.SetVMState(infrav1.Deleted)
 				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, nil)
@@ -264,9 +649,10 @@ func TestDeleteVM(t *testing.T) {
 		{
 			name:          "error occurs when deleting vm",
 			expectedError: "#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
+				s.CaptureDiagnosticsOnDelete().Return(false)
 				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(internalError())
 				s.SetVMState(infrav1.Deleting)
 				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, internalError())
@@ -275,9 +661,51 @@ func TestDeleteVM(t *testing.T) {
 		{
 			name:          "delete the vm successfully",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
-				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
+				s.CaptureDiagnosticsOnDelete().Return(false)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil)
+				s.SetVMState(infrav1.Deleted)
+				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "capture the diagnostics of the vm before deleting it",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
+				s.CaptureDiagnosticsOnDelete().Return(true)
+				s.GetLongRunningOperationState(fakeVMSpec.Name, serviceName, infrav1.DeleteFuture).Return(nil)
+				c.RetrieveBootDiagnosticsData(gomockinternal.AContext(), &fakeVMSpec).Return("https://diag.blob.core.windows.net/serial.log", "https://diag.blob.core.windows.net/screenshot.bmp", nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil)
+				s.SetVMState(infrav1.Deleted)
+				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "failing to capture the diagnostics of the vm doesn't block its deletion",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
+				s.CaptureDiagnosticsOnDelete().Return(true)
+				s.GetLongRunningOperationState(fakeVMSpec.Name, serviceName, infrav1.DeleteFuture).Return(nil)
+				c.RetrieveBootDiagnosticsData(gomockinternal.AContext(), &fakeVMSpec).Return("", "", internalError())
+				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil)
+				s.SetVMState(infrav1.Deleted)
+				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "diagnostics are not captured again while the vm is being deleted",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.AzureServiceDeleteTimeout(serviceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
+				s.CaptureDiagnosticsOnDelete().Return(true)
+				s.GetLongRunningOperationState(fakeVMSpec.Name, serviceName, infrav1.DeleteFuture).Return(&infrav1.Future{})
 				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil)
 				s.SetVMState(infrav1.Deleted)
 				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, nil)
@@ -294,12 +722,14 @@ func TestDeleteVM(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), clientMock.EXPECT())
 
 			s := &Service{
 				Scope:      scopeMock,
 				Reconciler: asyncMock,
+				client:     clientMock,
 			}
 
 			err := s.Delete(context.TODO())
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworkgateways
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// VirtualNetworkGatewaySpec defines the specification for a virtual network gateway.
+type VirtualNetworkGatewaySpec struct {
+	Name           string
+	ResourceGroup  string
+	Location       string
+	ClusterName    string
+	Type           infrav1.VirtualNetworkGatewayType
+	SKU            string
+	VPNType        infrav1.VPNType
+	SubnetID       string
+	PublicIPID     string
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the virtual network gateway.
+func (s *VirtualNetworkGatewaySpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *VirtualNetworkGatewaySpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for virtual network gateways.
+func (s *VirtualNetworkGatewaySpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the virtual network gateway.
+func (s *VirtualNetworkGatewaySpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingGateway, ok := existing.(armnetwork.VirtualNetworkGateway)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.VirtualNetworkGateway", existing)
+		}
+		// Only the SKU of a gateway can be changed, other changes require recreating it.
+		if existingGateway.Properties == nil || existingGateway.Properties.SKU == nil ||
+			string(ptr.Deref(existingGateway.Properties.SKU.Name, "")) == s.SKU {
+			return nil, nil
+		}
+		existingGateway.Properties.SKU = s.sku()
+		return existingGateway, nil
+	}
+
+	var vpnType *armnetwork.VPNType
+	if s.VPNType != "" {
+		vpnType = ptr.To(armnetwork.VPNType(s.VPNType))
+	}
+
+	return armnetwork.VirtualNetworkGateway{
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+			GatewayType: ptr.To(armnetwork.VirtualNetworkGatewayType(s.Type)),
+			VPNType:     vpnType,
+			SKU:         s.sku(),
+			IPConfigurations: []*armnetwork.VirtualNetworkGatewayIPConfiguration{
+				{
+					Name: ptr.To("default"),
+					Properties: &armnetwork.VirtualNetworkGatewayIPConfigurationPropertiesFormat{
+						PrivateIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodDynamic),
+						Subnet:                    &armnetwork.SubResource{ID: ptr.To(s.SubnetID)},
+						PublicIPAddress:           &armnetwork.SubResource{ID: ptr.To(s.PublicIPID)},
+					},
+				},
+			},
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
+
+// sku returns the SKU of the virtual network gateway, whose tier always matches its name.
+func (s *VirtualNetworkGatewaySpec) sku() *armnetwork.VirtualNetworkGatewaySKU {
+	return &armnetwork.VirtualNetworkGatewaySKU{
+		Name: ptr.To(armnetwork.VirtualNetworkGatewaySKUName(s.SKU)),
+		Tier: ptr.To(armnetwork.VirtualNetworkGatewaySKUTier(s.SKU)),
+	}
+}
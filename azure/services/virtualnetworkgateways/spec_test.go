@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworkgateways
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var fakeExpressRouteGatewaySpec = VirtualNetworkGatewaySpec{
+	Name:          "my-cluster-vnet-gateway",
+	ResourceGroup: "my-rg",
+	Location:      "eastus",
+	ClusterName:   "my-cluster",
+	Type:          infrav1.VirtualNetworkGatewayTypeExpressRoute,
+	SKU:           "ErGw1AZ",
+	SubnetID:      fakeGatewaySpec.SubnetID,
+	PublicIPID:    fakeGatewaySpec.PublicIPID,
+}
+
+func existingGateway(sku string) armnetwork.VirtualNetworkGateway {
+	return armnetwork.VirtualNetworkGateway{
+		ID:       ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworkGateways/my-cluster-vnet-gateway"),
+		Name:     ptr.To("my-cluster-vnet-gateway"),
+		Location: ptr.To("eastus"),
+		Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+			GatewayType: ptr.To(armnetwork.VirtualNetworkGatewayTypeVPN),
+			VPNType:     ptr.To(armnetwork.VPNTypeRouteBased),
+			SKU: &armnetwork.VirtualNetworkGatewaySKU{
+				Name: ptr.To(armnetwork.VirtualNetworkGatewaySKUName(sku)),
+				Tier: ptr.To(armnetwork.VirtualNetworkGatewaySKUTier(sku)),
+			},
+		},
+	}
+}
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *VirtualNetworkGatewaySpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new VPN gateway",
+			spec:     &fakeGatewaySpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.VirtualNetworkGateway{}))
+				gateway := result.(armnetwork.VirtualNetworkGateway)
+				g.Expect(gateway.Location).To(Equal(ptr.To("eastus")))
+				g.Expect(gateway.Properties.GatewayType).To(Equal(ptr.To(armnetwork.VirtualNetworkGatewayTypeVPN)))
+				g.Expect(gateway.Properties.VPNType).To(Equal(ptr.To(armnetwork.VPNTypeRouteBased)))
+				g.Expect(gateway.Properties.SKU.Name).To(Equal(ptr.To(armnetwork.VirtualNetworkGatewaySKUNameVPNGw1AZ)))
+				g.Expect(gateway.Properties.SKU.Tier).To(Equal(ptr.To(armnetwork.VirtualNetworkGatewaySKUTierVPNGw1AZ)))
+				g.Expect(gateway.Properties.IPConfigurations).To(HaveLen(1))
+				g.Expect(gateway.Properties.IPConfigurations[0].Properties.Subnet.ID).To(Equal(ptr.To(fakeGatewaySpec.SubnetID)))
+				g.Expect(gateway.Properties.IPConfigurations[0].Properties.PublicIPAddress.ID).To(Equal(ptr.To(fakeGatewaySpec.PublicIPID)))
+				g.Expect(gateway.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", ptr.To("owned")))
+			},
+		},
+		{
+			name:     "new ExpressRoute gateway has no VPN type",
+			spec:     &fakeExpressRouteGatewaySpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.VirtualNetworkGateway{}))
+				gateway := result.(armnetwork.VirtualNetworkGateway)
+				g.Expect(gateway.Properties.GatewayType).To(Equal(ptr.To(armnetwork.VirtualNetworkGatewayTypeExpressRoute)))
+				g.Expect(gateway.Properties.VPNType).To(BeNil())
+				g.Expect(gateway.Properties.SKU.Name).To(Equal(ptr.To(armnetwork.VirtualNetworkGatewaySKUNameErGw1AZ)))
+			},
+		},
+		{
+			name:     "existing gateway with the same SKU is not updated",
+			spec:     &fakeGatewaySpec,
+			existing: existingGateway("VpnGw1AZ"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "existing gateway with a different SKU is resized",
+			spec:     &fakeGatewaySpec,
+			existing: existingGateway("VpnGw2AZ"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.VirtualNetworkGateway{}))
+				gateway := result.(armnetwork.VirtualNetworkGateway)
+				g.Expect(gateway.ID).To(Equal(existingGateway("VpnGw2AZ").ID))
+				g.Expect(gateway.Properties.SKU.Name).To(Equal(ptr.To(armnetwork.VirtualNetworkGatewaySKUNameVPNGw1AZ)))
+				g.Expect(gateway.Properties.SKU.Tier).To(Equal(ptr.To(armnetwork.VirtualNetworkGatewaySKUTierVPNGw1AZ)))
+			},
+		},
+		{
+			name:     "error when existing is not a virtual network gateway",
+			spec:     &fakeGatewaySpec,
+			existing: "not a gateway",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "string is not an armnetwork.VirtualNetworkGateway",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworkgateways
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworkgateways/mock_virtualnetworkgateways"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+var (
+	fakeGatewaySpec = VirtualNetworkGatewaySpec{
+		Name:          "my-cluster-vnet-gateway",
+		ResourceGroup: "my-rg",
+		Location:      "eastus",
+		ClusterName:   "my-cluster",
+		Type:          infrav1.VirtualNetworkGatewayTypeVPN,
+		SKU:           "VpnGw1AZ",
+		VPNType:       infrav1.VPNTypeRouteBased,
+		SubnetID:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/GatewaySubnet",
+		PublicIPID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-cluster-vnet-gateway-pip",
+	}
+	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func internalError() *azcore.ResponseError {
+	return &azcore.ResponseError{
+		RawResponse: &http.Response{
+			Body:       io.NopCloser(strings.NewReader("#: Internal Server Error: StatusCode=500")),
+			StatusCode: http.StatusInternalServerError,
+		},
+	}
+}
+
+func TestReconcileVirtualNetworkGateway(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no virtual network gateway spec is found",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VirtualNetworkGatewaySpec().Return(nil)
+			},
+		},
+		{
+			name:          "create virtual network gateway",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VirtualNetworkGatewaySpec().Return(&fakeGatewaySpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeGatewaySpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.VirtualNetworkGatewayReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "virtual network gateway is still provisioning",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VirtualNetworkGatewaySpec().Return(&fakeGatewaySpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeGatewaySpec, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.VirtualNetworkGatewayReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "error creating the virtual network gateway",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceReconcileTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VirtualNetworkGatewaySpec().Return(&fakeGatewaySpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeGatewaySpec, ServiceName).Return(nil, internalError())
+				s.UpdatePutStatus(infrav1.VirtualNetworkGatewayReadyCondition, ServiceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_virtualnetworkgateways.NewMockVirtualNetworkGatewayScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteVirtualNetworkGateway(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no virtual network gateway spec is found",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VirtualNetworkGatewaySpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete virtual network gateway",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VirtualNetworkGatewaySpec().Return(&fakeGatewaySpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeGatewaySpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.VirtualNetworkGatewayReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "error deleting the virtual network gateway",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualnetworkgateways.MockVirtualNetworkGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureServiceDeleteTimeout(ServiceName).Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VirtualNetworkGatewaySpec().Return(&fakeGatewaySpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeGatewaySpec, ServiceName).Return(internalError())
+				s.UpdateDeleteStatus(infrav1.VirtualNetworkGatewayReadyCondition, ServiceName, internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_virtualnetworkgateways.NewMockVirtualNetworkGatewayScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
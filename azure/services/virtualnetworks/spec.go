@@ -37,6 +37,7 @@ type VNetSpec struct {
 	ExtendedLocation *infrav1.ExtendedLocationSpec
 	ClusterName      string
 	AdditionalTags   infrav1.Tags
+	Adopt            bool
 }
 
 // ResourceRef implements azure.ASOResourceSpecGetter.
@@ -63,6 +64,13 @@ func (s *VNetSpec) Parameters(ctx context.Context, existing *asonetworkv1.Virtua
 				}),
 			},
 		}
+	} else if s.Adopt && !infrav1.Tags(existing.Status.Tags).HasOwned(s.ClusterName) {
+		// Keep the tags of the virtual network when it is adopted and tag it as owned by the cluster.
+		tags := infrav1.Tags{}
+		tags.Merge(existing.Status.Tags)
+		tags.Merge(existing.Spec.Tags)
+		tags[infrav1.ClusterTagKey(s.ClusterName)] = string(infrav1.ResourceLifecycleOwned)
+		vnet.Spec.Tags = tags
 	}
 
 	vnet.Spec.AzureName = s.Name
@@ -80,5 +88,5 @@ func (s *VNetSpec) Parameters(ctx context.Context, existing *asonetworkv1.Virtua
 
 // WasManaged implements azure.ASOResourceSpecGetter.
 func (s *VNetSpec) WasManaged(resource *asonetworkv1.VirtualNetwork) bool {
-	return infrav1.Tags(resource.Status.Tags).HasOwned(s.ClusterName)
+	return s.Adopt || infrav1.Tags(resource.Status.Tags).HasOwned(s.ClusterName)
 }
@@ -115,6 +115,40 @@ func TestParameters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "adopted existing vnet",
+			spec: VNetSpec{
+				ResourceGroup: "rg",
+				Name:          "name",
+				CIDRs:         []string{"cidr"},
+				Location:      "location",
+				ClusterName:   "cluster",
+				Adopt:         true,
+			},
+			existing: &asonetworkv1.VirtualNetwork{
+				Spec: asonetworkv1.VirtualNetwork_Spec{
+					Tags: map[string]string{
+						"tags": "set",
+					},
+				},
+			},
+			expected: &asonetworkv1.VirtualNetwork{
+				Spec: asonetworkv1.VirtualNetwork_Spec{
+					Tags: map[string]string{
+						"tags": "set",
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster": "owned",
+					},
+					AzureName: "name",
+					Owner: &genruntime.KnownResourceReference{
+						Name: "rg",
+					},
+					Location: ptr.To("location"),
+					AddressSpace: &asonetworkv1.AddressSpace{
+						AddressPrefixes: []string{"cidr"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -54,6 +54,10 @@ func postCreateOrUpdateResourceHook(ctx context.Context, scope VNetScope, existi
 		return err
 	}
 
+	if err := aso.RecordOwnership(scope, "VirtualNetwork", existingVnet.AzureName(), existingVnet); err != nil {
+		return err
+	}
+
 	vnet := scope.Vnet()
 	vnet.ID = ptr.Deref(existingVnet.Status.Id, "")
 	vnet.Tags = existingVnet.Status.Tags
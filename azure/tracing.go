@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// requestIDHeader is the header ARM uses to identify a request in its own logs.
+const requestIDHeader = "x-ms-request-id"
+
+// tracingPolicy records an OpenTelemetry span for each request sent to Azure Resource Manager.
+// It implements the policy.Policy interface.
+type tracingPolicy struct{}
+
+// Do sends the request within a span named after its HTTP method and resource type. The span carries
+// the correlation ID sent with the request and the request ID returned by ARM.
+func (p tracingPolicy) Do(req *policy.Request) (*http.Response, error) {
+	method := req.Raw().Method
+	resource := resourceType(req.Raw().URL.Path)
+
+	// The span must be started before correlationIDPolicy runs so the correlation ID it
+	// creates, if the context doesn't carry one already, is sent with the request.
+	ctx, span := tele.Tracer().Start(req.Raw().Context(), "ARM "+method+" "+resource,
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(method),
+			semconv.HTTPURLKey.String(req.Raw().URL.Redacted()),
+			attribute.String("azure.resource_type", resource),
+		),
+	)
+	defer span.End()
+
+	resp, err := req.WithContext(ctx).Next()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	if requestID := resp.Header.Get(requestIDHeader); requestID != "" {
+		span.SetAttributes(attribute.String(requestIDHeader, requestID))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+func TestTracingPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	var sentCorrID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sentCorrID = r.Header.Get(string(tele.CorrIDKeyVal))
+		w.Header().Set(requestIDHeader, "my-request-id")
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	path := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+	req, err := runtime.NewRequest(context.Background(), http.MethodPut, server.URL+path)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp, err := defaultTestPipeline([]policy.Policy{tracingPolicy{}, correlationIDPolicy{}}).Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+
+	spans := recorder.Ended()
+	g.Expect(spans).To(HaveLen(1))
+	span := spans[0]
+	g.Expect(span.Name()).To(Equal("ARM PUT Microsoft.Network/virtualNetworks"))
+	g.Expect(span.Status().Code).To(Equal(codes.Error))
+	g.Expect(sentCorrID).NotTo(BeEmpty())
+	g.Expect(span.Attributes()).To(ContainElements(
+		attribute.String("http.method", http.MethodPut),
+		attribute.Int("http.status_code", http.StatusConflict),
+		attribute.String("azure.resource_type", "Microsoft.Network/virtualNetworks"),
+		attribute.String(string(tele.CorrIDKeyVal), sentCorrID),
+		attribute.String(requestIDHeader, "my-request-id"),
+	))
+}
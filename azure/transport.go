@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/pkg/errors"
+)
+
+// HTTPTransport sends the requests of all the Azure SDK clients and token credentials built by the manager. The
+// default transport of the SDK, which honors the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables, is used
+// when it is nil. It is set from the manager flags.
+var HTTPTransport policy.Transporter
+
+// NewHTTPTransport returns a transport sending requests through the proxy at proxyURL, or the proxy set by the
+// environment variables when proxyURL is empty, and trusting the certificates of the PEM file caBundleFile in
+// addition to the system ones.
+func NewHTTPTransport(proxyURL, caBundleFile string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid proxy URL %q", proxyURL)
+		}
+		if !u.IsAbs() || u.Host == "" {
+			return nil, errors.Errorf("invalid proxy URL %q: must be an absolute URL", proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+
+	if caBundleFile != "" {
+		pem, err := os.ReadFile(caBundleFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CA bundle %s", caBundleFile)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no PEM certificate found in CA bundle %s", caBundleFile)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
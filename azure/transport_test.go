@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewHTTPTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caBundle := filepath.Join(dir, "ca.pem")
+	g := NewWithT(t)
+	g.Expect(os.WriteFile(caBundle, certificatePEM(server), 0o600)).To(Succeed())
+	notPEM := filepath.Join(dir, "not-pem.txt")
+	g.Expect(os.WriteFile(notPEM, []byte("not a certificate"), 0o600)).To(Succeed())
+
+	tests := []struct {
+		name          string
+		proxyURL      string
+		caBundleFile  string
+		expectedErr   string
+		expectTrusted bool
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:          "trusts the certificates of the CA bundle",
+			caBundleFile:  caBundle,
+			expectTrusted: true,
+		},
+		{
+			name:     "valid proxy URL",
+			proxyURL: "http://proxy.example.com:3128",
+		},
+		{
+			name:        "relative proxy URL",
+			proxyURL:    "proxy.example.com",
+			expectedErr: `invalid proxy URL "proxy.example.com": must be an absolute URL`,
+		},
+		{
+			name:         "missing CA bundle",
+			caBundleFile: filepath.Join(dir, "missing.pem"),
+			expectedErr:  "failed to read CA bundle",
+		},
+		{
+			name:         "CA bundle without certificates",
+			caBundleFile: notPEM,
+			expectedErr:  "no PEM certificate found in CA bundle",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			client, err := NewHTTPTransport(tc.proxyURL, tc.caBundleFile)
+			if tc.expectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			transport := client.Transport.(*http.Transport)
+			if tc.proxyURL != "" {
+				req, err := http.NewRequest(http.MethodGet, "https://management.azure.com", http.NoBody)
+				g.Expect(err).NotTo(HaveOccurred())
+				proxy, err := transport.Proxy(req)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(proxy.String()).To(Equal(tc.proxyURL))
+				return
+			}
+
+			resp, err := client.Get(server.URL)
+			if tc.expectTrusted {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(resp.Body.Close()).To(Succeed())
+				g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring("certificate")))
+			}
+		})
+	}
+}
+
+// certificatePEM returns the PEM encoded certificate of a TLS test server.
+func certificatePEM(server *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
@@ -83,6 +83,41 @@ type TagsSpec struct {
 	Annotation string
 }
 
+// BootstrapDataBlobSpec defines the specification for the blob the bootstrap data of a machine is uploaded to.
+type BootstrapDataBlobSpec struct {
+	StorageAccountName string
+	ResourceGroup      string
+	ContainerName      string
+	BlobName           string
+	Access             infrav1.BootstrapDataAccess
+}
+
+// BootstrapDataKeyVaultSpec defines the specification for the key vault secrets the bootstrap data of a machine is
+// stored in.
+type BootstrapDataKeyVaultSpec struct {
+	VaultName string
+	// SecretName is the prefix of the names of the secrets, which are suffixed with their index.
+	SecretName       string
+	IdentityClientID string
+}
+
+// SpotPlacementCheckSpec defines the specification for the preflight check of Spot VM allocation and eviction.
+type SpotPlacementCheckSpec struct {
+	VMSize   string
+	Location string
+	// Zones are the availability zones the Spot VMs are created in, if any.
+	Zones        []string
+	DesiredCount int32
+	Check        infrav1.SpotPlacementCheck
+}
+
+// SpotPlacementScore is the Spot Placement Score of a VM size in a location or availability zone.
+type SpotPlacementScore struct {
+	// Zone is the availability zone of the score, or empty for a regional score.
+	Zone  string `json:"availabilityZone,omitempty"`
+	Score string `json:"score"`
+}
+
 // ExtensionSpec defines the specification for a VM or VMSS extension.
 type ExtensionSpec struct {
 	Name              string
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	asoconfig "github.com/Azure/azure-service-operator/v2/pkg/common/config"
 	"github.com/pkg/errors"
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/predicates"
@@ -73,6 +75,11 @@ func (asos *ASOSecretReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 			&corev1.Secret{},
 			handler.EnqueueRequestForOwner(asos.Scheme(), asos.RESTMapper(), &infrav1.AzureManagedControlPlane{}, handler.OnlyControllerOwner()),
 		).
+		// Add a watch on AzureClusterIdentity secrets so rotated credentials are propagated to the ASO secrets.
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(asos.identitySecretToClusters),
+		).
 		// Add a watch on infrav1.AzureManagedControlPlane.
 		Watches(
 			&infrav1.AzureManagedControlPlane{},
@@ -93,6 +100,64 @@ func (asos *ASOSecretReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 		Complete(asos)
 }
 
+// identitySecretToClusters maps a secret referenced by AzureClusterIdentities to the AzureClusters and
+// AzureManagedControlPlanes using those identities.
+func (asos *ASOSecretReconciler) identitySecretToClusters(ctx context.Context, o client.Object) []reconcile.Request {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.ASOSecretReconciler.identitySecretToClusters")
+	defer done()
+
+	identities := &infrav1.AzureClusterIdentityList{}
+	if err := asos.List(ctx, identities); err != nil {
+		log.Error(err, "failed to list AzureClusterIdentities")
+		return nil
+	}
+
+	var identityKeys []types.NamespacedName
+	for _, identity := range identities.Items {
+		if identity.Spec.ClientSecret.Name == o.GetName() && identity.Spec.ClientSecret.Namespace == o.GetNamespace() {
+			identityKeys = append(identityKeys, types.NamespacedName{Name: identity.Name, Namespace: identity.Namespace})
+		}
+	}
+	if len(identityKeys) == 0 {
+		return nil
+	}
+
+	usesIdentity := func(identityRef *corev1.ObjectReference, namespace string) bool {
+		if identityRef == nil {
+			return false
+		}
+		if identityRef.Namespace != "" {
+			namespace = identityRef.Namespace
+		}
+		return slices.Contains(identityKeys, types.NamespacedName{Name: identityRef.Name, Namespace: namespace})
+	}
+
+	var requests []reconcile.Request
+	azureClusters := &infrav1.AzureClusterList{}
+	if err := asos.List(ctx, azureClusters); err != nil {
+		log.Error(err, "failed to list AzureClusters")
+		return nil
+	}
+	for _, azureCluster := range azureClusters.Items {
+		if usesIdentity(azureCluster.Spec.IdentityRef, azureCluster.Namespace) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&azureCluster)})
+		}
+	}
+
+	azureManagedControlPlanes := &infrav1.AzureManagedControlPlaneList{}
+	if err := asos.List(ctx, azureManagedControlPlanes); err != nil {
+		log.Error(err, "failed to list AzureManagedControlPlanes")
+		return nil
+	}
+	for _, azureManagedControlPlane := range azureManagedControlPlanes.Items {
+		if usesIdentity(azureManagedControlPlane.Spec.IdentityRef, azureManagedControlPlane.Namespace) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&azureManagedControlPlane)})
+		}
+	}
+
+	return requests
+}
+
 // Reconcile reconciles the ASO secrets associated with AzureCluster objects.
 func (asos *ASOSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	ctx, cancel := context.WithTimeout(ctx, asos.Timeouts.DefaultedLoopTimeout())
@@ -247,6 +312,23 @@ func (asos *ASOSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	return ctrl.Result{}, nil
 }
 
+// labelIdentitySecretForMove adds the clusterctl move label to an AzureClusterIdentity secret so that
+// `clusterctl move` copies it to the target management cluster along with the identity.
+func (asos *ASOSecretReconciler) labelIdentitySecretForMove(ctx context.Context, identitySecret *corev1.Secret) error {
+	if _, ok := identitySecret.Labels[clusterctlv1.ClusterctlMoveLabel]; ok {
+		return nil
+	}
+	patch := client.MergeFrom(identitySecret.DeepCopy())
+	if identitySecret.Labels == nil {
+		identitySecret.Labels = map[string]string{}
+	}
+	identitySecret.Labels[clusterctlv1.ClusterctlMoveLabel] = ""
+	if err := asos.Patch(ctx, identitySecret, patch); err != nil {
+		return errors.Wrap(err, "failed to label AzureClusterIdentity secret for clusterctl move")
+	}
+	return nil
+}
+
 func (asos *ASOSecretReconciler) createSecretFromClusterIdentity(ctx context.Context, clusterIdentity *corev1.ObjectReference, cluster *clusterv1.Cluster, azureClient scope.AzureClients) (*corev1.Secret, error) {
 	newASOSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -298,13 +380,20 @@ func (asos *ASOSecretReconciler) createSecretFromClusterIdentity(ctx context.Con
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to fetch AzureClusterIdentity secret")
 	}
+	if err := asos.labelIdentitySecretForMove(ctx, identitySecret); err != nil {
+		return nil, err
+	}
 
 	switch identity.Spec.Type {
 	case infrav1.ServicePrincipal, infrav1.ManualServicePrincipal:
 		newASOSecret.Data[asoconfig.AzureClientSecret] = identitySecret.Data[scope.AzureSecretKey]
 	case infrav1.ServicePrincipalCertificate:
-		newASOSecret.Data[asoconfig.AzureClientCertificate] = identitySecret.Data["certificate"]
-		newASOSecret.Data[asoconfig.AzureClientCertificatePassword] = identitySecret.Data["password"]
+		certificate, ok := identitySecret.Data[scope.AzureCertificateKey]
+		if !ok {
+			certificate = identitySecret.Data[scope.AzureSecretKey]
+		}
+		newASOSecret.Data[asoconfig.AzureClientCertificate] = certificate
+		newASOSecret.Data[asoconfig.AzureClientCertificatePassword] = identitySecret.Data[scope.AzureCertificatePasswordKey]
 	}
 	return newASOSecret, nil
 }
@@ -31,9 +31,11 @@ import (
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestASOSecretReconcile(t *testing.T) {
@@ -327,6 +329,88 @@ func TestASOSecretReconcile(t *testing.T) {
 	}
 }
 
+func TestIdentitySecretToClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	identitySecret := getASOAzureClusterIdentitySecret()
+	identity := getASOAzureClusterIdentity(func(identity *infrav1.AzureClusterIdentity) {
+		identity.Spec.Type = infrav1.ServicePrincipal
+		identity.Spec.ClientSecret = corev1.SecretReference{
+			Name:      identitySecret.Name,
+			Namespace: identitySecret.Namespace,
+		}
+	})
+	identityRef := &corev1.ObjectReference{
+		Name: identity.Name,
+		Kind: infrav1.AzureClusterIdentityKind,
+	}
+
+	objects := []runtime.Object{
+		identitySecret,
+		identity,
+		getASOAzureCluster(func(c *infrav1.AzureCluster) {
+			c.Spec.IdentityRef = identityRef
+		}),
+		getASOAzureCluster(func(c *infrav1.AzureCluster) {
+			c.Name = "other-azure-cluster"
+			c.Spec.IdentityRef = &corev1.ObjectReference{
+				Name: "other-identity",
+				Kind: infrav1.AzureClusterIdentityKind,
+			}
+		}),
+		getASOAzureCluster(func(c *infrav1.AzureCluster) {
+			c.Name = "other-namespace-azure-cluster"
+			c.Namespace = "other-namespace"
+			c.Spec.IdentityRef = &corev1.ObjectReference{
+				Name:      identity.Name,
+				Namespace: identity.Namespace,
+				Kind:      infrav1.AzureClusterIdentityKind,
+			}
+		}),
+		getASOAzureManagedControlPlane(func(c *infrav1.AzureManagedControlPlane) {
+			c.Spec.IdentityRef = identityRef
+		}),
+	}
+	reconciler := &ASOSecretReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build(),
+	}
+
+	requests := reconciler.identitySecretToClusters(context.Background(), identitySecret)
+	g.Expect(requests).To(ConsistOf(
+		reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-azure-cluster"}},
+		reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "other-namespace", Name: "other-namespace-azure-cluster"}},
+		reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-azure-managed-control-plane"}},
+	))
+
+	otherSecret := getASOAzureClusterIdentitySecret(func(secret *corev1.Secret) {
+		secret.Name = "other-secret"
+	})
+	g.Expect(reconciler.identitySecretToClusters(context.Background(), otherSecret)).To(BeEmpty())
+}
+
+func TestLabelIdentitySecretForMove(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	identitySecret := getASOAzureClusterIdentitySecret()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(identitySecret).Build()
+	reconciler := &ASOSecretReconciler{Client: c}
+
+	secret := &corev1.Secret{}
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(identitySecret), secret)).To(Succeed())
+	g.Expect(reconciler.labelIdentitySecretForMove(context.Background(), secret)).To(Succeed())
+
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(identitySecret), secret)).To(Succeed())
+	g.Expect(secret.Labels).To(HaveKeyWithValue(clusterctlv1.ClusterctlMoveLabel, ""))
+	g.Expect(secret.Data).To(Equal(identitySecret.Data))
+}
+
 func getASOCluster(changes ...func(*clusterv1.Cluster)) *clusterv1.Cluster {
 	input := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
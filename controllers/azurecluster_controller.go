@@ -104,10 +104,11 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates;azuremachinetemplates/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities;azureclusteridentities/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=resources.azure.com,resources=resourcegroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=resources.azure.com,resources=resourcegroups/status,verbs=get;list;watch
-// +kubebuilder:rbac:groups=network.azure.com,resources=natgateways;bastionhosts;privateendpoints;virtualnetworks;virtualnetworkssubnets,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=network.azure.com,resources=natgateways/status;bastionhosts/status;privateendpoints/status;virtualnetworks/status;virtualnetworkssubnets/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=network.azure.com,resources=natgateways;bastionhosts;privateendpoints;virtualnetworks;virtualnetworkssubnets;dnszonesarecords,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=network.azure.com,resources=natgateways/status;bastionhosts/status;privateendpoints/status;virtualnetworks/status;virtualnetworkssubnets/status;dnszonesarecords/status,verbs=get;list;watch
 
 // Reconcile idempotently gets, creates, and updates a cluster.
 func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -147,6 +148,12 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	log = log.WithValues("cluster", cluster.Name)
+	ctx = azure.WithAuditRequester(ctx, azure.AuditRequester{
+		Kind:      infrav1.AzureClusterKind,
+		Namespace: azureCluster.Namespace,
+		Name:      azureCluster.Name,
+		Cluster:   cluster.Name,
+	})
 
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
@@ -285,6 +292,14 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 
 	azureCluster := clusterScope.AzureCluster
 
+	if IsDeletionProtected(azureCluster) {
+		msg := fmt.Sprintf("Azure resources are protected from deletion, remove the %s annotation to delete them", infrav1.DeletionProtectionAnnotation)
+		log.Info("Skipping AzureCluster delete: " + msg)
+		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, infrav1.DeletionProtectedReason, msg)
+		conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, infrav1.DeletionProtectedReason, clusterv1.ConditionSeverityWarning, "%s", msg)
+		return reconcile.Result{}, nil
+	}
+
 	acs, err := acr.createAzureClusterService(clusterScope)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
@@ -304,6 +319,16 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 			}
 		}
 
+		if azure.IsResourceLocked(err) {
+			// Retrying won't succeed until the lock is removed, which changes nothing on the AzureCluster, so
+			// only retry on the next resync or change of the AzureCluster.
+			msg := fmt.Sprintf("Azure resources are locked, remove the management lock or set the %s annotation to %q to leave them in Azure: %s", infrav1.LockedResourcePolicyAnnotation, infrav1.LockedResourcePolicyOrphan, err.Error())
+			log.Info("Failed to delete AzureCluster: " + msg)
+			acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, infrav1.ResourceLockedReason, msg)
+			conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, infrav1.ResourceLockedReason, clusterv1.ConditionSeverityError, "%s", msg)
+			return reconcile.Result{}, nil
+		}
+
 		wrappedErr := errors.Wrapf(err, "error deleting AzureCluster %s/%s", azureCluster.Namespace, azureCluster.Name)
 		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "ClusterReconcilerDeleteFailed", wrappedErr.Error())
 		conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
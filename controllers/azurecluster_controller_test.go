@@ -408,6 +408,31 @@ func TestAzureClusterReconcileDelete(t *testing.T) {
 			cache:       &scope.ClusterCache{},
 			expectedErr: "error deleting AzureCluster",
 		},
+		"should not retry deleting locked resources": {
+			createAzureClusterService: func(cs *scope.ClusterScope) (*azureClusterService, error) {
+				return getDefaultAzureClusterService(func(acs *azureClusterService) {
+					acs.scope = cs
+					acs.Delete = func(context.Context) error {
+						return azure.WithTerminalError(azure.ResourceLockedError{Resource: "my-rg/my-vnet", Message: "the scope is locked"})
+					}
+				}), nil
+			},
+			cache: &scope.ClusterCache{},
+		},
+		"should not delete Azure resources when protected from deletion": {
+			createAzureClusterService: func(cs *scope.ClusterScope) (*azureClusterService, error) {
+				return getDefaultAzureClusterService(func(acs *azureClusterService) {
+					acs.scope = cs
+					acs.Delete = func(context.Context) error {
+						return errors.New("Delete should not be called")
+					}
+				}), nil
+			},
+			azureClusterOptions: func(ac *infrav1.AzureCluster) {
+				ac.Annotations = map[string]string{infrav1.DeletionProtectionAnnotation: "true"}
+			},
+			cache: &scope.ClusterCache{},
+		},
 	}
 
 	for name, c := range cases {
@@ -20,19 +20,30 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/armtemplate"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dnsrecords"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/permissions"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routeservers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworkgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -44,11 +55,14 @@ type azureClusterService struct {
 	scope *scope.ClusterScope
 	// services is the list of services that are reconciled by this controller.
 	// The order of the services is important as it determines the order in which the services are reconciled.
-	services  []azure.ServiceReconciler
-	skuCache  *resourceskus.Cache
-	Reconcile func(context.Context) error
-	Pause     func(context.Context) error
-	Delete    func(context.Context) error
+	services []azure.ServiceReconciler
+	// concurrent is the set of names of services which don't depend on each other.
+	// Consecutive services in this set are reconciled concurrently.
+	concurrent sets.Set[string]
+	skuCache   *resourceskus.Cache
+	Reconcile  func(context.Context) error
+	Pause      func(context.Context) error
+	Delete     func(context.Context) error
 }
 
 // newAzureClusterService populates all the services based on input scope.
@@ -57,6 +71,10 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating a NewCache")
 	}
+	asgSvc, err := applicationsecuritygroups.New(scope)
+	if err != nil {
+		return nil, err
+	}
 	securityGroupsSvc, err := securitygroups.New(scope)
 	if err != nil {
 		return nil, err
@@ -81,23 +99,53 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 	if err != nil {
 		return nil, err
 	}
+	privateLinkServicesSvc, err := privatelinkservices.New(scope)
+	if err != nil {
+		return nil, err
+	}
+	flowLogsSvc, err := flowlogs.New(scope)
+	if err != nil {
+		return nil, err
+	}
+	permissionsSvc, err := permissions.New(scope)
+	if err != nil {
+		return nil, err
+	}
+	vnetGatewaysSvc, err := virtualnetworkgateways.New(scope)
+	if err != nil {
+		return nil, err
+	}
+	routeServersSvc, err := routeservers.New(scope)
+	if err != nil {
+		return nil, err
+	}
 	acs := &azureClusterService{
 		scope: scope,
 		services: []azure.ServiceReconciler{
 			groups.New(scope),
+			permissionsSvc,
 			virtualnetworks.New(scope),
+			asgSvc,
 			securityGroupsSvc,
 			routeTablesSvc,
 			publicIPsSvc,
+			flowLogsSvc,
 			natgateways.New(scope),
 			subnets.New(scope),
 			vnetPeeringsSvc,
 			loadbalancersSvc,
+			privateLinkServicesSvc,
+			dnsrecords.New(scope),
 			privateDNSSvc,
 			privateendpoints.New(scope),
 			bastionhosts.New(scope),
+			vnetGatewaysSvc,
+			routeServersSvc,
 		},
-		skuCache: skuCache,
+		// Security groups, route tables and public IPs only depend on the virtual network.
+		// Application security groups are reconciled beforehand, as security rules may reference them.
+		concurrent: sets.New(securityGroupsSvc.Name(), routeTablesSvc.Name(), publicIPsSvc.Name()),
+		skuCache:   skuCache,
 	}
 	acs.Reconcile = acs.reconcile
 	acs.Pause = acs.pause
@@ -119,15 +167,115 @@ func (s *azureClusterService) reconcile(ctx context.Context) error {
 	s.scope.SetDNSName()
 	s.scope.SetControlPlaneSecurityRules()
 
-	for _, service := range s.services {
-		if err := service.Reconcile(ctx); err != nil {
-			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", service.Name())
+	if s.scope.DryRun() {
+		template, err := s.template(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to render ARM template")
+		}
+		if err := reconcileARMTemplate(ctx, s.scope.GetClient(), s.scope.AzureCluster, template); err != nil {
+			return err
 		}
 	}
 
+	for services := s.services; len(services) > 0; {
+		if n := s.concurrentPrefix(services); n > 1 {
+			if err := reconcileConcurrently(ctx, services[:n]); err != nil {
+				return err
+			}
+			services = services[n:]
+			continue
+		}
+		if err := services[0].Reconcile(ctx); err != nil {
+			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", services[0].Name())
+		}
+		services = services[1:]
+	}
+
+	s.scope.SetResourceIDs()
 	return nil
 }
 
+// template renders the Azure resources of the cluster as an ARM template, in the order in which they are reconciled.
+func (s *azureClusterService) template(ctx context.Context) (*armtemplate.Template, error) {
+	b := armtemplate.NewBuilder(s.scope.GetClient().Scheme())
+	var errs []error
+	addResources := func(specs ...azure.ResourceSpecGetter) {
+		for _, spec := range specs {
+			if spec != nil {
+				errs = append(errs, b.AddResource(ctx, spec))
+			}
+		}
+	}
+
+	for _, spec := range s.scope.GroupSpecs() {
+		errs = append(errs, armtemplate.AddASOResource(ctx, b, spec))
+	}
+	errs = append(errs, armtemplate.AddASOResource(ctx, b, s.scope.VNetSpec()))
+	addResources(s.scope.ApplicationSecurityGroupSpecs()...)
+	addResources(s.scope.NSGSpecs()...)
+	addResources(s.scope.RouteTableSpecs()...)
+	addResources(s.scope.PublicIPSpecs()...)
+	addResources(s.scope.FlowLogSpecs()...)
+	for _, spec := range s.scope.NatGatewaySpecs() {
+		errs = append(errs, armtemplate.AddASOResource(ctx, b, spec))
+	}
+	for _, spec := range s.scope.SubnetSpecs() {
+		errs = append(errs, armtemplate.AddASOResource(ctx, b, spec))
+	}
+	addResources(s.scope.VnetPeeringSpecs()...)
+	addResources(s.scope.LBSpecs()...)
+	addResources(s.scope.PrivateLinkServiceSpec())
+	for _, spec := range s.scope.DNSRecordSpecs() {
+		errs = append(errs, armtemplate.AddASOResource(ctx, b, spec))
+	}
+	zoneSpec, linkSpecs, recordSpecs := s.scope.PrivateDNSSpec()
+	addResources(zoneSpec)
+	addResources(linkSpecs...)
+	addResources(recordSpecs...)
+	for _, spec := range s.scope.PrivateEndpointSpecs() {
+		errs = append(errs, armtemplate.AddASOResource(ctx, b, spec))
+	}
+	if spec := s.scope.AzureBastionSpec(); spec != nil {
+		errs = append(errs, armtemplate.AddASOResource(ctx, b, spec))
+	}
+	addResources(s.scope.VirtualNetworkGatewaySpec())
+	routeServerSpec, ipConfigSpec, bgpConnectionSpecs := s.scope.RouteServerSpecs()
+	addResources(routeServerSpec, ipConfigSpec)
+	addResources(bgpConnectionSpecs...)
+
+	if err := kerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return b.Template(), nil
+}
+
+// concurrentPrefix returns the number of services at the start of the list which can be reconciled concurrently.
+func (s *azureClusterService) concurrentPrefix(services []azure.ServiceReconciler) int {
+	if len(s.concurrent) == 0 {
+		return 0
+	}
+	n := 0
+	for n < len(services) && s.concurrent.Has(services[n].Name()) {
+		n++
+	}
+	return n
+}
+
+// reconcileConcurrently reconciles the services concurrently and returns the first error which occurred.
+func reconcileConcurrently(ctx context.Context, services []azure.ServiceReconciler) error {
+	var g errgroup.Group
+	for _, service := range services {
+		service := service
+		g.Go(func() error {
+			if err := service.Reconcile(ctx); err != nil {
+				return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", service.Name())
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
 // Pause pauses all components making up the cluster.
 func (s *azureClusterService) pause(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Pause")
@@ -162,6 +310,17 @@ func (s *azureClusterService) delete(ctx context.Context) error {
 			return errors.Wrap(err, "failed to delete peerings")
 		}
 
+		// Flow logs live in the Network Watcher resource group, so they need to be deleted explicitly too.
+		if s.scope.AzureCluster.Spec.NetworkSpec.FlowLogs != nil {
+			flowLogsSvc, err := s.getService(flowlogs.ServiceName)
+			if err != nil {
+				return errors.Wrap(err, "failed to get flow logs service")
+			}
+			if err := flowLogsSvc.Delete(ctx); err != nil {
+				return errors.Wrap(err, "failed to delete flow logs")
+			}
+		}
+
 		groupSvc, err := s.getService(groups.ServiceName)
 		if err != nil {
 			return errors.Wrap(err, "failed to get group service")
@@ -27,11 +27,14 @@ import (
 	asoannotations "github.com/Azure/azure-service-operator/v2/pkg/common/annotations"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/armtemplate"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
@@ -46,6 +49,7 @@ import (
 func TestAzureClusterServiceReconcile(t *testing.T) {
 	cases := map[string]struct {
 		expectedError string
+		concurrent    sets.Set[string]
 		expect        func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
 	}{
 		"all services are reconciled in order": {
@@ -66,6 +70,30 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 					two.Name().Return("two"))
 			},
 		},
+		"independent services are reconciled concurrently": {
+			expectedError: "",
+			concurrent:    sets.New("two", "three"),
+			expect: func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				one.Name().Return("one").AnyTimes()
+				two.Name().Return("two").AnyTimes()
+				three.Name().Return("three").AnyTimes()
+				oneCall := one.Reconcile(gomockinternal.AContext()).Return(nil)
+				two.Reconcile(gomockinternal.AContext()).Return(nil).After(oneCall)
+				three.Reconcile(gomockinternal.AContext()).Return(nil).After(oneCall)
+			},
+		},
+		"concurrent service reconcile fails": {
+			expectedError: "failed to reconcile AzureCluster service three: some error happened",
+			concurrent:    sets.New("two", "three"),
+			expect: func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				one.Name().Return("one").AnyTimes()
+				two.Name().Return("two").AnyTimes()
+				three.Name().Return("three").AnyTimes()
+				one.Reconcile(gomockinternal.AContext()).Return(nil)
+				two.Reconcile(gomockinternal.AContext()).Return(nil)
+				three.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened"))
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -92,7 +120,8 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 					svcTwoMock,
 					svcThreeMock,
 				},
-				skuCache: resourceskus.NewStaticCache([]armcompute.ResourceSKU{}, ""),
+				concurrent: tc.concurrent,
+				skuCache:   resourceskus.NewStaticCache([]armcompute.ResourceSKU{}, ""),
 			}
 
 			err := s.reconcile(context.TODO())
@@ -106,6 +135,95 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileConcurrentlyAnnotations(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	clusterScope := &scope.ClusterScope{
+		Cluster:      &clusterv1.Cluster{},
+		AzureCluster: &infrav1.AzureCluster{},
+	}
+
+	// The security groups service writes the last applied security rules annotation while the other services of
+	// the concurrent group read the dry-run and locked resource policy annotations. Run with -race to detect
+	// unsynchronized access to the annotations.
+	writer := mock_azure.NewMockServiceReconciler(mockCtrl)
+	writer.EXPECT().Reconcile(gomockinternal.AContext()).DoAndReturn(func(context.Context) error {
+		for i := range 100 {
+			if err := clusterScope.UpdateAnnotationJSON(azure.SecurityRuleLastAppliedAnnotation, map[string]interface{}{"nsg": i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	reader := func() *mock_azure.MockServiceReconciler {
+		svc := mock_azure.NewMockServiceReconciler(mockCtrl)
+		svc.EXPECT().Reconcile(gomockinternal.AContext()).DoAndReturn(func(context.Context) error {
+			for range 100 {
+				_ = clusterScope.DryRun()
+				_ = clusterScope.OrphanLockedResources()
+				_ = clusterScope.AdoptResources()
+			}
+			return nil
+		})
+		return svc
+	}
+
+	g.Expect(reconcileConcurrently(context.TODO(), []azure.ServiceReconciler{writer, reader(), reader()})).To(Succeed())
+	g.Expect(clusterScope.AzureCluster.Annotations).To(HaveKey(azure.SecurityRuleLastAppliedAnnotation))
+}
+
+func TestAzureClusterServiceTemplate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	cluster := newCluster("foo")
+	azureCluster := newAzureCluster("westus")
+	azureCluster.Default()
+	fakeIdentity := &infrav1.AzureClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-identity",
+			Namespace: "default",
+		},
+		Spec: infrav1.AzureClusterIdentitySpec{
+			Type:     infrav1.ServicePrincipal,
+			TenantID: "fake-tenantid",
+		},
+	}
+	fakeSecret := &corev1.Secret{Data: map[string][]byte{"clientSecret": []byte("fooSecret")}}
+	kubeclient := fakeclient.NewClientBuilder().WithScheme(setupScheme(g)).WithRuntimeObjects(fakeIdentity, fakeSecret).Build()
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+		Client:       kubeclient,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterScope.AzureCluster.SetBackendPoolNameDefault()
+	clusterScope.SetDNSName()
+	clusterScope.SetControlPlaneSecurityRules()
+
+	s := &azureClusterService{scope: clusterScope}
+	template, err := s.template(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(template.Resources).To(HaveLen(2))
+	g.Expect(template.Resources[0]).To(HaveKeyWithValue("type", "Microsoft.Resources/resourceGroups"))
+	g.Expect(template.Resources[0]).To(HaveKeyWithValue("name", "bar"))
+	g.Expect(template.Resources[1]).To(HaveKeyWithValue("resourceGroup", "bar"))
+	deployment := template.Resources[1]["properties"].(map[string]interface{})["template"].(*armtemplate.Template)
+	var types []interface{}
+	for _, resource := range deployment.Resources {
+		types = append(types, resource["type"])
+	}
+	g.Expect(types).To(ContainElements(
+		"Microsoft.Network/virtualNetworks",
+		"Microsoft.Network/networkSecurityGroups",
+		"Microsoft.Network/virtualNetworks/subnets",
+		"Microsoft.Network/loadBalancers",
+	))
+}
+
 func TestAzureClusterServicePause(t *testing.T) {
 	type pausingServiceReconciler struct {
 		*mock_azure.MockServiceReconciler
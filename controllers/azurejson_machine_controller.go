@@ -197,6 +197,11 @@ func (r *AzureJSONMachineReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{}, errors.Wrap(err, "failed to create scope")
 	}
 
+	if clusterScope.CloudProviderConfigDisabled() {
+		log.V(4).Info("cloud provider config is disabled for the cluster, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	apiVersion, kind := infrav1.GroupVersion.WithKind("AzureMachine").ToAPIVersionAndKind()
 	owner := metav1.OwnerReference{
 		APIVersion: apiVersion,
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -163,10 +164,14 @@ func TestAzureJSONMachineReconciler(t *testing.T) {
 	}
 	fakeSecret := &corev1.Secret{Data: map[string][]byte{"clientSecret": []byte("fooSecret")}}
 
+	azureClusterWithoutCloudProviderConfig := azureCluster.DeepCopy()
+	azureClusterWithoutCloudProviderConfig.Spec.DisableCloudProviderConfig = true
+
 	cases := map[string]struct {
-		objects []runtime.Object
-		fail    bool
-		err     string
+		objects  []runtime.Object
+		fail     bool
+		err      string
+		noSecret bool
 	}{
 		"should reconcile normally": {
 			objects: []runtime.Object{
@@ -177,6 +182,16 @@ func TestAzureJSONMachineReconciler(t *testing.T) {
 				fakeSecret,
 			},
 		},
+		"cloud provider config is disabled": {
+			objects: []runtime.Object{
+				cluster,
+				azureClusterWithoutCloudProviderConfig,
+				azureMachine,
+				fakeIdentity,
+				fakeSecret,
+			},
+			noSecret: true,
+		},
 		"missing azure cluster should return error": {
 			objects: []runtime.Object{
 				cluster,
@@ -249,6 +264,12 @@ func TestAzureJSONMachineReconciler(t *testing.T) {
 					t.Errorf("expected success, but got error: %s", err.Error())
 				}
 			}
+			if tc.noSecret {
+				err := client.Get(context.Background(), types.NamespacedName{Name: "my-machine-azure-json"}, &corev1.Secret{})
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("expected no cloud provider config secret, but got: %v", err)
+				}
+			}
 		})
 	}
 }
@@ -139,6 +139,11 @@ func (r *AzureJSONMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl
 		return reconcile.Result{}, errors.Wrapf(err, "failed to create cluster scope for cluster %s/%s", cluster.Namespace, cluster.Name)
 	}
 
+	if clusterScope.CloudProviderConfigDisabled() {
+		log.V(4).Info("cloud provider config is disabled for the cluster, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	// Construct secret for this machine
 	userAssignedIdentityIfExists := ""
 	if len(azureMachinePool.Spec.UserAssignedIdentities) > 0 {
@@ -122,6 +122,7 @@ func (amr *AzureMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinesets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 
@@ -171,6 +172,12 @@ func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	log = log.WithValues("cluster", cluster.Name)
+	ctx = azure.WithAuditRequester(ctx, azure.AuditRequester{
+		Kind:      infrav1.AzureMachineKind,
+		Namespace: azureMachine.Namespace,
+		Name:      azureMachine.Name,
+		Cluster:   cluster.Name,
+	})
 
 	log = log.WithValues("AzureCluster", cluster.Spec.InfrastructureRef.Name)
 	azureClusterName := client.ObjectKey{
@@ -362,6 +369,14 @@ func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineS
 	defer done()
 
 	log.Info("Handling deleted AzureMachine")
+	// The Azure resources of the machine are protected when the machine is, or when the whole cluster is being deleted and is protected.
+	if IsDeletionProtected(machineScope.AzureMachine) || (IsDeletionProtected(clusterScope.AzureCluster) && !clusterScope.Cluster.DeletionTimestamp.IsZero()) {
+		msg := fmt.Sprintf("Azure resources are protected from deletion, remove the %s annotation to delete them", infrav1.DeletionProtectionAnnotation)
+		log.Info("Skipping AzureMachine delete: " + msg)
+		amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.DeletionProtectedReason, msg)
+		conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, infrav1.DeletionProtectedReason, clusterv1.ConditionSeverityWarning, "%s", msg)
+		return reconcile.Result{}, nil
+	}
 	conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := machineScope.PatchObject(ctx); err != nil {
 		return reconcile.Result{}, err
@@ -388,6 +403,15 @@ func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineS
 				}
 			}
 
+			if azure.IsResourceLocked(err) {
+				// Retrying won't succeed until the lock is removed, so only retry on the next resync or change of the AzureMachine.
+				msg := fmt.Sprintf("Azure resources are locked, remove the management lock or set the %s annotation to %q to leave them in Azure: %s", infrav1.LockedResourcePolicyAnnotation, infrav1.LockedResourcePolicyOrphan, err.Error())
+				log.Info("Failed to delete AzureMachine: " + msg)
+				amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.ResourceLockedReason, msg)
+				conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, infrav1.ResourceLockedReason, clusterv1.ConditionSeverityError, "%s", msg)
+				return reconcile.Result{}, nil
+			}
+
 			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "Error deleting AzureMachine", errors.Wrapf(err, "error deleting AzureMachine %s/%s", machineScope.Namespace(), machineScope.Name()).Error())
 			return reconcile.Result{}, errors.Wrapf(err, "error deleting AzureMachine %s/%s", machineScope.Namespace(), machineScope.Name())
 		}
@@ -326,6 +326,13 @@ func TestAzureMachineReconcileDelete(t *testing.T) {
 			cache:                     &scope.MachineCache{},
 			expectedErr:               "error deleting AzureMachine",
 		},
+		"should not delete Azure resources when protected from deletion": {
+			createAzureMachineService: getFakeAzureMachineServiceWithGeneralError,
+			azureMachineOptions: func(am *infrav1.AzureMachine) {
+				am.Annotations = map[string]string{infrav1.DeletionProtectionAnnotation: "true"}
+			},
+			cache: &scope.MachineCache{},
+		},
 	}
 
 	for name, c := range cases {
@@ -20,15 +20,21 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/armtemplate"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootstrapdata"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/features"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/jitaccesspolicies"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/spotplacement"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
@@ -77,6 +83,10 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating tags service")
 	}
+	bootstrapDataSvc, err := bootstrapdata.New(machineScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating bootstrapdata service")
+	}
 	virtualmachinesSvc, err := virtualmachines.New(machineScope)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating virtualmachines service")
@@ -85,6 +95,18 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating vmextensions service")
 	}
+	jitAccessPoliciesSvc, err := jitaccesspolicies.New(machineScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating jitaccesspolicies service")
+	}
+	featuresSvc, err := features.New(machineScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating features service")
+	}
+	spotPlacementSvc, err := spotplacement.New(machineScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating spotplacement service")
+	}
 	networkInterfacesSvc, err := networkinterfaces.New(machineScope, cache)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating networkinterfaces service")
@@ -92,14 +114,18 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 	ams := &azureMachineService{
 		scope: machineScope,
 		services: []azure.ServiceReconciler{
+			featuresSvc,
+			spotPlacementSvc,
 			publicIPsSvc,
 			inboundnatrulesSvc,
 			networkInterfacesSvc,
 			availabilitySetsSvc,
 			disksSvc,
+			bootstrapDataSvc,
 			virtualmachinesSvc,
 			roleAssignmentsSvc,
 			vmextensionsSvc,
+			jitAccessPoliciesSvc,
 			tagsSvc,
 		},
 		skuCache: cache,
@@ -123,6 +149,20 @@ func (s *azureMachineService) reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed defaulting subnet name")
 	}
 
+	if err := s.scope.SetFailureDomain(ctx); err != nil {
+		return errors.Wrap(err, "failed defaulting failure domain")
+	}
+
+	if s.scope.DryRun() {
+		template, err := s.template(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to render ARM template")
+		}
+		if err := reconcileARMTemplate(ctx, s.scope.GetClient(), s.scope.AzureMachine, template); err != nil {
+			return err
+		}
+	}
+
 	for _, service := range s.services {
 		if err := service.Reconcile(ctx); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureMachine service %s", service.Name())
@@ -132,6 +172,28 @@ func (s *azureMachineService) reconcile(ctx context.Context) error {
 	return nil
 }
 
+// template renders the Azure resources of the machine as an ARM template, in the order in which they are reconciled.
+func (s *azureMachineService) template(ctx context.Context) (*armtemplate.Template, error) {
+	b := armtemplate.NewBuilder(s.scope.GetClient().Scheme())
+	var specs []azure.ResourceSpecGetter
+	specs = append(specs, s.scope.PublicIPSpecs()...)
+	specs = append(specs, s.scope.NICSpecs()...)
+	if spec := s.scope.AvailabilitySetSpec(); spec != nil {
+		specs = append(specs, spec)
+	}
+	specs = append(specs, s.scope.VMSpec())
+	specs = append(specs, s.scope.VMExtensionSpecs()...)
+
+	var errs []error
+	for _, spec := range specs {
+		errs = append(errs, b.AddResource(ctx, spec))
+	}
+	if err := kerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return b.Template(), nil
+}
+
 // pause pauses all components making up the machine.
 func (s *azureMachineService) pause(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureMachineService.pause")
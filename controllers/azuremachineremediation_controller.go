@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// defaultRemediationTimeout is how long to wait for a machine to become healthy after an Azure-side remediation when
+// the AzureMachineRemediation doesn't specify a timeout.
+const defaultRemediationTimeout = 10 * time.Minute
+
+// AzureMachineRemediationReconciler implements the Cluster API external remediation contract for AzureMachines. It
+// restarts or redeploys the virtual machine of an unhealthy Machine, and hands the Machine back to its owner to be
+// replaced if the virtual machine doesn't recover.
+type AzureMachineRemediationReconciler struct {
+	client.Client
+	Recorder         record.EventRecorder
+	Timeouts         reconciler.Timeouts
+	WatchFilterValue string
+}
+
+// SetupWithManager initializes this controller with a manager.
+func (r *AzureMachineRemediationReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, log, done := tele.StartSpanWithLogger(ctx,
+		"controllers.AzureMachineRemediationReconciler.SetupWithManager",
+		tele.KVP("controller", infrav1.AzureMachineRemediationKind),
+	)
+	defer done()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.AzureMachineRemediation{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachineremediations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachineremediations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachineremediationtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;update;patch
+
+// Reconcile restarts or redeploys the virtual machine of the Machine an AzureMachineRemediation was created for.
+func (r *AzureMachineRemediationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeouts.DefaultedLoopTimeout())
+	defer cancel()
+
+	ctx, log, done := tele.StartSpanWithLogger(ctx,
+		"controllers.AzureMachineRemediationReconciler.Reconcile",
+		tele.KVP("namespace", req.Namespace),
+		tele.KVP("name", req.Name),
+		tele.KVP("kind", infrav1.AzureMachineRemediationKind),
+	)
+	defer done()
+
+	remediation := &infrav1.AzureMachineRemediation{}
+	if err := r.Get(ctx, req.NamespacedName, remediation); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if remediation.Status.Phase == infrav1.RemediationPhaseDeleting {
+		log.V(4).Info("Machine was handed back to its owner for replacement")
+		return ctrl.Result{}, nil
+	}
+
+	machine, err := util.GetOwnerMachine(ctx, r.Client, remediation.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machine == nil {
+		log.Info("Waiting for MachineHealthCheck to set OwnerRef on AzureMachineRemediation")
+		return ctrl.Result{}, nil
+	}
+	log = log.WithValues("machine", machine.Name)
+
+	cluster, err := util.GetClusterByName(ctx, r.Client, machine.Namespace, machine.Spec.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to get owning cluster")
+	}
+	if annotations.IsPaused(cluster, remediation) {
+		log.Info("AzureMachineRemediation or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(remediation, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, remediation); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	strategy := remediation.Spec.Strategy
+	timeout := defaultRemediationTimeout
+	if strategy.Timeout != nil {
+		timeout = strategy.Timeout.Duration
+	}
+
+	if remediation.Status.LastRemediated != nil {
+		if remaining := time.Until(remediation.Status.LastRemediated.Add(timeout)); remaining > 0 {
+			log.V(4).Info("Waiting for the machine to become healthy", "remaining", remaining)
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	if remediation.Status.RetryCount >= max(strategy.RetryLimit, 1) {
+		return ctrl.Result{}, r.replaceMachine(ctx, remediation, machine, fmt.Sprintf("machine did not recover after %d remediation attempts", remediation.Status.RetryCount))
+	}
+
+	if machine.Spec.InfrastructureRef.Kind != infrav1.AzureMachineKind {
+		return ctrl.Result{}, r.replaceMachine(ctx, remediation, machine, fmt.Sprintf("infrastructure kind %s can't be remediated", machine.Spec.InfrastructureRef.Kind))
+	}
+	azureMachine := &infrav1.AzureMachine{}
+	key := client.ObjectKey{Namespace: machine.Namespace, Name: machine.Spec.InfrastructureRef.Name}
+	if err := r.Get(ctx, key, azureMachine); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get AzureMachine %s", key.Name)
+	}
+	if azureMachine.Spec.ProviderID == nil {
+		return ctrl.Result{}, r.replaceMachine(ctx, remediation, machine, "virtual machine was never provisioned")
+	}
+
+	annotation, action := infrav1.RedeployAnnotation, infrav1.RemediationTypeRedeploy
+	if strategy.Type == infrav1.RemediationTypeRestart {
+		annotation, action = infrav1.RestartAnnotation, infrav1.RemediationTypeRestart
+	}
+	before := azureMachine.DeepCopy()
+	if azureMachine.Annotations == nil {
+		azureMachine.Annotations = map[string]string{}
+	}
+	azureMachine.Annotations[annotation] = "true"
+	if err := r.Patch(ctx, azureMachine, client.MergeFrom(before)); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to annotate AzureMachine %s", azureMachine.Name)
+	}
+
+	remediation.Status.Phase = infrav1.RemediationPhaseRunning
+	remediation.Status.RetryCount++
+	remediation.Status.LastRemediated = &metav1.Time{Time: time.Now()}
+	log.Info("Requested remediation of the virtual machine", "type", action, "attempt", remediation.Status.RetryCount)
+	r.Recorder.Eventf(remediation, corev1.EventTypeNormal, "RemediationRequested", "Requested %s of AzureMachine %s (attempt %d)", action, azureMachine.Name, remediation.Status.RetryCount)
+
+	return ctrl.Result{RequeueAfter: timeout}, nil
+}
+
+// replaceMachine gives up on remediating the virtual machine and lets the owner of the Machine replace it, as described
+// by the Cluster API external remediation contract.
+func (r *AzureMachineRemediationReconciler) replaceMachine(ctx context.Context, remediation *infrav1.AzureMachineRemediation, machine *clusterv1.Machine, reason string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineRemediationReconciler.replaceMachine")
+	defer done()
+
+	machineHelper, err := patch.NewHelper(machine, r.Client)
+	if err != nil {
+		return errors.Wrap(err, "failed to init patch helper")
+	}
+	conditions.MarkFalse(machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "%s", reason)
+	if err := machineHelper.Patch(ctx, machine); err != nil {
+		return errors.Wrapf(err, "failed to patch Machine %s", machine.Name)
+	}
+
+	remediation.Status.Phase = infrav1.RemediationPhaseDeleting
+	log.Info("Handing the machine back to its owner for replacement", "reason", reason)
+	r.Recorder.Eventf(remediation, corev1.EventTypeWarning, "RemediationFailed", "Machine %s will be replaced: %s", machine.Name, reason)
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAzureMachineRemediationReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	scheme, err := newScheme()
+	NewGomegaWithT(t).Expect(err).NotTo(HaveOccurred())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+	}
+	newMachine := func() *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-machine", Namespace: "default", UID: "machine-uid"},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: "my-cluster",
+				InfrastructureRef: corev1.ObjectReference{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       infrav1.AzureMachineKind,
+					Name:       "my-azure-machine",
+				},
+			},
+		}
+	}
+	newAzureMachine := func() *infrav1.AzureMachine {
+		return &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-azure-machine", Namespace: "default"},
+			Spec:       infrav1.AzureMachineSpec{ProviderID: ptr.To("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")},
+		}
+	}
+	newRemediation := func() *infrav1.AzureMachineRemediation {
+		return &infrav1.AzureMachineRemediation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-machine",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Machine",
+						Name:       "my-machine",
+						UID:        "machine-uid",
+					},
+				},
+			},
+		}
+	}
+	newReconciler := func(objs ...client.Object) *AzureMachineRemediationReconciler {
+		c := fakeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&infrav1.AzureMachineRemediation{}, &clusterv1.Machine{}).
+			WithObjects(objs...).
+			Build()
+		return &AzureMachineRemediationReconciler{
+			Client:   c,
+			Recorder: record.NewFakeRecorder(10),
+		}
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-machine"}}
+
+	t.Run("AzureMachineRemediation does not exist", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		r := newReconciler()
+		result, err := r.Reconcile(ctx, request)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+	})
+
+	t.Run("redeploys the virtual machine by default", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		r := newReconciler(cluster.DeepCopy(), newMachine(), newAzureMachine(), newRemediation())
+		result, err := r.Reconcile(ctx, request)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: defaultRemediationTimeout}))
+
+		azureMachine := &infrav1.AzureMachine{}
+		g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "my-azure-machine"}, azureMachine)).To(Succeed())
+		g.Expect(azureMachine.Annotations).To(HaveKeyWithValue(infrav1.RedeployAnnotation, "true"))
+
+		remediation := &infrav1.AzureMachineRemediation{}
+		g.Expect(r.Get(ctx, request.NamespacedName, remediation)).To(Succeed())
+		g.Expect(remediation.Status.Phase).To(Equal(infrav1.RemediationPhaseRunning))
+		g.Expect(remediation.Status.RetryCount).To(Equal(1))
+		g.Expect(remediation.Status.LastRemediated).NotTo(BeNil())
+	})
+
+	t.Run("restarts the virtual machine", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		remediation := newRemediation()
+		remediation.Spec.Strategy = infrav1.RemediationStrategy{
+			Type:    infrav1.RemediationTypeRestart,
+			Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+		}
+		r := newReconciler(cluster.DeepCopy(), newMachine(), newAzureMachine(), remediation)
+		result, err := r.Reconcile(ctx, request)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: 5 * time.Minute}))
+
+		azureMachine := &infrav1.AzureMachine{}
+		g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "my-azure-machine"}, azureMachine)).To(Succeed())
+		g.Expect(azureMachine.Annotations).To(HaveKeyWithValue(infrav1.RestartAnnotation, "true"))
+		g.Expect(azureMachine.Annotations).NotTo(HaveKey(infrav1.RedeployAnnotation))
+	})
+
+	t.Run("waits for the machine to recover before retrying", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		remediation := newRemediation()
+		remediation.Status = infrav1.AzureMachineRemediationStatus{
+			Phase:          infrav1.RemediationPhaseRunning,
+			RetryCount:     1,
+			LastRemediated: &metav1.Time{Time: time.Now()},
+		}
+		r := newReconciler(cluster.DeepCopy(), newMachine(), newAzureMachine(), remediation)
+		result, err := r.Reconcile(ctx, request)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		g.Expect(result.RequeueAfter).To(BeNumerically("<=", defaultRemediationTimeout))
+
+		azureMachine := &infrav1.AzureMachine{}
+		g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "my-azure-machine"}, azureMachine)).To(Succeed())
+		g.Expect(azureMachine.Annotations).To(BeEmpty())
+	})
+
+	t.Run("retries until the retry limit is reached", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		remediation := newRemediation()
+		remediation.Spec.Strategy.RetryLimit = 2
+		remediation.Status = infrav1.AzureMachineRemediationStatus{
+			Phase:          infrav1.RemediationPhaseRunning,
+			RetryCount:     1,
+			LastRemediated: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		}
+		r := newReconciler(cluster.DeepCopy(), newMachine(), newAzureMachine(), remediation)
+		_, err := r.Reconcile(ctx, request)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(r.Get(ctx, request.NamespacedName, remediation)).To(Succeed())
+		g.Expect(remediation.Status.Phase).To(Equal(infrav1.RemediationPhaseRunning))
+		g.Expect(remediation.Status.RetryCount).To(Equal(2))
+	})
+
+	t.Run("hands the machine back to its owner when remediation fails", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		remediation := newRemediation()
+		remediation.Status = infrav1.AzureMachineRemediationStatus{
+			Phase:          infrav1.RemediationPhaseRunning,
+			RetryCount:     1,
+			LastRemediated: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		}
+		r := newReconciler(cluster.DeepCopy(), newMachine(), newAzureMachine(), remediation)
+		result, err := r.Reconcile(ctx, request)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+
+		g.Expect(r.Get(ctx, request.NamespacedName, remediation)).To(Succeed())
+		g.Expect(remediation.Status.Phase).To(Equal(infrav1.RemediationPhaseDeleting))
+
+		machine := &clusterv1.Machine{}
+		g.Expect(r.Get(ctx, request.NamespacedName, machine)).To(Succeed())
+		g.Expect(conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(machine, clusterv1.MachineOwnerRemediatedCondition)).To(Equal(clusterv1.WaitingForRemediationReason))
+	})
+
+	t.Run("hands the machine back to its owner when the virtual machine was never provisioned", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		azureMachine := newAzureMachine()
+		azureMachine.Spec.ProviderID = nil
+		r := newReconciler(cluster.DeepCopy(), newMachine(), azureMachine, newRemediation())
+		_, err := r.Reconcile(ctx, request)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		remediation := &infrav1.AzureMachineRemediation{}
+		g.Expect(r.Get(ctx, request.NamespacedName, remediation)).To(Succeed())
+		g.Expect(remediation.Status.Phase).To(Equal(infrav1.RemediationPhaseDeleting))
+		g.Expect(remediation.Status.RetryCount).To(BeZero())
+	})
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// gpuResourceNames maps GPU vendors to the extended resource names advertised by their Kubernetes device plugins.
+var gpuResourceNames = map[string]corev1.ResourceName{
+	azure.GPUVendorNVIDIA: "nvidia.com/gpu",
+	azure.GPUVendorAMD:    "amd.com/gpu",
+}
+
+// AzureMachineTemplateReconciler reports the capacity of the VM size of AzureMachineTemplates, so that the cluster
+// autoscaler can scale node groups up from zero.
+type AzureMachineTemplateReconciler struct {
+	client.Client
+	Recorder         record.EventRecorder
+	Timeouts         reconciler.Timeouts
+	WatchFilterValue string
+}
+
+// SetupWithManager initializes this controller with a manager.
+func (r *AzureMachineTemplateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, log, done := tele.StartSpanWithLogger(ctx,
+		"controllers.AzureMachineTemplateReconciler.SetupWithManager",
+		tele.KVP("controller", infrav1.AzureMachineTemplateKind),
+	)
+	defer done()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.AzureMachineTemplate{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
+		Named("AzureMachineTemplateCapacity").
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates/status,verbs=get;update;patch
+
+// Reconcile updates the capacity in the status of an AzureMachineTemplate from its VM size.
+func (r *AzureMachineTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeouts.DefaultedLoopTimeout())
+	defer cancel()
+
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineTemplateReconciler.Reconcile",
+		tele.KVP("namespace", req.Namespace),
+		tele.KVP("name", req.Name),
+		tele.KVP("kind", infrav1.AzureMachineTemplateKind),
+	)
+	defer done()
+
+	azureMachineTemplate := &infrav1.AzureMachineTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, azureMachineTemplate); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, azureMachineTemplate.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		log.Info("Cluster Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", cluster.Name)
+
+	if annotations.IsPaused(cluster, azureMachineTemplate) {
+		log.Info("AzureMachineTemplate or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	if cluster.Spec.InfrastructureRef == nil || cluster.Spec.InfrastructureRef.Kind != infrav1.AzureClusterKind {
+		log.V(4).Info("infra ref is not an AzureCluster")
+		return ctrl.Result{}, nil
+	}
+
+	azureCluster := &infrav1.AzureCluster{}
+	azureClusterName := types.NamespacedName{
+		Namespace: req.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	if err := r.Get(ctx, azureClusterName, azureCluster); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to fetch AzureCluster")
+	}
+
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		Client:       r.Client,
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+		Timeouts:     r.Timeouts,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
+	}
+
+	skuCache, err := resourceskus.GetCache(clusterScope, clusterScope.Location())
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to init resourceskus cache")
+	}
+	sku, err := skuCache.Get(ctx, azureMachineTemplate.Spec.Template.Spec.VMSize, resourceskus.VirtualMachines)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get SKU %s", azureMachineTemplate.Spec.Template.Spec.VMSize)
+	}
+
+	capacity, err := vmSizeCapacity(sku)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if apiequality.Semantic.DeepEqual(capacity, azureMachineTemplate.Status.Capacity) {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(azureMachineTemplate, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+	azureMachineTemplate.Status.Capacity = capacity
+	return ctrl.Result{}, patchHelper.Patch(ctx, azureMachineTemplate)
+}
+
+// vmSizeCapacity returns the CPU, memory and GPU capacity of a VM size.
+func vmSizeCapacity(sku resourceskus.SKU) (corev1.ResourceList, error) {
+	capacity := corev1.ResourceList{}
+
+	if vCPUs, ok := sku.GetCapability(resourceskus.VCPUs); ok {
+		quantity, err := resource.ParseQuantity(vCPUs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse vCPUs %q", vCPUs)
+		}
+		capacity[corev1.ResourceCPU] = quantity
+	}
+
+	if memoryGB, ok := sku.GetCapability(resourceskus.MemoryGB); ok {
+		quantity, err := resource.ParseQuantity(memoryGB + "Gi")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse memory %q", memoryGB)
+		}
+		capacity[corev1.ResourceMemory] = quantity
+	}
+
+	if gpus := sku.GPUCount(); gpus > 0 {
+		capacity[gpuResourceNames[sku.GPUVendor()]] = *resource.NewQuantity(gpus, resource.DecimalSI)
+	}
+
+	return capacity, nil
+}
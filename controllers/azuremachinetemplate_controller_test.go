@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+)
+
+func TestVMSizeCapacity(t *testing.T) {
+	tests := []struct {
+		name         string
+		sku          resourceskus.SKU
+		wantCapacity corev1.ResourceList
+		wantErr      bool
+	}{
+		{
+			name: "VM size without GPUs",
+			sku: resourceskus.SKU{
+				Name: ptr.To("Standard_D2s_v3"),
+				Capabilities: []*armcompute.ResourceSKUCapabilities{
+					{Name: ptr.To(resourceskus.VCPUs), Value: ptr.To("2")},
+					{Name: ptr.To(resourceskus.MemoryGB), Value: ptr.To("8")},
+				},
+			},
+			wantCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+		{
+			name: "VM size with NVIDIA GPUs",
+			sku: resourceskus.SKU{
+				Name: ptr.To("Standard_NC24s_v3"),
+				Capabilities: []*armcompute.ResourceSKUCapabilities{
+					{Name: ptr.To(resourceskus.VCPUs), Value: ptr.To("24")},
+					{Name: ptr.To(resourceskus.MemoryGB), Value: ptr.To("448")},
+					{Name: ptr.To(resourceskus.GPUs), Value: ptr.To("4")},
+				},
+			},
+			wantCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("24"),
+				corev1.ResourceMemory: resource.MustParse("448Gi"),
+				"nvidia.com/gpu":      resource.MustParse("4"),
+			},
+		},
+		{
+			name: "VM size with AMD GPUs and fractional memory",
+			sku: resourceskus.SKU{
+				Name: ptr.To("Standard_NV4as_v4"),
+				Capabilities: []*armcompute.ResourceSKUCapabilities{
+					{Name: ptr.To(resourceskus.VCPUs), Value: ptr.To("4")},
+					{Name: ptr.To(resourceskus.MemoryGB), Value: ptr.To("14.5")},
+					{Name: ptr.To(resourceskus.GPUs), Value: ptr.To("1")},
+				},
+			},
+			wantCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("14.5Gi"),
+				"amd.com/gpu":         resource.MustParse("1"),
+			},
+		},
+		{
+			name: "invalid memory",
+			sku: resourceskus.SKU{
+				Name: ptr.To("Standard_D2s_v3"),
+				Capabilities: []*armcompute.ResourceSKUCapabilities{
+					{Name: ptr.To(resourceskus.MemoryGB), Value: ptr.To("lots")},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			capacity, err := vmSizeCapacity(tc.sku)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(capacity).To(HaveLen(len(tc.wantCapacity)))
+			for name, quantity := range tc.wantCapacity {
+				actual := capacity[name]
+				g.Expect(actual.Equal(quantity)).To(BeTrue(), "unexpected %s capacity %s", name, actual.String())
+			}
+		})
+	}
+}
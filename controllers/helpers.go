@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -36,11 +37,13 @@ import (
 	"k8s.io/klog/v2"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/armtemplate"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -212,8 +215,8 @@ func GetCloudProviderSecret(d azure.ClusterScoper, namespace, name string, owner
 		controlPlaneConfig, workerNodeConfig = newCloudProviderConfig(d)
 	}
 
-	// Enable VMSS Flexible nodes if MachinePools are enabled
-	if feature.Gates.Enabled(capifeature.MachinePool) {
+	// Enable VMSS Flexible nodes if MachinePools are enabled or the out-of-tree cloud provider, which supports them, is used.
+	if feature.Gates.Enabled(capifeature.MachinePool) || (d.CloudProviderConfigOverrides() != nil && d.CloudProviderConfigOverrides().External != nil) {
 		if controlPlaneConfig != nil && controlPlaneConfig.VMType == "vmss" {
 			controlPlaneConfig.EnableVmssFlexNodes = true
 		}
@@ -273,7 +276,7 @@ func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudPro
 			AadClientSecret:              d.ClientSecret(),
 			TenantID:                     d.TenantID(),
 			SubscriptionID:               d.SubscriptionID(),
-			ResourceGroup:                d.ResourceGroup(),
+			ResourceGroup:                d.NodeResourceGroup(),
 			SecurityGroupName:            subnet.SecurityGroup.Name,
 			SecurityGroupResourceGroup:   d.Vnet().ResourceGroup,
 			Location:                     d.Location(),
@@ -286,6 +289,7 @@ func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudPro
 			RouteTableName:               subnet.RouteTable.Name,
 			LoadBalancerSku:              "Standard",
 			LoadBalancerName:             d.OutboundLBName(infrav1.Node),
+			LoadBalancerResourceGroup:    loadBalancerResourceGroup(d),
 			MaximumLoadBalancerRuleCount: 250,
 			UseManagedIdentityExtension:  false,
 			UseInstanceMetadata:          true,
@@ -296,7 +300,7 @@ func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudPro
 			AadClientSecret:              d.ClientSecret(),
 			TenantID:                     d.TenantID(),
 			SubscriptionID:               d.SubscriptionID(),
-			ResourceGroup:                d.ResourceGroup(),
+			ResourceGroup:                d.NodeResourceGroup(),
 			SecurityGroupName:            subnet.SecurityGroup.Name,
 			SecurityGroupResourceGroup:   d.Vnet().ResourceGroup,
 			Location:                     d.Location(),
@@ -309,12 +313,22 @@ func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudPro
 			RouteTableName:               subnet.RouteTable.Name,
 			LoadBalancerSku:              "Standard",
 			LoadBalancerName:             d.OutboundLBName(infrav1.Node),
+			LoadBalancerResourceGroup:    loadBalancerResourceGroup(d),
 			MaximumLoadBalancerRuleCount: 250,
 			UseManagedIdentityExtension:  false,
 			UseInstanceMetadata:          true,
 		}).overrideFromSpec(d)
 }
 
+// loadBalancerResourceGroup returns the resource group of the load balancers of the cluster when it differs from the
+// resource group of the nodes, or an empty string otherwise.
+func loadBalancerResourceGroup(d azure.ClusterScoper) string {
+	if d.ResourceGroup() == d.NodeResourceGroup() {
+		return ""
+	}
+	return d.ResourceGroup()
+}
+
 // getOneNodeSubnet returns one of the subnets for the node role.
 func getOneNodeSubnet(d azure.ClusterScoper) infrav1.SubnetSpec {
 	for _, subnet := range d.Subnets() {
@@ -345,11 +359,17 @@ type CloudProviderConfig struct {
 	RouteTableName               string `json:"routeTableName"`
 	LoadBalancerSku              string `json:"loadBalancerSku"`
 	LoadBalancerName             string `json:"loadBalancerName"`
+	LoadBalancerResourceGroup    string `json:"loadBalancerResourceGroup,omitempty"`
 	MaximumLoadBalancerRuleCount int    `json:"maximumLoadBalancerRuleCount"`
 	UseManagedIdentityExtension  bool   `json:"useManagedIdentityExtension"`
 	UseInstanceMetadata          bool   `json:"useInstanceMetadata"`
 	EnableVmssFlexNodes          bool   `json:"enableVmssFlexNodes,omitempty"`
 	UserAssignedIdentityID       string `json:"userAssignedIdentityID,omitempty"`
+	ExcludeMasterFromStandardLB  *bool  `json:"excludeMasterFromStandardLB,omitempty"`
+	// The following fields are only understood by the out-of-tree cloud provider.
+	LoadBalancerBackendPoolConfigurationType  string `json:"loadBalancerBackendPoolConfigurationType,omitempty"`
+	ClusterServiceLoadBalancerHealthProbeMode string `json:"clusterServiceLoadBalancerHealthProbeMode,omitempty"`
+	PutVMSSVMBatchSize                        int    `json:"putVMSSVMBatchSize,omitempty"`
 	CloudProviderRateLimitConfig
 	BackOffConfig
 }
@@ -396,6 +416,12 @@ func (cpc *CloudProviderConfig) overrideFromSpec(d azure.ClusterScoper) *CloudPr
 	}
 
 	cpc.BackOffConfig = toCloudProviderBackOffConfig(d.CloudProviderConfigOverrides().BackOffs)
+	cpc.ExcludeMasterFromStandardLB = d.CloudProviderConfigOverrides().ExcludeMasterFromStandardLB
+	if external := d.CloudProviderConfigOverrides().External; external != nil {
+		cpc.LoadBalancerBackendPoolConfigurationType = external.LoadBalancerBackendPoolConfigurationType
+		cpc.ClusterServiceLoadBalancerHealthProbeMode = external.ClusterServiceLoadBalancerHealthProbeMode
+		cpc.PutVMSSVMBatchSize = external.PutVMSSVMBatchSize
+	}
 	return cpc
 }
 
@@ -471,6 +497,75 @@ func toCloudProviderBackOffConfig(source infrav1.BackOffConfig) BackOffConfig {
 	return backOffConfig
 }
 
+const (
+	// armTemplateConfigMapSuffix is the suffix of the name of the ConfigMap holding the ARM template of the Azure
+	// resources of an object reconciled in dry-run mode.
+	armTemplateConfigMapSuffix = "-arm-template"
+	// armTemplateKey is the key of the ARM template in its ConfigMap.
+	armTemplateKey = "template.json"
+	// maxARMTemplateSize is the maximum size of an ARM template in its ConfigMap, as the data of a ConfigMap can't
+	// exceed 1 MiB. Some room is left for the key and metadata.
+	maxARMTemplateSize = 1<<20 - 1024
+)
+
+// armTemplateConfigMapName returns the name of the ConfigMap holding the ARM template of an object, which includes
+// its kind as AzureClusters and AzureMachines may have the same name.
+func armTemplateConfigMapName(owner client.Object, scheme *runtime.Scheme) (string, error) {
+	gvk, err := apiutil.GVKForObject(owner, scheme)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the kind of the ARM template owner")
+	}
+	return owner.GetName() + "-" + strings.ToLower(gvk.Kind) + armTemplateConfigMapSuffix, nil
+}
+
+// reconcileARMTemplate writes the ARM template of the Azure resources of an object reconciled in dry-run mode to a
+// ConfigMap named after the object and owned by it. Templates too large for a ConfigMap are not written, and the
+// ConfigMap of a previous template is deleted rather than left out of date.
+func reconcileARMTemplate(ctx context.Context, kubeclient client.Client, owner client.Object, template *armtemplate.Template) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.reconcileARMTemplate")
+	defer done()
+
+	name, err := armTemplateConfigMapName(owner, kubeclient.Scheme())
+	if err != nil {
+		return err
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: owner.GetNamespace(),
+			Name:      name,
+		},
+	}
+
+	data, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal ARM template")
+	}
+	if len(data) > maxARMTemplateSize {
+		// Indentation makes large templates much larger, drop it before giving up.
+		if data, err = json.Marshal(template); err != nil {
+			return errors.Wrap(err, "failed to marshal ARM template")
+		}
+	}
+	if len(data) > maxARMTemplateSize {
+		log.Info("ARM template is too large for a ConfigMap, skipping it", "configMap", configMap.Name, "size", len(data))
+		record.Warnf(owner, "ARMTemplateTooLarge", "ARM template of %d bytes exceeds the maximum size of %d bytes of ConfigMap %s", len(data), maxARMTemplateSize, configMap.Name)
+		if err := kubeclient.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, kubeclient, configMap, func() error {
+		configMap.Data = map[string]string{armTemplateKey: string(data)}
+		return controllerutil.SetOwnerReference(owner, configMap, kubeclient.Scheme())
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to reconcile ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+	}
+	log.V(2).Info("reconciled ARM template", "configMap", configMap.Name, "result", result)
+	return nil
+}
+
 func reconcileAzureSecret(ctx context.Context, kubeclient client.Client, owner metav1.OwnerReference, newSecret *corev1.Secret, clusterName string) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.reconcileAzureSecret")
 	defer done()
@@ -1124,6 +1219,12 @@ func GetClusterScoper(ctx context.Context, logger logr.Logger, c client.Client,
 	return nil, errors.Errorf("unsupported infrastructure type %q, should be AzureCluster or AzureManagedCluster", cluster.Spec.InfrastructureRef.Kind)
 }
 
+// IsDeletionProtected returns true if the object's Azure resources must not be deleted because it has the
+// deletion protection annotation set to "true".
+func IsDeletionProtected(obj metav1.Object) bool {
+	return obj.GetAnnotations()[infrav1.DeletionProtectionAnnotation] == "true"
+}
+
 // AddBlockMoveAnnotation adds CAPI's block-move annotation and returns whether or not the annotation was added.
 func AddBlockMoveAnnotation(obj metav1.Object) bool {
 	annotations := obj.GetAnnotations()
@@ -29,6 +29,7 @@ import (
 	"go.uber.org/mock/gomock"
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,6 +37,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/armtemplate"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test/mock_log"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -140,6 +142,13 @@ func TestGetCloudProviderConfig(t *testing.T) {
 			expectedControlPlaneConfig: spCustomVnetControlPlaneCloudConfig,
 			expectedWorkerNodeConfig:   spCustomVnetWorkerNodeCloudConfig,
 		},
+		"with node resource group": {
+			cluster:                    cluster,
+			azureCluster:               withNodeResourceGroup(*azureCluster),
+			identityType:               infrav1.VMIdentityNone,
+			expectedControlPlaneConfig: nodeResourceGroupCloudConfig,
+			expectedWorkerNodeConfig:   nodeResourceGroupCloudConfig,
+		},
 		"with rate limits": {
 			cluster:                    cluster,
 			azureCluster:               withRateLimits(*azureCluster),
@@ -154,6 +163,20 @@ func TestGetCloudProviderConfig(t *testing.T) {
 			expectedControlPlaneConfig: backOffCloudConfig,
 			expectedWorkerNodeConfig:   backOffCloudConfig,
 		},
+		"with excludeMasterFromStandardLB": {
+			cluster:                    cluster,
+			azureCluster:               withExcludeMasterFromStandardLB(*azureCluster),
+			identityType:               infrav1.VMIdentityNone,
+			expectedControlPlaneConfig: excludeMasterFromStandardLBCloudConfig,
+			expectedWorkerNodeConfig:   excludeMasterFromStandardLBCloudConfig,
+		},
+		"with external cloud provider": {
+			cluster:                    cluster,
+			azureCluster:               withExternalCloudProvider(*azureCluster),
+			identityType:               infrav1.VMIdentityNone,
+			expectedControlPlaneConfig: externalCloudProviderCloudConfig,
+			expectedWorkerNodeConfig:   externalCloudProviderCloudConfig,
+		},
 		"with machinepools": {
 			cluster:                    cluster,
 			azureCluster:               azureCluster,
@@ -366,6 +389,31 @@ func TestReconcileAzureSecret(t *testing.T) {
 	}
 }
 
+func TestReconcileARMTemplate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	azureCluster := newAzureCluster("westus")
+	azureCluster.UID = "uid"
+	kubeclient := fake.NewClientBuilder().WithScheme(setupScheme(g)).WithObjects(azureCluster).Build()
+
+	template := &armtemplate.Template{Schema: "schema", ContentVersion: "1.0.0.0"}
+	g.Expect(reconcileARMTemplate(ctx, kubeclient, azureCluster, template)).To(Succeed())
+	template.ContentVersion = "2.0.0.0"
+	g.Expect(reconcileARMTemplate(ctx, kubeclient, azureCluster, template)).To(Succeed())
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: "default", Name: "foo-azurecluster-arm-template"}
+	g.Expect(kubeclient.Get(ctx, key, configMap)).To(Succeed())
+	g.Expect(configMap.Data).To(HaveKeyWithValue("template.json", ContainSubstring(`"contentVersion": "2.0.0.0"`)))
+	g.Expect(configMap.OwnerReferences).To(ConsistOf(HaveField("Name", "foo")))
+
+	// A template too large for a ConfigMap replaces the previous one by nothing.
+	template.Resources = []armtemplate.Resource{{"properties": strings.Repeat("x", maxARMTemplateSize)}}
+	g.Expect(reconcileARMTemplate(ctx, kubeclient, azureCluster, template)).To(Succeed())
+	g.Expect(apierrors.IsNotFound(kubeclient.Get(ctx, key, configMap))).To(BeTrue())
+}
+
 func setupScheme(g *WithT) *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
@@ -464,6 +512,27 @@ func withbackOffConfig(ac infrav1.AzureCluster) *infrav1.AzureCluster {
 	return &ac
 }
 
+func withExcludeMasterFromStandardLB(ac infrav1.AzureCluster) *infrav1.AzureCluster {
+	ac.Spec.CloudProviderConfigOverrides = &infrav1.CloudProviderConfigOverrides{ExcludeMasterFromStandardLB: ptr.To(false)}
+	return &ac
+}
+
+func withNodeResourceGroup(ac infrav1.AzureCluster) *infrav1.AzureCluster {
+	ac.Spec.NodeResourceGroup = "bar-compute"
+	return &ac
+}
+
+func withExternalCloudProvider(ac infrav1.AzureCluster) *infrav1.AzureCluster {
+	ac.Spec.CloudProviderConfigOverrides = &infrav1.CloudProviderConfigOverrides{
+		External: &infrav1.ExternalCloudProviderConfig{
+			LoadBalancerBackendPoolConfigurationType:  "nodeIP",
+			ClusterServiceLoadBalancerHealthProbeMode: "shared",
+			PutVMSSVMBatchSize:                        10,
+		},
+	}
+	return &ac
+}
+
 func newAzureClusterWithCustomVnet(location string) *infrav1.AzureCluster {
 	return &infrav1.AzureCluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -678,6 +747,29 @@ const (
     "useManagedIdentityExtension": false,
     "useInstanceMetadata": true,
     "enableVmssFlexNodes": true
+}`
+	nodeResourceGroupCloudConfig = `{
+    "cloud": "AzurePublicCloud",
+    "tenantId": "fooTenant",
+    "subscriptionId": "baz",
+    "aadClientId": "fooClient",
+    "aadClientSecret": "fooSecret",
+    "resourceGroup": "bar-compute",
+    "securityGroupName": "foo-node-nsg",
+    "securityGroupResourceGroup": "bar",
+    "location": "bar",
+    "vmType": "vmss",
+    "vnetName": "foo-vnet",
+    "vnetResourceGroup": "bar",
+    "subnetName": "foo-node-subnet",
+    "routeTableName": "foo-node-routetable",
+    "loadBalancerSku": "Standard",
+    "loadBalancerName": "",
+    "loadBalancerResourceGroup": "bar",
+    "maximumLoadBalancerRuleCount": 250,
+    "useManagedIdentityExtension": false,
+    "useInstanceMetadata": true,
+    "enableVmssFlexNodes": true
 }`
 	rateLimitsControlPlaneCloudConfig = `{
     "cloud": "AzurePublicCloud",
@@ -759,6 +851,54 @@ const (
     "cloudProviderBackoffExponent": 1.2000000000000002,
     "cloudProviderBackoffDuration": 60,
     "cloudProviderBackoffJitter": 1.2000000000000002
+}`
+	excludeMasterFromStandardLBCloudConfig = `{
+    "cloud": "AzurePublicCloud",
+    "tenantId": "fooTenant",
+    "subscriptionId": "baz",
+    "aadClientId": "fooClient",
+    "aadClientSecret": "fooSecret",
+    "resourceGroup": "bar",
+    "securityGroupName": "foo-node-nsg",
+    "securityGroupResourceGroup": "bar",
+    "location": "bar",
+    "vmType": "vmss",
+    "vnetName": "foo-vnet",
+    "vnetResourceGroup": "bar",
+    "subnetName": "foo-node-subnet",
+    "routeTableName": "foo-node-routetable",
+    "loadBalancerSku": "Standard",
+    "loadBalancerName": "",
+    "maximumLoadBalancerRuleCount": 250,
+    "useManagedIdentityExtension": false,
+    "useInstanceMetadata": true,
+    "enableVmssFlexNodes": true,
+    "excludeMasterFromStandardLB": false
+}`
+	externalCloudProviderCloudConfig = `{
+    "cloud": "AzurePublicCloud",
+    "tenantId": "fooTenant",
+    "subscriptionId": "baz",
+    "aadClientId": "fooClient",
+    "aadClientSecret": "fooSecret",
+    "resourceGroup": "bar",
+    "securityGroupName": "foo-node-nsg",
+    "securityGroupResourceGroup": "bar",
+    "location": "bar",
+    "vmType": "vmss",
+    "vnetName": "foo-vnet",
+    "vnetResourceGroup": "bar",
+    "subnetName": "foo-node-subnet",
+    "routeTableName": "foo-node-routetable",
+    "loadBalancerSku": "Standard",
+    "loadBalancerName": "",
+    "maximumLoadBalancerRuleCount": 250,
+    "useManagedIdentityExtension": false,
+    "useInstanceMetadata": true,
+    "enableVmssFlexNodes": true,
+    "loadBalancerBackendPoolConfigurationType": "nodeIP",
+    "clusterServiceLoadBalancerHealthProbeMode": "shared",
+    "putVMSSVMBatchSize": 10
 }`
 	vmssCloudConfig = `{
     "cloud": "AzurePublicCloud",
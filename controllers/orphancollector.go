@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/orphans"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OrphanCollector periodically deletes the virtual machines of the clusters it reconciles whose AzureMachine
+// no longer exists, along with their network interfaces and disks. When CollectResourceGroups is set, it also
+// deletes the resource groups tagged as owned by clusters which no longer exist. Candidates are only logged
+// when DryRun is set.
+type OrphanCollector struct {
+	Client           client.Client
+	Interval         time.Duration
+	DryRun           bool
+	Timeouts         reconciler.Timeouts
+	WatchNamespace   string
+	WatchFilterValue string
+	// CollectResourceGroups enables the subscription-wide collection of resource groups. It is only safe when
+	// the management cluster knows about every cluster created in the subscriptions of its AzureClusters.
+	CollectResourceGroups bool
+}
+
+// Start runs a collection every interval until the context is done. It implements manager.Runnable.
+func (oc *OrphanCollector) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		_, log, done := tele.StartSpanWithLogger(ctx, "controllers.OrphanCollector.Start")
+		defer done()
+
+		if err := oc.collect(ctx); err != nil {
+			log.Error(err, "failed to collect orphaned Azure resources")
+		}
+	}, oc.Interval)
+	return nil
+}
+
+// collect finds and, unless in dry-run mode, deletes orphaned resources in the subscriptions and
+// resource groups of the AzureClusters matching the namespace and watch filter of the manager.
+func (oc *OrphanCollector) collect(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.OrphanCollector.collect")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, oc.Timeouts.DefaultedLoopTimeout())
+	defer cancel()
+
+	var clusters sets.Set[string]
+	if oc.CollectResourceGroups {
+		var err error
+		if clusters, err = oc.clusterNames(ctx); err != nil {
+			return err
+		}
+	}
+	reconciled, err := oc.reconciledClusters(ctx)
+	if err != nil {
+		return err
+	}
+
+	seen := sets.New[string]()
+	for _, rc := range reconciled {
+		cluster, azureCluster := rc.cluster, rc.azureCluster
+		machines, err := oc.machineNames(ctx, cluster)
+		if err != nil {
+			return err
+		}
+		clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+			Client:       oc.Client,
+			Cluster:      cluster,
+			AzureCluster: azureCluster,
+			Timeouts:     oc.Timeouts,
+		})
+		if err != nil {
+			log.Error(err, "failed to create scope", "cluster", cluster.Name)
+			continue
+		}
+		svc, err := orphans.New(clusterScope)
+		if err != nil {
+			log.Error(err, "failed to create orphans service", "cluster", cluster.Name)
+			continue
+		}
+		candidates, err := svc.Find(ctx, machines)
+		if err != nil {
+			log.Error(err, "failed to find orphaned resources", "cluster", cluster.Name)
+			continue
+		}
+		if oc.CollectResourceGroups {
+			groups, err := svc.FindResourceGroups(ctx, clusters)
+			if err != nil {
+				log.Error(err, "failed to find orphaned resource groups", "cluster", cluster.Name)
+				continue
+			}
+			candidates = append(groups, candidates...)
+		}
+
+		// Resource groups of other clusters are found once per subscription.
+		var orphaned []orphans.Resource
+		for _, candidate := range candidates {
+			if seen.Has(candidate.ID) {
+				continue
+			}
+			seen.Insert(candidate.ID)
+			orphaned = append(orphaned, candidate)
+			log.Info("found orphaned resource", "id", candidate.ID, "reason", candidate.Reason, "dryRun", oc.DryRun)
+		}
+		if oc.DryRun || len(orphaned) == 0 {
+			continue
+		}
+		if err := svc.Delete(ctx, orphaned); err != nil {
+			log.Error(err, "failed to delete orphaned resources", "cluster", cluster.Name)
+		}
+	}
+	return nil
+}
+
+// reconciledCluster is an AzureCluster and its owner Cluster.
+type reconciledCluster struct {
+	cluster      *clusterv1.Cluster
+	azureCluster *infrav1.AzureCluster
+}
+
+// reconciledClusters returns the AzureClusters the manager reconciles, which are the ones in its namespace
+// matching its watch filter, skipping the ones which are deleted, paused or externally managed.
+func (oc *OrphanCollector) reconciledClusters(ctx context.Context) ([]reconciledCluster, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.OrphanCollector.reconciledClusters")
+	defer done()
+
+	azureClusters := &infrav1.AzureClusterList{}
+	if err := oc.Client.List(ctx, azureClusters, client.InNamespace(oc.WatchNamespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list AzureClusters")
+	}
+
+	var reconciled []reconciledCluster
+	for i := range azureClusters.Items {
+		azureCluster := &azureClusters.Items[i]
+		if !azureCluster.DeletionTimestamp.IsZero() || annotations.IsExternallyManaged(azureCluster) {
+			continue
+		}
+		if oc.WatchFilterValue != "" && !labels.HasWatchLabel(azureCluster, oc.WatchFilterValue) {
+			continue
+		}
+		cluster, err := util.GetOwnerCluster(ctx, oc.Client, azureCluster.ObjectMeta)
+		if err != nil || cluster == nil {
+			continue
+		}
+		if annotations.IsPaused(cluster, azureCluster) {
+			log.V(4).Info("Skipping paused cluster", "cluster", cluster.Name)
+			continue
+		}
+		reconciled = append(reconciled, reconciledCluster{cluster: cluster, azureCluster: azureCluster})
+	}
+	return reconciled, nil
+}
+
+// clusterNames returns the names of all Clusters of the management cluster.
+func (oc *OrphanCollector) clusterNames(ctx context.Context) (sets.Set[string], error) {
+	clusters := &clusterv1.ClusterList{}
+	if err := oc.Client.List(ctx, clusters); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+	names := sets.New[string]()
+	for _, cluster := range clusters.Items {
+		names.Insert(cluster.Name)
+	}
+	return names, nil
+}
+
+// machineNames returns the names of the AzureMachines of a Cluster.
+func (oc *OrphanCollector) machineNames(ctx context.Context, cluster *clusterv1.Cluster) (sets.Set[string], error) {
+	machines := &infrav1.AzureMachineList{}
+	if err := oc.Client.List(ctx, machines, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list AzureMachines of cluster %s", cluster.Name)
+	}
+	names := sets.New[string]()
+	for _, machine := range machines.Items {
+		names.Insert(machine.Name)
+	}
+	return names, nil
+}
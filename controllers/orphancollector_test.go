@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOrphanCollectorNames(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+
+	objects := []client.Object{
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-a", Namespace: "default"}},
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-b", Namespace: "other"}},
+		&infrav1.AzureMachine{ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-a",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster-a"},
+		}},
+		&infrav1.AzureMachine{ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-b",
+			Namespace: "other",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster-a"},
+		}},
+		&infrav1.AzureMachine{ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-c",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster-c"},
+		}},
+	}
+	oc := &OrphanCollector{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+	}
+
+	clusters, err := oc.clusterNames(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusters).To(Equal(sets.New("cluster-a", "cluster-b")))
+
+	machines, err := oc.machineNames(context.Background(), objects[0].(*clusterv1.Cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(machines).To(Equal(sets.New("machine-a")))
+}
+
+func TestOrphanCollectorReconciledClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+
+	azureCluster := func(name, namespace string, labels, annotations map[string]string) *infrav1.AzureCluster {
+		return &infrav1.AzureCluster{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       name,
+			}},
+		}}
+	}
+	objects := []client.Object{
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "reconciled", Namespace: "default"}},
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"}},
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "filtered", Namespace: "default"}},
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "paused", Namespace: "default"}, Spec: clusterv1.ClusterSpec{Paused: true}},
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "paused-infra", Namespace: "default"}},
+		azureCluster("reconciled", "default", map[string]string{clusterv1.WatchLabel: "capz"}, nil),
+		azureCluster("other-namespace", "other", map[string]string{clusterv1.WatchLabel: "capz"}, nil),
+		azureCluster("filtered", "default", map[string]string{clusterv1.WatchLabel: "other"}, nil),
+		azureCluster("paused", "default", map[string]string{clusterv1.WatchLabel: "capz"}, nil),
+		azureCluster("paused-infra", "default", map[string]string{clusterv1.WatchLabel: "capz"}, map[string]string{clusterv1.PausedAnnotation: ""}),
+		azureCluster("no-owner", "default", map[string]string{clusterv1.WatchLabel: "capz"}, nil),
+	}
+	oc := &OrphanCollector{
+		Client:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		WatchNamespace:   "default",
+		WatchFilterValue: "capz",
+	}
+
+	reconciled, err := oc.reconciledClusters(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconciled).To(HaveLen(1))
+	g.Expect(reconciled[0].cluster.Name).To(Equal("reconciled"))
+	g.Expect(reconciled[0].azureCluster.Name).To(Equal("reconciled"))
+
+	oc.WatchNamespace, oc.WatchFilterValue = "", ""
+	reconciled, err = oc.reconciledClusters(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconciled).To(HaveLen(3))
+}
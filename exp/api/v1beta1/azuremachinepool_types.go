@@ -55,6 +55,12 @@ type (
 		// +optional
 		Image *infrav1.Image `json:"image,omitempty"`
 
+		// FIPS specifies whether the virtual machines run a FIPS 140-2 validated image. When true and image is omitted,
+		// the image defaults to the FIPS-enabled variant of the Azure Marketplace "capi" offer; when an image is provided,
+		// it must be a FIPS variant. FIPS-enabled default images are only available for Linux.
+		// +optional
+		FIPS bool `json:"fips,omitempty"`
+
 		// OSDisk contains the operating system disk information for a Virtual Machine
 		OSDisk infrav1.OSDisk `json:"osDisk"`
 
@@ -157,6 +163,14 @@ type (
 		// +kubebuilder:default={type: "RollingUpdate", rollingUpdate: {maxSurge: 1, maxUnavailable: 0, deletePolicy: Oldest}}
 		Strategy AzureMachinePoolDeploymentStrategy `json:"strategy,omitempty"`
 
+		// DeletePolicy defines the policy used to select the instances to remove when the AzureMachinePool is scaled
+		// down and none of its AzureMachinePoolMachines carry the delete machine annotation.
+		// Valid values are "Random", "Newest", "Oldest".
+		// When no value is supplied, the DeletePolicy of the rolling update strategy is used.
+		// +optional
+		// +kubebuilder:validation:Enum=Random;Newest;Oldest
+		DeletePolicy AzureMachinePoolDeletePolicyType `json:"deletePolicy,omitempty"`
+
 		// OrchestrationMode specifies the orchestration mode for the Virtual Machine Scale Set
 		// +kubebuilder:default=Uniform
 		OrchestrationMode infrav1.OrchestrationModeType `json:"orchestrationMode,omitempty"`
@@ -169,6 +183,13 @@ type (
 		// ZoneBalane dictates whether to force strictly even Virtual Machine distribution cross x-zones in case there is zone outage.
 		// +optional
 		ZoneBalance *bool `json:"zoneBalance,omitempty"`
+
+		// NodeDrainTimeout is the total amount of time that the controller will spend on draining the node of an
+		// instance before removing the instance from the scale set, when the node wasn't already drained by the owner
+		// Machine. The default value is 0, meaning that the node can be drained without any time limitations.
+		// NOTE: NodeDrainTimeout is different from `kubectl drain --timeout`
+		// +optional
+		NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
 	}
 
 	// AzureMachinePoolDeploymentStrategyType is the type of deployment strategy employed to rollout a new version of
@@ -148,6 +148,10 @@ func (amp *AzureMachinePool) ValidateImage() error {
 		}
 	}
 
+	if errs := infrav1.ValidateFIPSImage(amp.Spec.Template.FIPS, amp.Spec.Template.Image, amp.Spec.Template.OSDisk.OSType, field.NewPath("image")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
 	return nil
 }
 
@@ -44,6 +44,7 @@ import (
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -226,6 +227,12 @@ func (ampr *AzureMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	logger = logger.WithValues("cluster", cluster.Name)
+	ctx = azure.WithAuditRequester(ctx, azure.AuditRequester{
+		Kind:      infrav1.AzureMachinePoolKind,
+		Namespace: azMachinePool.Namespace,
+		Name:      azMachinePool.Name,
+		Cluster:   cluster.Name,
+	})
 
 	clusterScope, err := infracontroller.GetClusterScoper(ctx, logger, ampr.Client, cluster, ampr.Timeouts)
 	if err != nil {
@@ -400,6 +407,14 @@ func (ampr *AzureMachinePoolReconciler) reconcileDelete(ctx context.Context, mac
 
 		log.V(4).Info("deleting AzureMachinePool resource individually")
 		if err := amps.Delete(ctx); err != nil {
+			if azure.IsResourceLocked(err) {
+				// Retrying won't succeed until the lock is removed, so only retry on the next resync or change of the AzureMachinePool.
+				msg := fmt.Sprintf("Azure resources are locked, remove the management lock or set the %s annotation to %q to leave them in Azure: %s", infrav1.LockedResourcePolicyAnnotation, infrav1.LockedResourcePolicyOrphan, err.Error())
+				log.Info("Failed to delete AzureMachinePool: " + msg)
+				ampr.Recorder.Eventf(machinePoolScope.AzureMachinePool, corev1.EventTypeWarning, infrav1.ResourceLockedReason, msg)
+				conditions.MarkFalse(machinePoolScope.AzureMachinePool, infrav1.ScaleSetRunningCondition, infrav1.ResourceLockedReason, clusterv1.ConditionSeverityError, "%s", msg)
+				return reconcile.Result{}, nil
+			}
 			return reconcile.Result{}, errors.Wrapf(err, "error deleting AzureMachinePool %s/%s", machinePoolScope.AzureMachinePool.Namespace, machinePoolScope.Name())
 		}
 	}
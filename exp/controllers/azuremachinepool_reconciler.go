@@ -22,9 +22,11 @@ import (
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/features"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/spotplacement"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -42,6 +44,14 @@ func newAzureMachinePoolService(machinePoolScope *scope.MachinePoolScope) (*azur
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create a NewCache")
 	}
+	featuresSvc, err := features.New(machinePoolScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a features service")
+	}
+	spotPlacementSvc, err := spotplacement.New(machinePoolScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a spotplacement service")
+	}
 	roleAssignmentsSvc, err := roleassignments.New(machinePoolScope)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create a roleassignments service")
@@ -58,6 +68,8 @@ func newAzureMachinePoolService(machinePoolScope *scope.MachinePoolScope) (*azur
 	return &azureMachinePoolService{
 		scope: machinePoolScope,
 		services: []azure.ServiceReconciler{
+			featuresSvc,
+			spotPlacementSvc,
 			scaleSetsSvc,
 			roleAssignmentsSvc,
 			tagsSvc,
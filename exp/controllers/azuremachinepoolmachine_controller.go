@@ -160,6 +160,12 @@ func (ampmr *AzureMachinePoolMachineController) Reconcile(ctx context.Context, r
 	}
 
 	logger = logger.WithValues("cluster", cluster.Name)
+	ctx = azure.WithAuditRequester(ctx, azure.AuditRequester{
+		Kind:      infrav1exp.AzureMachinePoolMachineKind,
+		Namespace: azureMachine.Namespace,
+		Name:      azureMachine.Name,
+		Cluster:   cluster.Name,
+	})
 
 	// Return early if the object or Cluster is paused.
 	if annotations.IsPaused(cluster, azureMachine) {
@@ -340,8 +346,9 @@ func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Cont
 	log.Info("Deleting AzureMachinePoolMachine")
 
 	// deleting a single machine
-	// 1) delete the infrastructure, node drain already done by owner Machine
-	// 2) remove finalizer
+	// 1) cordon and drain the node, unless already done by owner Machine
+	// 2) delete the infrastructure
+	// 3) remove finalizer
 
 	ampms, err := ampmr.reconcilerFactory(machineScope)
 	if err != nil {
@@ -416,6 +423,10 @@ func (r *azureMachinePoolMachineReconciler) Delete(ctx context.Context) error {
 		}
 	}()
 
+	if err := r.Scope.CordonAndDrain(ctx); err != nil {
+		return errors.Wrap(err, "failed to cordon and drain the scalesetVMs")
+	}
+
 	if err := r.scalesetVMsService.Delete(ctx); err != nil {
 		return errors.Wrap(err, "failed to reconcile scalesetVMs")
 	}
@@ -24,6 +24,8 @@ import (
 	"time"
 
 	// +kubebuilder:scaffold:imports
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	asocontainerservicev1api20210501 "github.com/Azure/azure-service-operator/v2/api/containerservice/v1api20210501"
 	asocontainerservicev1api20230201 "github.com/Azure/azure-service-operator/v2/api/containerservice/v1api20230201"
 	asocontainerservicev1api20230202preview "github.com/Azure/azure-service-operator/v2/api/containerservice/v1api20230202preview"
@@ -32,6 +34,7 @@ import (
 	asocontainerservicev1api20231102preview "github.com/Azure/azure-service-operator/v2/api/containerservice/v1api20231102preview"
 	asocontainerservicev1api20240402preview "github.com/Azure/azure-service-operator/v2/api/containerservice/v1api20240402preview"
 	asokubernetesconfigurationv1 "github.com/Azure/azure-service-operator/v2/api/kubernetesconfiguration/v1api20230501"
+	asonetworkv1api20180501 "github.com/Azure/azure-service-operator/v2/api/network/v1api20180501"
 	asonetworkv1api20201101 "github.com/Azure/azure-service-operator/v2/api/network/v1api20201101"
 	asonetworkv1api20220701 "github.com/Azure/azure-service-operator/v2/api/network/v1api20220701"
 	asoresourcesv1 "github.com/Azure/azure-service-operator/v2/api/resources/v1api20200601"
@@ -44,10 +47,14 @@ import (
 	"k8s.io/klog/v2"
 	infrav1alpha "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	infrav1beta2 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	infrav1controllersexp "sigs.k8s.io/cluster-api-provider-azure/exp/controllers"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/auditlog"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
@@ -75,6 +82,7 @@ var (
 func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = infrav1.AddToScheme(scheme)
+	_ = infrav1beta2.AddToScheme(scheme)
 	_ = infrav1exp.AddToScheme(scheme)
 	_ = infrav1alpha.AddToScheme(scheme)
 	_ = clusterv1.AddToScheme(scheme)
@@ -86,6 +94,7 @@ func init() {
 	_ = asocontainerservicev1api20231001.AddToScheme(scheme)
 	_ = asonetworkv1api20220701.AddToScheme(scheme)
 	_ = asonetworkv1api20201101.AddToScheme(scheme)
+	_ = asonetworkv1api20180501.AddToScheme(scheme)
 	_ = asocontainerservicev1api20230202preview.AddToScheme(scheme)
 	_ = asocontainerservicev1api20230315preview.AddToScheme(scheme)
 	_ = asocontainerservicev1api20231102preview.AddToScheme(scheme)
@@ -116,6 +125,22 @@ var (
 	diagnosticsOptions                 = flags.DiagnosticsOptions{}
 	timeouts                           reconciler.Timeouts
 	enableTracing                      bool
+	tracingEndpoint                    string
+	orphanCollectionInterval           time.Duration
+	azureGetCacheTTL                   time.Duration
+	listBasedReconcile                 bool
+	orphanCollectionDryRun             bool
+	orphanCollectionResourceGroups     bool
+	serviceReconcileTimeouts           map[string]string
+	serviceDeleteTimeouts              map[string]string
+	requiredTags                       map[string]string
+	azureProxyURL                      string
+	azureCABundle                      string
+	auditLogStorageContainerURL        string
+	auditLogIngestionEndpoint          string
+	auditLogIngestionRuleID            string
+	auditLogIngestionStream            string
+	auditLogFlushInterval              time.Duration
 )
 
 // InitFlags initializes all command-line flags.
@@ -237,6 +262,24 @@ func InitFlags(fs *pflag.FlagSet) {
 		"The maximum duration each Azure service reconcile can run (e.g. 90m)",
 	)
 
+	fs.StringToStringVar(&serviceReconcileTimeouts,
+		"service-reconcile-timeouts",
+		nil,
+		"Comma-separated list of service=duration pairs overriding --service-reconcile-timeout for specific Azure services (e.g. scalesets=30m,virtualmachine=20m)",
+	)
+
+	fs.StringToStringVar(&serviceDeleteTimeouts,
+		"service-delete-timeouts",
+		nil,
+		"Comma-separated list of service=duration pairs for the maximum duration each delete of specific Azure services can run. Defaults to the service's reconcile timeout (e.g. scalesets=30m)",
+	)
+
+	fs.StringToStringVar(&requiredTags,
+		"required-tags",
+		nil,
+		"Comma-separated list of key=value tags the additional tags of every AzureCluster and AzureManagedControlPlane must contain, so that they are applied to all Azure resources. A tag with an empty value may be set to any value (e.g. costcenter=,environment=prod)",
+	)
+
 	fs.DurationVar(&timeouts.AzureCall,
 		"api-call-timeout",
 		reconciler.DefaultAzureCallTimeout,
@@ -256,6 +299,115 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Enable tracing to the opentelemetry-collector service in the same namespace.",
 	)
 
+	fs.StringVar(
+		&tracingEndpoint,
+		"tracing-endpoint",
+		"opentelemetry-collector:4317",
+		"Address of the OTLP gRPC endpoint traces are exported to when tracing is enabled.",
+	)
+
+	fs.DurationVar(&orphanCollectionInterval,
+		"orphan-collection-interval",
+		0,
+		"The interval at which Azure resources owned by clusters or machines that no longer exist are looked for (e.g. 1h). Orphan collection is disabled if unset.",
+	)
+
+	fs.DurationVar(&azureGetCacheTTL,
+		"azure-get-cache-ttl",
+		0,
+		"How long the responses of Azure GET requests for virtual machines, network interfaces and public IPs are reused across reconciliations (e.g. 10s), to reduce the number of GET requests made to Azure. Caching is disabled if unset.",
+	)
+
+	fs.BoolVar(&listBasedReconcile,
+		"list-based-reconcile",
+		false,
+		"Fetch virtual machines and network interfaces with one LIST call per resource group instead of one GET call per machine, and serve them from the cache enabled by --azure-get-cache-ttl. Recommended for clusters with hundreds of machines.",
+	)
+
+	fs.BoolVar(&orphanCollectionDryRun,
+		"orphan-collection-dry-run",
+		true,
+		"Only log the orphaned Azure resources found by orphan collection instead of deleting them.",
+	)
+
+	fs.BoolVar(&orphanCollectionResourceGroups,
+		"orphan-collection-resource-groups",
+		false,
+		"Also collect the resource groups of the subscriptions of the AzureClusters which are tagged as owned by a cluster that doesn't exist on this management cluster. Only enable it if this management cluster creates every cluster in these subscriptions. Can't be used with --namespace or --watch-filter.",
+	)
+
+	fs.StringVar(&azure.CloudEndpointOverrides.ResourceManager,
+		"azure-resource-manager-endpoint",
+		"",
+		"Base URL of Azure Resource Manager overriding the one of the cloud environment of every cluster (e.g. https://management.example.com), for Azure environments fronted by gateways.",
+	)
+
+	fs.StringVar(&azure.CloudEndpointOverrides.ResourceManagerAudience,
+		"azure-resource-manager-audience",
+		"",
+		"Audience of the tokens sent to Azure Resource Manager overriding the one of the cloud environment of every cluster.",
+	)
+
+	fs.StringVar(&azure.CloudEndpointOverrides.ActiveDirectoryAuthorityHost,
+		"azure-active-directory-authority-host",
+		"",
+		"Base URL of the Azure Active Directory authority overriding the one of the cloud environment of every cluster (e.g. https://login.example.com).",
+	)
+
+	fs.StringVar(&azureProxyURL,
+		"azure-proxy-url",
+		"",
+		"URL of the HTTP(S) proxy the requests to Azure are sent through (e.g. http://proxy.example.com:3128). The proxy set by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables is used if unset.",
+	)
+
+	fs.StringVar(&azureCABundle,
+		"azure-ca-bundle",
+		"",
+		"Path of a PEM file of certificate authorities trusted for the requests to Azure in addition to the system ones, e.g. those of a TLS-intercepting proxy.",
+	)
+
+	fs.StringVar(&azure.UserAgentSuffix,
+		"user-agent-suffix",
+		"",
+		"String appended to the user agent of the requests to Azure, e.g. a partner attribution identifier like pid-00000000-0000-0000-0000-000000000000.",
+	)
+
+	fs.BoolVar(&azure.TelemetryDisabled,
+		"disable-telemetry",
+		false,
+		"Remove the identifiers of CAPZ and of the Azure SDK from the user agent of the requests to Azure. The --user-agent-suffix is still sent.",
+	)
+
+	fs.StringVar(&auditLogStorageContainerURL,
+		"audit-log-storage-container-url",
+		"",
+		"URL of the storage container the audit records of the create, update and delete requests sent to Azure Resource Manager are uploaded to as blobs (e.g. https://account.blob.core.windows.net/capz-audit). The manager's identity needs the Storage Blob Data Contributor role on the container.",
+	)
+
+	fs.StringVar(&auditLogIngestionEndpoint,
+		"audit-log-ingestion-endpoint",
+		"",
+		"Logs ingestion endpoint of the Azure Monitor data collection endpoint the audit records are sent to, to store them in a Log Analytics workspace. Requires --audit-log-ingestion-rule-id and --audit-log-ingestion-stream.",
+	)
+
+	fs.StringVar(&auditLogIngestionRuleID,
+		"audit-log-ingestion-rule-id",
+		"",
+		"Immutable ID of the data collection rule the audit records are sent to. The manager's identity needs the Monitoring Metrics Publisher role on the rule.",
+	)
+
+	fs.StringVar(&auditLogIngestionStream,
+		"audit-log-ingestion-stream",
+		"",
+		"Name of the stream of the data collection rule the audit records are sent to (e.g. Custom-CAPZAudit_CL).",
+	)
+
+	fs.DurationVar(&auditLogFlushInterval,
+		"audit-log-flush-interval",
+		auditlog.DefaultFlushInterval,
+		"The interval at which audit records are uploaded to the storage container or the logs ingestion endpoint.",
+	)
+
 	fs.StringVar(&azureBootrapConfigGVK,
 		"bootstrap-config-gvk",
 		"",
@@ -277,6 +429,45 @@ func main() {
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
 
+	var err error
+	if timeouts.AzureServiceReconcileOverrides, err = parseServiceTimeouts(serviceReconcileTimeouts); err != nil {
+		setupLog.Error(err, "invalid --service-reconcile-timeouts")
+		os.Exit(1)
+	}
+	if timeouts.AzureServiceDeleteOverrides, err = parseServiceTimeouts(serviceDeleteTimeouts); err != nil {
+		setupLog.Error(err, "invalid --service-delete-timeouts")
+		os.Exit(1)
+	}
+	infrav1.RequiredTags = requiredTags
+	if listBasedReconcile && azureGetCacheTTL <= 0 {
+		setupLog.Error(fmt.Errorf("--list-based-reconcile requires --azure-get-cache-ttl"), "invalid flags")
+		os.Exit(1)
+	}
+	if err := azure.CloudEndpointOverrides.Validate(); err != nil {
+		setupLog.Error(err, "invalid Azure endpoint flags")
+		os.Exit(1)
+	}
+	if err := azure.ValidateUserAgentSuffix(azure.UserAgentSuffix); err != nil {
+		setupLog.Error(err, "invalid --user-agent-suffix")
+		os.Exit(1)
+	}
+	if azureProxyURL != "" || azureCABundle != "" {
+		if azure.HTTPTransport, err = azure.NewHTTPTransport(azureProxyURL, azureCABundle); err != nil {
+			setupLog.Error(err, "invalid --azure-proxy-url or --azure-ca-bundle")
+			os.Exit(1)
+		}
+	}
+	if auditLogIngestionEndpoint != "" && (auditLogIngestionRuleID == "" || auditLogIngestionStream == "") {
+		setupLog.Error(fmt.Errorf("--audit-log-ingestion-endpoint requires --audit-log-ingestion-rule-id and --audit-log-ingestion-stream"), "invalid flags")
+		os.Exit(1)
+	}
+	if orphanCollectionResourceGroups && (watchNamespace != "" || watchFilterValue != "") {
+		setupLog.Error(fmt.Errorf("--orphan-collection-resource-groups can't be used with --namespace or --watch-filter"), "invalid flags")
+		os.Exit(1)
+	}
+	async.GetCacheTTL = azureGetCacheTTL
+	async.ListBasedGet = listBasedReconcile
+
 	// klog.Background will automatically use the right logger.
 	ctrl.SetLogger(klog.Background())
 
@@ -341,7 +532,7 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	if enableTracing {
-		if err := ot.RegisterTracing(ctx, setupLog); err != nil {
+		if err := ot.RegisterTracing(ctx, tracingEndpoint, setupLog); err != nil {
 			setupLog.Error(err, "unable to initialize tracing")
 			os.Exit(1)
 		}
@@ -364,6 +555,22 @@ func main() {
 	}
 }
 
+// parseServiceTimeouts parses the durations of a service=duration flag.
+func parseServiceTimeouts(flagValue map[string]string) (map[string]time.Duration, error) {
+	serviceTimeouts := make(map[string]time.Duration, len(flagValue))
+	for service, value := range flagValue {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for service %s: %w", service, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout for service %s: must be positive", service)
+		}
+		serviceTimeouts[service] = timeout
+	}
+	return serviceTimeouts, nil
+}
+
 func registerControllers(ctx context.Context, mgr manager.Manager) {
 	machineCache, err := coalescing.NewRequestCache(debouncingTimer)
 	if err != nil {
@@ -412,6 +619,26 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 		os.Exit(1)
 	}
 
+	if err := (&controllers.AzureMachineTemplateReconciler{
+		Client:           mgr.GetClient(),
+		Recorder:         mgr.GetEventRecorderFor("azuremachinetemplate-reconciler"),
+		Timeouts:         timeouts,
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AzureMachineTemplate")
+		os.Exit(1)
+	}
+
+	if err := (&controllers.AzureMachineRemediationReconciler{
+		Client:           mgr.GetClient(),
+		Recorder:         mgr.GetEventRecorderFor("azuremachineremediation-reconciler"),
+		Timeouts:         timeouts,
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AzureMachineRemediation")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.ASOSecretReconciler{
 		Client:           mgr.GetClient(),
 		Recorder:         mgr.GetEventRecorderFor("asosecret-reconciler"),
@@ -576,6 +803,63 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 			os.Exit(1)
 		}
 	}
+
+	if orphanCollectionInterval > 0 {
+		if err := mgr.Add(&controllers.OrphanCollector{
+			Client:                mgr.GetClient(),
+			Interval:              orphanCollectionInterval,
+			DryRun:                orphanCollectionDryRun,
+			Timeouts:              timeouts,
+			WatchNamespace:        watchNamespace,
+			WatchFilterValue:      watchFilterValue,
+			CollectResourceGroups: orphanCollectionResourceGroups,
+		}); err != nil {
+			setupLog.Error(err, "unable to create runnable", "runnable", "OrphanCollector")
+			os.Exit(1)
+		}
+	}
+
+	registerAuditLogShippers(mgr)
+}
+
+// registerAuditLogShippers adds the runnables uploading the audit records of Azure Resource Manager requests to the
+// destinations set by the --audit-log-* flags. Audit records are only logged if none is set.
+func registerAuditLogShippers(mgr manager.Manager) {
+	if auditLogStorageContainerURL == "" && auditLogIngestionEndpoint == "" {
+		return
+	}
+	cred, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+		ClientOptions: azcore.ClientOptions{Transport: azure.HTTPTransport},
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create the credential of the audit log shippers")
+		os.Exit(1)
+	}
+	log := ctrl.Log.WithName("auditlog")
+	var shippers []*auditlog.Shipper
+	if auditLogStorageContainerURL != "" {
+		shipper, err := auditlog.NewStorageShipper(auditLogStorageContainerURL, auditLogFlushInterval, cred, log)
+		if err != nil {
+			setupLog.Error(err, "invalid --audit-log-storage-container-url")
+			os.Exit(1)
+		}
+		shippers = append(shippers, shipper)
+	}
+	if auditLogIngestionEndpoint != "" {
+		shipper, err := auditlog.NewLogsIngestionShipper(auditLogIngestionEndpoint, auditLogIngestionRuleID, auditLogIngestionStream, auditLogFlushInterval, cred, log)
+		if err != nil {
+			setupLog.Error(err, "invalid --audit-log-ingestion-* flags")
+			os.Exit(1)
+		}
+		shippers = append(shippers, shipper)
+	}
+	for _, shipper := range shippers {
+		if err := mgr.Add(shipper); err != nil {
+			setupLog.Error(err, "unable to create runnable", "runnable", "AuditLogShipper")
+			os.Exit(1)
+		}
+		azure.AuditSinks = append(azure.AuditSinks, shipper)
+	}
 }
 
 func registerWebhooks(mgr manager.Manager) {
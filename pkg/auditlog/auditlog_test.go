@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+type fakeUploader struct {
+	mu      sync.Mutex
+	err     error
+	batches [][]azure.AuditRecord
+}
+
+func (f *fakeUploader) upload(_ context.Context, records []azure.AuditRecord) ([]azure.AuditRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return records, f.err
+	}
+	f.batches = append(f.batches, records)
+	return nil, nil
+}
+
+func TestShipperFlush(t *testing.T) {
+	g := NewWithT(t)
+
+	u := &fakeUploader{err: errors.New("unavailable")}
+	s := newShipper(u, 0, logr.Discard())
+	g.Expect(s.interval).To(Equal(DefaultFlushInterval))
+
+	s.Audit(azure.AuditRecord{ResourceID: "first"})
+	s.flush(context.Background())
+	g.Expect(u.batches).To(BeEmpty())
+	g.Expect(s.records).To(HaveLen(1))
+
+	u.err = nil
+	s.Audit(azure.AuditRecord{ResourceID: "second"})
+	s.flush(context.Background())
+	g.Expect(s.records).To(BeEmpty())
+	g.Expect(u.batches).To(HaveLen(1))
+	g.Expect(u.batches[0]).To(HaveLen(2))
+	g.Expect(u.batches[0][0].ResourceID).To(Equal("first"))
+	g.Expect(u.batches[0][1].ResourceID).To(Equal("second"))
+
+	s.flush(context.Background())
+	g.Expect(u.batches).To(HaveLen(1))
+}
+
+func TestShipperDropsOldestRecords(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newShipper(&fakeUploader{}, DefaultFlushInterval, logr.Discard())
+	for range maxBufferedRecords + 5 {
+		s.Audit(azure.AuditRecord{})
+	}
+	s.Audit(azure.AuditRecord{ResourceID: "last"})
+	g.Expect(s.records).To(HaveLen(maxBufferedRecords))
+	g.Expect(s.dropped).To(Equal(6))
+	g.Expect(s.records[maxBufferedRecords-1].ResourceID).To(Equal("last"))
+}
+
+func TestShipperStartFlushesOnStop(t *testing.T) {
+	g := NewWithT(t)
+
+	u := &fakeUploader{}
+	s := newShipper(u, DefaultFlushInterval, logr.Discard())
+	s.Audit(azure.AuditRecord{ResourceID: "pending"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g.Expect(s.Start(ctx)).To(Succeed())
+	g.Expect(u.batches).To(HaveLen(1))
+}
+
+func TestBlobUploader(t *testing.T) {
+	g := NewWithT(t)
+
+	var req *http.Request
+	var lines []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	u := &blobUploader{
+		containerURL: server.URL + "/audit/",
+		pipeline:     newPipeline(&azfake.TokenCredential{}, storageScope, server.Client()),
+	}
+	records := []azure.AuditRecord{{ResourceID: "one"}, {ResourceID: "two"}}
+	failed, err := u.upload(context.Background(), records)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(failed).To(BeEmpty())
+
+	g.Expect(req.Method).To(Equal(http.MethodPut))
+	g.Expect(req.URL.Path).To(MatchRegexp(`^/audit/\d{4}/\d{2}/\d{2}/\d{6}\.\d{3}-[0-9a-f]{8}\.json$`))
+	g.Expect(req.Header.Get("x-ms-blob-type")).To(Equal("BlockBlob"))
+	g.Expect(req.Header.Get("x-ms-version")).To(Equal(storageAPIVersion))
+	g.Expect(req.Header.Get("Authorization")).To(HavePrefix("Bearer "))
+	g.Expect(lines).To(HaveLen(2))
+	var record azure.AuditRecord
+	g.Expect(json.Unmarshal([]byte(lines[1]), &record)).To(Succeed())
+	g.Expect(record.ResourceID).To(Equal("two"))
+}
+
+func TestIngestionUploader(t *testing.T) {
+	g := NewWithT(t)
+
+	var req *http.Request
+	var body []byte
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	u := &ingestionUploader{
+		streamURL: ingestionStreamURL(server.URL+"/", "dcr-123", "Custom-CAPZAudit_CL"),
+		pipeline:  newPipeline(&azfake.TokenCredential{}, "https://monitor.azure.com//.default", server.Client()),
+	}
+	failed, err := u.upload(context.Background(), []azure.AuditRecord{{ResourceID: "one"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(failed).To(BeEmpty())
+
+	g.Expect(req.Method).To(Equal(http.MethodPost))
+	g.Expect(req.URL.Path).To(Equal("/dataCollectionRules/dcr-123/streams/Custom-CAPZAudit_CL"))
+	g.Expect(req.URL.Query().Get("api-version")).To(Equal(logsIngestionAPIVersion))
+	var records []azure.AuditRecord
+	g.Expect(json.Unmarshal(body, &records)).To(Succeed())
+	g.Expect(records).To(HaveLen(1))
+	g.Expect(records[0].ResourceID).To(Equal("one"))
+}
+
+func TestUploaderErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	pipeline := newPipeline(&azfake.TokenCredential{}, storageScope, server.Client())
+	records := []azure.AuditRecord{{ResourceID: "one"}}
+	failed, err := (&blobUploader{containerURL: server.URL, pipeline: pipeline}).upload(context.Background(), records)
+	g.Expect(err).To(MatchError(ContainSubstring("403")))
+	g.Expect(failed).To(Equal(records))
+	failed, err = (&ingestionUploader{streamURL: server.URL, pipeline: pipeline}).upload(context.Background(), records)
+	g.Expect(err).To(MatchError(ContainSubstring("403")))
+	g.Expect(failed).To(Equal(records))
+}
+
+func TestIngestionUploaderBatches(t *testing.T) {
+	g := NewWithT(t)
+
+	var mu sync.Mutex
+	var sizes []int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		sizes = append(sizes, len(body))
+		// The second request fails.
+		if len(sizes) == 2 {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	u := &ingestionUploader{
+		streamURL: server.URL,
+		pipeline:  newPipeline(&azfake.TokenCredential{}, "https://monitor.azure.com//.default", server.Client()),
+	}
+	var records []azure.AuditRecord
+	for i := range 25 {
+		records = append(records, azure.AuditRecord{ResourceID: strconv.Itoa(i), Error: strings.Repeat("x", 100000)})
+	}
+	records = append(records, azure.AuditRecord{ResourceID: "huge", Error: strings.Repeat("x", maxIngestionRequestBytes)})
+
+	failed, err := u.upload(context.Background(), records)
+	g.Expect(err).To(MatchError(ContainSubstring("413")))
+	g.Expect(err).To(MatchError(ContainSubstring("dropped audit record of resource huge")))
+	g.Expect(sizes).To(HaveLen(3))
+	for _, size := range sizes {
+		g.Expect(size).To(BeNumerically("<=", maxIngestionRequestBytes))
+	}
+	g.Expect(failed).To(HaveLen(9))
+	g.Expect(failed[0].ResourceID).To(Equal("9"))
+}
+
+func TestNewShippers(t *testing.T) {
+	tests := []struct {
+		name    string
+		create  func() (*Shipper, error)
+		wantErr string
+	}{
+		{
+			name: "valid storage container",
+			create: func() (*Shipper, error) {
+				return NewStorageShipper("https://account.blob.core.windows.net/audit", 0, &azfake.TokenCredential{}, logr.Discard())
+			},
+		},
+		{
+			name: "storage container without https",
+			create: func() (*Shipper, error) {
+				return NewStorageShipper("http://account.blob.core.windows.net/audit", 0, &azfake.TokenCredential{}, logr.Discard())
+			},
+			wantErr: "not an absolute https URL",
+		},
+		{
+			name: "valid logs ingestion endpoint",
+			create: func() (*Shipper, error) {
+				return NewLogsIngestionShipper("https://dce.eastus-1.ingest.monitor.azure.com", "dcr-123", "Custom-CAPZAudit_CL", 0, &azfake.TokenCredential{}, logr.Discard())
+			},
+		},
+		{
+			name: "logs ingestion without stream",
+			create: func() (*Shipper, error) {
+				return NewLogsIngestionShipper("https://dce.eastus-1.ingest.monitor.azure.com", "dcr-123", "", 0, &azfake.TokenCredential{}, logr.Discard())
+			},
+			wantErr: "stream name",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s, err := tc.create()
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(s).NotTo(BeNil())
+		})
+	}
+}
+
+func TestIngestionScope(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{endpoint: "https://dce.eastus-1.ingest.monitor.azure.com", want: "https://monitor.azure.com//.default"},
+		{endpoint: "https://dce.usgovvirginia-1.ingest.monitor.azure.us", want: "https://monitor.azure.us//.default"},
+		{endpoint: "https://dce.chinaeast2-1.ingest.monitor.azure.cn", want: "https://monitor.azure.cn//.default"},
+	}
+	for _, tc := range tests {
+		t.Run(strings.TrimPrefix(tc.endpoint, "https://"), func(t *testing.T) {
+			g := NewWithT(t)
+			scope, err := ingestionScope(tc.endpoint)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(scope).To(Equal(tc.want))
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+const (
+	// DefaultFlushInterval is the default interval at which buffered audit records are uploaded.
+	DefaultFlushInterval = 30 * time.Second
+
+	// maxBufferedRecords is the number of audit records buffered while uploads fail, beyond which the oldest records
+	// are dropped.
+	maxBufferedRecords = 10000
+
+	// flushTimeout bounds the upload of the records buffered when the manager stops.
+	flushTimeout = 10 * time.Second
+)
+
+// uploader uploads a batch of audit records to a destination outside of the cluster. It returns the records which
+// could not be uploaded along with the error.
+type uploader interface {
+	upload(ctx context.Context, records []azure.AuditRecord) ([]azure.AuditRecord, error)
+}
+
+// Shipper buffers the audit records of mutating Azure Resource Manager requests and periodically uploads them.
+// It implements the azure.AuditSink and the manager.Runnable interfaces.
+type Shipper struct {
+	uploader uploader
+	interval time.Duration
+	log      logr.Logger
+
+	mu      sync.Mutex
+	records []azure.AuditRecord
+	dropped int
+}
+
+// NewStorageShipper creates a Shipper uploading the audit records as blobs to the storage container at containerURL,
+// with tokens of the credential.
+func NewStorageShipper(containerURL string, interval time.Duration, cred azcore.TokenCredential, log logr.Logger) (*Shipper, error) {
+	if err := validateHTTPSURL(containerURL); err != nil {
+		return nil, errors.Wrap(err, "invalid storage container URL")
+	}
+	u := &blobUploader{
+		containerURL: containerURL,
+		pipeline:     newPipeline(cred, storageScope, azure.HTTPTransport),
+	}
+	return newShipper(u, interval, log), nil
+}
+
+// NewLogsIngestionShipper creates a Shipper sending the audit records to the stream of a data collection rule through
+// the logs ingestion endpoint of Azure Monitor, with tokens of the credential.
+func NewLogsIngestionShipper(endpoint, ruleID, stream string, interval time.Duration, cred azcore.TokenCredential, log logr.Logger) (*Shipper, error) {
+	if err := validateHTTPSURL(endpoint); err != nil {
+		return nil, errors.Wrap(err, "invalid logs ingestion endpoint")
+	}
+	if ruleID == "" || stream == "" {
+		return nil, errors.New("the immutable ID of a data collection rule and a stream name are required")
+	}
+	scope, err := ingestionScope(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u := &ingestionUploader{
+		streamURL: ingestionStreamURL(endpoint, ruleID, stream),
+		pipeline:  newPipeline(cred, scope, azure.HTTPTransport),
+	}
+	return newShipper(u, interval, log), nil
+}
+
+// validateHTTPSURL returns an error if rawURL is not an absolute HTTPS URL, as tokens are only sent over TLS.
+func validateHTTPSURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.Errorf("%q is not an absolute https URL", rawURL)
+	}
+	return nil
+}
+
+// newShipper creates a Shipper uploading buffered records with the uploader every interval.
+func newShipper(u uploader, interval time.Duration, log logr.Logger) *Shipper {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	return &Shipper{uploader: u, interval: interval, log: log}
+}
+
+// Audit buffers an audit record until the next upload.
+func (s *Shipper) Audit(record azure.AuditRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buffer(record)
+}
+
+// buffer appends records to the buffer, dropping the oldest ones beyond maxBufferedRecords. It must be called with
+// the lock held.
+func (s *Shipper) buffer(records ...azure.AuditRecord) {
+	s.records = append(s.records, records...)
+	if excess := len(s.records) - maxBufferedRecords; excess > 0 {
+		s.records = s.records[excess:]
+		s.dropped += excess
+	}
+}
+
+// Start uploads the buffered records every interval until the context is done, then uploads the remaining ones.
+func (s *Shipper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.flush(ctx)
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			defer cancel()
+			s.flush(flushCtx)
+			return nil
+		}
+	}
+}
+
+// flush uploads the buffered records. Records that fail to upload are buffered again for the next attempt.
+func (s *Shipper) flush(ctx context.Context) {
+	s.mu.Lock()
+	records, dropped := s.records, s.dropped
+	s.records, s.dropped = nil, 0
+	s.mu.Unlock()
+
+	if dropped > 0 {
+		s.log.Info("dropped audit records that could not be uploaded", "count", dropped)
+	}
+	if len(records) == 0 {
+		return
+	}
+	failed, err := s.uploader.upload(ctx, records)
+	if err != nil {
+		s.log.Error(err, "failed to upload audit records, will retry", "count", len(failed))
+	}
+	if len(failed) > 0 {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		pending := s.records
+		s.records = nil
+		s.buffer(append(failed, pending...)...)
+	}
+}
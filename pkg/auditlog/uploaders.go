@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/version"
+)
+
+const (
+	// storageAPIVersion is the version of the Blob service REST API used to upload audit records, which are sent as
+	// plain requests as the Azure SDK for Go used by CAPZ has no data plane client for Azure Storage.
+	storageAPIVersion = "2021-08-06"
+
+	// storageScope is the scope of the tokens used to upload blobs.
+	storageScope = "https://storage.azure.com/.default"
+
+	// logsIngestionAPIVersion is the version of the Logs Ingestion API of Azure Monitor.
+	logsIngestionAPIVersion = "2023-01-01"
+
+	// maxIngestionRequestBytes is the maximum size of the body of a request to the Logs Ingestion API, which rejects
+	// requests larger than 1 MB.
+	maxIngestionRequestBytes = 1000000
+)
+
+// blobUploader uploads each batch of audit records as a blob of newline-delimited JSON to a container of a storage
+// account.
+type blobUploader struct {
+	containerURL string
+	pipeline     runtime.Pipeline
+}
+
+// upload uploads the records to a new blob named after the current date and time. The records are only uploaded
+// together, so all of them are returned on failure.
+func (b *blobUploader) upload(ctx context.Context, records []azure.AuditRecord) ([]azure.AuditRecord, error) {
+	if err := b.uploadBlob(ctx, records); err != nil {
+		return records, err
+	}
+	return nil, nil
+}
+
+// uploadBlob uploads the records to a new blob named after the current date and time.
+func (b *blobUploader) uploadBlob(ctx context.Context, records []azure.AuditRecord) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return errors.Wrap(err, "failed to encode audit record")
+		}
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return errors.Wrap(err, "failed to generate audit blob name")
+	}
+	now := time.Now().UTC()
+	blobURL := strings.TrimSuffix(b.containerURL, "/") + "/" + now.Format("2006/01/02/150405.000") + "-" + hex.EncodeToString(suffix) + ".json"
+
+	req, err := runtime.NewRequest(ctx, http.MethodPut, blobURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to create audit blob request")
+	}
+	req.Raw().Header.Set("x-ms-version", storageAPIVersion)
+	req.Raw().Header.Set("x-ms-blob-type", "BlockBlob")
+	if err := req.SetBody(streaming.NopCloser(bytes.NewReader(body.Bytes())), "application/x-ndjson"); err != nil {
+		return errors.Wrap(err, "failed to set audit blob request body")
+	}
+	resp, err := b.pipeline.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to upload audit blob")
+	}
+	defer resp.Body.Close()
+	if !runtime.HasStatusCode(resp, http.StatusCreated) {
+		return runtime.NewResponseError(resp)
+	}
+	return nil
+}
+
+// ingestionUploader sends each batch of audit records to a stream of a data collection rule of Azure Monitor, which
+// routes them to a Log Analytics workspace.
+type ingestionUploader struct {
+	streamURL string
+	pipeline  runtime.Pipeline
+}
+
+// upload sends the records to the stream of the data collection rule, in as many requests as needed to keep the body
+// of each of them below the size limit of the Logs Ingestion API. It returns the records of the requests which
+// failed. Records which are too large to be sent on their own are dropped.
+func (i *ingestionUploader) upload(ctx context.Context, records []azure.AuditRecord) ([]azure.AuditRecord, error) {
+	var failed []azure.AuditRecord
+	var errs []error
+	var batch []azure.AuditRecord
+	// The body is a JSON array, whose brackets and separators take one byte per record and one more.
+	size := 1
+	send := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := i.send(ctx, batch); err != nil {
+			failed = append(failed, batch...)
+			errs = append(errs, err)
+		}
+		batch, size = nil, 1
+	}
+	for _, record := range records {
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to encode audit record"))
+			continue
+		}
+		if len(encoded)+2 > maxIngestionRequestBytes {
+			errs = append(errs, errors.Errorf("dropped audit record of resource %s larger than %d bytes", record.ResourceID, maxIngestionRequestBytes))
+			continue
+		}
+		if size+len(encoded)+1 > maxIngestionRequestBytes {
+			send()
+		}
+		batch = append(batch, record)
+		size += len(encoded) + 1
+	}
+	send()
+	return failed, kerrors.NewAggregate(errs)
+}
+
+// send sends a batch of records to the stream of the data collection rule in a single request.
+func (i *ingestionUploader) send(ctx context.Context, records []azure.AuditRecord) error {
+	req, err := runtime.NewRequest(ctx, http.MethodPost, i.streamURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to create logs ingestion request")
+	}
+	if err := runtime.MarshalAsJSON(req, records); err != nil {
+		return errors.Wrap(err, "failed to encode audit records")
+	}
+	resp, err := i.pipeline.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send audit records to the logs ingestion endpoint")
+	}
+	defer resp.Body.Close()
+	if !runtime.HasStatusCode(resp, http.StatusNoContent) {
+		return runtime.NewResponseError(resp)
+	}
+	return nil
+}
+
+// ingestionStreamURL returns the URL audit records are sent to for the stream of a data collection rule.
+func ingestionStreamURL(endpoint, ruleID, stream string) string {
+	query := url.Values{"api-version": []string{logsIngestionAPIVersion}}
+	return strings.TrimSuffix(endpoint, "/") + "/dataCollectionRules/" + url.PathEscape(ruleID) + "/streams/" + url.PathEscape(stream) + "?" + query.Encode()
+}
+
+// ingestionScope returns the scope of the tokens used to send logs to the endpoint, which depends on its cloud.
+func ingestionScope(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid logs ingestion endpoint")
+	}
+	switch host := strings.ToLower(u.Hostname()); {
+	case strings.HasSuffix(host, ".azure.us"):
+		return "https://monitor.azure.us//.default", nil
+	case strings.HasSuffix(host, ".azure.cn"):
+		return "https://monitor.azure.cn//.default", nil
+	default:
+		return "https://monitor.azure.com//.default", nil
+	}
+}
+
+// newPipeline creates a pipeline authorizing requests with tokens of the credential for the scope. It doesn't use the
+// options of the Azure Resource Manager clients, so that uploads of audit records are not audited themselves.
+func newPipeline(cred azcore.TokenCredential, scope string, transport policy.Transporter) runtime.Pipeline {
+	tokenPolicy := runtime.NewBearerTokenPolicy(cred, []string{scope}, nil)
+	return runtime.NewPipeline("auditlog", version.Get().String(), runtime.PipelineOptions{PerRetry: []policy.Policy{tokenPolicy}}, &policy.ClientOptions{Transport: transport})
+}
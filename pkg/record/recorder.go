@@ -34,7 +34,7 @@ var (
 
 func init() {
 	defaultRecorder = new(record.FakeRecorder)
-	eng = cases.Title(language.English)
+	eng = cases.Title(language.English, cases.NoLower)
 }
 
 // InitFromRecorder initializes the global default recorder. It can only be called once.
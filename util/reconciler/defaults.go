@@ -45,6 +45,10 @@ type Timeouts struct {
 	AzureCall time.Duration
 	// Requeue is the value for the reconcile retry.
 	Requeue time.Duration
+	// AzureServiceReconcileOverrides are the timeouts for the reconcile of specific Azure services, by service name.
+	AzureServiceReconcileOverrides map[string]time.Duration
+	// AzureServiceDeleteOverrides are the timeouts for the delete of specific Azure services, by service name.
+	AzureServiceDeleteOverrides map[string]time.Duration
 }
 
 // DefaultedAzureCallTimeout will default the timeout if it is zero-valued.
@@ -65,6 +69,25 @@ func (t Timeouts) DefaultedAzureServiceReconcileTimeout() time.Duration {
 	return t.AzureServiceReconcile
 }
 
+// AzureServiceReconcileTimeout returns the reconcile timeout of the named Azure service, defaulting to the
+// timeout of all Azure services.
+func (t Timeouts) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	if timeout, ok := t.AzureServiceReconcileOverrides[serviceName]; ok && timeout > 0 {
+		return timeout
+	}
+
+	return t.DefaultedAzureServiceReconcileTimeout()
+}
+
+// AzureServiceDeleteTimeout returns the delete timeout of the named Azure service, defaulting to its reconcile timeout.
+func (t Timeouts) AzureServiceDeleteTimeout(serviceName string) time.Duration {
+	if timeout, ok := t.AzureServiceDeleteOverrides[serviceName]; ok && timeout > 0 {
+		return timeout
+	}
+
+	return t.AzureServiceReconcileTimeout(serviceName)
+}
+
 // DefaultedReconcilerRequeue will default the timeout if it is zero-valued.
 func (t Timeouts) DefaultedReconcilerRequeue() time.Duration {
 	if t.Requeue <= 0 {
@@ -167,3 +167,82 @@ func TestDefaultedAzureServiceReconcileTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureServiceReconcileTimeout(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Overrides map[string]time.Duration
+		Expected  time.Duration
+	}{
+		{
+			Name:     "WithoutOverrides",
+			Expected: 30 * time.Minute,
+		},
+		{
+			Name:      "WithOverride",
+			Overrides: map[string]time.Duration{"scalesets": 2 * time.Hour},
+			Expected:  2 * time.Hour,
+		},
+		{
+			Name:      "WithOverrideForAnotherService",
+			Overrides: map[string]time.Duration{"virtualmachine": 2 * time.Hour},
+			Expected:  30 * time.Minute,
+		},
+		{
+			Name:      "WithNegativeOverride",
+			Overrides: map[string]time.Duration{"scalesets": time.Duration(-2)},
+			Expected:  30 * time.Minute,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			timeouts := reconciler.Timeouts{
+				AzureServiceReconcile:          30 * time.Minute,
+				AzureServiceReconcileOverrides: c.Overrides,
+			}
+			g.Expect(timeouts.AzureServiceReconcileTimeout("scalesets")).To(gomega.Equal(c.Expected))
+		})
+	}
+}
+
+func TestAzureServiceDeleteTimeout(t *testing.T) {
+	cases := []struct {
+		Name               string
+		ReconcileOverrides map[string]time.Duration
+		DeleteOverrides    map[string]time.Duration
+		Expected           time.Duration
+	}{
+		{
+			Name:     "WithoutOverrides",
+			Expected: reconciler.DefaultAzureServiceReconcileTimeout,
+		},
+		{
+			Name:               "WithReconcileOverride",
+			ReconcileOverrides: map[string]time.Duration{"scalesets": 2 * time.Hour},
+			Expected:           2 * time.Hour,
+		},
+		{
+			Name:               "WithDeleteOverride",
+			ReconcileOverrides: map[string]time.Duration{"scalesets": 2 * time.Hour},
+			DeleteOverrides:    map[string]time.Duration{"scalesets": 3 * time.Hour},
+			Expected:           3 * time.Hour,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			timeouts := reconciler.Timeouts{
+				AzureServiceReconcileOverrides: c.ReconcileOverrides,
+				AzureServiceDeleteOverrides:    c.DeleteOverrides,
+			}
+			g.Expect(timeouts.AzureServiceDeleteTimeout("scalesets")).To(gomega.Equal(c.Expected))
+		})
+	}
+}